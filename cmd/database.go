@@ -7,6 +7,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
 )
 
 // Database represents the database command
@@ -69,6 +70,30 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] two-factor recovery code table maintained successfully")
 
+	err = datastore.Container.UserStore.SyncStructs(new(models.Ledger))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] ledger table maintained successfully")
+
+	err = datastore.Container.UserStore.SyncStructs(new(models.LedgerMember))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] ledger member table maintained successfully")
+
+	err = datastore.Container.UserStore.SyncStructs(new(models.ExchangeRateSnapshot))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] exchange rate snapshot table maintained successfully")
+
 	err = datastore.Container.TokenStore.SyncStructs(new(models.TokenRecord))
 
 	if err != nil {
@@ -157,6 +182,38 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction template table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionTemplateOccurrence))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction template occurrence table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionRule))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction rule table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionRevision))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction revision table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Payee))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] payee table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionPictureInfo))
 
 	if err != nil {
@@ -165,6 +222,14 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction picture table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.TransactionSplit))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] transaction split table maintained successfully")
+
 	err = datastore.Container.UserDataStore.SyncStructs(new(models.UserCustomExchangeRate))
 
 	if err != nil {
@@ -197,5 +262,99 @@ func updateAllDatabaseTablesStructure(c *core.CliContext) error {
 
 	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] insights explorer table maintained successfully")
 
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.SavedSearch))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] saved search table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Budget))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] budget table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.BudgetAmountChange))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] budget amount change table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Security))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] security table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.SecurityPrice))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] security price table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.InvestmentTransaction))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] investment transaction table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.Loan))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] loan table maintained successfully")
+
+	err = datastore.Container.UserDataStore.SyncStructs(new(models.ReconciliationSession))
+
+	if err != nil {
+		return err
+	}
+
+	log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] reconciliation session table maintained successfully")
+
+	migratedAccountCount, err := services.Accounts.MigrateAllAccountsAmountDecimalPlaces(c)
+
+	if err != nil {
+		return err
+	}
+
+	if migratedAccountCount > 0 {
+		log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] amounts of %d accounts have been migrated to the decimal places of their currencies", migratedAccountCount)
+	}
+
+	migratedBudgetCount, err := services.Budgets.MigrateAllBudgetsAmountDecimalPlaces(c)
+
+	if err != nil {
+		return err
+	}
+
+	if migratedBudgetCount > 0 {
+		log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] amounts of %d budgets have been migrated to the decimal places of their currencies", migratedBudgetCount)
+	}
+
+	migratedTransactionUserCount, err := services.Transactions.MigrateAllTransactionsOriginalAmountDecimalPlaces(c)
+
+	if err != nil {
+		return err
+	}
+
+	if migratedTransactionUserCount > 0 {
+		log.BootInfof(c, "[database.updateAllDatabaseTablesStructure] original amounts of transactions of %d users have been migrated to the decimal places of their original currencies", migratedTransactionUserCount)
+	}
+
 	return nil
 }
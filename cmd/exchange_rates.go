@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+)
+
+const exchangeRatesBackfillDateFormat = "2006-01-02"
+
+// ExchangeRates represents the exchange rates command
+var ExchangeRates = &cli.Command{
+	Name:  "exchangerates",
+	Usage: "ezBookkeeping exchange rates utilities",
+	Commands: []*cli.Command{
+		{
+			Name:   "snapshot",
+			Usage:  "Save the latest exchange rates of current data source as snapshot",
+			Action: bindAction(saveExchangeRatesSnapshot),
+		},
+		{
+			Name:   "backfill",
+			Usage:  "Save the historical exchange rates of current data source as snapshots",
+			Action: bindAction(backfillExchangeRatesSnapshots),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "start-date",
+					Required: true,
+					Usage:    "Start date (YYYY-MM-DD)",
+				},
+				&cli.StringFlag{
+					Name:     "end-date",
+					Required: false,
+					Usage:    "End date (YYYY-MM-DD), default is today",
+				},
+			},
+		},
+	},
+}
+
+func saveExchangeRatesSnapshot(c *core.CliContext) error {
+	config, err := initializeSystem(c)
+
+	if err != nil {
+		return err
+	}
+
+	err = exchangerates.Container.SaveLatestExchangeRatesSnapshot(c, config)
+
+	if err != nil {
+		log.CliErrorf(c, "[exchange_rates.saveExchangeRatesSnapshot] failed to save exchange rates snapshot, because %s", err.Error())
+		return err
+	}
+
+	log.CliInfof(c, "[exchange_rates.saveExchangeRatesSnapshot] exchange rates snapshot of data source \"%s\" has been saved successfully", config.ExchangeRatesDataSource)
+
+	return nil
+}
+
+func backfillExchangeRatesSnapshots(c *core.CliContext) error {
+	config, err := initializeSystem(c)
+
+	if err != nil {
+		return err
+	}
+
+	startTime, err := time.ParseInLocation(exchangeRatesBackfillDateFormat, c.String("start-date"), time.UTC)
+
+	if err != nil {
+		log.CliErrorf(c, "[exchange_rates.backfillExchangeRatesSnapshots] start date is invalid")
+		return errs.ErrExchangeRateDateInvalid
+	}
+
+	endTime := time.Now().UTC()
+
+	if c.String("end-date") != "" {
+		endTime, err = time.ParseInLocation(exchangeRatesBackfillDateFormat, c.String("end-date"), time.UTC)
+
+		if err != nil {
+			log.CliErrorf(c, "[exchange_rates.backfillExchangeRatesSnapshots] end date is invalid")
+			return errs.ErrExchangeRateDateInvalid
+		}
+	}
+
+	savedCount, err := exchangerates.Container.BackfillHistoricalExchangeRatesSnapshots(c, startTime, endTime)
+
+	if err != nil {
+		log.CliErrorf(c, "[exchange_rates.backfillExchangeRatesSnapshots] failed to backfill exchange rates snapshots (%d dates saved), because %s", savedCount, err.Error())
+		return err
+	}
+
+	log.CliInfof(c, "[exchange_rates.backfillExchangeRatesSnapshots] %d dates of exchange rates snapshots of data source \"%s\" have been saved successfully", savedCount, config.ExchangeRatesDataSource)
+
+	return nil
+}
@@ -171,6 +171,31 @@ var UserData = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:   "user-set-transaction-lock-time",
+			Usage:  "Set or clear transaction lock time of user or one account of user",
+			Action: bindAction(setUserTransactionLockTime),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "username",
+					Aliases:  []string{"n"},
+					Required: true,
+					Usage:    "Specific user name",
+				},
+				&cli.Int64Flag{
+					Name:     "lockTime",
+					Aliases:  []string{"t"},
+					Required: true,
+					Usage:    "Unix time before which (inclusive) transactions cannot be created, modified or deleted (0 means no lock)",
+				},
+				&cli.Int64Flag{
+					Name:     "accountId",
+					Aliases:  []string{"a"},
+					Required: false,
+					Usage:    "Specific account id, set the lock time of user if not specified",
+				},
+			},
+		},
 		{
 			Name:   "user-resend-verify-email",
 			Usage:  "Resend user verify email",
@@ -593,6 +618,38 @@ func removeUserFeatureRestriction(c *core.CliContext) error {
 	return nil
 }
 
+func setUserTransactionLockTime(c *core.CliContext) error {
+	_, err := initializeSystem(c)
+
+	if err != nil {
+		return err
+	}
+
+	username := c.String("username")
+	transactionLockTime := c.Int64("lockTime")
+	accountId := c.Int64("accountId")
+
+	if transactionLockTime < 0 {
+		log.CliErrorf(c, "[user_data.setUserTransactionLockTime] lockTime cannot be less than 0")
+		return nil
+	}
+
+	err = clis.UserData.SetUserTransactionLockTime(c, username, accountId, transactionLockTime)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.setUserTransactionLockTime] error occurs when setting transaction lock time")
+		return err
+	}
+
+	if accountId > 0 {
+		log.CliInfof(c, "[user_data.setUserTransactionLockTime] transaction lock time of account \"id:%d\" of user \"%s\" has been set to %d", accountId, username, transactionLockTime)
+	} else {
+		log.CliInfof(c, "[user_data.setUserTransactionLockTime] transaction lock time of user \"%s\" has been set to %d", username, transactionLockTime)
+	}
+
+	return nil
+}
+
 func resendUserVerifyEmail(c *core.CliContext) error {
 	_, err := initializeSystem(c)
 
@@ -954,6 +1011,11 @@ func printUserInfo(user *models.User) {
 	fmt.Printf("[Salt] %s\n", user.Salt)
 	fmt.Printf("[DefaultAccountId] %d\n", user.DefaultAccountId)
 	fmt.Printf("[TransactionEditScope] %s (%d)\n", user.TransactionEditScope, user.TransactionEditScope)
+
+	if user.TransactionLockTime > 0 {
+		fmt.Printf("[TransactionLockTime] %s (%d)\n", utils.FormatUnixTimeToLongDateTimeInServerTimezone(user.TransactionLockTime), user.TransactionLockTime)
+	}
+
 	fmt.Printf("[Language] %s\n", user.Language)
 	fmt.Printf("[DefaultCurrency] %s\n", user.DefaultCurrency)
 	fmt.Printf("[FirstDayOfWeek] %s (%d)\n", user.FirstDayOfWeek, user.FirstDayOfWeek)
@@ -22,6 +22,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/mcp"
 	"github.com/mayswind/ezbookkeeping/pkg/middlewares"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/requestid"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -119,6 +120,7 @@ func startWebServer(c *core.CliContext) error {
 		_ = v.RegisterValidation("validTagFilter", validators.ValidTagFilter)
 		_ = v.RegisterValidation("validItemFilter", validators.ValidItemFilter)
 		_ = v.RegisterValidation("validFiscalYearStart", validators.ValidateFiscalYearStart)
+		_ = v.RegisterValidation("validScheduledFrequency", validators.ValidScheduledFrequency)
 	}
 
 	router.NoRoute(bindApi(api.Default.ApiNotFound))
@@ -187,9 +189,17 @@ func startWebServer(c *core.CliContext) error {
 	if config.EnableTransactionPictures {
 		pictureRoute := router.Group("/pictures")
 		pictureRoute.Use(bindMiddleware(middlewares.JWTAuthorizationByQueryString(config)))
+		pictureRoute.Use(bindMiddleware(middlewares.LedgerAuthorization(models.LEDGER_MEMBER_ROLE_VIEWER)))
 		{
 			pictureRoute.GET("/:fileName", bindImage(api.TransactionPictures.TransactionPictureGetHandler))
 		}
+
+		attachmentRoute := router.Group("/attachments")
+		attachmentRoute.Use(bindMiddleware(middlewares.JWTAuthorizationByQueryString(config)))
+		attachmentRoute.Use(bindMiddleware(middlewares.LedgerAuthorization(models.LEDGER_MEMBER_ROLE_VIEWER)))
+		{
+			attachmentRoute.GET("/:fileName", bindAttachment(api.TransactionPictures.TransactionAttachmentGetHandler))
+		}
 	}
 
 	router.GET("/healthz.json", bindApi(api.Healths.HealthStatusHandler))
@@ -238,6 +248,7 @@ func startWebServer(c *core.CliContext) error {
 		mcpRoute.Use(bindMiddleware(middlewares.RequestLog))
 		mcpRoute.Use(bindMiddleware(middlewares.MCPServerIpLimit(config)))
 		mcpRoute.Use(bindMiddleware(middlewares.JWTMCPAuthorization(config)))
+		mcpRoute.Use(bindMiddleware(middlewares.LedgerAuthorization(models.LEDGER_MEMBER_ROLE_VIEWER)))
 		{
 			mcpRoute.POST("", bindJSONRPCApi(map[string]core.JSONRPCApiHandlerFunc{
 				"initialize":     api.ModelContextProtocols.InitializeHandler,
@@ -370,99 +381,192 @@ func startWebServer(c *core.CliContext) error {
 				apiV1Route.GET("/data/export.tsv", bindTsv(api.DataManagements.ExportDataToEzbookkeepingTSVHandler))
 			}
 
-			// Accounts
-			apiV1Route.GET("/accounts/list.json", bindApi(api.Accounts.AccountListHandler))
-			apiV1Route.GET("/accounts/get.json", bindApi(api.Accounts.AccountGetHandler))
-			apiV1Route.POST("/accounts/add.json", bindApi(api.Accounts.AccountCreateHandler))
-			apiV1Route.POST("/accounts/modify.json", bindApi(api.Accounts.AccountModifyHandler))
-			apiV1Route.POST("/accounts/hide.json", bindApi(api.Accounts.AccountHideHandler))
-			apiV1Route.POST("/accounts/move.json", bindApi(api.Accounts.AccountMoveHandler))
-			apiV1Route.POST("/accounts/delete.json", bindApi(api.Accounts.AccountDeleteHandler))
-			apiV1Route.POST("/accounts/sub_account/delete.json", bindApi(api.Accounts.SubAccountDeleteHandler))
-
-			// Transactions
-			apiV1Route.GET("/transactions/count.json", bindApi(api.Transactions.TransactionCountHandler))
-			apiV1Route.GET("/transactions/list.json", bindApi(api.Transactions.TransactionListHandler))
-			apiV1Route.GET("/transactions/list/by_month.json", bindApi(api.Transactions.TransactionMonthListHandler))
-			apiV1Route.GET("/transactions/list/all.json", bindApi(api.Transactions.TransactionListAllHandler))
-			apiV1Route.GET("/transactions/reconciliation_statements.json", bindApi(api.Transactions.TransactionReconciliationStatementHandler))
-			apiV1Route.GET("/transactions/statistics.json", bindApi(api.Transactions.TransactionStatisticsHandler))
-			apiV1Route.GET("/transactions/statistics/trends.json", bindApi(api.Transactions.TransactionStatisticsTrendsHandler))
-			apiV1Route.GET("/transactions/statistics/asset_trends.json", bindApi(api.Transactions.TransactionStatisticsAssetTrendsHandler))
-			apiV1Route.GET("/transactions/amounts.json", bindApi(api.Transactions.TransactionAmountsHandler))
-			apiV1Route.GET("/transactions/get.json", bindApi(api.Transactions.TransactionGetHandler))
-			apiV1Route.POST("/transactions/add.json", bindApi(api.Transactions.TransactionCreateHandler))
-			apiV1Route.POST("/transactions/modify.json", bindApi(api.Transactions.TransactionModifyHandler))
-			apiV1Route.POST("/transactions/move/all.json", bindApi(api.Transactions.TransactionMoveAllBetweenAccountsHandler))
-			apiV1Route.POST("/transactions/delete.json", bindApi(api.Transactions.TransactionDeleteHandler))
-
-			if config.EnableDataImport {
-				apiV1Route.POST("/transactions/parse_dsv_file.json", bindApi(api.Transactions.TransactionParseImportDsvFileDataHandler))
-				apiV1Route.POST("/transactions/parse_import.json", bindApi(api.Transactions.TransactionParseImportFileHandler))
-				apiV1Route.POST("/transactions/import.json", bindApi(api.Transactions.TransactionImportHandler))
-				apiV1Route.GET("/transactions/import/process.json", bindApi(api.Transactions.TransactionImportProcessHandler))
-			}
-
-			// Transaction Pictures
-			if config.EnableTransactionPictures {
-				apiV1Route.POST("/transaction/pictures/upload.json", bindApi(api.TransactionPictures.TransactionPictureUploadHandler))
-				apiV1Route.POST("/transaction/pictures/remove_unused.json", bindApi(api.TransactionPictures.TransactionPictureRemoveUnusedHandler))
+			// Shared Ledgers
+			apiV1Route.GET("/ledgers/list.json", bindApi(api.Ledgers.LedgerListHandler))
+			apiV1Route.POST("/ledgers/add.json", bindApi(api.Ledgers.LedgerCreateHandler))
+			apiV1Route.POST("/ledgers/modify.json", bindApi(api.Ledgers.LedgerModifyHandler))
+			apiV1Route.POST("/ledgers/delete.json", bindApi(api.Ledgers.LedgerDeleteHandler))
+			apiV1Route.POST("/ledgers/members/invite.json", bindApi(api.Ledgers.LedgerMemberInviteHandler))
+			apiV1Route.POST("/ledgers/members/modify_role.json", bindApi(api.Ledgers.LedgerMemberModifyRoleHandler))
+			apiV1Route.POST("/ledgers/members/remove.json", bindApi(api.Ledgers.LedgerMemberRemoveHandler))
+			apiV1Route.POST("/ledgers/invitations/accept.json", bindApi(api.Ledgers.LedgerInvitationAcceptHandler))
+
+			// Apis below can operate on the data of a shared ledger specified by ledger id, each api requires the specified role of current user in the ledger
+			viewerLedgerRole := bindMiddleware(middlewares.LedgerAuthorization(models.LEDGER_MEMBER_ROLE_VIEWER))
+			editorLedgerRole := bindMiddleware(middlewares.LedgerAuthorization(models.LEDGER_MEMBER_ROLE_EDITOR))
+			adminLedgerRole := bindMiddleware(middlewares.LedgerAuthorization(models.LEDGER_MEMBER_ROLE_ADMIN))
+
+			ledgerRoute := apiV1Route.Group("")
+			{
+				// Accounts
+				ledgerRoute.GET("/accounts/list.json", viewerLedgerRole, bindApi(api.Accounts.AccountListHandler))
+				ledgerRoute.GET("/accounts/get.json", viewerLedgerRole, bindApi(api.Accounts.AccountGetHandler))
+				ledgerRoute.POST("/accounts/add.json", editorLedgerRole, bindApi(api.Accounts.AccountCreateHandler))
+				ledgerRoute.POST("/accounts/modify.json", editorLedgerRole, bindApi(api.Accounts.AccountModifyHandler))
+				ledgerRoute.POST("/accounts/hide.json", editorLedgerRole, bindApi(api.Accounts.AccountHideHandler))
+				ledgerRoute.POST("/accounts/move.json", editorLedgerRole, bindApi(api.Accounts.AccountMoveHandler))
+				ledgerRoute.POST("/accounts/delete.json", adminLedgerRole, bindApi(api.Accounts.AccountDeleteHandler))
+				ledgerRoute.POST("/accounts/sub_account/delete.json", adminLedgerRole, bindApi(api.Accounts.SubAccountDeleteHandler))
+
+				// Transactions
+				ledgerRoute.GET("/transactions/count.json", viewerLedgerRole, bindApi(api.Transactions.TransactionCountHandler))
+				ledgerRoute.GET("/transactions/list.json", viewerLedgerRole, bindApi(api.Transactions.TransactionListHandler))
+				ledgerRoute.GET("/transactions/list/by_month.json", viewerLedgerRole, bindApi(api.Transactions.TransactionMonthListHandler))
+				ledgerRoute.GET("/transactions/list/all.json", viewerLedgerRole, bindApi(api.Transactions.TransactionListAllHandler))
+				ledgerRoute.GET("/transactions/reconciliation_statements.json", viewerLedgerRole, bindApi(api.Transactions.TransactionReconciliationStatementHandler))
+				ledgerRoute.GET("/transactions/credit_card_statements.json", viewerLedgerRole, bindApi(api.Transactions.TransactionCreditCardStatementListHandler))
+				ledgerRoute.GET("/transactions/statistics.json", viewerLedgerRole, bindApi(api.Transactions.TransactionStatisticsHandler))
+				ledgerRoute.GET("/transactions/statistics/trends.json", viewerLedgerRole, bindApi(api.Transactions.TransactionStatisticsTrendsHandler))
+				ledgerRoute.GET("/transactions/statistics/asset_trends.json", viewerLedgerRole, bindApi(api.Transactions.TransactionStatisticsAssetTrendsHandler))
+				ledgerRoute.GET("/transactions/statistics/items.json", viewerLedgerRole, bindApi(api.Transactions.TransactionItemStatisticsHandler))
+				ledgerRoute.GET("/transactions/statistics/payees.json", viewerLedgerRole, bindApi(api.Transactions.TransactionPayeeStatisticsHandler))
+				ledgerRoute.GET("/transactions/amounts.json", viewerLedgerRole, bindApi(api.Transactions.TransactionAmountsHandler))
+				ledgerRoute.GET("/transactions/get.json", viewerLedgerRole, bindApi(api.Transactions.TransactionGetHandler))
+				ledgerRoute.POST("/transactions/add.json", editorLedgerRole, bindApi(api.Transactions.TransactionCreateHandler))
+				ledgerRoute.POST("/transactions/modify.json", editorLedgerRole, bindApi(api.Transactions.TransactionModifyHandler))
+				ledgerRoute.POST("/transactions/move/all.json", adminLedgerRole, bindApi(api.Transactions.TransactionMoveAllBetweenAccountsHandler))
+				ledgerRoute.POST("/transactions/cleared_status/modify.json", editorLedgerRole, bindApi(api.Transactions.TransactionModifyClearedStatusHandler))
+				ledgerRoute.POST("/transactions/delete.json", editorLedgerRole, bindApi(api.Transactions.TransactionDeleteHandler))
+				ledgerRoute.POST("/transactions/bulk_edit.json", adminLedgerRole, bindApi(api.Transactions.TransactionBulkEditHandler))
+				ledgerRoute.GET("/transactions/revisions/list.json", viewerLedgerRole, bindApi(api.TransactionRevisions.TransactionRevisionListHandler))
+				ledgerRoute.POST("/transactions/revisions/revert.json", editorLedgerRole, bindApi(api.TransactionRevisions.TransactionRevisionRevertHandler))
+
+				if config.EnableDataImport {
+					ledgerRoute.POST("/transactions/parse_dsv_file.json", editorLedgerRole, bindApi(api.Transactions.TransactionParseImportDsvFileDataHandler))
+					ledgerRoute.POST("/transactions/parse_import.json", editorLedgerRole, bindApi(api.Transactions.TransactionParseImportFileHandler))
+					ledgerRoute.POST("/transactions/import.json", adminLedgerRole, bindApi(api.Transactions.TransactionImportHandler))
+					ledgerRoute.GET("/transactions/import/process.json", viewerLedgerRole, bindApi(api.Transactions.TransactionImportProcessHandler))
+				}
+
+				// Trash
+				ledgerRoute.GET("/trash/list.json", viewerLedgerRole, bindApi(api.Trash.TrashListHandler))
+				ledgerRoute.POST("/trash/restore.json", editorLedgerRole, bindApi(api.Trash.TrashRestoreHandler))
+				ledgerRoute.POST("/trash/purge.json", adminLedgerRole, bindApi(api.Trash.TrashPurgeHandler))
+
+				// Transaction Pictures
+				if config.EnableTransactionPictures {
+					ledgerRoute.POST("/transaction/pictures/upload.json", editorLedgerRole, bindApi(api.TransactionPictures.TransactionPictureUploadHandler))
+					ledgerRoute.POST("/transaction/attachments/upload.json", editorLedgerRole, bindApi(api.TransactionPictures.TransactionAttachmentUploadHandler))
+					ledgerRoute.POST("/transaction/pictures/remove_unused.json", adminLedgerRole, bindApi(api.TransactionPictures.TransactionPictureRemoveUnusedHandler))
+				}
+
+				// Transaction Categories
+				ledgerRoute.GET("/transaction/categories/list.json", viewerLedgerRole, bindApi(api.TransactionCategories.CategoryListHandler))
+				ledgerRoute.GET("/transaction/categories/get.json", viewerLedgerRole, bindApi(api.TransactionCategories.CategoryGetHandler))
+				ledgerRoute.POST("/transaction/categories/add.json", editorLedgerRole, bindApi(api.TransactionCategories.CategoryCreateHandler))
+				ledgerRoute.POST("/transaction/categories/add_batch.json", editorLedgerRole, bindApi(api.TransactionCategories.CategoryCreateBatchHandler))
+				ledgerRoute.POST("/transaction/categories/modify.json", editorLedgerRole, bindApi(api.TransactionCategories.CategoryModifyHandler))
+				ledgerRoute.POST("/transaction/categories/hide.json", editorLedgerRole, bindApi(api.TransactionCategories.CategoryHideHandler))
+				ledgerRoute.POST("/transaction/categories/move.json", editorLedgerRole, bindApi(api.TransactionCategories.CategoryMoveHandler))
+				ledgerRoute.POST("/transaction/categories/delete.json", adminLedgerRole, bindApi(api.TransactionCategories.CategoryDeleteHandler))
+
+				// Transaction Tag Groups
+				ledgerRoute.GET("/transaction/tags/groups/list.json", viewerLedgerRole, bindApi(api.TransactionTagGroups.TagGroupListHandler))
+				ledgerRoute.GET("/transaction/tags/groups/get.json", viewerLedgerRole, bindApi(api.TransactionTagGroups.TagGroupGetHandler))
+				ledgerRoute.POST("/transaction/tags/groups/add.json", editorLedgerRole, bindApi(api.TransactionTagGroups.TagGroupCreateHandler))
+				ledgerRoute.POST("/transaction/tags/groups/modify.json", editorLedgerRole, bindApi(api.TransactionTagGroups.TagGroupModifyHandler))
+				ledgerRoute.POST("/transaction/tags/groups/move.json", editorLedgerRole, bindApi(api.TransactionTagGroups.TagGroupMoveHandler))
+				ledgerRoute.POST("/transaction/tags/groups/delete.json", adminLedgerRole, bindApi(api.TransactionTagGroups.TagGroupDeleteHandler))
+
+				// Transaction Tags
+				ledgerRoute.GET("/transaction/tags/list.json", viewerLedgerRole, bindApi(api.TransactionTags.TagListHandler))
+				ledgerRoute.GET("/transaction/tags/get.json", viewerLedgerRole, bindApi(api.TransactionTags.TagGetHandler))
+				ledgerRoute.POST("/transaction/tags/add.json", editorLedgerRole, bindApi(api.TransactionTags.TagCreateHandler))
+				ledgerRoute.POST("/transaction/tags/add_batch.json", editorLedgerRole, bindApi(api.TransactionTags.TagCreateBatchHandler))
+				ledgerRoute.POST("/transaction/tags/modify.json", editorLedgerRole, bindApi(api.TransactionTags.TagModifyHandler))
+				ledgerRoute.POST("/transaction/tags/hide.json", editorLedgerRole, bindApi(api.TransactionTags.TagHideHandler))
+				ledgerRoute.POST("/transaction/tags/move.json", editorLedgerRole, bindApi(api.TransactionTags.TagMoveHandler))
+				ledgerRoute.POST("/transaction/tags/delete.json", adminLedgerRole, bindApi(api.TransactionTags.TagDeleteHandler))
+
+				// Transaction Item Groups
+				ledgerRoute.GET("/transaction/items/groups/list.json", viewerLedgerRole, bindApi(api.TransactionItemGroups.ItemGroupListHandler))
+				ledgerRoute.GET("/transaction/items/groups/get.json", viewerLedgerRole, bindApi(api.TransactionItemGroups.ItemGroupGetHandler))
+				ledgerRoute.POST("/transaction/items/groups/add.json", editorLedgerRole, bindApi(api.TransactionItemGroups.ItemGroupCreateHandler))
+				ledgerRoute.POST("/transaction/items/groups/modify.json", editorLedgerRole, bindApi(api.TransactionItemGroups.ItemGroupModifyHandler))
+				ledgerRoute.POST("/transaction/items/groups/move.json", editorLedgerRole, bindApi(api.TransactionItemGroups.ItemGroupMoveHandler))
+				ledgerRoute.POST("/transaction/items/groups/delete.json", adminLedgerRole, bindApi(api.TransactionItemGroups.ItemGroupDeleteHandler))
+
+				// Transaction Items
+				ledgerRoute.GET("/transaction/items/list.json", viewerLedgerRole, bindApi(api.TransactionItems.ItemListHandler))
+				ledgerRoute.GET("/transaction/items/get.json", viewerLedgerRole, bindApi(api.TransactionItems.ItemGetHandler))
+				ledgerRoute.POST("/transaction/items/add.json", editorLedgerRole, bindApi(api.TransactionItems.ItemCreateHandler))
+				ledgerRoute.POST("/transaction/items/add_batch.json", editorLedgerRole, bindApi(api.TransactionItems.ItemCreateBatchHandler))
+				ledgerRoute.POST("/transaction/items/modify.json", editorLedgerRole, bindApi(api.TransactionItems.ItemModifyHandler))
+				ledgerRoute.POST("/transaction/items/hide.json", editorLedgerRole, bindApi(api.TransactionItems.ItemHideHandler))
+				ledgerRoute.POST("/transaction/items/move.json", editorLedgerRole, bindApi(api.TransactionItems.ItemMoveHandler))
+				ledgerRoute.POST("/transaction/items/delete.json", adminLedgerRole, bindApi(api.TransactionItems.ItemDeleteHandler))
+
+				// Payees
+				ledgerRoute.GET("/payees/list.json", viewerLedgerRole, bindApi(api.Payees.PayeeListHandler))
+				ledgerRoute.GET("/payees/get.json", viewerLedgerRole, bindApi(api.Payees.PayeeGetHandler))
+				ledgerRoute.POST("/payees/add.json", editorLedgerRole, bindApi(api.Payees.PayeeCreateHandler))
+				ledgerRoute.POST("/payees/add_batch.json", editorLedgerRole, bindApi(api.Payees.PayeeCreateBatchHandler))
+				ledgerRoute.POST("/payees/modify.json", editorLedgerRole, bindApi(api.Payees.PayeeModifyHandler))
+				ledgerRoute.POST("/payees/hide.json", editorLedgerRole, bindApi(api.Payees.PayeeHideHandler))
+				ledgerRoute.POST("/payees/move.json", editorLedgerRole, bindApi(api.Payees.PayeeMoveHandler))
+				ledgerRoute.POST("/payees/delete.json", adminLedgerRole, bindApi(api.Payees.PayeeDeleteHandler))
+
+				// Securities
+				ledgerRoute.GET("/securities/list.json", viewerLedgerRole, bindApi(api.Securities.SecurityListHandler))
+				ledgerRoute.GET("/securities/get.json", viewerLedgerRole, bindApi(api.Securities.SecurityGetHandler))
+				ledgerRoute.POST("/securities/add.json", editorLedgerRole, bindApi(api.Securities.SecurityCreateHandler))
+				ledgerRoute.POST("/securities/modify.json", editorLedgerRole, bindApi(api.Securities.SecurityModifyHandler))
+				ledgerRoute.POST("/securities/delete.json", adminLedgerRole, bindApi(api.Securities.SecurityDeleteHandler))
+				ledgerRoute.GET("/securities/prices/list.json", viewerLedgerRole, bindApi(api.Securities.SecurityPriceListHandler))
+				ledgerRoute.POST("/securities/prices/add.json", editorLedgerRole, bindApi(api.Securities.SecurityPriceCreateHandler))
+				ledgerRoute.POST("/securities/prices/update.json", editorLedgerRole, bindApi(api.Securities.SecurityPriceUpdateHandler))
+				ledgerRoute.POST("/securities/prices/delete.json", editorLedgerRole, bindApi(api.Securities.SecurityPriceDeleteHandler))
+
+				// Investment Transactions
+				ledgerRoute.GET("/investment/transactions/list.json", viewerLedgerRole, bindApi(api.InvestmentTransactions.InvestmentTransactionListHandler))
+				ledgerRoute.POST("/investment/transactions/add.json", editorLedgerRole, bindApi(api.InvestmentTransactions.InvestmentTransactionCreateHandler))
+				ledgerRoute.POST("/investment/transactions/modify.json", editorLedgerRole, bindApi(api.InvestmentTransactions.InvestmentTransactionModifyHandler))
+				ledgerRoute.POST("/investment/transactions/delete.json", editorLedgerRole, bindApi(api.InvestmentTransactions.InvestmentTransactionDeleteHandler))
+				ledgerRoute.GET("/investment/holdings/list.json", viewerLedgerRole, bindApi(api.InvestmentTransactions.InvestmentHoldingListHandler))
+
+				// Loans
+				ledgerRoute.GET("/loans/list.json", viewerLedgerRole, bindApi(api.Loans.LoanListHandler))
+				ledgerRoute.GET("/loans/get.json", viewerLedgerRole, bindApi(api.Loans.LoanGetHandler))
+				ledgerRoute.GET("/loans/schedule.json", viewerLedgerRole, bindApi(api.Loans.LoanScheduleHandler))
+				ledgerRoute.POST("/loans/add.json", editorLedgerRole, bindApi(api.Loans.LoanCreateHandler))
+				ledgerRoute.POST("/loans/modify.json", editorLedgerRole, bindApi(api.Loans.LoanModifyHandler))
+				ledgerRoute.POST("/loans/delete.json", adminLedgerRole, bindApi(api.Loans.LoanDeleteHandler))
+
+				// Reconciliation Sessions
+				ledgerRoute.GET("/reconciliations/list.json", viewerLedgerRole, bindApi(api.ReconciliationSessions.ReconciliationSessionListHandler))
+				ledgerRoute.GET("/reconciliations/get.json", viewerLedgerRole, bindApi(api.ReconciliationSessions.ReconciliationSessionGetHandler))
+				ledgerRoute.POST("/reconciliations/add.json", editorLedgerRole, bindApi(api.ReconciliationSessions.ReconciliationSessionCreateHandler))
+				ledgerRoute.POST("/reconciliations/complete.json", editorLedgerRole, bindApi(api.ReconciliationSessions.ReconciliationSessionCompleteHandler))
+				ledgerRoute.POST("/reconciliations/delete.json", adminLedgerRole, bindApi(api.ReconciliationSessions.ReconciliationSessionDeleteHandler))
 			}
 
-			// Transaction Categories
-			apiV1Route.GET("/transaction/categories/list.json", bindApi(api.TransactionCategories.CategoryListHandler))
-			apiV1Route.GET("/transaction/categories/get.json", bindApi(api.TransactionCategories.CategoryGetHandler))
-			apiV1Route.POST("/transaction/categories/add.json", bindApi(api.TransactionCategories.CategoryCreateHandler))
-			apiV1Route.POST("/transaction/categories/add_batch.json", bindApi(api.TransactionCategories.CategoryCreateBatchHandler))
-			apiV1Route.POST("/transaction/categories/modify.json", bindApi(api.TransactionCategories.CategoryModifyHandler))
-			apiV1Route.POST("/transaction/categories/hide.json", bindApi(api.TransactionCategories.CategoryHideHandler))
-			apiV1Route.POST("/transaction/categories/move.json", bindApi(api.TransactionCategories.CategoryMoveHandler))
-			apiV1Route.POST("/transaction/categories/delete.json", bindApi(api.TransactionCategories.CategoryDeleteHandler))
-
-			// Transaction Tag Groups
-			apiV1Route.GET("/transaction/tags/groups/list.json", bindApi(api.TransactionTagGroups.TagGroupListHandler))
-			apiV1Route.GET("/transaction/tags/groups/get.json", bindApi(api.TransactionTagGroups.TagGroupGetHandler))
-			apiV1Route.POST("/transaction/tags/groups/add.json", bindApi(api.TransactionTagGroups.TagGroupCreateHandler))
-			apiV1Route.POST("/transaction/tags/groups/modify.json", bindApi(api.TransactionTagGroups.TagGroupModifyHandler))
-			apiV1Route.POST("/transaction/tags/groups/move.json", bindApi(api.TransactionTagGroups.TagGroupMoveHandler))
-			apiV1Route.POST("/transaction/tags/groups/delete.json", bindApi(api.TransactionTagGroups.TagGroupDeleteHandler))
-
-			// Transaction Tags
-			apiV1Route.GET("/transaction/tags/list.json", bindApi(api.TransactionTags.TagListHandler))
-			apiV1Route.GET("/transaction/tags/get.json", bindApi(api.TransactionTags.TagGetHandler))
-			apiV1Route.POST("/transaction/tags/add.json", bindApi(api.TransactionTags.TagCreateHandler))
-			apiV1Route.POST("/transaction/tags/add_batch.json", bindApi(api.TransactionTags.TagCreateBatchHandler))
-			apiV1Route.POST("/transaction/tags/modify.json", bindApi(api.TransactionTags.TagModifyHandler))
-			apiV1Route.POST("/transaction/tags/hide.json", bindApi(api.TransactionTags.TagHideHandler))
-			apiV1Route.POST("/transaction/tags/move.json", bindApi(api.TransactionTags.TagMoveHandler))
-			apiV1Route.POST("/transaction/tags/delete.json", bindApi(api.TransactionTags.TagDeleteHandler))
-
-			// Transaction Item Groups
-			apiV1Route.GET("/transaction/items/groups/list.json", bindApi(api.TransactionItemGroups.ItemGroupListHandler))
-			apiV1Route.GET("/transaction/items/groups/get.json", bindApi(api.TransactionItemGroups.ItemGroupGetHandler))
-			apiV1Route.POST("/transaction/items/groups/add.json", bindApi(api.TransactionItemGroups.ItemGroupCreateHandler))
-			apiV1Route.POST("/transaction/items/groups/modify.json", bindApi(api.TransactionItemGroups.ItemGroupModifyHandler))
-			apiV1Route.POST("/transaction/items/groups/move.json", bindApi(api.TransactionItemGroups.ItemGroupMoveHandler))
-			apiV1Route.POST("/transaction/items/groups/delete.json", bindApi(api.TransactionItemGroups.ItemGroupDeleteHandler))
-
-			// Transaction Items
-			apiV1Route.GET("/transaction/items/list.json", bindApi(api.TransactionItems.ItemListHandler))
-			apiV1Route.GET("/transaction/items/get.json", bindApi(api.TransactionItems.ItemGetHandler))
-			apiV1Route.POST("/transaction/items/add.json", bindApi(api.TransactionItems.ItemCreateHandler))
-			apiV1Route.POST("/transaction/items/add_batch.json", bindApi(api.TransactionItems.ItemCreateBatchHandler))
-			apiV1Route.POST("/transaction/items/modify.json", bindApi(api.TransactionItems.ItemModifyHandler))
-			apiV1Route.POST("/transaction/items/hide.json", bindApi(api.TransactionItems.ItemHideHandler))
-			apiV1Route.POST("/transaction/items/move.json", bindApi(api.TransactionItems.ItemMoveHandler))
-			apiV1Route.POST("/transaction/items/delete.json", bindApi(api.TransactionItems.ItemDeleteHandler))
-
 			// Transaction Templates
 			apiV1Route.GET("/transaction/templates/list.json", bindApi(api.TransactionTemplates.TemplateListHandler))
 			apiV1Route.GET("/transaction/templates/get.json", bindApi(api.TransactionTemplates.TemplateGetHandler))
+			apiV1Route.GET("/transaction/templates/occurrences/upcoming.json", bindApi(api.TransactionTemplates.TemplateUpcomingOccurrenceListHandler))
+			apiV1Route.GET("/transaction/templates/occurrences/list.json", bindApi(api.TransactionTemplates.TemplateOccurrenceListHandler))
 			apiV1Route.POST("/transaction/templates/add.json", bindApi(api.TransactionTemplates.TemplateCreateHandler))
 			apiV1Route.POST("/transaction/templates/modify.json", bindApi(api.TransactionTemplates.TemplateModifyHandler))
 			apiV1Route.POST("/transaction/templates/hide.json", bindApi(api.TransactionTemplates.TemplateHideHandler))
 			apiV1Route.POST("/transaction/templates/move.json", bindApi(api.TransactionTemplates.TemplateMoveHandler))
 			apiV1Route.POST("/transaction/templates/delete.json", bindApi(api.TransactionTemplates.TemplateDeleteHandler))
+			apiV1Route.POST("/transaction/templates/occurrences/confirm.json", bindApi(api.TransactionTemplates.TemplateOccurrenceConfirmHandler))
+			apiV1Route.POST("/transaction/templates/occurrences/skip.json", bindApi(api.TransactionTemplates.TemplateOccurrenceSkipHandler))
+			apiV1Route.POST("/transaction/templates/occurrences/skip_upcoming.json", bindApi(api.TransactionTemplates.TemplateUpcomingOccurrenceSkipHandler))
+			apiV1Route.POST("/transaction/templates/occurrences/postpone_upcoming.json", bindApi(api.TransactionTemplates.TemplateUpcomingOccurrencePostponeHandler))
+			apiV1Route.POST("/transaction/templates/occurrences/delete.json", bindApi(api.TransactionTemplates.TemplateOccurrenceDeleteHandler))
+
+			// Transaction Rules
+			apiV1Route.GET("/transaction/rules/list.json", bindApi(api.TransactionRules.RuleListHandler))
+			apiV1Route.GET("/transaction/rules/get.json", bindApi(api.TransactionRules.RuleGetHandler))
+			apiV1Route.POST("/transaction/rules/add.json", bindApi(api.TransactionRules.RuleCreateHandler))
+			apiV1Route.POST("/transaction/rules/modify.json", bindApi(api.TransactionRules.RuleModifyHandler))
+			apiV1Route.POST("/transaction/rules/move.json", bindApi(api.TransactionRules.RuleMoveHandler))
+			apiV1Route.POST("/transaction/rules/delete.json", bindApi(api.TransactionRules.RuleDeleteHandler))
+			apiV1Route.POST("/transaction/rules/run.json", bindApi(api.TransactionRules.RuleRunHandler))
+
+			// Forecasts
+			apiV1Route.GET("/forecasts/cash_flow.json", bindApi(api.Forecasts.CashFlowForecastHandler))
 
 			// Insights Explorers
 			apiV1Route.GET("/insights/explorers/list.json", bindApi(api.InsightsExplorers.InsightsExplorerListHandler))
@@ -473,6 +577,25 @@ func startWebServer(c *core.CliContext) error {
 			apiV1Route.POST("/insights/explorers/move.json", bindApi(api.InsightsExplorers.InsightsExplorerMoveHandler))
 			apiV1Route.POST("/insights/explorers/delete.json", bindApi(api.InsightsExplorers.InsightsExplorerDeleteHandler))
 
+			// Saved Searches
+			apiV1Route.GET("/saved_searches/list.json", bindApi(api.SavedSearches.SavedSearchListHandler))
+			apiV1Route.GET("/saved_searches/get.json", bindApi(api.SavedSearches.SavedSearchGetHandler))
+			apiV1Route.POST("/saved_searches/add.json", bindApi(api.SavedSearches.SavedSearchCreateHandler))
+			apiV1Route.POST("/saved_searches/modify.json", bindApi(api.SavedSearches.SavedSearchModifyHandler))
+			apiV1Route.POST("/saved_searches/hide.json", bindApi(api.SavedSearches.SavedSearchHideHandler))
+			apiV1Route.POST("/saved_searches/move.json", bindApi(api.SavedSearches.SavedSearchMoveHandler))
+			apiV1Route.POST("/saved_searches/delete.json", bindApi(api.SavedSearches.SavedSearchDeleteHandler))
+
+			// Budgets
+			apiV1Route.GET("/budgets/list.json", bindApi(api.Budgets.BudgetListHandler))
+			apiV1Route.GET("/budgets/get.json", bindApi(api.Budgets.BudgetGetHandler))
+			apiV1Route.GET("/budgets/actual.json", bindApi(api.Budgets.BudgetActualHandler))
+			apiV1Route.POST("/budgets/add.json", bindApi(api.Budgets.BudgetCreateHandler))
+			apiV1Route.POST("/budgets/modify.json", bindApi(api.Budgets.BudgetModifyHandler))
+			apiV1Route.POST("/budgets/hide.json", bindApi(api.Budgets.BudgetHideHandler))
+			apiV1Route.POST("/budgets/move.json", bindApi(api.Budgets.BudgetMoveHandler))
+			apiV1Route.POST("/budgets/delete.json", bindApi(api.Budgets.BudgetDeleteHandler))
+
 			// Large Language Models (only OCR bill recognition is kept; AI image recognition has been removed)
 			if config.TransactionFromOCRImageRecognition {
 				apiV1Route.POST("/llm/transactions/recognize_receipt_image_ocr.json", bindApi(api.LargeLanguageModels.RecognizeReceiptImageByOCRHandler))
@@ -480,6 +603,7 @@ func startWebServer(c *core.CliContext) error {
 
 			// Exchange Rates
 			apiV1Route.GET("/exchange_rates/latest.json", bindApi(api.ExchangeRates.LatestExchangeRateHandler))
+			apiV1Route.GET("/exchange_rates/historical.json", bindApi(api.ExchangeRates.HistoricalExchangeRateHandler))
 			apiV1Route.POST("/exchange_rates/user_custom/update.json", bindApi(api.ExchangeRates.UserCustomExchangeRateUpdateHandler))
 			apiV1Route.POST("/exchange_rates/user_custom/delete.json", bindApi(api.ExchangeRates.UserCustomExchangeRateDeleteHandler))
 
@@ -662,6 +786,19 @@ func bindImage(fn core.ImageHandlerFunc) gin.HandlerFunc {
 	}
 }
 
+func bindAttachment(fn core.AttachmentHandlerFunc) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		c := core.WrapWebContext(ginCtx)
+		result, contentType, fileName, err := fn(c)
+
+		if err != nil {
+			utils.PrintDataErrorResult(c, "text/text", err)
+		} else {
+			utils.PrintAttachmentSuccessResult(c, contentType, fileName, result)
+		}
+	}
+}
+
 func bindCachedImage(fn core.ImageHandlerFunc, store persistence.CacheStore) gin.HandlerFunc {
 	return cache.CachePage(store, time.Minute, func(ginCtx *gin.Context) {
 		c := core.WrapWebContext(ginCtx)
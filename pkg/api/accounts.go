@@ -162,7 +162,7 @@ func (a *AccountsApi) AccountCreateHandler(c *core.WebContext) (any, *errs.Error
 		return nil, errs.ErrAccountCategoryInvalid
 	}
 
-	if accountCreateReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && accountCreateReq.CreditCardStatementDate != 0 {
+	if accountCreateReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && (accountCreateReq.CreditCardStatementDate != 0 || accountCreateReq.CreditCardGracePeriodDays != 0 || accountCreateReq.CreditCardMinimumPaymentRate != 0) {
 		log.Warnf(c, "[accounts.AccountCreateHandler] cannot set statement date with category \"%d\"", accountCreateReq.Category)
 		return nil, errs.ErrCannotSetStatementDateForNonCreditCard
 	}
@@ -221,7 +221,7 @@ func (a *AccountsApi) AccountCreateHandler(c *core.WebContext) (any, *errs.Error
 				return nil, errs.ErrAccountBalanceTimeNotSet
 			}
 
-			if subAccount.CreditCardStatementDate != 0 {
+			if subAccount.CreditCardStatementDate != 0 || subAccount.CreditCardGracePeriodDays != 0 || subAccount.CreditCardMinimumPaymentRate != 0 {
 				log.Warnf(c, "[accounts.AccountCreateHandler] sub-account#%d cannot set statement date", i)
 				return nil, errs.ErrCannotSetStatementDateForSubAccount
 			}
@@ -327,7 +327,7 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 		return nil, errs.ErrAccountCategoryInvalid
 	}
 
-	if accountModifyReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && accountModifyReq.CreditCardStatementDate != 0 {
+	if accountModifyReq.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD && (accountModifyReq.CreditCardStatementDate != 0 || accountModifyReq.CreditCardGracePeriodDays != 0 || accountModifyReq.CreditCardMinimumPaymentRate != 0) {
 		log.Warnf(c, "[accounts.AccountModifyHandler] cannot set statement date with category \"%d\"", accountModifyReq.Category)
 		return nil, errs.ErrCannotSetStatementDateForNonCreditCard
 	}
@@ -359,6 +359,11 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 		return nil, errs.ErrNotSupportedChangeBalanceTime
 	}
 
+	if accountModifyReq.TransactionLockTime != nil && *accountModifyReq.TransactionLockTime < mainAccount.GetTransactionLockTime() {
+		log.Warnf(c, "[accounts.AccountModifyHandler] cannot move transaction lock time of account \"id:%d\" backward", mainAccount.AccountId)
+		return nil, errs.ErrCannotMoveTransactionLockTimeBackward
+	}
+
 	if mainAccount.Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
 		if len(accountModifyReq.SubAccounts) > 0 {
 			log.Warnf(c, "[accounts.AccountModifyHandler] account cannot have any sub-accounts")
@@ -419,9 +424,14 @@ func (a *AccountsApi) AccountModifyHandler(c *core.WebContext) (any, *errs.Error
 				if subAccountReq.BalanceTime != nil {
 					return nil, errs.ErrNotSupportedChangeBalanceTime
 				}
+
+				if subAccountReq.TransactionLockTime != nil && *subAccountReq.TransactionLockTime < subAccount.GetTransactionLockTime() {
+					log.Warnf(c, "[accounts.AccountModifyHandler] cannot move transaction lock time of sub-account#%d backward", i)
+					return nil, errs.ErrCannotMoveTransactionLockTimeBackward
+				}
 			}
 
-			if subAccountReq.CreditCardStatementDate != 0 {
+			if subAccountReq.CreditCardStatementDate != 0 || subAccountReq.CreditCardGracePeriodDays != 0 || subAccountReq.CreditCardMinimumPaymentRate != 0 {
 				log.Warnf(c, "[accounts.AccountModifyHandler] sub-account#%d cannot set statement date", i)
 				return nil, errs.ErrCannotSetStatementDateForSubAccount
 			}
@@ -713,6 +723,8 @@ func (a *AccountsApi) createNewAccountModel(uid int64, accountCreateReq *models.
 
 	if !isSubAccount && accountCreateReq.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
 		accountExtend.CreditCardStatementDate = &accountCreateReq.CreditCardStatementDate
+		accountExtend.CreditCardGracePeriodDays = &accountCreateReq.CreditCardGracePeriodDays
+		accountExtend.CreditCardMinimumPaymentRate = &accountCreateReq.CreditCardMinimumPaymentRate
 	}
 
 	return &models.Account{
@@ -733,6 +745,10 @@ func (a *AccountsApi) createNewAccountModel(uid int64, accountCreateReq *models.
 func (a *AccountsApi) createNewSubAccountModelForModify(uid int64, accountType models.AccountType, accountModifyReq *models.AccountModifyRequest, order int32) *models.Account {
 	accountExtend := &models.AccountExtend{}
 
+	if accountModifyReq.TransactionLockTime != nil && *accountModifyReq.TransactionLockTime > 0 {
+		accountExtend.TransactionLockTime = accountModifyReq.TransactionLockTime
+	}
+
 	return &models.Account{
 		Uid:          uid,
 		Name:         accountModifyReq.Name,
@@ -769,6 +785,16 @@ func (a *AccountsApi) getToUpdateAccount(uid int64, accountModifyReq *models.Acc
 
 	if !isSubAccount && accountModifyReq.Category == models.ACCOUNT_CATEGORY_CREDIT_CARD {
 		newAccountExtend.CreditCardStatementDate = &accountModifyReq.CreditCardStatementDate
+		newAccountExtend.CreditCardGracePeriodDays = &accountModifyReq.CreditCardGracePeriodDays
+		newAccountExtend.CreditCardMinimumPaymentRate = &accountModifyReq.CreditCardMinimumPaymentRate
+	}
+
+	if accountModifyReq.TransactionLockTime != nil {
+		if *accountModifyReq.TransactionLockTime > 0 {
+			newAccountExtend.TransactionLockTime = accountModifyReq.TransactionLockTime
+		}
+	} else if oldAccount.Extend != nil {
+		newAccountExtend.TransactionLockTime = oldAccount.Extend.TransactionLockTime
 	}
 
 	newAccount := &models.Account{
@@ -800,7 +826,10 @@ func (a *AccountsApi) getToUpdateAccount(uid int64, accountModifyReq *models.Acc
 
 	oldAccountExtend := oldAccount.Extend
 
-	if newAccountExtend.CreditCardStatementDate != oldAccountExtend.CreditCardStatementDate {
+	if newAccountExtend.CreditCardStatementDate != oldAccountExtend.CreditCardStatementDate ||
+		newAccountExtend.CreditCardGracePeriodDays != oldAccountExtend.CreditCardGracePeriodDays ||
+		newAccountExtend.CreditCardMinimumPaymentRate != oldAccountExtend.CreditCardMinimumPaymentRate ||
+		newAccount.GetTransactionLockTime() != oldAccount.GetTransactionLockTime() {
 		return newAccount
 	}
 
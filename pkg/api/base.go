@@ -16,6 +16,7 @@ import (
 )
 
 const internalTransactionPictureUrlFormat = "%spictures/%d.%s"
+const internalTransactionAttachmentUrlFormat = "%sattachments/%d.%s"
 
 // ApiUsingConfig represents an api that need to use config
 type ApiUsingConfig struct {
@@ -28,17 +29,32 @@ func (a *ApiUsingConfig) CurrentConfig() *settings.Config {
 }
 
 // GetTransactionPictureInfoResponse returns the view-object of transaction picture basic info according to the transaction picture model
-func (a *ApiUsingConfig) GetTransactionPictureInfoResponse(pictureInfo *models.TransactionPictureInfo) *models.TransactionPictureInfoBasicResponse {
-	originalUrl := fmt.Sprintf(internalTransactionPictureUrlFormat, a.CurrentConfig().RootUrl, pictureInfo.PictureId, pictureInfo.PictureExtension)
-	return pictureInfo.ToTransactionPictureInfoBasicResponse(originalUrl)
+// The urls contain the ledger id if current request operates on a shared ledger, so that the pictures can be read from the ledger owner's data
+func (a *ApiUsingConfig) GetTransactionPictureInfoResponse(c *core.WebContext, pictureInfo *models.TransactionPictureInfo) *models.TransactionPictureInfoBasicResponse {
+	downloadUrl := fmt.Sprintf(internalTransactionAttachmentUrlFormat, a.CurrentConfig().RootUrl, pictureInfo.PictureId, pictureInfo.PictureExtension)
+	originalUrl := downloadUrl
+
+	if pictureInfo.GetAttachmentType() == models.TRANSACTION_ATTACHMENT_TYPE_IMAGE {
+		originalUrl = fmt.Sprintf(internalTransactionPictureUrlFormat, a.CurrentConfig().RootUrl, pictureInfo.PictureId, pictureInfo.PictureExtension)
+	}
+
+	if c.GetCurrentLedgerOwnerUid() > 0 {
+		if ledgerId, err := c.GetLedgerId(); err == nil && ledgerId > 0 {
+			ledgerQueryString := fmt.Sprintf("?%s=%d", core.LedgerIdQueryStringParamName, ledgerId)
+			downloadUrl = downloadUrl + ledgerQueryString
+			originalUrl = originalUrl + ledgerQueryString
+		}
+	}
+
+	return pictureInfo.ToTransactionPictureInfoBasicResponse(originalUrl, downloadUrl)
 }
 
 // GetTransactionPictureInfoResponseList returns the view-object list of transaction picture basic info according to the transaction picture model
-func (a *ApiUsingConfig) GetTransactionPictureInfoResponseList(pictureInfos []*models.TransactionPictureInfo) models.TransactionPictureInfoBasicResponseSlice {
+func (a *ApiUsingConfig) GetTransactionPictureInfoResponseList(c *core.WebContext, pictureInfos []*models.TransactionPictureInfo) models.TransactionPictureInfoBasicResponseSlice {
 	pictureInfoResps := make(models.TransactionPictureInfoBasicResponseSlice, len(pictureInfos))
 
 	for i := 0; i < len(pictureInfos); i++ {
-		pictureInfoResps[i] = a.GetTransactionPictureInfoResponse(pictureInfos[i])
+		pictureInfoResps[i] = a.GetTransactionPictureInfoResponse(c, pictureInfos[i])
 	}
 
 	sort.Sort(pictureInfoResps)
@@ -46,6 +62,23 @@ func (a *ApiUsingConfig) GetTransactionPictureInfoResponseList(pictureInfos []*m
 	return pictureInfoResps
 }
 
+// GetTransactionRevisionActor returns the actor of transaction modification according to the current request
+func (a *ApiUsingConfig) GetTransactionRevisionActor(c *core.WebContext) *models.TransactionRevisionActor {
+	actor := &models.TransactionRevisionActor{
+		OperatorUid: c.GetCurrentOperatorUid(),
+		Source:      models.TRANSACTION_REVISION_SOURCE_WEB,
+		ClientIp:    c.ClientIP(),
+	}
+
+	claims := c.GetTokenClaims()
+
+	if claims != nil {
+		actor.Source = models.GetTransactionRevisionSourceByTokenType(claims.Type)
+	}
+
+	return actor
+}
+
 // GetAfterRegisterNotificationContent returns the notification content displayed each time users register
 func (a *ApiUsingConfig) GetAfterRegisterNotificationContent(userLanguage string, clientLanguage string) string {
 	language := userLanguage
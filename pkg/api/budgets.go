@@ -0,0 +1,640 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// BudgetsApi represents budget api
+type BudgetsApi struct {
+	ApiUsingConfig
+	budgets      *services.BudgetService
+	transactions *services.TransactionService
+	categories   *services.TransactionCategoryService
+	accounts     *services.AccountService
+	users        *services.UserService
+}
+
+// Initialize a budget api singleton instance
+var (
+	Budgets = &BudgetsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		budgets:      services.Budgets,
+		transactions: services.Transactions,
+		categories:   services.TransactionCategories,
+		accounts:     services.Accounts,
+		users:        services.Users,
+	}
+)
+
+// BudgetListHandler returns budget list of current user
+func (a *BudgetsApi) BudgetListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	budgets, err := a.budgets.GetAllBudgetsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetListHandler] failed to get budgets for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	budgetResps := make(models.BudgetInfoResponseSlice, len(budgets))
+
+	for i := 0; i < len(budgets); i++ {
+		budgetResps[i] = budgets[i].ToBudgetInfoResponse()
+	}
+
+	sort.Sort(budgetResps)
+
+	return budgetResps, nil
+}
+
+// BudgetGetHandler returns one specific budget of current user
+func (a *BudgetsApi) BudgetGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetGetReq models.BudgetGetRequest
+	err := c.ShouldBindQuery(&budgetGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	budget, err := a.budgets.GetBudgetByBudgetId(c, uid, budgetGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetGetHandler] failed to get budget \"id:%d\" for user \"uid:%d\", because %s", budgetGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return budget.ToBudgetInfoResponse(), nil
+}
+
+// BudgetCreateHandler saves a new budget by request parameters for current user
+func (a *BudgetsApi) BudgetCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetCreateReq models.BudgetCreateRequest
+	err := c.ShouldBindJSON(&budgetCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+
+	maxOrderId, err := a.budgets.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	budget := a.createNewBudgetModel(uid, &budgetCreateReq, maxOrderId+1)
+	err = a.checkBudgetValid(c, budget)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetCreateHandler] budget is invalid for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.budgets.CreateBudget(c, budget)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetCreateHandler] failed to create budget \"id:%d\" for user \"uid:%d\", because %s", budget.BudgetId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetCreateHandler] user \"uid:%d\" has created a new budget \"id:%d\" successfully", uid, budget.BudgetId)
+
+	return budget.ToBudgetInfoResponse(), nil
+}
+
+// BudgetModifyHandler saves an existed budget by request parameters for current user
+func (a *BudgetsApi) BudgetModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetModifyReq models.BudgetModifyRequest
+	err := c.ShouldBindJSON(&budgetModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	budget, err := a.budgets.GetBudgetByBudgetId(c, uid, budgetModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetModifyHandler] failed to get budget \"id:%d\" for user \"uid:%d\", because %s", budgetModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newBudget := &models.Budget{
+		BudgetId:   budget.BudgetId,
+		Uid:        uid,
+		Name:       budgetModifyReq.Name,
+		Type:       budgetModifyReq.Type,
+		PeriodType: budgetModifyReq.PeriodType,
+		CategoryId: budgetModifyReq.CategoryId,
+		AccountId:  budgetModifyReq.AccountId,
+		Currency:   budgetModifyReq.Currency,
+		Amount:     budgetModifyReq.Amount,
+		Rollover:   budgetModifyReq.Rollover,
+		Hidden:     budgetModifyReq.Hidden,
+		Comment:    budgetModifyReq.Comment,
+	}
+
+	if newBudget.Name == budget.Name &&
+		newBudget.Type == budget.Type &&
+		newBudget.PeriodType == budget.PeriodType &&
+		newBudget.CategoryId == budget.CategoryId &&
+		newBudget.AccountId == budget.AccountId &&
+		newBudget.Currency == budget.Currency &&
+		newBudget.Amount == budget.Amount &&
+		newBudget.Rollover == budget.Rollover &&
+		newBudget.Hidden == budget.Hidden &&
+		newBudget.Comment == budget.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.checkBudgetValid(c, newBudget)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetModifyHandler] budget \"id:%d\" is invalid for user \"uid:%d\", because %s", budgetModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.budgets.ModifyBudget(c, newBudget)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetModifyHandler] failed to update budget \"id:%d\" for user \"uid:%d\", because %s", budgetModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetModifyHandler] user \"uid:%d\" has updated budget \"id:%d\" successfully", uid, budgetModifyReq.Id)
+
+	newBudget.DisplayOrder = budget.DisplayOrder
+
+	return newBudget.ToBudgetInfoResponse(), nil
+}
+
+// BudgetHideHandler hides a budget by request parameters for current user
+func (a *BudgetsApi) BudgetHideHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetHideReq models.BudgetHideRequest
+	err := c.ShouldBindJSON(&budgetHideReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetHideHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.budgets.HideBudget(c, uid, []int64{budgetHideReq.Id}, budgetHideReq.Hidden)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetHideHandler] failed to hide budget \"id:%d\" for user \"uid:%d\", because %s", budgetHideReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetHideHandler] user \"uid:%d\" has hidden budget \"id:%d\"", uid, budgetHideReq.Id)
+	return true, nil
+}
+
+// BudgetMoveHandler moves display order of existed budgets by request parameters for current user
+func (a *BudgetsApi) BudgetMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetMoveReq models.BudgetMoveRequest
+	err := c.ShouldBindJSON(&budgetMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	budgets := make([]*models.Budget, len(budgetMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(budgetMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := budgetMoveReq.NewDisplayOrders[i]
+		budget := &models.Budget{
+			Uid:          uid,
+			BudgetId:     newDisplayOrder.Id,
+			DisplayOrder: newDisplayOrder.DisplayOrder,
+		}
+
+		budgets[i] = budget
+	}
+
+	err = a.budgets.ModifyBudgetDisplayOrders(c, uid, budgets)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetMoveHandler] failed to move budgets for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetMoveHandler] user \"uid:%d\" has moved budgets", uid)
+	return true, nil
+}
+
+// BudgetDeleteHandler deletes an existed budget by request parameters for current user
+func (a *BudgetsApi) BudgetDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetDeleteReq models.BudgetDeleteRequest
+	err := c.ShouldBindJSON(&budgetDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.budgets.DeleteBudget(c, uid, budgetDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetDeleteHandler] failed to delete budget \"id:%d\" for user \"uid:%d\", because %s", budgetDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[budgets.BudgetDeleteHandler] user \"uid:%d\" has deleted budget \"id:%d\"", uid, budgetDeleteReq.Id)
+	return true, nil
+}
+
+// BudgetActualHandler returns the budget amount and actual amount of the budget period which contains the specified time for current user
+func (a *BudgetsApi) BudgetActualHandler(c *core.WebContext) (any, *errs.Error) {
+	var budgetActualReq models.BudgetActualRequest
+	err := c.ShouldBindQuery(&budgetActualReq)
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetActualHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[budgets.BudgetActualHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[budgets.BudgetActualHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	var budgets []*models.Budget
+
+	if budgetActualReq.Id > 0 {
+		budget, err := a.budgets.GetBudgetByBudgetId(c, uid, budgetActualReq.Id)
+
+		if err != nil {
+			log.Errorf(c, "[budgets.BudgetActualHandler] failed to get budget \"id:%d\" for user \"uid:%d\", because %s", budgetActualReq.Id, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		budgets = append(budgets, budget)
+	} else {
+		allBudgets, err := a.budgets.GetAllBudgetsByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[budgets.BudgetActualHandler] failed to get budgets for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		for i := 0; i < len(allBudgets); i++ {
+			if !allBudgets[i].Hidden {
+				budgets = append(budgets, allBudgets[i])
+			}
+		}
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetActualHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	categories, err := a.categories.GetAllCategoriesByUid(c, uid, 0, -1)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetActualHandler] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accountMap := a.accounts.GetAccountMapByList(accounts)
+	categoryMap := a.categories.GetCategoryMapByList(categories)
+
+	referenceTime := time.Now()
+
+	if budgetActualReq.Time > 0 {
+		referenceTime = time.Unix(budgetActualReq.Time, 0)
+	}
+
+	referenceTime = referenceTime.In(clientTimezone)
+
+	budgetIds := make([]int64, 0, len(budgets))
+	allCurrencies := make(map[string]bool)
+
+	for i := 0; i < len(budgets); i++ {
+		budgetIds = append(budgetIds, budgets[i].BudgetId)
+		allCurrencies[budgets[i].Currency] = true
+	}
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+			allCurrencies[accounts[i].Currency] = true
+		}
+	}
+
+	allAmountChanges, err := a.budgets.GetBudgetAmountChangesByBudgetIds(c, uid, budgetIds)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetActualHandler] failed to get budget amount changes for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	// the period start times of each budget, which contain all the past periods if the budget rolls over, and the last one is the start time of next period of current period
+	allBudgetPeriodStartTimes := make([][]time.Time, len(budgets))
+	allPeriodBoundaries := make(map[int64]bool)
+
+	for i := 0; i < len(budgets); i++ {
+		budget := budgets[i]
+		periodStartTimes, err := a.getBudgetPeriodStartTimes(user, budget, referenceTime, clientTimezone)
+
+		if err != nil {
+			log.Errorf(c, "[budgets.BudgetActualHandler] failed to get period of budget \"id:%d\" for user \"uid:%d\", because %s", budget.BudgetId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		allBudgetPeriodStartTimes[i] = periodStartTimes
+
+		for j := 0; j < len(periodStartTimes); j++ {
+			allPeriodBoundaries[periodStartTimes[j].Unix()] = true
+		}
+
+		if budget.Rollover {
+			for _, amountChange := range allAmountChanges[budget.BudgetId] {
+				allCurrencies[amountChange.Currency] = true
+			}
+		}
+	}
+
+	periodBoundaryUnixTimes := make([]int64, 0, len(allPeriodBoundaries))
+
+	for unixTime := range allPeriodBoundaries {
+		periodBoundaryUnixTimes = append(periodBoundaryUnixTimes, unixTime)
+	}
+
+	sort.Slice(periodBoundaryUnixTimes, func(i, j int) bool {
+		return periodBoundaryUnixTimes[i] < periodBoundaryUnixTimes[j]
+	})
+
+	periodTotalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalIncomeAndExpenseInPeriods(c, uid, periodBoundaryUnixTimes)
+
+	if err != nil {
+		log.Errorf(c, "[budgets.BudgetActualHandler] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var historicalExchangeRates *models.HistoricalExchangeRates
+
+	if len(allCurrencies) > 1 && len(periodBoundaryUnixTimes) > 1 {
+		historicalExchangeRates, err = exchangerates.Container.GetHistoricalExchangeRates(c, uid, a.CurrentConfig(), periodBoundaryUnixTimes[0], periodBoundaryUnixTimes[len(periodBoundaryUnixTimes)-1]-1)
+
+		if err != nil {
+			log.Errorf(c, "[budgets.BudgetActualHandler] failed to get historical exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	budgetActualResps := make(models.BudgetActualResponseSlice, 0, len(budgets))
+
+	for i := 0; i < len(budgets); i++ {
+		budget := budgets[i]
+		periodStartTimes := allBudgetPeriodStartTimes[i]
+		startTime := periodStartTimes[len(periodStartTimes)-2]
+		nextStartTime := periodStartTimes[len(periodStartTimes)-1]
+		rolloverAmount := int64(0)
+
+		for j := 0; j < len(periodStartTimes)-2; j++ {
+			periodAmount, periodCurrency := budget.GetAmountAndCurrencyInPeriod(allAmountChanges[budget.BudgetId], periodStartTimes[j+1])
+			periodAmount, exchanged := historicalExchangeRates.GetExchangedAmountOnDate(periodAmount, periodCurrency, budget.Currency, models.GetExchangeRateSnapshotDate(periodStartTimes[j].Unix()))
+
+			if !exchanged {
+				log.Warnf(c, "[budgets.BudgetActualHandler] cannot exchange the amount of budget \"id:%d\" from \"%s\" to \"%s\" for user \"uid:%d\"", budget.BudgetId, periodCurrency, budget.Currency, uid)
+				return nil, errs.ErrTransactionAmountCannotBeExchanged
+			}
+
+			actualAmount, err := a.getBudgetActualAmount(budget, periodTotalAmounts, periodBoundaryUnixTimes, periodStartTimes[j], periodStartTimes[j+1], accountMap, categoryMap, historicalExchangeRates)
+
+			if err != nil {
+				log.Warnf(c, "[budgets.BudgetActualHandler] failed to get rollover amount of budget \"id:%d\" for user \"uid:%d\", because %s", budget.BudgetId, uid, err.Error())
+				return nil, errs.Or(err, errs.ErrOperationFailed)
+			}
+
+			rolloverAmount = periodAmount + rolloverAmount - actualAmount
+
+			// only the unspent amount would be rolled over to the next period
+			if rolloverAmount < 0 {
+				rolloverAmount = 0
+			}
+		}
+
+		actualAmount, err := a.getBudgetActualAmount(budget, periodTotalAmounts, periodBoundaryUnixTimes, startTime, nextStartTime, accountMap, categoryMap, historicalExchangeRates)
+
+		if err != nil {
+			log.Warnf(c, "[budgets.BudgetActualHandler] failed to get actual amount of budget \"id:%d\" for user \"uid:%d\", because %s", budget.BudgetId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		budgetActualResps = append(budgetActualResps, &models.BudgetActualResponse{
+			Id:              budget.BudgetId,
+			Name:            budget.Name,
+			Type:            budget.Type,
+			PeriodType:      budget.PeriodType,
+			CategoryId:      budget.CategoryId,
+			AccountId:       budget.AccountId,
+			Currency:        budget.Currency,
+			StartTime:       startTime.Unix(),
+			EndTime:         nextStartTime.Unix() - 1,
+			BudgetAmount:    budget.Amount,
+			RolloverAmount:  rolloverAmount,
+			AvailableAmount: budget.Amount + rolloverAmount,
+			ActualAmount:    actualAmount,
+			RemainingAmount: budget.Amount + rolloverAmount - actualAmount,
+			DisplayOrder:    budget.DisplayOrder,
+		})
+	}
+
+	sort.Sort(budgetActualResps)
+
+	return budgetActualResps, nil
+}
+
+func (a *BudgetsApi) checkBudgetValid(c *core.WebContext, budget *models.Budget) error {
+	if budget.PeriodType < models.BUDGET_PERIOD_TYPE_WEEKLY || budget.PeriodType > models.BUDGET_PERIOD_TYPE_FISCAL_YEAR {
+		return errs.ErrBudgetPeriodTypeInvalid
+	}
+
+	if budget.Type == models.BUDGET_TYPE_TOTAL {
+		if budget.CategoryId != 0 {
+			return errs.ErrBudgetCategoryIdInvalid
+		}
+
+		if budget.AccountId != 0 {
+			return errs.ErrBudgetAccountIdInvalid
+		}
+	} else if budget.Type == models.BUDGET_TYPE_CATEGORY {
+		if budget.CategoryId <= 0 {
+			return errs.ErrBudgetCategoryIdInvalid
+		}
+
+		if budget.AccountId != 0 {
+			return errs.ErrBudgetAccountIdInvalid
+		}
+
+		category, err := a.categories.GetCategoryByCategoryId(c, budget.Uid, budget.CategoryId)
+
+		if err != nil {
+			return err
+		}
+
+		if category.Type != models.CATEGORY_TYPE_INCOME && category.Type != models.CATEGORY_TYPE_EXPENSE {
+			return errs.ErrBudgetCategoryTypeInvalid
+		}
+	} else if budget.Type == models.BUDGET_TYPE_ACCOUNT {
+		if budget.AccountId <= 0 {
+			return errs.ErrBudgetAccountIdInvalid
+		}
+
+		if budget.CategoryId != 0 {
+			return errs.ErrBudgetCategoryIdInvalid
+		}
+
+		account, err := a.accounts.GetAccountByAccountId(c, budget.Uid, budget.AccountId)
+
+		if err != nil {
+			return err
+		}
+
+		if account.Type == models.ACCOUNT_TYPE_SINGLE_ACCOUNT && account.Currency != budget.Currency {
+			return errs.ErrBudgetCurrencyNotMatchAccount
+		}
+	} else {
+		return errs.ErrBudgetTypeInvalid
+	}
+
+	return nil
+}
+
+func (a *BudgetsApi) getBudgetPeriodStartTimes(user *models.User, budget *models.Budget, referenceTime time.Time, clientTimezone *time.Location) ([]time.Time, error) {
+	currentStartTime, currentNextStartTime, err := budget.GetPeriodTimeRange(referenceTime, user.FirstDayOfWeek, user.FiscalYearStart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	periodStartTimes := make([]time.Time, 0, 2)
+
+	if budget.Rollover {
+		startTime, nextStartTime, err := budget.GetPeriodTimeRange(time.Unix(budget.CreatedUnixTime, 0).In(clientTimezone), user.FirstDayOfWeek, user.FiscalYearStart)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for startTime.Before(currentStartTime) {
+			periodStartTimes = append(periodStartTimes, startTime)
+			startTime, nextStartTime, err = budget.GetPeriodTimeRange(nextStartTime, user.FirstDayOfWeek, user.FiscalYearStart)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	periodStartTimes = append(periodStartTimes, currentStartTime, currentNextStartTime)
+
+	return periodStartTimes, nil
+}
+
+func (a *BudgetsApi) getBudgetActualAmount(budget *models.Budget, periodTotalAmounts [][]*models.Transaction, periodBoundaryUnixTimes []int64, startTime time.Time, nextStartTime time.Time, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, historicalExchangeRates *models.HistoricalExchangeRates) (int64, error) {
+	actualAmount := int64(0)
+
+	startIndex := sort.Search(len(periodBoundaryUnixTimes), func(i int) bool {
+		return periodBoundaryUnixTimes[i] >= startTime.Unix()
+	})
+
+	for i := startIndex; i < len(periodTotalAmounts) && periodBoundaryUnixTimes[i] < nextStartTime.Unix(); i++ {
+		totalAmounts := periodTotalAmounts[i]
+
+		for j := 0; j < len(totalAmounts); j++ {
+			totalAmountItem := totalAmounts[j]
+
+			if totalAmountItem.Type != models.TRANSACTION_DB_TYPE_INCOME && totalAmountItem.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+				continue
+			}
+
+			account, exists := accountMap[totalAmountItem.AccountId]
+
+			if !exists {
+				continue
+			}
+
+			if budget.Type == models.BUDGET_TYPE_TOTAL {
+				if totalAmountItem.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+					continue
+				}
+			} else if budget.Type == models.BUDGET_TYPE_CATEGORY {
+				category, exists := categoryMap[totalAmountItem.CategoryId]
+
+				if !exists || (category.CategoryId != budget.CategoryId && category.ParentCategoryId != budget.CategoryId) {
+					continue
+				}
+			} else if budget.Type == models.BUDGET_TYPE_ACCOUNT {
+				if totalAmountItem.Type != models.TRANSACTION_DB_TYPE_EXPENSE || (account.AccountId != budget.AccountId && account.ParentAccountId != budget.AccountId) {
+					continue
+				}
+			} else {
+				continue
+			}
+
+			amount, exchanged := historicalExchangeRates.GetExchangedAmount(totalAmountItem.Amount, account.Currency, budget.Currency, periodBoundaryUnixTimes[i])
+
+			if !exchanged {
+				return 0, errs.ErrTransactionAmountCannotBeExchanged
+			}
+
+			actualAmount += amount
+		}
+	}
+
+	return actualAmount, nil
+}
+
+func (a *BudgetsApi) createNewBudgetModel(uid int64, budgetCreateReq *models.BudgetCreateRequest, order int32) *models.Budget {
+	return &models.Budget{
+		Uid:          uid,
+		Name:         budgetCreateReq.Name,
+		Type:         budgetCreateReq.Type,
+		PeriodType:   budgetCreateReq.PeriodType,
+		CategoryId:   budgetCreateReq.CategoryId,
+		AccountId:    budgetCreateReq.AccountId,
+		Currency:     budgetCreateReq.Currency,
+		Amount:       budgetCreateReq.Amount,
+		Rollover:     budgetCreateReq.Rollover,
+		Comment:      budgetCreateReq.Comment,
+		DisplayOrder: order,
+	}
+}
@@ -33,8 +33,17 @@ type DataManagementsApi struct {
 	itemGroups              *services.TransactionItemGroupService
 	pictures                *services.TransactionPictureService
 	templates               *services.TransactionTemplateService
+	templateOccurrences     *services.TransactionTemplateOccurrenceService
+	transactionRules        *services.TransactionRuleService
+	payees                  *services.PayeeService
 	userCustomExchangeRates *services.UserCustomExchangeRatesService
 	insightsExploreres      *services.InsightsExplorerService
+	savedSearches           *services.SavedSearchService
+	budgets                 *services.BudgetService
+	securities              *services.SecurityService
+	investmentTransactions  *services.InvestmentTransactionService
+	loans                   *services.LoanService
+	reconciliationSessions  *services.ReconciliationSessionService
 }
 
 // Initialize a data management api singleton instance
@@ -54,8 +63,17 @@ var (
 		itemGroups:              services.TransactionItemGroups,
 		pictures:                services.TransactionPictures,
 		templates:               services.TransactionTemplates,
+		templateOccurrences:     services.TransactionTemplateOccurrences,
+		transactionRules:        services.TransactionRules,
+		payees:                  services.Payees,
 		userCustomExchangeRates: services.UserCustomExchangeRates,
 		insightsExploreres:      services.InsightsExplorers,
+		savedSearches:           services.SavedSearches,
+		budgets:                 services.Budgets,
+		securities:              services.Securities,
+		investmentTransactions:  services.InvestmentTransactions,
+		loans:                   services.Loans,
+		reconciliationSessions:  services.ReconciliationSessions,
 	}
 )
 
@@ -179,6 +197,27 @@ func (a *DataManagementsApi) ClearAllDataHandler(c *core.WebContext) (any, *errs
 		return nil, errs.ErrNotPermittedToPerformThisAction
 	}
 
+	err = a.templateOccurrences.DeleteAllOccurrences(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all transaction template occurrences, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.transactionRules.DeleteAllRules(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all transaction rules, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.payees.DeleteAllPayees(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all payees, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	err = a.templates.DeleteAllTemplates(c, uid)
 
 	if err != nil {
@@ -249,6 +288,48 @@ func (a *DataManagementsApi) ClearAllDataHandler(c *core.WebContext) (any, *errs
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	err = a.savedSearches.DeleteAllSavedSearches(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all saved searches, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.budgets.DeleteAllBudgets(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all budgets, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.investmentTransactions.DeleteAllInvestmentTransactions(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all investment transactions, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.securities.DeleteAllSecurities(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all securities, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.loans.DeleteAllLoans(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all loans, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.reconciliationSessions.DeleteAllReconciliationSessions(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.ClearAllDataHandler] failed to delete all reconciliation sessions, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	log.Infof(c, "[data_managements.ClearAllDataHandler] user \"uid:%d\" has cleared all data", uid)
 	return true, nil
 }
@@ -337,7 +418,7 @@ func (a *DataManagementsApi) ClearAllTransactionsByAccountHandler(c *core.WebCon
 		return nil, errs.ErrCannotDeleteTransactionInParentAccount
 	}
 
-	err = a.transactions.DeleteAllTransactionsOfAccount(c, uid, account.AccountId, pageCountForClearTransactions)
+	err = a.transactions.DeleteAllTransactionsOfAccount(c, uid, account.AccountId, pageCountForClearTransactions, a.GetTransactionRevisionActor(c))
 
 	if err != nil {
 		log.Errorf(c, "[data_managements.ClearAllTransactionsByAccountHandler] failed to delete all transactions in account \"id:%d\", because %s", account.AccountId, err.Error())
@@ -429,6 +510,13 @@ func (a *DataManagementsApi) getExportedFileContent(c *core.WebContext, fileType
 		return nil, "", errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(exportTransactionDataReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[data_managements.getExportedFileContent] get payee ids error, because %s", err.Error())
+		return nil, "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	noTags := exportTransactionDataReq.TagFilter == models.TransactionNoTagFilterValue
 	var tagFilters []*models.TransactionTagFilter
 
@@ -452,20 +540,51 @@ func (a *DataManagementsApi) getExportedFileContent(c *core.WebContext, fileType
 		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(exportTransactionDataReq.MinTime)
 	}
 
-	allTransactions, err := a.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, exportTransactionDataReq.Type, allCategoryIds, allAccountIds, tagFilters, noTags, exportTransactionDataReq.AmountFilter, exportTransactionDataReq.Keyword, pageCountForDataExport, true)
+	searchConditions := &models.TransactionSearchConditions{
+		Type:               exportTransactionDataReq.Type,
+		MaxTransactionTime: maxTransactionTime,
+		MinTransactionTime: minTransactionTime,
+		CategoryIds:        allCategoryIds,
+		AccountIds:         allAccountIds,
+		PayeeIds:           payeeIds,
+		TagFilters:         tagFilters,
+		NoTags:             noTags,
+		AmountFilter:       exportTransactionDataReq.AmountFilter,
+		Keyword:            exportTransactionDataReq.Keyword,
+	}
+
+	if exportTransactionDataReq.SavedSearchId > 0 || exportTransactionDataReq.SearchQuery != "" {
+		querySearchConditions, err := a.transactions.GetTransactionSearchConditions(c, uid, exportTransactionDataReq.SavedSearchId, exportTransactionDataReq.SearchQuery, clientTimezone)
+
+		if err != nil {
+			log.Warnf(c, "[data_managements.getExportedFileContent] parse transaction search query error, because %s", err.Error())
+			return nil, "", errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
+	allTransactions, err := a.transactions.GetAllSpecifiedTransactions(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword, pageCountForDataExport, true)
 
 	if err != nil {
 		log.Errorf(c, "[data_managements.getExportedFileContent] failed to all transactions user \"uid:%d\", because %s", uid, err.Error())
 		return nil, "", errs.ErrOperationFailed
 	}
 
+	allSplits, err := a.transactions.GetAllSplitsMapOfAllTransactions(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[data_managements.getExportedFileContent] failed to get all transaction splits for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, "", errs.ErrOperationFailed
+	}
+
 	dataExporter := converters.GetTransactionDataExporter(fileType)
 
 	if dataExporter == nil {
 		return nil, "", errs.ErrNotImplemented
 	}
 
-	result, err := dataExporter.ToExportedContent(c, uid, allTransactions, accountMap, categoryMap, tagMap, tagIndexes)
+	result, err := dataExporter.ToExportedContent(c, uid, allTransactions, accountMap, categoryMap, tagMap, tagIndexes, allSplits)
 
 	if err != nil {
 		log.Errorf(c, "[data_managements.getExportedFileContent] failed to get exported data for \"uid:%d\", because %s", uid, err.Error())
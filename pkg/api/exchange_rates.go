@@ -39,6 +39,32 @@ func (a *ExchangeRatesApi) LatestExchangeRateHandler(c *core.WebContext) (any, *
 	return exchangeRateResponse, nil
 }
 
+// HistoricalExchangeRateHandler returns exchange rate data effective on the specified date
+func (a *ExchangeRatesApi) HistoricalExchangeRateHandler(c *core.WebContext) (any, *errs.Error) {
+	var historicalExchangeRateReq models.HistoricalExchangeRateRequest
+	err := c.ShouldBindQuery(&historicalExchangeRateReq)
+
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	date, err := historicalExchangeRateReq.GetNumericDate()
+
+	if err != nil {
+		log.Warnf(c, "[exchange_rates.HistoricalExchangeRateHandler] parse date \"%s\" failed, because %s", historicalExchangeRateReq.Date, err.Error())
+		return nil, errs.Or(err, errs.ErrExchangeRateDateInvalid)
+	}
+
+	exchangeRateResponse, err := exchangerates.Container.GetExchangeRatesOnDate(c, c.GetCurrentUid(), a.CurrentConfig(), date)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return exchangeRateResponse, nil
+}
+
 // UserCustomExchangeRateUpdateHandler updates user custom exchange rates data by request parameters for current user
 func (a *ExchangeRatesApi) UserCustomExchangeRateUpdateHandler(c *core.WebContext) (any, *errs.Error) {
 	var customExchangeRateUpdateReq models.UserCustomExchangeRateUpdateRequest
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// ForecastsApi represents forecast api
+type ForecastsApi struct {
+	ApiUsingConfig
+	accounts    *services.AccountService
+	templates   *services.TransactionTemplateService
+	occurrences *services.TransactionTemplateOccurrenceService
+	users       *services.UserService
+}
+
+// Initialize a forecast api singleton instance
+var (
+	Forecasts = &ForecastsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		accounts:    services.Accounts,
+		templates:   services.TransactionTemplates,
+		occurrences: services.TransactionTemplateOccurrences,
+		users:       services.Users,
+	}
+)
+
+// CashFlowForecastHandler returns the projected daily balances of all accounts of current user by applying upcoming scheduled transactions
+func (a *ForecastsApi) CashFlowForecastHandler(c *core.WebContext) (any, *errs.Error) {
+	var forecastReq models.CashFlowForecastRequest
+	err := c.ShouldBindQuery(&forecastReq)
+
+	if err != nil {
+		log.Warnf(c, "[forecasts.CashFlowForecastHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[forecasts.CashFlowForecastHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[forecasts.CashFlowForecastHandler] failed to get user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[forecasts.CashFlowForecastHandler] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	var templates []*models.TransactionTemplate
+	var templateOccurrences []*models.TransactionTemplateOccurrence
+
+	// scheduled transactions would not be created when it is disabled, so the forecast only contains current balances
+	if a.CurrentConfig().EnableScheduledTransaction {
+		templates, err = a.templates.GetAllTemplatesByUid(c, uid, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE)
+
+		if err != nil {
+			log.Errorf(c, "[forecasts.CashFlowForecastHandler] failed to get all scheduled templates for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		templateOccurrences, err = a.occurrences.GetAllUnprocessedOccurrencesByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[forecasts.CashFlowForecastHandler] failed to get all scheduled template occurrences for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	exchangeRates, err := exchangerates.Container.GetLatestExchangeRates(c, uid, a.CurrentConfig())
+
+	if err != nil {
+		log.Warnf(c, "[forecasts.CashFlowForecastHandler] failed to get latest exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		exchangeRates = nil
+	}
+
+	startUnixTime := time.Now().Unix()
+	endUnixTime := models.GetCashFlowForecastEndTime(startUnixTime, forecastReq.GetHorizonDays(), clientTimezone)
+	occurrences := models.GetScheduledTransactionOccurrences(templates, startUnixTime, endUnixTime)
+	occurrences = models.ApplyTransactionTemplateOccurrences(occurrences, templateOccurrences, templates, startUnixTime, endUnixTime)
+
+	return models.GetCashFlowForecast(accounts, occurrences, startUnixTime, endUnixTime, clientTimezone, user.DefaultCurrency, exchangeRates), nil
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// InvestmentTransactionsApi represents investment transaction api
+type InvestmentTransactionsApi struct {
+	investmentTransactions *services.InvestmentTransactionService
+	securities             *services.SecurityService
+	accounts               *services.AccountService
+}
+
+// Initialize an investment transaction api singleton instance
+var (
+	InvestmentTransactions = &InvestmentTransactionsApi{
+		investmentTransactions: services.InvestmentTransactions,
+		securities:             services.Securities,
+		accounts:               services.Accounts,
+	}
+)
+
+// InvestmentTransactionListHandler returns investment transaction list of current user
+func (a *InvestmentTransactionsApi) InvestmentTransactionListHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionListReq models.InvestmentTransactionListRequest
+	err := c.ShouldBindQuery(&transactionListReq)
+
+	if err != nil {
+		log.Warnf(c, "[investment_transactions.InvestmentTransactionListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	transactions, err := a.investmentTransactions.GetInvestmentTransactions(c, uid, transactionListReq.AccountId, transactionListReq.SecurityId, 0)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionListHandler] failed to get investment transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionResps := make([]*models.InvestmentTransactionInfoResponse, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transactionResps[len(transactions)-1-i] = transactions[i].ToInvestmentTransactionInfoResponse()
+	}
+
+	return transactionResps, nil
+}
+
+// InvestmentTransactionCreateHandler saves a new investment transaction by request parameters for current user
+func (a *InvestmentTransactionsApi) InvestmentTransactionCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionCreateReq models.InvestmentTransactionCreateRequest
+	err := c.ShouldBindJSON(&transactionCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[investment_transactions.InvestmentTransactionCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	security, err := a.securities.GetSecurityBySecurityId(c, uid, transactionCreateReq.SecurityId)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionCreateHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", transactionCreateReq.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.checkInvestmentAccount(c, uid, transactionCreateReq.AccountId, security)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transaction := &models.InvestmentTransaction{
+		Uid:             uid,
+		AccountId:       transactionCreateReq.AccountId,
+		SecurityId:      transactionCreateReq.SecurityId,
+		TransactionTime: transactionCreateReq.Time,
+		Type:            transactionCreateReq.Type,
+		Quantity:        transactionCreateReq.Quantity,
+		Price:           transactionCreateReq.Price,
+		Amount:          transactionCreateReq.Amount,
+		Fee:             transactionCreateReq.Fee,
+		Comment:         transactionCreateReq.Comment,
+	}
+
+	err = a.investmentTransactions.CreateInvestmentTransaction(c, transaction, security.CostBasisMethod)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionCreateHandler] failed to create investment transaction for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[investment_transactions.InvestmentTransactionCreateHandler] user \"uid:%d\" has created a new investment transaction \"id:%d\" successfully", uid, transaction.InvestmentTransactionId)
+
+	return transaction.ToInvestmentTransactionInfoResponse(), nil
+}
+
+// InvestmentTransactionModifyHandler saves an existed investment transaction by request parameters for current user
+func (a *InvestmentTransactionsApi) InvestmentTransactionModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionModifyReq models.InvestmentTransactionModifyRequest
+	err := c.ShouldBindJSON(&transactionModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[investment_transactions.InvestmentTransactionModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	transaction, err := a.investmentTransactions.GetInvestmentTransactionById(c, uid, transactionModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionModifyHandler] failed to get investment transaction \"id:%d\" for user \"uid:%d\", because %s", transactionModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	security, err := a.securities.GetSecurityBySecurityId(c, uid, transaction.SecurityId)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionModifyHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", transaction.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newTransaction := &models.InvestmentTransaction{
+		InvestmentTransactionId: transaction.InvestmentTransactionId,
+		Uid:                     uid,
+		AccountId:               transaction.AccountId,
+		SecurityId:              transaction.SecurityId,
+		TransactionTime:         transactionModifyReq.Time,
+		Type:                    transaction.Type,
+		Quantity:                transactionModifyReq.Quantity,
+		Price:                   transactionModifyReq.Price,
+		Amount:                  transactionModifyReq.Amount,
+		Fee:                     transactionModifyReq.Fee,
+		Comment:                 transactionModifyReq.Comment,
+	}
+
+	if newTransaction.TransactionTime == transaction.TransactionTime &&
+		newTransaction.Quantity == transaction.Quantity &&
+		newTransaction.Price == transaction.Price &&
+		newTransaction.Amount == transaction.Amount &&
+		newTransaction.Fee == transaction.Fee &&
+		newTransaction.Comment == transaction.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.investmentTransactions.ModifyInvestmentTransaction(c, newTransaction, security.CostBasisMethod)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionModifyHandler] failed to update investment transaction \"id:%d\" for user \"uid:%d\", because %s", transactionModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[investment_transactions.InvestmentTransactionModifyHandler] user \"uid:%d\" has updated investment transaction \"id:%d\" successfully", uid, transactionModifyReq.Id)
+
+	return newTransaction.ToInvestmentTransactionInfoResponse(), nil
+}
+
+// InvestmentTransactionDeleteHandler deletes an existed investment transaction by request parameters for current user
+func (a *InvestmentTransactionsApi) InvestmentTransactionDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionDeleteReq models.InvestmentTransactionDeleteRequest
+	err := c.ShouldBindJSON(&transactionDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[investment_transactions.InvestmentTransactionDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	transaction, err := a.investmentTransactions.GetInvestmentTransactionById(c, uid, transactionDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionDeleteHandler] failed to get investment transaction \"id:%d\" for user \"uid:%d\", because %s", transactionDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	security, err := a.securities.GetSecurityBySecurityId(c, uid, transaction.SecurityId)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionDeleteHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", transaction.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.investmentTransactions.DeleteInvestmentTransaction(c, transaction, security.CostBasisMethod)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentTransactionDeleteHandler] failed to delete investment transaction \"id:%d\" for user \"uid:%d\", because %s", transactionDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[investment_transactions.InvestmentTransactionDeleteHandler] user \"uid:%d\" has deleted investment transaction \"id:%d\"", uid, transactionDeleteReq.Id)
+	return true, nil
+}
+
+// InvestmentHoldingListHandler returns the holdings, cost basis, market value and gains of all securities in investment accounts of current user
+func (a *InvestmentTransactionsApi) InvestmentHoldingListHandler(c *core.WebContext) (any, *errs.Error) {
+	var holdingListReq models.InvestmentHoldingListRequest
+	err := c.ShouldBindQuery(&holdingListReq)
+
+	if err != nil {
+		log.Warnf(c, "[investment_transactions.InvestmentHoldingListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	transactions, err := a.investmentTransactions.GetInvestmentTransactions(c, uid, holdingListReq.AccountId, 0, 0)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentHoldingListHandler] failed to get investment transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	securities, err := a.securities.GetAllSecuritiesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentHoldingListHandler] failed to get securities for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	securityMap := make(map[int64]*models.Security, len(securities))
+	securityIds := make([]int64, len(securities))
+
+	for i := 0; i < len(securities); i++ {
+		securityMap[securities[i].SecurityId] = securities[i]
+		securityIds[i] = securities[i].SecurityId
+	}
+
+	holdings, err := a.investmentTransactions.CalculateInvestmentHoldings(transactions, securityMap)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentHoldingListHandler] failed to calculate investment holdings for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	latestPriceMap, err := a.securities.GetLatestSecurityPrices(c, uid, securityIds, time.Now().Unix())
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.InvestmentHoldingListHandler] failed to get latest security prices for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	allHoldings := holdings.GetAllHoldings()
+	holdingResps := make([]*models.InvestmentHoldingInfoResponse, len(allHoldings))
+
+	for i := 0; i < len(allHoldings); i++ {
+		holding := allHoldings[i]
+		holdingResps[i] = holding.ToInvestmentHoldingInfoResponse(securityMap[holding.SecurityId].Currency, latestPriceMap[holding.SecurityId])
+	}
+
+	return holdingResps, nil
+}
+
+func (a *InvestmentTransactionsApi) checkInvestmentAccount(c *core.WebContext, uid int64, accountId int64, security *models.Security) error {
+	account, err := a.accounts.GetAccountByAccountId(c, uid, accountId)
+
+	if err != nil {
+		log.Errorf(c, "[investment_transactions.checkInvestmentAccount] failed to get account \"id:%d\" for user \"uid:%d\", because %s", accountId, uid, err.Error())
+		return err
+	}
+
+	if account.Category != models.ACCOUNT_CATEGORY_INVESTMENT || account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+		log.Warnf(c, "[investment_transactions.checkInvestmentAccount] account \"id:%d\" of user \"uid:%d\" is not a single investment account", accountId, uid)
+		return errs.ErrInvestmentAccountInvalid
+	}
+
+	if account.Currency != security.Currency {
+		log.Warnf(c, "[investment_transactions.checkInvestmentAccount] currency of account \"id:%d\" does not match security \"id:%d\" of user \"uid:%d\"", accountId, security.SecurityId, uid)
+		return errs.ErrInvestmentAccountCurrencyNotMatchSecurity
+	}
+
+	return nil
+}
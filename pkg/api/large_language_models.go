@@ -2,6 +2,7 @@ package api
 
 import (
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -173,6 +174,20 @@ func (a *LargeLanguageModelsApi) RecognizeReceiptImageByOCRHandler(c *core.WebCo
 		project := strings.TrimSpace(raw.Project)
 		if project != "" {
 			result.ItemNames = []string{project}
+
+			quantity := strings.TrimSpace(raw.Quantity)
+			unitPrice := strings.TrimSpace(raw.UnitPrice)
+
+			if quantity != "" || unitPrice != "" {
+				result.ItemDetails = []*models.RecognizedReceiptImageItemDetail{
+					{
+						ItemName:  project,
+						Quantity:  quantity,
+						Unit:      strings.TrimSpace(raw.Unit),
+						UnitPrice: unitPrice,
+					},
+				}
+			}
 		}
 
 		parsedList = append(parsedList, result)
@@ -234,7 +249,7 @@ func (a *LargeLanguageModelsApi) RecognizeReceiptImageByOCRHandler(c *core.WebCo
 
 	transactions := make([]models.RecognizedReceiptImageResponse, 0, len(parsedList))
 	for _, one := range parsedList {
-		resp, parseErr := a.parseRecognizedReceiptImageResponse(c, uid, clientTimezone, one, accountMap, expenseCategoryMap, incomeCategoryMap, transferCategoryMap, tagMap, itemNameMap)
+		resp, parseErr := a.parseRecognizedReceiptImageResponse(c, uid, user.DefaultCurrency, clientTimezone, one, accountMap, expenseCategoryMap, incomeCategoryMap, transferCategoryMap, tagMap, itemNameMap)
 		if parseErr != nil {
 			continue
 		}
@@ -247,7 +262,7 @@ func (a *LargeLanguageModelsApi) RecognizeReceiptImageByOCRHandler(c *core.WebCo
 	return &models.RecognizedReceiptImageListResponse{Transactions: transactions}, nil
 }
 
-func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.WebContext, uid int64, clientTimezone *time.Location, recognizedResult *models.RecognizedReceiptImageResult, accountMap map[string]*models.Account, expenseCategoryMap map[string]*models.TransactionCategory, incomeCategoryMap map[string]*models.TransactionCategory, transferCategoryMap map[string]*models.TransactionCategory, tagMap map[string]*models.TransactionTag, itemNameMap map[string]*models.TransactionItem) (*models.RecognizedReceiptImageResponse, *errs.Error) {
+func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.WebContext, uid int64, defaultCurrency string, clientTimezone *time.Location, recognizedResult *models.RecognizedReceiptImageResult, accountMap map[string]*models.Account, expenseCategoryMap map[string]*models.TransactionCategory, incomeCategoryMap map[string]*models.TransactionCategory, transferCategoryMap map[string]*models.TransactionCategory, tagMap map[string]*models.TransactionTag, itemNameMap map[string]*models.TransactionItem) (*models.RecognizedReceiptImageResponse, *errs.Error) {
 	recognizedReceiptImageResponse := &models.RecognizedReceiptImageResponse{
 		Type: models.TRANSACTION_TYPE_EXPENSE,
 	}
@@ -305,8 +320,29 @@ func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.Web
 		}
 	}
 
+	sourceCurrency := defaultCurrency
+	destinationCurrency := defaultCurrency
+
+	if len(recognizedResult.AccountName) > 0 {
+		account, exists := accountMap[recognizedResult.AccountName]
+
+		if exists {
+			recognizedReceiptImageResponse.SourceAccountId = account.AccountId
+			sourceCurrency = account.Currency
+		}
+	}
+
+	if len(recognizedResult.DestinationAccountName) > 0 {
+		account, exists := accountMap[recognizedResult.DestinationAccountName]
+
+		if exists {
+			recognizedReceiptImageResponse.DestinationAccountId = account.AccountId
+			destinationCurrency = account.Currency
+		}
+	}
+
 	if len(recognizedResult.Amount) > 0 {
-		amount, err := utils.ParseAmount(recognizedResult.Amount)
+		amount, err := utils.ParseCurrencyAmount(recognizedResult.Amount, sourceCurrency)
 
 		if err != nil {
 			log.Errorf(c, "[large_language_models.parseRecognizedReceiptImageResponse] recoginzed amount \"%s\" is invalid", recognizedResult.Amount)
@@ -316,7 +352,7 @@ func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.Web
 		recognizedReceiptImageResponse.SourceAmount = amount
 
 		if recognizedReceiptImageResponse.Type == models.TRANSACTION_TYPE_TRANSFER && len(recognizedResult.DestinationAmount) > 0 {
-			destinationAmount, err := utils.ParseAmount(recognizedResult.DestinationAmount)
+			destinationAmount, err := utils.ParseCurrencyAmount(recognizedResult.DestinationAmount, destinationCurrency)
 
 			if err != nil {
 				log.Errorf(c, "[large_language_models.parseRecognizedReceiptImageResponse] recoginzed destination amount \"%s\" is invalid", recognizedResult.DestinationAmount)
@@ -327,22 +363,6 @@ func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.Web
 		}
 	}
 
-	if len(recognizedResult.AccountName) > 0 {
-		account, exists := accountMap[recognizedResult.AccountName]
-
-		if exists {
-			recognizedReceiptImageResponse.SourceAccountId = account.AccountId
-		}
-	}
-
-	if len(recognizedResult.DestinationAccountName) > 0 {
-		account, exists := accountMap[recognizedResult.DestinationAccountName]
-
-		if exists {
-			recognizedReceiptImageResponse.DestinationAccountId = account.AccountId
-		}
-	}
-
 	if len(recognizedResult.TagNames) > 0 {
 		tagIds := make([]string, 0, len(recognizedResult.TagNames))
 
@@ -372,9 +392,95 @@ func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageResponse(c *core.Web
 		recognizedReceiptImageResponse.ItemIds = itemIds
 	}
 
+	if len(recognizedResult.ItemDetails) > 0 {
+		itemDetails := make([]*models.TransactionItemDetailInfoResponse, 0, len(recognizedResult.ItemDetails))
+
+		for i := 0; i < len(recognizedResult.ItemDetails); i++ {
+			itemDetail, err := a.parseRecognizedReceiptImageItemDetail(recognizedResult.ItemDetails[i], itemNameMap, sourceCurrency)
+
+			if err != nil {
+				log.Warnf(c, "[large_language_models.parseRecognizedReceiptImageResponse] recoginzed item detail of \"%s\" is invalid, because %s", recognizedResult.ItemDetails[i].ItemName, err.Error())
+				continue
+			}
+
+			if itemDetail == nil {
+				continue
+			}
+
+			if !slices.Contains(recognizedReceiptImageResponse.ItemIds, utils.Int64ToString(itemDetail.ItemId)) {
+				recognizedReceiptImageResponse.ItemIds = append(recognizedReceiptImageResponse.ItemIds, utils.Int64ToString(itemDetail.ItemId))
+			}
+
+			itemDetails = append(itemDetails, itemDetail)
+		}
+
+		if len(itemDetails) > 0 {
+			recognizedReceiptImageResponse.ItemDetails = itemDetails
+		}
+	}
+
 	return recognizedReceiptImageResponse, nil
 }
 
+func (a *LargeLanguageModelsApi) parseRecognizedReceiptImageItemDetail(recognizedItemDetail *models.RecognizedReceiptImageItemDetail, itemNameMap map[string]*models.TransactionItem, currency string) (*models.TransactionItemDetailInfoResponse, error) {
+	if recognizedItemDetail == nil {
+		return nil, nil
+	}
+
+	item, exists := itemNameMap[recognizedItemDetail.ItemName]
+
+	if !exists {
+		return nil, nil
+	}
+
+	itemDetailReq := &models.TransactionItemDetailRequest{
+		ItemId: item.ItemId,
+		Unit:   recognizedItemDetail.Unit,
+	}
+
+	if len(recognizedItemDetail.Quantity) > 0 {
+		quantity, err := utils.StringToFloat64(recognizedItemDetail.Quantity)
+
+		if err != nil {
+			return nil, err
+		}
+
+		itemDetailReq.Quantity = quantity
+	}
+
+	if len(recognizedItemDetail.UnitPrice) > 0 {
+		unitPrice, err := utils.ParseAmountWithDecimalPlaces(recognizedItemDetail.UnitPrice, utils.GetCurrencyAmountDecimalPlaces(currency))
+
+		if err != nil {
+			return nil, err
+		}
+
+		itemDetailReq.UnitPrice = unitPrice
+	}
+
+	if len(recognizedItemDetail.Amount) > 0 {
+		amount, err := utils.ParseCurrencyAmount(recognizedItemDetail.Amount, currency)
+
+		if err != nil {
+			return nil, err
+		}
+
+		itemDetailReq.Amount = amount
+	}
+
+	itemIndex := &models.TransactionItemIndex{
+		ItemId: item.ItemId,
+	}
+
+	itemIndex.FillDetail(itemDetailReq)
+
+	if !itemIndex.HasDetail() {
+		return nil, nil
+	}
+
+	return itemIndex.ToTransactionItemDetailInfoResponse(), nil
+}
+
 func (a *LargeLanguageModelsApi) getLongDateTime(dateTime string) string {
 	if utils.IsValidLongDateTimeFormat(dateTime) {
 		return dateTime
@@ -0,0 +1,394 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// LedgersApi represents shared ledger api
+type LedgersApi struct {
+	ledgers *services.LedgerService
+	users   *services.UserService
+}
+
+// Initialize a shared ledger api singleton instance
+var (
+	Ledgers = &LedgersApi{
+		ledgers: services.Ledgers,
+		users:   services.Users,
+	}
+)
+
+// LedgerListHandler returns the shared ledger owned by current user and all shared ledgers current user joined or is invited to
+func (a *LedgersApi) LedgerListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentOperatorUid()
+	ledgerResps := make(models.LedgerInfoResponseSlice, 0)
+
+	ownedLedger, err := a.ledgers.GetLedgerByOwnerUid(c, uid)
+
+	if err != nil && err != errs.ErrLedgerNotFound {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get owned ledger for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if ownedLedger != nil {
+		ledgerResp, err := a.getLedgerInfoResponse(c, ownedLedger, nil)
+
+		if err != nil {
+			log.Errorf(c, "[ledgers.LedgerListHandler] failed to get ledger \"id:%d\" info for user \"uid:%d\", because %s", ownedLedger.LedgerId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		ledgerResps = append(ledgerResps, ledgerResp)
+	}
+
+	memberships, err := a.ledgers.GetAllMembershipsByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get ledger memberships for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ledgerIds := make([]int64, len(memberships))
+
+	for i := 0; i < len(memberships); i++ {
+		ledgerIds[i] = memberships[i].LedgerId
+	}
+
+	ledgerMap, err := a.ledgers.GetLedgersByLedgerIds(c, ledgerIds)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerListHandler] failed to get joined ledgers for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	for i := 0; i < len(memberships); i++ {
+		ledger, exists := ledgerMap[memberships[i].LedgerId]
+
+		if !exists {
+			continue
+		}
+
+		ledgerResp, err := a.getLedgerInfoResponse(c, ledger, memberships[i])
+
+		if err != nil {
+			log.Errorf(c, "[ledgers.LedgerListHandler] failed to get ledger \"id:%d\" info for user \"uid:%d\", because %s", ledger.LedgerId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		ledgerResps = append(ledgerResps, ledgerResp)
+	}
+
+	sort.Sort(ledgerResps)
+
+	return ledgerResps, nil
+}
+
+// LedgerCreateHandler saves a new shared ledger of current user's data by request parameters
+func (a *LedgersApi) LedgerCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var ledgerCreateReq models.LedgerCreateRequest
+	err := c.ShouldBindJSON(&ledgerCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+
+	ledger := &models.Ledger{
+		OwnerUid: uid,
+		Name:     ledgerCreateReq.Name,
+	}
+
+	err = a.ledgers.CreateLedger(c, ledger)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerCreateHandler] failed to create ledger for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerCreateHandler] user \"uid:%d\" has created a new ledger \"id:%d\" successfully", uid, ledger.LedgerId)
+
+	ledgerResp, err := a.getLedgerInfoResponse(c, ledger, nil)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerCreateHandler] failed to get ledger \"id:%d\" info for user \"uid:%d\", because %s", ledger.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return ledgerResp, nil
+}
+
+// LedgerModifyHandler saves an existed shared ledger by request parameters, only the owner and admins can modify the ledger
+func (a *LedgersApi) LedgerModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ledgerModifyReq models.LedgerModifyRequest
+	err := c.ShouldBindJSON(&ledgerModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+	ledger, role, err := a.ledgers.GetLedgerAndRoleOfUser(c, ledgerModifyReq.Id, uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerModifyHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if !role.CanManage() {
+		log.Warnf(c, "[ledgers.LedgerModifyHandler] user \"uid:%d\" has no permission to modify ledger \"id:%d\"", uid, ledgerModifyReq.Id)
+		return nil, errs.ErrLedgerPermissionDenied
+	}
+
+	if ledger.Name == ledgerModifyReq.Name {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	ledger.Name = ledgerModifyReq.Name
+	err = a.ledgers.ModifyLedger(c, ledger)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerModifyHandler] failed to update ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerModifyHandler] user \"uid:%d\" has updated ledger \"id:%d\" successfully", uid, ledgerModifyReq.Id)
+
+	return true, nil
+}
+
+// LedgerDeleteHandler deletes an existed shared ledger of current user and all its members
+func (a *LedgersApi) LedgerDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var ledgerDeleteReq models.LedgerDeleteRequest
+	err := c.ShouldBindJSON(&ledgerDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+	err = a.ledgers.DeleteLedger(c, uid, ledgerDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerDeleteHandler] failed to delete ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerDeleteHandler] user \"uid:%d\" has deleted ledger \"id:%d\"", uid, ledgerDeleteReq.Id)
+	return true, nil
+}
+
+// LedgerMemberInviteHandler invites a user to the shared ledger by request parameters, only the owner and admins can invite members
+func (a *LedgersApi) LedgerMemberInviteHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberInviteReq models.LedgerMemberInviteRequest
+	err := c.ShouldBindJSON(&memberInviteReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberInviteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+	ledger, role, err := a.ledgers.GetLedgerAndRoleOfUser(c, memberInviteReq.LedgerId, uid)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberInviteHandler] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", memberInviteReq.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if !role.CanManage() {
+		log.Warnf(c, "[ledgers.LedgerMemberInviteHandler] user \"uid:%d\" has no permission to invite members to ledger \"id:%d\"", uid, memberInviteReq.LedgerId)
+		return nil, errs.ErrLedgerPermissionDenied
+	}
+
+	if !role.CanManageRole(memberInviteReq.Role) {
+		log.Warnf(c, "[ledgers.LedgerMemberInviteHandler] user \"uid:%d\" cannot invite member with role \"%d\" to ledger \"id:%d\"", uid, memberInviteReq.Role, memberInviteReq.LedgerId)
+		return nil, errs.ErrLedgerMemberRoleNotLowerThanYours
+	}
+
+	invitee, err := a.users.GetUserByUsername(c, memberInviteReq.Username)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberInviteHandler] failed to get invited user for ledger \"id:%d\", because %s", memberInviteReq.LedgerId, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	member := &models.LedgerMember{
+		Uid:        invitee.Uid,
+		Role:       memberInviteReq.Role,
+		InviterUid: uid,
+	}
+
+	err = a.ledgers.CreateLedgerMember(c, ledger, member)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberInviteHandler] failed to invite user \"uid:%d\" to ledger \"id:%d\" by user \"uid:%d\", because %s", invitee.Uid, ledger.LedgerId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerMemberInviteHandler] user \"uid:%d\" has invited user \"uid:%d\" to ledger \"id:%d\" successfully", uid, invitee.Uid, ledger.LedgerId)
+
+	return member.ToLedgerMemberInfoResponse(invitee), nil
+}
+
+// LedgerMemberModifyRoleHandler updates the role of a shared ledger member by request parameters
+func (a *LedgersApi) LedgerMemberModifyRoleHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberModifyRoleReq models.LedgerMemberModifyRoleRequest
+	err := c.ShouldBindJSON(&memberModifyRoleReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberModifyRoleHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+	member, role, err := a.getLedgerMemberAndRoleOfCurrentUser(c, memberModifyRoleReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberModifyRoleHandler] failed to get ledger member \"id:%d\" for user \"uid:%d\", because %s", memberModifyRoleReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if !role.CanManage() {
+		log.Warnf(c, "[ledgers.LedgerMemberModifyRoleHandler] user \"uid:%d\" has no permission to modify members of ledger \"id:%d\"", uid, member.LedgerId)
+		return nil, errs.ErrLedgerPermissionDenied
+	}
+
+	if !role.CanManageRole(member.Role) || !role.CanManageRole(memberModifyRoleReq.Role) {
+		log.Warnf(c, "[ledgers.LedgerMemberModifyRoleHandler] user \"uid:%d\" cannot modify role of ledger member \"id:%d\" to \"%d\"", uid, member.MemberId, memberModifyRoleReq.Role)
+		return nil, errs.ErrLedgerMemberRoleNotLowerThanYours
+	}
+
+	if member.Role == memberModifyRoleReq.Role {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	member.Role = memberModifyRoleReq.Role
+	err = a.ledgers.ModifyLedgerMemberRole(c, member)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberModifyRoleHandler] failed to update ledger member \"id:%d\" for user \"uid:%d\", because %s", member.MemberId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerMemberModifyRoleHandler] user \"uid:%d\" has updated role of ledger member \"id:%d\" successfully", uid, member.MemberId)
+
+	return true, nil
+}
+
+// LedgerMemberRemoveHandler removes a member from the shared ledger, or leaves the shared ledger if the member is current user
+func (a *LedgersApi) LedgerMemberRemoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var memberRemoveReq models.LedgerMemberRemoveRequest
+	err := c.ShouldBindJSON(&memberRemoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerMemberRemoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+	member, role, err := a.getLedgerMemberAndRoleOfCurrentUser(c, memberRemoveReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberRemoveHandler] failed to get ledger member \"id:%d\" for user \"uid:%d\", because %s", memberRemoveReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if member.Uid != uid && !role.CanManageRole(member.Role) {
+		log.Warnf(c, "[ledgers.LedgerMemberRemoveHandler] user \"uid:%d\" has no permission to remove ledger member \"id:%d\"", uid, member.MemberId)
+		return nil, errs.ErrLedgerPermissionDenied
+	}
+
+	err = a.ledgers.DeleteLedgerMember(c, member.MemberId)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerMemberRemoveHandler] failed to delete ledger member \"id:%d\" for user \"uid:%d\", because %s", member.MemberId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerMemberRemoveHandler] user \"uid:%d\" has removed ledger member \"id:%d\" from ledger \"id:%d\"", uid, member.MemberId, member.LedgerId)
+	return true, nil
+}
+
+// LedgerInvitationAcceptHandler accepts the shared ledger invitation of current user
+func (a *LedgersApi) LedgerInvitationAcceptHandler(c *core.WebContext) (any, *errs.Error) {
+	var invitationAcceptReq models.LedgerInvitationAcceptRequest
+	err := c.ShouldBindJSON(&invitationAcceptReq)
+
+	if err != nil {
+		log.Warnf(c, "[ledgers.LedgerInvitationAcceptHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentOperatorUid()
+	err = a.ledgers.AcceptLedgerInvitation(c, uid, invitationAcceptReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[ledgers.LedgerInvitationAcceptHandler] failed to accept ledger invitation \"id:%d\" for user \"uid:%d\", because %s", invitationAcceptReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[ledgers.LedgerInvitationAcceptHandler] user \"uid:%d\" has accepted ledger invitation \"id:%d\"", uid, invitationAcceptReq.Id)
+	return true, nil
+}
+
+func (a *LedgersApi) getLedgerMemberAndRoleOfCurrentUser(c *core.WebContext, memberId int64) (*models.LedgerMember, models.LedgerMemberRole, error) {
+	member, err := a.ledgers.GetLedgerMemberByMemberId(c, memberId)
+
+	if err != nil {
+		return nil, models.LEDGER_MEMBER_ROLE_NONE, err
+	}
+
+	_, role, err := a.ledgers.GetLedgerAndRoleOfUser(c, member.LedgerId, c.GetCurrentOperatorUid())
+
+	if err != nil {
+		return nil, models.LEDGER_MEMBER_ROLE_NONE, err
+	}
+
+	return member, role, nil
+}
+
+func (a *LedgersApi) getLedgerInfoResponse(c *core.WebContext, ledger *models.Ledger, currentMember *models.LedgerMember) (*models.LedgerInfoResponse, error) {
+	var members []*models.LedgerMember
+	uids := []int64{ledger.OwnerUid}
+
+	if currentMember == nil || currentMember.GetEffectiveRole().CanManage() {
+		var err error
+		members, err = a.ledgers.GetAllMembersByLedgerId(c, ledger.LedgerId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < len(members); i++ {
+			uids = append(uids, members[i].Uid)
+		}
+	}
+
+	userMap, err := a.users.GetUsersByUids(c, uids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerResp := ledger.ToLedgerInfoResponse(userMap[ledger.OwnerUid], currentMember)
+
+	if members != nil {
+		ledgerResp.Members = make([]*models.LedgerMemberInfoResponse, len(members))
+
+		for i := 0; i < len(members); i++ {
+			ledgerResp.Members[i] = members[i].ToLedgerMemberInfoResponse(userMap[members[i].Uid])
+		}
+	}
+
+	return ledgerResp, nil
+}
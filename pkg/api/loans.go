@@ -0,0 +1,297 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// LoansApi represents loan api
+type LoansApi struct {
+	loans      *services.LoanService
+	accounts   *services.AccountService
+	categories *services.TransactionCategoryService
+}
+
+// Initialize a loan api singleton instance
+var (
+	Loans = &LoansApi{
+		loans:      services.Loans,
+		accounts:   services.Accounts,
+		categories: services.TransactionCategories,
+	}
+)
+
+// LoanListHandler returns loan list of current user
+func (a *LoansApi) LoanListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	loans, err := a.loans.GetAllLoansByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanListHandler] failed to get loans for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	loanResps := make(models.LoanInfoResponseSlice, len(loans))
+
+	for i := 0; i < len(loans); i++ {
+		loanResps[i] = loans[i].ToLoanInfoResponse()
+	}
+
+	sort.Sort(loanResps)
+
+	return loanResps, nil
+}
+
+// LoanGetHandler returns one specific loan of current user
+func (a *LoansApi) LoanGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var loanGetReq models.LoanGetRequest
+	err := c.ShouldBindQuery(&loanGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[loans.LoanGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	loan, err := a.loans.GetLoanByLoanId(c, uid, loanGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanGetHandler] failed to get loan \"id:%d\" for user \"uid:%d\", because %s", loanGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return loan.ToLoanInfoResponse(), nil
+}
+
+// LoanScheduleHandler returns the amortization schedule of one specific loan of current user
+func (a *LoansApi) LoanScheduleHandler(c *core.WebContext) (any, *errs.Error) {
+	var loanGetReq models.LoanGetRequest
+	err := c.ShouldBindQuery(&loanGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[loans.LoanScheduleHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	loan, err := a.loans.GetLoanByLoanId(c, uid, loanGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanScheduleHandler] failed to get loan \"id:%d\" for user \"uid:%d\", because %s", loanGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	schedule := loan.GetAmortizationSchedule()
+	scheduleResps := make([]*models.LoanAmortizationItemResponse, len(schedule))
+
+	for i := 0; i < len(schedule); i++ {
+		scheduleResps[i] = schedule[i].ToLoanAmortizationItemResponse(loan.PaidPeriods)
+	}
+
+	return scheduleResps, nil
+}
+
+// LoanCreateHandler saves a new loan by request parameters for current user
+func (a *LoansApi) LoanCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var loanCreateReq models.LoanCreateRequest
+	err := c.ShouldBindJSON(&loanCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[loans.LoanCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.checkLoanAccountsAndCategories(c, uid, loanCreateReq.AccountId, loanCreateReq.PaymentAccountId, loanCreateReq.PrincipalCategoryId, loanCreateReq.InterestCategoryId)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	loan := &models.Loan{
+		Uid:                 uid,
+		AccountId:           loanCreateReq.AccountId,
+		PaymentAccountId:    loanCreateReq.PaymentAccountId,
+		PrincipalCategoryId: loanCreateReq.PrincipalCategoryId,
+		InterestCategoryId:  loanCreateReq.InterestCategoryId,
+		Name:                loanCreateReq.Name,
+		Principal:           loanCreateReq.Principal,
+		AnnualInterestRate:  loanCreateReq.AnnualInterestRate,
+		TermMonths:          loanCreateReq.TermMonths,
+		PaymentDay:          loanCreateReq.PaymentDay,
+		StartTime:           loanCreateReq.StartTime,
+		TimezoneUtcOffset:   loanCreateReq.UtcOffset,
+		ScheduledEnabled:    loanCreateReq.ScheduledEnabled,
+		ScheduledAt:         TransactionTemplates.getUTCScheduledAt(loanCreateReq.UtcOffset),
+		Comment:             loanCreateReq.Comment,
+	}
+
+	if loanCreateReq.PaidPeriods != nil {
+		loan.PaidPeriods = *loanCreateReq.PaidPeriods
+	} else {
+		loan.PaidPeriods = loan.GetDuePeriods(time.Now().Unix())
+	}
+
+	err = a.loans.CreateLoan(c, loan)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanCreateHandler] failed to create loan \"id:%d\" for user \"uid:%d\", because %s", loan.LoanId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[loans.LoanCreateHandler] user \"uid:%d\" has created a new loan \"id:%d\" successfully", uid, loan.LoanId)
+
+	return loan.ToLoanInfoResponse(), nil
+}
+
+// LoanModifyHandler saves an existed loan by request parameters for current user
+func (a *LoansApi) LoanModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var loanModifyReq models.LoanModifyRequest
+	err := c.ShouldBindJSON(&loanModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[loans.LoanModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	loan, err := a.loans.GetLoanByLoanId(c, uid, loanModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanModifyHandler] failed to get loan \"id:%d\" for user \"uid:%d\", because %s", loanModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if loanModifyReq.Name == loan.Name &&
+		loanModifyReq.PaymentAccountId == loan.PaymentAccountId &&
+		loanModifyReq.PrincipalCategoryId == loan.PrincipalCategoryId &&
+		loanModifyReq.InterestCategoryId == loan.InterestCategoryId &&
+		loanModifyReq.PaidPeriods == loan.PaidPeriods &&
+		loanModifyReq.ScheduledEnabled == loan.ScheduledEnabled &&
+		loanModifyReq.Comment == loan.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.checkLoanAccountsAndCategories(c, uid, loan.AccountId, loanModifyReq.PaymentAccountId, loanModifyReq.PrincipalCategoryId, loanModifyReq.InterestCategoryId)
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	loan.Name = loanModifyReq.Name
+	loan.PaymentAccountId = loanModifyReq.PaymentAccountId
+	loan.PrincipalCategoryId = loanModifyReq.PrincipalCategoryId
+	loan.InterestCategoryId = loanModifyReq.InterestCategoryId
+	loan.PaidPeriods = loanModifyReq.PaidPeriods
+	loan.ScheduledEnabled = loanModifyReq.ScheduledEnabled
+	loan.Comment = loanModifyReq.Comment
+
+	err = a.loans.ModifyLoan(c, loan)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanModifyHandler] failed to update loan \"id:%d\" for user \"uid:%d\", because %s", loanModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[loans.LoanModifyHandler] user \"uid:%d\" has updated loan \"id:%d\" successfully", uid, loanModifyReq.Id)
+
+	return loan.ToLoanInfoResponse(), nil
+}
+
+// LoanDeleteHandler deletes an existed loan by request parameters for current user
+func (a *LoansApi) LoanDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var loanDeleteReq models.LoanDeleteRequest
+	err := c.ShouldBindJSON(&loanDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[loans.LoanDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.loans.DeleteLoan(c, uid, loanDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[loans.LoanDeleteHandler] failed to delete loan \"id:%d\" for user \"uid:%d\", because %s", loanDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[loans.LoanDeleteHandler] user \"uid:%d\" has deleted loan \"id:%d\"", uid, loanDeleteReq.Id)
+	return true, nil
+}
+
+func (a *LoansApi) checkLoanAccountsAndCategories(c *core.WebContext, uid int64, accountId int64, paymentAccountId int64, principalCategoryId int64, interestCategoryId int64) error {
+	accountMap, err := a.accounts.GetAccountsByAccountIds(c, uid, []int64{accountId, paymentAccountId})
+
+	if err != nil {
+		log.Errorf(c, "[loans.checkLoanAccountsAndCategories] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return err
+	}
+
+	account, exists := accountMap[accountId]
+
+	if !exists {
+		return errs.ErrAccountNotFound
+	}
+
+	if account.Category != models.ACCOUNT_CATEGORY_DEBT || account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+		return errs.ErrLoanAccountInvalid
+	}
+
+	paymentAccount, exists := accountMap[paymentAccountId]
+
+	if !exists {
+		return errs.ErrAccountNotFound
+	}
+
+	if paymentAccountId == accountId || paymentAccount.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+		return errs.ErrLoanPaymentAccountInvalid
+	}
+
+	if paymentAccount.Currency != account.Currency {
+		return errs.ErrLoanCurrencyNotMatch
+	}
+
+	categoryMap, err := a.categories.GetCategoriesByCategoryIds(c, uid, []int64{principalCategoryId, interestCategoryId})
+
+	if err != nil {
+		log.Errorf(c, "[loans.checkLoanAccountsAndCategories] failed to get categories for user \"uid:%d\", because %s", uid, err.Error())
+		return err
+	}
+
+	principalCategory, exists := categoryMap[principalCategoryId]
+
+	if !exists {
+		return errs.ErrTransactionCategoryNotFound
+	}
+
+	if principalCategory.Type != models.CATEGORY_TYPE_TRANSFER {
+		return errs.ErrLoanPrincipalCategoryInvalid
+	}
+
+	interestCategory, exists := categoryMap[interestCategoryId]
+
+	if !exists {
+		return errs.ErrTransactionCategoryNotFound
+	}
+
+	if interestCategory.Type != models.CATEGORY_TYPE_EXPENSE {
+		return errs.ErrLoanInterestCategoryInvalid
+	}
+
+	if principalCategory.ParentCategoryId == models.LevelOneTransactionCategoryParentId || interestCategory.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+		return errs.ErrCannotUsePrimaryCategoryForTransaction
+	}
+
+	if principalCategory.Hidden || interestCategory.Hidden {
+		return errs.ErrCannotUseHiddenTransactionCategory
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ type ModelContextProtocolAPI struct {
 	transactionCategories *services.TransactionCategoryService
 	transactionTags       *services.TransactionTagService
 	accounts              *services.AccountService
+	savedSearches         *services.SavedSearchService
 	users                 *services.UserService
 	tokens                *services.TokenService
 }
@@ -36,6 +37,7 @@ var (
 		transactionCategories: services.TransactionCategories,
 		transactionTags:       services.TransactionTags,
 		accounts:              services.Accounts,
+		savedSearches:         services.SavedSearches,
 		users:                 services.Users,
 		tokens:                services.Tokens,
 	}
@@ -254,6 +256,11 @@ func (a *ModelContextProtocolAPI) GetAccountService() *services.AccountService {
 	return a.accounts
 }
 
+// GetSavedSearchService implements the MCPAvailableServices interface
+func (a *ModelContextProtocolAPI) GetSavedSearchService() *services.SavedSearchService {
+	return a.savedSearches
+}
+
 // GetUserService implements the MCPAvailableServices interface
 func (a *ModelContextProtocolAPI) GetUserService() *services.UserService {
 	return a.users
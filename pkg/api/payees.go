@@ -0,0 +1,343 @@
+package api
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// PayeesApi represents payee api
+type PayeesApi struct {
+	payees *services.PayeeService
+}
+
+// Initialize a payee api singleton instance
+var (
+	Payees = &PayeesApi{
+		payees: services.Payees,
+	}
+)
+
+// PayeeListHandler returns payee list of current user
+func (a *PayeesApi) PayeeListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	payees, err := a.payees.GetAllPayeesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeListHandler] failed to get payees for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	payeeResps := make(models.PayeeInfoResponseSlice, len(payees))
+
+	for i := 0; i < len(payees); i++ {
+		payeeResps[i] = payees[i].ToPayeeInfoResponse()
+	}
+
+	sort.Sort(payeeResps)
+
+	return payeeResps, nil
+}
+
+// PayeeGetHandler returns one specific payee of current user
+func (a *PayeesApi) PayeeGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeGetReq models.PayeeGetRequest
+	err := c.ShouldBindQuery(&payeeGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	payee, err := a.payees.GetPayeeByPayeeId(c, uid, payeeGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeGetHandler] failed to get payee \"id:%d\" for user \"uid:%d\", because %s", payeeGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	payeeResp := payee.ToPayeeInfoResponse()
+
+	return payeeResp, nil
+}
+
+// PayeeCreateHandler saves a new payee by request parameters for current user
+func (a *PayeesApi) PayeeCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeCreateReq models.PayeeCreateRequest
+	err := c.ShouldBindJSON(&payeeCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	maxOrderId, err := a.payees.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	payee, err := a.createNewPayeeModel(uid, &payeeCreateReq, maxOrderId+1)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeCreateHandler] failed to create payee model for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.payees.CreatePayee(c, payee)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeCreateHandler] failed to create payee \"id:%d\" for user \"uid:%d\", because %s", payee.PayeeId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[payees.PayeeCreateHandler] user \"uid:%d\" has created a new payee \"id:%d\" successfully", uid, payee.PayeeId)
+
+	payeeResp := payee.ToPayeeInfoResponse()
+
+	return payeeResp, nil
+}
+
+// PayeeCreateBatchHandler saves some new payees by request parameters for current user
+func (a *PayeesApi) PayeeCreateBatchHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeCreateBatchReq models.PayeeCreateBatchRequest
+	err := c.ShouldBindJSON(&payeeCreateBatchReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeCreateBatchHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	maxOrderId, err := a.payees.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeCreateBatchHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	payees := make([]*models.Payee, len(payeeCreateBatchReq.Payees))
+
+	for i := 0; i < len(payeeCreateBatchReq.Payees); i++ {
+		payee, err := a.createNewPayeeModel(uid, payeeCreateBatchReq.Payees[i], maxOrderId+1+int32(i))
+
+		if err != nil {
+			log.Warnf(c, "[payees.PayeeCreateBatchHandler] failed to create payee#%d model for user \"uid:%d\", because %s", i, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		payees[i] = payee
+	}
+
+	err = a.payees.CreatePayees(c, uid, payees, payeeCreateBatchReq.SkipExists)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeCreateBatchHandler] failed to create payees for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[payees.PayeeCreateBatchHandler] user \"uid:%d\" has created payees successfully", uid)
+
+	payeeResps := make(models.PayeeInfoResponseSlice, len(payees))
+
+	for i := 0; i < len(payees); i++ {
+		payeeResps[i] = payees[i].ToPayeeInfoResponse()
+	}
+
+	sort.Sort(payeeResps)
+
+	return payeeResps, nil
+}
+
+// PayeeModifyHandler saves an existed payee by request parameters for current user
+func (a *PayeesApi) PayeeModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeModifyReq models.PayeeModifyRequest
+	err := c.ShouldBindJSON(&payeeModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	payee, err := a.payees.GetPayeeByPayeeId(c, uid, payeeModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeModifyHandler] failed to get payee \"id:%d\" for user \"uid:%d\", because %s", payeeModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newPayee, err := a.createNewPayeeModel(uid, &models.PayeeCreateRequest{
+		Name:              payeeModifyReq.Name,
+		Aliases:           payeeModifyReq.Aliases,
+		DefaultCategoryId: payeeModifyReq.DefaultCategoryId,
+		DefaultTagIds:     payeeModifyReq.DefaultTagIds,
+		Comment:           payeeModifyReq.Comment,
+	}, payee.DisplayOrder)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeModifyHandler] failed to create payee model for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newPayee.PayeeId = payee.PayeeId
+	payeeNameChanged := newPayee.Name != payee.Name
+
+	if !payeeNameChanged &&
+		newPayee.DefaultCategoryId == payee.DefaultCategoryId &&
+		newPayee.DefaultTagIds == payee.DefaultTagIds &&
+		slices.Equal(newPayee.GetAliases(), payee.GetAliases()) &&
+		newPayee.Comment == payee.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.payees.ModifyPayee(c, newPayee, payeeNameChanged)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeModifyHandler] failed to update payee \"id:%d\" for user \"uid:%d\", because %s", payeeModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[payees.PayeeModifyHandler] user \"uid:%d\" has updated payee \"id:%d\" successfully", uid, payeeModifyReq.Id)
+
+	newPayee.Hidden = payee.Hidden
+	payeeResp := newPayee.ToPayeeInfoResponse()
+
+	return payeeResp, nil
+}
+
+// PayeeHideHandler hides a payee by request parameters for current user
+func (a *PayeesApi) PayeeHideHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeHideReq models.PayeeHideRequest
+	err := c.ShouldBindJSON(&payeeHideReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeHideHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.payees.HidePayee(c, uid, []int64{payeeHideReq.Id}, payeeHideReq.Hidden)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeHideHandler] failed to hide payee \"id:%d\" for user \"uid:%d\", because %s", payeeHideReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[payees.PayeeHideHandler] user \"uid:%d\" has hidden payee \"id:%d\"", uid, payeeHideReq.Id)
+	return true, nil
+}
+
+// PayeeMoveHandler moves display order of existed payees by request parameters for current user
+func (a *PayeesApi) PayeeMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeMoveReq models.PayeeMoveRequest
+	err := c.ShouldBindJSON(&payeeMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	payees := make([]*models.Payee, len(payeeMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(payeeMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := payeeMoveReq.NewDisplayOrders[i]
+		payee := &models.Payee{
+			Uid:          uid,
+			PayeeId:      newDisplayOrder.Id,
+			DisplayOrder: newDisplayOrder.DisplayOrder,
+		}
+
+		payees[i] = payee
+	}
+
+	err = a.payees.ModifyPayeeDisplayOrders(c, uid, payees)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeMoveHandler] failed to move payees for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[payees.PayeeMoveHandler] user \"uid:%d\" has moved payees", uid)
+	return true, nil
+}
+
+// PayeeDeleteHandler deletes an existed payee by request parameters for current user
+func (a *PayeesApi) PayeeDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeDeleteReq models.PayeeDeleteRequest
+	err := c.ShouldBindJSON(&payeeDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[payees.PayeeDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.payees.DeletePayee(c, uid, payeeDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[payees.PayeeDeleteHandler] failed to delete payee \"id:%d\" for user \"uid:%d\", because %s", payeeDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[payees.PayeeDeleteHandler] user \"uid:%d\" has deleted payee \"id:%d\"", uid, payeeDeleteReq.Id)
+	return true, nil
+}
+
+func (a *PayeesApi) createNewPayeeModel(uid int64, payeeCreateReq *models.PayeeCreateRequest, order int32) (*models.Payee, error) {
+	name := strings.TrimSpace(payeeCreateReq.Name)
+	aliases := make([]string, 0, len(payeeCreateReq.Aliases))
+
+	for i := 0; i < len(payeeCreateReq.Aliases); i++ {
+		alias := strings.TrimSpace(payeeCreateReq.Aliases[i])
+
+		if alias == "" || strings.EqualFold(alias, name) {
+			continue
+		}
+
+		if slices.ContainsFunc(aliases, func(existedAlias string) bool { return strings.EqualFold(existedAlias, alias) }) {
+			continue
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	if len(aliases) > models.MaximumAliasesCountOfPayee {
+		return nil, errs.ErrPayeeHasTooManyAliases
+	}
+
+	defaultTagIds, err := utils.StringArrayToInt64Array(payeeCreateReq.DefaultTagIds)
+
+	if err != nil {
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
+	defaultTagIds = utils.ToUniqueInt64Slice(defaultTagIds)
+
+	if len(defaultTagIds) > models.MaximumDefaultTagsCountOfPayee {
+		return nil, errs.ErrPayeeHasTooManyDefaultTags
+	}
+
+	return &models.Payee{
+		Uid:               uid,
+		Name:              name,
+		DisplayOrder:      order,
+		DefaultCategoryId: payeeCreateReq.DefaultCategoryId,
+		DefaultTagIds:     strings.Join(utils.Int64ArrayToStringArray(defaultTagIds), ","),
+		Extend: &models.PayeeExtend{
+			Aliases: aliases,
+		},
+		Comment: payeeCreateReq.Comment,
+	}, nil
+}
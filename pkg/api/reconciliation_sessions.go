@@ -0,0 +1,202 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// ReconciliationSessionsApi represents reconciliation session api
+type ReconciliationSessionsApi struct {
+	sessions *services.ReconciliationSessionService
+	accounts *services.AccountService
+}
+
+// Initialize a reconciliation session api singleton instance
+var (
+	ReconciliationSessions = &ReconciliationSessionsApi{
+		sessions: services.ReconciliationSessions,
+		accounts: services.Accounts,
+	}
+)
+
+// ReconciliationSessionListHandler returns reconciliation session list of current user
+func (a *ReconciliationSessionsApi) ReconciliationSessionListHandler(c *core.WebContext) (any, *errs.Error) {
+	var sessionListReq models.ReconciliationSessionListRequest
+	err := c.ShouldBindQuery(&sessionListReq)
+
+	if err != nil {
+		log.Warnf(c, "[reconciliation_sessions.ReconciliationSessionListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	sessions, err := a.sessions.GetReconciliationSessions(c, uid, sessionListReq.AccountId)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionListHandler] failed to get reconciliation sessions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	sessionResps := make(models.ReconciliationSessionInfoResponseSlice, len(sessions))
+
+	for i := 0; i < len(sessions); i++ {
+		sessionResps[i], err = a.getReconciliationSessionInfoResponse(c, sessions[i])
+
+		if err != nil {
+			log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionListHandler] failed to get cleared balance of reconciliation session \"id:%d\" for user \"uid:%d\", because %s", sessions[i].SessionId, uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	sort.Sort(sessionResps)
+
+	return sessionResps, nil
+}
+
+// ReconciliationSessionGetHandler returns one specific reconciliation session of current user
+func (a *ReconciliationSessionsApi) ReconciliationSessionGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var sessionGetReq models.ReconciliationSessionGetRequest
+	err := c.ShouldBindQuery(&sessionGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[reconciliation_sessions.ReconciliationSessionGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	session, err := a.sessions.GetReconciliationSessionById(c, uid, sessionGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionGetHandler] failed to get reconciliation session \"id:%d\" for user \"uid:%d\", because %s", sessionGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	sessionResp, err := a.getReconciliationSessionInfoResponse(c, session)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionGetHandler] failed to get cleared balance of reconciliation session \"id:%d\" for user \"uid:%d\", because %s", session.SessionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return sessionResp, nil
+}
+
+// ReconciliationSessionCreateHandler starts a new reconciliation session by request parameters for current user
+func (a *ReconciliationSessionsApi) ReconciliationSessionCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var sessionCreateReq models.ReconciliationSessionCreateRequest
+	err := c.ShouldBindJSON(&sessionCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[reconciliation_sessions.ReconciliationSessionCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	account, err := a.accounts.GetAccountByAccountId(c, uid, sessionCreateReq.AccountId)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionCreateHandler] failed to get account \"id:%d\" for user \"uid:%d\", because %s", sessionCreateReq.AccountId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT {
+		log.Warnf(c, "[reconciliation_sessions.ReconciliationSessionCreateHandler] account \"id:%d\" for user \"uid:%d\" is not a single account", sessionCreateReq.AccountId, uid)
+		return nil, errs.ErrAccountTypeInvalid
+	}
+
+	session := &models.ReconciliationSession{
+		Uid:                    uid,
+		AccountId:              account.AccountId,
+		StatementEndTime:       sessionCreateReq.StatementEndTime,
+		StatementEndingBalance: sessionCreateReq.StatementEndingBalance,
+	}
+
+	err = a.sessions.CreateReconciliationSession(c, session)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionCreateHandler] failed to create reconciliation session for account \"id:%d\" of user \"uid:%d\", because %s", account.AccountId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[reconciliation_sessions.ReconciliationSessionCreateHandler] user \"uid:%d\" has created a new reconciliation session \"id:%d\" successfully", uid, session.SessionId)
+
+	sessionResp, err := a.getReconciliationSessionInfoResponse(c, session)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionCreateHandler] failed to get cleared balance of reconciliation session \"id:%d\" for user \"uid:%d\", because %s", session.SessionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return sessionResp, nil
+}
+
+// ReconciliationSessionCompleteHandler completes an existed reconciliation session and locks all reconciled transactions for current user
+func (a *ReconciliationSessionsApi) ReconciliationSessionCompleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var sessionCompleteReq models.ReconciliationSessionCompleteRequest
+	err := c.ShouldBindJSON(&sessionCompleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[reconciliation_sessions.ReconciliationSessionCompleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	session, err := a.sessions.GetReconciliationSessionById(c, uid, sessionCompleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionCompleteHandler] failed to get reconciliation session \"id:%d\" for user \"uid:%d\", because %s", sessionCompleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.sessions.CompleteReconciliationSession(c, session)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionCompleteHandler] failed to complete reconciliation session \"id:%d\" for user \"uid:%d\", because %s", sessionCompleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[reconciliation_sessions.ReconciliationSessionCompleteHandler] user \"uid:%d\" has completed reconciliation session \"id:%d\" and reconciled %d transactions", uid, session.SessionId, session.ReconciledCount)
+
+	return session.ToReconciliationSessionInfoResponse(nil), nil
+}
+
+// ReconciliationSessionDeleteHandler deletes an existed reconciliation session by request parameters for current user
+func (a *ReconciliationSessionsApi) ReconciliationSessionDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var sessionDeleteReq models.ReconciliationSessionDeleteRequest
+	err := c.ShouldBindJSON(&sessionDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[reconciliation_sessions.ReconciliationSessionDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.sessions.DeleteReconciliationSession(c, uid, sessionDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[reconciliation_sessions.ReconciliationSessionDeleteHandler] failed to delete reconciliation session \"id:%d\" for user \"uid:%d\", because %s", sessionDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[reconciliation_sessions.ReconciliationSessionDeleteHandler] user \"uid:%d\" has deleted reconciliation session \"id:%d\"", uid, sessionDeleteReq.Id)
+	return true, nil
+}
+
+func (a *ReconciliationSessionsApi) getReconciliationSessionInfoResponse(c *core.WebContext, session *models.ReconciliationSession) (*models.ReconciliationSessionInfoResponse, error) {
+	if session.IsCompleted() {
+		return session.ToReconciliationSessionInfoResponse(nil), nil
+	}
+
+	clearedBalance, err := a.sessions.GetClearedBalance(c, session)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return session.ToReconciliationSessionInfoResponse(clearedBalance), nil
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// SavedSearchesApi represents saved searches api
+type SavedSearchesApi struct {
+	savedSearches *services.SavedSearchService
+}
+
+// Initialize a saved searches api singleton instance
+var (
+	SavedSearches = &SavedSearchesApi{
+		savedSearches: services.SavedSearches,
+	}
+)
+
+// SavedSearchListHandler returns saved search list of current user
+func (a *SavedSearchesApi) SavedSearchListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	savedSearches, err := a.savedSearches.GetAllSavedSearchesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchListHandler] failed to get saved searches for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	savedSearchResps := make(models.SavedSearchInfoResponseSlice, len(savedSearches))
+
+	for i := 0; i < len(savedSearches); i++ {
+		savedSearchResps[i] = savedSearches[i].ToSavedSearchInfoResponse()
+	}
+
+	sort.Sort(savedSearchResps)
+
+	return savedSearchResps, nil
+}
+
+// SavedSearchGetHandler returns one specific saved search of current user
+func (a *SavedSearchesApi) SavedSearchGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var savedSearchGetReq models.SavedSearchGetRequest
+	err := c.ShouldBindQuery(&savedSearchGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	savedSearch, err := a.savedSearches.GetSavedSearchBySavedSearchId(c, uid, savedSearchGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchGetHandler] failed to get saved search \"id:%d\" for user \"uid:%d\", because %s", savedSearchGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return savedSearch.ToSavedSearchInfoResponse(), nil
+}
+
+// SavedSearchCreateHandler saves a new saved search by request parameters for current user
+func (a *SavedSearchesApi) SavedSearchCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var savedSearchCreateReq models.SavedSearchCreateRequest
+	err := c.ShouldBindJSON(&savedSearchCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	_, err = models.ParseTransactionSearchQuery(savedSearchCreateReq.Query)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchCreateHandler] parse search query failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrTransactionSearchQueryInvalid)
+	}
+
+	uid := c.GetCurrentUid()
+
+	maxOrderId, err := a.savedSearches.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	savedSearch := a.createNewSavedSearchModel(uid, &savedSearchCreateReq, maxOrderId+1)
+
+	err = a.savedSearches.CreateSavedSearch(c, savedSearch)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchCreateHandler] failed to create saved search \"id:%d\" for user \"uid:%d\", because %s", savedSearch.SavedSearchId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[saved_searches.SavedSearchCreateHandler] user \"uid:%d\" has created a new saved search \"id:%d\" successfully", uid, savedSearch.SavedSearchId)
+
+	return savedSearch.ToSavedSearchInfoResponse(), nil
+}
+
+// SavedSearchModifyHandler saves an existed saved search by request parameters for current user
+func (a *SavedSearchesApi) SavedSearchModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var savedSearchModifyReq models.SavedSearchModifyRequest
+	err := c.ShouldBindJSON(&savedSearchModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	_, err = models.ParseTransactionSearchQuery(savedSearchModifyReq.Query)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchModifyHandler] parse search query failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrTransactionSearchQueryInvalid)
+	}
+
+	uid := c.GetCurrentUid()
+	savedSearch, err := a.savedSearches.GetSavedSearchBySavedSearchId(c, uid, savedSearchModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchModifyHandler] failed to get saved search \"id:%d\" for user \"uid:%d\", because %s", savedSearchModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newSavedSearch := &models.SavedSearch{
+		SavedSearchId: savedSearch.SavedSearchId,
+		Uid:           uid,
+		Name:          savedSearchModifyReq.Name,
+		Query:         savedSearchModifyReq.Query,
+	}
+
+	savedSearchNameChanged := newSavedSearch.Name != savedSearch.Name
+
+	if !savedSearchNameChanged && newSavedSearch.Query == savedSearch.Query {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.savedSearches.ModifySavedSearch(c, newSavedSearch, savedSearchNameChanged)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchModifyHandler] failed to update saved search \"id:%d\" for user \"uid:%d\", because %s", savedSearchModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[saved_searches.SavedSearchModifyHandler] user \"uid:%d\" has updated saved search \"id:%d\" successfully", uid, savedSearchModifyReq.Id)
+
+	savedSearch.Name = newSavedSearch.Name
+	savedSearch.Query = newSavedSearch.Query
+
+	return savedSearch.ToSavedSearchInfoResponse(), nil
+}
+
+// SavedSearchHideHandler hides a saved search by request parameters for current user
+func (a *SavedSearchesApi) SavedSearchHideHandler(c *core.WebContext) (any, *errs.Error) {
+	var savedSearchHideReq models.SavedSearchHideRequest
+	err := c.ShouldBindJSON(&savedSearchHideReq)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchHideHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.savedSearches.HideSavedSearch(c, uid, []int64{savedSearchHideReq.Id}, savedSearchHideReq.Hidden)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchHideHandler] failed to hide saved search \"id:%d\" for user \"uid:%d\", because %s", savedSearchHideReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[saved_searches.SavedSearchHideHandler] user \"uid:%d\" has hidden saved search \"id:%d\"", uid, savedSearchHideReq.Id)
+	return true, nil
+}
+
+// SavedSearchMoveHandler moves display order of existed saved searches by request parameters for current user
+func (a *SavedSearchesApi) SavedSearchMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var savedSearchMoveReq models.SavedSearchMoveRequest
+	err := c.ShouldBindJSON(&savedSearchMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	savedSearches := make([]*models.SavedSearch, len(savedSearchMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(savedSearchMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := savedSearchMoveReq.NewDisplayOrders[i]
+		savedSearch := &models.SavedSearch{
+			Uid:           uid,
+			SavedSearchId: newDisplayOrder.Id,
+			DisplayOrder:  newDisplayOrder.DisplayOrder,
+		}
+
+		savedSearches[i] = savedSearch
+	}
+
+	err = a.savedSearches.ModifySavedSearchDisplayOrders(c, uid, savedSearches)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchMoveHandler] failed to move saved searches for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[saved_searches.SavedSearchMoveHandler] user \"uid:%d\" has moved saved searches", uid)
+	return true, nil
+}
+
+// SavedSearchDeleteHandler deletes an existed saved search by request parameters for current user
+func (a *SavedSearchesApi) SavedSearchDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var savedSearchDeleteReq models.SavedSearchDeleteRequest
+	err := c.ShouldBindJSON(&savedSearchDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[saved_searches.SavedSearchDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.savedSearches.DeleteSavedSearch(c, uid, savedSearchDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[saved_searches.SavedSearchDeleteHandler] failed to delete saved search \"id:%d\" for user \"uid:%d\", because %s", savedSearchDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[saved_searches.SavedSearchDeleteHandler] user \"uid:%d\" has deleted saved search \"id:%d\"", uid, savedSearchDeleteReq.Id)
+	return true, nil
+}
+
+func (a *SavedSearchesApi) createNewSavedSearchModel(uid int64, savedSearchCreateReq *models.SavedSearchCreateRequest, order int32) *models.SavedSearch {
+	return &models.SavedSearch{
+		Uid:          uid,
+		Name:         savedSearchCreateReq.Name,
+		Query:        savedSearchCreateReq.Query,
+		DisplayOrder: order,
+	}
+}
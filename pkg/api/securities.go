@@ -0,0 +1,297 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/securityprices"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+)
+
+// SecuritiesApi represents security api
+type SecuritiesApi struct {
+	securities *services.SecurityService
+}
+
+// Initialize a security api singleton instance
+var (
+	Securities = &SecuritiesApi{
+		securities: services.Securities,
+	}
+)
+
+// SecurityListHandler returns security list of current user
+func (a *SecuritiesApi) SecurityListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	securities, err := a.securities.GetAllSecuritiesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityListHandler] failed to get securities for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	securityResps := make(models.SecurityInfoResponseSlice, len(securities))
+
+	for i := 0; i < len(securities); i++ {
+		securityResps[i] = securities[i].ToSecurityInfoResponse()
+	}
+
+	sort.Sort(securityResps)
+
+	return securityResps, nil
+}
+
+// SecurityGetHandler returns one specific security of current user
+func (a *SecuritiesApi) SecurityGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var securityGetReq models.SecurityGetRequest
+	err := c.ShouldBindQuery(&securityGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	security, err := a.securities.GetSecurityBySecurityId(c, uid, securityGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityGetHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", securityGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return security.ToSecurityInfoResponse(), nil
+}
+
+// SecurityCreateHandler saves a new security by request parameters for current user
+func (a *SecuritiesApi) SecurityCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var securityCreateReq models.SecurityCreateRequest
+	err := c.ShouldBindJSON(&securityCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	security := &models.Security{
+		Uid:             uid,
+		Ticker:          securityCreateReq.Ticker,
+		Name:            securityCreateReq.Name,
+		Currency:        securityCreateReq.Currency,
+		CostBasisMethod: securityCreateReq.CostBasisMethod,
+		Comment:         securityCreateReq.Comment,
+	}
+
+	err = a.securities.CreateSecurity(c, security)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityCreateHandler] failed to create security \"id:%d\" for user \"uid:%d\", because %s", security.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[securities.SecurityCreateHandler] user \"uid:%d\" has created a new security \"id:%d\" successfully", uid, security.SecurityId)
+
+	return security.ToSecurityInfoResponse(), nil
+}
+
+// SecurityModifyHandler saves an existed security by request parameters for current user
+func (a *SecuritiesApi) SecurityModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var securityModifyReq models.SecurityModifyRequest
+	err := c.ShouldBindJSON(&securityModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	security, err := a.securities.GetSecurityBySecurityId(c, uid, securityModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityModifyHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", securityModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newSecurity := &models.Security{
+		SecurityId:      security.SecurityId,
+		Uid:             uid,
+		Ticker:          securityModifyReq.Ticker,
+		Name:            securityModifyReq.Name,
+		Currency:        security.Currency,
+		CostBasisMethod: securityModifyReq.CostBasisMethod,
+		Hidden:          securityModifyReq.Hidden,
+		Comment:         securityModifyReq.Comment,
+	}
+
+	if newSecurity.Ticker == security.Ticker &&
+		newSecurity.Name == security.Name &&
+		newSecurity.CostBasisMethod == security.CostBasisMethod &&
+		newSecurity.Hidden == security.Hidden &&
+		newSecurity.Comment == security.Comment {
+		return nil, errs.ErrNothingWillBeUpdated
+	}
+
+	err = a.securities.ModifySecurity(c, newSecurity)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityModifyHandler] failed to update security \"id:%d\" for user \"uid:%d\", because %s", securityModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[securities.SecurityModifyHandler] user \"uid:%d\" has updated security \"id:%d\" successfully", uid, securityModifyReq.Id)
+
+	return newSecurity.ToSecurityInfoResponse(), nil
+}
+
+// SecurityDeleteHandler deletes an existed security by request parameters for current user
+func (a *SecuritiesApi) SecurityDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var securityDeleteReq models.SecurityDeleteRequest
+	err := c.ShouldBindJSON(&securityDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.securities.DeleteSecurity(c, uid, securityDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityDeleteHandler] failed to delete security \"id:%d\" for user \"uid:%d\", because %s", securityDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[securities.SecurityDeleteHandler] user \"uid:%d\" has deleted security \"id:%d\"", uid, securityDeleteReq.Id)
+	return true, nil
+}
+
+// SecurityPriceListHandler returns the price history of one specific security of current user
+func (a *SecuritiesApi) SecurityPriceListHandler(c *core.WebContext) (any, *errs.Error) {
+	var priceListReq models.SecurityPriceListRequest
+	err := c.ShouldBindQuery(&priceListReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityPriceListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	prices, err := a.securities.GetSecurityPrices(c, uid, priceListReq.SecurityId, priceListReq.StartTime, priceListReq.EndTime)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceListHandler] failed to get prices of security \"id:%d\" for user \"uid:%d\", because %s", priceListReq.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	priceResps := make([]*models.SecurityPriceInfoResponse, len(prices))
+
+	for i := 0; i < len(prices); i++ {
+		priceResps[i] = prices[i].ToSecurityPriceInfoResponse()
+	}
+
+	return priceResps, nil
+}
+
+// SecurityPriceCreateHandler saves a manually entered price of one specific security for current user
+func (a *SecuritiesApi) SecurityPriceCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var priceCreateReq models.SecurityPriceCreateRequest
+	err := c.ShouldBindJSON(&priceCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityPriceCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	_, err = a.securities.GetSecurityBySecurityId(c, uid, priceCreateReq.SecurityId)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceCreateHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", priceCreateReq.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	price := &models.SecurityPrice{
+		Uid:        uid,
+		SecurityId: priceCreateReq.SecurityId,
+		PriceTime:  priceCreateReq.Time,
+		Price:      priceCreateReq.Price,
+		Source:     models.SecurityPriceSourceManual,
+	}
+
+	err = a.securities.SaveSecurityPrice(c, price)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceCreateHandler] failed to save price of security \"id:%d\" for user \"uid:%d\", because %s", priceCreateReq.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[securities.SecurityPriceCreateHandler] user \"uid:%d\" has saved price \"id:%d\" of security \"id:%d\" successfully", uid, price.PriceId, priceCreateReq.SecurityId)
+
+	return price.ToSecurityPriceInfoResponse(), nil
+}
+
+// SecurityPriceUpdateHandler fetches and saves the latest price of one specific security from the security prices data source for current user
+func (a *SecuritiesApi) SecurityPriceUpdateHandler(c *core.WebContext) (any, *errs.Error) {
+	var priceUpdateReq models.SecurityPriceUpdateRequest
+	err := c.ShouldBindJSON(&priceUpdateReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityPriceUpdateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !securityprices.Container.IsEnabled() {
+		return nil, errs.ErrSecurityPricesDataSourceNotEnabled
+	}
+
+	uid := c.GetCurrentUid()
+	security, err := a.securities.GetSecurityBySecurityId(c, uid, priceUpdateReq.SecurityId)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceUpdateHandler] failed to get security \"id:%d\" for user \"uid:%d\", because %s", priceUpdateReq.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	price, err := securityprices.Container.GetLatestSecurityPrice(c, uid, security)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceUpdateHandler] failed to fetch latest price of security \"id:%d\" for user \"uid:%d\", because %s", security.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	err = a.securities.SaveSecurityPrice(c, price)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceUpdateHandler] failed to save price of security \"id:%d\" for user \"uid:%d\", because %s", security.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[securities.SecurityPriceUpdateHandler] user \"uid:%d\" has updated price of security \"id:%d\" from data source \"%s\"", uid, security.SecurityId, price.Source)
+
+	return price.ToSecurityPriceInfoResponse(), nil
+}
+
+// SecurityPriceDeleteHandler deletes an existed security price by request parameters for current user
+func (a *SecuritiesApi) SecurityPriceDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var priceDeleteReq models.SecurityPriceDeleteRequest
+	err := c.ShouldBindJSON(&priceDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[securities.SecurityPriceDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.securities.DeleteSecurityPrice(c, uid, priceDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[securities.SecurityPriceDeleteHandler] failed to delete security price \"id:%d\" for user \"uid:%d\", because %s", priceDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[securities.SecurityPriceDeleteHandler] user \"uid:%d\" has deleted security price \"id:%d\"", uid, priceDeleteReq.Id)
+	return true, nil
+}
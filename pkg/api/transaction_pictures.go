@@ -1,6 +1,11 @@
 package api
 
 import (
+	"io"
+	"mime/multipart"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
@@ -19,6 +24,9 @@ type TransactionPicturesApi struct {
 	pictures *services.TransactionPictureService
 }
 
+// maxSniffedFileHeaderSize is the maximum size of file header used to detect the content type
+const maxSniffedFileHeaderSize = 512
+
 // Initialize a transaction api singleton instance
 var (
 	TransactionPictures = &TransactionPicturesApi{
@@ -77,7 +85,21 @@ func (a *TransactionPicturesApi) TransactionPictureUploadHandler(c *core.WebCont
 		return nil, errs.ErrOperationFailed
 	}
 
-	pictureInfo := a.createNewPictureInfoModel(uid, fileExtension, c.ClientIP())
+	fileHeader, err := a.readFileHeader(pictureFile)
+
+	if err != nil {
+		pictureFile.Close()
+		log.Errorf(c, "[transaction_pictures.TransactionPictureUploadHandler] failed to read transaction picture file header for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	if !utils.IsFileContentMatchedExtension(fileExtension, fileHeader) {
+		pictureFile.Close()
+		log.Warnf(c, "[transaction_pictures.TransactionPictureUploadHandler] the content of transaction picture does not match the file extension \"%s\" for user \"uid:%d\"", fileExtension, uid)
+		return nil, errs.ErrImageTypeNotSupported
+	}
+
+	pictureInfo := a.createNewPictureInfoModel(uid, fileExtension, pictureFiles[0].Filename, utils.GetImageContentType(fileExtension), pictureFiles[0].Size, c.ClientIP())
 
 	clientSessionIds := form.Value["clientSessionId"]
 	clientSessionId := ""
@@ -101,7 +123,7 @@ func (a *TransactionPicturesApi) TransactionPictureUploadHandler(c *core.WebCont
 					return nil, errs.Or(err, errs.ErrOperationFailed)
 				}
 
-				pictureInfoResp := a.GetTransactionPictureInfoResponse(pictureInfo)
+				pictureInfoResp := a.GetTransactionPictureInfoResponse(c, pictureInfo)
 
 				return pictureInfoResp, nil
 			}
@@ -116,11 +138,111 @@ func (a *TransactionPicturesApi) TransactionPictureUploadHandler(c *core.WebCont
 	}
 
 	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_PICTURE, uid, clientSessionId, utils.Int64ToString(pictureInfo.PictureId))
-	pictureInfoResp := a.GetTransactionPictureInfoResponse(pictureInfo)
+	pictureInfoResp := a.GetTransactionPictureInfoResponse(c, pictureInfo)
 
 	return pictureInfoResp, nil
 }
 
+// TransactionAttachmentUploadHandler saves transaction attachment by request parameters for current user
+func (a *TransactionPicturesApi) TransactionAttachmentUploadHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	form, err := c.MultipartForm()
+
+	if err != nil {
+		log.Errorf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] failed to get multi-part form data for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrParameterInvalid
+	}
+
+	attachmentFiles := form.File["attachment"]
+
+	if len(attachmentFiles) < 1 {
+		log.Warnf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] there is no transaction attachment in request for user \"uid:%d\"", uid)
+		return nil, errs.ErrNoTransactionAttachment
+	}
+
+	if attachmentFiles[0].Size < 1 {
+		log.Warnf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] the size of transaction attachment in request is zero for user \"uid:%d\"", uid)
+		return nil, errs.ErrTransactionPictureIsEmpty
+	}
+
+	fileExtension := strings.ToLower(utils.GetFileNameExtension(attachmentFiles[0].Filename))
+	contentType := utils.GetAttachmentContentType(fileExtension)
+
+	if contentType == "" {
+		log.Warnf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] the file extension \"%s\" of transaction attachment in request is not supported for user \"uid:%d\"", fileExtension, uid)
+		return nil, errs.ErrTransactionAttachmentTypeNotSupported
+	}
+
+	maxFileSize := a.getMaxAttachmentFileSize(models.GetTransactionAttachmentType(contentType))
+
+	if attachmentFiles[0].Size > int64(maxFileSize) {
+		log.Warnf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] the upload file size \"%d\" exceeds the maximum size \"%d\" of transaction attachment for user \"uid:%d\"", attachmentFiles[0].Size, maxFileSize, uid)
+		return nil, errs.ErrExceedMaxTransactionAttachmentFileSize
+	}
+
+	attachmentFile, err := attachmentFiles[0].Open()
+
+	if err != nil {
+		log.Errorf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] failed to get transaction attachment file from request for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	fileHeader, err := a.readFileHeader(attachmentFile)
+
+	if err != nil {
+		attachmentFile.Close()
+		log.Errorf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] failed to read transaction attachment file header for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.ErrOperationFailed
+	}
+
+	if !utils.IsFileContentMatchedExtension(fileExtension, fileHeader) {
+		attachmentFile.Close()
+		log.Warnf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] the content type \"%s\" of transaction attachment does not match the file extension \"%s\" for user \"uid:%d\"", utils.SniffFileContentType(fileHeader), fileExtension, uid)
+		return nil, errs.ErrTransactionAttachmentContentMismatch
+	}
+
+	attachmentInfo := a.createNewPictureInfoModel(uid, fileExtension, attachmentFiles[0].Filename, contentType, attachmentFiles[0].Size, c.ClientIP())
+
+	clientSessionIds := form.Value["clientSessionId"]
+	clientSessionId := ""
+
+	if len(clientSessionIds) > 0 {
+		clientSessionId = clientSessionIds[0]
+	}
+
+	if a.CurrentConfig().EnableDuplicateSubmissionsCheck && clientSessionId != "" {
+		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_PICTURE, uid, clientSessionId)
+
+		if found {
+			log.Infof(c, "[transaction_pictures.TransactionAttachmentUploadHandler] another transaction attachment \"id:%s\" has been uploaded for user \"uid:%d\"", remark, uid)
+			attachmentId, err := utils.StringToInt64(remark)
+
+			if err == nil {
+				attachmentFile.Close()
+				attachmentInfo, err = a.pictures.GetPictureInfoByPictureId(c, uid, attachmentId)
+
+				if err != nil {
+					log.Errorf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] failed to get existed transaction attachment \"id:%d\" for user \"uid:%d\", because %s", attachmentId, uid, err.Error())
+					return nil, errs.Or(err, errs.ErrOperationFailed)
+				}
+
+				return a.GetTransactionPictureInfoResponse(c, attachmentInfo), nil
+			}
+		}
+	}
+
+	err = a.pictures.UploadPicture(c, attachmentInfo, attachmentFile)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_pictures.TransactionAttachmentUploadHandler] failed to upload transaction attachment for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_PICTURE, uid, clientSessionId, utils.Int64ToString(attachmentInfo.PictureId))
+
+	return a.GetTransactionPictureInfoResponse(c, attachmentInfo), nil
+}
+
 // TransactionPictureGetHandler returns transaction picture data for current user
 func (a *TransactionPicturesApi) TransactionPictureGetHandler(c *core.WebContext) ([]byte, string, *errs.Error) {
 	fileName := c.Param("fileName")
@@ -152,6 +274,36 @@ func (a *TransactionPicturesApi) TransactionPictureGetHandler(c *core.WebContext
 	return pictureData, contentType, nil
 }
 
+// TransactionAttachmentGetHandler returns transaction attachment data for current user
+func (a *TransactionPicturesApi) TransactionAttachmentGetHandler(c *core.WebContext) ([]byte, string, string, *errs.Error) {
+	fileName := c.Param("fileName")
+	fileExtension := utils.GetFileNameExtension(fileName)
+
+	if utils.GetAttachmentContentType(fileExtension) == "" {
+		return nil, "", "", errs.ErrTransactionAttachmentTypeNotSupported
+	}
+
+	fileBaseName := utils.GetFileNameWithoutExtension(fileName)
+	attachmentId, err := utils.StringToInt64(fileBaseName)
+
+	if err != nil {
+		return nil, "", "", errs.ErrTransactionPictureIdInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	attachmentInfo, attachmentData, err := a.pictures.GetAttachmentByPictureId(c, uid, attachmentId, fileExtension)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transaction_pictures.TransactionAttachmentGetHandler] failed to get transaction attachment, because %s", err.Error())
+		}
+
+		return nil, "", "", errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return attachmentData, attachmentInfo.GetContentType(), attachmentInfo.GetDownloadFileName(), nil
+}
+
 // TransactionPictureRemoveUnusedHandler removes unused transaction picture by request parameters for current user
 func (a *TransactionPicturesApi) TransactionPictureRemoveUnusedHandler(c *core.WebContext) (any, *errs.Error) {
 	var pictureDeleteReq models.TransactionPictureUnusedDeleteRequest
@@ -173,11 +325,47 @@ func (a *TransactionPicturesApi) TransactionPictureRemoveUnusedHandler(c *core.W
 	return true, nil
 }
 
-func (a *TransactionPicturesApi) createNewPictureInfoModel(uid int64, fileExtension string, clientIp string) *models.TransactionPictureInfo {
+func (a *TransactionPicturesApi) createNewPictureInfoModel(uid int64, fileExtension string, fileName string, contentType string, fileSize int64, clientIp string) *models.TransactionPictureInfo {
+	fileName = strings.TrimSpace(fileName)
+
+	if utf8.RuneCountInString(fileName) > 255 {
+		fileName = string([]rune(fileName)[0:255])
+	}
+
 	return &models.TransactionPictureInfo{
 		Uid:              uid,
 		TransactionId:    models.TransactionPictureNewPictureTransactionId,
 		PictureExtension: fileExtension,
+		FileName:         fileName,
+		ContentType:      contentType,
+		FileSize:         fileSize,
 		CreatedIp:        clientIp,
 	}
 }
+
+func (a *TransactionPicturesApi) getMaxAttachmentFileSize(attachmentType models.TransactionAttachmentType) uint32 {
+	if attachmentType == models.TRANSACTION_ATTACHMENT_TYPE_IMAGE {
+		return a.CurrentConfig().MaxTransactionPictureFileSize
+	} else if attachmentType == models.TRANSACTION_ATTACHMENT_TYPE_PDF {
+		return a.CurrentConfig().MaxTransactionPdfAttachmentFileSize
+	}
+
+	return a.CurrentConfig().MaxTransactionDocumentAttachmentFileSize
+}
+
+func (a *TransactionPicturesApi) readFileHeader(file multipart.File) ([]byte, error) {
+	fileHeader := make([]byte, maxSniffedFileHeaderSize)
+	readBytes, err := io.ReadFull(file, fileHeader)
+
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fileHeader[0:readBytes], nil
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionRevisionsApi represents transaction revision api
+type TransactionRevisionsApi struct {
+	ApiUsingConfig
+	revisions           *services.TransactionRevisionService
+	transactions        *services.TransactionService
+	transactionTags     *services.TransactionTagService
+	transactionItems    *services.TransactionItemService
+	transactionPictures *services.TransactionPictureService
+	users               *services.UserService
+}
+
+// Initialize a transaction revision api singleton instance
+var (
+	TransactionRevisions = &TransactionRevisionsApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		revisions:           services.TransactionRevisions,
+		transactions:        services.Transactions,
+		transactionTags:     services.TransactionTags,
+		transactionItems:    services.TransactionItems,
+		transactionPictures: services.TransactionPictures,
+		users:               services.Users,
+	}
+)
+
+// TransactionRevisionListHandler returns all revisions of specified transaction for current user
+func (a *TransactionRevisionsApi) TransactionRevisionListHandler(c *core.WebContext) (any, *errs.Error) {
+	var revisionListReq models.TransactionRevisionListRequest
+	err := c.ShouldBindQuery(&revisionListReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	transaction, err := a.transactions.GetTransactionByTransactionId(c, uid, revisionListReq.TransactionId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionListHandler] failed to get transaction \"id:%d\" for user \"uid:%d\", because %s", revisionListReq.TransactionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionId := transaction.TransactionId
+	transactionType := transaction.Type
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+		transactionId = transaction.RelatedId
+		transactionType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
+	}
+
+	revisions, err := a.revisions.GetRevisionsByTransactionId(c, uid, transactionId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionListHandler] failed to get revisions of transaction \"id:%d\" for user \"uid:%d\", because %s", transactionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	operators, err := a.users.GetUsersByUids(c, a.revisions.GetRevisionOperatorUids(revisions))
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionListHandler] failed to get operators of transaction revisions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	revisionResps := make(models.TransactionRevisionInfoResponseSlice, len(revisions))
+
+	for i := 0; i < len(revisions); i++ {
+		revision := revisions[i]
+		revisionResps[i] = revision.ToTransactionRevisionInfoResponse(transactionType)
+
+		if operator, exists := operators[revision.OperatorUid]; exists {
+			revisionResps[i].Operator = &models.TransactionCreatorInfoResponse{
+				Username: operator.Username,
+				Nickname: operator.Nickname,
+			}
+		}
+	}
+
+	sort.Sort(revisionResps)
+
+	return revisionResps, nil
+}
+
+// TransactionRevisionRevertHandler restores the specified transaction to the content before the specified revision for current user
+func (a *TransactionRevisionsApi) TransactionRevisionRevertHandler(c *core.WebContext) (any, *errs.Error) {
+	var revisionRevertReq models.TransactionRevisionRevertRequest
+	err := c.ShouldBindJSON(&revisionRevertReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	transaction, err := a.transactions.GetTransactionByTransactionId(c, uid, revisionRevertReq.TransactionId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to get transaction \"id:%d\" for user \"uid:%d\", because %s", revisionRevertReq.TransactionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] cannot revert transaction \"id:%d\" for user \"uid:%d\", because transaction type is transfer in", revisionRevertReq.TransactionId, uid)
+		return nil, errs.ErrTransactionTypeInvalid
+	}
+
+	revision, err := a.revisions.GetRevisionByRevisionId(c, uid, revisionRevertReq.RevisionId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to get revision \"id:%d\" for user \"uid:%d\", because %s", revisionRevertReq.RevisionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if revision.TransactionId != transaction.TransactionId {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] revision \"id:%d\" does not belong to transaction \"id:%d\" for user \"uid:%d\"", revision.RevisionId, transaction.TransactionId, uid)
+		return nil, errs.ErrTransactionRevisionNotFound
+	}
+
+	if revision.Before == nil {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] revision \"id:%d\" of user \"uid:%d\" has no snapshot", revision.RevisionId, uid)
+		return nil, errs.ErrTransactionRevisionSnapshotInvalid
+	}
+
+	snapshot := revision.Before
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE && snapshot.CategoryId != 0 {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] snapshot of revision \"id:%d\" for balance modification transaction has category id", revision.RevisionId)
+		return nil, errs.ErrTransactionRevisionSnapshotInvalid
+	} else if transaction.Type != models.TRANSACTION_DB_TYPE_MODIFY_BALANCE && snapshot.CategoryId == 0 {
+		log.Warnf(c, "[transaction_revisions.TransactionRevisionRevertHandler] snapshot of revision \"id:%d\" for non-balance modification transaction has no category id", revision.RevisionId)
+		return nil, errs.ErrTransactionRevisionSnapshotInvalid
+	}
+
+	allTransactionTagIds, err := a.transactionTags.GetAllTagIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to get transactions tag ids for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionTagIds := allTransactionTagIds[transaction.TransactionId]
+
+	allTransactionItemIndexes, err := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to get transaction item ids for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionItemIds := a.transactionItems.GetGroupedTransactionItemIds(allTransactionItemIndexes[transaction.TransactionId])[transaction.TransactionId]
+
+	transactionPictureInfos, err := a.transactionPictures.GetPictureInfosByTransactionId(c, uid, transaction.TransactionId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to get transaction picture infos for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionPictureIds := a.transactionPictures.GetTransactionPictureIds(transactionPictureInfos)
+
+	newTransaction := &models.Transaction{
+		TransactionId:     transaction.TransactionId,
+		Uid:               uid,
+		CategoryId:        snapshot.CategoryId,
+		TransactionTime:   snapshot.TransactionTime,
+		TimezoneUtcOffset: snapshot.TimezoneUtcOffset,
+		AccountId:         snapshot.AccountId,
+		Amount:            snapshot.Amount,
+		OriginalCurrency:  snapshot.OriginalCurrency,
+		OriginalAmount:    snapshot.OriginalAmount,
+		PayeeId:           snapshot.PayeeId,
+		HideAmount:        snapshot.HideAmount,
+		Comment:           snapshot.Comment,
+		GeoLongitude:      snapshot.GeoLongitude,
+		GeoLatitude:       snapshot.GeoLatitude,
+	}
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		newTransaction.RelatedAccountId = snapshot.RelatedAccountId
+		newTransaction.RelatedAccountAmount = snapshot.RelatedAccountAmount
+	}
+
+	transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone)
+	newTransactionEditable := user.CanEditTransactionByTransactionTime(newTransaction.TransactionTime, clientTimezone)
+
+	if !transactionEditable || !newTransactionEditable {
+		return nil, errs.ErrCannotModifyTransactionWithThisTransactionTime
+	}
+
+	var addTransactionTagIds []int64
+	var removeTransactionTagIds []int64
+
+	currentTagIds := utils.ToUniqueInt64Slice(transactionTagIds)
+	slices.Sort(currentTagIds)
+
+	if !slices.Equal(currentTagIds, snapshot.TagIds) {
+		removeTransactionTagIds = transactionTagIds
+		addTransactionTagIds = snapshot.TagIds
+	}
+
+	snapshotItemIds := snapshot.GetItemIds()
+	addTransactionItemIds := utils.Int64SliceMinus(snapshotItemIds, transactionItemIds)
+	removeTransactionItemIds := utils.Int64SliceMinus(transactionItemIds, snapshotItemIds)
+
+	// pictures removed after the revision have been deleted, so only the pictures added after the revision are removed
+	removeTransactionPictureIds := utils.Int64SliceMinus(transactionPictureIds, snapshot.PictureIds)
+
+	actor := a.GetTransactionRevisionActor(c)
+	actor.RevertedRevisionId = revision.RevisionId
+
+	err = a.transactions.ModifyTransaction(c, newTransaction, len(transactionTagIds), addTransactionTagIds, removeTransactionTagIds, addTransactionItemIds, removeTransactionItemIds, snapshot.GetItemDetails(), nil, removeTransactionPictureIds, snapshot.ToTransactionSplits(), actor)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_revisions.TransactionRevisionRevertHandler] failed to revert transaction \"id:%d\" to revision \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, revision.RevisionId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_revisions.TransactionRevisionRevertHandler] user \"uid:%d\" has reverted transaction \"id:%d\" to revision \"id:%d\" successfully", uid, transaction.TransactionId, revision.RevisionId)
+
+	return true, nil
+}
@@ -0,0 +1,519 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const pageCountForRunningTransactionRules = 1000
+const maximumTransactionsCountOfRunningTransactionRules = 10000
+
+// TransactionRulesApi represents transaction rule api
+type TransactionRulesApi struct {
+	ApiUsingConfig
+	ApiUsingDuplicateChecker
+	rules            *services.TransactionRuleService
+	transactions     *services.TransactionService
+	transactionTags  *services.TransactionTagService
+	transactionItems *services.TransactionItemService
+	users            *services.UserService
+}
+
+// Initialize a transaction rule api singleton instance
+var (
+	TransactionRules = &TransactionRulesApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		ApiUsingDuplicateChecker: ApiUsingDuplicateChecker{
+			ApiUsingConfig: ApiUsingConfig{
+				container: settings.Container,
+			},
+			container: duplicatechecker.Container,
+		},
+		rules:            services.TransactionRules,
+		transactions:     services.Transactions,
+		transactionTags:  services.TransactionTags,
+		transactionItems: services.TransactionItems,
+		users:            services.Users,
+	}
+)
+
+// RuleListHandler returns transaction rule list of current user
+func (a *TransactionRulesApi) RuleListHandler(c *core.WebContext) (any, *errs.Error) {
+	uid := c.GetCurrentUid()
+	rules, err := a.rules.GetAllRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleListHandler] failed to get rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	ruleResps := make(models.TransactionRuleInfoResponseSlice, len(rules))
+
+	for i := 0; i < len(rules); i++ {
+		ruleResps[i] = rules[i].ToTransactionRuleInfoResponse()
+	}
+
+	sort.Sort(ruleResps)
+
+	return ruleResps, nil
+}
+
+// RuleGetHandler returns one specific transaction rule of current user
+func (a *TransactionRulesApi) RuleGetHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleGetReq models.TransactionRuleGetRequest
+	err := c.ShouldBindQuery(&ruleGetReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleGetHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rule, err := a.rules.GetRuleByRuleId(c, uid, ruleGetReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleGetHandler] failed to get rule \"id:%d\" for user \"uid:%d\", because %s", ruleGetReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	return rule.ToTransactionRuleInfoResponse(), nil
+}
+
+// RuleCreateHandler saves a new transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleCreateHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleCreateReq models.TransactionRuleCreateRequest
+	err := c.ShouldBindJSON(&ruleCreateReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleCreateHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	err = a.validateRule(ruleCreateReq.Conditions, ruleCreateReq.Actions)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleCreateHandler] rule is invalid, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrIncompleteOrIncorrectSubmission)
+	}
+
+	uid := c.GetCurrentUid()
+
+	maxOrderId, err := a.rules.GetMaxDisplayOrder(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to get max display order for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	rule := &models.TransactionRule{
+		Uid:            uid,
+		Name:           ruleCreateReq.Name,
+		DisplayOrder:   maxOrderId + 1,
+		Conditions:     ruleCreateReq.Conditions,
+		Actions:        ruleCreateReq.Actions,
+		StopProcessing: ruleCreateReq.StopProcessing,
+	}
+
+	if a.CurrentConfig().EnableDuplicateSubmissionsCheck && ruleCreateReq.ClientSessionId != "" {
+		found, remark := a.GetSubmissionRemark(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION_RULE, uid, ruleCreateReq.ClientSessionId)
+
+		if found {
+			log.Infof(c, "[transaction_rules.RuleCreateHandler] another rule \"id:%s\" has been created for user \"uid:%d\"", remark, uid)
+			ruleId, err := utils.StringToInt64(remark)
+
+			if err == nil {
+				rule, err = a.rules.GetRuleByRuleId(c, uid, ruleId)
+
+				if err != nil {
+					log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to get existed rule \"id:%d\" for user \"uid:%d\", because %s", ruleId, uid, err.Error())
+					return nil, errs.Or(err, errs.ErrOperationFailed)
+				}
+
+				return rule.ToTransactionRuleInfoResponse(), nil
+			}
+		}
+	}
+
+	err = a.rules.CreateRule(c, rule)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleCreateHandler] failed to create rule \"id:%d\" for user \"uid:%d\", because %s", rule.RuleId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleCreateHandler] user \"uid:%d\" has created a new rule \"id:%d\" successfully", uid, rule.RuleId)
+
+	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION_RULE, uid, ruleCreateReq.ClientSessionId, utils.Int64ToString(rule.RuleId))
+
+	return rule.ToTransactionRuleInfoResponse(), nil
+}
+
+// RuleModifyHandler saves an existed transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleModifyHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleModifyReq models.TransactionRuleModifyRequest
+	err := c.ShouldBindJSON(&ruleModifyReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleModifyHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	err = a.validateRule(ruleModifyReq.Conditions, ruleModifyReq.Actions)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleModifyHandler] rule is invalid, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrIncompleteOrIncorrectSubmission)
+	}
+
+	uid := c.GetCurrentUid()
+	rule, err := a.rules.GetRuleByRuleId(c, uid, ruleModifyReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleModifyHandler] failed to get rule \"id:%d\" for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newRule := &models.TransactionRule{
+		RuleId:         rule.RuleId,
+		Uid:            uid,
+		Name:           ruleModifyReq.Name,
+		DisplayOrder:   rule.DisplayOrder,
+		Conditions:     ruleModifyReq.Conditions,
+		Actions:        ruleModifyReq.Actions,
+		StopProcessing: ruleModifyReq.StopProcessing,
+		Disabled:       ruleModifyReq.Disabled,
+	}
+
+	err = a.rules.ModifyRule(c, newRule)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleModifyHandler] failed to update rule \"id:%d\" for user \"uid:%d\", because %s", ruleModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleModifyHandler] user \"uid:%d\" has updated rule \"id:%d\" successfully", uid, ruleModifyReq.Id)
+
+	return newRule.ToTransactionRuleInfoResponse(), nil
+}
+
+// RuleMoveHandler moves display order of existed transaction rules by request parameters for current user
+func (a *TransactionRulesApi) RuleMoveHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleMoveReq models.TransactionRuleMoveRequest
+	err := c.ShouldBindJSON(&ruleMoveReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleMoveHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	rules := make([]*models.TransactionRule, len(ruleMoveReq.NewDisplayOrders))
+
+	for i := 0; i < len(ruleMoveReq.NewDisplayOrders); i++ {
+		newDisplayOrder := ruleMoveReq.NewDisplayOrders[i]
+		rule := &models.TransactionRule{
+			Uid:          uid,
+			RuleId:       newDisplayOrder.Id,
+			DisplayOrder: newDisplayOrder.DisplayOrder,
+		}
+
+		rules[i] = rule
+	}
+
+	err = a.rules.ModifyRuleDisplayOrders(c, uid, rules)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleMoveHandler] failed to move rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleMoveHandler] user \"uid:%d\" has moved rules", uid)
+	return true, nil
+}
+
+// RuleDeleteHandler deletes an existed transaction rule by request parameters for current user
+func (a *TransactionRulesApi) RuleDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleDeleteReq models.TransactionRuleDeleteRequest
+	err := c.ShouldBindJSON(&ruleDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.rules.DeleteRule(c, uid, ruleDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleDeleteHandler] failed to delete rule \"id:%d\" for user \"uid:%d\", because %s", ruleDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_rules.RuleDeleteHandler] user \"uid:%d\" has deleted rule \"id:%d\"", uid, ruleDeleteReq.Id)
+	return true, nil
+}
+
+// RuleRunHandler applies transaction rules to existed transactions of current user, or only returns the changes if it is a dry run
+func (a *TransactionRulesApi) RuleRunHandler(c *core.WebContext) (any, *errs.Error) {
+	var ruleRunReq models.TransactionRuleRunRequest
+	err := c.ShouldBindJSON(&ruleRunReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleRunHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if ruleRunReq.StartTime > 0 && ruleRunReq.EndTime > 0 && ruleRunReq.StartTime > ruleRunReq.EndTime {
+		return nil, errs.ErrIncompleteOrIncorrectSubmission
+	}
+
+	ruleIds, err := utils.StringArrayToInt64Array(ruleRunReq.RuleIds)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleRunHandler] parse rule ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionRuleIdInvalid
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transaction_rules.RuleRunHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transaction_rules.RuleRunHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	rules, err := a.getRulesToRun(c, uid, ruleIds)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleRunHandler] failed to get rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	maxTransactionTime := int64(0)
+	minTransactionTime := int64(0)
+
+	if ruleRunReq.EndTime > 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(ruleRunReq.EndTime)
+	}
+
+	if ruleRunReq.StartTime > 0 {
+		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(ruleRunReq.StartTime)
+	}
+
+	transactions, err := a.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, 0, nil, nil, nil, nil, false, "", "", pageCountForRunningTransactionRules, true)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleRunHandler] failed to get transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if len(transactions) > maximumTransactionsCountOfRunningTransactionRules {
+		return nil, errs.ErrTransactionRuleRunTooManyTransactions
+	}
+
+	transactionIds := a.transactions.GetTransactionIds(transactions)
+	allTransactionTagIds, err := a.transactionTags.GetAllTagIdsOfTransactions(c, uid, transactionIds)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleRunHandler] failed to get transactions tag ids for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	allTransactionItemIndexes, err := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, transactionIds)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_rules.RuleRunHandler] failed to get transactions item indexes for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	response := &models.TransactionRuleRunResponse{
+		DryRun:  ruleRunReq.DryRun,
+		Changes: make([]*models.TransactionRuleChangeResponse, 0),
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		transactionType, err := transaction.Type.ToTransactionType()
+
+		if err != nil {
+			continue
+		}
+
+		itemIndexes := allTransactionItemIndexes[transaction.TransactionId]
+		itemIds := make([]int64, len(itemIndexes))
+
+		for j := 0; j < len(itemIndexes); j++ {
+			itemIds[j] = itemIndexes[j].ItemId
+		}
+
+		originalTarget := &models.TransactionRuleTarget{
+			Type:                 transactionType,
+			CategoryId:           transaction.CategoryId,
+			AccountId:            transaction.AccountId,
+			DestinationAccountId: transaction.RelatedAccountId,
+			Amount:               transaction.Amount,
+			HideAmount:           transaction.HideAmount,
+			Comment:              transaction.Comment,
+			TagIds:               allTransactionTagIds[transaction.TransactionId],
+			ItemIds:              itemIds,
+		}
+
+		if transactionType != models.TRANSACTION_TYPE_TRANSFER {
+			originalTarget.DestinationAccountId = 0
+		}
+
+		target := originalTarget.Clone()
+		appliedRuleIds := models.ApplyTransactionRules(rules, target)
+
+		if len(appliedRuleIds) < 1 {
+			continue
+		}
+
+		response.MatchedCount++
+
+		if target.IsSameContent(originalTarget) {
+			continue
+		}
+
+		response.ModifiedCount++
+
+		change := &models.TransactionRuleChangeResponse{
+			TransactionId: transaction.TransactionId,
+			Time:          utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime),
+			RuleIds:       utils.Int64ArrayToStringArray(appliedRuleIds),
+			Before:        originalTarget.ToTransactionRuleTargetResponse(),
+			After:         target.ToTransactionRuleTargetResponse(),
+		}
+
+		response.Changes = append(response.Changes, change)
+
+		if ruleRunReq.DryRun {
+			continue
+		}
+
+		if !user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone) {
+			response.FailedCount++
+			continue
+		}
+
+		err = a.modifyTransactionByRuleTarget(c, transaction, originalTarget, target, itemIndexes)
+
+		if err != nil {
+			log.Warnf(c, "[transaction_rules.RuleRunHandler] failed to apply rules to transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
+			response.FailedCount++
+			continue
+		}
+
+		change.Applied = true
+	}
+
+	if !ruleRunReq.DryRun {
+		log.Infof(c, "[transaction_rules.RuleRunHandler] user \"uid:%d\" has applied rules to %d transactions, %d transactions failed", uid, response.ModifiedCount-response.FailedCount, response.FailedCount)
+	}
+
+	return response, nil
+}
+
+func (a *TransactionRulesApi) validateRule(conditions *models.TransactionRuleConditions, actions *models.TransactionRuleActions) error {
+	err := conditions.Validate()
+
+	if err != nil {
+		return err
+	}
+
+	return actions.Validate(conditions)
+}
+
+func (a *TransactionRulesApi) getRulesToRun(c *core.WebContext, uid int64, ruleIds []int64) ([]*models.TransactionRule, error) {
+	if len(ruleIds) < 1 {
+		return a.rules.GetAllEnabledRulesByUid(c, uid)
+	}
+
+	allRules, err := a.rules.GetAllRulesByUid(c, uid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ruleMap := make(map[int64]*models.TransactionRule, len(allRules))
+
+	for i := 0; i < len(allRules); i++ {
+		ruleMap[allRules[i].RuleId] = allRules[i]
+	}
+
+	rules := make([]*models.TransactionRule, 0, len(ruleIds))
+
+	for i := 0; i < len(ruleIds); i++ {
+		rule, exists := ruleMap[ruleIds[i]]
+
+		if !exists {
+			return nil, errs.ErrTransactionRuleNotFound
+		}
+
+		// the specified rules would be run even if they are disabled, so that user can preview the rules before enabling them
+		rule.Disabled = false
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (a *TransactionRulesApi) modifyTransactionByRuleTarget(c *core.WebContext, transaction *models.Transaction, originalTarget *models.TransactionRuleTarget, target *models.TransactionRuleTarget, itemIndexes []*models.TransactionItemIndex) error {
+	allTransactionSplits, err := a.transactions.GetSplitsOfTransactions(c, transaction.Uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		return err
+	}
+
+	itemDetails := make(map[int64]*models.TransactionItemIndex, len(itemIndexes))
+
+	for i := 0; i < len(itemIndexes); i++ {
+		itemDetails[itemIndexes[i].ItemId] = itemIndexes[i]
+	}
+
+	newTransaction := &models.Transaction{
+		TransactionId:        transaction.TransactionId,
+		Uid:                  transaction.Uid,
+		CategoryId:           target.CategoryId,
+		TransactionTime:      transaction.TransactionTime,
+		TimezoneUtcOffset:    transaction.TimezoneUtcOffset,
+		AccountId:            transaction.AccountId,
+		Amount:               transaction.Amount,
+		RelatedAccountId:     transaction.RelatedAccountId,
+		RelatedAccountAmount: transaction.RelatedAccountAmount,
+		PayeeId:              transaction.PayeeId,
+		HideAmount:           target.HideAmount,
+		Comment:              target.Comment,
+		GeoLongitude:         transaction.GeoLongitude,
+		GeoLatitude:          transaction.GeoLatitude,
+	}
+
+	if target.Type == models.TRANSACTION_TYPE_TRANSFER {
+		newTransaction.RelatedAccountId = target.DestinationAccountId
+	}
+
+	addTagIds := utils.Int64SliceMinus(target.TagIds, originalTarget.TagIds)
+	addItemIds := utils.Int64SliceMinus(target.ItemIds, originalTarget.ItemIds)
+
+	return a.transactions.ModifyTransaction(c, newTransaction, len(originalTarget.TagIds), addTagIds, nil, addItemIds, nil, itemDetails, nil, nil, allTransactionSplits[transaction.TransactionId], a.GetTransactionRevisionActor(c))
+}
@@ -21,7 +21,9 @@ const maximumTagsCountOfTemplate = 10
 type TransactionTemplatesApi struct {
 	ApiUsingConfig
 	ApiUsingDuplicateChecker
-	templates *services.TransactionTemplateService
+	templates    *services.TransactionTemplateService
+	occurrences  *services.TransactionTemplateOccurrenceService
+	transactions *services.TransactionService
 }
 
 // Initialize a transaction template api singleton instance
@@ -36,7 +38,9 @@ var (
 			},
 			container: duplicatechecker.Container,
 		},
-		templates: services.TransactionTemplates,
+		templates:    services.TransactionTemplates,
+		occurrences:  services.TransactionTemplateOccurrences,
+		transactions: services.Transactions,
 	}
 )
 
@@ -107,6 +111,55 @@ func (a *TransactionTemplatesApi) TemplateGetHandler(c *core.WebContext) (any, *
 	return templateResp, nil
 }
 
+// TemplateUpcomingOccurrenceListHandler returns upcoming transactions which will be created by scheduled transaction templates of current user
+func (a *TransactionTemplatesApi) TemplateUpcomingOccurrenceListHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrenceListReq models.ScheduledTransactionUpcomingOccurrenceListRequest
+	err := c.ShouldBindQuery(&occurrenceListReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateUpcomingOccurrenceListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateUpcomingOccurrenceListHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	templates, err := a.templates.GetAllTemplatesByUid(c, uid, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateUpcomingOccurrenceListHandler] failed to get templates for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	templateOccurrences, err := a.occurrences.GetAllUnprocessedOccurrencesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateUpcomingOccurrenceListHandler] failed to get template occurrences for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	startUnixTime := time.Now().Unix()
+	endUnixTime := models.GetCashFlowForecastEndTime(startUnixTime, occurrenceListReq.GetHorizonDays(), clientTimezone)
+	occurrences := models.GetScheduledTransactionOccurrences(templates, startUnixTime, endUnixTime)
+	occurrences = models.ApplyTransactionTemplateOccurrences(occurrences, templateOccurrences, templates, startUnixTime, endUnixTime)
+	occurrenceResps := make([]*models.ScheduledTransactionOccurrenceInfoResponse, len(occurrences))
+
+	for i := 0; i < len(occurrences); i++ {
+		occurrenceResps[i] = occurrences[i].ToScheduledTransactionOccurrenceInfoResponse()
+	}
+
+	return occurrenceResps, nil
+}
+
 // TemplateCreateHandler saves a new transaction template by request parameters for current user
 func (a *TransactionTemplatesApi) TemplateCreateHandler(c *core.WebContext) (any, *errs.Error) {
 	var templateCreateReq models.TransactionTemplateCreateRequest
@@ -143,6 +196,12 @@ func (a *TransactionTemplatesApi) TemplateCreateHandler(c *core.WebContext) (any
 		} else if *templateCreateReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateCreateReq.ScheduledFrequency == "" {
 			return nil, errs.ErrScheduledTransactionFrequencyInvalid
 		}
+
+		if *templateCreateReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED {
+			if _, err := models.GetTransactionScheduleRule(*templateCreateReq.ScheduledFrequencyType, *templateCreateReq.ScheduledFrequency); err != nil {
+				return nil, errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
 	}
 
 	if len(templateCreateReq.TagIds) > maximumTagsCountOfTemplate {
@@ -242,6 +301,12 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 		} else if *templateModifyReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED && *templateModifyReq.ScheduledFrequency == "" {
 			return nil, errs.ErrScheduledTransactionFrequencyInvalid
 		}
+
+		if *templateModifyReq.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED {
+			if _, err := models.GetTransactionScheduleRule(*templateModifyReq.ScheduledFrequencyType, *templateModifyReq.ScheduledFrequency); err != nil {
+				return nil, errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
 	}
 
 	if len(templateModifyReq.TagIds) > maximumTagsCountOfTemplate {
@@ -265,7 +330,8 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 
 	if template.TemplateType == models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE {
 		newTemplate.ScheduledFrequencyType = *templateModifyReq.ScheduledFrequencyType
-		newTemplate.ScheduledFrequency = a.getOrderedFrequencyValues(*templateModifyReq.ScheduledFrequency)
+		newTemplate.ScheduledNeedConfirm = templateModifyReq.ScheduledNeedConfirm
+		newTemplate.ScheduledFrequency = a.getNormalizedFrequency(*templateModifyReq.ScheduledFrequencyType, *templateModifyReq.ScheduledFrequency)
 		newTemplate.ScheduledAt = a.getUTCScheduledAt(*templateModifyReq.ScheduledTimezoneUtcOffset)
 		newTemplate.ScheduledTimezoneUtcOffset = *templateModifyReq.ScheduledTimezoneUtcOffset
 
@@ -316,7 +382,8 @@ func (a *TransactionTemplatesApi) TemplateModifyHandler(c *core.WebContext) (any
 				newTemplate.ScheduledStartTime == template.ScheduledStartTime &&
 				newTemplate.ScheduledEndTime == template.ScheduledEndTime &&
 				newTemplate.ScheduledAt == template.ScheduledAt &&
-				newTemplate.ScheduledTimezoneUtcOffset == template.ScheduledTimezoneUtcOffset {
+				newTemplate.ScheduledTimezoneUtcOffset == template.ScheduledTimezoneUtcOffset &&
+				newTemplate.ScheduledNeedConfirm == template.ScheduledNeedConfirm {
 				return nil, errs.ErrNothingWillBeUpdated
 			}
 		}
@@ -457,6 +524,215 @@ func (a *TransactionTemplatesApi) TemplateDeleteHandler(c *core.WebContext) (any
 	return true, nil
 }
 
+// TemplateOccurrenceListHandler returns stored occurrences of scheduled transaction templates of current user
+func (a *TransactionTemplatesApi) TemplateOccurrenceListHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrenceListReq models.TransactionTemplateOccurrenceListRequest
+	err := c.ShouldBindQuery(&occurrenceListReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateOccurrenceListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	uid := c.GetCurrentUid()
+	templates, err := a.templates.GetAllTemplatesByUid(c, uid, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceListHandler] failed to get templates for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	occurrences, err := a.occurrences.GetAllOccurrencesByUid(c, uid, occurrenceListReq.Status)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceListHandler] failed to get template occurrences for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	templateMap := make(map[int64]*models.TransactionTemplate, len(templates))
+
+	for i := 0; i < len(templates); i++ {
+		templateMap[templates[i].TemplateId] = templates[i]
+	}
+
+	occurrenceResps := make([]*models.TransactionTemplateOccurrenceInfoResponse, 0, len(occurrences))
+
+	for i := 0; i < len(occurrences); i++ {
+		template, exists := templateMap[occurrences[i].TemplateId]
+
+		if !exists {
+			continue
+		}
+
+		occurrenceResps = append(occurrenceResps, occurrences[i].ToTransactionTemplateOccurrenceInfoResponse(template))
+	}
+
+	return occurrenceResps, nil
+}
+
+// TemplateOccurrenceConfirmHandler creates the transaction of a pending scheduled transaction template occurrence for current user
+func (a *TransactionTemplatesApi) TemplateOccurrenceConfirmHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrenceConfirmReq models.TransactionTemplateOccurrenceConfirmRequest
+	err := c.ShouldBindJSON(&occurrenceConfirmReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateOccurrenceConfirmHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	uid := c.GetCurrentUid()
+	occurrence, err := a.occurrences.GetOccurrenceByOccurrenceId(c, uid, occurrenceConfirmReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceConfirmHandler] failed to get template occurrence \"id:%d\" for user \"uid:%d\", because %s", occurrenceConfirmReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if occurrence.Status != models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING {
+		return nil, errs.ErrTransactionTemplateOccurrenceNotPending
+	}
+
+	template, err := a.templates.GetTemplateByTemplateId(c, uid, occurrence.TemplateId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceConfirmHandler] failed to get template \"id:%d\" for user \"uid:%d\", because %s", occurrence.TemplateId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transaction, err := template.ToScheduledTransaction(occurrence.GetTransactionUnixTime())
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateOccurrenceConfirmHandler] template \"id:%d\" has invalid transaction type", template.TemplateId)
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if occurrenceConfirmReq.SourceAmount != nil {
+		transaction.Amount = *occurrenceConfirmReq.SourceAmount
+	}
+
+	if occurrenceConfirmReq.DestinationAmount != nil && template.Type == models.TRANSACTION_TYPE_TRANSFER {
+		transaction.RelatedAccountAmount = *occurrenceConfirmReq.DestinationAmount
+	}
+
+	transaction.CreatedIp = c.ClientIP()
+
+	err = a.transactions.CreateTransactionOfOccurrence(c, transaction, template.GetTagIds(), occurrence, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING, a.GetTransactionRevisionActor(c))
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceConfirmHandler] failed to create transaction and confirm template occurrence \"id:%d\" of user \"uid:%d\", because %s", occurrence.OccurrenceId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_templates.TemplateOccurrenceConfirmHandler] user \"uid:%d\" has confirmed template occurrence \"id:%d\" and created transaction \"id:%d\"", uid, occurrence.OccurrenceId, transaction.TransactionId)
+
+	return occurrence.ToTransactionTemplateOccurrenceInfoResponse(template), nil
+}
+
+// TemplateOccurrenceSkipHandler skips a pending scheduled transaction template occurrence for current user
+func (a *TransactionTemplatesApi) TemplateOccurrenceSkipHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrenceSkipReq models.TransactionTemplateOccurrenceSkipRequest
+	err := c.ShouldBindJSON(&occurrenceSkipReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateOccurrenceSkipHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.occurrences.SkipPendingOccurrence(c, uid, occurrenceSkipReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceSkipHandler] failed to skip template occurrence \"id:%d\" for user \"uid:%d\", because %s", occurrenceSkipReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_templates.TemplateOccurrenceSkipHandler] user \"uid:%d\" has skipped template occurrence \"id:%d\"", uid, occurrenceSkipReq.Id)
+	return true, nil
+}
+
+// TemplateUpcomingOccurrenceSkipHandler skips a single upcoming occurrence of scheduled transaction template for current user
+func (a *TransactionTemplatesApi) TemplateUpcomingOccurrenceSkipHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrenceSkipReq models.TransactionTemplateUpcomingOccurrenceSkipRequest
+	err := c.ShouldBindJSON(&occurrenceSkipReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateUpcomingOccurrenceSkipHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	occurrence := &models.TransactionTemplateOccurrence{
+		Uid:           c.GetCurrentUid(),
+		TemplateId:    occurrenceSkipReq.TemplateId,
+		Status:        models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_SKIPPED,
+		ScheduledTime: occurrenceSkipReq.Time,
+	}
+
+	return a.createUpcomingOccurrence(c, "TemplateUpcomingOccurrenceSkipHandler", occurrence)
+}
+
+// TemplateUpcomingOccurrencePostponeHandler postpones a single upcoming occurrence of scheduled transaction template to another time for current user
+func (a *TransactionTemplatesApi) TemplateUpcomingOccurrencePostponeHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrencePostponeReq models.TransactionTemplateUpcomingOccurrencePostponeRequest
+	err := c.ShouldBindJSON(&occurrencePostponeReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateUpcomingOccurrencePostponeHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if occurrencePostponeReq.PostponedTime <= occurrencePostponeReq.Time {
+		return nil, errs.ErrTransactionTemplateOccurrencePostponedTimeInvalid
+	}
+
+	occurrence := &models.TransactionTemplateOccurrence{
+		Uid:           c.GetCurrentUid(),
+		TemplateId:    occurrencePostponeReq.TemplateId,
+		Status:        models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED,
+		ScheduledTime: occurrencePostponeReq.Time,
+		PostponedTime: occurrencePostponeReq.PostponedTime,
+	}
+
+	return a.createUpcomingOccurrence(c, "TemplateUpcomingOccurrencePostponeHandler", occurrence)
+}
+
+// TemplateOccurrenceDeleteHandler deletes a skipped or postponed upcoming occurrence, so that it will be created as scheduled again
+func (a *TransactionTemplatesApi) TemplateOccurrenceDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var occurrenceDeleteReq models.TransactionTemplateOccurrenceDeleteRequest
+	err := c.ShouldBindJSON(&occurrenceDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[transaction_templates.TemplateOccurrenceDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	uid := c.GetCurrentUid()
+	err = a.occurrences.DeleteOccurrence(c, uid, occurrenceDeleteReq.Id)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.TemplateOccurrenceDeleteHandler] failed to delete template occurrence \"id:%d\" for user \"uid:%d\", because %s", occurrenceDeleteReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_templates.TemplateOccurrenceDeleteHandler] user \"uid:%d\" has deleted template occurrence \"id:%d\"", uid, occurrenceDeleteReq.Id)
+	return true, nil
+}
+
 func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCreateReq *models.TransactionTemplateCreateRequest, order int32) (*models.TransactionTemplate, error) {
 	template := &models.TransactionTemplate{
 		Uid:                  uid,
@@ -476,7 +752,8 @@ func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCrea
 
 	if templateCreateReq.TemplateType == models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE {
 		template.ScheduledFrequencyType = *templateCreateReq.ScheduledFrequencyType
-		template.ScheduledFrequency = a.getOrderedFrequencyValues(*templateCreateReq.ScheduledFrequency)
+		template.ScheduledNeedConfirm = templateCreateReq.ScheduledNeedConfirm
+		template.ScheduledFrequency = a.getNormalizedFrequency(*templateCreateReq.ScheduledFrequencyType, *templateCreateReq.ScheduledFrequency)
 		template.ScheduledAt = a.getUTCScheduledAt(*templateCreateReq.ScheduledTimezoneUtcOffset)
 		template.ScheduledTimezoneUtcOffset = *templateCreateReq.ScheduledTimezoneUtcOffset
 
@@ -510,6 +787,51 @@ func (a *TransactionTemplatesApi) createNewTemplateModel(uid int64, templateCrea
 	return template, nil
 }
 
+func (a *TransactionTemplatesApi) createUpcomingOccurrence(c *core.WebContext, handlerName string, occurrence *models.TransactionTemplateOccurrence) (any, *errs.Error) {
+	if !a.CurrentConfig().EnableScheduledTransaction {
+		return nil, errs.ErrScheduledTransactionNotEnabled
+	}
+
+	now := time.Now().Unix()
+
+	if occurrence.ScheduledTime <= now {
+		return nil, errs.ErrTransactionTemplateOccurrenceTimeInvalid
+	}
+
+	if occurrence.Status == models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED && occurrence.PostponedTime <= now {
+		return nil, errs.ErrTransactionTemplateOccurrencePostponedTimeInvalid
+	}
+
+	uid := occurrence.Uid
+	template, err := a.templates.GetTemplateByTemplateId(c, uid, occurrence.TemplateId)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.%s] failed to get template \"id:%d\" for user \"uid:%d\", because %s", handlerName, occurrence.TemplateId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if template.TemplateType != models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE {
+		return nil, errs.ErrTransactionTemplateNotFound
+	}
+
+	scheduledOccurrences := models.GetScheduledTransactionOccurrences([]*models.TransactionTemplate{template}, occurrence.ScheduledTime, occurrence.ScheduledTime)
+
+	if len(scheduledOccurrences) < 1 {
+		return nil, errs.ErrTransactionTemplateOccurrenceTimeInvalid
+	}
+
+	err = a.occurrences.CreateOccurrence(c, occurrence)
+
+	if err != nil {
+		log.Errorf(c, "[transaction_templates.%s] failed to create occurrence of template \"id:%d\" for user \"uid:%d\", because %s", handlerName, occurrence.TemplateId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transaction_templates.%s] user \"uid:%d\" has created occurrence \"id:%d\" of template \"id:%d\"", handlerName, uid, occurrence.OccurrenceId, occurrence.TemplateId)
+
+	return occurrence.ToTransactionTemplateOccurrenceInfoResponse(template), nil
+}
+
 func (a *TransactionTemplatesApi) getUTCScheduledAt(scheduledTimezoneUtcOffset int16) int16 {
 	templateTimeZone := time.FixedZone("Template Timezone", int(scheduledTimezoneUtcOffset)*60)
 	transactionTime := time.Date(2020, 1, 1, 0, 0, 0, 0, templateTimeZone)
@@ -520,6 +842,20 @@ func (a *TransactionTemplatesApi) getUTCScheduledAt(scheduledTimezoneUtcOffset i
 	return int16(minutesElapsedOfDayInUtc)
 }
 
+func (a *TransactionTemplatesApi) getNormalizedFrequency(frequencyType models.TransactionScheduleFrequencyType, frequencyValue string) string {
+	if frequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RULE {
+		return a.getOrderedFrequencyValues(frequencyValue)
+	}
+
+	rule, err := models.ParseTransactionScheduleRule(frequencyValue)
+
+	if err != nil {
+		return frequencyValue
+	}
+
+	return rule.String()
+}
+
 func (a *TransactionTemplatesApi) getOrderedFrequencyValues(frequencyValue string) string {
 	if frequencyValue == "" {
 		return ""
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 
@@ -17,11 +18,13 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/duplicatechecker"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
 )
 
 const pageCountForAccountStatement = 1000
@@ -37,6 +40,10 @@ type TransactionsApi struct {
 	transactionPictures   *services.TransactionPictureService
 	accounts              *services.AccountService
 	users                 *services.UserService
+	securities            *services.SecurityService
+	investments           *services.InvestmentTransactionService
+	transactionRules      *services.TransactionRuleService
+	payees                *services.PayeeService
 }
 
 // Initialize a transaction api singleton instance
@@ -58,6 +65,10 @@ var (
 		transactionPictures:   services.TransactionPictures,
 		accounts:              services.Accounts,
 		users:                 services.Users,
+		securities:            services.Securities,
+		investments:           services.InvestmentTransactions,
+		transactionRules:      services.TransactionRules,
+		payees:                services.Payees,
 	}
 )
 
@@ -71,6 +82,13 @@ func (a *TransactionsApi) TransactionCountHandler(c *core.WebContext) (any, *err
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCountHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
 	uid := c.GetCurrentUid()
 
 	allAccountIds, err := a.accounts.GetAccountOrSubAccountIds(c, transactionCountReq.AccountIds, uid)
@@ -87,6 +105,13 @@ func (a *TransactionsApi) TransactionCountHandler(c *core.WebContext) (any, *err
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(transactionCountReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCountHandler] get payee ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	noTags := transactionCountReq.TagFilter == models.TransactionNoTagFilterValue
 	var tagFilters []*models.TransactionTagFilter
 
@@ -99,7 +124,31 @@ func (a *TransactionsApi) TransactionCountHandler(c *core.WebContext) (any, *err
 		}
 	}
 
-	totalCount, err := a.transactions.GetTransactionCount(c, uid, transactionCountReq.MaxTime, transactionCountReq.MinTime, transactionCountReq.Type, allCategoryIds, allAccountIds, tagFilters, noTags, transactionCountReq.AmountFilter, transactionCountReq.Keyword)
+	searchConditions := &models.TransactionSearchConditions{
+		Type:               transactionCountReq.Type,
+		MaxTransactionTime: transactionCountReq.MaxTime,
+		MinTransactionTime: transactionCountReq.MinTime,
+		CategoryIds:        allCategoryIds,
+		AccountIds:         allAccountIds,
+		PayeeIds:           payeeIds,
+		TagFilters:         tagFilters,
+		NoTags:             noTags,
+		AmountFilter:       transactionCountReq.AmountFilter,
+		Keyword:            transactionCountReq.Keyword,
+	}
+
+	if transactionCountReq.SavedSearchId > 0 || transactionCountReq.SearchQuery != "" {
+		querySearchConditions, err := a.transactions.GetTransactionSearchConditions(c, uid, transactionCountReq.SavedSearchId, transactionCountReq.SearchQuery, clientTimezone)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionCountHandler] parse transaction search query error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
+	totalCount, err := a.transactions.GetTransactionCount(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionCountHandler] failed to get transaction count for user \"uid:%d\", because %s", uid, err.Error())
@@ -155,6 +204,13 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(transactionListReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionListHandler] get payee ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	noTags := transactionListReq.TagFilter == models.TransactionNoTagFilterValue
 	var tagFilters []*models.TransactionTagFilter
 
@@ -167,10 +223,34 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 		}
 	}
 
+	searchConditions := &models.TransactionSearchConditions{
+		Type:               transactionListReq.Type,
+		MaxTransactionTime: transactionListReq.MaxTime,
+		MinTransactionTime: transactionListReq.MinTime,
+		CategoryIds:        allCategoryIds,
+		AccountIds:         allAccountIds,
+		PayeeIds:           payeeIds,
+		TagFilters:         tagFilters,
+		NoTags:             noTags,
+		AmountFilter:       transactionListReq.AmountFilter,
+		Keyword:            transactionListReq.Keyword,
+	}
+
+	if transactionListReq.SavedSearchId > 0 || transactionListReq.SearchQuery != "" {
+		querySearchConditions, err := a.transactions.GetTransactionSearchConditions(c, uid, transactionListReq.SavedSearchId, transactionListReq.SearchQuery, clientTimezone)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionListHandler] parse transaction search query error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
 	var totalCount int64
 
 	if transactionListReq.WithCount {
-		totalCount, err = a.transactions.GetTransactionCount(c, uid, transactionListReq.MaxTime, transactionListReq.MinTime, transactionListReq.Type, allCategoryIds, allAccountIds, tagFilters, noTags, transactionListReq.AmountFilter, transactionListReq.Keyword)
+		totalCount, err = a.transactions.GetTransactionCount(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword)
 
 		if err != nil {
 			log.Errorf(c, "[transactions.TransactionListHandler] failed to get transaction count for user \"uid:%d\", because %s", uid, err.Error())
@@ -178,7 +258,7 @@ func (a *TransactionsApi) TransactionListHandler(c *core.WebContext) (any, *errs
 		}
 	}
 
-	transactions, err := a.transactions.GetTransactionsByMaxTime(c, uid, transactionListReq.MaxTime, transactionListReq.MinTime, transactionListReq.Type, allCategoryIds, allAccountIds, tagFilters, noTags, transactionListReq.AmountFilter, transactionListReq.Keyword, transactionListReq.Page, transactionListReq.Count, true, true)
+	transactions, err := a.transactions.GetTransactionsByMaxTime(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword, transactionListReq.Page, transactionListReq.Count, true, true)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionListHandler] failed to get transactions earlier than \"%d\" for user \"uid:%d\", because %s", transactionListReq.MaxTime, uid, err.Error())
@@ -258,6 +338,13 @@ func (a *TransactionsApi) TransactionMonthListHandler(c *core.WebContext) (any,
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(transactionListReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionMonthListHandler] get payee ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	noTags := transactionListReq.TagFilter == models.TransactionNoTagFilterValue
 	var tagFilters []*models.TransactionTagFilter
 
@@ -270,7 +357,7 @@ func (a *TransactionsApi) TransactionMonthListHandler(c *core.WebContext) (any,
 		}
 	}
 
-	transactions, err := a.transactions.GetTransactionsInMonthByPage(c, uid, transactionListReq.Year, transactionListReq.Month, transactionListReq.Type, allCategoryIds, allAccountIds, tagFilters, noTags, transactionListReq.AmountFilter, transactionListReq.Keyword)
+	transactions, err := a.transactions.GetTransactionsInMonthByPage(c, uid, transactionListReq.Year, transactionListReq.Month, transactionListReq.Type, allCategoryIds, allAccountIds, payeeIds, tagFilters, noTags, transactionListReq.AmountFilter, transactionListReq.Keyword)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionMonthListHandler] failed to get transactions in month \"%d-%d\" for user \"uid:%d\", because %s", transactionListReq.Year, transactionListReq.Month, uid, err.Error())
@@ -334,6 +421,13 @@ func (a *TransactionsApi) TransactionListAllHandler(c *core.WebContext) (any, *e
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(transactionAllListReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionListAllHandler] get payee ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	noTags := transactionAllListReq.TagFilter == models.TransactionNoTagFilterValue
 	var tagFilters []*models.TransactionTagFilter
 
@@ -357,7 +451,31 @@ func (a *TransactionsApi) TransactionListAllHandler(c *core.WebContext) (any, *e
 		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(transactionAllListReq.StartTime)
 	}
 
-	allTransactions, err := a.transactions.GetAllSpecifiedTransactions(c, uid, maxTransactionTime, minTransactionTime, transactionAllListReq.Type, allCategoryIds, allAccountIds, tagFilters, noTags, transactionAllListReq.AmountFilter, transactionAllListReq.Keyword, pageCountForDataExport, true)
+	searchConditions := &models.TransactionSearchConditions{
+		Type:               transactionAllListReq.Type,
+		MaxTransactionTime: maxTransactionTime,
+		MinTransactionTime: minTransactionTime,
+		CategoryIds:        allCategoryIds,
+		AccountIds:         allAccountIds,
+		PayeeIds:           payeeIds,
+		TagFilters:         tagFilters,
+		NoTags:             noTags,
+		AmountFilter:       transactionAllListReq.AmountFilter,
+		Keyword:            transactionAllListReq.Keyword,
+	}
+
+	if transactionAllListReq.SavedSearchId > 0 || transactionAllListReq.SearchQuery != "" {
+		querySearchConditions, err := a.transactions.GetTransactionSearchConditions(c, uid, transactionAllListReq.SavedSearchId, transactionAllListReq.SearchQuery, clientTimezone)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionListAllHandler] parse transaction search query error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
+	allTransactions, err := a.transactions.GetAllSpecifiedTransactions(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword, pageCountForDataExport, true)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionListAllHandler] failed to get all transactions for user \"uid:%d\", because %s", uid, err.Error())
@@ -482,6 +600,68 @@ func (a *TransactionsApi) TransactionReconciliationStatementHandler(c *core.WebC
 	return reconciliationStatementResp, nil
 }
 
+// TransactionCreditCardStatementListHandler returns the latest closed statements of specified credit card account of current user
+func (a *TransactionsApi) TransactionCreditCardStatementListHandler(c *core.WebContext) (any, *errs.Error) {
+	var statementListReq models.CreditCardStatementListRequest
+	err := c.ShouldBindQuery(&statementListReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCreditCardStatementListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCreditCardStatementListHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	account, err := a.accounts.GetAccountByAccountId(c, uid, statementListReq.AccountId)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionCreditCardStatementListHandler] failed to get account \"id:%d\" for user \"uid:%d\", because %s", statementListReq.AccountId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if account.Type != models.ACCOUNT_TYPE_SINGLE_ACCOUNT || account.ParentAccountId != models.LevelOneAccountParentId {
+		log.Warnf(c, "[transactions.TransactionCreditCardStatementListHandler] account \"id:%d\" for user \"uid:%d\" is not a single account", statementListReq.AccountId, uid)
+		return nil, errs.ErrAccountTypeInvalid
+	}
+
+	if account.Category != models.ACCOUNT_CATEGORY_CREDIT_CARD {
+		log.Warnf(c, "[transactions.TransactionCreditCardStatementListHandler] account \"id:%d\" for user \"uid:%d\" is not a credit card account", statementListReq.AccountId, uid)
+		return nil, errs.ErrAccountNotCreditCard
+	}
+
+	if account.GetCreditCardStatementDate() < 1 {
+		log.Warnf(c, "[transactions.TransactionCreditCardStatementListHandler] statement date of account \"id:%d\" for user \"uid:%d\" is not set", statementListReq.AccountId, uid)
+		return nil, errs.ErrCreditCardStatementDateNotSet
+	}
+
+	now := time.Now().Unix()
+	minimumPaymentRate := account.GetCreditCardMinimumPaymentRate()
+	statements := models.GetClosedCreditCardStatements(account.GetCreditCardStatementDate(), account.GetCreditCardGracePeriodDays(), statementListReq.GetCreditCardStatementCount(), now, clientTimezone)
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(statements[len(statements)-1].StartTime)
+
+	transactionsWithAccountBalance, _, _, openingBalance, _, err := a.transactions.GetAllTransactionsInOneAccountWithAccountBalanceByMaxTime(c, uid, pageCountForAccountStatement, 0, minTransactionTime, account.AccountId, account.Category)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionCreditCardStatementListHandler] failed to get transactions of account \"id:%d\" for user \"uid:%d\", because %s", account.AccountId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	models.FillCreditCardStatements(statements, transactionsWithAccountBalance, openingBalance)
+	statementResps := make([]*models.CreditCardStatementInfoResponse, len(statements))
+
+	for i := 0; i < len(statements); i++ {
+		statementResps[i] = statements[i].ToCreditCardStatementInfoResponse(minimumPaymentRate, now)
+	}
+
+	return statementResps, nil
+}
+
 // TransactionStatisticsHandler returns transaction statistics of current user
 func (a *TransactionsApi) TransactionStatisticsHandler(c *core.WebContext) (any, *errs.Error) {
 	var statisticReq models.TransactionStatisticRequest
@@ -511,14 +691,75 @@ func (a *TransactionsApi) TransactionStatisticsHandler(c *core.WebContext) (any,
 		}
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(statisticReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionStatisticsHandler] get payee ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	uid := c.GetCurrentUid()
-	totalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, statisticReq.StartTime, statisticReq.EndTime, tagFilters, noTags, statisticReq.Keyword, clientTimezone, statisticReq.UseTransactionTimezone)
+	startTime := statisticReq.StartTime
+	endTime := statisticReq.EndTime
+	searchConditions := &models.TransactionSearchConditions{
+		PayeeIds:   payeeIds,
+		TagFilters: tagFilters,
+		NoTags:     noTags,
+		Keyword:    statisticReq.Keyword,
+	}
+
+	if statisticReq.SavedSearchId > 0 || statisticReq.SearchQuery != "" {
+		querySearchConditions, err := a.transactions.GetTransactionSearchConditions(c, uid, statisticReq.SavedSearchId, statisticReq.SearchQuery, clientTimezone)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionStatisticsHandler] parse transaction search query error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		if querySearchConditions.AmountFilter != "" {
+			return nil, errs.ErrTransactionSearchQueryConditionNotSupported
+		}
+
+		searchConditions.Merge(querySearchConditions)
+
+		if querySearchConditions.MinTransactionTime > 0 {
+			queryStartTime := utils.GetUnixTimeFromTransactionTime(querySearchConditions.MinTransactionTime)
+
+			if queryStartTime > startTime {
+				startTime = queryStartTime
+			}
+		}
+
+		if querySearchConditions.MaxTransactionTime > 0 {
+			queryEndTime := utils.GetUnixTimeFromTransactionTime(querySearchConditions.MaxTransactionTime)
+
+			if endTime <= 0 || queryEndTime < endTime {
+				endTime = queryEndTime
+			}
+		}
+	}
+
+	var amountExchanger models.TransactionAmountExchanger
+	amountCurrency := ""
+
+	if statisticReq.UseHistoricalExchangeRates {
+		amountExchanger, amountCurrency, err = a.getHistoricalTransactionAmountExchanger(c, uid, startTime, endTime)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionStatisticsHandler] failed to get historical exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	totalAmounts, err := a.transactions.GetAccountsAndCategoriesTotalInflowAndOutflow(c, uid, startTime, endTime, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.PayeeIds, searchConditions.Keyword, clientTimezone, statisticReq.UseTransactionTimezone, statisticReq.GroupByOriginalCurrency, amountExchanger)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionStatisticsHandler] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	totalAmounts = searchConditions.FilterTransactionsByTypeCategoryAndAccount(totalAmounts)
+
 	statisticResp := &models.TransactionStatisticResponse{
 		StartTime: statisticReq.StartTime,
 		EndTime:   statisticReq.EndTime,
@@ -532,6 +773,11 @@ func (a *TransactionsApi) TransactionStatisticsHandler(c *core.WebContext) (any,
 			CategoryId:  totalAmountItem.CategoryId,
 			AccountId:   totalAmountItem.AccountId,
 			TotalAmount: totalAmountItem.Amount,
+			Currency:    amountCurrency,
+		}
+
+		if totalAmountItem.OriginalCurrency != "" {
+			statisticResp.Items[i].Currency = totalAmountItem.OriginalCurrency
 		}
 
 		if totalAmountItem.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || totalAmountItem.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
@@ -579,8 +825,67 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 		}
 	}
 
+	payeeIds, err := a.payees.GetPayeeIds(statisticTrendsReq.PayeeIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionStatisticsTrendsHandler] get payee ids error, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	uid := c.GetCurrentUid()
-	allMonthlyTotalAmounts, err := a.transactions.GetAccountsAndCategoriesMonthlyInflowAndOutflow(c, uid, startYear, startMonth, endYear, endMonth, tagFilters, noTags, statisticTrendsReq.Keyword, clientTimezone, statisticTrendsReq.UseTransactionTimezone)
+	searchConditions := &models.TransactionSearchConditions{
+		PayeeIds:   payeeIds,
+		TagFilters: tagFilters,
+		NoTags:     noTags,
+		Keyword:    statisticTrendsReq.Keyword,
+	}
+
+	if statisticTrendsReq.SavedSearchId > 0 || statisticTrendsReq.SearchQuery != "" {
+		querySearchConditions, err := a.transactions.GetTransactionSearchConditions(c, uid, statisticTrendsReq.SavedSearchId, statisticTrendsReq.SearchQuery, clientTimezone)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionStatisticsTrendsHandler] parse transaction search query error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		if querySearchConditions.AmountFilter != "" || querySearchConditions.MinTransactionTime > 0 || querySearchConditions.MaxTransactionTime > 0 {
+			return nil, errs.ErrTransactionSearchQueryConditionNotSupported
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
+	var amountExchanger models.TransactionAmountExchanger
+	amountCurrency := ""
+
+	if statisticTrendsReq.UseHistoricalExchangeRates {
+		var startUnixTime, endUnixTime int64
+
+		if startYear > 0 && startMonth > 0 {
+			startTransactionTime, _, err := utils.GetTransactionTimeRangeByYearMonth(startYear, startMonth)
+
+			if err == nil {
+				startUnixTime = utils.GetUnixTimeFromTransactionTime(startTransactionTime)
+			}
+		}
+
+		if endYear > 0 && endMonth > 0 {
+			_, endTransactionTime, err := utils.GetTransactionTimeRangeByYearMonth(endYear, endMonth)
+
+			if err == nil {
+				endUnixTime = utils.GetUnixTimeFromTransactionTime(endTransactionTime)
+			}
+		}
+
+		amountExchanger, amountCurrency, err = a.getHistoricalTransactionAmountExchanger(c, uid, startUnixTime, endUnixTime)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionStatisticsTrendsHandler] failed to get historical exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	allMonthlyTotalAmounts, err := a.transactions.GetAccountsAndCategoriesMonthlyInflowAndOutflow(c, uid, startYear, startMonth, endYear, endMonth, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.PayeeIds, searchConditions.Keyword, clientTimezone, statisticTrendsReq.UseTransactionTimezone, amountExchanger)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionStatisticsTrendsHandler] failed to get accounts and categories total income and expense for user \"uid:%d\", because %s", uid, err.Error())
@@ -590,6 +895,7 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 	statisticTrendsResp := make(models.TransactionStatisticTrendsResponseItemSlice, 0, len(allMonthlyTotalAmounts))
 
 	for yearMonth, monthlyTotalAmounts := range allMonthlyTotalAmounts {
+		monthlyTotalAmounts = searchConditions.FilterTransactionsByTypeCategoryAndAccount(monthlyTotalAmounts)
 		monthlyStatisticResp := &models.TransactionStatisticTrendsResponseItem{
 			Year:  yearMonth / 100,
 			Month: yearMonth % 100,
@@ -602,6 +908,7 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 				CategoryId:  totalAmountItem.CategoryId,
 				AccountId:   totalAmountItem.AccountId,
 				TotalAmount: totalAmountItem.Amount,
+				Currency:    amountCurrency,
 			}
 
 			if totalAmountItem.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || totalAmountItem.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
@@ -618,128 +925,349 @@ func (a *TransactionsApi) TransactionStatisticsTrendsHandler(c *core.WebContext)
 	return statisticTrendsResp, nil
 }
 
-// TransactionStatisticsAssetTrendsHandler returns transaction statistics asset trends of current user
-func (a *TransactionsApi) TransactionStatisticsAssetTrendsHandler(c *core.WebContext) (any, *errs.Error) {
-	var statisticAssetTrendsReq models.TransactionStatisticAssetTrendsRequest
-	err := c.ShouldBindQuery(&statisticAssetTrendsReq)
+// TransactionItemStatisticsHandler returns total quantities, total line amounts and average unit prices of transaction items of current user
+func (a *TransactionsApi) TransactionItemStatisticsHandler(c *core.WebContext) (any, *errs.Error) {
+	var itemStatisticReq models.TransactionItemStatisticRequest
+	err := c.ShouldBindQuery(&itemStatisticReq)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] parse request failed, because %s", err.Error())
+		log.Warnf(c, "[transactions.TransactionItemStatisticsHandler] parse request failed, because %s", err.Error())
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
 	clientTimezone, err := c.GetClientTimezone()
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] cannot get client timezone, because %s", err.Error())
+		log.Warnf(c, "[transactions.TransactionItemStatisticsHandler] cannot get client timezone, because %s", err.Error())
 		return nil, errs.ErrClientTimezoneOffsetInvalid
 	}
 
-	uid := c.GetCurrentUid()
-
-	maxTransactionTime := int64(0)
+	startYear, startMonth, endYear, endMonth, err := itemStatisticReq.GetNumericYearMonthRange()
 
-	if statisticAssetTrendsReq.EndTime > 0 {
-		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(statisticAssetTrendsReq.EndTime)
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionItemStatisticsHandler] cannot parse year month, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	minTransactionTime := int64(0)
+	itemIds, err := a.transactionItems.GetItemIds(itemStatisticReq.ItemIds)
 
-	if statisticAssetTrendsReq.StartTime > 0 {
-		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(statisticAssetTrendsReq.StartTime)
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionItemStatisticsHandler] parse item ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionItemIdInvalid
 	}
 
-	accountDailyBalances, err := a.transactions.GetAllAccountsDailyOpeningAndClosingBalance(c, uid, maxTransactionTime, minTransactionTime, clientTimezone)
+	uid := c.GetCurrentUid()
+	allMonthlyTotalAmounts, err := a.transactionItems.GetItemsMonthlyTotalAmounts(c, uid, startYear, startMonth, endYear, endMonth, itemIds, clientTimezone, itemStatisticReq.UseTransactionTimezone)
 
 	if err != nil {
-		log.Errorf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] failed to get transactions from \"%d\" to \"%d\" for user \"uid:%d\", because %s", statisticAssetTrendsReq.StartTime, statisticAssetTrendsReq.EndTime, uid, err.Error())
+		log.Errorf(c, "[transactions.TransactionItemStatisticsHandler] failed to get transaction items total amounts for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	statisticAssetTrendsResp := make(models.TransactionStatisticAssetTrendsResponseItemSlice, 0)
+	accountIds := make([]int64, 0)
 
-	for yearMonthDay, dailyAccountBalances := range accountDailyBalances {
-		dailyStatisticResp := &models.TransactionStatisticAssetTrendsResponseItem{
-			Year:  yearMonthDay / 10000,
-			Month: (yearMonthDay % 10000) / 100,
-			Day:   yearMonthDay % 100,
-			Items: make([]*models.TransactionStatisticAssetTrendsResponseDataItem, len(dailyAccountBalances)),
+	for _, monthlyTotalAmounts := range allMonthlyTotalAmounts {
+		for i := 0; i < len(monthlyTotalAmounts); i++ {
+			accountIds = append(accountIds, monthlyTotalAmounts[i].AccountId)
 		}
+	}
 
-		for i := 0; i < len(dailyAccountBalances); i++ {
-			accountBalance := dailyAccountBalances[i]
-			dailyStatisticResp.Items[i] = &models.TransactionStatisticAssetTrendsResponseDataItem{
-				AccountId:             accountBalance.AccountId,
-				AccountOpeningBalance: accountBalance.AccountOpeningBalance,
-				AccountClosingBalance: accountBalance.AccountClosingBalance,
-			}
-		}
+	accountMap, err := a.accounts.GetAccountsByAccountIds(c, uid, utils.ToUniqueInt64Slice(accountIds))
 
-		statisticAssetTrendsResp = append(statisticAssetTrendsResp, dailyStatisticResp)
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionItemStatisticsHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	sort.Sort(statisticAssetTrendsResp)
+	itemStatisticRespMap := make(map[string]*models.TransactionItemStatisticResponseItem)
+	itemStatisticTrendsRespMap := make(map[string]*models.TransactionItemStatisticTrendsResponseItem)
 
-	return statisticAssetTrendsResp, nil
-}
+	for yearMonth, monthlyTotalAmounts := range allMonthlyTotalAmounts {
+		for i := 0; i < len(monthlyTotalAmounts); i++ {
+			totalAmount := monthlyTotalAmounts[i]
+			account, exists := accountMap[totalAmount.AccountId]
 
-// TransactionAmountsHandler returns transaction amounts of current user
-func (a *TransactionsApi) TransactionAmountsHandler(c *core.WebContext) (any, *errs.Error) {
-	var transactionAmountsReq models.TransactionAmountsRequest
-	err := c.ShouldBindQuery(&transactionAmountsReq)
+			if !exists {
+				continue
+			}
 
-	if err != nil {
-		log.Warnf(c, "[transactions.TransactionAmountsHandler] parse request failed, because %s", err.Error())
-		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
-	}
+			itemKey := fmt.Sprintf("%d_%s_%s", totalAmount.ItemId, account.Currency, totalAmount.Unit)
+			itemStatisticResp, exists := itemStatisticRespMap[itemKey]
 
-	requestItems, err := transactionAmountsReq.GetTransactionAmountsRequestItems()
+			if !exists {
+				itemStatisticResp = &models.TransactionItemStatisticResponseItem{
+					ItemId:   totalAmount.ItemId,
+					Currency: account.Currency,
+					Unit:     totalAmount.Unit,
+					Trends:   make(models.TransactionItemStatisticTrendsResponseItemSlice, 0),
+				}
 
-	if err != nil {
-		log.Warnf(c, "[transactions.TransactionAmountsHandler] get request item failed, because %s", err.Error())
-		return nil, errs.ErrQueryItemsInvalid
-	}
+				itemStatisticRespMap[itemKey] = itemStatisticResp
+			}
 
-	if len(requestItems) < 1 {
-		log.Warnf(c, "[transactions.TransactionAmountsHandler] parse request failed, because there are no valid items")
-		return nil, errs.ErrQueryItemsEmpty
-	}
+			itemStatisticResp.TotalQuantity += totalAmount.Quantity
+			itemStatisticResp.TotalAmount += totalAmount.Amount
 
-	if len(requestItems) > 20 {
-		log.Warnf(c, "[transactions.TransactionAmountsHandler] parse request failed, because there are too many items")
-		return nil, errs.ErrQueryItemsTooMuch
-	}
+			trendsKey := fmt.Sprintf("%s_%d", itemKey, yearMonth)
+			itemStatisticTrendsResp, exists := itemStatisticTrendsRespMap[trendsKey]
 
-	excludeAccountIds := make([]int64, 0)
-	excludeCategoryIds := make([]int64, 0)
+			if !exists {
+				itemStatisticTrendsResp = &models.TransactionItemStatisticTrendsResponseItem{
+					Year:  yearMonth / 100,
+					Month: yearMonth % 100,
+				}
 
-	if transactionAmountsReq.ExcludeAccountIds != "" {
-		excludeAccountIds, err = utils.StringArrayToInt64Array(strings.Split(transactionAmountsReq.ExcludeAccountIds, ","))
+				itemStatisticTrendsRespMap[trendsKey] = itemStatisticTrendsResp
+				itemStatisticResp.Trends = append(itemStatisticResp.Trends, itemStatisticTrendsResp)
+			}
 
-		if err != nil {
-			return nil, errs.ErrAccountIdInvalid
+			itemStatisticTrendsResp.TotalQuantity += totalAmount.Quantity
+			itemStatisticTrendsResp.TotalAmount += totalAmount.Amount
 		}
 	}
 
-	if transactionAmountsReq.ExcludeCategoryIds != "" {
-		excludeCategoryIds, err = utils.StringArrayToInt64Array(strings.Split(transactionAmountsReq.ExcludeCategoryIds, ","))
+	itemStatisticResps := make(models.TransactionItemStatisticResponseItemSlice, 0, len(itemStatisticRespMap))
 
-		if err != nil {
-			return nil, errs.ErrTransactionCategoryIdInvalid
+	for _, itemStatisticResp := range itemStatisticRespMap {
+		itemStatisticResp.AverageUnitPrice = models.GetTransactionItemUnitPrice(itemStatisticResp.TotalAmount, itemStatisticResp.TotalQuantity)
+
+		for i := 0; i < len(itemStatisticResp.Trends); i++ {
+			trendsResp := itemStatisticResp.Trends[i]
+			trendsResp.AverageUnitPrice = models.GetTransactionItemUnitPrice(trendsResp.TotalAmount, trendsResp.TotalQuantity)
 		}
+
+		sort.Sort(itemStatisticResp.Trends)
+		itemStatisticResps = append(itemStatisticResps, itemStatisticResp)
+	}
+
+	sort.Sort(itemStatisticResps)
+
+	return itemStatisticResps, nil
+}
+
+// TransactionPayeeStatisticsHandler returns transaction payee statistics of current user
+func (a *TransactionsApi) TransactionPayeeStatisticsHandler(c *core.WebContext) (any, *errs.Error) {
+	var payeeStatisticReq models.TransactionPayeeStatisticRequest
+	err := c.ShouldBindQuery(&payeeStatisticReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionPayeeStatisticsHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
 	clientTimezone, err := c.GetClientTimezone()
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionAmountsHandler] cannot get client timezone, because %s", err.Error())
+		log.Warnf(c, "[transactions.TransactionPayeeStatisticsHandler] cannot get client timezone, because %s", err.Error())
 		return nil, errs.ErrClientTimezoneOffsetInvalid
 	}
 
-	uid := c.GetCurrentUid()
+	payeeIds, err := a.payees.GetPayeeIds(payeeStatisticReq.PayeeIds)
 
-	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
-	accountMap := a.accounts.GetAccountMapByList(accounts)
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionPayeeStatisticsHandler] parse payee ids failed, because %s", err.Error())
+		return nil, errs.ErrPayeeIdInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	totalAmounts, err := a.payees.GetPayeesTotalAmounts(c, uid, payeeStatisticReq.StartTime, payeeStatisticReq.EndTime, payeeIds, clientTimezone, payeeStatisticReq.UseTransactionTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionPayeeStatisticsHandler] failed to get payees total amounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	accountIds := make([]int64, 0, len(totalAmounts))
+
+	for i := 0; i < len(totalAmounts); i++ {
+		accountIds = append(accountIds, totalAmounts[i].AccountId)
+	}
+
+	accountMap, err := a.accounts.GetAccountsByAccountIds(c, uid, utils.ToUniqueInt64Slice(accountIds))
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionPayeeStatisticsHandler] failed to get accounts for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	payeeStatisticRespMap := make(map[string]*models.TransactionPayeeStatisticResponseItem)
+
+	for i := 0; i < len(totalAmounts); i++ {
+		totalAmount := totalAmounts[i]
+		account, exists := accountMap[totalAmount.AccountId]
+
+		if !exists {
+			continue
+		}
+
+		payeeKey := fmt.Sprintf("%d_%s", totalAmount.PayeeId, account.Currency)
+		payeeStatisticResp, exists := payeeStatisticRespMap[payeeKey]
+
+		if !exists {
+			payeeStatisticResp = &models.TransactionPayeeStatisticResponseItem{
+				PayeeId:  totalAmount.PayeeId,
+				Currency: account.Currency,
+			}
+
+			payeeStatisticRespMap[payeeKey] = payeeStatisticResp
+		}
+
+		payeeStatisticResp.TotalIncomeAmount += totalAmount.IncomeAmount
+		payeeStatisticResp.TotalExpenseAmount += totalAmount.ExpenseAmount
+		payeeStatisticResp.TransactionCount += totalAmount.TransactionCount
+	}
+
+	payeeStatisticResps := make(models.TransactionPayeeStatisticResponseItemSlice, 0, len(payeeStatisticRespMap))
+
+	for _, payeeStatisticResp := range payeeStatisticRespMap {
+		payeeStatisticResps = append(payeeStatisticResps, payeeStatisticResp)
+	}
+
+	sort.Sort(payeeStatisticResps)
+
+	return payeeStatisticResps, nil
+}
+
+// TransactionStatisticsAssetTrendsHandler returns transaction statistics asset trends of current user
+func (a *TransactionsApi) TransactionStatisticsAssetTrendsHandler(c *core.WebContext) (any, *errs.Error) {
+	var statisticAssetTrendsReq models.TransactionStatisticAssetTrendsRequest
+	err := c.ShouldBindQuery(&statisticAssetTrendsReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+
+	maxTransactionTime := int64(0)
+
+	if statisticAssetTrendsReq.EndTime > 0 {
+		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(statisticAssetTrendsReq.EndTime)
+	}
+
+	minTransactionTime := int64(0)
+
+	if statisticAssetTrendsReq.StartTime > 0 {
+		minTransactionTime = utils.GetMinTransactionTimeFromUnixTime(statisticAssetTrendsReq.StartTime)
+	}
+
+	accountDailyBalances, err := a.transactions.GetAllAccountsDailyOpeningAndClosingBalance(c, uid, maxTransactionTime, minTransactionTime, clientTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] failed to get transactions from \"%d\" to \"%d\" for user \"uid:%d\", because %s", statisticAssetTrendsReq.StartTime, statisticAssetTrendsReq.EndTime, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	statisticAssetTrendsResp := make(models.TransactionStatisticAssetTrendsResponseItemSlice, 0)
+
+	for yearMonthDay, dailyAccountBalances := range accountDailyBalances {
+		dailyStatisticResp := &models.TransactionStatisticAssetTrendsResponseItem{
+			Year:  yearMonthDay / 10000,
+			Month: (yearMonthDay % 10000) / 100,
+			Day:   yearMonthDay % 100,
+			Items: make([]*models.TransactionStatisticAssetTrendsResponseDataItem, len(dailyAccountBalances)),
+		}
+
+		for i := 0; i < len(dailyAccountBalances); i++ {
+			accountBalance := dailyAccountBalances[i]
+			dailyStatisticResp.Items[i] = &models.TransactionStatisticAssetTrendsResponseDataItem{
+				AccountId:             accountBalance.AccountId,
+				AccountOpeningBalance: accountBalance.AccountOpeningBalance,
+				AccountClosingBalance: accountBalance.AccountClosingBalance,
+			}
+		}
+
+		statisticAssetTrendsResp = append(statisticAssetTrendsResp, dailyStatisticResp)
+	}
+
+	sort.Sort(statisticAssetTrendsResp)
+
+	err = a.fillInvestmentAssetTrends(c, uid, statisticAssetTrendsResp, clientTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] failed to fill investment values for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if statisticAssetTrendsReq.UseHistoricalExchangeRates {
+		historicalExchangeRates, accountCurrencies, defaultCurrency, err := a.getHistoricalExchangeRates(c, uid, statisticAssetTrendsReq.StartTime, statisticAssetTrendsReq.EndTime)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionStatisticsAssetTrendsHandler] failed to get historical exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		a.exchangeAssetTrendsByHistoricalExchangeRates(statisticAssetTrendsResp, historicalExchangeRates, accountCurrencies, defaultCurrency)
+	}
+
+	return statisticAssetTrendsResp, nil
+}
+
+// TransactionAmountsHandler returns transaction amounts of current user
+func (a *TransactionsApi) TransactionAmountsHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionAmountsReq models.TransactionAmountsRequest
+	err := c.ShouldBindQuery(&transactionAmountsReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionAmountsHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	requestItems, err := transactionAmountsReq.GetTransactionAmountsRequestItems()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionAmountsHandler] get request item failed, because %s", err.Error())
+		return nil, errs.ErrQueryItemsInvalid
+	}
+
+	if len(requestItems) < 1 {
+		log.Warnf(c, "[transactions.TransactionAmountsHandler] parse request failed, because there are no valid items")
+		return nil, errs.ErrQueryItemsEmpty
+	}
+
+	if len(requestItems) > 20 {
+		log.Warnf(c, "[transactions.TransactionAmountsHandler] parse request failed, because there are too many items")
+		return nil, errs.ErrQueryItemsTooMuch
+	}
+
+	excludeAccountIds := make([]int64, 0)
+	excludeCategoryIds := make([]int64, 0)
+
+	if transactionAmountsReq.ExcludeAccountIds != "" {
+		excludeAccountIds, err = utils.StringArrayToInt64Array(strings.Split(transactionAmountsReq.ExcludeAccountIds, ","))
+
+		if err != nil {
+			return nil, errs.ErrAccountIdInvalid
+		}
+	}
+
+	if transactionAmountsReq.ExcludeCategoryIds != "" {
+		excludeCategoryIds, err = utils.StringArrayToInt64Array(strings.Split(transactionAmountsReq.ExcludeCategoryIds, ","))
+
+		if err != nil {
+			return nil, errs.ErrTransactionCategoryIdInvalid
+		}
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionAmountsHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+	accountMap := a.accounts.GetAccountMapByList(accounts)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionAmountsHandler] failed to get all accounts for user \"uid:%d\", because %s", uid, err.Error())
@@ -923,9 +1451,10 @@ func (a *TransactionsApi) TransactionGetHandler(c *core.WebContext) (any, *errs.
 
 	transactionEditable := transaction.IsEditable(user, clientTimezone, accountMap[transaction.AccountId], accountMap[transaction.RelatedAccountId])
 	transactionTagIds := allTransactionTagIds[transaction.TransactionId]
-	transactionItemIds, _ := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
-	transactionItemIdsSlice := transactionItemIds[transaction.TransactionId]
+	transactionItemIndexes, _ := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, []int64{transaction.TransactionId})
+	transactionItemIdsSlice := a.transactionItems.GetGroupedTransactionItemIds(transactionItemIndexes[transaction.TransactionId])[transaction.TransactionId]
 	transactionResp := transaction.ToTransactionInfoResponse(transactionTagIds, transactionItemIdsSlice, transactionEditable)
+	transactionResp.ItemDetails = a.getTransactionItemDetailInfoResponses(transactionItemIndexes[transaction.TransactionId])
 
 	if !transactionGetReq.TrimAccount {
 		if sourceAccount := accountMap[transaction.AccountId]; sourceAccount != nil {
@@ -953,7 +1482,29 @@ func (a *TransactionsApi) TransactionGetHandler(c *core.WebContext) (any, *errs.
 	}
 
 	if transactionGetReq.WithPictures && a.CurrentConfig().EnableTransactionPictures {
-		transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(pictureInfos)
+		transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(c, pictureInfos)
+	}
+
+	transactionSplits, err := a.transactions.GetSplitsOfTransactions(c, uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionGetHandler] failed to get transaction splits for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionResp.Splits = a.getTransactionSplitInfoResponseList(transactionSplits[transaction.TransactionId])
+
+	if transaction.IsCreatedByOtherMember() {
+		creator, err := a.users.GetUserById(c, transaction.CreatedByUid)
+
+		if err != nil && err != errs.ErrUserNotFound {
+			log.Errorf(c, "[transactions.TransactionGetHandler] failed to get transaction creator for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		if creator != nil {
+			transactionResp.CreatedBy = a.getTransactionCreatorInfoResponse(creator)
+		}
 	}
 
 	return transactionResp, nil
@@ -969,6 +1520,33 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
+	if transactionCreateReq.ApplyRules {
+		rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, c.GetCurrentUid())
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionCreateHandler] failed to get transaction rules for user \"uid:%d\", because %s", c.GetCurrentUid(), err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		a.applyTransactionRules(rules, &transactionCreateReq)
+	}
+
+	if transactionCreateReq.PayeeId > 0 {
+		payee, err := a.payees.GetPayeeByPayeeId(c, c.GetCurrentUid(), transactionCreateReq.PayeeId)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionCreateHandler] failed to get payee \"id:%d\" for user \"uid:%d\", because %s", transactionCreateReq.PayeeId, c.GetCurrentUid(), err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		err = a.applyPayeeDefaults(c, c.GetCurrentUid(), []*models.Payee{payee}, []*models.TransactionCreateRequest{&transactionCreateReq})
+
+		if err != nil {
+			log.Errorf(c, "[transactions.TransactionCreateHandler] failed to apply payee defaults for user \"uid:%d\", because %s", c.GetCurrentUid(), err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
 	clientTimezone, err := c.GetClientTimezone()
 
 	if err != nil {
@@ -1009,6 +1587,20 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrTransactionHasTooManyItems
 	}
 
+	itemDetails, err := a.createNewTransactionItemDetailModels(itemIds, transactionCreateReq.ItemDetails)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCreateHandler] parse transaction item details failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrIncompleteOrIncorrectSubmission)
+	}
+
+	splits, err := a.createNewTransactionSplitModels(transactionCreateReq.Splits)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionCreateHandler] parse transaction splits failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrIncompleteOrIncorrectSubmission)
+	}
+
 	uid := c.GetCurrentUid()
 
 	if len(itemIds) > 0 {
@@ -1050,6 +1642,14 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrTransactionDestinationAmountCannotBeSet
 	}
 
+	if transactionCreateReq.Type != models.TRANSACTION_TYPE_EXPENSE && transactionCreateReq.Type != models.TRANSACTION_TYPE_INCOME && (transactionCreateReq.OriginalCurrency != "" || transactionCreateReq.OriginalAmount != 0) {
+		log.Warnf(c, "[transactions.TransactionCreateHandler] non-expense and non-income transaction original amount cannot be set")
+		return nil, errs.ErrTransactionOriginalAmountCannotBeSet
+	} else if transactionCreateReq.OriginalCurrency == "" && transactionCreateReq.OriginalAmount != 0 {
+		log.Warnf(c, "[transactions.TransactionCreateHandler] transaction original currency is empty but original amount is set")
+		return nil, errs.ErrTransactionOriginalCurrencyIsEmpty
+	}
+
 	user, err := a.users.GetUserById(c, uid)
 
 	if err != nil {
@@ -1060,7 +1660,7 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrUserNotFound
 	}
 
-	transaction := a.createNewTransactionModel(uid, &transactionCreateReq, c.ClientIP())
+	transaction := a.createNewTransactionModel(uid, c.GetCurrentOperatorUid(), &transactionCreateReq, c.ClientIP())
 	transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone)
 
 	if !transactionEditable {
@@ -1103,14 +1703,14 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 				existingItemIds, _ := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, []int64{transactionId})
 				existingItemIdsSlice := existingItemIds[transactionId]
 				transactionResp := transaction.ToTransactionInfoResponse(tagIds, existingItemIdsSlice, transactionEditable)
-				transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(pictureInfos)
+				transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(c, pictureInfos)
 
 				return transactionResp, nil
 			}
 		}
 	}
 
-	err = a.transactions.CreateTransaction(c, transaction, tagIds, itemIds, pictureIds)
+	err = a.transactions.CreateTransaction(c, transaction, tagIds, itemIds, itemDetails, pictureIds, splits, a.GetTransactionRevisionActor(c))
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionCreateHandler] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
@@ -1121,7 +1721,9 @@ func (a *TransactionsApi) TransactionCreateHandler(c *core.WebContext) (any, *er
 
 	a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_NEW_TRANSACTION, uid, transactionCreateReq.ClientSessionId, utils.Int64ToString(transaction.TransactionId))
 	transactionResp := transaction.ToTransactionInfoResponse(tagIds, itemIds, transactionEditable)
-	transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(pictureInfos)
+	transactionResp.Pictures = a.GetTransactionPictureInfoResponseList(c, pictureInfos)
+	transactionResp.ItemDetails = a.getTransactionItemDetailInfoResponsesByMap(itemIds, itemDetails)
+	transactionResp.Splits = a.getTransactionSplitInfoResponseList(splits)
 
 	return transactionResp, nil
 }
@@ -1176,6 +1778,20 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrTransactionHasTooManyPictures
 	}
 
+	itemDetails, err := a.createNewTransactionItemDetailModels(itemIds, transactionModifyReq.ItemDetails)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] parse transaction item details failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrIncompleteOrIncorrectSubmission)
+	}
+
+	splits, err := a.createNewTransactionSplitModels(transactionModifyReq.Splits)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] parse transaction splits failed, because %s", err.Error())
+		return nil, errs.Or(err, errs.ErrIncompleteOrIncorrectSubmission)
+	}
+
 	uid := c.GetCurrentUid()
 	user, err := a.users.GetUserById(c, uid)
 
@@ -1207,6 +1823,34 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrIncompleteOrIncorrectSubmission
 	}
 
+	// the original currency and amount are not changed if they are not specified
+	originalCurrency := transaction.OriginalCurrency
+	originalAmount := transaction.OriginalAmount
+
+	if transactionModifyReq.OriginalCurrency != nil {
+		originalCurrency = *transactionModifyReq.OriginalCurrency
+
+		// clearing the original currency also clears the original amount if the amount is not specified
+		if originalCurrency == "" && transactionModifyReq.OriginalAmount == nil {
+			originalAmount = 0
+		}
+	}
+
+	if transactionModifyReq.OriginalAmount != nil {
+		originalAmount = *transactionModifyReq.OriginalAmount
+	}
+
+	if transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE && transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && (originalCurrency != "" || originalAmount != 0) {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] non-expense and non-income transaction original amount cannot be set")
+		return nil, errs.ErrTransactionOriginalAmountCannotBeSet
+	} else if originalCurrency == "" && originalAmount != 0 {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] transaction original currency is empty but original amount is set")
+		return nil, errs.ErrTransactionOriginalCurrencyIsEmpty
+	} else if _, ok := validators.AllCurrencyNames[originalCurrency]; originalCurrency != "" && !ok {
+		log.Warnf(c, "[transactions.TransactionModifyHandler] transaction original currency \"%s\" is not supported", originalCurrency)
+		return nil, errs.ErrTransactionOriginalCurrencyInvalid
+	}
+
 	allTransactionTagIds, err := a.transactionTags.GetAllTagIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
 
 	if err != nil {
@@ -1220,19 +1864,36 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		transactionTagIds = make([]int64, 0, 0)
 	}
 
-	allTransactionItemIds, err := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, []int64{transaction.TransactionId})
+	allTransactionItemIndexes, err := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, []int64{transaction.TransactionId})
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionModifyHandler] failed to get transaction item ids for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
-	transactionItemIds := allTransactionItemIds[transaction.TransactionId]
+	transactionItemIndexes := allTransactionItemIndexes[transaction.TransactionId]
+	transactionItemIds := a.transactionItems.GetGroupedTransactionItemIds(transactionItemIndexes)[transaction.TransactionId]
 
 	if transactionItemIds == nil {
 		transactionItemIds = make([]int64, 0, 0)
 	}
 
+	itemDetailsModified := false
+
+	for i := 0; i < len(transactionItemIndexes); i++ {
+		itemIndex := transactionItemIndexes[i]
+		itemDetail, exists := itemDetails[itemIndex.ItemId]
+
+		if !exists {
+			itemDetail = &models.TransactionItemIndex{}
+		}
+
+		if !itemIndex.IsSameDetail(itemDetail) {
+			itemDetailsModified = true
+			break
+		}
+	}
+
 	if len(itemIds) > 0 {
 		itemMap, err := a.transactionItems.GetItemsByItemIds(c, uid, itemIds)
 
@@ -1258,6 +1919,28 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 
 	transactionPictureIds := a.transactionPictures.GetTransactionPictureIds(transactionPictureInfos)
 
+	allTransactionSplits, err := a.transactions.GetSplitsOfTransactions(c, uid, []int64{transaction.TransactionId})
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionModifyHandler] failed to get transaction splits for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	transactionSplits := allTransactionSplits[transaction.TransactionId]
+
+	// keep the current splits when the splits field is absent, an empty array clears all splits
+	if transactionModifyReq.Splits == nil {
+		splits = transactionSplits
+	}
+
+	splitsModified := len(splits) != len(transactionSplits)
+
+	for i := 0; i < len(transactionSplits) && !splitsModified; i++ {
+		if !transactionSplits[i].IsSameContent(splits[i]) {
+			splitsModified = true
+		}
+	}
+
 	newTransaction := &models.Transaction{
 		TransactionId:     transaction.TransactionId,
 		Uid:               uid,
@@ -1266,6 +1949,9 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		TimezoneUtcOffset: transactionModifyReq.UtcOffset,
 		AccountId:         transactionModifyReq.SourceAccountId,
 		Amount:            transactionModifyReq.SourceAmount,
+		OriginalCurrency:  originalCurrency,
+		OriginalAmount:    originalAmount,
+		PayeeId:           transactionModifyReq.PayeeId,
 		HideAmount:        transactionModifyReq.HideAmount,
 		Comment:           transactionModifyReq.Comment,
 	}
@@ -1285,6 +1971,9 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		newTransaction.TimezoneUtcOffset == transaction.TimezoneUtcOffset &&
 		newTransaction.AccountId == transaction.AccountId &&
 		newTransaction.Amount == transaction.Amount &&
+		newTransaction.OriginalCurrency == transaction.OriginalCurrency &&
+		newTransaction.OriginalAmount == transaction.OriginalAmount &&
+		newTransaction.PayeeId == transaction.PayeeId &&
 		(transaction.Type != models.TRANSACTION_DB_TYPE_TRANSFER_OUT || newTransaction.RelatedAccountId == transaction.RelatedAccountId) &&
 		(transaction.Type != models.TRANSACTION_DB_TYPE_TRANSFER_OUT || newTransaction.RelatedAccountAmount == transaction.RelatedAccountAmount) &&
 		newTransaction.HideAmount == transaction.HideAmount &&
@@ -1293,7 +1982,9 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		newTransaction.GeoLatitude == transaction.GeoLatitude &&
 		utils.Int64SliceEquals(tagIds, transactionTagIds) &&
 		utils.Int64SliceEquals(itemIds, transactionItemIds) &&
-		utils.Int64SliceEquals(pictureIds, transactionPictureIds) {
+		!itemDetailsModified &&
+		utils.Int64SliceEquals(pictureIds, transactionPictureIds) &&
+		!splitsModified {
 		return nil, errs.ErrNothingWillBeUpdated
 	}
 
@@ -1354,7 +2045,7 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 		}
 	}
 
-	err = a.transactions.ModifyTransaction(c, newTransaction, len(transactionTagIds), addTransactionTagIds, removeTransactionTagIds, addTransactionItemIds, removeTransactionItemIds, addTransactionPictureIds, removeTransactionPictureIds)
+	err = a.transactions.ModifyTransaction(c, newTransaction, len(transactionTagIds), addTransactionTagIds, removeTransactionTagIds, addTransactionItemIds, removeTransactionItemIds, itemDetails, addTransactionPictureIds, removeTransactionPictureIds, splits, a.GetTransactionRevisionActor(c))
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionModifyHandler] failed to update transaction \"id:%d\" for user \"uid:%d\", because %s", transactionModifyReq.Id, uid, err.Error())
@@ -1365,7 +2056,9 @@ func (a *TransactionsApi) TransactionModifyHandler(c *core.WebContext) (any, *er
 
 	newTransaction.Type = transaction.Type
 	newTransactionResp := newTransaction.ToTransactionInfoResponse(tagIds, itemIds, transactionEditable)
-	newTransactionResp.Pictures = a.GetTransactionPictureInfoResponseList(newPictureInfos)
+	newTransactionResp.Pictures = a.GetTransactionPictureInfoResponseList(c, newPictureInfos)
+	newTransactionResp.ItemDetails = a.getTransactionItemDetailInfoResponsesByMap(itemIds, itemDetails)
+	newTransactionResp.Splits = a.getTransactionSplitInfoResponseList(splits)
 
 	return newTransactionResp, nil
 }
@@ -1427,20 +2120,42 @@ func (a *TransactionsApi) TransactionMoveAllBetweenAccountsHandler(c *core.WebCo
 	return true, nil
 }
 
-// TransactionDeleteHandler deletes an existed transaction by request parameters for current user
-func (a *TransactionsApi) TransactionDeleteHandler(c *core.WebContext) (any, *errs.Error) {
-	var transactionDeleteReq models.TransactionDeleteRequest
-	err := c.ShouldBindJSON(&transactionDeleteReq)
+// TransactionModifyClearedStatusHandler updates the cleared status of an existed transaction by request parameters for current user
+func (a *TransactionsApi) TransactionModifyClearedStatusHandler(c *core.WebContext) (any, *errs.Error) {
+	var clearedStatusModifyReq models.TransactionClearedStatusModifyRequest
+	err := c.ShouldBindJSON(&clearedStatusModifyReq)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionDeleteHandler] parse request failed, because %s", err.Error())
+		log.Warnf(c, "[transactions.TransactionModifyClearedStatusHandler] parse request failed, because %s", err.Error())
 		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
 	}
 
-	clientTimezone, err := c.GetClientTimezone()
+	uid := c.GetCurrentUid()
+	err = a.transactions.ModifyTransactionClearedStatus(c, uid, clearedStatusModifyReq.Id, clearedStatusModifyReq.ClearedStatus)
 
 	if err != nil {
-		log.Warnf(c, "[transactions.TransactionDeleteHandler] cannot get client timezone, because %s", err.Error())
+		log.Errorf(c, "[transactions.TransactionModifyClearedStatusHandler] failed to update cleared status of transaction \"id:%d\" for user \"uid:%d\", because %s", clearedStatusModifyReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[transactions.TransactionModifyClearedStatusHandler] user \"uid:%d\" has updated cleared status of transaction \"id:%d\" to %d", uid, clearedStatusModifyReq.Id, clearedStatusModifyReq.ClearedStatus)
+	return true, nil
+}
+
+// TransactionDeleteHandler deletes an existed transaction by request parameters for current user
+func (a *TransactionsApi) TransactionDeleteHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionDeleteReq models.TransactionDeleteRequest
+	err := c.ShouldBindJSON(&transactionDeleteReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionDeleteHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionDeleteHandler] cannot get client timezone, because %s", err.Error())
 		return nil, errs.ErrClientTimezoneOffsetInvalid
 	}
 
@@ -1473,7 +2188,7 @@ func (a *TransactionsApi) TransactionDeleteHandler(c *core.WebContext) (any, *er
 		return nil, errs.ErrCannotDeleteTransactionWithThisTransactionTime
 	}
 
-	err = a.transactions.DeleteTransaction(c, uid, transactionDeleteReq.Id)
+	err = a.transactions.DeleteTransaction(c, uid, transactionDeleteReq.Id, a.GetTransactionRevisionActor(c))
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionDeleteHandler] failed to delete transaction \"id:%d\" for user \"uid:%d\", because %s", transactionDeleteReq.Id, uid, err.Error())
@@ -1484,6 +2199,152 @@ func (a *TransactionsApi) TransactionDeleteHandler(c *core.WebContext) (any, *er
 	return true, nil
 }
 
+// TransactionBulkEditHandler applies the same changes to all transactions matched by filter or ids for current user
+func (a *TransactionsApi) TransactionBulkEditHandler(c *core.WebContext) (any, *errs.Error) {
+	var transactionBulkEditReq models.TransactionBulkEditRequest
+	err := c.ShouldBindJSON(&transactionBulkEditReq)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	clientTimezone, err := c.GetClientTimezone()
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] cannot get client timezone, because %s", err.Error())
+		return nil, errs.ErrClientTimezoneOffsetInvalid
+	}
+
+	uid := c.GetCurrentUid()
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		if !errs.IsCustomError(err) {
+			log.Errorf(c, "[transactions.TransactionBulkEditHandler] failed to get user, because %s", err.Error())
+		}
+
+		return nil, errs.ErrUserNotFound
+	}
+
+	transactionIds, err := utils.StringArrayToInt64Array(transactionBulkEditReq.TransactionIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse transaction ids failed, because %s", err.Error())
+		return nil, errs.ErrTransactionIdInvalid
+	}
+
+	filter := &models.TransactionBulkEditFilter{
+		TransactionIds:     transactionIds,
+		MaxTransactionTime: transactionBulkEditReq.MaxTime,
+		MinTransactionTime: transactionBulkEditReq.MinTime,
+		Type:               transactionBulkEditReq.Type,
+		AmountFilter:       transactionBulkEditReq.AmountFilter,
+		Keyword:            transactionBulkEditReq.Keyword,
+	}
+
+	if len(transactionIds) < 1 {
+		filter.AccountIds, err = a.accounts.GetAccountOrSubAccountIds(c, transactionBulkEditReq.AccountIds, uid)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionBulkEditHandler] get account error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		filter.CategoryIds, err = a.transactionCategories.GetCategoryOrSubCategoryIds(c, transactionBulkEditReq.CategoryIds, uid)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionBulkEditHandler] get transaction category error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		filter.PayeeIds, err = a.payees.GetPayeeIds(transactionBulkEditReq.PayeeIds)
+
+		if err != nil {
+			log.Warnf(c, "[transactions.TransactionBulkEditHandler] get payee ids error, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		filter.NoTags = transactionBulkEditReq.TagFilter == models.TransactionNoTagFilterValue
+
+		if !filter.NoTags {
+			filter.TagFilters, err = models.ParseTransactionTagFilter(transactionBulkEditReq.TagFilter)
+
+			if err != nil {
+				log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse transaction tag filters error, because %s", err.Error())
+				return nil, errs.Or(err, errs.ErrOperationFailed)
+			}
+		}
+
+		filter.NoItems = transactionBulkEditReq.ItemFilter == models.TransactionNoItemFilterValue
+
+		if !filter.NoItems {
+			filter.ItemFilters, err = models.ParseTransactionItemFilter(transactionBulkEditReq.ItemFilter)
+
+			if err != nil {
+				log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse transaction item filters error, because %s", err.Error())
+				return nil, errs.Or(err, errs.ErrOperationFailed)
+			}
+		}
+	}
+
+	changes := &models.TransactionBulkEditChanges{
+		CategoryId: transactionBulkEditReq.SetCategoryId,
+		AccountId:  transactionBulkEditReq.SetAccountId,
+		Comment:    transactionBulkEditReq.SetComment,
+		Delete:     transactionBulkEditReq.Delete,
+	}
+
+	changes.AddTagIds, err = utils.StringArrayToInt64Array(transactionBulkEditReq.AddTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse tag ids to add failed, because %s", err.Error())
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
+	if len(changes.AddTagIds) > models.MaximumTagsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManyTags
+	}
+
+	changes.RemoveTagIds, err = utils.StringArrayToInt64Array(transactionBulkEditReq.RemoveTagIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse tag ids to remove failed, because %s", err.Error())
+		return nil, errs.ErrTransactionTagIdInvalid
+	}
+
+	changes.AddItemIds, err = utils.StringArrayToInt64Array(transactionBulkEditReq.AddItemIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse item ids to add failed, because %s", err.Error())
+		return nil, errs.ErrTransactionItemIdInvalid
+	}
+
+	if len(changes.AddItemIds) > models.MaximumItemsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManyItems
+	}
+
+	changes.RemoveItemIds, err = utils.StringArrayToInt64Array(transactionBulkEditReq.RemoveItemIds)
+
+	if err != nil {
+		log.Warnf(c, "[transactions.TransactionBulkEditHandler] parse item ids to remove failed, because %s", err.Error())
+		return nil, errs.ErrTransactionItemIdInvalid
+	}
+
+	result, err := a.transactions.BulkEditTransactions(c, user, clientTimezone, filter, changes, transactionBulkEditReq.DryRun, a.GetTransactionRevisionActor(c))
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionBulkEditHandler] failed to bulk edit transactions for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if !transactionBulkEditReq.DryRun {
+		log.Infof(c, "[transactions.TransactionBulkEditHandler] user \"uid:%d\" has bulk edited transactions, %d modified, %d deleted, %d skipped", uid, len(result.ModifiedTransactionIds), len(result.DeletedTransactionIds), len(result.SkippedTransactionIds))
+	}
+
+	return result.ToTransactionBulkEditResponse(transactionBulkEditReq.DryRun), nil
+}
+
 // TransactionParseImportDsvFileDataHandler returns the parsed file data by request parameters for current user
 func (a *TransactionsApi) TransactionParseImportDsvFileDataHandler(c *core.WebContext) (any, *errs.Error) {
 	uid := c.GetCurrentUid()
@@ -1778,6 +2639,25 @@ func (a *TransactionsApi) TransactionParseImportFileHandler(c *core.WebContext)
 		return nil, errs.Or(err, errs.ErrOperationFailed)
 	}
 
+	payees, err := a.payees.GetAllPayeesByUid(c, user.Uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionParseImportFileHandler] failed to get payees for user \"uid:%d\", because %s", user.Uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	for i := 0; i < len(parsedTransactions); i++ {
+		parsedTransaction := parsedTransactions[i]
+
+		if parsedTransaction.OriginalPayeeName == "" {
+			continue
+		}
+
+		if payee := a.payees.GetPayeeByName(payees, parsedTransaction.OriginalPayeeName); payee != nil {
+			parsedTransaction.PayeeId = payee.PayeeId
+		}
+	}
+
 	parsedTransactionRespsList := parsedTransactions.ToImportTransactionResponseList()
 
 	if len(parsedTransactionRespsList) < 1 {
@@ -1834,10 +2714,28 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		}
 	}
 
+	rules, err := a.transactionRules.GetAllEnabledRulesByUid(c, uid)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionImportHandler] failed to get transaction rules for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	newPayees, err := a.fillImportTransactionPayees(c, uid, transactionImportReq.Transactions)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.TransactionImportHandler] failed to fill payees for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
 	newTransactionTagIdsMap := make(map[int][]int64, len(transactionImportReq.Transactions))
 
 	for i := 0; i < len(transactionImportReq.Transactions); i++ {
 		transactionCreateReq := transactionImportReq.Transactions[i]
+
+		// item actions of transaction rules are ignored, because imported transactions do not support items
+		a.applyTransactionRules(rules, transactionCreateReq)
+
 		tagIds, err := utils.StringArrayToInt64Array(transactionCreateReq.TagIds)
 
 		if err != nil {
@@ -1872,6 +2770,17 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 			return nil, errs.ErrTransactionDestinationAmountCannotBeSet
 		}
 
+		if transactionCreateReq.Type != models.TRANSACTION_TYPE_EXPENSE && transactionCreateReq.Type != models.TRANSACTION_TYPE_INCOME && (transactionCreateReq.OriginalCurrency != "" || transactionCreateReq.OriginalAmount != 0) {
+			log.Warnf(c, "[transactions.TransactionImportHandler] non-expense and non-income transaction \"index:%d\" original amount cannot be set", i)
+			return nil, errs.ErrTransactionOriginalAmountCannotBeSet
+		} else if transactionCreateReq.OriginalCurrency == "" && transactionCreateReq.OriginalAmount != 0 {
+			log.Warnf(c, "[transactions.TransactionImportHandler] transaction \"index:%d\" original currency is empty but original amount is set", i)
+			return nil, errs.ErrTransactionOriginalCurrencyIsEmpty
+		} else if _, ok := validators.AllCurrencyNames[transactionCreateReq.OriginalCurrency]; transactionCreateReq.OriginalCurrency != "" && !ok {
+			log.Warnf(c, "[transactions.TransactionImportHandler] transaction \"index:%d\" original currency \"%s\" is not supported", i, transactionCreateReq.OriginalCurrency)
+			return nil, errs.ErrTransactionOriginalCurrencyInvalid
+		}
+
 		newTransactionTagIdsMap[i] = tagIds
 	}
 
@@ -1893,7 +2802,7 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 
 	for i := 0; i < len(transactionImportReq.Transactions); i++ {
 		transactionCreateReq := transactionImportReq.Transactions[i]
-		transaction := a.createNewTransactionModel(uid, transactionCreateReq, c.ClientIP())
+		transaction := a.createNewTransactionModel(uid, c.GetCurrentOperatorUid(), transactionCreateReq, c.ClientIP())
 		transactionEditable := user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone)
 
 		if !transactionEditable {
@@ -1903,7 +2812,7 @@ func (a *TransactionsApi) TransactionImportHandler(c *core.WebContext) (any, *er
 		newTransactions[i] = transaction
 	}
 
-	err = a.transactions.BatchCreateTransactions(c, user.Uid, newTransactions, newTransactionTagIdsMap, func(currentProcess float64) {
+	err = a.transactions.BatchCreateTransactions(c, user.Uid, newTransactions, newTransactionTagIdsMap, newPayees, a.GetTransactionRevisionActor(c), func(currentProcess float64) {
 		a.SetSubmissionRemarkIfEnable(duplicatechecker.DUPLICATE_CHECKER_TYPE_IMPORT_TRANSACTIONS, uid, transactionImportReq.ClientSessionId, fmt.Sprintf("processing:%.2f", currentProcess))
 	})
 	count := len(newTransactions)
@@ -1971,6 +2880,176 @@ func (a *TransactionsApi) TransactionImportProcessHandler(c *core.WebContext) (a
 	return process, nil
 }
 
+func (a *TransactionsApi) applyTransactionRules(rules []*models.TransactionRule, transactionCreateReq *models.TransactionCreateRequest) {
+	if len(rules) < 1 {
+		return
+	}
+
+	// keep the original request if tag ids or item ids are invalid, these errors would be returned by the following validation
+	if _, err := utils.StringArrayToInt64Array(transactionCreateReq.TagIds); err != nil {
+		return
+	}
+
+	if _, err := utils.StringArrayToInt64Array(transactionCreateReq.ItemIds); err != nil {
+		return
+	}
+
+	target := models.NewTransactionRuleTargetFromCreateRequest(transactionCreateReq)
+
+	if len(models.ApplyTransactionRules(rules, target)) > 0 {
+		target.FillCreateRequest(transactionCreateReq)
+	}
+}
+
+func (a *TransactionsApi) fillImportTransactionPayees(c *core.WebContext, uid int64, transactionCreateReqs []*models.TransactionCreateRequest) ([]*models.Payee, error) {
+	var payees []*models.Payee
+	var newPayees []*models.Payee
+	newPayeeNames := make(map[string]*models.Payee)
+	transactionPayees := make([]*models.Payee, len(transactionCreateReqs))
+	maxDisplayOrder := int32(-1)
+
+	for i := 0; i < len(transactionCreateReqs); i++ {
+		transactionCreateReq := transactionCreateReqs[i]
+		payeeName := strings.TrimSpace(transactionCreateReq.Counterparty)
+
+		if transactionCreateReq.PayeeId != 0 || payeeName == "" || utf8.RuneCountInString(payeeName) > 64 {
+			continue
+		}
+
+		if transactionCreateReq.Type != models.TRANSACTION_TYPE_INCOME && transactionCreateReq.Type != models.TRANSACTION_TYPE_EXPENSE {
+			continue
+		}
+
+		if payees == nil {
+			allPayees, err := a.payees.GetAllPayeesByUid(c, uid)
+
+			if err != nil {
+				return nil, err
+			}
+
+			payees = allPayees
+
+			if payees == nil {
+				payees = make([]*models.Payee, 0)
+			}
+		}
+
+		if payee := a.payees.GetPayeeByName(payees, payeeName); payee != nil {
+			transactionPayees[i] = payee
+			continue
+		}
+
+		if payee, exists := newPayeeNames[strings.ToLower(payeeName)]; exists {
+			transactionPayees[i] = payee
+			continue
+		}
+
+		if maxDisplayOrder < 0 {
+			displayOrder, err := a.payees.GetMaxDisplayOrder(c, uid)
+
+			if err != nil {
+				return nil, err
+			}
+
+			maxDisplayOrder = displayOrder
+		}
+
+		payee := &models.Payee{
+			Uid:          uid,
+			Name:         payeeName,
+			DisplayOrder: maxDisplayOrder + int32(len(newPayees)) + 1,
+			Extend:       &models.PayeeExtend{},
+		}
+
+		newPayees = append(newPayees, payee)
+		newPayeeNames[strings.ToLower(payeeName)] = payee
+		transactionPayees[i] = payee
+	}
+
+	// the new payees would be saved together with the imported transactions
+	if len(newPayees) > 0 {
+		err := a.payees.FillNewPayeesIdsAndTimes(newPayees)
+
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof(c, "[transactions.fillImportTransactionPayees] user \"uid:%d\" needs to create %d payees automatically", uid, len(newPayees))
+	}
+
+	matchedPayees := make([]*models.Payee, 0, len(transactionCreateReqs))
+	matchedTransactionCreateReqs := make([]*models.TransactionCreateRequest, 0, len(transactionCreateReqs))
+
+	for i := 0; i < len(transactionCreateReqs); i++ {
+		payee := transactionPayees[i]
+
+		// the existed payee with the same name may be hidden
+		if payee == nil || payee.PayeeId == 0 || payee.Hidden {
+			continue
+		}
+
+		transactionCreateReqs[i].PayeeId = payee.PayeeId
+		matchedPayees = append(matchedPayees, payee)
+		matchedTransactionCreateReqs = append(matchedTransactionCreateReqs, transactionCreateReqs[i])
+	}
+
+	err := a.applyPayeeDefaults(c, uid, matchedPayees, matchedTransactionCreateReqs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newPayees, nil
+}
+
+func (a *TransactionsApi) applyPayeeDefaults(c *core.WebContext, uid int64, payees []*models.Payee, transactionCreateReqs []*models.TransactionCreateRequest) error {
+	defaultCategoryIds := make([]int64, 0, len(payees))
+
+	for i := 0; i < len(payees); i++ {
+		if payees[i].DefaultCategoryId != 0 && transactionCreateReqs[i].CategoryId == 0 {
+			defaultCategoryIds = append(defaultCategoryIds, payees[i].DefaultCategoryId)
+		}
+	}
+
+	var categoryMap map[int64]*models.TransactionCategory
+
+	if len(defaultCategoryIds) > 0 {
+		categories, err := a.transactionCategories.GetCategoriesByCategoryIds(c, uid, utils.ToUniqueInt64Slice(defaultCategoryIds))
+
+		if err != nil {
+			return err
+		}
+
+		categoryMap = categories
+	}
+
+	for i := 0; i < len(payees); i++ {
+		payee := payees[i]
+		transactionCreateReq := transactionCreateReqs[i]
+
+		if payee.Hidden {
+			continue
+		}
+
+		if transactionCreateReq.CategoryId == 0 && payee.DefaultCategoryId != 0 {
+			category, exists := categoryMap[payee.DefaultCategoryId]
+
+			// the default category is only used when it matches the transaction type
+			if exists && !category.Hidden &&
+				((transactionCreateReq.Type == models.TRANSACTION_TYPE_INCOME && category.Type == models.CATEGORY_TYPE_INCOME) ||
+					(transactionCreateReq.Type == models.TRANSACTION_TYPE_EXPENSE && category.Type == models.CATEGORY_TYPE_EXPENSE)) {
+				transactionCreateReq.CategoryId = category.CategoryId
+			}
+		}
+
+		if len(transactionCreateReq.TagIds) < 1 && payee.DefaultTagIds != "" {
+			transactionCreateReq.TagIds = utils.Int64ArrayToStringArray(payee.GetDefaultTagIds())
+		}
+	}
+
+	return nil
+}
+
 func (a *TransactionsApi) filterTransactions(c *core.WebContext, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account) []*models.Transaction {
 	finalTransactions := make([]*models.Transaction, 0, len(transactions))
 
@@ -2027,6 +3106,253 @@ func (a *TransactionsApi) getTransactionItemInfoResponses(itemIds []int64, allTr
 	return allItems
 }
 
+func (a *TransactionsApi) getTransactionItemDetailInfoResponses(itemIndexes []*models.TransactionItemIndex) []*models.TransactionItemDetailInfoResponse {
+	var itemDetailResps []*models.TransactionItemDetailInfoResponse
+
+	for i := 0; i < len(itemIndexes); i++ {
+		if itemIndexes[i].HasDetail() {
+			itemDetailResps = append(itemDetailResps, itemIndexes[i].ToTransactionItemDetailInfoResponse())
+		}
+	}
+
+	return itemDetailResps
+}
+
+func (a *TransactionsApi) getTransactionItemDetailInfoResponsesByMap(itemIds []int64, itemDetails map[int64]*models.TransactionItemIndex) []*models.TransactionItemDetailInfoResponse {
+	itemIndexes := make([]*models.TransactionItemIndex, 0, len(itemDetails))
+
+	for i := 0; i < len(itemIds); i++ {
+		if itemDetail, exists := itemDetails[itemIds[i]]; exists {
+			itemIndexes = append(itemIndexes, itemDetail)
+		}
+	}
+
+	return a.getTransactionItemDetailInfoResponses(itemIndexes)
+}
+
+func (a *TransactionsApi) getTransactionSplitInfoResponseList(splits []*models.TransactionSplit) []*models.TransactionSplitInfoResponse {
+	if len(splits) < 1 {
+		return nil
+	}
+
+	splitResps := make([]*models.TransactionSplitInfoResponse, len(splits))
+
+	for i := 0; i < len(splits); i++ {
+		splitResps[i] = splits[i].ToTransactionSplitInfoResponse()
+	}
+
+	return splitResps
+}
+
+func (a *TransactionsApi) getTransactionCreatorMap(c *core.WebContext, transactions []*models.Transaction) (map[int64]*models.User, error) {
+	creatorUids := make([]int64, 0)
+
+	for i := 0; i < len(transactions); i++ {
+		if transactions[i].IsCreatedByOtherMember() {
+			creatorUids = append(creatorUids, transactions[i].CreatedByUid)
+		}
+	}
+
+	return a.users.GetUsersByUids(c, utils.ToUniqueInt64Slice(creatorUids))
+}
+
+func (a *TransactionsApi) getTransactionCreatorInfoResponse(creator *models.User) *models.TransactionCreatorInfoResponse {
+	return &models.TransactionCreatorInfoResponse{
+		Username: creator.Username,
+		Nickname: creator.Nickname,
+	}
+}
+
+func (a *TransactionsApi) getHistoricalExchangeRates(c *core.WebContext, uid int64, startUnixTime int64, endUnixTime int64) (*models.HistoricalExchangeRates, map[int64]string, string, error) {
+	user, err := a.users.GetUserById(c, uid)
+
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	accountCurrencies := make(map[int64]string, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		accountCurrencies[accounts[i].AccountId] = accounts[i].Currency
+	}
+
+	historicalExchangeRates, err := exchangerates.Container.GetHistoricalExchangeRates(c, uid, a.CurrentConfig(), startUnixTime, endUnixTime)
+
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return historicalExchangeRates, accountCurrencies, user.DefaultCurrency, nil
+}
+
+func (a *TransactionsApi) getHistoricalTransactionAmountExchanger(c *core.WebContext, uid int64, startUnixTime int64, endUnixTime int64) (models.TransactionAmountExchanger, string, error) {
+	historicalExchangeRates, accountCurrencies, defaultCurrency, err := a.getHistoricalExchangeRates(c, uid, startUnixTime, endUnixTime)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	amountExchanger := historicalExchangeRates.ToTransactionAmountExchanger(accountCurrencies, defaultCurrency)
+
+	return func(transaction *models.Transaction) (int64, bool) {
+		amount, exchanged := amountExchanger(transaction)
+
+		if !exchanged {
+			log.Warnf(c, "[transactions.getHistoricalTransactionAmountExchanger] cannot exchange the amounts of account \"id:%d\" to \"%s\" for user \"uid:%d\", the statistics cannot be calculated", transaction.AccountId, defaultCurrency, uid)
+		}
+
+		return amount, exchanged
+	}, defaultCurrency, nil
+}
+
+func (a *TransactionsApi) exchangeAssetTrendsByHistoricalExchangeRates(statisticAssetTrendsResp models.TransactionStatisticAssetTrendsResponseItemSlice, historicalExchangeRates *models.HistoricalExchangeRates, accountCurrencies map[int64]string, defaultCurrency string) {
+	for i := 0; i < len(statisticAssetTrendsResp); i++ {
+		dailyStatisticResp := statisticAssetTrendsResp[i]
+		date := dailyStatisticResp.Year*10000 + dailyStatisticResp.Month*100 + dailyStatisticResp.Day
+
+		for j := 0; j < len(dailyStatisticResp.Items); j++ {
+			item := dailyStatisticResp.Items[j]
+			accountCurrency, exists := accountCurrencies[item.AccountId]
+
+			if !exists {
+				continue
+			}
+
+			amounts := []*int64{&item.AccountOpeningBalance, &item.AccountClosingBalance, &item.InvestmentCostBasis, &item.InvestmentMarketValue, &item.InvestmentUnrealizedGain, &item.InvestmentRealizedGain}
+			exchangedAmounts := make([]int64, len(amounts))
+			allExchanged := true
+
+			for k := 0; k < len(amounts); k++ {
+				exchangedAmount, exchanged := historicalExchangeRates.GetExchangedAmountOnDate(*amounts[k], accountCurrency, defaultCurrency, date)
+
+				if !exchanged {
+					allExchanged = false
+					break
+				}
+
+				exchangedAmounts[k] = exchangedAmount
+			}
+
+			// keep the amounts in account currency if the exchange rate is unavailable, the currency field would be empty in that case
+			if !allExchanged {
+				continue
+			}
+
+			for k := 0; k < len(amounts); k++ {
+				*amounts[k] = exchangedAmounts[k]
+			}
+
+			item.Currency = defaultCurrency
+		}
+	}
+}
+
+func (a *TransactionsApi) fillInvestmentAssetTrends(c *core.WebContext, uid int64, statisticAssetTrendsResp models.TransactionStatisticAssetTrendsResponseItemSlice, clientTimezone *time.Location) error {
+	if len(statisticAssetTrendsResp) < 1 {
+		return nil
+	}
+
+	accounts, err := a.accounts.GetAllAccountsByUid(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	investmentAccountIds := make(map[int64]bool)
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i].Category == models.ACCOUNT_CATEGORY_INVESTMENT {
+			investmentAccountIds[accounts[i].AccountId] = true
+		}
+	}
+
+	if len(investmentAccountIds) < 1 {
+		return nil
+	}
+
+	lastDay := statisticAssetTrendsResp[len(statisticAssetTrendsResp)-1]
+	maxTime := time.Date(int(lastDay.Year), time.Month(lastDay.Month), int(lastDay.Day), 23, 59, 59, 0, clientTimezone).Unix()
+
+	investmentTransactions, err := a.investments.GetInvestmentTransactions(c, uid, 0, 0, maxTime)
+
+	if err != nil {
+		return err
+	}
+
+	if len(investmentTransactions) < 1 {
+		return nil
+	}
+
+	securities, err := a.securities.GetAllSecuritiesByUid(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	securityMap := make(map[int64]*models.Security, len(securities))
+
+	for i := 0; i < len(securities); i++ {
+		securityMap[securities[i].SecurityId] = securities[i]
+	}
+
+	prices, err := a.securities.GetAllSecurityPricesByUid(c, uid, maxTime)
+
+	if err != nil {
+		return err
+	}
+
+	holdings := models.NewInvestmentHoldings()
+	latestPriceMap := make(map[int64]*models.SecurityPrice)
+	transactionIndex := 0
+	priceIndex := 0
+
+	for i := 0; i < len(statisticAssetTrendsResp); i++ {
+		dailyStatisticResp := statisticAssetTrendsResp[i]
+		dayEndTime := time.Date(int(dailyStatisticResp.Year), time.Month(dailyStatisticResp.Month), int(dailyStatisticResp.Day), 23, 59, 59, 0, clientTimezone).Unix()
+
+		for ; transactionIndex < len(investmentTransactions) && investmentTransactions[transactionIndex].TransactionTime <= dayEndTime; transactionIndex++ {
+			transaction := investmentTransactions[transactionIndex]
+			security, exists := securityMap[transaction.SecurityId]
+
+			if !exists {
+				continue
+			}
+
+			err = holdings.Apply(transaction, security.CostBasisMethod)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		for ; priceIndex < len(prices) && prices[priceIndex].PriceTime <= dayEndTime; priceIndex++ {
+			latestPriceMap[prices[priceIndex].SecurityId] = prices[priceIndex]
+		}
+
+		for j := 0; j < len(dailyStatisticResp.Items); j++ {
+			item := dailyStatisticResp.Items[j]
+
+			if !investmentAccountIds[item.AccountId] {
+				continue
+			}
+
+			costBasis, marketValue, realizedGain := holdings.GetAccountValuation(item.AccountId, latestPriceMap)
+			item.InvestmentCostBasis = costBasis
+			item.InvestmentMarketValue = marketValue
+			item.InvestmentUnrealizedGain = marketValue - costBasis
+			item.InvestmentRealizedGain = realizedGain
+		}
+	}
+
+	return nil
+}
+
 func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, user *models.User, transactions []*models.Transaction, clientTimezone *time.Location, withPictures bool, trimAccount bool, trimCategory bool, trimTag bool, trimItem bool) (models.TransactionInfoResponseSlice, error) {
 	uid := user.Uid
 	transactionIds := make([]int64, len(transactions))
@@ -2066,13 +3392,28 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 		return nil, err
 	}
 
-	allTransactionItemIds, err := a.transactionItems.GetAllItemIdsOfTransactions(c, uid, transactionIds)
+	allTransactionItemIndexes, err := a.transactionItems.GetAllItemIndexesOfTransactions(c, uid, transactionIds)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.getTransactionResponseListResult] failed to get transactions item ids for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, err
 	}
 
+	allItemIndexes := make([]*models.TransactionItemIndex, 0, len(allTransactionItemIndexes))
+
+	for _, itemIndexes := range allTransactionItemIndexes {
+		allItemIndexes = append(allItemIndexes, itemIndexes...)
+	}
+
+	allTransactionItemIds := a.transactionItems.GetGroupedTransactionItemIds(allItemIndexes)
+
+	allTransactionSplits, err := a.transactions.GetSplitsOfTransactions(c, uid, transactionIds)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionResponseListResult] failed to get transactions splits for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
 	var categoryMap map[int64]*models.TransactionCategory
 	var tagMap map[int64]*models.TransactionTag
 	var itemMap map[int64]*models.TransactionItem
@@ -2118,6 +3459,13 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 		}
 	}
 
+	creatorMap, err := a.getTransactionCreatorMap(c, transactions)
+
+	if err != nil {
+		log.Errorf(c, "[transactions.getTransactionResponseListResult] failed to get transactions creators for user \"uid:%d\", because %s", uid, err.Error())
+		return nil, err
+	}
+
 	result := make(models.TransactionInfoResponseSlice, len(transactions))
 
 	for i := 0; i < len(transactions); i++ {
@@ -2132,6 +3480,14 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 		transactionItemIds := allTransactionItemIds[transaction.TransactionId]
 		result[i] = transaction.ToTransactionInfoResponse(transactionTagIds, transactionItemIds, transactionEditable)
 
+		if creator := creatorMap[transaction.CreatedByUid]; creator != nil && transaction.IsCreatedByOtherMember() {
+			result[i].CreatedBy = a.getTransactionCreatorInfoResponse(creator)
+		}
+
+		if transactionSplits, exists := allTransactionSplits[transaction.TransactionId]; exists {
+			result[i].Splits = a.getTransactionSplitInfoResponseList(transactionSplits)
+		}
+
 		if !trimAccount {
 			if sourceAccount := allAccounts[transaction.AccountId]; sourceAccount != nil {
 				result[i].SourceAccount = sourceAccount.ToAccountInfoResponse()
@@ -2156,11 +3512,13 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 			result[i].Items = a.getTransactionItemInfoResponses(transactionItemIds, itemMap)
 		}
 
+		result[i].ItemDetails = a.getTransactionItemDetailInfoResponses(allTransactionItemIndexes[transaction.TransactionId])
+
 		if withPictures && a.CurrentConfig().EnableTransactionPictures {
 			pictureInfos, exists := pictureInfoMap[transaction.TransactionId]
 
 			if exists {
-				result[i].Pictures = a.GetTransactionPictureInfoResponseList(pictureInfos)
+				result[i].Pictures = a.GetTransactionPictureInfoResponseList(c, pictureInfos)
 			}
 		}
 	}
@@ -2170,7 +3528,76 @@ func (a *TransactionsApi) getTransactionResponseListResult(c *core.WebContext, u
 	return result, nil
 }
 
-func (a *TransactionsApi) createNewTransactionModel(uid int64, transactionCreateReq *models.TransactionCreateRequest, clientIp string) *models.Transaction {
+func (a *TransactionsApi) createNewTransactionItemDetailModels(itemIds []int64, itemDetailReqs []*models.TransactionItemDetailRequest) (map[int64]*models.TransactionItemIndex, error) {
+	if len(itemDetailReqs) < 1 {
+		return nil, nil
+	}
+
+	itemIdsMap := make(map[int64]bool, len(itemIds))
+
+	for i := 0; i < len(itemIds); i++ {
+		itemIdsMap[itemIds[i]] = true
+	}
+
+	itemDetails := make(map[int64]*models.TransactionItemIndex, len(itemDetailReqs))
+
+	for i := 0; i < len(itemDetailReqs); i++ {
+		itemDetailReq := itemDetailReqs[i]
+
+		if _, exists := itemIdsMap[itemDetailReq.ItemId]; !exists {
+			return nil, errs.ErrTransactionItemDetailNotMatchItem
+		}
+
+		if _, exists := itemDetails[itemDetailReq.ItemId]; exists {
+			return nil, errs.ErrTransactionHasDuplicateItemDetails
+		}
+
+		itemDetail := &models.TransactionItemIndex{
+			ItemId: itemDetailReq.ItemId,
+		}
+
+		itemDetail.FillDetail(itemDetailReq)
+		itemDetails[itemDetailReq.ItemId] = itemDetail
+	}
+
+	return itemDetails, nil
+}
+
+func (a *TransactionsApi) createNewTransactionSplitModels(splitReqs []*models.TransactionSplitRequest) ([]*models.TransactionSplit, error) {
+	if len(splitReqs) < 1 {
+		return nil, nil
+	}
+
+	if len(splitReqs) > models.MaximumSplitsCountOfTransaction {
+		return nil, errs.ErrTransactionHasTooManySplits
+	}
+
+	splits := make([]*models.TransactionSplit, len(splitReqs))
+
+	for i := 0; i < len(splitReqs); i++ {
+		splitReq := splitReqs[i]
+		tagIds, err := utils.StringArrayToInt64Array(splitReq.TagIds)
+
+		if err != nil {
+			return nil, errs.ErrTransactionSplitTagIdInvalid
+		}
+
+		if len(tagIds) > models.MaximumTagsCountOfTransaction {
+			return nil, errs.ErrTransactionSplitHasTooManyTags
+		}
+
+		splits[i] = &models.TransactionSplit{
+			CategoryId: splitReq.CategoryId,
+			Amount:     splitReq.Amount,
+			TagIds:     strings.Join(utils.Int64ArrayToStringArray(utils.ToUniqueInt64Slice(tagIds)), ","),
+			Comment:    splitReq.Comment,
+		}
+	}
+
+	return splits, nil
+}
+
+func (a *TransactionsApi) createNewTransactionModel(uid int64, operatorUid int64, transactionCreateReq *models.TransactionCreateRequest, clientIp string) *models.Transaction {
 	var transactionDbType models.TransactionDbType
 
 	if transactionCreateReq.Type == models.TRANSACTION_TYPE_MODIFY_BALANCE {
@@ -2191,9 +3618,13 @@ func (a *TransactionsApi) createNewTransactionModel(uid int64, transactionCreate
 		TimezoneUtcOffset: transactionCreateReq.UtcOffset,
 		AccountId:         transactionCreateReq.SourceAccountId,
 		Amount:            transactionCreateReq.SourceAmount,
+		OriginalCurrency:  transactionCreateReq.OriginalCurrency,
+		OriginalAmount:    transactionCreateReq.OriginalAmount,
+		PayeeId:           transactionCreateReq.PayeeId,
 		HideAmount:        transactionCreateReq.HideAmount,
 		Comment:           transactionCreateReq.Comment,
 		CreatedIp:         clientIp,
+		CreatedByUid:      operatorUid,
 	}
 
 	if transactionCreateReq.Type == models.TRANSACTION_TYPE_TRANSFER {
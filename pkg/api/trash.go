@@ -0,0 +1,228 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// TrashApi represents recycle bin api
+type TrashApi struct {
+	ApiUsingConfig
+	transactions          *services.TransactionService
+	transactionPictures   *services.TransactionPictureService
+	accounts              *services.AccountService
+	transactionCategories *services.TransactionCategoryService
+	transactionTags       *services.TransactionTagService
+	transactionItems      *services.TransactionItemService
+	transactionTemplates  *services.TransactionTemplateService
+}
+
+// Initialize a recycle bin api singleton instance
+var (
+	Trash = &TrashApi{
+		ApiUsingConfig: ApiUsingConfig{
+			container: settings.Container,
+		},
+		transactions:          services.Transactions,
+		transactionPictures:   services.TransactionPictures,
+		accounts:              services.Accounts,
+		transactionCategories: services.TransactionCategories,
+		transactionTags:       services.TransactionTags,
+		transactionItems:      services.TransactionItems,
+		transactionTemplates:  services.TransactionTemplates,
+	}
+)
+
+// TrashListHandler returns all deleted data of specified type for current user
+func (a *TrashApi) TrashListHandler(c *core.WebContext) (any, *errs.Error) {
+	var trashListReq models.TrashItemListRequest
+	err := c.ShouldBindQuery(&trashListReq)
+
+	if err != nil {
+		log.Warnf(c, "[trash.TrashListHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	var trashItems models.TrashItemInfoResponseSlice
+
+	switch trashListReq.Type {
+	case models.TRASH_ITEM_TYPE_TRANSACTION:
+		transactions, err := a.transactions.GetAllDeletedTransactionsByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[trash.TrashListHandler] failed to get deleted transactions for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		trashItems = make(models.TrashItemInfoResponseSlice, len(transactions))
+
+		for i := 0; i < len(transactions); i++ {
+			trashItems[i] = transactions[i].ToTrashItemInfoResponse()
+		}
+	case models.TRASH_ITEM_TYPE_ACCOUNT:
+		accounts, err := a.accounts.GetAllDeletedAccountsByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[trash.TrashListHandler] failed to get deleted accounts for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		trashItems = make(models.TrashItemInfoResponseSlice, len(accounts))
+
+		for i := 0; i < len(accounts); i++ {
+			trashItems[i] = accounts[i].ToTrashItemInfoResponse()
+		}
+	case models.TRASH_ITEM_TYPE_CATEGORY:
+		categories, err := a.transactionCategories.GetAllDeletedCategoriesByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[trash.TrashListHandler] failed to get deleted categories for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		trashItems = make(models.TrashItemInfoResponseSlice, len(categories))
+
+		for i := 0; i < len(categories); i++ {
+			trashItems[i] = categories[i].ToTrashItemInfoResponse()
+		}
+	case models.TRASH_ITEM_TYPE_TAG:
+		tags, err := a.transactionTags.GetAllDeletedTagsByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[trash.TrashListHandler] failed to get deleted tags for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		trashItems = make(models.TrashItemInfoResponseSlice, len(tags))
+
+		for i := 0; i < len(tags); i++ {
+			trashItems[i] = tags[i].ToTrashItemInfoResponse()
+		}
+	case models.TRASH_ITEM_TYPE_ITEM:
+		items, err := a.transactionItems.GetAllDeletedItemsByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[trash.TrashListHandler] failed to get deleted items for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		trashItems = make(models.TrashItemInfoResponseSlice, len(items))
+
+		for i := 0; i < len(items); i++ {
+			trashItems[i] = items[i].ToTrashItemInfoResponse()
+		}
+	case models.TRASH_ITEM_TYPE_TEMPLATE:
+		templates, err := a.transactionTemplates.GetAllDeletedTemplatesByUid(c, uid)
+
+		if err != nil {
+			log.Errorf(c, "[trash.TrashListHandler] failed to get deleted templates for user \"uid:%d\", because %s", uid, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+
+		trashItems = make(models.TrashItemInfoResponseSlice, len(templates))
+
+		for i := 0; i < len(templates); i++ {
+			trashItems[i] = templates[i].ToTrashItemInfoResponse()
+		}
+	default:
+		return nil, errs.ErrTrashItemTypeInvalid
+	}
+
+	sort.Sort(trashItems)
+
+	return trashItems, nil
+}
+
+// TrashRestoreHandler restores deleted data of specified type for current user
+func (a *TrashApi) TrashRestoreHandler(c *core.WebContext) (any, *errs.Error) {
+	var trashRestoreReq models.TrashItemRestoreRequest
+	err := c.ShouldBindJSON(&trashRestoreReq)
+
+	if err != nil {
+		log.Warnf(c, "[trash.TrashRestoreHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+
+	switch trashRestoreReq.Type {
+	case models.TRASH_ITEM_TYPE_TRANSACTION:
+		err = a.transactions.RestoreTransaction(c, uid, trashRestoreReq.Id, a.GetTransactionRevisionActor(c))
+	case models.TRASH_ITEM_TYPE_ACCOUNT:
+		err = a.accounts.RestoreAccount(c, uid, trashRestoreReq.Id)
+	case models.TRASH_ITEM_TYPE_CATEGORY:
+		err = a.transactionCategories.RestoreCategory(c, uid, trashRestoreReq.Id)
+	case models.TRASH_ITEM_TYPE_TAG:
+		err = a.transactionTags.RestoreTag(c, uid, trashRestoreReq.Id)
+	case models.TRASH_ITEM_TYPE_ITEM:
+		err = a.transactionItems.RestoreItem(c, uid, trashRestoreReq.Id)
+	case models.TRASH_ITEM_TYPE_TEMPLATE:
+		err = a.transactionTemplates.RestoreTemplate(c, uid, trashRestoreReq.Id)
+	default:
+		return nil, errs.ErrTrashItemTypeInvalid
+	}
+
+	if err != nil {
+		log.Errorf(c, "[trash.TrashRestoreHandler] failed to restore deleted data \"type:%d, id:%d\" for user \"uid:%d\", because %s", trashRestoreReq.Type, trashRestoreReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	log.Infof(c, "[trash.TrashRestoreHandler] user \"uid:%d\" has restored deleted data \"type:%d, id:%d\" successfully", uid, trashRestoreReq.Type, trashRestoreReq.Id)
+
+	return true, nil
+}
+
+// TrashPurgeHandler permanently deletes deleted data of specified type for current user
+func (a *TrashApi) TrashPurgeHandler(c *core.WebContext) (any, *errs.Error) {
+	var trashPurgeReq models.TrashItemPurgeRequest
+	err := c.ShouldBindJSON(&trashPurgeReq)
+
+	if err != nil {
+		log.Warnf(c, "[trash.TrashPurgeHandler] parse request failed, because %s", err.Error())
+		return nil, errs.NewIncompleteOrIncorrectSubmissionError(err)
+	}
+
+	uid := c.GetCurrentUid()
+	var pictureInfos []*models.TransactionPictureInfo
+
+	switch trashPurgeReq.Type {
+	case models.TRASH_ITEM_TYPE_TRANSACTION:
+		pictureInfos, err = a.transactions.PurgeTransaction(c, uid, trashPurgeReq.Id)
+	case models.TRASH_ITEM_TYPE_ACCOUNT:
+		err = a.accounts.PurgeAccount(c, uid, trashPurgeReq.Id)
+	case models.TRASH_ITEM_TYPE_CATEGORY:
+		err = a.transactionCategories.PurgeCategory(c, uid, trashPurgeReq.Id)
+	case models.TRASH_ITEM_TYPE_TAG:
+		err = a.transactionTags.PurgeTag(c, uid, trashPurgeReq.Id)
+	case models.TRASH_ITEM_TYPE_ITEM:
+		err = a.transactionItems.PurgeItem(c, uid, trashPurgeReq.Id)
+	case models.TRASH_ITEM_TYPE_TEMPLATE:
+		err = a.transactionTemplates.PurgeTemplate(c, uid, trashPurgeReq.Id)
+	default:
+		return nil, errs.ErrTrashItemTypeInvalid
+	}
+
+	if err != nil {
+		log.Errorf(c, "[trash.TrashPurgeHandler] failed to purge deleted data \"type:%d, id:%d\" for user \"uid:%d\", because %s", trashPurgeReq.Type, trashPurgeReq.Id, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrOperationFailed)
+	}
+
+	if len(pictureInfos) > 0 {
+		err = a.transactionPictures.DeletePictureFiles(c, pictureInfos)
+
+		if err != nil {
+			log.Warnf(c, "[trash.TrashPurgeHandler] failed to delete picture files of transaction \"id:%d\" for user \"uid:%d\", because %s", trashPurgeReq.Id, uid, err.Error())
+		}
+	}
+
+	log.Infof(c, "[trash.TrashPurgeHandler] user \"uid:%d\" has purged deleted data \"type:%d, id:%d\" successfully", uid, trashPurgeReq.Type, trashPurgeReq.Id)
+
+	return true, nil
+}
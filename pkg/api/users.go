@@ -326,6 +326,18 @@ func (a *UsersApi) UserUpdateProfileHandler(c *core.WebContext) (any, *errs.Erro
 		userNew.TransactionEditScope = models.TRANSACTION_EDIT_SCOPE_INVALID
 	}
 
+	if userUpdateReq.TransactionLockTime != nil && *userUpdateReq.TransactionLockTime != user.TransactionLockTime {
+		if *userUpdateReq.TransactionLockTime < user.TransactionLockTime {
+			log.Warnf(c, "[users.UserUpdateProfileHandler] cannot move transaction lock time backward for user \"uid:%d\"", uid)
+			return nil, errs.ErrCannotMoveTransactionLockTimeBackward
+		}
+
+		user.TransactionLockTime = *userUpdateReq.TransactionLockTime
+		userNew.TransactionLockTime = *userUpdateReq.TransactionLockTime
+		modifyProfileBasicInfo = true
+		anythingUpdate = true
+	}
+
 	modifyUserLanguage := false
 
 	if userUpdateReq.Language != user.Language {
@@ -290,6 +290,41 @@ func (l *UserDataCli) RemoveUserFeatureRestrictions(c *core.CliContext, username
 	return nil
 }
 
+// SetUserTransactionLockTime sets the transaction lock time of user or one account of user according to the specified user name, the lock time can be moved backward or cleared
+func (l *UserDataCli) SetUserTransactionLockTime(c *core.CliContext, username string, accountId int64, transactionLockTime int64) error {
+	if username == "" {
+		log.CliErrorf(c, "[user_data.SetUserTransactionLockTime] user name is empty")
+		return errs.ErrUsernameIsEmpty
+	}
+
+	if accountId <= 0 {
+		err := l.users.SetUserTransactionLockTime(c, username, transactionLockTime)
+
+		if err != nil {
+			log.CliErrorf(c, "[user_data.SetUserTransactionLockTime] failed to set user transaction lock time by user name \"%s\", because %s", username, err.Error())
+			return err
+		}
+
+		return nil
+	}
+
+	uid, err := l.getUserIdByUsername(c, username)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.SetUserTransactionLockTime] error occurs when getting user id by user name")
+		return err
+	}
+
+	err = l.accounts.SetAccountTransactionLockTime(c, uid, accountId, transactionLockTime)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.SetUserTransactionLockTime] failed to set transaction lock time of account \"id:%d\" for user \"%s\", because %s", accountId, username, err.Error())
+		return err
+	}
+
+	return nil
+}
+
 // ResendVerifyEmail resends an email with account activation link
 func (l *UserDataCli) ResendVerifyEmail(c *core.CliContext, username string) error {
 	if !l.CurrentConfig().EnableUserVerifyEmail {
@@ -777,13 +812,20 @@ func (l *UserDataCli) ExportTransaction(c *core.CliContext, username string, fil
 		return nil, err
 	}
 
+	allSplits, err := l.transactions.GetAllSplitsMapOfAllTransactions(c, uid)
+
+	if err != nil {
+		log.CliErrorf(c, "[user_data.ExportTransaction] failed to get all transaction splits for user \"%s\", because %s", username, err.Error())
+		return nil, err
+	}
+
 	dataExporter := converters.GetTransactionDataExporter(fileType)
 
 	if dataExporter == nil {
 		return nil, errs.ErrNotImplemented
 	}
 
-	result, err := dataExporter.ToExportedContent(c, uid, allTransactions, accountMap, categoryMap, tagMap, tagIndexesMap)
+	result, err := dataExporter.ToExportedContent(c, uid, allTransactions, accountMap, categoryMap, tagMap, tagIndexesMap, allSplits)
 
 	if err != nil {
 		log.CliErrorf(c, "[user_data.ExportTransaction] failed to get csv format exported data for \"%s\", because %s", username, err.Error())
@@ -869,7 +911,12 @@ func (l *UserDataCli) ImportTransaction(c *core.CliContext, username string, fil
 		return errs.ErrOperationFailed
 	}
 
-	err = l.transactions.BatchCreateTransactions(c, user.Uid, newTransactions, newTransactionTagIdsMap, nil)
+	actor := &models.TransactionRevisionActor{
+		OperatorUid: user.Uid,
+		Source:      models.TRANSACTION_REVISION_SOURCE_CLI,
+	}
+
+	err = l.transactions.BatchCreateTransactions(c, user.Uid, newTransactions, newTransactionTagIdsMap, nil, actor, nil)
 
 	if err != nil {
 		log.CliErrorf(c, "[user_data.ImportTransaction] failed to create transaction, because %s", err.Error())
@@ -569,6 +569,31 @@ func TestAlipayCsvFileImporterParseImportedData_ParseDescription(t *testing.T) {
 	assert.Equal(t, "test", allNewTransactions[0].Comment)
 }
 
+func TestAlipayCsvFileImporterParseImportedData_ParsePayeeName(t *testing.T) {
+	importer := AlipayWebTransactionDataCsvFileImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	data, err := simplifiedchinese.GB18030.NewEncoder().String("支付宝交易记录明细查询\n" +
+		"账号:[xxx@xxx.xxx]\n" +
+		"起始日期:[2024-01-01 00:00:00]    终止日期:[2024-09-01 23:59:59]\n" +
+		"---------------------------------交易记录明细列表------------------------------------\n" +
+		"交易创建时间              ,交易对方            ,商品名称                ,金额（元）,收/支     ,交易状态    ,\n" +
+		"2024-09-01 12:34:56 ,Test Store          ,test                ,0.12   ,支出      ,交易成功    ,\n" +
+		"------------------------------------------------------------------------------------\n")
+	assert.Nil(t, err)
+
+	allNewTransactions, _, _, _, _, _, err := importer.ParseImportedData(context, user, []byte(data), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(allNewTransactions))
+	assert.Equal(t, "Test Store", allNewTransactions[0].OriginalPayeeName)
+}
+
 func TestAlipayCsvFileImporterParseImportedData_SkipClosedIncomeOrTransferTransaction(t *testing.T) {
 	importer := AlipayWebTransactionDataCsvFileImporter
 	context := core.NewNullContext()
@@ -80,6 +80,12 @@ func (p *alipayTransactionDataRowParser) Parse(ctx core.Context, user *models.Us
 		data[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = ""
 	}
 
+	if p.hasOriginalColumn(p.columns.targetNameColumnName) && dataRow.GetData(p.columns.typeColumnName) != alipayTransactionTypeNameMapping[models.TRANSACTION_TYPE_TRANSFER] {
+		data[datatable.TRANSACTION_DATA_TABLE_PAYEE] = dataRow.GetData(p.columns.targetNameColumnName)
+	} else {
+		data[datatable.TRANSACTION_DATA_TABLE_PAYEE] = ""
+	}
+
 	relatedAccountName := ""
 
 	if p.hasOriginalColumn(p.columns.relatedAccountColumnName) {
@@ -187,11 +193,11 @@ func (p *alipayTransactionDataRowParser) Parse(ctx core.Context, user *models.Us
 
 	if data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == alipayTransactionTypeNameMapping[models.TRANSACTION_TYPE_INCOME] && statusName != "" {
 		if statusName == alipayTransactionDataStatusRefundSuccessName || statusName == alipayTransactionDataStatusTaxRefundSuccessName {
-			amount, err := utils.ParseAmount(data[datatable.TRANSACTION_DATA_TABLE_AMOUNT])
+			amount, err := utils.ParseAmountOfUndeterminedCurrency(data[datatable.TRANSACTION_DATA_TABLE_AMOUNT])
 
 			if err == nil {
 				data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = alipayTransactionTypeNameMapping[models.TRANSACTION_TYPE_EXPENSE]
-				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(-amount)
 			}
 		}
 	}
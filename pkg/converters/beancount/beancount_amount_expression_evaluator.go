@@ -13,7 +13,6 @@ import (
 const maxAllowedDecimalCount = 6
 const normalizeFactor = int64(1000000)
 const normalizedDecimalsMaxZeroString = "000000"
-const normalizedNumberToAmountFactor = int64(10000) // 1000000 / 100
 
 var operatorPriority = map[rune]int{
 	'+': 1,
@@ -54,10 +53,11 @@ func normalizeNumber(textualNumber string) (*big.Int, error) {
 	return result, nil
 }
 
-func denormalizeNumberToTextualAmount(num *big.Int) string {
+func denormalizeNumberToTextualAmount(num *big.Int, currency string) string {
+	decimalPlaces := utils.GetCurrencyAmountDecimalPlaces(currency)
 	result := big.NewInt(0).Add(num, big.NewInt(0)) // make a copy of num
-	result = result.Div(result, big.NewInt(normalizedNumberToAmountFactor))
-	return utils.FormatAmount(result.Int64())
+	result = result.Div(result, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(maxAllowedDecimalCount-decimalPlaces)), nil))
+	return utils.FormatAmountWithDecimalPlaces(result.Int64(), decimalPlaces)
 }
 
 func toPostfixExprTokens(ctx core.Context, expr string) ([]string, error) {
@@ -221,7 +221,7 @@ func evaluatePostfixExpr(ctx core.Context, tokens []string) (*big.Int, error) {
 	return stack[0], nil
 }
 
-func evaluateBeancountAmountExpression(ctx core.Context, expr string) (string, error) {
+func evaluateBeancountAmountExpression(ctx core.Context, expr string, currency string) (string, error) {
 	if expr == "" {
 		return "", nil
 	}
@@ -238,5 +238,5 @@ func evaluateBeancountAmountExpression(ctx core.Context, expr string) (string, e
 		return "", err
 	}
 
-	return denormalizeNumberToTextualAmount(result), nil
+	return denormalizeNumberToTextualAmount(result, currency), nil
 }
@@ -145,91 +145,99 @@ func TestEvaluatePostfixExpr_InvalidExpression(t *testing.T) {
 func TestEvaluateBeancountAmountExpression_ValidExpression(t *testing.T) {
 	context := core.NewNullContext()
 
-	result, err := evaluateBeancountAmountExpression(context, "")
+	result, err := evaluateBeancountAmountExpression(context, "", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "1+2")
+	result, err = evaluateBeancountAmountExpression(context, "1+2", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "3.00", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "(1+2)*3")
+	result, err = evaluateBeancountAmountExpression(context, "(1+2)*3", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "9.00", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "-1+2")
+	result, err = evaluateBeancountAmountExpression(context, "-1+2", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "1.00", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "1.5+2.5")
+	result, err = evaluateBeancountAmountExpression(context, "1.5+2.5", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "4.00", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "1+2*3-(4/2)")
+	result, err = evaluateBeancountAmountExpression(context, "1+2*3-(4/2)", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "5.00", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "2*-3-3/-2")
+	result, err = evaluateBeancountAmountExpression(context, "2*-3-3/-2", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "-4.50", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "-1.2-3.4*(-5.6/7.8*(9.0-1.2))")
+	result, err = evaluateBeancountAmountExpression(context, "-1.2-3.4*(-5.6/7.8*(9.0-1.2))", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "17.84", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "(((2+3)))*(((((-5+7)))))")
+	result, err = evaluateBeancountAmountExpression(context, "(((2+3)))*(((((-5+7)))))", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "10.00", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "3.5+0.1")
+	result, err = evaluateBeancountAmountExpression(context, "3.5+0.1", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "3.60", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "3.55+0.11")
+	result, err = evaluateBeancountAmountExpression(context, "3.55+0.11", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "3.66", result)
 
-	result, err = evaluateBeancountAmountExpression(context, "3.555+0.111")
+	result, err = evaluateBeancountAmountExpression(context, "3.555+0.111", "CNY")
 	assert.Nil(t, err)
 	assert.Equal(t, "3.66", result)
+
+	result, err = evaluateBeancountAmountExpression(context, "3.555+0.111", "KWD")
+	assert.Nil(t, err)
+	assert.Equal(t, "3.666", result)
+
+	result, err = evaluateBeancountAmountExpression(context, "1000/4", "JPY")
+	assert.Nil(t, err)
+	assert.Equal(t, "250.00", result)
 }
 
 func TestEvaluateBeancountAmountExpression_InvalidExpression(t *testing.T) {
 	context := core.NewNullContext()
 
-	_, err := evaluateBeancountAmountExpression(context, "1++2")
+	_, err := evaluateBeancountAmountExpression(context, "1++2", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1^2")
+	_, err = evaluateBeancountAmountExpression(context, "1^2", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "+-*/")
+	_, err = evaluateBeancountAmountExpression(context, "+-*/", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "a+b")
+	_, err = evaluateBeancountAmountExpression(context, "a+b", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1/0")
+	_, err = evaluateBeancountAmountExpression(context, "1/0", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1+(2*3")
+	_, err = evaluateBeancountAmountExpression(context, "1+(2*3", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1+2*3)")
+	_, err = evaluateBeancountAmountExpression(context, "1+2*3)", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1+((((2*3)))")
+	_, err = evaluateBeancountAmountExpression(context, "1+((((2*3)))", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1+2(3)")
+	_, err = evaluateBeancountAmountExpression(context, "1+2(3)", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "1)*(2")
+	_, err = evaluateBeancountAmountExpression(context, "1)*(2", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "0.abcd+1")
+	_, err = evaluateBeancountAmountExpression(context, "0.abcd+1", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 
-	_, err = evaluateBeancountAmountExpression(context, "0.1234567+1")
+	_, err = evaluateBeancountAmountExpression(context, "0.1234567+1", "CNY")
 	assert.Equal(t, errs.ErrInvalidAmountExpression, err)
 }
@@ -422,15 +422,6 @@ func (r *beancountDataReader) readTransactionPostingLine(ctx core.Context, lineI
 		return nil, errs.ErrAmountInvalid
 	}
 
-	finalAmount, err := evaluateBeancountAmountExpression(ctx, transactionPositing.OriginalAmount)
-
-	if err != nil {
-		log.Warnf(ctx, "[beancount_data_reader.readTransactionPostingLine] cannot evaluate amount expression in line#%d \"%s\", because %s", lineIndex, strings.Join(items, " "), err.Error())
-		return nil, errs.ErrAmountInvalid
-	} else {
-		transactionPositing.Amount = finalAmount
-	}
-
 	commodityActualIndex := -1
 	transactionPositing.Commodity, commodityActualIndex = r.getNotEmptyItemAndIndexFromIndex(items, amountActualLastIndex+1)
 
@@ -444,6 +435,15 @@ func (r *beancountDataReader) readTransactionPostingLine(ctx core.Context, lineI
 		return nil, errs.ErrInvalidBeancountFile
 	}
 
+	finalAmount, err := evaluateBeancountAmountExpression(ctx, transactionPositing.OriginalAmount, transactionPositing.Commodity)
+
+	if err != nil {
+		log.Warnf(ctx, "[beancount_data_reader.readTransactionPostingLine] cannot evaluate amount expression in line#%d \"%s\", because %s", lineIndex, strings.Join(items, " "), err.Error())
+		return nil, errs.ErrAmountInvalid
+	} else {
+		transactionPositing.Amount = finalAmount
+	}
+
 	// parse remain items
 	if commodityActualIndex > 0 {
 		for i := commodityActualIndex + 1; i < len(items); i++ {
@@ -128,14 +128,14 @@ func (t *beancountTransactionDataRowIterator) parseTransaction(ctx core.Context,
 			return nil, errs.ErrMissingAccountData
 		}
 
-		amount1, err := utils.ParseAmount(splitData1.Amount)
+		amount1, err := utils.ParseAmountOfUndeterminedCurrency(splitData1.Amount)
 
 		if err != nil {
 			log.Errorf(ctx, "[beancount_transaction_data_table.parseTransaction] cannot parse amount \"%s\", because %s", splitData1.Amount, err.Error())
 			return nil, errs.ErrAmountInvalid
 		}
 
-		amount2, err := utils.ParseAmount(splitData2.Amount)
+		amount2, err := utils.ParseAmountOfUndeterminedCurrency(splitData2.Amount)
 
 		if err != nil {
 			log.Errorf(ctx, "[beancount_transaction_data_table.parseTransaction] cannot parse amount \"%s\", because %s", splitData2.Amount, err.Error())
@@ -165,7 +165,7 @@ func (t *beancountTransactionDataRowIterator) parseTransaction(ctx core.Context,
 			data[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = fromAccount.Name
 			data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = toAccount.Name
 			data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = toCurrency
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(toAmount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(toAmount)
 		} else if account1.AccountType == beancountExpensesAccountType && (account2.AccountType == beancountAssetsAccountType || account2.AccountType == beancountLiabilitiesAccountType) ||
 			(account2.AccountType == beancountExpensesAccountType && (account1.AccountType == beancountAssetsAccountType || account1.AccountType == beancountLiabilitiesAccountType)) { // expense
 			fromAccount := account1
@@ -184,7 +184,7 @@ func (t *beancountTransactionDataRowIterator) parseTransaction(ctx core.Context,
 			data[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = toAccount.Name
 			data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = fromAccount.Name
 			data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = fromCurrency
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-fromAmount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(-fromAmount)
 		} else if (account1.AccountType == beancountAssetsAccountType || account1.AccountType == beancountLiabilitiesAccountType) &&
 			(account2.AccountType == beancountAssetsAccountType || account2.AccountType == beancountLiabilitiesAccountType) {
 			var fromAccount, toAccount *beancountAccount
@@ -214,10 +214,10 @@ func (t *beancountTransactionDataRowIterator) parseTransaction(ctx core.Context,
 			data[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = ""
 			data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = fromAccount.Name
 			data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = fromCurrency
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(fromAmount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(fromAmount)
 			data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME] = toAccount.Name
 			data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY] = toCurrency
-			data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmount(toAmount)
+			data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(toAmount)
 		} else {
 			log.Errorf(ctx, "[beancount_transaction_data_table.parseTransaction] cannot parse transaction, because unexcepted account types \"%d\" and \"%d\"", account1.AccountType, account2.AccountType)
 			return nil, errs.ErrThereAreNotSupportedTransactionType
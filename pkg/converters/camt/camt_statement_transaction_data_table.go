@@ -22,6 +22,8 @@ var camtTransactionSupportedColumns = map[datatable.TransactionDataTableColumn]b
 	datatable.TRANSACTION_DATA_TABLE_AMOUNT:               true,
 	datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME: true,
 	datatable.TRANSACTION_DATA_TABLE_DESCRIPTION:          true,
+	datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY:    true,
+	datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT:      true,
 }
 
 // camtStatementTransactionDataTable defines the structure of camt statement transaction data table
@@ -273,14 +275,29 @@ func (t *camtStatementTransactionDataRowIterator) parseTransaction(ctx core.Cont
 		return nil, errs.ErrAmountInvalid
 	}
 
-	amount, err := utils.ParseAmount(amountValue)
+	amount, err := utils.ParseCurrencyAmount(amountValue, data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY])
 
 	if err != nil {
 		log.Errorf(ctx, "[camt_statement_transaction_data_table.parseTransaction] cannot parsing transaction amount \"%s\", because %s", amountValue, err.Error())
 		return nil, errs.ErrAmountInvalid
 	}
 
-	data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amount)
+	data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(amount, data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY])
+
+	// the instructed amount is the amount in the currency of the original payment order (e.g. card purchase made abroad)
+	if transactionDetails != nil && transactionDetails.AmountDetails != nil && transactionDetails.AmountDetails.InstructedAmount != nil &&
+		transactionDetails.AmountDetails.InstructedAmount.Currency != "" && transactionDetails.AmountDetails.InstructedAmount.Value != "" &&
+		transactionDetails.AmountDetails.InstructedAmount.Currency != data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] {
+		originalAmount, err := utils.ParseCurrencyAmount(transactionDetails.AmountDetails.InstructedAmount.Value, transactionDetails.AmountDetails.InstructedAmount.Currency)
+
+		if err != nil {
+			log.Errorf(ctx, "[camt_statement_transaction_data_table.parseTransaction] cannot parsing transaction instructed amount \"%s\", because %s", transactionDetails.AmountDetails.InstructedAmount.Value, err.Error())
+			return nil, errs.ErrAmountInvalid
+		}
+
+		data[datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY] = transactionDetails.AmountDetails.InstructedAmount.Currency
+		data[datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT] = utils.FormatCurrencyAmount(originalAmount, transactionDetails.AmountDetails.InstructedAmount.Currency)
+	}
 
 	if entry.CreditDebitIndicator == CAMT_INDICATOR_CREDIT {
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = utils.IntToString(int(models.TRANSACTION_TYPE_INCOME))
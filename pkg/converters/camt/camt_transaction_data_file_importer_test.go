@@ -638,6 +638,79 @@ func TestCamt053TransactionDataFileParseImportedData_ParseTransactionInvalidAmou
 	assert.EqualError(t, err, errs.ErrAmountInvalid.Message)
 }
 
+func TestCamt053TransactionDataFileParseImportedData_ParseTransactionOriginalAmountAndCurrency(t *testing.T) {
+	importer := Camt053TransactionDataImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	allNewTransactions, _, _, _, _, _, err := importer.ParseImportedData(context, user, []byte(
+		`<?xml version="1.0" encoding="UTF-8"?>
+		<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+			<BkToCstmrStmt>
+				<Stmt>
+					<Acct>
+						<Id>
+							<IBAN>123</IBAN>
+						</Id>
+						<Ccy>EUR</Ccy>
+					</Acct>
+					<Ntry>
+						<BookgDt>
+							<DtTm>2024-09-01T12:34:56+08:00</DtTm>
+						</BookgDt>
+						<CdtDbtInd>DBIT</CdtDbtInd>
+						<Amt Ccy="EUR">9.35</Amt>
+						<NtryDtls>
+							<TxDtls>
+								<AmtDtls>
+									<InstdAmt>
+										<Amt Ccy="JPY">1500</Amt>
+									</InstdAmt>
+									<TxAmt>
+										<Amt Ccy="EUR">9.35</Amt>
+									</TxAmt>
+								</AmtDtls>
+							</TxDtls>
+						</NtryDtls>
+					</Ntry>
+					<Ntry>
+						<BookgDt>
+							<DtTm>2024-09-02T12:34:56+08:00</DtTm>
+						</BookgDt>
+						<CdtDbtInd>DBIT</CdtDbtInd>
+						<Amt Ccy="EUR">12.34</Amt>
+						<NtryDtls>
+							<TxDtls>
+								<AmtDtls>
+									<InstdAmt>
+										<Amt Ccy="EUR">12.34</Amt>
+									</InstdAmt>
+								</AmtDtls>
+							</TxDtls>
+						</NtryDtls>
+					</Ntry>
+				</Stmt>
+			</BkToCstmrStmt>
+		</Document>`), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(allNewTransactions))
+
+	assert.Equal(t, "EUR", allNewTransactions[0].OriginalSourceAccountCurrency)
+	assert.Equal(t, int64(935), allNewTransactions[0].Amount)
+	assert.Equal(t, "JPY", allNewTransactions[0].OriginalCurrency)
+	assert.Equal(t, int64(150000), allNewTransactions[0].OriginalAmount)
+
+	assert.Equal(t, "EUR", allNewTransactions[1].OriginalSourceAccountCurrency)
+	assert.Equal(t, int64(1234), allNewTransactions[1].Amount)
+	assert.Equal(t, "", allNewTransactions[1].OriginalCurrency)
+	assert.Equal(t, int64(0), allNewTransactions[1].OriginalAmount)
+}
+
 func TestCamt053TransactionDataFileParseImportedData_ParseDescription(t *testing.T) {
 	importer := Camt053TransactionDataImporter
 	context := core.NewNullContext()
@@ -19,7 +19,7 @@ type DataTableTransactionDataExporter struct {
 }
 
 // BuildExportedContent writes the exported transaction data to the data table builder
-func (c *DataTableTransactionDataExporter) BuildExportedContent(ctx core.Context, dataTableBuilder datatable.TransactionDataTableBuilder, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, allTagIndexes map[int64][]int64) error {
+func (c *DataTableTransactionDataExporter) BuildExportedContent(ctx core.Context, dataTableBuilder datatable.TransactionDataTableBuilder, uid int64, transactions []*models.Transaction, accountMap map[int64]*models.Account, categoryMap map[int64]*models.TransactionCategory, tagMap map[int64]*models.TransactionTag, allTagIndexes map[int64][]int64, allSplits map[int64][]*models.TransactionSplit) error {
 	for i := 0; i < len(transactions); i++ {
 		transaction := transactions[i]
 
@@ -38,19 +38,45 @@ func (c *DataTableTransactionDataExporter) BuildExportedContent(ctx core.Context
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = c.getExportedTransactionSubCategoryName(dataTableBuilder, transaction.CategoryId, categoryMap)
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = c.getExportedAccountName(dataTableBuilder, transaction.AccountId, accountMap)
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = c.getAccountCurrency(dataTableBuilder, transaction.AccountId, accountMap)
-		dataRowMap[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(transaction.Amount)
+		dataRowMap[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = c.getExportedAmount(transaction.Amount, transaction.AccountId, accountMap)
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
 			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME] = c.getExportedAccountName(dataTableBuilder, transaction.RelatedAccountId, accountMap)
 			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY] = c.getAccountCurrency(dataTableBuilder, transaction.RelatedAccountId, accountMap)
-			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmount(transaction.RelatedAccountAmount)
+			dataRowMap[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = c.getExportedAmount(transaction.RelatedAccountAmount, transaction.RelatedAccountId, accountMap)
 		}
 
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_GEOGRAPHIC_LOCATION] = c.getExportedGeographicLocation(transaction)
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_TAGS] = c.getExportedTags(dataTableBuilder, transaction.TransactionId, allTagIndexes, tagMap)
 		dataRowMap[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = dataTableBuilder.ReplaceDelimiters(transaction.Comment)
 
-		dataTableBuilder.AppendTransaction(dataRowMap)
+		splits, exists := allSplits[transaction.TransactionId]
+
+		if !exists || len(splits) < 1 || (transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE) {
+			dataTableBuilder.AppendTransaction(dataRowMap)
+			continue
+		}
+
+		// write one row for each split line, and the category, amount, tags and description in each row are from the split line
+		for j := 0; j < len(splits); j++ {
+			split := splits[j]
+			splitDataRowMap := make(map[datatable.TransactionDataTableColumn]string, len(dataRowMap))
+
+			for column, value := range dataRowMap {
+				splitDataRowMap[column] = value
+			}
+
+			splitDataRowMap[datatable.TRANSACTION_DATA_TABLE_CATEGORY] = c.getExportedTransactionCategoryName(dataTableBuilder, split.CategoryId, categoryMap)
+			splitDataRowMap[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = c.getExportedTransactionSubCategoryName(dataTableBuilder, split.CategoryId, categoryMap)
+			splitDataRowMap[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = c.getExportedAmount(split.Amount, transaction.AccountId, accountMap)
+			splitDataRowMap[datatable.TRANSACTION_DATA_TABLE_TAGS] = c.getExportedTagNames(dataTableBuilder, split.GetTagIds(), tagMap)
+
+			if split.Comment != "" {
+				splitDataRowMap[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = dataTableBuilder.ReplaceDelimiters(split.Comment)
+			}
+
+			dataTableBuilder.AppendTransaction(splitDataRowMap)
+		}
 	}
 
 	return nil
@@ -122,6 +148,16 @@ func (c *DataTableTransactionDataExporter) getAccountCurrency(dataTableBuilder d
 	}
 }
 
+func (c *DataTableTransactionDataExporter) getExportedAmount(amount int64, accountId int64, accountMap map[int64]*models.Account) string {
+	account, exists := accountMap[accountId]
+
+	if exists {
+		return utils.FormatCurrencyAmount(amount, account.Currency)
+	} else {
+		return utils.FormatAmount(amount)
+	}
+}
+
 func (c *DataTableTransactionDataExporter) getExportedGeographicLocation(transaction *models.Transaction) string {
 	if transaction.GeoLongitude != 0 || transaction.GeoLatitude != 0 {
 		return fmt.Sprintf("%f%s%f", transaction.GeoLongitude, c.geoLocationSeparator, transaction.GeoLatitude)
@@ -137,6 +173,10 @@ func (c *DataTableTransactionDataExporter) getExportedTags(dataTableBuilder data
 		return ""
 	}
 
+	return c.getExportedTagNames(dataTableBuilder, tagIndexes, tagMap)
+}
+
+func (c *DataTableTransactionDataExporter) getExportedTagNames(dataTableBuilder datatable.TransactionDataTableBuilder, tagIndexes []int64, tagMap map[int64]*models.TransactionTag) string {
 	var ret strings.Builder
 
 	for i := 0; i < len(tagIndexes); i++ {
@@ -215,7 +215,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			accountCurrency = account.Currency
 		}
 
-		amount, err := utils.ParseAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_AMOUNT))
+		amount, err := utils.ParseCurrencyAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_AMOUNT), accountCurrency)
 
 		if err != nil {
 			log.Errorf(ctx, "[data_table_transaction_data_importer.ParseImportedData] cannot parse acmount \"%s\" in data row \"index:%d\" for user \"uid:%d\", because %s", dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_AMOUNT), dataRowIndex, user.Uid, err.Error())
@@ -260,7 +260,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			relatedAccountId = account2.AccountId
 
 			if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT) {
-				relatedAccountAmount, err = utils.ParseAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT))
+				relatedAccountAmount, err = utils.ParseCurrencyAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT), account2Currency)
 
 				if err != nil {
 					log.Errorf(ctx, "[data_table_transaction_data_importer.ParseImportedData] cannot parse acmount2 \"%s\" in data row \"index:%d\" for user \"uid:%d\", because %s", dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT), dataRowIndex, user.Uid, err.Error())
@@ -271,6 +271,27 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			}
 		}
 
+		originalCurrency := ""
+		originalAmount := int64(0)
+
+		if (transactionDbType == models.TRANSACTION_DB_TYPE_EXPENSE || transactionDbType == models.TRANSACTION_DB_TYPE_INCOME) &&
+			dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY) && dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT) &&
+			dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY) != "" && dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY) != accountCurrency {
+			originalCurrency = dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY)
+
+			if _, ok := validators.AllCurrencyNames[originalCurrency]; !ok {
+				log.Errorf(ctx, "[data_table_transaction_data_importer.ParseImportedData] original currency \"%s\" is not supported in data row \"index:%d\" for user \"uid:%d\"", originalCurrency, dataRowIndex, user.Uid)
+				return nil, nil, nil, nil, nil, nil, errs.ErrTransactionOriginalCurrencyInvalid
+			}
+
+			originalAmount, err = utils.ParseCurrencyAmount(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT), originalCurrency)
+
+			if err != nil {
+				log.Errorf(ctx, "[data_table_transaction_data_importer.ParseImportedData] cannot parse original amount \"%s\" in data row \"index:%d\" for user \"uid:%d\", because %s", dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT), dataRowIndex, user.Uid, err.Error())
+				return nil, nil, nil, nil, nil, nil, errs.ErrAmountInvalid
+			}
+		}
+
 		geoLongitude := float64(0)
 		geoLatitude := float64(0)
 
@@ -358,6 +379,12 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			}
 		}
 
+		payeeName := ""
+
+		if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_PAYEE) {
+			payeeName = strings.TrimSpace(dataRow.GetData(datatable.TRANSACTION_DATA_TABLE_PAYEE))
+		}
+
 		description := ""
 
 		if dataTable.HasColumn(datatable.TRANSACTION_DATA_TABLE_DESCRIPTION) {
@@ -380,6 +407,8 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 				HideAmount:           false,
 				RelatedAccountId:     relatedAccountId,
 				RelatedAccountAmount: relatedAccountAmount,
+				OriginalCurrency:     originalCurrency,
+				OriginalAmount:       originalAmount,
 				Comment:              description,
 				GeoLongitude:         geoLongitude,
 				GeoLatitude:          geoLatitude,
@@ -392,6 +421,7 @@ func (c *DataTableTransactionDataImporter) ParseImportedData(ctx core.Context, u
 			OriginalDestinationAccountName:     account2Name,
 			OriginalDestinationAccountCurrency: account2Currency,
 			OriginalTagNames:                   tagNames,
+			OriginalPayeeName:                  payeeName,
 		}
 
 		allNewTransactions = append(allNewTransactions, transaction)
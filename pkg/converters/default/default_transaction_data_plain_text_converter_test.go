@@ -121,7 +121,83 @@ func TestDefaultTransactionDataCSVFileConverterToExportedContent(t *testing.T) {
 		"2024-09-01 12:34:56,+08:00,Income,Test Category,Test Sub Category,Test Account,CNY,123.45,,,,123.450000 45.670000,Test Tag;Test Tag2,Hello World\n" +
 		"2024-09-01 12:34:56,+00:00,Expense,Test Category2,Test Sub Category2,Test Account,CNY,-0.10,,,,,Test Tag,Foo#Bar\n" +
 		"2024-09-01 12:34:56,-05:00,Transfer,Test Category3,Test Sub Category3,Test Account,CNY,123.45,Test Account2,USD,17.35,,Test Tag2,T\te s t test\n"
-	actualContent, err := exporter.ToExportedContent(context, 123, transactions, accountMap, categoryMap, tagMap, allTagIndexes)
+	actualContent, err := exporter.ToExportedContent(context, 123, transactions, accountMap, categoryMap, tagMap, allTagIndexes, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedContent, string(actualContent))
+}
+
+func TestDefaultTransactionDataCSVFileConverterToExportedContent_SplitTransaction(t *testing.T) {
+	exporter := DefaultTransactionDataCSVFileConverter
+	context := core.NewNullContext()
+
+	transactions := make([]*models.Transaction, 1)
+	transactions[0] = &models.Transaction{
+		TransactionId:     1,
+		TransactionTime:   1725194096000,
+		Type:              models.TRANSACTION_DB_TYPE_EXPENSE,
+		TimezoneUtcOffset: 0,
+		CategoryId:        2,
+		AccountId:         1,
+		Amount:            3000,
+		Comment:           "Supermarket",
+	}
+
+	accountMap := make(map[int64]*models.Account, 1)
+	accountMap[1] = &models.Account{
+		AccountId: 1,
+		Name:      "Test Account",
+		Currency:  "CNY",
+	}
+
+	categoryMap := make(map[int64]*models.TransactionCategory, 3)
+	categoryMap[1] = &models.TransactionCategory{
+		CategoryId: 1,
+		Type:       models.CATEGORY_TYPE_EXPENSE,
+		Name:       "Test Category",
+	}
+	categoryMap[2] = &models.TransactionCategory{
+		CategoryId:       2,
+		Type:             models.CATEGORY_TYPE_EXPENSE,
+		ParentCategoryId: 1,
+		Name:             "Test Sub Category",
+	}
+	categoryMap[3] = &models.TransactionCategory{
+		CategoryId:       3,
+		Type:             models.CATEGORY_TYPE_EXPENSE,
+		ParentCategoryId: 1,
+		Name:             "Test Sub Category2",
+	}
+
+	tagMap := make(map[int64]*models.TransactionTag, 1)
+	tagMap[1] = &models.TransactionTag{
+		TagId: 1,
+		Name:  "Test Tag",
+	}
+
+	allTagIndexes := make(map[int64][]int64, 1)
+	allTagIndexes[1] = []int64{1}
+
+	allSplits := make(map[int64][]*models.TransactionSplit, 1)
+	allSplits[1] = []*models.TransactionSplit{
+		{
+			TransactionId: 1,
+			CategoryId:    2,
+			Amount:        1000,
+		},
+		{
+			TransactionId: 1,
+			CategoryId:    3,
+			Amount:        2000,
+			TagIds:        "1",
+			Comment:       "Household",
+		},
+	}
+
+	expectedContent := "Time,Timezone,Type,Category,Sub Category,Account,Account Currency,Amount,Account2,Account2 Currency,Account2 Amount,Geographic Location,Tags,Description\n" +
+		"2024-09-01 12:34:56,+00:00,Expense,Test Category,Test Sub Category,Test Account,CNY,10.00,,,,,,Supermarket\n" +
+		"2024-09-01 12:34:56,+00:00,Expense,Test Category,Test Sub Category2,Test Account,CNY,20.00,,,,,Test Tag,Household\n"
+	actualContent, err := exporter.ToExportedContent(context, 123, transactions, accountMap, categoryMap, tagMap, allTagIndexes, allSplits)
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedContent, string(actualContent))
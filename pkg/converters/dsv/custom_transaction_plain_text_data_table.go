@@ -247,13 +247,13 @@ func (t *customPlainTextDataRowIterator) parseAmount(ctx core.Context, amountVal
 	}
 
 	amountValue = utils.TrimTrailingZerosInDecimal(amountValue)
-	amount, err := utils.ParseAmount(amountValue)
+	amount, err := utils.ParseAmountOfUndeterminedCurrency(amountValue)
 
 	if err != nil {
 		return "", errs.ErrAmountInvalid
 	}
 
-	return utils.FormatAmount(amount), nil
+	return utils.FormatAmountOfUndeterminedCurrency(amount), nil
 }
 
 // CreateNewCustomPlainTextDataTable returns transaction data table from imported data table
@@ -49,7 +49,7 @@ func (p *feideeMymoneyElecloudTransactionDataRowParser) Parse(data map[datatable
 	rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = strings.ReplaceAll(rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT], ",", "") // remove thousand separator
 
 	if rowData[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == FEIDEE_MYMONEY_ELECLOUD_TRANSACTION_TYPE_MODIFY_BALANCE_NAME {
-		amount, err := utils.ParseAmount(rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT])
+		amount, err := utils.ParseAmountOfUndeterminedCurrency(rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT])
 
 		if err != nil {
 			return nil, false, errs.ErrAmountInvalid
@@ -60,10 +60,10 @@ func (p *feideeMymoneyElecloudTransactionDataRowParser) Parse(data map[datatable
 			rowData[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = FEIDEE_MYMONEY_ELECLOUD_TRANSACTION_TYPE_INCOME_NAME
 		} else {
 			rowData[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = FEIDEE_MYMONEY_ELECLOUD_TRANSACTION_TYPE_EXPENSE_NAME
-			rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+			rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(-amount)
 		}
 	} else if rowData[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == FEIDEE_MYMONEY_ELECLOUD_TRANSACTION_TYPE_OUTSTANDING_MODIFY_BALANCE_NAME {
-		amount, err := utils.ParseAmount(rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT])
+		amount, err := utils.ParseAmountOfUndeterminedCurrency(rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT])
 
 		if err != nil {
 			return nil, false, errs.ErrAmountInvalid
@@ -74,7 +74,7 @@ func (p *feideeMymoneyElecloudTransactionDataRowParser) Parse(data map[datatable
 			rowData[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = FEIDEE_MYMONEY_ELECLOUD_TRANSACTION_TYPE_EXPENSE_NAME
 		} else {
 			rowData[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = FEIDEE_MYMONEY_ELECLOUD_TRANSACTION_TYPE_INCOME_NAME
-			rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+			rowData[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(-amount)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package gnucash
 
 import (
+	"math"
 	"strings"
 
 	"github.com/mayswind/ezbookkeeping/pkg/converters/datatable"
@@ -141,13 +142,13 @@ func (t *gnucashTransactionDataRowIterator) parseTransaction(ctx core.Context, u
 			return nil, false, errs.ErrAmountInvalid
 		}
 
-		amount1, err := t.parseAmount(splitData1.Quantity)
+		amount1, err := t.parseAmount(splitData1.Quantity, t.getCurrency(account1))
 
 		if err != nil {
 			return nil, false, err
 		}
 
-		amount2, err := t.parseAmount(splitData2.Quantity)
+		amount2, err := t.parseAmount(splitData2.Quantity, t.getCurrency(account2))
 
 		if err != nil {
 			return nil, false, err
@@ -194,13 +195,13 @@ func (t *gnucashTransactionDataRowIterator) parseTransaction(ctx core.Context, u
 				toAccount = account1
 			}
 
-			amount, err := utils.ParseAmount(fromAmount)
+			amount, err := utils.ParseAmountOfUndeterminedCurrency(fromAmount)
 
 			if err != nil {
 				return nil, false, errs.ErrAmountInvalid
 			}
 
-			fromAmount = utils.FormatAmount(-amount)
+			fromAmount = utils.FormatAmountOfUndeterminedCurrency(-amount)
 
 			data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = utils.IntToString(int(models.TRANSACTION_TYPE_EXPENSE))
 			data[datatable.TRANSACTION_DATA_TABLE_CATEGORY] = t.getCategoryName(toAccount)
@@ -266,13 +267,13 @@ func (t *gnucashTransactionDataRowIterator) parseTransaction(ctx core.Context, u
 			return nil, false, errs.ErrAmountInvalid
 		}
 
-		amount, err := t.parseAmount(splitData.Quantity)
+		amount, err := t.parseAmount(splitData.Quantity, t.getCurrency(account))
 
 		if err != nil {
 			return nil, false, err
 		}
 
-		amountNum, err := utils.ParseAmount(amount)
+		amountNum, err := utils.ParseAmountOfUndeterminedCurrency(amount)
 
 		if err != nil {
 			return nil, false, err
@@ -298,7 +299,7 @@ func (t *gnucashTransactionDataRowIterator) parseTransaction(ctx core.Context, u
 	return data, true, nil
 }
 
-func (t *gnucashTransactionDataRowIterator) parseAmount(quantity string) (string, error) {
+func (t *gnucashTransactionDataRowIterator) parseAmount(quantity string, currency string) (string, error) {
 	items := strings.Split(quantity, "/")
 
 	if len(items) != 2 {
@@ -321,9 +322,18 @@ func (t *gnucashTransactionDataRowIterator) parseAmount(quantity string) (string
 		return "", errs.ErrAmountInvalid
 	}
 
-	value = value * 100 / factor
+	decimalPlaces := utils.GetCurrencyAmountDecimalPlaces(currency)
+	value = value * int64(math.Pow10(int(decimalPlaces))) / factor
 
-	return utils.FormatAmount(value), nil
+	return utils.FormatAmountWithDecimalPlaces(value, decimalPlaces), nil
+}
+
+func (t *gnucashTransactionDataRowIterator) getCurrency(accountData *gnucashAccountData) string {
+	if accountData.Commodity != nil && accountData.Commodity.Space == gnucashCommodityCurrencySpace {
+		return accountData.Commodity.Id
+	}
+
+	return ""
 }
 
 func (t *gnucashTransactionDataRowIterator) getCategoryName(accountData *gnucashAccountData) string {
@@ -242,7 +242,7 @@ func (t *iifTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 	if transactionType == iifTransactionTypeBeginningBalance { // balance modification
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = iifTransactionTypeNameMapping[models.TRANSACTION_TYPE_MODIFY_BALANCE]
 		data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = mainAccountName
-		data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(mainAmountNum)
+		data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(mainAmountNum)
 	} else if (t.dataTable.incomeAccountNames[mainAccountName] && !t.dataTable.incomeAccountNames[splitAccountName] && !t.dataTable.expenseAccountNames[splitAccountName]) ||
 		(t.dataTable.incomeAccountNames[splitAccountName] && !t.dataTable.incomeAccountNames[mainAccountName] && !t.dataTable.expenseAccountNames[mainAccountName]) { // income
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = iifTransactionTypeNameMapping[models.TRANSACTION_TYPE_INCOME]
@@ -283,7 +283,7 @@ func (t *iifTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		}
 
 		data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = accountName
-		data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amountNum)
+		data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(amountNum)
 	} else if (t.dataTable.expenseAccountNames[mainAccountName] && !t.dataTable.expenseAccountNames[splitAccountName] && !t.dataTable.incomeAccountNames[splitAccountName]) ||
 		(t.dataTable.expenseAccountNames[splitAccountName] && !t.dataTable.expenseAccountNames[mainAccountName] && !t.dataTable.incomeAccountNames[mainAccountName]) { // expense
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = iifTransactionTypeNameMapping[models.TRANSACTION_TYPE_EXPENSE]
@@ -324,7 +324,7 @@ func (t *iifTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		}
 
 		data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = accountName
-		data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amountNum)
+		data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(amountNum)
 	} else {
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = iifTransactionTypeNameMapping[models.TRANSACTION_TYPE_TRANSFER]
 		data[datatable.TRANSACTION_DATA_TABLE_SUB_CATEGORY] = ""
@@ -357,15 +357,15 @@ func (t *iifTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		}
 
 		if amountNum >= 0 {
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amountNum)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(amountNum)
 		} else {
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amountNum)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(-amountNum)
 		}
 
 		if relatedAmountNum >= 0 {
-			data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmount(relatedAmountNum)
+			data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(relatedAmountNum)
 		} else {
-			data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmount(-relatedAmountNum)
+			data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = utils.FormatAmountOfUndeterminedCurrency(-relatedAmountNum)
 		}
 	}
 
@@ -513,5 +513,5 @@ func getIncomeAndExpenseAccountNameMap(accountDatasets []*iifAccountDataset) (in
 }
 
 func parseAmount(amount string) (int64, error) {
-	return utils.ParseAmount(strings.ReplaceAll(amount, ",", ""))
+	return utils.ParseAmountOfUndeterminedCurrency(strings.ReplaceAll(amount, ",", ""))
 }
@@ -12,7 +12,8 @@ const (
 )
 
 const (
-	MT_INFORMATION_TO_ACCOUNT_OWNER_TAG_REMITTANCE string = "REMI"
+	MT_INFORMATION_TO_ACCOUNT_OWNER_TAG_REMITTANCE      string = "REMI"
+	MT_INFORMATION_TO_ACCOUNT_OWNER_TAG_ORIGINAL_AMOUNT string = "OCMT"
 )
 
 // mt940Data defines the structure of mt940 data
@@ -195,6 +195,53 @@ func TestMT940TransactionDataFileParseImportedData_ParseDescription(t *testing.T
 	assert.Equal(t, "Transaction 1\nPart 2\nPart 3", allNewTransactions[0].Comment)
 }
 
+func TestMT940TransactionDataFileParseImportedData_ParseOriginalAmountAndCurrency(t *testing.T) {
+	importer := MT940TransactionDataFileImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	allNewTransactions, _, _, _, _, _, err := importer.ParseImportedData(context, user, []byte(
+		`{1:F01TESTBANK123456789}{2:I940TESTBANK}{4:
+		:20:123456789
+		:25:12345678
+		:28C:123/1
+		:60F:C250601EUR123,45
+		:61:2506010601D9,35NTRFTEST
+		:86:/REMI/Card payment/OCMT/JPY1500,/
+		:61:2506020602D12,34NTRFTEST
+		:86:/REMI/Card payment/OCMT/EUR12,34/
+		:62F:C250602EUR101,76
+		-}`), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(allNewTransactions))
+
+	assert.Equal(t, int64(935), allNewTransactions[0].Amount)
+	assert.Equal(t, "JPY", allNewTransactions[0].OriginalCurrency)
+	assert.Equal(t, int64(150000), allNewTransactions[0].OriginalAmount)
+	assert.Equal(t, "Card payment", allNewTransactions[0].Comment)
+
+	assert.Equal(t, int64(1234), allNewTransactions[1].Amount)
+	assert.Equal(t, "", allNewTransactions[1].OriginalCurrency)
+	assert.Equal(t, int64(0), allNewTransactions[1].OriginalAmount)
+
+	_, _, _, _, _, _, err = importer.ParseImportedData(context, user, []byte(
+		`{1:F01TESTBANK123456789}{2:I940TESTBANK}{4:
+		:20:123456789
+		:25:12345678
+		:28C:123/1
+		:60F:C250601EUR123,45
+		:61:2506010601D9,35NTRFTEST
+		:86:/OCMT/JPY15a0,/
+		:62F:C250601EUR114,10
+		-}`), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.EqualError(t, err, errs.ErrAmountInvalid.Message)
+}
+
 func TestMT940TransactionDataFileParseImportedData_MissingRequiredField(t *testing.T) {
 	importer := MT940TransactionDataFileImporter
 	context := core.NewNullContext()
@@ -20,6 +20,8 @@ var mt940TransactionSupportedColumns = map[datatable.TransactionDataTableColumn]
 	datatable.TRANSACTION_DATA_TABLE_AMOUNT:               true,
 	datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME: true,
 	datatable.TRANSACTION_DATA_TABLE_DESCRIPTION:          true,
+	datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY:    true,
+	datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT:      true,
 }
 
 // mt940TransactionDataTable represents the mt940 statement data dataTable
@@ -126,20 +128,14 @@ func (t *mt940TransactionDataRowIterator) parseTransaction(ctx core.Context, use
 		return nil, errs.ErrAccountCurrencyInvalid
 	}
 
-	amountValue := strings.ReplaceAll(statement.Amount, ",", ".") // decimal separator is comma in mt data
-
-	if len(amountValue) > 0 && amountValue[len(amountValue)-1] == '.' {
-		amountValue = amountValue[:len(amountValue)-1]
-	}
-
-	amount, err := utils.ParseAmount(amountValue)
+	amount, err := t.parseAmount(statement.Amount, data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY])
 
 	if err != nil {
 		log.Errorf(ctx, "[mt_transaction_data_table.parseTransaction] cannot parsing transaction amount \"%s\", because %s", statement.Amount, err.Error())
 		return nil, errs.ErrAmountInvalid
 	}
 
-	data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amount)
+	data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(amount, data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY])
 
 	if statement.CreditDebitMark == MT_MARK_CREDIT {
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = utils.IntToString(int(models.TRANSACTION_TYPE_INCOME))
@@ -159,6 +155,21 @@ func (t *mt940TransactionDataRowIterator) parseTransaction(ctx core.Context, use
 		if value, exists := informationToAccountOwnerMap[MT_INFORMATION_TO_ACCOUNT_OWNER_TAG_REMITTANCE]; exists {
 			data[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = value
 		}
+
+		if value, exists := informationToAccountOwnerMap[MT_INFORMATION_TO_ACCOUNT_OWNER_TAG_ORIGINAL_AMOUNT]; exists && len(value) > 3 {
+			originalCurrency := value[0:3]
+			originalAmount, err := t.parseAmount(value[3:], originalCurrency)
+
+			if err != nil {
+				log.Errorf(ctx, "[mt_transaction_data_table.parseTransaction] cannot parsing transaction original amount \"%s\", because %s", value, err.Error())
+				return nil, errs.ErrAmountInvalid
+			}
+
+			if originalCurrency != data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] {
+				data[datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY] = originalCurrency
+				data[datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT] = utils.FormatCurrencyAmount(originalAmount, originalCurrency)
+			}
+		}
 	} else {
 		data[datatable.TRANSACTION_DATA_TABLE_DESCRIPTION] = strings.Join(statement.InformationToAccountOwner, "\n")
 	}
@@ -166,6 +177,16 @@ func (t *mt940TransactionDataRowIterator) parseTransaction(ctx core.Context, use
 	return data, nil
 }
 
+func (t *mt940TransactionDataRowIterator) parseAmount(value string, currency string) (int64, error) {
+	amountValue := strings.ReplaceAll(value, ",", ".") // decimal separator is comma in mt data
+
+	if len(amountValue) > 0 && amountValue[len(amountValue)-1] == '.' {
+		amountValue = amountValue[:len(amountValue)-1]
+	}
+
+	return utils.ParseCurrencyAmount(amountValue, currency)
+}
+
 // createNewMT940TransactionDataTable creates a new mt940 statement data dataTable
 func createNewMT940TransactionDataTable(data *mt940Data) (*mt940TransactionDataTable, error) {
 	if data == nil || len(data.Statements) < 1 {
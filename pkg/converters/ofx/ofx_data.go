@@ -158,8 +158,8 @@ type ofxBaseStatementTransaction struct {
 	Name             string             `xml:"NAME"`
 	Payee            *ofxPayee          `xml:"PAYEE"`
 	Memo             string             `xml:"MEMO"`
-	Currency         string             `xml:"CURRENCY"`
-	OriginalCurrency string             `xml:"ORIGCURRENCY"`
+	Currency         *ofxCurrency       `xml:"CURRENCY"`
+	OriginalCurrency *ofxCurrency       `xml:"ORIGCURRENCY"`
 }
 
 // ofxBankStatementTransaction represents the struct of open financial exchange (ofx) bank statement transaction
@@ -174,6 +174,13 @@ type ofxCreditCardStatementTransaction struct {
 	AccountTo *ofxCreditCardAccount `xml:"CCACCTTO"`
 }
 
+// ofxCurrency represents the struct of open financial exchange (ofx) currency info, which contains the currency rate and currency symbol, or only the currency code text (non-standard)
+type ofxCurrency struct {
+	Value          string `xml:",chardata"`
+	CurrencyRate   string `xml:"CURRATE"`
+	CurrencySymbol string `xml:"CURSYM"`
+}
+
 // ofxPayee represents the struct of open financial exchange (ofx) payee info
 type ofxPayee struct {
 	Name       string `xml:"NAME"`
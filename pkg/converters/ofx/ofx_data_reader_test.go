@@ -362,8 +362,10 @@ func TestCreateNewOFXFileReader_OFX1ParseTransaction(t *testing.T) {
 	assert.Equal(t, "123.45", transaction.Amount)
 	assert.Equal(t, "Test Name", transaction.Name)
 	assert.Equal(t, "Some Text", transaction.Memo)
-	assert.Equal(t, "CNY", transaction.Currency)
-	assert.Equal(t, "USD", transaction.OriginalCurrency)
+	assert.NotNil(t, transaction.Currency)
+	assert.Equal(t, "CNY", transaction.Currency.Value)
+	assert.NotNil(t, transaction.OriginalCurrency)
+	assert.Equal(t, "USD", transaction.OriginalCurrency.Value)
 }
 
 func TestCreateNewOFXFileReader_OFX1ParseTransactionPayee(t *testing.T) {
@@ -513,6 +513,198 @@ func TestOFXTransactionDataFileParseImportedData_ParseTransactionCurrency(t *tes
 	assert.Equal(t, "USD", allNewTransactions[0].OriginalSourceAccountCurrency)
 }
 
+func TestOFXTransactionDataFileParseImportedData_ParseOriginalAmountAndCurrency(t *testing.T) {
+	importer := OFXTransactionDataImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	allNewTransactions, _, _, _, _, _, err := importer.ParseImportedData(context, user, []byte(
+		"<OFX>\n"+
+			"  <BANKMSGSRSV1>\n"+
+			"    <STMTTRNRS>\n"+
+			"      <STMTRS>\n"+
+			"        <CURDEF>CNY</CURDEF>\n"+
+			"        <BANKACCTFROM>\n"+
+			"          <ACCTID>123</ACCTID>\n"+
+			"        </BANKACCTFROM>\n"+
+			"        <BANKTRANLIST>\n"+
+			"          <STMTTRN>\n"+
+			"            <TRNTYPE>DEBIT</TRNTYPE>\n"+
+			"            <DTPOSTED>20240902012345.000[+8:CST]</DTPOSTED>\n"+
+			"            <TRNAMT>-71.00</TRNAMT>\n"+
+			"            <ORIGCURRENCY>\n"+
+			"              <CURRATE>7.1</CURRATE>\n"+
+			"              <CURSYM>USD</CURSYM>\n"+
+			"            </ORIGCURRENCY>\n"+
+			"          </STMTTRN>\n"+
+			"          <STMTTRN>\n"+
+			"            <TRNTYPE>DEBIT</TRNTYPE>\n"+
+			"            <DTPOSTED>20240901012345.000[+8:CST]</DTPOSTED>\n"+
+			"            <TRNAMT>-12.34</TRNAMT>\n"+
+			"            <ORIGCURRENCY>\n"+
+			"              <CURSYM>USD</CURSYM>\n"+
+			"            </ORIGCURRENCY>\n"+
+			"          </STMTTRN>\n"+
+			"        </BANKTRANLIST>\n"+
+			"      </STMTRS>\n"+
+			"    </STMTTRNRS>\n"+
+			"  </BANKMSGSRSV1>\n"+
+			"</OFX>"), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(allNewTransactions))
+	assert.Equal(t, "", allNewTransactions[0].OriginalCurrency)
+	assert.Equal(t, int64(0), allNewTransactions[0].OriginalAmount)
+	assert.Equal(t, int64(7100), allNewTransactions[1].Amount)
+	assert.Equal(t, "USD", allNewTransactions[1].OriginalCurrency)
+	assert.Equal(t, int64(1000), allNewTransactions[1].OriginalAmount)
+
+	allNewTransactions, _, _, _, _, _, err = importer.ParseImportedData(context, user, []byte(
+		"<OFX>\n"+
+			"  <BANKMSGSRSV1>\n"+
+			"    <STMTTRNRS>\n"+
+			"      <STMTRS>\n"+
+			"        <CURDEF>CNY</CURDEF>\n"+
+			"        <BANKACCTFROM>\n"+
+			"          <ACCTID>123</ACCTID>\n"+
+			"        </BANKACCTFROM>\n"+
+			"        <BANKTRANLIST>\n"+
+			"          <STMTTRN>\n"+
+			"            <TRNTYPE>DEBIT</TRNTYPE>\n"+
+			"            <DTPOSTED>20240901012345.000[+8:CST]</DTPOSTED>\n"+
+			"            <TRNAMT>-10.00</TRNAMT>\n"+
+			"            <CURRENCY>\n"+
+			"              <CURRATE>7.1</CURRATE>\n"+
+			"              <CURSYM>USD</CURSYM>\n"+
+			"            </CURRENCY>\n"+
+			"          </STMTTRN>\n"+
+			"        </BANKTRANLIST>\n"+
+			"      </STMTRS>\n"+
+			"    </STMTTRNRS>\n"+
+			"  </BANKMSGSRSV1>\n"+
+			"</OFX>"), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(allNewTransactions))
+	assert.Equal(t, "USD", allNewTransactions[0].OriginalSourceAccountCurrency)
+	assert.Equal(t, int64(1000), allNewTransactions[0].Amount)
+	assert.Equal(t, "", allNewTransactions[0].OriginalCurrency)
+
+	allNewTransactions, _, _, _, _, _, err = importer.ParseImportedData(context, user, []byte(
+		"OFXHEADER:100\n"+
+			"DATA:OFXSGML\n"+
+			"VERSION:103\n"+
+			"SECURITY:NONE\n"+
+			"ENCODING:USASCII\n"+
+			"CHARSET:1252\n"+
+			"COMPRESSION:NONE\n"+
+			"OLDFILEUID:NONE\n"+
+			"NEWFILEUID:NONE\n"+
+			"\n"+
+			"<OFX>\n"+
+			"<BANKMSGSRSV1>\n"+
+			"<STMTTRNRS>\n"+
+			"<STMTRS>\n"+
+			"<CURDEF>CNY\n"+
+			"<BANKACCTFROM>\n"+
+			"<ACCTID>123\n"+
+			"</BANKACCTFROM>\n"+
+			"<BANKTRANLIST>\n"+
+			"<STMTTRN>\n"+
+			"<TRNTYPE>DEBIT\n"+
+			"<DTPOSTED>20240901012345.000[+8:CST]\n"+
+			"<TRNAMT>-71.00\n"+
+			"<ORIGCURRENCY>\n"+
+			"<CURRATE>7.1\n"+
+			"<CURSYM>USD\n"+
+			"</ORIGCURRENCY>\n"+
+			"</STMTTRN>\n"+
+			"</BANKTRANLIST>\n"+
+			"</STMTRS>\n"+
+			"</STMTTRNRS>\n"+
+			"</BANKMSGSRSV1>\n"+
+			"</OFX>"), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(allNewTransactions))
+	assert.Equal(t, "CNY", allNewTransactions[0].OriginalSourceAccountCurrency)
+	assert.Equal(t, int64(7100), allNewTransactions[0].Amount)
+	assert.Equal(t, "USD", allNewTransactions[0].OriginalCurrency)
+	assert.Equal(t, int64(1000), allNewTransactions[0].OriginalAmount)
+
+	allNewTransactions, _, _, _, _, _, err = importer.ParseImportedData(context, user, []byte(
+		"OFXHEADER:100\n"+
+			"DATA:OFXSGML\n"+
+			"VERSION:103\n"+
+			"SECURITY:NONE\n"+
+			"ENCODING:USASCII\n"+
+			"CHARSET:1252\n"+
+			"COMPRESSION:NONE\n"+
+			"OLDFILEUID:NONE\n"+
+			"NEWFILEUID:NONE\n"+
+			"\n"+
+			"<OFX>\n"+
+			"<BANKMSGSRSV1>\n"+
+			"<STMTTRNRS>\n"+
+			"<STMTRS>\n"+
+			"<CURDEF>CNY\n"+
+			"<BANKACCTFROM>\n"+
+			"<ACCTID>123\n"+
+			"</BANKACCTFROM>\n"+
+			"<BANKTRANLIST>\n"+
+			"<STMTTRN>\n"+
+			"<TRNTYPE>DEBIT\n"+
+			"<DTPOSTED>20240901012345.000[+8:CST]\n"+
+			"<TRNAMT>-10.00\n"+
+			"<CURRENCY>\n"+
+			"<CURRATE>7.1\n"+
+			"<CURSYM>USD\n"+
+			"</CURRENCY>\n"+
+			"</STMTTRN>\n"+
+			"</BANKTRANLIST>\n"+
+			"</STMTRS>\n"+
+			"</STMTTRNRS>\n"+
+			"</BANKMSGSRSV1>\n"+
+			"</OFX>"), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(allNewTransactions))
+	assert.Equal(t, "USD", allNewTransactions[0].OriginalSourceAccountCurrency)
+	assert.Equal(t, int64(1000), allNewTransactions[0].Amount)
+	assert.Equal(t, "", allNewTransactions[0].OriginalCurrency)
+
+	// Invalid currency rate
+	_, _, _, _, _, _, err = importer.ParseImportedData(context, user, []byte(
+		"<OFX>\n"+
+			"  <BANKMSGSRSV1>\n"+
+			"    <STMTTRNRS>\n"+
+			"      <STMTRS>\n"+
+			"        <CURDEF>CNY</CURDEF>\n"+
+			"        <BANKACCTFROM>\n"+
+			"          <ACCTID>123</ACCTID>\n"+
+			"        </BANKACCTFROM>\n"+
+			"        <BANKTRANLIST>\n"+
+			"          <STMTTRN>\n"+
+			"            <TRNTYPE>DEBIT</TRNTYPE>\n"+
+			"            <DTPOSTED>20240901012345.000[+8:CST]</DTPOSTED>\n"+
+			"            <TRNAMT>-71.00</TRNAMT>\n"+
+			"            <ORIGCURRENCY>\n"+
+			"              <CURRATE>0</CURRATE>\n"+
+			"              <CURSYM>USD</CURSYM>\n"+
+			"            </ORIGCURRENCY>\n"+
+			"          </STMTTRN>\n"+
+			"        </BANKTRANLIST>\n"+
+			"      </STMTRS>\n"+
+			"    </STMTTRNRS>\n"+
+			"  </BANKMSGSRSV1>\n"+
+			"</OFX>"), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.EqualError(t, err, errs.ErrAmountInvalid.Message)
+}
+
 func TestOFXTransactionDataFileParseImportedData_ParseDescription(t *testing.T) {
 	importer := OFXTransactionDataImporter
 	context := core.NewNullContext()
@@ -24,6 +24,8 @@ var ofxTransactionSupportedColumns = map[datatable.TransactionDataTableColumn]bo
 	datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY: true,
 	datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT:           true,
 	datatable.TRANSACTION_DATA_TABLE_DESCRIPTION:              true,
+	datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY:        true,
+	datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT:          true,
 }
 
 // ofxTransactionData defines the structure of open financial exchange (ofx) transaction data
@@ -142,8 +144,18 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 
 	data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = ofxTransaction.FromAccountId
 
-	if ofxTransaction.Currency != "" {
-		data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = ofxTransaction.Currency
+	currency := ""
+
+	if ofxTransaction.Currency != nil { // the amount is in the currency of <CURSYM> in <CURRENCY> aggregate
+		currency = t.getCurrencyCode(ofxTransaction.Currency)
+
+		if currency == "" {
+			return nil, errs.ErrAccountCurrencyInvalid
+		}
+	}
+
+	if currency != "" {
+		data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = currency
 	} else {
 		data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] = ofxTransaction.DefaultCurrency
 	}
@@ -152,11 +164,13 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		return nil, errs.ErrAccountCurrencyInvalid
 	}
 
+	accountCurrency := data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY]
+
 	if ofxTransaction.Amount == "" {
 		return nil, errs.ErrAmountInvalid
 	}
 
-	amount, err := utils.ParseAmount(utils.TrimTrailingZerosInDecimal(strings.ReplaceAll(ofxTransaction.Amount, ",", "."))) // ofx supports decimal point or comma to indicate the start of the fractional amount
+	amount, err := utils.ParseCurrencyAmount(strings.ReplaceAll(ofxTransaction.Amount, ",", "."), accountCurrency) // ofx supports decimal point or comma to indicate the start of the fractional amount
 
 	if err != nil {
 		log.Errorf(ctx, "[ofx_transaction_table.parseTransaction] cannot parsing transaction amount \"%s\", because %s", ofxTransaction.Amount, err.Error())
@@ -167,18 +181,18 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = utils.IntToString(int(transactionType))
 
 		if data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_INCOME] { // income
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(amount, accountCurrency)
 		} else if data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_EXPENSE] { // expense
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(-amount, accountCurrency)
 		} else { // transfer
 			if amount >= 0 { // transfer in
-				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amount)
+				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(amount, accountCurrency)
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME] = data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME]
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY] = data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY]
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = data[datatable.TRANSACTION_DATA_TABLE_AMOUNT]
 				data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = ""
 			} else { // transfer out
-				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(-amount, accountCurrency)
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME] = ofxTransaction.ToAccountId
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY] = data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY]
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = data[datatable.TRANSACTION_DATA_TABLE_AMOUNT]
@@ -187,10 +201,10 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 	} else { // transaction type depends on signage of amount
 		if amount >= 0 { // income
 			data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_INCOME]
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(amount, accountCurrency)
 		} else { // expense
 			data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_EXPENSE]
-			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+			data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(-amount, accountCurrency)
 		}
 	}
 
@@ -198,15 +212,37 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 		if ofxTransaction.FromCreditAccount || ofxTransaction.TransactionType == ofxGenericCreditTransaction {
 			if amount >= 0 { // payment
 				data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_TRANSFER]
-				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(amount)
+				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(amount, accountCurrency)
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_NAME] = data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME]
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_ACCOUNT_CURRENCY] = data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY]
 				data[datatable.TRANSACTION_DATA_TABLE_RELATED_AMOUNT] = data[datatable.TRANSACTION_DATA_TABLE_AMOUNT]
 				data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_NAME] = ""
 			} else { // purchase
 				data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] = ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_EXPENSE]
-				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatAmount(-amount)
+				data[datatable.TRANSACTION_DATA_TABLE_AMOUNT] = utils.FormatCurrencyAmount(-amount, accountCurrency)
+			}
+		}
+	}
+
+	if data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_INCOME] ||
+		data[datatable.TRANSACTION_DATA_TABLE_TRANSACTION_TYPE] == ofxTransactionTypeNameMapping[models.TRANSACTION_TYPE_EXPENSE] {
+		originalCurrency := ""
+		currencyRate := ""
+
+		if ofxTransaction.OriginalCurrency != nil { // the amount is converted from the currency of <CURSYM> in <ORIGCURRENCY> aggregate
+			originalCurrency = t.getCurrencyCode(ofxTransaction.OriginalCurrency)
+			currencyRate = strings.TrimSpace(ofxTransaction.OriginalCurrency.CurrencyRate)
+		}
+
+		if originalCurrency != "" && originalCurrency != data[datatable.TRANSACTION_DATA_TABLE_ACCOUNT_CURRENCY] && currencyRate != "" {
+			originalAmount, err := t.parseOriginalAmount(ctx, data[datatable.TRANSACTION_DATA_TABLE_AMOUNT], accountCurrency, originalCurrency, currencyRate)
+
+			if err != nil {
+				return nil, err
 			}
+
+			data[datatable.TRANSACTION_DATA_TABLE_ORIGINAL_CURRENCY] = originalCurrency
+			data[datatable.TRANSACTION_DATA_TABLE_ORIGINAL_AMOUNT] = utils.FormatCurrencyAmount(originalAmount, originalCurrency)
 		}
 	}
 
@@ -223,6 +259,33 @@ func (t *ofxTransactionDataRowIterator) parseTransaction(ctx core.Context, user
 	return data, nil
 }
 
+// getCurrencyCode returns the currency code of <CURSYM> in the currency aggregate, or the text of the currency element if it is not an aggregate
+func (t *ofxTransactionDataRowIterator) getCurrencyCode(currency *ofxCurrency) string {
+	if currencySymbol := strings.TrimSpace(currency.CurrencySymbol); currencySymbol != "" {
+		return currencySymbol
+	}
+
+	return strings.TrimSpace(currency.Value)
+}
+
+// parseOriginalAmount returns the original amount which the amount is converted from, the currency rate is the ratio of the account currency to the original currency
+func (t *ofxTransactionDataRowIterator) parseOriginalAmount(ctx core.Context, amountValue string, accountCurrency string, originalCurrency string, currencyRate string) (int64, error) {
+	amount, err := utils.ParseCurrencyAmount(amountValue, accountCurrency)
+
+	if err != nil {
+		return 0, errs.ErrAmountInvalid
+	}
+
+	rate, err := utils.StringToFloat64(strings.ReplaceAll(strings.TrimSpace(currencyRate), ",", "."))
+
+	if err != nil || rate <= 0 {
+		log.Errorf(ctx, "[ofx_transaction_table.parseOriginalAmount] cannot parsing currency rate \"%s\"", currencyRate)
+		return 0, errs.ErrAmountInvalid
+	}
+
+	return utils.RoundCurrencyAmount(utils.ExchangeAmountDecimalPlaces(float64(amount)/rate, accountCurrency, originalCurrency), originalCurrency), nil
+}
+
 func (t *ofxTransactionDataRowIterator) parseTransactionTimeAndTimeZone(ctx core.Context, datetime string) (string, string, error) {
 	if len(datetime) < 8 {
 		return "", "", errs.ErrTransactionTimeInvalid
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
@@ -13,6 +14,7 @@ const sgmlTagName = "sgml"
 const sgmlNameFieldName = "SGMLName"
 const xmlTagName = "xml"           // reuse xml tag
 const xmlNameFieldName = "XMLName" // reuse xml tag
+const charDataFieldTag = ",chardata"
 
 // sgmlFieldType represents SGML field type
 type sgmlFieldType byte
@@ -27,7 +29,8 @@ const (
 
 // sgmlTypeInfo represents the struct of SGML type reflection info
 type sgmlTypeInfo struct {
-	supportedFields map[string]*sgmlFieldInfo
+	supportedFields   map[string]*sgmlFieldInfo
+	charDataFieldName string
 }
 
 // sgmlFieldInfo represents the struct of SGML field info
@@ -38,7 +41,8 @@ type sgmlFieldInfo struct {
 }
 
 type Decoder struct {
-	xmlDecoder *xml.Decoder
+	xmlDecoder    *xml.Decoder
+	bufferedToken xml.Token
 }
 
 var sgmlTypeInfoMap sync.Map // map[reflect.Type]*typeInfo
@@ -71,7 +75,7 @@ func (d *Decoder) Decode(v any) error {
 	}
 
 	for {
-		token, err := d.xmlDecoder.RawToken()
+		token, err := d.readToken()
 
 		if err == io.EOF {
 			break
@@ -106,7 +110,7 @@ func (d *Decoder) unmarshal(element reflect.Value, elementName string) error {
 	currentSGMLFieldName := ""
 
 	for {
-		token, err := d.xmlDecoder.RawToken()
+		token, err := d.readToken()
 
 		if err == io.EOF {
 			break
@@ -132,7 +136,15 @@ func (d *Decoder) unmarshal(element reflect.Value, elementName string) error {
 						childElement = reflect.New(childElementType)
 					}
 
-					err := d.unmarshal(childElement.Elem(), fieldInfo.sgmlFieldName)
+					textual, err := d.unmarshalTextualStruct(childElement.Elem())
+
+					if err != nil {
+						return err
+					}
+
+					if !textual {
+						err = d.unmarshal(childElement.Elem(), fieldInfo.sgmlFieldName)
+					}
 
 					if err != nil {
 						return err
@@ -246,6 +258,11 @@ func (d *Decoder) getStructTypeInfo(reflectType reflect.Type) (*sgmlTypeInfo, er
 			sgmlFieldName = field.Tag.Get(xmlTagName)
 		}
 
+		if sgmlFieldName == charDataFieldTag && field.Type.Kind() == reflect.String {
+			newTypeInfo.charDataFieldName = field.Name
+			continue
+		}
+
 		if sgmlFieldName == "" || field.Name == sgmlNameFieldName || field.Name == xmlNameFieldName {
 			continue
 		}
@@ -292,6 +309,50 @@ func (d *Decoder) getStructTypeInfo(reflectType reflect.Type) (*sgmlTypeInfo, er
 	return typeInfo.(*sgmlTypeInfo), nil
 }
 
+// unmarshalTextualStruct fills the character data field of the struct if the element only contains text (e.g. <CURRENCY>USD), returns whether the element is textual
+func (d *Decoder) unmarshalTextualStruct(element reflect.Value) (bool, error) {
+	typeInfo, err := d.getStructTypeInfo(element.Type())
+
+	if err != nil {
+		return false, err
+	}
+
+	if typeInfo == nil || typeInfo.charDataFieldName == "" {
+		return false, nil
+	}
+
+	token, err := d.readToken()
+
+	if err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if charData, ok := token.(xml.CharData); ok {
+		value := d.getTextBeforeLineBreak(string(charData))
+
+		if strings.TrimSpace(value) != "" {
+			element.FieldByName(typeInfo.charDataFieldName).SetString(value)
+			return true, nil
+		}
+	}
+
+	d.bufferedToken = xml.CopyToken(token)
+
+	return false, nil
+}
+
+func (d *Decoder) readToken() (xml.Token, error) {
+	if d.bufferedToken != nil {
+		token := d.bufferedToken
+		d.bufferedToken = nil
+		return token, nil
+	}
+
+	return d.xmlDecoder.RawToken()
+}
+
 func (d *Decoder) getActualFieldValue(fieldName string, fieldValue string, textualFieldWithoutEndElementNames map[string]bool) string {
 	_, notHasEndElement := textualFieldWithoutEndElementNames[fieldName]
 
@@ -299,13 +360,17 @@ func (d *Decoder) getActualFieldValue(fieldName string, fieldValue string, textu
 		return fieldValue
 	}
 
-	for i := 0; i < len(fieldValue); i++ {
-		if fieldValue[i] == '\r' || fieldValue[i] == '\n' {
-			return fieldValue[0:i]
+	return d.getTextBeforeLineBreak(fieldValue)
+}
+
+func (d *Decoder) getTextBeforeLineBreak(value string) string {
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\r' || value[i] == '\n' {
+			return value[0:i]
 		}
 	}
 
-	return fieldValue
+	return value
 }
 
 // NewDecoder creates a new SGML parser reading from specified io reader
@@ -61,6 +61,18 @@ type TestStructWithXMLTag struct {
 	Text4   string                     `xml:"Text4"`
 }
 
+type TestCharDataStruct struct {
+	Value string `sgml:",chardata"`
+	Text1 string `sgml:"Text1"`
+}
+
+type TestStructWithCharDataStruct struct {
+	SGMLName string              `sgml:"Root"`
+	Child1   *TestCharDataStruct `sgml:"Child1"`
+	Child2   *TestCharDataStruct `sgml:"Child2"`
+	Text3    string              `sgml:"Text3"`
+}
+
 type TestNotExportedFieldStruct struct {
 	SGMLName string `sgml:"Root"`
 	Text1    string `sgml:"Text1"`
@@ -192,6 +204,49 @@ func TestDecoderDecode_NestedStructUsingPointer(t *testing.T) {
 	assert.Equal(t, "Bar", testStruct.Text4)
 }
 
+func TestDecoderDecode_CharDataStructWithText(t *testing.T) {
+	sgmlDecoder := NewDecoder(strings.NewReader(
+		"<Root>\n" +
+			"<Child1>Hello\n" +
+			"<Child2>World</Child2>\n" +
+			"<Text3>Foo\n" +
+			"</Root>\n"))
+
+	testStruct := &TestStructWithCharDataStruct{}
+	err := sgmlDecoder.Decode(&testStruct)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, testStruct.Child1)
+	assert.Equal(t, "Hello", testStruct.Child1.Value)
+	assert.Equal(t, "", testStruct.Child1.Text1)
+	assert.NotNil(t, testStruct.Child2)
+	assert.Equal(t, "World", testStruct.Child2.Value)
+	assert.Equal(t, "Foo", testStruct.Text3)
+}
+
+func TestDecoderDecode_CharDataStructWithChildElements(t *testing.T) {
+	sgmlDecoder := NewDecoder(strings.NewReader(
+		"<Root>\n" +
+			"<Child1>\n" +
+			"<Text1>Hello\n" +
+			"</Child1>\n" +
+			"<Child2><Text1>World</Text1></Child2>\n" +
+			"<Text3>Foo\n" +
+			"</Root>\n"))
+
+	testStruct := &TestStructWithCharDataStruct{}
+	err := sgmlDecoder.Decode(&testStruct)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, testStruct.Child1)
+	assert.Equal(t, "", testStruct.Child1.Value)
+	assert.Equal(t, "Hello", testStruct.Child1.Text1)
+	assert.NotNil(t, testStruct.Child2)
+	assert.Equal(t, "", testStruct.Child2.Value)
+	assert.Equal(t, "World", testStruct.Child2.Text1)
+	assert.Equal(t, "Foo", testStruct.Text3)
+}
+
 func TestDecoderDecode_EmbeddedStruct(t *testing.T) {
 	sgmlDecoder := NewDecoder(strings.NewReader(
 		"<Root>\n" +
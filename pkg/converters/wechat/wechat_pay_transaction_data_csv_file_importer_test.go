@@ -326,6 +326,31 @@ func TestWeChatPayCsvFileImporterParseImportedData_ParseDescription(t *testing.T
 	assert.Equal(t, "Test", allNewTransactions[0].Comment)
 }
 
+func TestWeChatPayCsvFileImporterParseImportedData_ParsePayeeName(t *testing.T) {
+	importer := WeChatPayTransactionDataCsvFileImporter
+	context := core.NewNullContext()
+
+	user := &models.User{
+		Uid:             1234567890,
+		DefaultCurrency: "CNY",
+	}
+
+	data := "微信支付账单明细,,,,\n" +
+		"微信昵称：[xxx],,,,\n" +
+		"起始时间：[2024-01-01 00:00:00] 终止时间：[2024-09-01 23:59:59],,,,\n" +
+		",,,,\n" +
+		"----------------------微信支付账单明细列表--------------------,,,,\n" +
+		"交易时间,交易类型,交易对方,收/支,金额(元),当前状态\n" +
+		"2024-09-01 12:34:56,商户消费,Test Store,支出,￥123.45,支付成功\n" +
+		"2024-09-01 23:59:59,零钱充值,/,/,￥0.05,充值完成\n"
+	allNewTransactions, _, _, _, _, _, err := importer.ParseImportedData(context, user, []byte(data), time.UTC, converter.DefaultImporterOptions, nil, nil, nil, nil, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, len(allNewTransactions))
+	assert.Equal(t, "Test Store", allNewTransactions[0].OriginalPayeeName)
+	assert.Equal(t, "", allNewTransactions[1].OriginalPayeeName)
+}
+
 func TestWeChatPayCsvFileImporterParseImportedData_SkipUnknownTransferTransaction(t *testing.T) {
 	importer := WeChatPayTransactionDataCsvFileImporter
 	context := core.NewNullContext()
@@ -16,6 +16,8 @@ const webContextTextualTokenFieldKey = "TOKEN_STRING"
 const webContextTokenClaimsFieldKey = "TOKEN_CLAIMS"
 const webContextTokenContextFieldKey = "TOKEN_CONTEXT"
 const webContextResponseErrorFieldKey = "RESPONSE_ERROR"
+const webContextLedgerOwnerUidFieldKey = "LEDGER_OWNER_UID"
+const webContextLedgerMemberRoleFieldKey = "LEDGER_MEMBER_ROLE"
 
 // AcceptLanguageHeaderName represents the header name of accept language
 const AcceptLanguageHeaderName = "Accept-Language"
@@ -29,6 +31,12 @@ const ClientTimezoneOffsetHeaderName = "X-Timezone-Offset"
 // ClientTimezoneNameHeaderName represents the header name of client timezone name
 const ClientTimezoneNameHeaderName = "X-Timezone-Name"
 
+// LedgerIdHeaderName represents the header name of the shared ledger id which current request operates on
+const LedgerIdHeaderName = "X-Ledger-Id"
+
+// LedgerIdQueryStringParamName represents the query string parameter name of ledger id
+const LedgerIdQueryStringParamName = "ledger_id"
+
 const tokenHeaderName = "Authorization"
 const tokenHeaderValuePrefix = "bearer "
 const tokenQueryStringParam = "token"
@@ -134,8 +142,51 @@ func (c *WebContext) GetTokenContext() string {
 	return context.(string)
 }
 
-// GetCurrentUid returns the current user uid by the current user token
+// SetCurrentLedgerOwnerUid sets the owner uid of the ledger which current request operates on
+func (c *WebContext) SetCurrentLedgerOwnerUid(uid int64) {
+	c.Set(webContextLedgerOwnerUidFieldKey, uid)
+}
+
+// GetCurrentLedgerOwnerUid returns the owner uid of the ledger which current request operates on
+func (c *WebContext) GetCurrentLedgerOwnerUid() int64 {
+	uid, exists := c.Get(webContextLedgerOwnerUidFieldKey)
+
+	if !exists {
+		return 0
+	}
+
+	return uid.(int64)
+}
+
+// SetCurrentLedgerMemberRole sets the role of current user in the ledger which current request operates on
+func (c *WebContext) SetCurrentLedgerMemberRole(role byte) {
+	c.Set(webContextLedgerMemberRoleFieldKey, role)
+}
+
+// GetCurrentLedgerMemberRole returns the role of current user in the ledger which current request operates on, returns 0 if the request does not specify a ledger
+func (c *WebContext) GetCurrentLedgerMemberRole() byte {
+	role, exists := c.Get(webContextLedgerMemberRoleFieldKey)
+
+	if !exists {
+		return 0
+	}
+
+	return role.(byte)
+}
+
+// GetCurrentUid returns the uid of the data owner which current request operates on, it is the owner of the ledger if current request specifies a shared ledger, otherwise it is the current user uid
 func (c *WebContext) GetCurrentUid() int64 {
+	ledgerOwnerUid := c.GetCurrentLedgerOwnerUid()
+
+	if ledgerOwnerUid > 0 {
+		return ledgerOwnerUid
+	}
+
+	return c.GetCurrentOperatorUid()
+}
+
+// GetCurrentOperatorUid returns the current user uid by the current user token
+func (c *WebContext) GetCurrentOperatorUid() int64 {
 	claims := c.GetTokenClaims()
 
 	if claims == nil {
@@ -145,6 +196,21 @@ func (c *WebContext) GetCurrentUid() int64 {
 	return claims.Uid
 }
 
+// GetLedgerId returns the ledger id from the request header or query string, returns 0 if the request does not specify a ledger
+func (c *WebContext) GetLedgerId() (int64, error) {
+	ledgerId := c.GetHeader(LedgerIdHeaderName)
+
+	if ledgerId == "" {
+		ledgerId = c.Query(LedgerIdQueryStringParamName)
+	}
+
+	if ledgerId == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(ledgerId, 10, 64)
+}
+
 // GetTokenStringFromHeader returns the token string from the request header
 func (c *WebContext) GetTokenStringFromHeader() string {
 	tokenHeader := c.GetHeader(tokenHeaderName)
@@ -30,5 +30,8 @@ type DataHandlerFunc func(*WebContext) ([]byte, string, *errs.Error)
 // ImageHandlerFunc represents the handler function that returns image byte array and content type
 type ImageHandlerFunc func(*WebContext) ([]byte, string, *errs.Error)
 
+// AttachmentHandlerFunc represents the handler function that returns file data byte array, content type and file name
+type AttachmentHandlerFunc func(*WebContext) ([]byte, string, string, *errs.Error)
+
 // ProxyHandlerFunc represents the reverse proxy handler function
 type ProxyHandlerFunc func(*WebContext) (*httputil.ReverseProxy, *errs.Error)
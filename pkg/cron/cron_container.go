@@ -7,6 +7,7 @@ import (
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
@@ -84,6 +85,14 @@ func (c *CronJobSchedulerContainer) registerAllJobs(ctx core.Context, config *se
 	if config.EnableCreateScheduledTransaction {
 		Container.registerIntervalJob(ctx, CreateScheduledTransactionJob)
 	}
+
+	if config.EnablePurgeDeletedData {
+		Container.registerIntervalJob(ctx, PurgeDeletedDataJob)
+	}
+
+	if config.EnableSaveExchangeRatesSnapshot && exchangerates.Container.IsExchangeRatesSnapshotSupported() {
+		Container.registerIntervalJob(ctx, SaveExchangeRatesSnapshotJob)
+	}
 }
 
 func (c *CronJobSchedulerContainer) registerIntervalJob(ctx core.Context, job *CronJob) {
@@ -4,7 +4,10 @@ import (
 	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/exchangerates"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
 
 // RemoveExpiredTokensJob represents the cron job which periodically remove expired user tokens from the database
@@ -19,14 +22,74 @@ var RemoveExpiredTokensJob = &CronJob{
 	},
 }
 
-// CreateScheduledTransactionJob represents the cron job which periodically create transaction by scheduled transaction template
+// CreateScheduledTransactionJob represents the cron job which periodically create transaction by scheduled transaction template and loan payment
 var CreateScheduledTransactionJob = &CronJob{
 	Name:        "CreateScheduledTransaction",
-	Description: "Periodically create transaction by scheduled transaction template.",
+	Description: "Periodically create transaction by scheduled transaction template and loan payment.",
 	Period: CronJobEvery15MinutesPeriod{
 		Second: 0,
 	},
 	Run: func(c *core.CronContext) error {
-		return services.Transactions.CreateScheduledTransactions(c, time.Now().Unix(), c.GetInterval())
+		currentUnixTime := time.Now().Unix()
+		err := services.Transactions.CreateScheduledTransactions(c, currentUnixTime, c.GetInterval())
+
+		if err != nil {
+			return err
+		}
+
+		err = services.Transactions.CreatePostponedScheduledTransactions(c, currentUnixTime, c.GetInterval())
+
+		if err != nil {
+			return err
+		}
+
+		return services.Transactions.CreateScheduledLoanPayments(c, currentUnixTime, c.GetInterval())
+	},
+}
+
+// SaveExchangeRatesSnapshotJob represents the cron job which periodically save the latest exchange rates of the current data source as the snapshot of the update date
+var SaveExchangeRatesSnapshotJob = &CronJob{
+	Name:        "SaveExchangeRatesSnapshot",
+	Description: "Periodically save the latest exchange rates of the current data source as the snapshot of the update date.",
+	Period: CronJobIntervalPeriod{
+		Interval: 6 * time.Hour,
+	},
+	Run: func(c *core.CronContext) error {
+		return exchangerates.Container.SaveLatestExchangeRatesSnapshot(c, settings.Container.GetCurrentConfig())
+	},
+}
+
+// PurgeDeletedDataJob represents the cron job which periodically purge the deleted data which exceeds the retention days from the database
+var PurgeDeletedDataJob = &CronJob{
+	Name:        "PurgeDeletedData",
+	Description: "Periodically purge the deleted data which exceeds the retention days from the database.",
+	Period: CronJobFixedHourPeriod{
+		Hour: 0,
+	},
+	Run: func(c *core.CronContext) error {
+		retentionDays := settings.Container.GetCurrentConfig().DeletedDataRetentionDays
+		deletedBeforeUnixTime := time.Now().Unix() - int64(retentionDays)*24*60*60
+
+		purgeFuncs := []func(c core.Context, deletedBeforeUnixTime int64) error{
+			services.Transactions.PurgeAllDeletedTransactions,
+			services.TransactionPictures.PurgeAllDeletedPictures,
+			services.Accounts.PurgeAllDeletedAccounts,
+			services.TransactionCategories.PurgeAllDeletedCategories,
+			services.TransactionTags.PurgeAllDeletedTags,
+			services.TransactionItems.PurgeAllDeletedItems,
+			services.TransactionTemplates.PurgeAllDeletedTemplates,
+		}
+
+		var errors []error
+
+		for i := 0; i < len(purgeFuncs); i++ {
+			err := purgeFuncs[i](c, deletedBeforeUnixTime)
+
+			if err != nil {
+				errors = append(errors, err)
+			}
+		}
+
+		return errs.NewMultiErrorOrNil(errors...)
 	},
 }
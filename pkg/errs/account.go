@@ -26,4 +26,6 @@ var (
 	ErrNotSupportedChangeCurrency             = NewNormalError(NormalSubcategoryAccount, 20, http.StatusBadRequest, "not supported to modify account currency")
 	ErrNotSupportedChangeBalance              = NewNormalError(NormalSubcategoryAccount, 21, http.StatusBadRequest, "not supported to modify account balance")
 	ErrNotSupportedChangeBalanceTime          = NewNormalError(NormalSubcategoryAccount, 22, http.StatusBadRequest, "not supported to modify account balance time")
+	ErrAccountNotCreditCard                   = NewNormalError(NormalSubcategoryAccount, 23, http.StatusBadRequest, "account is not a credit card account")
+	ErrCreditCardStatementDateNotSet          = NewNormalError(NormalSubcategoryAccount, 24, http.StatusBadRequest, "statement date of credit card account is not set")
 )
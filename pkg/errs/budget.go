@@ -0,0 +1,16 @@
+package errs
+
+import "net/http"
+
+// Error codes related to budgets
+var (
+	ErrBudgetIdInvalid               = NewNormalError(NormalSubcategoryBudget, 0, http.StatusBadRequest, "budget id is invalid")
+	ErrBudgetNotFound                = NewNormalError(NormalSubcategoryBudget, 1, http.StatusBadRequest, "budget not found")
+	ErrBudgetTypeInvalid             = NewNormalError(NormalSubcategoryBudget, 2, http.StatusBadRequest, "budget type is invalid")
+	ErrBudgetPeriodTypeInvalid       = NewNormalError(NormalSubcategoryBudget, 3, http.StatusBadRequest, "budget period type is invalid")
+	ErrBudgetCategoryIdInvalid       = NewNormalError(NormalSubcategoryBudget, 4, http.StatusBadRequest, "budget category id is invalid")
+	ErrBudgetAccountIdInvalid        = NewNormalError(NormalSubcategoryBudget, 5, http.StatusBadRequest, "budget account id is invalid")
+	ErrBudgetCategoryTypeInvalid     = NewNormalError(NormalSubcategoryBudget, 6, http.StatusBadRequest, "budget category type is invalid")
+	ErrBudgetCurrencyNotMatchAccount = NewNormalError(NormalSubcategoryBudget, 7, http.StatusBadRequest, "budget currency does not match account currency")
+	ErrBudgetFiscalYearStartInvalid  = NewNormalError(NormalSubcategoryBudget, 8, http.StatusBadRequest, "fiscal year start of budget is invalid")
+)
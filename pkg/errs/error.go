@@ -47,6 +47,17 @@ const (
 	NormalSubcategoryTagGroup               = 19
 	NormalSubcategoryItem                   = 20
 	NormalSubcategoryItemGroup              = 21
+	NormalSubcategoryBudget                 = 22
+	NormalSubcategoryLedger                 = 23
+	NormalSubcategoryInvestment             = 24
+	NormalSubcategoryLoan                   = 25
+	NormalSubcategoryReconciliation         = 26
+	NormalSubcategoryTransactionRule        = 27
+	NormalSubcategoryPayee                  = 28
+	NormalSubcategoryTransactionRevision    = 29
+	NormalSubcategoryTrash                  = 30
+	NormalSubcategorySavedSearch            = 31
+	NormalSubcategoryExchangeRate           = 32
 )
 
 // Error represents the specific error returned to user
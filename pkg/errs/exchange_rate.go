@@ -0,0 +1,12 @@
+package errs
+
+import "net/http"
+
+// Error codes related to exchange rates
+var (
+	ErrExchangeRateSnapshotNotFound         = NewNormalError(NormalSubcategoryExchangeRate, 0, http.StatusBadRequest, "exchange rate snapshot not found")
+	ErrHistoricalExchangeRatesNotSupported  = NewNormalError(NormalSubcategoryExchangeRate, 1, http.StatusBadRequest, "current exchange rates data source does not support historical exchange rates")
+	ErrExchangeRateDateInvalid              = NewNormalError(NormalSubcategoryExchangeRate, 2, http.StatusBadRequest, "exchange rate date is invalid")
+	ErrExchangeRateSnapshotDateRangeInvalid = NewNormalError(NormalSubcategoryExchangeRate, 3, http.StatusBadRequest, "exchange rate snapshot date range is invalid")
+	ErrTransactionAmountCannotBeExchanged   = NewNormalError(NormalSubcategoryExchangeRate, 4, http.StatusBadRequest, "transaction amount cannot be exchanged by historical exchange rates")
+)
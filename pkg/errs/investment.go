@@ -0,0 +1,24 @@
+package errs
+
+import "net/http"
+
+// Error codes related to investments
+var (
+	ErrSecurityIdInvalid                         = NewNormalError(NormalSubcategoryInvestment, 0, http.StatusBadRequest, "security id is invalid")
+	ErrSecurityNotFound                          = NewNormalError(NormalSubcategoryInvestment, 1, http.StatusBadRequest, "security not found")
+	ErrSecurityTickerAlreadyExists               = NewNormalError(NormalSubcategoryInvestment, 2, http.StatusBadRequest, "security ticker already exists")
+	ErrSecurityCostBasisMethodInvalid            = NewNormalError(NormalSubcategoryInvestment, 3, http.StatusBadRequest, "security cost basis method is invalid")
+	ErrSecurityInUseCannotBeDeleted              = NewNormalError(NormalSubcategoryInvestment, 4, http.StatusBadRequest, "security is in use and cannot be deleted")
+	ErrSecurityPriceIdInvalid                    = NewNormalError(NormalSubcategoryInvestment, 5, http.StatusBadRequest, "security price id is invalid")
+	ErrSecurityPriceNotFound                     = NewNormalError(NormalSubcategoryInvestment, 6, http.StatusBadRequest, "security price not found")
+	ErrSecurityPriceInvalid                      = NewNormalError(NormalSubcategoryInvestment, 7, http.StatusBadRequest, "security price is invalid")
+	ErrSecurityPricesDataSourceNotEnabled        = NewNormalError(NormalSubcategoryInvestment, 8, http.StatusBadRequest, "security prices data source is not enabled")
+	ErrInvestmentTransactionIdInvalid            = NewNormalError(NormalSubcategoryInvestment, 9, http.StatusBadRequest, "investment transaction id is invalid")
+	ErrInvestmentTransactionNotFound             = NewNormalError(NormalSubcategoryInvestment, 10, http.StatusBadRequest, "investment transaction not found")
+	ErrInvestmentTransactionTypeInvalid          = NewNormalError(NormalSubcategoryInvestment, 11, http.StatusBadRequest, "investment transaction type is invalid")
+	ErrInvestmentTransactionQuantityInvalid      = NewNormalError(NormalSubcategoryInvestment, 12, http.StatusBadRequest, "investment transaction quantity is invalid")
+	ErrInvestmentTransactionAmountInvalid        = NewNormalError(NormalSubcategoryInvestment, 13, http.StatusBadRequest, "investment transaction amount is invalid")
+	ErrInvestmentSellQuantityExceedsHolding      = NewNormalError(NormalSubcategoryInvestment, 14, http.StatusBadRequest, "sell quantity exceeds the holding quantity")
+	ErrInvestmentAccountInvalid                  = NewNormalError(NormalSubcategoryInvestment, 15, http.StatusBadRequest, "account is not an investment account")
+	ErrInvestmentAccountCurrencyNotMatchSecurity = NewNormalError(NormalSubcategoryInvestment, 16, http.StatusBadRequest, "account currency does not match security currency")
+)
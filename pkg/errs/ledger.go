@@ -0,0 +1,18 @@
+package errs
+
+import "net/http"
+
+// Error codes related to ledgers
+var (
+	ErrLedgerIdInvalid                   = NewNormalError(NormalSubcategoryLedger, 0, http.StatusBadRequest, "ledger id is invalid")
+	ErrLedgerNotFound                    = NewNormalError(NormalSubcategoryLedger, 1, http.StatusBadRequest, "ledger not found")
+	ErrLedgerAlreadyExists               = NewNormalError(NormalSubcategoryLedger, 2, http.StatusBadRequest, "ledger already exists")
+	ErrLedgerMemberIdInvalid             = NewNormalError(NormalSubcategoryLedger, 3, http.StatusBadRequest, "ledger member id is invalid")
+	ErrLedgerMemberNotFound              = NewNormalError(NormalSubcategoryLedger, 4, http.StatusBadRequest, "ledger member not found")
+	ErrLedgerMemberRoleInvalid           = NewNormalError(NormalSubcategoryLedger, 5, http.StatusBadRequest, "ledger member role is invalid")
+	ErrLedgerMemberAlreadyExists         = NewNormalError(NormalSubcategoryLedger, 6, http.StatusBadRequest, "user is already a member of the ledger")
+	ErrCannotInviteLedgerOwner           = NewNormalError(NormalSubcategoryLedger, 7, http.StatusBadRequest, "cannot invite the owner of the ledger")
+	ErrLedgerInvitationAlreadyAccepted   = NewNormalError(NormalSubcategoryLedger, 8, http.StatusBadRequest, "ledger invitation has already been accepted")
+	ErrLedgerPermissionDenied            = NewNormalError(NormalSubcategoryLedger, 9, http.StatusForbidden, "no permission to operate the ledger")
+	ErrLedgerMemberRoleNotLowerThanYours = NewNormalError(NormalSubcategoryLedger, 10, http.StatusForbidden, "cannot manage member whose role is not lower than yours")
+)
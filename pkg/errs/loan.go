@@ -0,0 +1,16 @@
+package errs
+
+import "net/http"
+
+// Error codes related to loans
+var (
+	ErrLoanIdInvalid                   = NewNormalError(NormalSubcategoryLoan, 0, http.StatusBadRequest, "loan id is invalid")
+	ErrLoanNotFound                    = NewNormalError(NormalSubcategoryLoan, 1, http.StatusBadRequest, "loan not found")
+	ErrLoanAccountInvalid              = NewNormalError(NormalSubcategoryLoan, 2, http.StatusBadRequest, "loan account must be a debt account")
+	ErrLoanPaymentAccountInvalid       = NewNormalError(NormalSubcategoryLoan, 3, http.StatusBadRequest, "loan payment account is invalid")
+	ErrLoanAccountAlreadyHasLoan       = NewNormalError(NormalSubcategoryLoan, 4, http.StatusBadRequest, "account already has a loan")
+	ErrLoanPrincipalCategoryInvalid    = NewNormalError(NormalSubcategoryLoan, 5, http.StatusBadRequest, "loan principal category must be a transfer category")
+	ErrLoanInterestCategoryInvalid     = NewNormalError(NormalSubcategoryLoan, 6, http.StatusBadRequest, "loan interest category must be an expense category")
+	ErrLoanCurrencyNotMatch            = NewNormalError(NormalSubcategoryLoan, 7, http.StatusBadRequest, "currency of loan account and payment account does not match")
+	ErrLoanPaidPeriodsExceedTermMonths = NewNormalError(NormalSubcategoryLoan, 8, http.StatusBadRequest, "loan paid periods exceed term months")
+)
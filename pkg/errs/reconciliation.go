@@ -0,0 +1,12 @@
+package errs
+
+import "net/http"
+
+// Error codes related to reconciliation sessions
+var (
+	ErrReconciliationSessionIdInvalid         = NewNormalError(NormalSubcategoryReconciliation, 0, http.StatusBadRequest, "reconciliation session id is invalid")
+	ErrReconciliationSessionNotFound          = NewNormalError(NormalSubcategoryReconciliation, 1, http.StatusBadRequest, "reconciliation session not found")
+	ErrReconciliationSessionAlreadyInProgress = NewNormalError(NormalSubcategoryReconciliation, 2, http.StatusBadRequest, "account already has a reconciliation session in progress")
+	ErrReconciliationSessionAlreadyCompleted  = NewNormalError(NormalSubcategoryReconciliation, 3, http.StatusBadRequest, "reconciliation session has already been completed")
+	ErrReconciliationBalanceNotMatch          = NewNormalError(NormalSubcategoryReconciliation, 4, http.StatusBadRequest, "cleared balance does not match statement ending balance")
+)
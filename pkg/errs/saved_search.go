@@ -0,0 +1,11 @@
+package errs
+
+import "net/http"
+
+// Error codes related to saved searches
+var (
+	ErrSavedSearchIdInvalid         = NewNormalError(NormalSubcategorySavedSearch, 0, http.StatusBadRequest, "saved search id is invalid")
+	ErrSavedSearchNotFound          = NewNormalError(NormalSubcategorySavedSearch, 1, http.StatusBadRequest, "saved search not found")
+	ErrSavedSearchNameIsEmpty       = NewNormalError(NormalSubcategorySavedSearch, 2, http.StatusBadRequest, "saved search name is empty")
+	ErrSavedSearchNameAlreadyExists = NewNormalError(NormalSubcategorySavedSearch, 3, http.StatusBadRequest, "saved search name already exists")
+)
@@ -30,4 +30,8 @@ var (
 	ErrInvalidOAuth2UserIdentifier                    = NewSystemError(SystemSubcategorySetting, 23, http.StatusInternalServerError, "invalid oauth 2.0 user identifier")
 	ErrInvalidOAuth2Provider                          = NewSystemError(SystemSubcategorySetting, 24, http.StatusInternalServerError, "invalid oauth 2.0 provider")
 	ErrInvalidOAuth2StateExpiredTime                  = NewSystemError(SystemSubcategorySetting, 25, http.StatusInternalServerError, "invalid oauth 2.0 state expired time")
+	ErrInvalidSecurityPricesDataSource                = NewSystemError(SystemSubcategorySetting, 26, http.StatusInternalServerError, "invalid security prices data source")
+	ErrInvalidExchangeRatesFallbackDataSource         = NewSystemError(SystemSubcategorySetting, 27, http.StatusInternalServerError, "invalid exchange rates fallback data source")
+	ErrInvalidExchangeRatesDivergenceWarningThreshold = NewSystemError(SystemSubcategorySetting, 28, http.StatusInternalServerError, "invalid exchange rates divergence warning threshold")
+	ErrMissingExchangeRatesDataSourceApiKey           = NewSystemError(SystemSubcategorySetting, 29, http.StatusInternalServerError, "missing exchange rates data source api key")
 )
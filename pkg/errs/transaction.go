@@ -46,4 +46,26 @@ var (
 	ErrCannotMoveTransactionFromOrToHiddenAccount                  = NewNormalError(NormalSubcategoryTransaction, 38, http.StatusBadRequest, "cannot move transaction from or to hidden account")
 	ErrCannotMoveTransactionFromOrToParentAccount                  = NewNormalError(NormalSubcategoryTransaction, 39, http.StatusBadRequest, "cannot move transaction from or to parent account")
 	ErrCannotMoveTransactionBetweenAccountsWithDifferentCurrencies = NewNormalError(NormalSubcategoryTransaction, 40, http.StatusBadRequest, "cannot move transaction between accounts with different currencies")
+	ErrTransactionHasTooManySplits                                 = NewNormalError(NormalSubcategoryTransaction, 41, http.StatusBadRequest, "transaction has too many splits")
+	ErrTransactionSplitsTooFew                                     = NewNormalError(NormalSubcategoryTransaction, 42, http.StatusBadRequest, "transaction must have at least two splits")
+	ErrTransactionSplitAmountsNotEqualToTotalAmount                = NewNormalError(NormalSubcategoryTransaction, 43, http.StatusBadRequest, "sum of transaction split amounts is not equal to transaction amount")
+	ErrTransactionCannotBeSplit                                    = NewNormalError(NormalSubcategoryTransaction, 44, http.StatusBadRequest, "only income or expense transaction can be split")
+	ErrTransactionSplitCategoryInvalid                             = NewNormalError(NormalSubcategoryTransaction, 45, http.StatusBadRequest, "transaction split category is invalid")
+	ErrTransactionSplitTagIdInvalid                                = NewNormalError(NormalSubcategoryTransaction, 46, http.StatusBadRequest, "transaction split tag id is invalid")
+	ErrTransactionSplitHasTooManyTags                              = NewNormalError(NormalSubcategoryTransaction, 47, http.StatusBadRequest, "transaction split has too many tags")
+	ErrTransactionItemDetailNotMatchItem                           = NewNormalError(NormalSubcategoryTransaction, 48, http.StatusBadRequest, "transaction item detail does not match any item of transaction")
+	ErrTransactionHasDuplicateItemDetails                          = NewNormalError(NormalSubcategoryTransaction, 49, http.StatusBadRequest, "transaction has duplicate item details")
+	ErrCannotModifyReconciledTransaction                           = NewNormalError(NormalSubcategoryTransaction, 50, http.StatusBadRequest, "cannot modify reconciled transaction")
+	ErrCannotDeleteReconciledTransaction                           = NewNormalError(NormalSubcategoryTransaction, 51, http.StatusBadRequest, "cannot delete reconciled transaction")
+	ErrTransactionTimeInLockedPeriod                               = NewNormalError(NormalSubcategoryTransaction, 52, http.StatusBadRequest, "transaction time is in the locked period")
+	ErrCannotMoveTransactionLockTimeBackward                       = NewNormalError(NormalSubcategoryTransaction, 53, http.StatusBadRequest, "cannot move transaction lock time backward")
+	ErrTransactionBulkEditNoChanges                                = NewNormalError(NormalSubcategoryTransaction, 54, http.StatusBadRequest, "no changes specified for bulk edit")
+	ErrTransactionBulkEditFilterIsEmpty                            = NewNormalError(NormalSubcategoryTransaction, 55, http.StatusBadRequest, "transaction filter of bulk edit cannot be empty")
+	ErrTooManyTransactionsToBulkEdit                               = NewNormalError(NormalSubcategoryTransaction, 56, http.StatusBadRequest, "too many transactions to bulk edit")
+	ErrCannotDeleteAndModifyTransactionsInBulkEdit                 = NewNormalError(NormalSubcategoryTransaction, 57, http.StatusBadRequest, "cannot delete and modify transactions in one bulk edit")
+	ErrTransactionSearchQueryInvalid                               = NewNormalError(NormalSubcategoryTransaction, 58, http.StatusBadRequest, "transaction search query is invalid")
+	ErrTransactionSearchQueryConditionNotSupported                 = NewNormalError(NormalSubcategoryTransaction, 59, http.StatusBadRequest, "transaction search query contains condition not supported here")
+	ErrTransactionOriginalAmountCannotBeSet                        = NewNormalError(NormalSubcategoryTransaction, 60, http.StatusBadRequest, "original amount cannot be set for this transaction type")
+	ErrTransactionOriginalCurrencyIsEmpty                          = NewNormalError(NormalSubcategoryTransaction, 61, http.StatusBadRequest, "original currency cannot be empty when original amount is set")
+	ErrTransactionOriginalCurrencyInvalid                          = NewNormalError(NormalSubcategoryTransaction, 62, http.StatusBadRequest, "original currency is invalid")
 )
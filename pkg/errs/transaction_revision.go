@@ -0,0 +1,10 @@
+package errs
+
+import "net/http"
+
+// Error codes related to transaction revisions
+var (
+	ErrTransactionRevisionIdInvalid       = NewNormalError(NormalSubcategoryTransactionRevision, 0, http.StatusBadRequest, "transaction revision id is invalid")
+	ErrTransactionRevisionNotFound        = NewNormalError(NormalSubcategoryTransactionRevision, 1, http.StatusBadRequest, "transaction revision not found")
+	ErrTransactionRevisionSnapshotInvalid = NewNormalError(NormalSubcategoryTransactionRevision, 2, http.StatusBadRequest, "transaction revision snapshot is invalid")
+)
@@ -0,0 +1,18 @@
+package errs
+
+import "net/http"
+
+// Error codes related to transaction rules
+var (
+	ErrTransactionRuleIdInvalid               = NewNormalError(NormalSubcategoryTransactionRule, 0, http.StatusBadRequest, "transaction rule id is invalid")
+	ErrTransactionRuleNotFound                = NewNormalError(NormalSubcategoryTransactionRule, 1, http.StatusBadRequest, "transaction rule not found")
+	ErrTransactionRuleConditionsEmpty         = NewNormalError(NormalSubcategoryTransactionRule, 2, http.StatusBadRequest, "transaction rule must have at least one condition")
+	ErrTransactionRuleActionsEmpty            = NewNormalError(NormalSubcategoryTransactionRule, 3, http.StatusBadRequest, "transaction rule must have at least one action")
+	ErrTransactionRuleCommentPatternInvalid   = NewNormalError(NormalSubcategoryTransactionRule, 4, http.StatusBadRequest, "transaction rule comment pattern is invalid")
+	ErrTransactionRuleAmountRangeInvalid      = NewNormalError(NormalSubcategoryTransactionRule, 5, http.StatusBadRequest, "transaction rule amount range is invalid")
+	ErrTransactionRuleTransactionTypeRequired = NewNormalError(NormalSubcategoryTransactionRule, 6, http.StatusBadRequest, "transaction rule must specify transaction type for this action")
+	ErrTransactionRuleTransactionTypeInvalid  = NewNormalError(NormalSubcategoryTransactionRule, 7, http.StatusBadRequest, "transaction rule transaction type is invalid")
+	ErrTransactionRuleHasTooManyTags          = NewNormalError(NormalSubcategoryTransactionRule, 8, http.StatusBadRequest, "transaction rule has too many tags")
+	ErrTransactionRuleHasTooManyItems         = NewNormalError(NormalSubcategoryTransactionRule, 9, http.StatusBadRequest, "transaction rule has too many items")
+	ErrTransactionRuleRunTooManyTransactions  = NewNormalError(NormalSubcategoryTransactionRule, 10, http.StatusBadRequest, "too many transactions to run transaction rules")
+)
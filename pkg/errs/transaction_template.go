@@ -11,4 +11,11 @@ var (
 	ErrScheduledTransactionFrequencyInvalid                  = NewNormalError(NormalSubcategoryTemplate, 4, http.StatusBadRequest, "scheduled transaction frequency is invalid")
 	ErrTransactionTemplateHasTooManyTags                     = NewNormalError(NormalSubcategoryTemplate, 5, http.StatusBadRequest, "transaction template has too many tags")
 	ErrScheduledTransactionTemplateStartDataLaterThanEndDate = NewNormalError(NormalSubcategoryTemplate, 6, http.StatusBadRequest, "scheduled transaction start date is later than end time")
+	ErrTransactionTemplateOccurrenceIdInvalid                = NewNormalError(NormalSubcategoryTemplate, 7, http.StatusBadRequest, "transaction template occurrence id is invalid")
+	ErrTransactionTemplateOccurrenceNotFound                 = NewNormalError(NormalSubcategoryTemplate, 8, http.StatusBadRequest, "transaction template occurrence not found")
+	ErrTransactionTemplateOccurrenceNotPending               = NewNormalError(NormalSubcategoryTemplate, 9, http.StatusBadRequest, "transaction template occurrence is not pending")
+	ErrTransactionTemplateOccurrenceAlreadyExists            = NewNormalError(NormalSubcategoryTemplate, 10, http.StatusBadRequest, "transaction template occurrence has already been processed")
+	ErrTransactionTemplateOccurrenceTimeInvalid              = NewNormalError(NormalSubcategoryTemplate, 11, http.StatusBadRequest, "transaction template occurrence time is invalid")
+	ErrTransactionTemplateOccurrencePostponedTimeInvalid     = NewNormalError(NormalSubcategoryTemplate, 12, http.StatusBadRequest, "transaction template occurrence postponed time is invalid")
+	ErrTransactionTemplateOccurrenceCannotBeDeleted          = NewNormalError(NormalSubcategoryTemplate, 13, http.StatusBadRequest, "transaction template occurrence cannot be deleted")
 )
@@ -0,0 +1,11 @@
+package errs
+
+import "net/http"
+
+// Error codes related to recycle bin
+var (
+	ErrTrashItemTypeInvalid       = NewNormalError(NormalSubcategoryTrash, 0, http.StatusBadRequest, "trash item type is invalid")
+	ErrTrashItemIdInvalid         = NewNormalError(NormalSubcategoryTrash, 1, http.StatusBadRequest, "trash item id is invalid")
+	ErrTrashItemNotFound          = NewNormalError(NormalSubcategoryTrash, 2, http.StatusBadRequest, "trash item not found")
+	ErrTrashItemParentNotRestored = NewNormalError(NormalSubcategoryTrash, 3, http.StatusBadRequest, "parent of trash item has not been restored")
+)
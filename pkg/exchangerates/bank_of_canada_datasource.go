@@ -2,6 +2,7 @@ package exchangerates
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"net/http"
 	"strings"
@@ -16,12 +17,14 @@ import (
 )
 
 const bankOfCanadaExchangeRateUrl = "https://www.bankofcanada.ca/valet/observations/group/FX_RATES_DAILY/json?recent=1"
+const bankOfCanadaHistoricalExchangeRateUrlFormat = "https://www.bankofcanada.ca/valet/observations/group/FX_RATES_DAILY/json?start_date=%s&end_date=%s"
 const bankOfCanadaExchangeRateReferenceUrl = "https://www.bankofcanada.ca/rates/exchange/daily-exchange-rates/"
 const bankOfCanadaDataSource = "Bank of Canada"
 const bankOfCanadaBaseCurrency = "CAD"
 
 const bankOfCanadaDataUpdateDateFormat = "2006-01-02 15:04"
 const bankOfCanadaDataUpdateDateTimezone = "America/Toronto"
+const bankOfCanadaHistoricalRequestDateFormat = "2006-01-02"
 
 // BankOfCanadaDataSource defines the structure of exchange rates data source of bank of Canada
 type BankOfCanadaDataSource struct {
@@ -130,6 +133,28 @@ func (e *BankOfCanadaExchangeRateData) ToLatestExchangeRateResponse(c core.Conte
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRateResponses returns the view-objects of each day according to original data from bank of Canada
+func (e *BankOfCanadaExchangeRateData) ToHistoricalExchangeRateResponses(c core.Context) []*models.LatestExchangeRateResponse {
+	historicalExchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(e.Observations))
+
+	for i := 0; i < len(e.Observations); i++ {
+		dailyExchangeRateData := &BankOfCanadaExchangeRateData{
+			Observations: []BankOfCanadaObservationData{e.Observations[i]},
+		}
+
+		dailyExchangeRateResp := dailyExchangeRateData.ToLatestExchangeRateResponse(c)
+
+		if dailyExchangeRateResp == nil {
+			log.Warnf(c, "[bank_of_canada_datasource.ToHistoricalExchangeRateResponses] skip exchange rates of observation#%d", i)
+			continue
+		}
+
+		historicalExchangeRateResps = append(historicalExchangeRateResps, dailyExchangeRateResp)
+	}
+
+	return historicalExchangeRateResps
+}
+
 // BuildRequests returns the bank of Canada exchange rates http requests
 func (e *BankOfCanadaDataSource) BuildRequests() ([]*http.Request, error) {
 	req, err := http.NewRequest("GET", bankOfCanadaExchangeRateUrl, nil)
@@ -160,3 +185,35 @@ func (e *BankOfCanadaDataSource) Parse(c core.Context, content []byte) (*models.
 
 	return latestExchangeRateResponse, nil
 }
+
+// BuildHistoricalRequests returns the bank of Canada historical exchange rates http requests
+func (e *BankOfCanadaDataSource) BuildHistoricalRequests(startTime time.Time, endTime time.Time) ([]*http.Request, error) {
+	url := fmt.Sprintf(bankOfCanadaHistoricalExchangeRateUrlFormat, startTime.Format(bankOfCanadaHistoricalRequestDateFormat), endTime.Format(bankOfCanadaHistoricalRequestDateFormat))
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// ParseHistorical returns the common response entities of each day according to the bank of Canada data source raw response
+func (e *BankOfCanadaDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error) {
+	bankOfCanadaData := &BankOfCanadaExchangeRateData{}
+	err := json.Unmarshal(content, bankOfCanadaData)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_canada_datasource.ParseHistorical] failed to parse json data, because %s", err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	historicalExchangeRateResps := bankOfCanadaData.ToHistoricalExchangeRateResponses(c)
+
+	if len(historicalExchangeRateResps) < 1 {
+		log.Errorf(c, "[bank_of_canada_datasource.ParseHistorical] failed to parse historical exchange rate data")
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return historicalExchangeRateResps, nil
+}
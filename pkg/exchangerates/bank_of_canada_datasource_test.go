@@ -195,3 +195,31 @@ func TestBankOfCanadaDataSource_InvalidRate(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
 }
+
+func TestBankOfCanadaDataSource_HistoricalDataExtractEachDay(t *testing.T) {
+	dataSource := &BankOfCanadaDataSource{}
+	context := core.NewNullContext()
+
+	actualHistoricalExchangeRateResponses, err := dataSource.ParseHistorical(context, []byte(bankOfCanadaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(actualHistoricalExchangeRateResponses))
+
+	assert.Equal(t, int64(1577827800), actualHistoricalExchangeRateResponses[0].UpdateTime)
+	assert.Equal(t, 1, len(actualHistoricalExchangeRateResponses[0].ExchangeRates))
+	assert.Equal(t, "VND", actualHistoricalExchangeRateResponses[0].ExchangeRates[0].Currency)
+
+	assert.Equal(t, int64(1617309000), actualHistoricalExchangeRateResponses[1].UpdateTime)
+	assert.Equal(t, 2, len(actualHistoricalExchangeRateResponses[1].ExchangeRates))
+	assert.Contains(t, actualHistoricalExchangeRateResponses[1].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.7958615200955034",
+	})
+}
+
+func TestBankOfCanadaDataSource_HistoricalEmptyObservationsContent(t *testing.T) {
+	dataSource := &BankOfCanadaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.ParseHistorical(context, []byte("{\"observations\": []}"))
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,238 @@
+package exchangerates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const bankOfEnglandExchangeRateUrlFormat = "https://www.bankofengland.co.uk/boeapps/database/_iadb-fromshowcolumns.asp?csv.x=yes&Datefrom=%s&Dateto=now&SeriesCodes=%s&CSVF=TN&UsingCodes=Y&VPD=Y&VFD=N"
+const bankOfEnglandExchangeRateReferenceUrl = "https://www.bankofengland.co.uk/boeapps/database/Rates.asp?Travel=NIxAZx&into=GBP"
+const bankOfEnglandDataSource = "Bank of England"
+const bankOfEnglandBaseCurrency = "GBP"
+
+const bankOfEnglandRequestDateFormat = "02/Jan/2006"
+const bankOfEnglandRequestRecentDays = 10
+const bankOfEnglandUpdateDateFormat = "02 Jan 2006 15:04"
+const bankOfEnglandUpdateDateTimezone = "Europe/London"
+const bankOfEnglandDateColumnName = "DATE"
+
+// bankOfEnglandSeriesCodeCurrencies represents the series codes of the daily spot exchange rates against sterling in the bank of England database
+var bankOfEnglandSeriesCodeCurrencies = map[string]string{
+	"XUDLADS":  "AUD",
+	"XUDLBK89": "CNY",
+	"XUDLCDS":  "CAD",
+	"XUDLDKS":  "DKK",
+	"XUDLERS":  "EUR",
+	"XUDLHDS":  "HKD",
+	"XUDLJYS":  "JPY",
+	"XUDLNDS":  "NZD",
+	"XUDLNKS":  "NOK",
+	"XUDLSFS":  "CHF",
+	"XUDLSGS":  "SGD",
+	"XUDLSKS":  "SEK",
+	"XUDLSRS":  "SAR",
+	"XUDLUSS":  "USD",
+	"XUDLZRS":  "ZAR",
+}
+
+// BankOfEnglandDataSource defines the structure of exchange rates data source of bank of England
+type BankOfEnglandDataSource struct {
+	HttpExchangeRatesDataSource
+}
+
+// BankOfEnglandExchangeRateData represents the whole data from bank of England
+type BankOfEnglandExchangeRateData struct {
+	ExchangeRates []*BankOfEnglandExchangeRate
+}
+
+// BankOfEnglandExchangeRate represents the exchange rate data from bank of England
+type BankOfEnglandExchangeRate struct {
+	Currency string
+	Rate     string
+	Date     string
+}
+
+// ToLatestExchangeRateResponse returns a view-object according to original data from bank of England
+func (e *BankOfEnglandExchangeRateData) ToLatestExchangeRateResponse(c core.Context) *models.LatestExchangeRateResponse {
+	if len(e.ExchangeRates) < 1 {
+		log.Errorf(c, "[bank_of_england_datasource.ToLatestExchangeRateResponse] all exchange rates is empty")
+		return nil
+	}
+
+	timezone, err := time.LoadLocation(bankOfEnglandUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_england_datasource.ToLatestExchangeRateResponse] failed to get timezone, timezone name is %s", bankOfEnglandUpdateDateTimezone)
+		return nil
+	}
+
+	latestExchangeRates := make(map[string]*models.LatestExchangeRate, len(e.ExchangeRates))
+	latestExchangeRateTimes := make(map[string]int64, len(e.ExchangeRates))
+	latestUpdateTime := int64(0)
+
+	for i := 0; i < len(e.ExchangeRates); i++ {
+		exchangeRate := e.ExchangeRates[i]
+
+		if _, exists := validators.AllCurrencyNames[exchangeRate.Currency]; !exists {
+			continue
+		}
+
+		updateDateTime := exchangeRate.Date + " 16:00" // the spot exchange rates are observed by the bank of England at 16:00 London time
+		updateTime, err := time.ParseInLocation(bankOfEnglandUpdateDateFormat, updateDateTime, timezone)
+
+		if err != nil {
+			log.Errorf(c, "[bank_of_england_datasource.ToLatestExchangeRateResponse] failed to parse update date, datetime is %s", updateDateTime)
+			return nil
+		}
+
+		if existedUpdateTime, exists := latestExchangeRateTimes[exchangeRate.Currency]; exists && existedUpdateTime >= updateTime.Unix() {
+			continue
+		}
+
+		finalExchangeRate := exchangeRate.ToLatestExchangeRate(c)
+
+		if finalExchangeRate == nil {
+			continue
+		}
+
+		latestExchangeRates[exchangeRate.Currency] = finalExchangeRate
+		latestExchangeRateTimes[exchangeRate.Currency] = updateTime.Unix()
+
+		if updateTime.Unix() > latestUpdateTime {
+			latestUpdateTime = updateTime.Unix()
+		}
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(latestExchangeRates))
+
+	for _, exchangeRate := range latestExchangeRates {
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+
+	sort.Sort(exchangeRates)
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    bankOfEnglandDataSource,
+		ReferenceUrl:  bankOfEnglandExchangeRateReferenceUrl,
+		UpdateTime:    latestUpdateTime,
+		BaseCurrency:  bankOfEnglandBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp
+}
+
+// ToLatestExchangeRate returns a data pair according to original data from bank of England
+func (e *BankOfEnglandExchangeRate) ToLatestExchangeRate(c core.Context) *models.LatestExchangeRate {
+	rate, err := utils.StringToFloat64(e.Rate)
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_england_datasource.ToLatestExchangeRate] failed to parse rate, currency is %s, rate is %s", e.Currency, e.Rate)
+		return nil
+	}
+
+	if rate <= 0 || math.IsInf(rate, 0) {
+		log.Warnf(c, "[bank_of_england_datasource.ToLatestExchangeRate] rate is invalid, currency is %s, rate is %s", e.Currency, e.Rate)
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: e.Currency,
+		Rate:     utils.Float64ToString(rate),
+	}
+}
+
+// BuildRequests returns the bank of England exchange rates http requests
+func (e *BankOfEnglandDataSource) BuildRequests() ([]*http.Request, error) {
+	seriesCodes := make([]string, 0, len(bankOfEnglandSeriesCodeCurrencies))
+
+	for seriesCode := range bankOfEnglandSeriesCodeCurrencies {
+		seriesCodes = append(seriesCodes, seriesCode)
+	}
+
+	sort.Strings(seriesCodes)
+
+	startDate := time.Now().AddDate(0, 0, -bankOfEnglandRequestRecentDays).Format(bankOfEnglandRequestDateFormat)
+	req, err := http.NewRequest("GET", fmt.Sprintf(bankOfEnglandExchangeRateUrlFormat, startDate, strings.Join(seriesCodes, ",")), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the bank of England data source raw response
+func (e *BankOfEnglandDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	csvReader := csv.NewReader(bytes.NewReader(content))
+	csvReader.FieldsPerRecord = -1
+	allLines, err := csvReader.ReadAll()
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] failed to parse csv data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	if len(allLines) < 2 {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] there is no exchange rates data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	headerLine := allLines[0]
+
+	if len(headerLine) < 2 || strings.TrimSpace(headerLine[0]) != bankOfEnglandDateColumnName {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] the header line is invalid, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	bankOfEnglandData := &BankOfEnglandExchangeRateData{
+		ExchangeRates: make([]*BankOfEnglandExchangeRate, 0, (len(allLines)-1)*(len(headerLine)-1)),
+	}
+
+	for i := 1; i < len(allLines); i++ {
+		line := allLines[i]
+
+		if len(line) < 1 || strings.TrimSpace(line[0]) == "" {
+			continue
+		}
+
+		date := strings.TrimSpace(line[0])
+
+		for j := 1; j < len(line) && j < len(headerLine); j++ {
+			currency, exists := bankOfEnglandSeriesCodeCurrencies[strings.TrimSpace(headerLine[j])]
+			rate := strings.TrimSpace(line[j])
+
+			if !exists || rate == "" {
+				continue
+			}
+
+			bankOfEnglandData.ExchangeRates = append(bankOfEnglandData.ExchangeRates, &BankOfEnglandExchangeRate{
+				Currency: currency,
+				Rate:     rate,
+				Date:     date,
+			})
+		}
+	}
+
+	latestExchangeRateResponse := bankOfEnglandData.ToLatestExchangeRateResponse(c)
+
+	if latestExchangeRateResponse == nil {
+		log.Errorf(c, "[bank_of_england_datasource.Parse] failed to parse latest exchange rate data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return latestExchangeRateResponse, nil
+}
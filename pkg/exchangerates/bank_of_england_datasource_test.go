@@ -0,0 +1,107 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const bankOfEnglandMinimumRequiredContent = "DATE,XUDLUSS,XUDLJYS\n" +
+	"14 Nov 2024,1.2665,196.7\n" +
+	"15 Nov 2024,1.2623,\n"
+
+func TestBankOfEnglandDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfEnglandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "GBP", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestBankOfEnglandDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfEnglandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1731686400), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestBankOfEnglandDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfEnglandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 2)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.2623",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "196.7",
+	})
+}
+
+func TestBankOfEnglandDataSource_BlankContent(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_OnlyHeaderLine(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("DATE,XUDLUSS,XUDLJYS\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_InvalidHeaderLine(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<html><body>Error</body></html>\n"+
+		"15 Nov 2024,1.2623\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_InvalidUpdateDate(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("DATE,XUDLUSS\n"+
+		"2024-11-15,1.2623\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_UnknownSeriesCode(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("DATE,XUDLXXX\n"+
+		"15 Nov 2024,1\n"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfEnglandDataSource_InvalidRate(t *testing.T) {
+	dataSource := &BankOfEnglandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("DATE,XUDLUSS,XUDLJYS\n"+
+		"15 Nov 2024,null,196.7\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 1)
+
+	actualLatestExchangeRateResponse, err = dataSource.Parse(context, []byte("DATE,XUDLUSS,XUDLJYS\n"+
+		"15 Nov 2024,0,196.7\n"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 1)
+}
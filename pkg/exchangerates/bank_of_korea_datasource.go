@@ -0,0 +1,200 @@
+package exchangerates
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const bankOfKoreaExchangeRateUrlFormat = "https://ecos.bok.or.kr/api/StatisticSearch/%s/json/en/1/1000/731Y001/D/%s/%s"
+const bankOfKoreaExchangeRateReferenceUrl = "https://ecos.bok.or.kr/"
+const bankOfKoreaDataSource = "한국은행"
+const bankOfKoreaBaseCurrency = "KRW"
+
+const bankOfKoreaRequestDateFormat = "20060102"
+const bankOfKoreaRequestRecentDays = 10
+const bankOfKoreaUpdateDateFormat = "20060102"
+const bankOfKoreaUpdateDateTimezone = "Asia/Seoul"
+
+// bankOfKoreaItemCodeCurrencies represents the item codes of the won per foreign currency exchange rates (statistic code 731Y001) in the bank of Korea economic statistics system
+var bankOfKoreaItemCodeCurrencies = map[string]string{
+	"0000001": "USD",
+	"0000002": "JPY",
+	"0000003": "EUR",
+	"0000053": "CNY",
+}
+
+// bankOfKoreaItemCodeUnits represents the units of the foreign currencies which are not quoted per one unit
+var bankOfKoreaItemCodeUnits = map[string]float64{
+	"0000002": 100,
+}
+
+// BankOfKoreaDataSource defines the structure of exchange rates data source of bank of Korea
+type BankOfKoreaDataSource struct {
+	HttpExchangeRatesDataSource
+	apiKey string
+}
+
+// BankOfKoreaExchangeRateData represents the whole data from bank of Korea
+type BankOfKoreaExchangeRateData struct {
+	StatisticSearch *BankOfKoreaStatisticSearchResult `json:"StatisticSearch"`
+}
+
+// BankOfKoreaStatisticSearchResult represents the statistic search result from bank of Korea
+type BankOfKoreaStatisticSearchResult struct {
+	ExchangeRates []*BankOfKoreaExchangeRate `json:"row"`
+}
+
+// BankOfKoreaExchangeRate represents the exchange rate data from bank of Korea
+type BankOfKoreaExchangeRate struct {
+	ItemCode string `json:"ITEM_CODE1"`
+	Rate     string `json:"DATA_VALUE"`
+	Date     string `json:"TIME"`
+}
+
+// ToLatestExchangeRateResponse returns a view-object according to original data from bank of Korea
+func (e *BankOfKoreaExchangeRateData) ToLatestExchangeRateResponse(c core.Context) *models.LatestExchangeRateResponse {
+	if e.StatisticSearch == nil || len(e.StatisticSearch.ExchangeRates) < 1 {
+		log.Errorf(c, "[bank_of_korea_datasource.ToLatestExchangeRateResponse] all exchange rates is empty")
+		return nil
+	}
+
+	timezone, err := time.LoadLocation(bankOfKoreaUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_korea_datasource.ToLatestExchangeRateResponse] failed to get timezone, timezone name is %s", bankOfKoreaUpdateDateTimezone)
+		return nil
+	}
+
+	latestExchangeRates := make(map[string]*models.LatestExchangeRate, len(bankOfKoreaItemCodeCurrencies))
+	latestExchangeRateTimes := make(map[string]int64, len(bankOfKoreaItemCodeCurrencies))
+	latestUpdateTime := int64(0)
+
+	for i := 0; i < len(e.StatisticSearch.ExchangeRates); i++ {
+		exchangeRate := e.StatisticSearch.ExchangeRates[i]
+		currency, exists := bankOfKoreaItemCodeCurrencies[exchangeRate.ItemCode]
+
+		if !exists {
+			continue
+		}
+
+		updateTime, err := time.ParseInLocation(bankOfKoreaUpdateDateFormat, exchangeRate.Date, timezone)
+
+		if err != nil {
+			log.Errorf(c, "[bank_of_korea_datasource.ToLatestExchangeRateResponse] failed to parse update date, datetime is %s", exchangeRate.Date)
+			return nil
+		}
+
+		if existedUpdateTime, exists := latestExchangeRateTimes[currency]; exists && existedUpdateTime >= updateTime.Unix() {
+			continue
+		}
+
+		finalExchangeRate := exchangeRate.ToLatestExchangeRate(c, currency)
+
+		if finalExchangeRate == nil {
+			continue
+		}
+
+		latestExchangeRates[currency] = finalExchangeRate
+		latestExchangeRateTimes[currency] = updateTime.Unix()
+
+		if updateTime.Unix() > latestUpdateTime {
+			latestUpdateTime = updateTime.Unix()
+		}
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(latestExchangeRates))
+
+	for _, exchangeRate := range latestExchangeRates {
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+
+	sort.Sort(exchangeRates)
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    bankOfKoreaDataSource,
+		ReferenceUrl:  bankOfKoreaExchangeRateReferenceUrl,
+		UpdateTime:    latestUpdateTime,
+		BaseCurrency:  bankOfKoreaBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp
+}
+
+// ToLatestExchangeRate returns a data pair according to original data from bank of Korea
+func (e *BankOfKoreaExchangeRate) ToLatestExchangeRate(c core.Context, currency string) *models.LatestExchangeRate {
+	rate, err := utils.StringToFloat64(e.Rate)
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_korea_datasource.ToLatestExchangeRate] failed to parse rate, currency is %s, rate is %s", currency, e.Rate)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[bank_of_korea_datasource.ToLatestExchangeRate] rate is invalid, currency is %s, rate is %s", currency, e.Rate)
+		return nil
+	}
+
+	unit := float64(1)
+
+	if itemUnit, exists := bankOfKoreaItemCodeUnits[e.ItemCode]; exists {
+		unit = itemUnit
+	}
+
+	finalRate := unit / rate
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: currency,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
+
+// BuildRequests returns the bank of Korea exchange rates http requests
+func (e *BankOfKoreaDataSource) BuildRequests() ([]*http.Request, error) {
+	now := time.Now()
+	startDate := now.AddDate(0, 0, -bankOfKoreaRequestRecentDays).Format(bankOfKoreaRequestDateFormat)
+	endDate := now.Format(bankOfKoreaRequestDateFormat)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(bankOfKoreaExchangeRateUrlFormat, url.PathEscape(e.apiKey), startDate, endDate), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the bank of Korea data source raw response
+func (e *BankOfKoreaDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	bankOfKoreaData := &BankOfKoreaExchangeRateData{}
+	err := json.Unmarshal(content, bankOfKoreaData)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_korea_datasource.Parse] failed to parse json data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResponse := bankOfKoreaData.ToLatestExchangeRateResponse(c)
+
+	if latestExchangeRateResponse == nil {
+		log.Errorf(c, "[bank_of_korea_datasource.Parse] failed to parse latest exchange rate data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return latestExchangeRateResponse, nil
+}
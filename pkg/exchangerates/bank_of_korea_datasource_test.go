@@ -0,0 +1,113 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const bankOfKoreaMinimumRequiredContent = "{\n" +
+	"  \"StatisticSearch\": {\n" +
+	"    \"list_total_count\": 3,\n" +
+	"    \"row\": [\n" +
+	"      {\"STAT_CODE\": \"731Y001\", \"ITEM_CODE1\": \"0000001\", \"ITEM_NAME1\": \"U.S. Dollar(Basic Rate)\", \"UNIT_NAME\": \"KRW\", \"TIME\": \"20241114\", \"DATA_VALUE\": \"1405.1\"},\n" +
+	"      {\"STAT_CODE\": \"731Y001\", \"ITEM_CODE1\": \"0000001\", \"ITEM_NAME1\": \"U.S. Dollar(Basic Rate)\", \"UNIT_NAME\": \"KRW\", \"TIME\": \"20241115\", \"DATA_VALUE\": \"1398.8\"},\n" +
+	"      {\"STAT_CODE\": \"731Y001\", \"ITEM_CODE1\": \"0000002\", \"ITEM_NAME1\": \"Japanese Yen(100Yen)\", \"UNIT_NAME\": \"KRW\", \"TIME\": \"20241114\", \"DATA_VALUE\": \"901.67\"}\n" +
+	"    ]\n" +
+	"  }\n" +
+	"}"
+
+func TestBankOfKoreaDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfKoreaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "KRW", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestBankOfKoreaDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfKoreaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1731596400), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestBankOfKoreaDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfKoreaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 2)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.0007148984844152131",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "0.11090532012820656",
+	})
+}
+
+func TestBankOfKoreaDataSource_BlankContent(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfKoreaDataSource_ErrorResult(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{\"RESULT\": {\"CODE\": \"INFO-100\", \"MESSAGE\": \"Invalid authentication key\"}}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfKoreaDataSource_EmptyData(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{\"StatisticSearch\": {\"list_total_count\": 0, \"row\": []}}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfKoreaDataSource_InvalidUpdateDate(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{\"StatisticSearch\": {\"row\": ["+
+		"{\"ITEM_CODE1\": \"0000001\", \"TIME\": \"2024-11-15\", \"DATA_VALUE\": \"1398.8\"}"+
+		"]}}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfKoreaDataSource_UnknownItemCode(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"StatisticSearch\": {\"row\": ["+
+		"{\"ITEM_CODE1\": \"9999999\", \"TIME\": \"20241115\", \"DATA_VALUE\": \"1\"}"+
+		"]}}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfKoreaDataSource_InvalidRate(t *testing.T) {
+	dataSource := &BankOfKoreaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"StatisticSearch\": {\"row\": ["+
+		"{\"ITEM_CODE1\": \"0000001\", \"TIME\": \"20241115\", \"DATA_VALUE\": \"null\"},"+
+		"{\"ITEM_CODE1\": \"0000002\", \"TIME\": \"20241115\", \"DATA_VALUE\": \"0\"}"+
+		"]}}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
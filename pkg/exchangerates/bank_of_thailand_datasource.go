@@ -0,0 +1,207 @@
+package exchangerates
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const bankOfThailandExchangeRateUrlFormat = "https://gateway.api.bot.or.th/Stat-ExchangeRate/v2/DAILY_AVG_EXG_RATE/?start_period=%s&end_period=%s"
+const bankOfThailandExchangeRateReferenceUrl = "https://www.bot.or.th/en/statistics/exchange-rate.html"
+const bankOfThailandDataSource = "ธนาคารแห่งประเทศไทย"
+const bankOfThailandBaseCurrency = "THB"
+
+const bankOfThailandRequestDateFormat = "2006-01-02"
+const bankOfThailandRequestRecentDays = 10
+const bankOfThailandUpdateDateFormat = "2006-01-02"
+const bankOfThailandUpdateDateTimezone = "Asia/Bangkok"
+
+// bankOfThailandCurrencyUnitPattern matches the unit of foreign currency in the currency name (e.g. "JAPAN : YEN (100 YEN)")
+var bankOfThailandCurrencyUnitPattern = regexp.MustCompile(`\((\d+) [^)]*\)`)
+
+// BankOfThailandDataSource defines the structure of exchange rates data source of bank of Thailand
+type BankOfThailandDataSource struct {
+	HttpExchangeRatesDataSource
+	apiKey string
+}
+
+// BankOfThailandExchangeRateData represents the whole data from bank of Thailand
+type BankOfThailandExchangeRateData struct {
+	Result *BankOfThailandExchangeRateResult `json:"result"`
+}
+
+// BankOfThailandExchangeRateResult represents the result of exchange rates api from bank of Thailand
+type BankOfThailandExchangeRateResult struct {
+	Data *BankOfThailandExchangeRateResultData `json:"data"`
+}
+
+// BankOfThailandExchangeRateResultData represents the data of exchange rates api from bank of Thailand
+type BankOfThailandExchangeRateResultData struct {
+	ExchangeRates []*BankOfThailandExchangeRate `json:"data_detail"`
+}
+
+// BankOfThailandExchangeRate represents the exchange rate data from bank of Thailand
+type BankOfThailandExchangeRate struct {
+	Currency     string `json:"currency_id"`
+	CurrencyName string `json:"currency_name_eng"`
+	Rate         string `json:"mid_rate"`
+	Date         string `json:"period"`
+}
+
+// ToLatestExchangeRateResponse returns a view-object according to original data from bank of Thailand
+func (e *BankOfThailandExchangeRateData) ToLatestExchangeRateResponse(c core.Context) *models.LatestExchangeRateResponse {
+	if e.Result == nil || e.Result.Data == nil || len(e.Result.Data.ExchangeRates) < 1 {
+		log.Errorf(c, "[bank_of_thailand_datasource.ToLatestExchangeRateResponse] all exchange rates is empty")
+		return nil
+	}
+
+	timezone, err := time.LoadLocation(bankOfThailandUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.ToLatestExchangeRateResponse] failed to get timezone, timezone name is %s", bankOfThailandUpdateDateTimezone)
+		return nil
+	}
+
+	allExchangeRates := e.Result.Data.ExchangeRates
+	latestExchangeRates := make(map[string]*models.LatestExchangeRate, len(allExchangeRates))
+	latestExchangeRateTimes := make(map[string]int64, len(allExchangeRates))
+	latestUpdateTime := int64(0)
+
+	for i := 0; i < len(allExchangeRates); i++ {
+		exchangeRate := allExchangeRates[i]
+
+		if _, exists := validators.AllCurrencyNames[exchangeRate.Currency]; !exists {
+			continue
+		}
+
+		updateTime, err := time.ParseInLocation(bankOfThailandUpdateDateFormat, exchangeRate.Date, timezone)
+
+		if err != nil {
+			log.Errorf(c, "[bank_of_thailand_datasource.ToLatestExchangeRateResponse] failed to parse update date, datetime is %s", exchangeRate.Date)
+			return nil
+		}
+
+		if existedUpdateTime, exists := latestExchangeRateTimes[exchangeRate.Currency]; exists && existedUpdateTime >= updateTime.Unix() {
+			continue
+		}
+
+		finalExchangeRate := exchangeRate.ToLatestExchangeRate(c)
+
+		if finalExchangeRate == nil {
+			continue
+		}
+
+		latestExchangeRates[exchangeRate.Currency] = finalExchangeRate
+		latestExchangeRateTimes[exchangeRate.Currency] = updateTime.Unix()
+
+		if updateTime.Unix() > latestUpdateTime {
+			latestUpdateTime = updateTime.Unix()
+		}
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(latestExchangeRates))
+
+	for _, exchangeRate := range latestExchangeRates {
+		exchangeRates = append(exchangeRates, exchangeRate)
+	}
+
+	sort.Sort(exchangeRates)
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    bankOfThailandDataSource,
+		ReferenceUrl:  bankOfThailandExchangeRateReferenceUrl,
+		UpdateTime:    latestUpdateTime,
+		BaseCurrency:  bankOfThailandBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp
+}
+
+// ToLatestExchangeRate returns a data pair according to original data from bank of Thailand
+func (e *BankOfThailandExchangeRate) ToLatestExchangeRate(c core.Context) *models.LatestExchangeRate {
+	rate, err := utils.StringToFloat64(strings.TrimSpace(e.Rate))
+
+	if err != nil {
+		log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] failed to parse rate, currency is %s, rate is %s", e.Currency, e.Rate)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] rate is invalid, currency is %s, rate is %s", e.Currency, e.Rate)
+		return nil
+	}
+
+	unit := float64(1)
+	unitMatches := bankOfThailandCurrencyUnitPattern.FindStringSubmatch(e.CurrencyName)
+
+	if len(unitMatches) > 1 {
+		unit, err = utils.StringToFloat64(unitMatches[1])
+
+		if err != nil || unit <= 0 {
+			log.Warnf(c, "[bank_of_thailand_datasource.ToLatestExchangeRate] unit is invalid, currency is %s, currency name is %s", e.Currency, e.CurrencyName)
+			return nil
+		}
+	}
+
+	finalRate := unit / rate
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: e.Currency,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
+
+// BuildRequests returns the bank of Thailand exchange rates http requests
+func (e *BankOfThailandDataSource) BuildRequests() ([]*http.Request, error) {
+	now := time.Now()
+	startDate := now.AddDate(0, 0, -bankOfThailandRequestRecentDays).Format(bankOfThailandRequestDateFormat)
+	endDate := now.Format(bankOfThailandRequestDateFormat)
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(bankOfThailandExchangeRateUrlFormat, startDate, endDate), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", e.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the bank of Thailand data source raw response
+func (e *BankOfThailandDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	bankOfThailandData := &BankOfThailandExchangeRateData{}
+	err := json.Unmarshal(content, bankOfThailandData)
+
+	if err != nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.Parse] failed to parse json data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResponse := bankOfThailandData.ToLatestExchangeRateResponse(c)
+
+	if latestExchangeRateResponse == nil {
+		log.Errorf(c, "[bank_of_thailand_datasource.Parse] failed to parse latest exchange rate data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return latestExchangeRateResponse, nil
+}
@@ -0,0 +1,123 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const bankOfThailandMinimumRequiredContent = "{\n" +
+	"  \"result\": {\n" +
+	"    \"timestamp\": \"2024-11-16 10:00:00\",\n" +
+	"    \"api\": \"Daily Weighted-average Interbank Exchange Rate - THB / Foreign Currency\",\n" +
+	"    \"data\": {\n" +
+	"      \"data_header\": {\"report_uoq_name_eng\": \"(Unit : Baht / 1 Unit of Foreign Currency)\", \"last_updated\": \"2024-11-15\"},\n" +
+	"      \"data_detail\": [\n" +
+	"        {\"period\": \"2024-11-14\", \"currency_id\": \"USD\", \"currency_name_eng\": \"USA : DOLLAR (USD)\", \"buying_sight\": \"34.6100000\", \"buying_transfer\": \"34.6800000\", \"selling\": \"35.0200000\", \"mid_rate\": \"34.8500000\"},\n" +
+	"        {\"period\": \"2024-11-15\", \"currency_id\": \"USD\", \"currency_name_eng\": \"USA : DOLLAR (USD)\", \"buying_sight\": \"34.5400000\", \"buying_transfer\": \"34.6100000\", \"selling\": \"34.9500000\", \"mid_rate\": \"34.7800000\"},\n" +
+	"        {\"period\": \"2024-11-15\", \"currency_id\": \"JPY\", \"currency_name_eng\": \"JAPAN : YEN (100 YEN)\", \"buying_sight\": \"21.9800000\", \"buying_transfer\": \"22.0500000\", \"selling\": \"22.6400000\", \"mid_rate\": \"22.3400000\"}\n" +
+	"      ]\n" +
+	"    }\n" +
+	"  }\n" +
+	"}"
+
+func TestBankOfThailandDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfThailandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "THB", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestBankOfThailandDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfThailandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1731603600), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestBankOfThailandDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(bankOfThailandMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 2)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.02875215641173088",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "4.476275738585497",
+	})
+}
+
+func TestBankOfThailandDataSource_BlankContent(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_EmptyData(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{}"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = dataSource.Parse(context, []byte("{\"result\": {\"data\": {\"data_detail\": []}}}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_InvalidUpdateDate(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{\"result\": {\"data\": {\"data_detail\": ["+
+		"{\"period\": \"15.11.2024\", \"currency_id\": \"USD\", \"currency_name_eng\": \"USA : DOLLAR (USD)\", \"mid_rate\": \"34.78\"}"+
+		"]}}}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestBankOfThailandDataSource_InvalidCurrency(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"result\": {\"data\": {\"data_detail\": ["+
+		"{\"period\": \"2024-11-15\", \"currency_id\": \"XXX\", \"currency_name_eng\": \"UNKNOWN (XXX)\", \"mid_rate\": \"1\"}"+
+		"]}}}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfThailandDataSource_InvalidUnit(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"result\": {\"data\": {\"data_detail\": ["+
+		"{\"period\": \"2024-11-15\", \"currency_id\": \"JPY\", \"currency_name_eng\": \"JAPAN : YEN (0 YEN)\", \"mid_rate\": \"22.34\"}"+
+		"]}}}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestBankOfThailandDataSource_InvalidRate(t *testing.T) {
+	dataSource := &BankOfThailandDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"result\": {\"data\": {\"data_detail\": ["+
+		"{\"period\": \"2024-11-15\", \"currency_id\": \"USD\", \"currency_name_eng\": \"USA : DOLLAR (USD)\", \"mid_rate\": \"\"},"+
+		"{\"period\": \"2024-11-15\", \"currency_id\": \"EUR\", \"currency_name_eng\": \"EURO ZONE : EURO (EUR)\", \"mid_rate\": \"0\"}"+
+		"]}}}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
@@ -0,0 +1,169 @@
+package exchangerates
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const centralBankOfTurkiyeExchangeRateUrl = "https://www.tcmb.gov.tr/kurlar/today.xml"
+const centralBankOfTurkiyeExchangeRateReferenceUrl = "https://www.tcmb.gov.tr/wps/wcm/connect/EN/TCMB+EN/Main+Menu/Statistics/Exchange+Rates/Indicative+Exchange+Rates"
+const centralBankOfTurkiyeDataSource = "Türkiye Cumhuriyet Merkez Bankası"
+const centralBankOfTurkiyeBaseCurrency = "TRY"
+
+const centralBankOfTurkiyeUpdateDateFormat = "02.01.2006 15:04"
+const centralBankOfTurkiyeUpdateDateTimezone = "Europe/Istanbul"
+
+// CentralBankOfTurkiyeDataSource defines the structure of exchange rates data source of the central bank of the Republic of Türkiye
+type CentralBankOfTurkiyeDataSource struct {
+	HttpExchangeRatesDataSource
+}
+
+// CentralBankOfTurkiyeExchangeRateData represents the whole data from the central bank of the Republic of Türkiye
+type CentralBankOfTurkiyeExchangeRateData struct {
+	XMLName       xml.Name                            `xml:"Tarih_Date"`
+	Date          string                              `xml:"Tarih,attr"`
+	ExchangeRates []*CentralBankOfTurkiyeExchangeRate `xml:"Currency"`
+}
+
+// CentralBankOfTurkiyeExchangeRate represents the exchange rate data from the central bank of the Republic of Türkiye
+type CentralBankOfTurkiyeExchangeRate struct {
+	Currency string `xml:"CurrencyCode,attr"`
+	Unit     string `xml:"Unit"`
+	Rate     string `xml:"ForexBuying"`
+}
+
+// ToLatestExchangeRateResponse returns a view-object according to original data from the central bank of the Republic of Türkiye
+func (e *CentralBankOfTurkiyeExchangeRateData) ToLatestExchangeRateResponse(c core.Context) *models.LatestExchangeRateResponse {
+	if len(e.ExchangeRates) < 1 {
+		log.Errorf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRateResponse] all exchange rates is empty")
+		return nil
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(e.ExchangeRates))
+
+	for i := 0; i < len(e.ExchangeRates); i++ {
+		exchangeRate := e.ExchangeRates[i]
+
+		if _, exists := validators.AllCurrencyNames[exchangeRate.Currency]; !exists {
+			continue
+		}
+
+		finalExchangeRate := exchangeRate.ToLatestExchangeRate(c)
+
+		if finalExchangeRate == nil {
+			continue
+		}
+
+		exchangeRates = append(exchangeRates, finalExchangeRate)
+	}
+
+	timezone, err := time.LoadLocation(centralBankOfTurkiyeUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRateResponse] failed to get timezone, timezone name is %s", centralBankOfTurkiyeUpdateDateTimezone)
+		return nil
+	}
+
+	updateDateTime := e.Date + " 15:30" // the indicative exchange rates are announced at 15:30 Istanbul time
+	updateTime, err := time.ParseInLocation(centralBankOfTurkiyeUpdateDateFormat, updateDateTime, timezone)
+
+	if err != nil {
+		log.Errorf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRateResponse] failed to parse update date, datetime is %s", updateDateTime)
+		return nil
+	}
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    centralBankOfTurkiyeDataSource,
+		ReferenceUrl:  centralBankOfTurkiyeExchangeRateReferenceUrl,
+		UpdateTime:    updateTime.Unix(),
+		BaseCurrency:  centralBankOfTurkiyeBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp
+}
+
+// ToLatestExchangeRate returns a data pair according to original data from the central bank of the Republic of Türkiye
+func (e *CentralBankOfTurkiyeExchangeRate) ToLatestExchangeRate(c core.Context) *models.LatestExchangeRate {
+	rate, err := utils.StringToFloat64(strings.TrimSpace(e.Rate))
+
+	if err != nil {
+		log.Warnf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRate] failed to parse rate, currency is %s, rate is %s", e.Currency, e.Rate)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRate] rate is invalid, currency is %s, rate is %s", e.Currency, e.Rate)
+		return nil
+	}
+
+	unit, err := utils.StringToFloat64(strings.TrimSpace(e.Unit))
+
+	if err != nil {
+		log.Warnf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRate] failed to parse unit, currency is %s, unit is %s", e.Currency, e.Unit)
+		return nil
+	}
+
+	if unit <= 0 {
+		log.Warnf(c, "[central_bank_of_turkiye_datasource.ToLatestExchangeRate] unit is less or equal zero, currency is %s, unit is %s", e.Currency, e.Unit)
+		return nil
+	}
+
+	finalRate := unit / rate
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: e.Currency,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
+
+// BuildRequests returns the central bank of the Republic of Türkiye exchange rates http requests
+func (e *CentralBankOfTurkiyeDataSource) BuildRequests() ([]*http.Request, error) {
+	req, err := http.NewRequest("GET", centralBankOfTurkiyeExchangeRateUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the central bank of the Republic of Türkiye data source raw response
+func (e *CentralBankOfTurkiyeDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
+	xmlDecoder.CharsetReader = charset.NewReaderLabel
+
+	centralBankOfTurkiyeData := &CentralBankOfTurkiyeExchangeRateData{}
+	err := xmlDecoder.Decode(centralBankOfTurkiyeData)
+
+	if err != nil {
+		log.Errorf(c, "[central_bank_of_turkiye_datasource.Parse] failed to parse xml data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResponse := centralBankOfTurkiyeData.ToLatestExchangeRateResponse(c)
+
+	if latestExchangeRateResponse == nil {
+		log.Errorf(c, "[central_bank_of_turkiye_datasource.Parse] failed to parse latest exchange rate data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return latestExchangeRateResponse, nil
+}
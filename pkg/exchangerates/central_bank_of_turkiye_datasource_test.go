@@ -0,0 +1,182 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const centralBankOfTurkiyeMinimumRequiredContent = "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+	"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">\n" +
+	"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n" +
+	"    <Unit>1</Unit>\n" +
+	"    <Isim>ABD DOLARI</Isim>\n" +
+	"    <CurrencyName>US DOLLAR</CurrencyName>\n" +
+	"    <ForexBuying>34.4468</ForexBuying>\n" +
+	"    <ForexSelling>34.5089</ForexSelling>\n" +
+	"  </Currency>\n" +
+	"  <Currency CrossOrder=\"4\" Kod=\"JPY\" CurrencyCode=\"JPY\">\n" +
+	"    <Unit>100</Unit>\n" +
+	"    <Isim>JAPON YENİ</Isim>\n" +
+	"    <CurrencyName>JAPENESE YEN</CurrencyName>\n" +
+	"    <ForexBuying>22.0371</ForexBuying>\n" +
+	"    <ForexSelling>22.1830</ForexSelling>\n" +
+	"  </Currency>\n" +
+	"</Tarih_Date>"
+
+func TestCentralBankOfTurkiyeDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(centralBankOfTurkiyeMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "TRY", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestCentralBankOfTurkiyeDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(centralBankOfTurkiyeMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1731673800), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestCentralBankOfTurkiyeDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(centralBankOfTurkiyeMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.02903027276844293",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "4.537802160901389",
+	})
+}
+
+func TestCentralBankOfTurkiyeDataSource_BlankContent(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkiyeDataSource_OnlyXMLHeader(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkiyeDataSource_EmptyExchangeRatesDataset(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"</Tarih_Date>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkiyeDataSource_InvalidUpdateDate(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"2024-11-15\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>34.4468</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestCentralBankOfTurkiyeDataSource_InvalidCurrency(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"XXX\" CurrencyCode=\"XXX\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>1</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestCentralBankOfTurkiyeDataSource_InvalidUnit(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>null</Unit>\n"+
+		"    <ForexBuying>34.4468</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+
+	actualLatestExchangeRateResponse, err = dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>0</Unit>\n"+
+		"    <ForexBuying>34.4468</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestCentralBankOfTurkiyeDataSource_EmptyRate(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying></ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestCentralBankOfTurkiyeDataSource_InvalidRate(t *testing.T) {
+	dataSource := &CentralBankOfTurkiyeDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>null</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+
+	actualLatestExchangeRateResponse, err = dataSource.Parse(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<Tarih_Date Tarih=\"15.11.2024\" Date=\"11/15/2024\" Bulten_No=\"2024/215\">"+
+		"  <Currency CrossOrder=\"0\" Kod=\"USD\" CurrencyCode=\"USD\">\n"+
+		"    <Unit>1</Unit>\n"+
+		"    <ForexBuying>0</ForexBuying>\n"+
+		"  </Currency>\n"+
+		"</Tarih_Date>"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
@@ -22,6 +23,15 @@ type HttpExchangeRatesDataSource interface {
 	Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error)
 }
 
+// HistoricalHttpExchangeRatesDataSource defines the structure of http exchange rates data source which supports historical exchange rates
+type HistoricalHttpExchangeRatesDataSource interface {
+	// BuildHistoricalRequests returns the http requests of historical exchange rates between the start time and the end time
+	BuildHistoricalRequests(startTime time.Time, endTime time.Time) ([]*http.Request, error)
+
+	// ParseHistorical returns the common response entities of each day according to the data source raw response
+	ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error)
+}
+
 // CommonHttpExchangeRatesDataProvider defines the structure of common http exchange rates data provider
 type CommonHttpExchangeRatesDataProvider struct {
 	ExchangeRatesDataProvider
@@ -105,6 +115,102 @@ func (e *CommonHttpExchangeRatesDataProvider) GetLatestExchangeRates(c core.Cont
 	return finalExchangeRateResponse, nil
 }
 
+// GetHistoricalExchangeRates returns the historical exchange rates of each day between the start time and the end time from the data source
+func (e *CommonHttpExchangeRatesDataProvider) GetHistoricalExchangeRates(c core.Context, startTime time.Time, endTime time.Time) ([]*models.LatestExchangeRateResponse, error) {
+	historicalDataSource, ok := e.dataSource.(HistoricalHttpExchangeRatesDataSource)
+
+	if !ok {
+		return nil, errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	requests, err := historicalDataSource.BuildHistoricalRequests(startTime, endTime)
+
+	if err != nil {
+		log.Errorf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] failed to build requests, because %s", err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	startDate := models.GetExchangeRateSnapshotDate(startTime.Unix())
+	endDate := models.GetExchangeRateSnapshotDate(endTime.Unix())
+	exchangeRateRespsMap := make(map[int32]*models.LatestExchangeRateResponse)
+
+	for i := 0; i < len(requests); i++ {
+		req := requests[i]
+		req = req.WithContext(httpclient.CustomHttpResponseLog(c, func(data []byte) {
+			log.Debugf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] response#%d is %s", i, data)
+		}))
+
+		resp, err := e.httpClient.Do(req)
+
+		if err != nil {
+			log.Errorf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] failed to request historical exchange rate data, because %s", err.Error())
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+
+		if resp.StatusCode != 200 {
+			log.Errorf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] failed to get historical exchange rate data response, because response code is %d", resp.StatusCode)
+			return nil, errs.ErrFailedToRequestRemoteApi
+		}
+
+		exchangeRateResps, err := historicalDataSource.ParseHistorical(c, body)
+
+		if err != nil {
+			log.Errorf(c, "[common_http_exchange_rates_data_provider.GetHistoricalExchangeRates] failed to parse response, because %s", err.Error())
+			return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
+		}
+
+		for j := 0; j < len(exchangeRateResps); j++ {
+			exchangeRateResp := exchangeRateResps[j]
+			date := models.GetExchangeRateSnapshotDate(exchangeRateResp.UpdateTime)
+
+			if date < startDate || date > endDate {
+				continue
+			}
+
+			existedExchangeRateResp, exists := exchangeRateRespsMap[date]
+
+			if !exists {
+				exchangeRateRespsMap[date] = exchangeRateResp
+				continue
+			}
+
+			existedExchangeRateResp.ExchangeRates = append(existedExchangeRateResp.ExchangeRates, exchangeRateResp.ExchangeRates...)
+		}
+	}
+
+	allExchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(exchangeRateRespsMap))
+
+	for _, exchangeRateResp := range exchangeRateRespsMap {
+		hasBaseCurrency := false
+
+		for i := 0; i < len(exchangeRateResp.ExchangeRates); i++ {
+			if exchangeRateResp.ExchangeRates[i].Currency == exchangeRateResp.BaseCurrency {
+				hasBaseCurrency = true
+				break
+			}
+		}
+
+		if !hasBaseCurrency {
+			exchangeRateResp.ExchangeRates = append(exchangeRateResp.ExchangeRates, &models.LatestExchangeRate{
+				Currency: exchangeRateResp.BaseCurrency,
+				Rate:     "1",
+			})
+		}
+
+		sort.Sort(exchangeRateResp.ExchangeRates)
+		allExchangeRateResps = append(allExchangeRateResps, exchangeRateResp)
+	}
+
+	sort.Slice(allExchangeRateResps, func(i, j int) bool {
+		return allExchangeRateResps[i].UpdateTime < allExchangeRateResps[j].UpdateTime
+	})
+
+	return allExchangeRateResps, nil
+}
+
 func newCommonHttpExchangeRatesDataProvider(config *settings.Config, dataSource HttpExchangeRatesDataSource) *CommonHttpExchangeRatesDataProvider {
 	return &CommonHttpExchangeRatesDataProvider{
 		dataSource: dataSource,
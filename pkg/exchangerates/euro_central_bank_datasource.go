@@ -17,12 +17,15 @@ import (
 )
 
 const euroCentralBankExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+const euroCentralBankHistoricalExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist.xml"
+const euroCentralBankRecentHistoricalExchangeRateUrl = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
 const euroCentralBankExchangeRateReferenceUrl = "https://www.ecb.europa.eu/stats/policy_and_exchange_rates/euro_reference_exchange_rates/html/index.en.html"
 const euroCentralBankDataSource = "European Central Bank"
 const euroCentralBankBaseCurrency = "EUR"
 
 const euroCentralBankDataUpdateDateFormat = "2006-01-02 15"
 const euroCentralBankDataUpdateDateTimezone = "Europe/Berlin"
+const euroCentralBankRecentHistoricalDays = 90
 
 // EuroCentralBankDataSource defines the structure of exchange rates data source of euro central bank
 type EuroCentralBankDataSource struct {
@@ -103,6 +106,28 @@ func (e *EuroCentralBankExchangeRateData) ToLatestExchangeRateResponse(c core.Co
 	return latestExchangeRateResp
 }
 
+// ToHistoricalExchangeRateResponses returns the view-objects of each day according to original data from euro central bank
+func (e *EuroCentralBankExchangeRateData) ToHistoricalExchangeRateResponses(c core.Context) []*models.LatestExchangeRateResponse {
+	historicalExchangeRateResps := make([]*models.LatestExchangeRateResponse, 0, len(e.AllExchangeRates))
+
+	for i := 0; i < len(e.AllExchangeRates); i++ {
+		dailyExchangeRateData := &EuroCentralBankExchangeRateData{
+			AllExchangeRates: []*EuroCentralBankExchangeRates{e.AllExchangeRates[i]},
+		}
+
+		dailyExchangeRateResp := dailyExchangeRateData.ToLatestExchangeRateResponse(c)
+
+		if dailyExchangeRateResp == nil {
+			log.Warnf(c, "[euro_central_bank_datasource.ToHistoricalExchangeRateResponses] skip exchange rates of date %s", e.AllExchangeRates[i].Date)
+			continue
+		}
+
+		historicalExchangeRateResps = append(historicalExchangeRateResps, dailyExchangeRateResp)
+	}
+
+	return historicalExchangeRateResps
+}
+
 // ToLatestExchangeRate returns a data pair according to original data from euro central bank
 func (e *EuroCentralBankExchangeRate) ToLatestExchangeRate() *models.LatestExchangeRate {
 	return &models.LatestExchangeRate{
@@ -144,3 +169,44 @@ func (e *EuroCentralBankDataSource) Parse(c core.Context, content []byte) (*mode
 
 	return latestExchangeRateResponse, nil
 }
+
+// BuildHistoricalRequests returns the euro central bank historical exchange rates http requests
+func (e *EuroCentralBankDataSource) BuildHistoricalRequests(startTime time.Time, endTime time.Time) ([]*http.Request, error) {
+	url := euroCentralBankHistoricalExchangeRateUrl
+
+	// the full history file is large, so use the file which only contains the recent days if possible
+	if time.Since(startTime) < (euroCentralBankRecentHistoricalDays-1)*24*time.Hour {
+		url = euroCentralBankRecentHistoricalExchangeRateUrl
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// ParseHistorical returns the common response entities of each day according to the euro central bank data source raw response
+func (e *EuroCentralBankDataSource) ParseHistorical(c core.Context, content []byte) ([]*models.LatestExchangeRateResponse, error) {
+	xmlDecoder := xml.NewDecoder(bytes.NewReader(content))
+	xmlDecoder.CharsetReader = charset.NewReaderLabel
+
+	euroCentralBankData := &EuroCentralBankExchangeRateData{}
+	err := xmlDecoder.Decode(euroCentralBankData)
+
+	if err != nil {
+		log.Errorf(c, "[euro_central_bank_datasource.ParseHistorical] failed to parse xml data, because %s", err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	historicalExchangeRateResps := euroCentralBankData.ToHistoricalExchangeRateResponses(c)
+
+	if len(historicalExchangeRateResps) < 1 {
+		log.Errorf(c, "[euro_central_bank_datasource.ParseHistorical] failed to parse historical exchange rate data")
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return historicalExchangeRateResps, nil
+}
@@ -138,3 +138,47 @@ func TestEuroCentralBankDataSource_InvalidRate(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
 }
+
+func TestEuroCentralBankDataSource_HistoricalDataExtractEachDay(t *testing.T) {
+	dataSource := &EuroCentralBankDataSource{}
+	context := core.NewNullContext()
+
+	actualHistoricalExchangeRateResponses, err := dataSource.ParseHistorical(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n"+
+		"<gesmes:Envelope xmlns:gesmes=\"http://www.gesmes.org/xml/2002-08-01\" xmlns=\"http://www.ecb.int/vocabulary/2002-08-01/eurofxref\">\n"+
+		"  <Cube>\n"+
+		"    <Cube time=\"2021-04-01\">\n"+
+		"      <Cube currency=\"USD\" rate=\"1.1746\" />\n"+
+		"    </Cube>\n"+
+		"    <Cube time=\"2021-03-31\">\n"+
+		"      <Cube currency=\"USD\" rate=\"1.1725\" />\n"+
+		"    </Cube>\n"+
+		"    <Cube time=\"2021-03-30\">\n"+
+		"    </Cube>\n"+
+		"  </Cube>\n"+
+		"</gesmes:Envelope>"))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, len(actualHistoricalExchangeRateResponses))
+
+	assert.Equal(t, int64(1617285600), actualHistoricalExchangeRateResponses[0].UpdateTime)
+	assert.Equal(t, "EUR", actualHistoricalExchangeRateResponses[0].BaseCurrency)
+	assert.Contains(t, actualHistoricalExchangeRateResponses[0].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.1746",
+	})
+
+	assert.Equal(t, int64(1617199200), actualHistoricalExchangeRateResponses[1].UpdateTime)
+	assert.Contains(t, actualHistoricalExchangeRateResponses[1].ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "1.1725",
+	})
+}
+
+func TestEuroCentralBankDataSource_HistoricalEmptyEnvelopeContent(t *testing.T) {
+	dataSource := &EuroCentralBankDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.ParseHistorical(context, []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?>"+
+		"<gesmes:Envelope xmlns:gesmes=\"http://www.gesmes.org/xml/2002-08-01\" xmlns=\"http://www.ecb.int/vocabulary/2002-08-01/eurofxref\">"+
+		"</gesmes:Envelope>"))
+	assert.NotEqual(t, nil, err)
+}
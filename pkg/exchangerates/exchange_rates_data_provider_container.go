@@ -1,78 +1,60 @@
 package exchangerates
 
 import (
+	"time"
+
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 )
 
 // ExchangeRatesDataProviderContainer contains the current exchange rates data provider
 type ExchangeRatesDataProviderContainer struct {
-	current ExchangeRatesDataProvider
+	current               ExchangeRatesDataProvider
+	currentDataSource     string
+	exchangeRateSnapshots *services.ExchangeRateSnapshotService
 }
 
 // Initialize a exchange rates data provider container singleton instance
 var (
-	Container = &ExchangeRatesDataProviderContainer{}
+	Container = &ExchangeRatesDataProviderContainer{
+		exchangeRateSnapshots: services.ExchangeRateSnapshots,
+	}
 )
 
 // InitializeExchangeRatesDataSource initializes the current exchange rates data source according to the config
 func InitializeExchangeRatesDataSource(config *settings.Config) error {
-	if config.ExchangeRatesDataSource == settings.ReserveBankOfAustraliaDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &ReserveBankOfAustraliaDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.BankOfCanadaDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &BankOfCanadaDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CzechNationalBankDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &CzechNationalBankDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.DanmarksNationalbankDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &DanmarksNationalbankDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.EuroCentralBankDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &EuroCentralBankDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfGeorgiaDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfGeorgiaDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CentralBankOfHungaryDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfHungaryDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.BankOfIsraelDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &BankOfIsraelDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CentralBankOfMyanmarDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfMyanmarDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NorgesBankDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &NorgesBankDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfPolandDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfPolandDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfRomaniaDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfRomaniaDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.BankOfRussiaDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &BankOfRussiaDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.SwissNationalBankDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &SwissNationalBankDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.NationalBankOfUkraineDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfUkraineDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.CentralBankOfUzbekistanDataSource {
-		Container.current = newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfUzbekistanDataSource{})
-		return nil
-	} else if config.ExchangeRatesDataSource == settings.UserCustomExchangeRatesDataSource {
-		Container.current = newUserCustomExchangeRatesDataProvider()
+	dataProvider, err := newExchangeRatesDataProvider(config, config.ExchangeRatesDataSource)
+
+	if err != nil {
+		return err
+	}
+
+	Container.currentDataSource = config.ExchangeRatesDataSource
+
+	if config.ExchangeRatesDataSource == settings.UserCustomExchangeRatesDataSource {
+		Container.current = dataProvider
 		return nil
 	}
 
-	return errs.ErrInvalidExchangeRatesDataSource
+	dataProviders := []ExchangeRatesDataProvider{dataProvider}
+
+	for i := 0; i < len(config.ExchangeRatesFallbackDataSources); i++ {
+		fallbackDataProvider, err := newExchangeRatesDataProvider(config, config.ExchangeRatesFallbackDataSources[i])
+
+		if err != nil {
+			return err
+		}
+
+		dataProviders = append(dataProviders, fallbackDataProvider)
+	}
+
+	Container.current = newFallbackExchangeRatesDataProvider(config, dataProviders)
+
+	return nil
 }
 
 // GetLatestExchangeRates returns the latest exchange rates data from the current exchange rates data source
@@ -83,3 +65,169 @@ func (e *ExchangeRatesDataProviderContainer) GetLatestExchangeRates(c core.Conte
 
 	return e.current.GetLatestExchangeRates(c, uid, currentConfig)
 }
+
+// IsExchangeRatesSnapshotSupported returns whether the current exchange rates data source supports saving exchange rates snapshots
+func (e *ExchangeRatesDataProviderContainer) IsExchangeRatesSnapshotSupported() bool {
+	// user custom exchange rates are different for each user, so there is no global snapshot for them
+	return e.current != nil && e.currentDataSource != settings.UserCustomExchangeRatesDataSource
+}
+
+// SaveLatestExchangeRatesSnapshot saves the latest exchange rates data from the current exchange rates data source as the snapshot of the update date
+func (e *ExchangeRatesDataProviderContainer) SaveLatestExchangeRatesSnapshot(c core.Context, currentConfig *settings.Config) error {
+	if !e.IsExchangeRatesSnapshotSupported() {
+		return errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	exchangeRates, err := e.current.GetLatestExchangeRates(c, 0, currentConfig)
+
+	if err != nil {
+		return err
+	}
+
+	if exchangeRates.IsStale {
+		return errs.ErrFailedToRequestRemoteApi
+	}
+
+	return e.saveExchangeRatesSnapshot(c, exchangeRates)
+}
+
+// BackfillHistoricalExchangeRatesSnapshots saves the historical exchange rates data between the start time and the end time from the current exchange rates data source as snapshots, and returns the count of saved dates
+func (e *ExchangeRatesDataProviderContainer) BackfillHistoricalExchangeRatesSnapshots(c core.Context, startTime time.Time, endTime time.Time) (int, error) {
+	if !e.IsExchangeRatesSnapshotSupported() {
+		return 0, errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	if startTime.After(endTime) {
+		return 0, errs.ErrExchangeRateSnapshotDateRangeInvalid
+	}
+
+	historicalDataProvider, ok := e.current.(HistoricalExchangeRatesDataProvider)
+
+	if !ok {
+		return 0, errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	allExchangeRates, err := historicalDataProvider.GetHistoricalExchangeRates(c, startTime, endTime)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(allExchangeRates); i++ {
+		err = e.saveExchangeRatesSnapshot(c, allExchangeRates[i])
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(allExchangeRates), nil
+}
+
+// GetExchangeRatesOnDate returns the exchange rates data effective on the specified numeric date (e.g. 20240131), or the latest exchange rates data if there is no snapshot
+func (e *ExchangeRatesDataProviderContainer) GetExchangeRatesOnDate(c core.Context, uid int64, currentConfig *settings.Config, date int32) (*models.LatestExchangeRateResponse, error) {
+	if e.IsExchangeRatesSnapshotSupported() {
+		exchangeRates, err := e.exchangeRateSnapshots.GetExchangeRatesOnDate(c, e.currentDataSource, date)
+
+		if err == nil {
+			return exchangeRates, nil
+		} else if err != errs.ErrExchangeRateSnapshotNotFound {
+			log.Errorf(c, "[exchange_rates_data_provider_container.GetExchangeRatesOnDate] failed to get exchange rates snapshot on \"%d\", because %s", date, err.Error())
+			return nil, errs.Or(err, errs.ErrOperationFailed)
+		}
+	}
+
+	return e.GetLatestExchangeRates(c, uid, currentConfig)
+}
+
+// GetHistoricalExchangeRates returns the historical exchange rates data effective between the start unix time and the end unix time
+func (e *ExchangeRatesDataProviderContainer) GetHistoricalExchangeRates(c core.Context, uid int64, currentConfig *settings.Config, startUnixTime int64, endUnixTime int64) (*models.HistoricalExchangeRates, error) {
+	latestExchangeRates, err := e.GetLatestExchangeRates(c, uid, currentConfig)
+
+	if err != nil {
+		log.Warnf(c, "[exchange_rates_data_provider_container.GetHistoricalExchangeRates] failed to get latest exchange rates for user \"uid:%d\", because %s", uid, err.Error())
+		latestExchangeRates = nil
+	}
+
+	if !e.IsExchangeRatesSnapshotSupported() {
+		return models.NewHistoricalExchangeRates(e.currentDataSource, nil, latestExchangeRates), nil
+	}
+
+	var startDate, endDate int32
+
+	if startUnixTime > 0 {
+		startDate = models.GetExchangeRateSnapshotDate(startUnixTime)
+	}
+
+	if endUnixTime > 0 {
+		endDate = models.GetExchangeRateSnapshotDate(endUnixTime)
+	}
+
+	snapshots, err := e.exchangeRateSnapshots.GetExchangeRateSnapshotsByDateRange(c, e.currentDataSource, startDate, endDate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewHistoricalExchangeRates(e.currentDataSource, snapshots, latestExchangeRates), nil
+}
+
+func (e *ExchangeRatesDataProviderContainer) saveExchangeRatesSnapshot(c core.Context, exchangeRates *models.LatestExchangeRateResponse) error {
+	snapshots := models.CreateExchangeRateSnapshots(e.currentDataSource, exchangeRates)
+
+	if len(snapshots) < 1 {
+		return nil
+	}
+
+	return e.exchangeRateSnapshots.SaveExchangeRateSnapshots(c, e.currentDataSource, snapshots[0].SnapshotDate, snapshots)
+}
+
+func newExchangeRatesDataProvider(config *settings.Config, dataSource string) (ExchangeRatesDataProvider, error) {
+	if dataSource == settings.ReserveBankOfAustraliaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &ReserveBankOfAustraliaDataSource{}), nil
+	} else if dataSource == settings.BankOfCanadaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &BankOfCanadaDataSource{}), nil
+	} else if dataSource == settings.PeoplesBankOfChinaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &PeoplesBankOfChinaDataSource{}), nil
+	} else if dataSource == settings.CzechNationalBankDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &CzechNationalBankDataSource{}), nil
+	} else if dataSource == settings.DanmarksNationalbankDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &DanmarksNationalbankDataSource{}), nil
+	} else if dataSource == settings.EuroCentralBankDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &EuroCentralBankDataSource{}), nil
+	} else if dataSource == settings.NationalBankOfGeorgiaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfGeorgiaDataSource{}), nil
+	} else if dataSource == settings.CentralBankOfHungaryDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfHungaryDataSource{}), nil
+	} else if dataSource == settings.BankOfIsraelDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &BankOfIsraelDataSource{}), nil
+	} else if dataSource == settings.BankOfKoreaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &BankOfKoreaDataSource{apiKey: config.ExchangeRatesBankOfKoreaApiKey}), nil
+	} else if dataSource == settings.CentralBankOfMyanmarDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfMyanmarDataSource{}), nil
+	} else if dataSource == settings.NorgesBankDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &NorgesBankDataSource{}), nil
+	} else if dataSource == settings.NationalBankOfPolandDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfPolandDataSource{}), nil
+	} else if dataSource == settings.NationalBankOfRomaniaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfRomaniaDataSource{}), nil
+	} else if dataSource == settings.BankOfRussiaDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &BankOfRussiaDataSource{}), nil
+	} else if dataSource == settings.SwissNationalBankDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &SwissNationalBankDataSource{}), nil
+	} else if dataSource == settings.BankOfThailandDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &BankOfThailandDataSource{apiKey: config.ExchangeRatesBankOfThailandApiKey}), nil
+	} else if dataSource == settings.CentralBankOfTurkiyeDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfTurkiyeDataSource{}), nil
+	} else if dataSource == settings.NationalBankOfUkraineDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &NationalBankOfUkraineDataSource{}), nil
+	} else if dataSource == settings.BankOfEnglandDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &BankOfEnglandDataSource{}), nil
+	} else if dataSource == settings.CentralBankOfUzbekistanDataSource {
+		return newCommonHttpExchangeRatesDataProvider(config, &CentralBankOfUzbekistanDataSource{}), nil
+	} else if dataSource == settings.UserCustomExchangeRatesDataSource {
+		return newUserCustomExchangeRatesDataProvider(), nil
+	}
+
+	return nil, errs.ErrInvalidExchangeRatesDataSource
+}
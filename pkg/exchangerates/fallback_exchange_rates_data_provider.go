@@ -0,0 +1,203 @@
+package exchangerates
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// FallbackExchangeRatesDataProvider defines the structure of exchange rates data provider which requests an ordered chain of data providers and caches the last successful response
+type FallbackExchangeRatesDataProvider struct {
+	ExchangeRatesDataProvider
+	dataProviders              []ExchangeRatesDataProvider
+	mergeFallbackCurrencies    bool
+	cacheExpiration            int64
+	divergenceWarningThreshold float64
+	cacheMutex                 sync.Mutex
+	cachedExchangeRates        *models.LatestExchangeRateResponse
+}
+
+// GetLatestExchangeRates returns the latest exchange rates data from the cache, or from the first available data provider merged with the other data providers, or the stale cached data if all data providers fail
+func (e *FallbackExchangeRatesDataProvider) GetLatestExchangeRates(c core.Context, uid int64, currentConfig *settings.Config) (*models.LatestExchangeRateResponse, error) {
+	now := time.Now().Unix()
+	cachedExchangeRates := e.getCachedExchangeRates()
+
+	if cachedExchangeRates != nil && e.cacheExpiration > 0 && now-cachedExchangeRates.FetchTime < e.cacheExpiration {
+		return cachedExchangeRates, nil
+	}
+
+	exchangeRates, err := e.getLatestExchangeRatesFromDataProviders(c, uid, currentConfig)
+
+	if err != nil {
+		if cachedExchangeRates == nil {
+			return nil, err
+		}
+
+		log.Warnf(c, "[fallback_exchange_rates_data_provider.GetLatestExchangeRates] all data sources failed, return stale exchange rates data fetched at %d", cachedExchangeRates.FetchTime)
+
+		staleExchangeRates := *cachedExchangeRates
+		staleExchangeRates.IsStale = true
+
+		return &staleExchangeRates, nil
+	}
+
+	exchangeRates.FetchTime = now
+	e.setCachedExchangeRates(exchangeRates)
+
+	return exchangeRates, nil
+}
+
+// GetHistoricalExchangeRates returns the historical exchange rates of each day between the start time and the end time from the primary data provider
+func (e *FallbackExchangeRatesDataProvider) GetHistoricalExchangeRates(c core.Context, startTime time.Time, endTime time.Time) ([]*models.LatestExchangeRateResponse, error) {
+	historicalDataProvider, ok := e.dataProviders[0].(HistoricalExchangeRatesDataProvider)
+
+	if !ok {
+		return nil, errs.ErrHistoricalExchangeRatesNotSupported
+	}
+
+	return historicalDataProvider.GetHistoricalExchangeRates(c, startTime, endTime)
+}
+
+func (e *FallbackExchangeRatesDataProvider) getLatestExchangeRatesFromDataProviders(c core.Context, uid int64, currentConfig *settings.Config) (*models.LatestExchangeRateResponse, error) {
+	requestAllDataProviders := e.mergeFallbackCurrencies || e.divergenceWarningThreshold > 0
+	var finalExchangeRates *models.LatestExchangeRateResponse
+	var lastErr error
+
+	for i := 0; i < len(e.dataProviders); i++ {
+		if finalExchangeRates != nil && !requestAllDataProviders {
+			break
+		}
+
+		exchangeRates, err := e.dataProviders[i].GetLatestExchangeRates(c, uid, currentConfig)
+
+		if err != nil {
+			log.Warnf(c, "[fallback_exchange_rates_data_provider.getLatestExchangeRatesFromDataProviders] failed to get latest exchange rates from data source#%d, because %s", i, err.Error())
+			lastErr = err
+			continue
+		}
+
+		if finalExchangeRates == nil {
+			if i > 0 {
+				log.Warnf(c, "[fallback_exchange_rates_data_provider.getLatestExchangeRatesFromDataProviders] use fallback data source \"%s\" as the primary data source is unavailable", exchangeRates.DataSource)
+			}
+
+			finalExchangeRates = &models.LatestExchangeRateResponse{
+				DataSource:    exchangeRates.DataSource,
+				ReferenceUrl:  exchangeRates.ReferenceUrl,
+				UpdateTime:    exchangeRates.UpdateTime,
+				BaseCurrency:  exchangeRates.BaseCurrency,
+				ExchangeRates: append(models.LatestExchangeRateSlice{}, exchangeRates.ExchangeRates...),
+			}
+
+			continue
+		}
+
+		e.mergeExchangeRates(c, finalExchangeRates, exchangeRates)
+	}
+
+	if finalExchangeRates == nil {
+		return nil, errs.Or(lastErr, errs.ErrFailedToRequestRemoteApi)
+	}
+
+	sort.Sort(finalExchangeRates.ExchangeRates)
+
+	return finalExchangeRates, nil
+}
+
+func (e *FallbackExchangeRatesDataProvider) mergeExchangeRates(c core.Context, finalExchangeRates *models.LatestExchangeRateResponse, exchangeRates *models.LatestExchangeRateResponse) {
+	baseCurrencyRate, exists := exchangeRates.GetExchangeRate(finalExchangeRates.BaseCurrency)
+
+	if !exists || baseCurrencyRate <= 0 {
+		log.Warnf(c, "[fallback_exchange_rates_data_provider.mergeExchangeRates] cannot merge exchange rates from data source \"%s\", because it does not contain base currency \"%s\"", exchangeRates.DataSource, finalExchangeRates.BaseCurrency)
+		return
+	}
+
+	existedExchangeRates := make(map[string]*models.LatestExchangeRate, len(finalExchangeRates.ExchangeRates))
+
+	for i := 0; i < len(finalExchangeRates.ExchangeRates); i++ {
+		existedExchangeRates[finalExchangeRates.ExchangeRates[i].Currency] = finalExchangeRates.ExchangeRates[i]
+	}
+
+	merged := false
+
+	for i := 0; i < len(exchangeRates.ExchangeRates); i++ {
+		exchangeRate := exchangeRates.ExchangeRates[i]
+		rate, err := utils.StringToFloat64(exchangeRate.Rate)
+
+		if err != nil || rate <= 0 || exchangeRate.Currency == finalExchangeRates.BaseCurrency {
+			continue
+		}
+
+		rebasedRate := rate / baseCurrencyRate
+		existedExchangeRate, exists := existedExchangeRates[exchangeRate.Currency]
+
+		if !exists {
+			if e.mergeFallbackCurrencies {
+				finalExchangeRates.ExchangeRates = append(finalExchangeRates.ExchangeRates, &models.LatestExchangeRate{
+					Currency: exchangeRate.Currency,
+					Rate:     utils.Float64ToString(rebasedRate),
+				})
+				existedExchangeRates[exchangeRate.Currency] = finalExchangeRates.ExchangeRates[len(finalExchangeRates.ExchangeRates)-1]
+				merged = true
+			}
+
+			continue
+		}
+
+		if e.divergenceWarningThreshold <= 0 {
+			continue
+		}
+
+		existedRate, err := utils.StringToFloat64(existedExchangeRate.Rate)
+
+		if err != nil || existedRate <= 0 {
+			continue
+		}
+
+		if math.Abs(rebasedRate-existedRate)/existedRate*100 > e.divergenceWarningThreshold {
+			log.Warnf(c, "[fallback_exchange_rates_data_provider.mergeExchangeRates] exchange rate of \"%s\" is %s in data source \"%s\", but is %s in data source \"%s\"", exchangeRate.Currency, existedExchangeRate.Rate, finalExchangeRates.DataSource, utils.Float64ToString(rebasedRate), exchangeRates.DataSource)
+
+			finalExchangeRates.DivergentExchangeRates = append(finalExchangeRates.DivergentExchangeRates, &models.LatestExchangeRateDivergence{
+				Currency:            exchangeRate.Currency,
+				Rate:                existedExchangeRate.Rate,
+				ReferenceDataSource: exchangeRates.DataSource,
+				ReferenceRate:       utils.Float64ToString(rebasedRate),
+			})
+		}
+	}
+
+	if merged {
+		finalExchangeRates.MergedDataSources = append(finalExchangeRates.MergedDataSources, exchangeRates.DataSource)
+	}
+}
+
+func (e *FallbackExchangeRatesDataProvider) getCachedExchangeRates() *models.LatestExchangeRateResponse {
+	e.cacheMutex.Lock()
+	defer e.cacheMutex.Unlock()
+
+	return e.cachedExchangeRates
+}
+
+func (e *FallbackExchangeRatesDataProvider) setCachedExchangeRates(exchangeRates *models.LatestExchangeRateResponse) {
+	e.cacheMutex.Lock()
+	defer e.cacheMutex.Unlock()
+
+	e.cachedExchangeRates = exchangeRates
+}
+
+func newFallbackExchangeRatesDataProvider(config *settings.Config, dataProviders []ExchangeRatesDataProvider) *FallbackExchangeRatesDataProvider {
+	return &FallbackExchangeRatesDataProvider{
+		dataProviders:              dataProviders,
+		mergeFallbackCurrencies:    config.ExchangeRatesMergeFallbackCurrencies,
+		cacheExpiration:            int64(config.ExchangeRatesCacheExpiration),
+		divergenceWarningThreshold: config.ExchangeRatesDivergenceWarningThreshold,
+	}
+}
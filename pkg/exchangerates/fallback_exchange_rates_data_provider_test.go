@@ -0,0 +1,217 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+type testExchangeRatesDataProvider struct {
+	exchangeRates *models.LatestExchangeRateResponse
+	err           error
+	requestCount  int
+}
+
+func (p *testExchangeRatesDataProvider) GetLatestExchangeRates(c core.Context, uid int64, currentConfig *settings.Config) (*models.LatestExchangeRateResponse, error) {
+	p.requestCount++
+	return p.exchangeRates, p.err
+}
+
+func newTestEuroExchangeRatesDataProvider() *testExchangeRatesDataProvider {
+	return &testExchangeRatesDataProvider{
+		exchangeRates: &models.LatestExchangeRateResponse{
+			DataSource:   "Primary",
+			UpdateTime:   1731974400,
+			BaseCurrency: "EUR",
+			ExchangeRates: models.LatestExchangeRateSlice{
+				{Currency: "EUR", Rate: "1"},
+				{Currency: "USD", Rate: "1.05"},
+				{Currency: "CAD", Rate: "1.5"},
+			},
+		},
+	}
+}
+
+func newTestCanadianDollarExchangeRatesDataProvider() *testExchangeRatesDataProvider {
+	return &testExchangeRatesDataProvider{
+		exchangeRates: &models.LatestExchangeRateResponse{
+			DataSource:   "Fallback",
+			UpdateTime:   1731974400,
+			BaseCurrency: "CAD",
+			ExchangeRates: models.LatestExchangeRateSlice{
+				{Currency: "CAD", Rate: "1"},
+				{Currency: "EUR", Rate: "0.5"},
+				{Currency: "USD", Rate: "0.6"},
+				{Currency: "JPY", Rate: "100"},
+			},
+		},
+	}
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_PrimaryDataSourceAvailable(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	fallback := newTestCanadianDollarExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{}, []ExchangeRatesDataProvider{primary, fallback})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Primary", exchangeRates.DataSource)
+	assert.Equal(t, "EUR", exchangeRates.BaseCurrency)
+	assert.Equal(t, 3, len(exchangeRates.ExchangeRates))
+	assert.Equal(t, 0, len(exchangeRates.MergedDataSources))
+	assert.False(t, exchangeRates.IsStale)
+	assert.Equal(t, 0, fallback.requestCount)
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_PrimaryDataSourceUnavailable(t *testing.T) {
+	primary := &testExchangeRatesDataProvider{err: errs.ErrFailedToRequestRemoteApi}
+	fallback := newTestCanadianDollarExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{}, []ExchangeRatesDataProvider{primary, fallback})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Fallback", exchangeRates.DataSource)
+	assert.Equal(t, "CAD", exchangeRates.BaseCurrency)
+	assert.Equal(t, 4, len(exchangeRates.ExchangeRates))
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_AllDataSourcesUnavailable(t *testing.T) {
+	primary := &testExchangeRatesDataProvider{err: errs.ErrFailedToRequestRemoteApi}
+	fallback := &testExchangeRatesDataProvider{err: errs.ErrFailedToRequestRemoteApi}
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{}, []ExchangeRatesDataProvider{primary, fallback})
+
+	_, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.EqualError(t, err, errs.ErrFailedToRequestRemoteApi.Message)
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_MergeFallbackCurrencies(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	fallback := newTestCanadianDollarExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{
+		ExchangeRatesMergeFallbackCurrencies: true,
+	}, []ExchangeRatesDataProvider{primary, fallback})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Primary", exchangeRates.DataSource)
+	assert.Equal(t, "EUR", exchangeRates.BaseCurrency)
+	assert.Equal(t, []string{"Fallback"}, exchangeRates.MergedDataSources)
+	assert.Equal(t, 0, len(exchangeRates.DivergentExchangeRates))
+
+	checkExchangeRatesHaveSpecifiedCurrencies(t, "EUR", []string{"CAD", "JPY", "USD"}, exchangeRates.ExchangeRates)
+
+	actualRate, exists := exchangeRates.GetExchangeRate("JPY")
+	assert.True(t, exists)
+	assert.Equal(t, float64(200), actualRate)
+
+	actualRate, exists = exchangeRates.GetExchangeRate("USD")
+	assert.True(t, exists)
+	assert.Equal(t, 1.05, actualRate)
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_MergeFallbackCurrenciesWithoutBaseCurrency(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	fallback := &testExchangeRatesDataProvider{
+		exchangeRates: &models.LatestExchangeRateResponse{
+			DataSource:   "Fallback",
+			BaseCurrency: "CZK",
+			ExchangeRates: models.LatestExchangeRateSlice{
+				{Currency: "JPY", Rate: "6.5"},
+			},
+		},
+	}
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{
+		ExchangeRatesMergeFallbackCurrencies: true,
+	}, []ExchangeRatesDataProvider{primary, fallback})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(exchangeRates.ExchangeRates))
+	assert.Equal(t, 0, len(exchangeRates.MergedDataSources))
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_DivergenceWarning(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	fallback := newTestCanadianDollarExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{
+		ExchangeRatesDivergenceWarningThreshold: 5,
+	}, []ExchangeRatesDataProvider{primary, fallback})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(exchangeRates.ExchangeRates))
+	assert.Equal(t, 0, len(exchangeRates.MergedDataSources))
+	assert.Equal(t, 2, len(exchangeRates.DivergentExchangeRates))
+
+	assert.Equal(t, "CAD", exchangeRates.DivergentExchangeRates[0].Currency)
+	assert.Equal(t, "1.5", exchangeRates.DivergentExchangeRates[0].Rate)
+	assert.Equal(t, "Fallback", exchangeRates.DivergentExchangeRates[0].ReferenceDataSource)
+	assert.Equal(t, "2", exchangeRates.DivergentExchangeRates[0].ReferenceRate)
+
+	assert.Equal(t, "USD", exchangeRates.DivergentExchangeRates[1].Currency)
+	assert.Equal(t, "1.05", exchangeRates.DivergentExchangeRates[1].Rate)
+	assert.Equal(t, "Fallback", exchangeRates.DivergentExchangeRates[1].ReferenceDataSource)
+	assert.Equal(t, "1.2", exchangeRates.DivergentExchangeRates[1].ReferenceRate)
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_DivergenceWithinThreshold(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	fallback := newTestCanadianDollarExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{
+		ExchangeRatesDivergenceWarningThreshold: 50,
+	}, []ExchangeRatesDataProvider{primary, fallback})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(exchangeRates.DivergentExchangeRates))
+	assert.Equal(t, 1, fallback.requestCount)
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_CachedExchangeRates(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{
+		ExchangeRatesCacheExpiration: 3600,
+	}, []ExchangeRatesDataProvider{primary})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.NotEqual(t, int64(0), exchangeRates.FetchTime)
+
+	exchangeRates, err = provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Primary", exchangeRates.DataSource)
+	assert.False(t, exchangeRates.IsStale)
+	assert.Equal(t, 1, primary.requestCount)
+
+	provider.cachedExchangeRates.FetchTime -= 3600
+
+	_, err = provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, primary.requestCount)
+}
+
+func TestFallbackExchangeRatesDataProviderGetLatestExchangeRates_StaleExchangeRates(t *testing.T) {
+	primary := newTestEuroExchangeRatesDataProvider()
+	provider := newFallbackExchangeRatesDataProvider(&settings.Config{}, []ExchangeRatesDataProvider{primary})
+
+	exchangeRates, err := provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.False(t, exchangeRates.IsStale)
+
+	fetchTime := exchangeRates.FetchTime
+	primary.exchangeRates = nil
+	primary.err = errs.ErrFailedToRequestRemoteApi
+
+	exchangeRates, err = provider.GetLatestExchangeRates(core.NewNullContext(), 0, &settings.Config{})
+	assert.Nil(t, err)
+	assert.True(t, exchangeRates.IsStale)
+	assert.Equal(t, fetchTime, exchangeRates.FetchTime)
+	assert.Equal(t, "Primary", exchangeRates.DataSource)
+	assert.Equal(t, 3, len(exchangeRates.ExchangeRates))
+	assert.False(t, provider.cachedExchangeRates.IsStale)
+}
@@ -0,0 +1,205 @@
+package exchangerates
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/validators"
+)
+
+const peoplesBankOfChinaExchangeRateUrl = "https://www.chinamoney.com.cn/r/cms/www/chinamoney/data/fx/ccpr.json"
+const peoplesBankOfChinaExchangeRateReferenceUrl = "https://www.chinamoney.com.cn/english/bmkcpr/"
+const peoplesBankOfChinaDataSource = "中国人民银行"
+const peoplesBankOfChinaBaseCurrency = "CNY"
+
+const peoplesBankOfChinaUpdateDateFormat = "2006-01-02 15:04"
+const peoplesBankOfChinaUpdateDateTimezone = "Asia/Shanghai"
+
+// PeoplesBankOfChinaDataSource defines the structure of exchange rates data source of the People's Bank of China
+type PeoplesBankOfChinaDataSource struct {
+	HttpExchangeRatesDataSource
+}
+
+// PeoplesBankOfChinaExchangeRateData represents the whole data of central parity rates of RMB authorized by the People's Bank of China
+type PeoplesBankOfChinaExchangeRateData struct {
+	Data          *PeoplesBankOfChinaExchangeRateDataInfo `json:"data"`
+	ExchangeRates []*PeoplesBankOfChinaExchangeRate       `json:"records"`
+}
+
+// PeoplesBankOfChinaExchangeRateDataInfo represents the data info of central parity rates of RMB authorized by the People's Bank of China
+type PeoplesBankOfChinaExchangeRateDataInfo struct {
+	LastDate string `json:"lastDate"`
+}
+
+// PeoplesBankOfChinaExchangeRate represents the central parity rate of a currency pair authorized by the People's Bank of China
+type PeoplesBankOfChinaExchangeRate struct {
+	CurrencyPair string `json:"vrtEName"`
+	Rate         string `json:"price"`
+}
+
+// ToLatestExchangeRateResponse returns a view-object according to original data from the People's Bank of China
+func (e *PeoplesBankOfChinaExchangeRateData) ToLatestExchangeRateResponse(c core.Context) *models.LatestExchangeRateResponse {
+	if len(e.ExchangeRates) < 1 {
+		log.Errorf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRateResponse] all exchange rates is empty")
+		return nil
+	}
+
+	if e.Data == nil {
+		log.Errorf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRateResponse] data info is null")
+		return nil
+	}
+
+	timezone, err := time.LoadLocation(peoplesBankOfChinaUpdateDateTimezone)
+
+	if err != nil {
+		log.Errorf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRateResponse] failed to get timezone, timezone name is %s", peoplesBankOfChinaUpdateDateTimezone)
+		return nil
+	}
+
+	updateTime, err := time.ParseInLocation(peoplesBankOfChinaUpdateDateFormat, e.Data.LastDate, timezone)
+
+	if err != nil {
+		log.Errorf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRateResponse] failed to parse update date, datetime is %s", e.Data.LastDate)
+		return nil
+	}
+
+	exchangeRates := make(models.LatestExchangeRateSlice, 0, len(e.ExchangeRates))
+
+	for i := 0; i < len(e.ExchangeRates); i++ {
+		finalExchangeRate := e.ExchangeRates[i].ToLatestExchangeRate(c)
+
+		if finalExchangeRate == nil {
+			continue
+		}
+
+		exchangeRates = append(exchangeRates, finalExchangeRate)
+	}
+
+	latestExchangeRateResp := &models.LatestExchangeRateResponse{
+		DataSource:    peoplesBankOfChinaDataSource,
+		ReferenceUrl:  peoplesBankOfChinaExchangeRateReferenceUrl,
+		UpdateTime:    updateTime.Unix(),
+		BaseCurrency:  peoplesBankOfChinaBaseCurrency,
+		ExchangeRates: exchangeRates,
+	}
+
+	return latestExchangeRateResp
+}
+
+// ToLatestExchangeRate returns a data pair according to original data from the People's Bank of China
+func (e *PeoplesBankOfChinaExchangeRate) ToLatestExchangeRate(c core.Context) *models.LatestExchangeRate {
+	currencies := strings.Split(e.CurrencyPair, "/")
+
+	if len(currencies) != 2 {
+		log.Warnf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRate] currency pair is invalid, currency pair is %s", e.CurrencyPair)
+		return nil
+	}
+
+	baseCurrency, baseUnit := e.parseCurrencyAndUnit(currencies[0])
+	quoteCurrency, quoteUnit := e.parseCurrencyAndUnit(currencies[1])
+
+	if baseUnit <= 0 || quoteUnit <= 0 {
+		log.Warnf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRate] unit is invalid, currency pair is %s", e.CurrencyPair)
+		return nil
+	}
+
+	rate, err := utils.StringToFloat64(e.Rate)
+
+	if err != nil {
+		log.Warnf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRate] failed to parse rate, currency pair is %s, rate is %s", e.CurrencyPair, e.Rate)
+		return nil
+	}
+
+	if rate <= 0 {
+		log.Warnf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRate] rate is invalid, currency pair is %s, rate is %s", e.CurrencyPair, e.Rate)
+		return nil
+	}
+
+	var currency string
+	var finalRate float64
+
+	// the rate means the amount of quote currency which equals to the base currency in specified units (e.g. 100JPY/CNY)
+	if quoteCurrency == peoplesBankOfChinaBaseCurrency {
+		currency = baseCurrency
+		finalRate = baseUnit / (rate * quoteUnit)
+	} else if baseCurrency == peoplesBankOfChinaBaseCurrency {
+		currency = quoteCurrency
+		finalRate = rate * quoteUnit / baseUnit
+	} else {
+		log.Warnf(c, "[peoples_bank_of_china_datasource.ToLatestExchangeRate] currency pair does not contain base currency, currency pair is %s", e.CurrencyPair)
+		return nil
+	}
+
+	if _, exists := validators.AllCurrencyNames[currency]; !exists {
+		return nil
+	}
+
+	if math.IsInf(finalRate, 0) {
+		return nil
+	}
+
+	return &models.LatestExchangeRate{
+		Currency: currency,
+		Rate:     utils.Float64ToString(finalRate),
+	}
+}
+
+func (e *PeoplesBankOfChinaExchangeRate) parseCurrencyAndUnit(value string) (string, float64) {
+	value = strings.TrimSpace(value)
+	unitLength := 0
+
+	for unitLength < len(value) && value[unitLength] >= '0' && value[unitLength] <= '9' {
+		unitLength++
+	}
+
+	if unitLength == 0 {
+		return value, 1
+	}
+
+	unit, err := utils.StringToFloat64(value[0:unitLength])
+
+	if err != nil {
+		return value[unitLength:], 0
+	}
+
+	return value[unitLength:], unit
+}
+
+// BuildRequests returns the People's Bank of China exchange rates http requests
+func (e *PeoplesBankOfChinaDataSource) BuildRequests() ([]*http.Request, error) {
+	req, err := http.NewRequest("GET", peoplesBankOfChinaExchangeRateUrl, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []*http.Request{req}, nil
+}
+
+// Parse returns the common response entity according to the People's Bank of China data source raw response
+func (e *PeoplesBankOfChinaDataSource) Parse(c core.Context, content []byte) (*models.LatestExchangeRateResponse, error) {
+	peoplesBankOfChinaData := &PeoplesBankOfChinaExchangeRateData{}
+	err := json.Unmarshal(content, peoplesBankOfChinaData)
+
+	if err != nil {
+		log.Errorf(c, "[peoples_bank_of_china_datasource.Parse] failed to parse json data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	latestExchangeRateResponse := peoplesBankOfChinaData.ToLatestExchangeRateResponse(c)
+
+	if latestExchangeRateResponse == nil {
+		log.Errorf(c, "[peoples_bank_of_china_datasource.Parse] failed to parse latest exchange rate data, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return latestExchangeRateResponse, nil
+}
@@ -0,0 +1,115 @@
+package exchangerates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+const peoplesBankOfChinaMinimumRequiredContent = "{\n" +
+	"  \"head\": {\"version\": \"2.0\", \"provider\": \"CWAP\", \"rep_code\": \"200\"},\n" +
+	"  \"data\": {\"lastDate\": \"2024-11-15 9:15\"},\n" +
+	"  \"records\": [\n" +
+	"    {\"vrtCode\": \"USD/CNY\", \"price\": \"7.1938\", \"vrtName\": \"美元/人民币\", \"vrtEName\": \"USD/CNY\"},\n" +
+	"    {\"vrtCode\": \"100JPY/CNY\", \"price\": \"4.6247\", \"vrtName\": \"100日元/人民币\", \"vrtEName\": \"100JPY/CNY\"},\n" +
+	"    {\"vrtCode\": \"CNY/MYR\", \"price\": \"0.62049\", \"vrtName\": \"人民币/林吉特\", \"vrtEName\": \"CNY/MYR\"}\n" +
+	"  ]\n" +
+	"}"
+
+func TestPeoplesBankOfChinaDataSource_StandardDataExtractBaseCurrency(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(peoplesBankOfChinaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "CNY", actualLatestExchangeRateResponse.BaseCurrency)
+}
+
+func TestPeoplesBankOfChinaDataSource_StandardDataExtractUpdateTime(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(peoplesBankOfChinaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1731633300), actualLatestExchangeRateResponse.UpdateTime)
+}
+
+func TestPeoplesBankOfChinaDataSource_StandardDataExtractExchangeRates(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte(peoplesBankOfChinaMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 3)
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "USD",
+		Rate:     "0.13900859073090716",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "JPY",
+		Rate:     "21.623024196164078",
+	})
+	assert.Contains(t, actualLatestExchangeRateResponse.ExchangeRates, &models.LatestExchangeRate{
+		Currency: "MYR",
+		Rate:     "0.62049",
+	})
+}
+
+func TestPeoplesBankOfChinaDataSource_BlankContent(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPeoplesBankOfChinaDataSource_EmptyData(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{}"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = dataSource.Parse(context, []byte("{\"data\": {\"lastDate\": \"2024-11-15 9:15\"}, \"records\": []}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPeoplesBankOfChinaDataSource_InvalidUpdateDate(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("{\"records\": [{\"vrtEName\": \"USD/CNY\", \"price\": \"7.1938\"}]}"))
+	assert.NotEqual(t, nil, err)
+
+	_, err = dataSource.Parse(context, []byte("{\"data\": {\"lastDate\": \"15.11.2024\"}, \"records\": [{\"vrtEName\": \"USD/CNY\", \"price\": \"7.1938\"}]}"))
+	assert.NotEqual(t, nil, err)
+}
+
+func TestPeoplesBankOfChinaDataSource_InvalidCurrencyPair(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"data\": {\"lastDate\": \"2024-11-15 9:15\"}, \"records\": ["+
+		"{\"vrtEName\": \"USDCNY\", \"price\": \"7.1938\"},"+
+		"{\"vrtEName\": \"USD/EUR\", \"price\": \"0.95\"},"+
+		"{\"vrtEName\": \"XXX/CNY\", \"price\": \"1\"},"+
+		"{\"vrtEName\": \"0JPY/CNY\", \"price\": \"4.6247\"}"+
+		"]}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
+
+func TestPeoplesBankOfChinaDataSource_InvalidRate(t *testing.T) {
+	dataSource := &PeoplesBankOfChinaDataSource{}
+	context := core.NewNullContext()
+
+	actualLatestExchangeRateResponse, err := dataSource.Parse(context, []byte("{\"data\": {\"lastDate\": \"2024-11-15 9:15\"}, \"records\": ["+
+		"{\"vrtEName\": \"USD/CNY\", \"price\": \"null\"},"+
+		"{\"vrtEName\": \"CNY/MYR\", \"price\": \"0\"}"+
+		"]}"))
+	assert.Equal(t, nil, err)
+	assert.Len(t, actualLatestExchangeRateResponse.ExchangeRates, 0)
+}
@@ -65,6 +65,11 @@ func (h *mcpAddTransactionToolHandler) OutputType() reflect.Type {
 
 // Handle processes the MCP call tool request and returns the response
 func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *MCPCallToolRequest, user *models.User, currentConfig *settings.Config, services MCPAvailableServices) (any, []*MCPTextContent, error) {
+	if c.GetCurrentLedgerOwnerUid() > 0 && !models.LedgerMemberRole(c.GetCurrentLedgerMemberRole()).CanWrite() {
+		log.Warnf(c, "[add_transaction.Handle] user \"uid:%d\" has no permission to add transaction to the ledger of user \"uid:%d\"", c.GetCurrentOperatorUid(), user.Uid)
+		return nil, nil, errs.ErrLedgerPermissionDenied
+	}
+
 	var addTransactionRequest MCPAddTransactionRequest
 
 	if callToolReq.Arguments != nil {
@@ -102,7 +107,6 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 	}
 
 	var destinationAccount *models.Account
-	destinationAccountId := int64(0)
 
 	if addTransactionRequest.Type == transactionTypeTransfer {
 		destinationAccount, exists = accountsMap[addTransactionRequest.DestinationAccountName]
@@ -111,8 +115,6 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 			log.Warnf(c, "[add_transaction.Handle] destination account \"%s\" not found for user \"uid:%d\"", addTransactionRequest.DestinationAccountName, uid)
 			return nil, nil, errs.ErrDestinationAccountNotFound
 		}
-
-		destinationAccountId = destinationAccount.AccountId
 	}
 
 	allCategories, err := services.GetTransactionCategoryService().GetAllCategoriesByUid(c, uid, 0, -1)
@@ -172,7 +174,7 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 		}
 	}
 
-	transaction, err := h.createNewTransactionModel(uid, &addTransactionRequest, transactionCategory.CategoryId, sourceAccount.AccountId, destinationAccountId, c.ClientIP())
+	transaction, err := h.createNewTransactionModel(uid, &addTransactionRequest, transactionCategory.CategoryId, sourceAccount, destinationAccount, c.ClientIP())
 
 	if err != nil {
 		return nil, nil, err
@@ -185,7 +187,13 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 	}
 
 	if !addTransactionRequest.DryRun {
-		err = services.GetTransactionService().CreateTransaction(c, transaction, tagIds, nil, nil)
+		actor := &models.TransactionRevisionActor{
+			OperatorUid: c.GetCurrentOperatorUid(),
+			Source:      models.TRANSACTION_REVISION_SOURCE_MCP,
+			ClientIp:    c.ClientIP(),
+		}
+
+		err = services.GetTransactionService().CreateTransaction(c, transaction, tagIds, nil, nil, nil, nil, actor)
 
 		if err != nil {
 			log.Errorf(c, "[add_transaction.Handle] failed to create transaction \"id:%d\" for user \"uid:%d\", because %s", transaction.TransactionId, uid, err.Error())
@@ -197,7 +205,7 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 		accountIds := []int64{sourceAccount.AccountId}
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			accountIds = append(accountIds, destinationAccountId)
+			accountIds = append(accountIds, destinationAccount.AccountId)
 		}
 
 		newAccounts, err := services.GetAccountService().GetAccountsByAccountIds(c, uid, accountIds)
@@ -238,7 +246,7 @@ func (h *mcpAddTransactionToolHandler) Handle(c *core.WebContext, callToolReq *M
 	}
 }
 
-func (h *mcpAddTransactionToolHandler) createNewTransactionModel(uid int64, addTransactionRequest *MCPAddTransactionRequest, categoryId int64, sourceAccountId int64, destinationAccountId int64, clientIp string) (*models.Transaction, error) {
+func (h *mcpAddTransactionToolHandler) createNewTransactionModel(uid int64, addTransactionRequest *MCPAddTransactionRequest, categoryId int64, sourceAccount *models.Account, destinationAccount *models.Account, clientIp string) (*models.Transaction, error) {
 	var transactionDbType models.TransactionDbType
 
 	if addTransactionRequest.Type == transactionTypeExpense {
@@ -255,7 +263,7 @@ func (h *mcpAddTransactionToolHandler) createNewTransactionModel(uid int64, addT
 		return nil, err
 	}
 
-	amount, err := utils.ParseAmount(addTransactionRequest.Amount)
+	amount, err := utils.ParseCurrencyAmount(addTransactionRequest.Amount, sourceAccount.Currency)
 
 	if err != nil {
 		return nil, err
@@ -267,7 +275,7 @@ func (h *mcpAddTransactionToolHandler) createNewTransactionModel(uid int64, addT
 		CategoryId:        categoryId,
 		TransactionTime:   utils.GetMinTransactionTimeFromUnixTime(transactionTime.Unix()),
 		TimezoneUtcOffset: utils.GetTimezoneOffsetMinutes(transactionTime.Unix(), transactionTime.Location()),
-		AccountId:         sourceAccountId,
+		AccountId:         sourceAccount.AccountId,
 		Amount:            amount,
 		HideAmount:        false,
 		Comment:           addTransactionRequest.Comment,
@@ -275,9 +283,9 @@ func (h *mcpAddTransactionToolHandler) createNewTransactionModel(uid int64, addT
 	}
 
 	if addTransactionRequest.Type == transactionTypeTransfer {
-		transaction.RelatedAccountId = destinationAccountId
+		transaction.RelatedAccountId = destinationAccount.AccountId
 
-		destinationAmount, err := utils.ParseAmount(addTransactionRequest.DestinationAmount)
+		destinationAmount, err := utils.ParseCurrencyAmount(addTransactionRequest.DestinationAmount, destinationAccount.Currency)
 
 		if err != nil {
 			return nil, err
@@ -310,17 +318,17 @@ func (h *mcpAddTransactionToolHandler) createNewMCPAddTransactionResponse(c *cor
 
 	if sourceAccountInfo != nil {
 		if sourceAccountInfo.IsAsset {
-			response.AccountBalance = utils.FormatAmount(sourceAccountInfo.Balance)
+			response.AccountBalance = utils.FormatCurrencyAmount(sourceAccountInfo.Balance, sourceAccountInfo.Currency)
 		} else if sourceAccountInfo.IsLiability {
-			response.AccountBalance = utils.FormatAmount(-sourceAccountInfo.Balance)
+			response.AccountBalance = utils.FormatCurrencyAmount(-sourceAccountInfo.Balance, sourceAccountInfo.Currency)
 		}
 	}
 
 	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && destinationAccountInfo != nil {
 		if destinationAccountInfo.IsAsset {
-			response.DestinationAccountBalance = utils.FormatAmount(destinationAccountInfo.Balance)
+			response.DestinationAccountBalance = utils.FormatCurrencyAmount(destinationAccountInfo.Balance, destinationAccountInfo.Currency)
 		} else if destinationAccountInfo.IsLiability {
-			response.DestinationAccountBalance = utils.FormatAmount(-destinationAccountInfo.Balance)
+			response.DestinationAccountBalance = utils.FormatCurrencyAmount(-destinationAccountInfo.Balance, destinationAccountInfo.Currency)
 		}
 	}
 
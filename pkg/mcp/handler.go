@@ -15,6 +15,7 @@ type MCPAvailableServices interface {
 	GetTransactionCategoryService() *services.TransactionCategoryService
 	GetTransactionTagService() *services.TransactionTagService
 	GetAccountService() *services.AccountService
+	GetSavedSearchService() *services.SavedSearchService
 	GetUserService() *services.UserService
 }
 
@@ -164,10 +164,10 @@ func (h *mcpQueryAllAccountsBalanceToolHandler) createNewMCPAccountBalanceInfo(a
 
 	if accountResp.IsAsset {
 		balanceInfo.Type = "asset"
-		balanceInfo.Balance = utils.FormatAmount(accountResp.Balance)
+		balanceInfo.Balance = utils.FormatCurrencyAmount(accountResp.Balance, accountResp.Currency)
 	} else if accountResp.IsLiability {
 		balanceInfo.Type = "liability"
-		balanceInfo.OutstandingBalance = utils.FormatAmount(-accountResp.Balance)
+		balanceInfo.OutstandingBalance = utils.FormatCurrencyAmount(-accountResp.Balance, accountResp.Currency)
 	}
 
 	return balanceInfo
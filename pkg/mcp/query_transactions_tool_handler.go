@@ -22,6 +22,8 @@ type MCPQueryTransactionsRequest struct {
 	SecondaryCategoryName string `json:"category_name,omitempty" jsonschema_description:"Primary or secondary category name to filter transactions by (optional)"`
 	AccountName           string `json:"account_name,omitempty" jsonschema_description:"Account name to filter transactions by (optional)"`
 	Keyword               string `json:"keyword,omitempty" jsonschema_description:"Keyword to search in transaction description (optional)"`
+	SavedSearchName       string `json:"saved_search_name,omitempty" jsonschema_description:"Name of the saved search whose conditions are used to filter transactions (optional)"`
+	Query                 string `json:"query,omitempty" jsonschema_description:"Search query to filter transactions by, e.g. category:Food amount:>50 tag:trip -tag:work account:\"Visa\" after:2025-01-01 before:2025-02-01 comment:~\"uber\" (optional, supported keys: type, category, account, payee, tag, amount, after, before, comment, prefix a key with - to exclude, other words are searched in transaction description)"`
 	Count                 int32  `json:"count,omitempty" jsonschema:"default=100" jsonschema_description:"Maximum number of results to return (default: 100)"`
 	Page                  int32  `json:"page,omitempty" jsonschema:"default=1" jsonschema_description:"Page number for pagination (default: 1)"`
 	ResponseFields        string `json:"response_fields,omitempty" jsonschema_description:"Comma-separated list of optional fields to include in the response (optional, leave empty for all fields, available fields: time, currency, category_name, account_name, comment)"`
@@ -153,14 +155,47 @@ func (h *mcpQueryTransactionsToolHandler) Handle(c *core.WebContext, callToolReq
 		}
 	}
 
-	totalCount, err := services.GetTransactionService().GetTransactionCount(c, uid, maxTransactionTime, minTransactionTime, transactionType, filterCategoryIds, filterAccountIds, nil, false, "", queryTransactionsRequest.Keyword)
+	searchConditions := &models.TransactionSearchConditions{
+		Type:               transactionType,
+		MaxTransactionTime: maxTransactionTime,
+		MinTransactionTime: minTransactionTime,
+		CategoryIds:        filterCategoryIds,
+		AccountIds:         filterAccountIds,
+		Keyword:            queryTransactionsRequest.Keyword,
+	}
+
+	savedSearchId := int64(0)
+
+	if queryTransactionsRequest.SavedSearchName != "" {
+		savedSearch, err := services.GetSavedSearchService().GetSavedSearchByName(c, uid, queryTransactionsRequest.SavedSearchName)
+
+		if err != nil {
+			log.Warnf(c, "[query_transactions.Handle] get saved search error, because %s", err.Error())
+			return nil, nil, err
+		}
+
+		savedSearchId = savedSearch.SavedSearchId
+	}
+
+	if savedSearchId > 0 || queryTransactionsRequest.Query != "" {
+		querySearchConditions, err := services.GetTransactionService().GetTransactionSearchConditions(c, uid, savedSearchId, queryTransactionsRequest.Query, minTime.Location())
+
+		if err != nil {
+			log.Warnf(c, "[query_transactions.Handle] parse transaction search query error, because %s", err.Error())
+			return nil, nil, err
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
+	totalCount, err := services.GetTransactionService().GetTransactionCount(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword)
 
 	if err != nil {
 		log.Errorf(c, "[transactions.TransactionListHandler] failed to get transaction count for user \"uid:%d\", because %s", uid, err.Error())
 		return nil, nil, err
 	}
 
-	transactions, err := services.GetTransactionService().GetTransactionsByMaxTime(c, uid, maxTransactionTime, minTransactionTime, transactionType, filterCategoryIds, filterAccountIds, nil, false, "", queryTransactionsRequest.Keyword, queryTransactionsRequest.Page, queryTransactionsRequest.Count, false, true)
+	transactions, err := services.GetTransactionService().GetTransactionsByMaxTime(c, uid, searchConditions.MaxTransactionTime, searchConditions.MinTransactionTime, searchConditions.Type, searchConditions.CategoryIds, searchConditions.AccountIds, searchConditions.PayeeIds, searchConditions.TagFilters, searchConditions.NoTags, searchConditions.AmountFilter, searchConditions.Keyword, queryTransactionsRequest.Page, queryTransactionsRequest.Count, false, true)
 	structuredResponse, response, err := h.createNewMCPQueryTransactionsResponse(c, &queryTransactionsRequest, transactions, totalCount, services.GetAccountService().GetAccountMapByList(allAccounts), services.GetTransactionCategoryService().GetCategoryMapByList(allCategories))
 
 	if err != nil {
@@ -188,8 +223,19 @@ func (h *mcpQueryTransactionsToolHandler) createNewMCPQueryTransactionsResponse(
 
 	for i := 0; i < len(transactions); i++ {
 		transaction := transactions[i]
+		sourceCurrency := ""
+		destinationCurrency := ""
+
+		if account, exists := accountsMap[transaction.AccountId]; exists && account != nil {
+			sourceCurrency = account.Currency
+		}
+
+		if account, exists := accountsMap[transaction.RelatedAccountId]; exists && account != nil {
+			destinationCurrency = account.Currency
+		}
+
 		transactionInfo := MCPTransactionInfo{
-			Amount: utils.FormatAmount(transaction.Amount),
+			Amount: utils.FormatCurrencyAmount(transaction.Amount, sourceCurrency),
 		}
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
@@ -201,7 +247,7 @@ func (h *mcpQueryTransactionsToolHandler) createNewMCPQueryTransactionsResponse(
 		}
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			transactionInfo.DestinationAmount = utils.FormatAmount(transaction.RelatedAccountAmount)
+			transactionInfo.DestinationAmount = utils.FormatCurrencyAmount(transaction.RelatedAccountAmount, destinationCurrency)
 		}
 
 		if _, exists := filteredFields["time"]; exists || len(filteredFields) == 0 {
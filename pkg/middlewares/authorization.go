@@ -6,6 +6,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/services"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -146,6 +147,43 @@ func JWTOAuth2CallbackAuthorization(config *settings.Config) core.MiddlewareHand
 	}
 }
 
+// LedgerAuthorization returns a middleware which verifies whether current user has the specified role (or higher) in the shared ledger specified in request
+func LedgerAuthorization(requiredRole models.LedgerMemberRole) core.MiddlewareHandlerFunc {
+	return func(c *core.WebContext) {
+		ledgerId, err := c.GetLedgerId()
+
+		if err != nil {
+			log.Warnf(c, "[authorization.LedgerAuthorization] failed to parse ledger id, because %s", err.Error())
+			utils.PrintJsonErrorResult(c, errs.ErrLedgerIdInvalid)
+			return
+		}
+
+		if ledgerId == 0 {
+			c.Next()
+			return
+		}
+
+		uid := c.GetCurrentOperatorUid()
+		ledger, role, err := services.Ledgers.GetLedgerAndRoleOfUser(c, ledgerId, uid)
+
+		if err != nil {
+			log.Warnf(c, "[authorization.LedgerAuthorization] failed to get ledger \"id:%d\" for user \"uid:%d\", because %s", ledgerId, uid, err.Error())
+			utils.PrintJsonErrorResult(c, errs.Or(err, errs.ErrOperationFailed))
+			return
+		}
+
+		if !role.HasPermissionOf(requiredRole) {
+			log.Warnf(c, "[authorization.LedgerAuthorization] user \"uid:%d\" with role \"%d\" has no permission to request \"%s\" of ledger \"id:%d\" which requires role \"%d\"", uid, role, c.Request.URL.Path, ledgerId, requiredRole)
+			utils.PrintJsonErrorResult(c, errs.ErrLedgerPermissionDenied)
+			return
+		}
+
+		c.SetCurrentLedgerOwnerUid(ledger.OwnerUid)
+		c.SetCurrentLedgerMemberRole(byte(role))
+		c.Next()
+	}
+}
+
 func jwtAuthorization(config *settings.Config, source TokenSourceType) core.MiddlewareHandlerFunc {
 	return func(c *core.WebContext) {
 		claims, tokenContext, err := getTokenClaims(c, source)
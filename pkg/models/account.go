@@ -65,65 +65,76 @@ const (
 )
 
 var defaultCreditCardAccountStatementDate = 0
+var defaultCreditCardAccountGracePeriodDays = 20
+var defaultCreditCardAccountMinimumPaymentRate = 100
 
 // Account represents account data stored in database
 type Account struct {
-	AccountId       int64           `xorm:"PK"`
-	Uid             int64           `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
-	Deleted         bool            `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
-	Category        AccountCategory `xorm:"NOT NULL"`
-	Type            AccountType     `xorm:"NOT NULL"`
-	ParentAccountId int64           `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
-	Name            string          `xorm:"VARCHAR(64) NOT NULL"`
-	DisplayOrder    int32           `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
-	Icon            int64           `xorm:"NOT NULL"`
-	Color           string          `xorm:"VARCHAR(6) NOT NULL"`
-	Currency        string          `xorm:"VARCHAR(3) NOT NULL"`
-	Balance         int64           `xorm:"NOT NULL"`
-	Comment         string          `xorm:"VARCHAR(255) NOT NULL"`
-	Extend          *AccountExtend  `xorm:"BLOB"`
-	Hidden          bool            `xorm:"NOT NULL"`
-	CreatedUnixTime int64
-	UpdatedUnixTime int64
-	DeletedUnixTime int64
+	AccountId           int64           `xorm:"PK"`
+	Uid                 int64           `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
+	Deleted             bool            `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
+	Category            AccountCategory `xorm:"NOT NULL"`
+	Type                AccountType     `xorm:"NOT NULL"`
+	ParentAccountId     int64           `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
+	Name                string          `xorm:"VARCHAR(64) NOT NULL"`
+	DisplayOrder        int32           `xorm:"INDEX(IDX_account_uid_deleted_parent_account_id_order) NOT NULL"`
+	Icon                int64           `xorm:"NOT NULL"`
+	Color               string          `xorm:"VARCHAR(6) NOT NULL"`
+	Currency            string          `xorm:"VARCHAR(3) NOT NULL"`
+	Balance             int64           `xorm:"NOT NULL"`
+	AmountDecimalPlaces int32           `xorm:"NOT NULL DEFAULT 2"`
+	Comment             string          `xorm:"VARCHAR(255) NOT NULL"`
+	Extend              *AccountExtend  `xorm:"BLOB"`
+	Hidden              bool            `xorm:"NOT NULL"`
+	CreatedUnixTime     int64
+	UpdatedUnixTime     int64
+	DeletedUnixTime     int64
 }
 
 // AccountExtend represents account extend data stored in database
 type AccountExtend struct {
-	CreditCardStatementDate *int `json:"creditCardStatementDate"`
+	CreditCardStatementDate      *int   `json:"creditCardStatementDate"`
+	CreditCardGracePeriodDays    *int   `json:"creditCardGracePeriodDays,omitempty"`
+	CreditCardMinimumPaymentRate *int   `json:"creditCardMinimumPaymentRate,omitempty"`
+	TransactionLockTime          *int64 `json:"transactionLockTime,omitempty"`
 }
 
 // AccountCreateRequest represents all parameters of account creation request
 type AccountCreateRequest struct {
-	Name                    string                  `json:"name" binding:"required,notBlank,max=64"`
-	Category                AccountCategory         `json:"category" binding:"required"`
-	Type                    AccountType             `json:"type" binding:"required"`
-	Icon                    int64                   `json:"icon,string" binding:"required,min=1"`
-	Color                   string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
-	Currency                string                  `json:"currency" binding:"required,len=3,validCurrency"`
-	Balance                 int64                   `json:"balance"`
-	BalanceTime             int64                   `json:"balanceTime"`
-	Comment                 string                  `json:"comment" binding:"max=255"`
-	CreditCardStatementDate int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
-	SubAccounts             []*AccountCreateRequest `json:"subAccounts" binding:"omitempty"`
-	ClientSessionId         string                  `json:"clientSessionId"`
+	Name                         string                  `json:"name" binding:"required,notBlank,max=64"`
+	Category                     AccountCategory         `json:"category" binding:"required"`
+	Type                         AccountType             `json:"type" binding:"required"`
+	Icon                         int64                   `json:"icon,string" binding:"required,min=1"`
+	Color                        string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
+	Currency                     string                  `json:"currency" binding:"required,len=3,validCurrency"`
+	Balance                      int64                   `json:"balance"`
+	BalanceTime                  int64                   `json:"balanceTime"`
+	Comment                      string                  `json:"comment" binding:"max=255"`
+	CreditCardStatementDate      int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
+	CreditCardGracePeriodDays    int                     `json:"creditCardGracePeriodDays" binding:"min=0,max=60"`
+	CreditCardMinimumPaymentRate int                     `json:"creditCardMinimumPaymentRate" binding:"min=0,max=100"`
+	SubAccounts                  []*AccountCreateRequest `json:"subAccounts" binding:"omitempty"`
+	ClientSessionId              string                  `json:"clientSessionId"`
 }
 
 // AccountModifyRequest represents all parameters of account modification request
 type AccountModifyRequest struct {
-	Id                      int64                   `json:"id,string" binding:"required,min=0"`
-	Name                    string                  `json:"name" binding:"required,notBlank,max=64"`
-	Category                AccountCategory         `json:"category" binding:"required"`
-	Icon                    int64                   `json:"icon,string" binding:"min=1"`
-	Color                   string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
-	Currency                *string                 `json:"currency" binding:"omitempty,len=3,validCurrency"`
-	Balance                 *int64                  `json:"balance" binding:"omitempty"`
-	BalanceTime             *int64                  `json:"balanceTime" binding:"omitempty"`
-	Comment                 string                  `json:"comment" binding:"max=255"`
-	CreditCardStatementDate int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
-	Hidden                  bool                    `json:"hidden"`
-	SubAccounts             []*AccountModifyRequest `json:"subAccounts" binding:"omitempty"`
-	ClientSessionId         string                  `json:"clientSessionId"`
+	Id                           int64                   `json:"id,string" binding:"required,min=0"`
+	Name                         string                  `json:"name" binding:"required,notBlank,max=64"`
+	Category                     AccountCategory         `json:"category" binding:"required"`
+	Icon                         int64                   `json:"icon,string" binding:"min=1"`
+	Color                        string                  `json:"color" binding:"required,len=6,validHexRGBColor"`
+	Currency                     *string                 `json:"currency" binding:"omitempty,len=3,validCurrency"`
+	Balance                      *int64                  `json:"balance" binding:"omitempty"`
+	BalanceTime                  *int64                  `json:"balanceTime" binding:"omitempty"`
+	Comment                      string                  `json:"comment" binding:"max=255"`
+	CreditCardStatementDate      int                     `json:"creditCardStatementDate" binding:"min=0,max=28"`
+	CreditCardGracePeriodDays    int                     `json:"creditCardGracePeriodDays" binding:"min=0,max=60"`
+	CreditCardMinimumPaymentRate int                     `json:"creditCardMinimumPaymentRate" binding:"min=0,max=100"`
+	TransactionLockTime          *int64                  `json:"transactionLockTime" binding:"omitempty,min=0"`
+	Hidden                       bool                    `json:"hidden"`
+	SubAccounts                  []*AccountModifyRequest `json:"subAccounts" binding:"omitempty"`
+	ClientSessionId              string                  `json:"clientSessionId"`
 }
 
 // AccountListRequest represents all parameters of account listing request
@@ -160,27 +171,32 @@ type AccountDeleteRequest struct {
 
 // AccountInfoResponse represents a view-object of account
 type AccountInfoResponse struct {
-	Id                      int64                    `json:"id,string"`
-	Name                    string                   `json:"name"`
-	ParentId                int64                    `json:"parentId,string"`
-	Category                AccountCategory          `json:"category"`
-	Type                    AccountType              `json:"type"`
-	Icon                    int64                    `json:"icon,string"`
-	Color                   string                   `json:"color"`
-	Currency                string                   `json:"currency"`
-	Balance                 int64                    `json:"balance"`
-	Comment                 string                   `json:"comment"`
-	CreditCardStatementDate *int                     `json:"creditCardStatementDate,omitempty"`
-	DisplayOrder            int32                    `json:"displayOrder"`
-	IsAsset                 bool                     `json:"isAsset,omitempty"`
-	IsLiability             bool                     `json:"isLiability,omitempty"`
-	Hidden                  bool                     `json:"hidden"`
-	SubAccounts             AccountInfoResponseSlice `json:"subAccounts,omitempty"`
+	Id                           int64                    `json:"id,string"`
+	Name                         string                   `json:"name"`
+	ParentId                     int64                    `json:"parentId,string"`
+	Category                     AccountCategory          `json:"category"`
+	Type                         AccountType              `json:"type"`
+	Icon                         int64                    `json:"icon,string"`
+	Color                        string                   `json:"color"`
+	Currency                     string                   `json:"currency"`
+	Balance                      int64                    `json:"balance"`
+	Comment                      string                   `json:"comment"`
+	CreditCardStatementDate      *int                     `json:"creditCardStatementDate,omitempty"`
+	CreditCardGracePeriodDays    *int                     `json:"creditCardGracePeriodDays,omitempty"`
+	CreditCardMinimumPaymentRate *int                     `json:"creditCardMinimumPaymentRate,omitempty"`
+	TransactionLockTime          int64                    `json:"transactionLockTime,omitempty"`
+	DisplayOrder                 int32                    `json:"displayOrder"`
+	IsAsset                      bool                     `json:"isAsset,omitempty"`
+	IsLiability                  bool                     `json:"isLiability,omitempty"`
+	Hidden                       bool                     `json:"hidden"`
+	SubAccounts                  AccountInfoResponseSlice `json:"subAccounts,omitempty"`
 }
 
 // ToAccountInfoResponse returns a view-object according to database model
 func (a *Account) ToAccountInfoResponse() *AccountInfoResponse {
 	var creditCardStatementDate *int
+	var creditCardGracePeriodDays *int
+	var creditCardMinimumPaymentRate *int
 
 	if a.ParentAccountId == LevelOneAccountParentId && a.Category == ACCOUNT_CATEGORY_CREDIT_CARD {
 		if a.Extend != nil {
@@ -188,27 +204,71 @@ func (a *Account) ToAccountInfoResponse() *AccountInfoResponse {
 		} else {
 			creditCardStatementDate = &defaultCreditCardAccountStatementDate
 		}
+
+		gracePeriodDays := a.GetCreditCardGracePeriodDays()
+		minimumPaymentRate := a.GetCreditCardMinimumPaymentRate()
+		creditCardGracePeriodDays = &gracePeriodDays
+		creditCardMinimumPaymentRate = &minimumPaymentRate
 	}
 
 	return &AccountInfoResponse{
-		Id:                      a.AccountId,
-		Name:                    a.Name,
-		ParentId:                a.ParentAccountId,
-		Category:                a.Category,
-		Type:                    a.Type,
-		Icon:                    a.Icon,
-		Color:                   a.Color,
-		Currency:                a.Currency,
-		Balance:                 a.Balance,
-		Comment:                 a.Comment,
-		CreditCardStatementDate: creditCardStatementDate,
-		DisplayOrder:            a.DisplayOrder,
-		IsAsset:                 assetAccountCategory[a.Category],
-		IsLiability:             liabilityAccountCategory[a.Category],
-		Hidden:                  a.Hidden,
+		Id:                           a.AccountId,
+		Name:                         a.Name,
+		ParentId:                     a.ParentAccountId,
+		Category:                     a.Category,
+		Type:                         a.Type,
+		Icon:                         a.Icon,
+		Color:                        a.Color,
+		Currency:                     a.Currency,
+		Balance:                      a.Balance,
+		Comment:                      a.Comment,
+		CreditCardStatementDate:      creditCardStatementDate,
+		CreditCardGracePeriodDays:    creditCardGracePeriodDays,
+		CreditCardMinimumPaymentRate: creditCardMinimumPaymentRate,
+		DisplayOrder:                 a.DisplayOrder,
+		IsAsset:                      assetAccountCategory[a.Category],
+		IsLiability:                  liabilityAccountCategory[a.Category],
+		TransactionLockTime:          a.GetTransactionLockTime(),
+		Hidden:                       a.Hidden,
 	}
 }
 
+// GetCreditCardStatementDate returns the statement date of credit card account, returns 0 if it is not set
+func (a *Account) GetCreditCardStatementDate() int {
+	if a.Extend == nil || a.Extend.CreditCardStatementDate == nil {
+		return defaultCreditCardAccountStatementDate
+	}
+
+	return *a.Extend.CreditCardStatementDate
+}
+
+// GetCreditCardGracePeriodDays returns the days between the statement date and the payment due date of credit card account
+func (a *Account) GetCreditCardGracePeriodDays() int {
+	if a.Extend == nil || a.Extend.CreditCardGracePeriodDays == nil || *a.Extend.CreditCardGracePeriodDays <= 0 {
+		return defaultCreditCardAccountGracePeriodDays
+	}
+
+	return *a.Extend.CreditCardGracePeriodDays
+}
+
+// GetCreditCardMinimumPaymentRate returns the percentage of statement balance which needs to be paid before the due date
+func (a *Account) GetCreditCardMinimumPaymentRate() int {
+	if a.Extend == nil || a.Extend.CreditCardMinimumPaymentRate == nil || *a.Extend.CreditCardMinimumPaymentRate <= 0 {
+		return defaultCreditCardAccountMinimumPaymentRate
+	}
+
+	return *a.Extend.CreditCardMinimumPaymentRate
+}
+
+// GetTransactionLockTime returns the unix time before which (inclusive) the transactions of this account cannot be created, modified or deleted, returns 0 if it is not set
+func (a *Account) GetTransactionLockTime() int64 {
+	if a.Extend == nil || a.Extend.TransactionLockTime == nil || *a.Extend.TransactionLockTime <= 0 {
+		return 0
+	}
+
+	return *a.Extend.TransactionLockTime
+}
+
 // FromDB fills the fields from the data stored in database
 func (a *AccountExtend) FromDB(data []byte) error {
 	return json.Unmarshal(data, a)
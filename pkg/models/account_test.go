@@ -49,3 +49,16 @@ func TestAccountInfoResponseSliceLess(t *testing.T) {
 	assert.Equal(t, int64(5), accountRespSlice[4].Id)
 	assert.Equal(t, int64(3), accountRespSlice[5].Id)
 }
+
+func TestAccountGetTransactionLockTime(t *testing.T) {
+	assert.Equal(t, int64(0), (&Account{}).GetTransactionLockTime())
+	assert.Equal(t, int64(0), (&Account{Extend: &AccountExtend{}}).GetTransactionLockTime())
+
+	transactionLockTime := int64(-1)
+	assert.Equal(t, int64(0), (&Account{Extend: &AccountExtend{TransactionLockTime: &transactionLockTime}}).GetTransactionLockTime())
+
+	transactionLockTime = 1700000000
+	account := &Account{Extend: &AccountExtend{TransactionLockTime: &transactionLockTime}}
+	assert.Equal(t, int64(1700000000), account.GetTransactionLockTime())
+	assert.Equal(t, int64(1700000000), account.ToAccountInfoResponse().TransactionLockTime)
+}
@@ -0,0 +1,276 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// BudgetType represents budget type
+type BudgetType byte
+
+// Budget types
+const (
+	BUDGET_TYPE_TOTAL    BudgetType = 1
+	BUDGET_TYPE_CATEGORY BudgetType = 2
+	BUDGET_TYPE_ACCOUNT  BudgetType = 3
+)
+
+// BudgetPeriodType represents budget period type
+type BudgetPeriodType byte
+
+// Budget period types
+const (
+	BUDGET_PERIOD_TYPE_WEEKLY      BudgetPeriodType = 1
+	BUDGET_PERIOD_TYPE_MONTHLY     BudgetPeriodType = 2
+	BUDGET_PERIOD_TYPE_YEARLY      BudgetPeriodType = 3
+	BUDGET_PERIOD_TYPE_FISCAL_YEAR BudgetPeriodType = 4
+)
+
+// Budget represents budget data stored in database
+type Budget struct {
+	BudgetId            int64            `xorm:"PK"`
+	Uid                 int64            `xorm:"INDEX(IDX_budget_uid_deleted_order) NOT NULL"`
+	Deleted             bool             `xorm:"INDEX(IDX_budget_uid_deleted_order) NOT NULL"`
+	Name                string           `xorm:"VARCHAR(64) NOT NULL"`
+	Type                BudgetType       `xorm:"TINYINT NOT NULL"`
+	PeriodType          BudgetPeriodType `xorm:"TINYINT NOT NULL"`
+	CategoryId          int64            `xorm:"NOT NULL"`
+	AccountId           int64            `xorm:"NOT NULL"`
+	Currency            string           `xorm:"VARCHAR(3) NOT NULL"`
+	Amount              int64            `xorm:"NOT NULL"`
+	AmountDecimalPlaces int32            `xorm:"NOT NULL DEFAULT 2"`
+	Rollover            bool             `xorm:"NOT NULL"`
+	DisplayOrder        int32            `xorm:"INDEX(IDX_budget_uid_deleted_order) NOT NULL"`
+	Hidden              bool             `xorm:"NOT NULL"`
+	Comment             string           `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime     int64
+	UpdatedUnixTime     int64
+	DeletedUnixTime     int64
+}
+
+// BudgetAmountChange represents the budget amount effective since the specified time stored in database
+type BudgetAmountChange struct {
+	ChangeId            int64  `xorm:"PK"`
+	Uid                 int64  `xorm:"INDEX(IDX_budget_amount_change_uid_budget_id_time) NOT NULL"`
+	BudgetId            int64  `xorm:"INDEX(IDX_budget_amount_change_uid_budget_id_time) NOT NULL"`
+	Currency            string `xorm:"VARCHAR(3) NOT NULL"`
+	Amount              int64  `xorm:"NOT NULL"`
+	AmountDecimalPlaces int32  `xorm:"NOT NULL DEFAULT 2"`
+	EffectiveUnixTime   int64  `xorm:"INDEX(IDX_budget_amount_change_uid_budget_id_time) NOT NULL"`
+	CreatedUnixTime     int64
+}
+
+// BudgetCreateRequest represents all parameters of budget creation request
+type BudgetCreateRequest struct {
+	Name            string           `json:"name" binding:"required,notBlank,max=64"`
+	Type            BudgetType       `json:"type" binding:"required"`
+	PeriodType      BudgetPeriodType `json:"periodType" binding:"required"`
+	CategoryId      int64            `json:"categoryId,string" binding:"min=0"`
+	AccountId       int64            `json:"accountId,string" binding:"min=0"`
+	Currency        string           `json:"currency" binding:"required,len=3,validCurrency"`
+	Amount          int64            `json:"amount" binding:"min=0,max=99999999999"`
+	Rollover        bool             `json:"rollover"`
+	Comment         string           `json:"comment" binding:"max=255"`
+	ClientSessionId string           `json:"clientSessionId"`
+}
+
+// BudgetModifyRequest represents all parameters of budget modification request
+type BudgetModifyRequest struct {
+	Id         int64            `json:"id,string" binding:"required,min=1"`
+	Name       string           `json:"name" binding:"required,notBlank,max=64"`
+	Type       BudgetType       `json:"type" binding:"required"`
+	PeriodType BudgetPeriodType `json:"periodType" binding:"required"`
+	CategoryId int64            `json:"categoryId,string" binding:"min=0"`
+	AccountId  int64            `json:"accountId,string" binding:"min=0"`
+	Currency   string           `json:"currency" binding:"required,len=3,validCurrency"`
+	Amount     int64            `json:"amount" binding:"min=0,max=99999999999"`
+	Rollover   bool             `json:"rollover"`
+	Comment    string           `json:"comment" binding:"max=255"`
+	Hidden     bool             `json:"hidden"`
+}
+
+// BudgetGetRequest represents all parameters of budget getting request
+type BudgetGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// BudgetHideRequest represents all parameters of budget hiding request
+type BudgetHideRequest struct {
+	Id     int64 `json:"id,string" binding:"required,min=1"`
+	Hidden bool  `json:"hidden"`
+}
+
+// BudgetMoveRequest represents all parameters of budget moving request
+type BudgetMoveRequest struct {
+	NewDisplayOrders []*BudgetNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// BudgetNewDisplayOrderRequest represents a data pair of id and display order
+type BudgetNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// BudgetDeleteRequest represents all parameters of budget deleting request
+type BudgetDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// BudgetActualRequest represents all parameters of budget-vs-actual request
+type BudgetActualRequest struct {
+	Id   int64 `form:"id,string" binding:"min=0"`
+	Time int64 `form:"time" binding:"min=0"`
+}
+
+// BudgetInfoResponse represents a view-object of budget info
+type BudgetInfoResponse struct {
+	Id           int64            `json:"id,string"`
+	Name         string           `json:"name"`
+	Type         BudgetType       `json:"type"`
+	PeriodType   BudgetPeriodType `json:"periodType"`
+	CategoryId   int64            `json:"categoryId,string"`
+	AccountId    int64            `json:"accountId,string"`
+	Currency     string           `json:"currency"`
+	Amount       int64            `json:"amount"`
+	Rollover     bool             `json:"rollover"`
+	DisplayOrder int32            `json:"displayOrder"`
+	Hidden       bool             `json:"hidden"`
+	Comment      string           `json:"comment"`
+}
+
+// BudgetActualResponse represents a view-object of budget and actual amount in one budget period
+type BudgetActualResponse struct {
+	Id              int64            `json:"id,string"`
+	Name            string           `json:"name"`
+	Type            BudgetType       `json:"type"`
+	PeriodType      BudgetPeriodType `json:"periodType"`
+	CategoryId      int64            `json:"categoryId,string"`
+	AccountId       int64            `json:"accountId,string"`
+	Currency        string           `json:"currency"`
+	StartTime       int64            `json:"startTime"`
+	EndTime         int64            `json:"endTime"`
+	BudgetAmount    int64            `json:"budgetAmount"`
+	RolloverAmount  int64            `json:"rolloverAmount"`
+	AvailableAmount int64            `json:"availableAmount"`
+	ActualAmount    int64            `json:"actualAmount"`
+	RemainingAmount int64            `json:"remainingAmount"`
+	DisplayOrder    int32            `json:"displayOrder"`
+}
+
+// GetPeriodTimeRange returns the start time and the start time of next period of the budget period which contains the specified time
+func (b *Budget) GetPeriodTimeRange(t time.Time, firstDayOfWeek core.WeekDay, fiscalYearStart core.FiscalYearStart) (time.Time, time.Time, error) {
+	dayStartTime := utils.GetStartOfDay(t)
+
+	switch b.PeriodType {
+	case BUDGET_PERIOD_TYPE_WEEKLY:
+		dayOfWeek := int(dayStartTime.Weekday()) - int(firstDayOfWeek)
+
+		if dayOfWeek < 0 {
+			dayOfWeek += 7
+		}
+
+		startTime := dayStartTime.AddDate(0, 0, -dayOfWeek)
+		return startTime, startTime.AddDate(0, 0, 7), nil
+	case BUDGET_PERIOD_TYPE_MONTHLY:
+		startTime := time.Date(dayStartTime.Year(), dayStartTime.Month(), 1, 0, 0, 0, 0, dayStartTime.Location())
+		return startTime, startTime.AddDate(0, 1, 0), nil
+	case BUDGET_PERIOD_TYPE_YEARLY:
+		startTime := time.Date(dayStartTime.Year(), time.January, 1, 0, 0, 0, 0, dayStartTime.Location())
+		return startTime, startTime.AddDate(1, 0, 0), nil
+	case BUDGET_PERIOD_TYPE_FISCAL_YEAR:
+		if fiscalYearStart < core.FISCAL_YEAR_START_MIN || fiscalYearStart > core.FISCAL_YEAR_START_MAX {
+			fiscalYearStart = core.FISCAL_YEAR_START_DEFAULT
+		}
+
+		month, day, err := fiscalYearStart.GetMonthDay()
+
+		if err != nil {
+			return time.Time{}, time.Time{}, errs.ErrBudgetFiscalYearStartInvalid
+		}
+
+		startTime := time.Date(dayStartTime.Year(), time.Month(month), int(day), 0, 0, 0, 0, dayStartTime.Location())
+
+		if dayStartTime.Before(startTime) {
+			startTime = startTime.AddDate(-1, 0, 0)
+		}
+
+		return startTime, startTime.AddDate(1, 0, 0), nil
+	default:
+		return time.Time{}, time.Time{}, errs.ErrBudgetPeriodTypeInvalid
+	}
+}
+
+// GetAmountAndCurrencyInPeriod returns the budget amount and currency which are effective at the end of the budget period, the amount changes must be sorted by effective time in ascending order
+// The current amount and currency are returned if there is no amount change before the end of the period (e.g. the budget is created before amount changes are saved)
+func (b *Budget) GetAmountAndCurrencyInPeriod(amountChanges []*BudgetAmountChange, nextStartTime time.Time) (int64, string) {
+	amount := b.Amount
+	currency := b.Currency
+
+	for i := 0; i < len(amountChanges); i++ {
+		if amountChanges[i].EffectiveUnixTime >= nextStartTime.Unix() {
+			break
+		}
+
+		amount = amountChanges[i].Amount
+		currency = amountChanges[i].Currency
+	}
+
+	return amount, currency
+}
+
+// ToBudgetInfoResponse returns a view-object according to database model
+func (b *Budget) ToBudgetInfoResponse() *BudgetInfoResponse {
+	return &BudgetInfoResponse{
+		Id:           b.BudgetId,
+		Name:         b.Name,
+		Type:         b.Type,
+		PeriodType:   b.PeriodType,
+		CategoryId:   b.CategoryId,
+		AccountId:    b.AccountId,
+		Currency:     b.Currency,
+		Amount:       b.Amount,
+		Rollover:     b.Rollover,
+		DisplayOrder: b.DisplayOrder,
+		Hidden:       b.Hidden,
+		Comment:      b.Comment,
+	}
+}
+
+// BudgetInfoResponseSlice represents the slice data structure of BudgetInfoResponse
+type BudgetInfoResponseSlice []*BudgetInfoResponse
+
+// Len returns the count of items
+func (s BudgetInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s BudgetInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s BudgetInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
+
+// BudgetActualResponseSlice represents the slice data structure of BudgetActualResponse
+type BudgetActualResponseSlice []*BudgetActualResponse
+
+// Len returns the count of items
+func (s BudgetActualResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s BudgetActualResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s BudgetActualResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestBudgetGetPeriodTimeRange_Weekly(t *testing.T) {
+	budget := &Budget{PeriodType: BUDGET_PERIOD_TYPE_WEEKLY}
+	timezone := time.FixedZone("Test Timezone", 8*60*60)
+
+	startTime, nextStartTime, err := budget.GetPeriodTimeRange(time.Date(2024, 5, 15, 13, 30, 0, 0, timezone), core.WEEKDAY_MONDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, timezone).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, timezone).Unix(), nextStartTime.Unix())
+
+	startTime, nextStartTime, err = budget.GetPeriodTimeRange(time.Date(2024, 5, 19, 23, 59, 59, 0, timezone), core.WEEKDAY_MONDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, timezone).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, timezone).Unix(), nextStartTime.Unix())
+
+	startTime, nextStartTime, err = budget.GetPeriodTimeRange(time.Date(2024, 5, 19, 10, 0, 0, 0, timezone), core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 5, 19, 0, 0, 0, 0, timezone).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2024, 5, 26, 0, 0, 0, 0, timezone).Unix(), nextStartTime.Unix())
+}
+
+func TestBudgetGetPeriodTimeRange_Monthly(t *testing.T) {
+	budget := &Budget{PeriodType: BUDGET_PERIOD_TYPE_MONTHLY}
+	timezone := time.FixedZone("Test Timezone", -5*60*60)
+
+	startTime, nextStartTime, err := budget.GetPeriodTimeRange(time.Date(2024, 12, 31, 23, 59, 59, 0, timezone), core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, timezone).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, timezone).Unix(), nextStartTime.Unix())
+}
+
+func TestBudgetGetPeriodTimeRange_Yearly(t *testing.T) {
+	budget := &Budget{PeriodType: BUDGET_PERIOD_TYPE_YEARLY}
+
+	startTime, nextStartTime, err := budget.GetPeriodTimeRange(time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), nextStartTime.Unix())
+}
+
+func TestBudgetGetPeriodTimeRange_FiscalYear(t *testing.T) {
+	budget := &Budget{PeriodType: BUDGET_PERIOD_TYPE_FISCAL_YEAR}
+	fiscalYearStart, _ := core.NewFiscalYearStart(4, 6)
+
+	startTime, nextStartTime, err := budget.GetPeriodTimeRange(time.Date(2024, 4, 5, 12, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, fiscalYearStart)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 4, 6, 0, 0, 0, 0, time.UTC).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC).Unix(), nextStartTime.Unix())
+
+	startTime, nextStartTime, err = budget.GetPeriodTimeRange(time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, fiscalYearStart)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2025, 4, 6, 0, 0, 0, 0, time.UTC).Unix(), nextStartTime.Unix())
+
+	startTime, nextStartTime, err = budget.GetPeriodTimeRange(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), core.WEEKDAY_SUNDAY, core.FiscalYearStart(0))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), startTime.Unix())
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), nextStartTime.Unix())
+}
+
+func TestBudgetGetPeriodTimeRange_InvalidPeriodType(t *testing.T) {
+	budget := &Budget{PeriodType: BudgetPeriodType(0)}
+
+	_, _, err := budget.GetPeriodTimeRange(time.Now(), core.WEEKDAY_SUNDAY, core.FISCAL_YEAR_START_DEFAULT)
+	assert.EqualError(t, err, errs.ErrBudgetPeriodTypeInvalid.Message)
+}
+
+func TestBudgetGetAmountAndCurrencyInPeriod(t *testing.T) {
+	budget := &Budget{Amount: 30000, Currency: "EUR"}
+	timezone := time.FixedZone("Test Timezone", 8*60*60)
+	amountChanges := []*BudgetAmountChange{
+		{Amount: 10000, Currency: "USD", EffectiveUnixTime: time.Date(2024, 3, 15, 0, 0, 0, 0, timezone).Unix()},
+		{Amount: 20000, Currency: "USD", EffectiveUnixTime: time.Date(2024, 5, 1, 0, 0, 0, 0, timezone).Unix()},
+		{Amount: 30000, Currency: "EUR", EffectiveUnixTime: time.Date(2024, 6, 10, 0, 0, 0, 0, timezone).Unix()},
+	}
+
+	amount, currency := budget.GetAmountAndCurrencyInPeriod(amountChanges, time.Date(2024, 3, 1, 0, 0, 0, 0, timezone))
+	assert.Equal(t, int64(30000), amount)
+	assert.Equal(t, "EUR", currency)
+
+	amount, currency = budget.GetAmountAndCurrencyInPeriod(amountChanges, time.Date(2024, 4, 1, 0, 0, 0, 0, timezone))
+	assert.Equal(t, int64(10000), amount)
+	assert.Equal(t, "USD", currency)
+
+	amount, currency = budget.GetAmountAndCurrencyInPeriod(amountChanges, time.Date(2024, 5, 1, 0, 0, 0, 0, timezone))
+	assert.Equal(t, int64(10000), amount)
+	assert.Equal(t, "USD", currency)
+
+	amount, currency = budget.GetAmountAndCurrencyInPeriod(amountChanges, time.Date(2024, 6, 1, 0, 0, 0, 0, timezone))
+	assert.Equal(t, int64(20000), amount)
+	assert.Equal(t, "USD", currency)
+
+	amount, currency = budget.GetAmountAndCurrencyInPeriod(nil, time.Date(2024, 6, 1, 0, 0, 0, 0, timezone))
+	assert.Equal(t, int64(30000), amount)
+	assert.Equal(t, "EUR", currency)
+}
+
+func TestBudgetInfoResponseSliceLess(t *testing.T) {
+	var budgetRespSlice BudgetInfoResponseSlice
+	budgetRespSlice = append(budgetRespSlice, &BudgetInfoResponse{
+		Id:           1,
+		DisplayOrder: 3,
+	})
+	budgetRespSlice = append(budgetRespSlice, &BudgetInfoResponse{
+		Id:           2,
+		DisplayOrder: 1,
+	})
+	budgetRespSlice = append(budgetRespSlice, &BudgetInfoResponse{
+		Id:           3,
+		DisplayOrder: 2,
+	})
+
+	sort.Sort(budgetRespSlice)
+
+	assert.Equal(t, int64(2), budgetRespSlice[0].Id)
+	assert.Equal(t, int64(3), budgetRespSlice[1].Id)
+	assert.Equal(t, int64(1), budgetRespSlice[2].Id)
+}
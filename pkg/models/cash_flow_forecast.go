@@ -0,0 +1,298 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const defaultCashFlowForecastHorizonDays = 30
+const secondsOfOneDay = int64(24 * 60 * 60)
+
+// ScheduledTransactionOccurrence represents a future transaction which will be created by scheduled transaction template
+type ScheduledTransactionOccurrence struct {
+	Template            *TransactionTemplate
+	TransactionUnixTime int64
+}
+
+// ScheduledTransactionUpcomingOccurrenceListRequest represents all parameters of upcoming scheduled transaction occurrence listing request
+type ScheduledTransactionUpcomingOccurrenceListRequest struct {
+	HorizonDays int32 `form:"horizon_days" binding:"min=0,max=366"`
+}
+
+// CashFlowForecastRequest represents all parameters of cash flow forecast request
+type CashFlowForecastRequest struct {
+	HorizonDays int32 `form:"horizon_days" binding:"min=0,max=366"`
+}
+
+// ScheduledTransactionOccurrenceInfoResponse represents a view-object of scheduled transaction occurrence
+type ScheduledTransactionOccurrenceInfoResponse struct {
+	TemplateId           int64           `json:"templateId,string"`
+	Name                 string          `json:"name"`
+	Type                 TransactionType `json:"type"`
+	CategoryId           int64           `json:"categoryId,string"`
+	Time                 int64           `json:"time"`
+	UtcOffset            int16           `json:"utcOffset"`
+	SourceAccountId      int64           `json:"sourceAccountId,string"`
+	DestinationAccountId int64           `json:"destinationAccountId,string,omitempty"`
+	SourceAmount         int64           `json:"sourceAmount"`
+	DestinationAmount    int64           `json:"destinationAmount,omitempty"`
+	HideAmount           bool            `json:"hideAmount"`
+}
+
+// CashFlowForecastDailyBalanceResponse represents a view-object of projected balance at the end of one day
+type CashFlowForecastDailyBalanceResponse struct {
+	Date     string `json:"date"`
+	Balance  int64  `json:"balance"`
+	Negative bool   `json:"negative"`
+}
+
+// CashFlowForecastAccountResponse represents a view-object of projected balances of one account
+type CashFlowForecastAccountResponse struct {
+	AccountId         int64                                   `json:"accountId,string"`
+	Currency          string                                  `json:"currency"`
+	CurrentBalance    int64                                   `json:"currentBalance"`
+	FirstNegativeDate string                                  `json:"firstNegativeDate,omitempty"`
+	Balances          []*CashFlowForecastDailyBalanceResponse `json:"balances"`
+}
+
+// CashFlowForecastResponse represents a view-object of cash flow forecast
+type CashFlowForecastResponse struct {
+	StartTime          int64                                         `json:"startTime"`
+	EndTime            int64                                         `json:"endTime"`
+	DefaultCurrency    string                                        `json:"defaultCurrency"`
+	ExcludedCurrencies []string                                      `json:"excludedCurrencies,omitempty"`
+	Accounts           []*CashFlowForecastAccountResponse            `json:"accounts"`
+	Totals             []*CashFlowForecastDailyBalanceResponse       `json:"totals"`
+	Occurrences        []*ScheduledTransactionOccurrenceInfoResponse `json:"occurrences"`
+}
+
+// GetHorizonDays returns the count of days which need to be listed
+func (r *ScheduledTransactionUpcomingOccurrenceListRequest) GetHorizonDays() int {
+	if r.HorizonDays < 1 {
+		return defaultCashFlowForecastHorizonDays
+	}
+
+	return int(r.HorizonDays)
+}
+
+// GetHorizonDays returns the count of days which need to be forecasted
+func (r *CashFlowForecastRequest) GetHorizonDays() int {
+	if r.HorizonDays < 1 {
+		return defaultCashFlowForecastHorizonDays
+	}
+
+	return int(r.HorizonDays)
+}
+
+// GetCashFlowForecastEndTime returns the last unix time of the day which is the specified days after the current day in the specified timezone
+func GetCashFlowForecastEndTime(currentUnixTime int64, horizonDays int, timezone *time.Location) int64 {
+	currentTime := time.Unix(currentUnixTime, 0).In(timezone)
+	endDate := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day()+horizonDays+1, 0, 0, 0, 0, timezone)
+
+	return endDate.Unix() - 1
+}
+
+// GetScheduledTransactionOccurrences returns all transactions which will be created by the scheduled transaction templates
+// between the start time and the end time (both inclusive), in ascending order of transaction time
+func GetScheduledTransactionOccurrences(templates []*TransactionTemplate, startUnixTime int64, endUnixTime int64) []*ScheduledTransactionOccurrence {
+	occurrences := make([]*ScheduledTransactionOccurrence, 0)
+	firstDayUnixTimeInUTC := startUnixTime - startUnixTime%secondsOfOneDay
+
+	for i := 0; i < len(templates); i++ {
+		template := templates[i]
+
+		if template.Deleted || template.TemplateType != TRANSACTION_TEMPLATE_TYPE_SCHEDULE || template.ScheduledFrequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED {
+			continue
+		}
+
+		rule, err := template.GetScheduleRule()
+
+		if err != nil {
+			continue
+		}
+
+		// scheduled at is the minutes elapsed of the day in UTC, which is the same as CreateScheduledTransactions
+		for dayUnixTime := firstDayUnixTimeInUTC; dayUnixTime <= endUnixTime; dayUnixTime += secondsOfOneDay {
+			transactionUnixTime := dayUnixTime + int64(template.ScheduledAt)*60
+
+			if transactionUnixTime < startUnixTime || transactionUnixTime > endUnixTime {
+				continue
+			}
+
+			if template.ScheduledStartTime != nil && *template.ScheduledStartTime > transactionUnixTime {
+				continue
+			}
+
+			if template.ScheduledEndTime != nil && *template.ScheduledEndTime < transactionUnixTime {
+				continue
+			}
+
+			if !template.isScheduledOn(rule, time.Unix(transactionUnixTime, 0)) {
+				continue
+			}
+
+			occurrences = append(occurrences, &ScheduledTransactionOccurrence{
+				Template:            template,
+				TransactionUnixTime: transactionUnixTime,
+			})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].TransactionUnixTime < occurrences[j].TransactionUnixTime
+	})
+
+	return occurrences
+}
+
+// GetCashFlowForecast returns the projected daily balances of all accounts and the total projected daily balances in default currency,
+// the forecast starts from current account balances and applies all scheduled transaction occurrences in order,
+// the balances of the accounts in currencies without exchange rate are not included in the totals
+func GetCashFlowForecast(accounts []*Account, occurrences []*ScheduledTransactionOccurrence, startUnixTime int64, endUnixTime int64, timezone *time.Location, defaultCurrency string, exchangeRates *LatestExchangeRateResponse) *CashFlowForecastResponse {
+	referencedAccountIds := make(map[int64]bool)
+
+	for i := 0; i < len(occurrences); i++ {
+		referencedAccountIds[occurrences[i].Template.AccountId] = true
+
+		if occurrences[i].Template.Type == TRANSACTION_TYPE_TRANSFER {
+			referencedAccountIds[occurrences[i].Template.RelatedAccountId] = true
+		}
+	}
+
+	forecastAccounts := make([]*Account, 0, len(accounts))
+	balances := make(map[int64]int64, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if account.Type != ACCOUNT_TYPE_SINGLE_ACCOUNT || (account.Hidden && !referencedAccountIds[account.AccountId]) {
+			continue
+		}
+
+		forecastAccounts = append(forecastAccounts, account)
+		balances[account.AccountId] = account.Balance
+	}
+
+	response := &CashFlowForecastResponse{
+		StartTime:       startUnixTime,
+		EndTime:         endUnixTime,
+		DefaultCurrency: defaultCurrency,
+		Accounts:        make([]*CashFlowForecastAccountResponse, len(forecastAccounts)),
+		Totals:          make([]*CashFlowForecastDailyBalanceResponse, 0),
+		Occurrences:     make([]*ScheduledTransactionOccurrenceInfoResponse, len(occurrences)),
+	}
+
+	excludedCurrencies := make(map[string]bool)
+
+	for i := 0; i < len(forecastAccounts); i++ {
+		response.Accounts[i] = &CashFlowForecastAccountResponse{
+			AccountId:      forecastAccounts[i].AccountId,
+			Currency:       forecastAccounts[i].Currency,
+			CurrentBalance: forecastAccounts[i].Balance,
+			Balances:       make([]*CashFlowForecastDailyBalanceResponse, 0),
+		}
+	}
+
+	for i := 0; i < len(occurrences); i++ {
+		response.Occurrences[i] = occurrences[i].ToScheduledTransactionOccurrenceInfoResponse()
+	}
+
+	startTime := time.Unix(startUnixTime, 0).In(timezone)
+	occurrenceIndex := 0
+
+	for day := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, timezone); day.Unix() <= endUnixTime; day = day.AddDate(0, 0, 1) {
+		dayEndUnixTime := day.AddDate(0, 0, 1).Unix() - 1
+		date := utils.FormatUnixTimeToLongDate(day.Unix(), timezone)
+
+		for ; occurrenceIndex < len(occurrences) && occurrences[occurrenceIndex].TransactionUnixTime <= dayEndUnixTime; occurrenceIndex++ {
+			occurrences[occurrenceIndex].applyToBalances(balances)
+		}
+
+		totalBalance := int64(0)
+
+		for i := 0; i < len(forecastAccounts); i++ {
+			account := forecastAccounts[i]
+			accountResp := response.Accounts[i]
+			balance := balances[account.AccountId]
+			negative := account.Category.IsAsset() && balance < 0
+
+			if negative && accountResp.FirstNegativeDate == "" {
+				accountResp.FirstNegativeDate = date
+			}
+
+			accountResp.Balances = append(accountResp.Balances, &CashFlowForecastDailyBalanceResponse{
+				Date:     date,
+				Balance:  balance,
+				Negative: negative,
+			})
+
+			exchangedBalance, exchanged := exchangeRates.GetExchangedAmount(balance, account.Currency, defaultCurrency)
+
+			if !exchanged {
+				excludedCurrencies[account.Currency] = true
+				continue
+			}
+
+			totalBalance += exchangedBalance
+		}
+
+		response.Totals = append(response.Totals, &CashFlowForecastDailyBalanceResponse{
+			Date:     date,
+			Balance:  totalBalance,
+			Negative: totalBalance < 0,
+		})
+	}
+
+	for currency := range excludedCurrencies {
+		response.ExcludedCurrencies = append(response.ExcludedCurrencies, currency)
+	}
+
+	sort.Strings(response.ExcludedCurrencies)
+
+	return response
+}
+
+// ToScheduledTransactionOccurrenceInfoResponse returns a view-object according to scheduled transaction occurrence
+func (o *ScheduledTransactionOccurrence) ToScheduledTransactionOccurrenceInfoResponse() *ScheduledTransactionOccurrenceInfoResponse {
+	template := o.Template
+	response := &ScheduledTransactionOccurrenceInfoResponse{
+		TemplateId:      template.TemplateId,
+		Name:            template.Name,
+		Type:            template.Type,
+		CategoryId:      template.CategoryId,
+		Time:            o.TransactionUnixTime,
+		UtcOffset:       template.ScheduledTimezoneUtcOffset,
+		SourceAccountId: template.AccountId,
+		SourceAmount:    template.Amount,
+		HideAmount:      template.HideAmount,
+	}
+
+	if template.Type == TRANSACTION_TYPE_TRANSFER {
+		response.DestinationAccountId = template.RelatedAccountId
+		response.DestinationAmount = template.RelatedAccountAmount
+	}
+
+	return response
+}
+
+func (o *ScheduledTransactionOccurrence) applyToBalances(balances map[int64]int64) {
+	template := o.Template
+
+	if template.Type == TRANSACTION_TYPE_INCOME {
+		if _, exists := balances[template.AccountId]; exists {
+			balances[template.AccountId] += template.Amount
+		}
+	} else if template.Type == TRANSACTION_TYPE_EXPENSE || template.Type == TRANSACTION_TYPE_TRANSFER {
+		if _, exists := balances[template.AccountId]; exists {
+			balances[template.AccountId] -= template.Amount
+		}
+	}
+
+	if template.Type == TRANSACTION_TYPE_TRANSFER {
+		if _, exists := balances[template.RelatedAccountId]; exists {
+			balances[template.RelatedAccountId] += template.RelatedAccountAmount
+		}
+	}
+}
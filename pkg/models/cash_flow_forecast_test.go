@@ -0,0 +1,139 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCashFlowForecastRequestGetHorizonDays(t *testing.T) {
+	req := &CashFlowForecastRequest{}
+	assert.Equal(t, 30, req.GetHorizonDays())
+
+	req.HorizonDays = 90
+	assert.Equal(t, 90, req.GetHorizonDays())
+}
+
+func TestGetCashFlowForecastEndTime(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 8*60*60)
+	currentUnixTime := time.Date(2024, time.January, 30, 10, 0, 0, 0, timezone).Unix()
+	expectedEndUnixTime := time.Date(2024, time.February, 2, 23, 59, 59, 0, timezone).Unix()
+
+	assert.Equal(t, expectedEndUnixTime, GetCashFlowForecastEndTime(currentUnixTime, 3, timezone))
+}
+
+func TestGetScheduledTransactionOccurrences(t *testing.T) {
+	startTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	endTime := time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC).Unix()
+	templates := []*TransactionTemplate{
+		{
+			TemplateId:             1,
+			TemplateType:           TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+			ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY,
+			ScheduledFrequency:     "15,-1",
+			ScheduledAt:            60,
+		},
+		{
+			TemplateId:             2,
+			TemplateType:           TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+			ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY,
+			ScheduledFrequency:     "1",
+			ScheduledStartTime:     &startTime,
+			ScheduledEndTime:       &startTime,
+		},
+		{
+			TemplateId:             3,
+			TemplateType:           TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+			ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY,
+			ScheduledFrequency:     "10",
+			ScheduledStartTime:     &startTime,
+		},
+		{
+			TemplateId:             4,
+			TemplateType:           TRANSACTION_TEMPLATE_TYPE_SCHEDULE,
+			ScheduledFrequencyType: TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED,
+		},
+	}
+
+	occurrences := GetScheduledTransactionOccurrences(templates, startTime, endTime)
+
+	assert.Equal(t, 7, len(occurrences))
+	assert.Equal(t, int64(2), occurrences[0].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(), occurrences[0].TransactionUnixTime)
+	assert.Equal(t, int64(3), occurrences[1].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Unix(), occurrences[1].TransactionUnixTime)
+	assert.Equal(t, int64(3), occurrences[2].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC).Unix(), occurrences[2].TransactionUnixTime)
+	assert.Equal(t, int64(1), occurrences[3].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 15, 1, 0, 0, 0, time.UTC).Unix(), occurrences[3].TransactionUnixTime)
+	assert.Equal(t, int64(3), occurrences[4].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC).Unix(), occurrences[4].TransactionUnixTime)
+	assert.Equal(t, int64(3), occurrences[5].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC).Unix(), occurrences[5].TransactionUnixTime)
+	assert.Equal(t, int64(1), occurrences[6].Template.TemplateId)
+	assert.Equal(t, time.Date(2024, time.January, 31, 1, 0, 0, 0, time.UTC).Unix(), occurrences[6].TransactionUnixTime)
+}
+
+func TestGetCashFlowForecast(t *testing.T) {
+	startTime := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC).Unix()
+	endTime := GetCashFlowForecastEndTime(startTime, 2, time.UTC)
+	accounts := []*Account{
+		{AccountId: 1, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CHECKING_ACCOUNT, Currency: "USD", Balance: 10000},
+		{AccountId: 2, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CASH, Currency: "EUR", Balance: 1000, Hidden: true},
+		{AccountId: 3, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CREDIT_CARD, Currency: "USD", Balance: -5000},
+		{AccountId: 4, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CASH, Currency: "XXX", Balance: 100},
+		{AccountId: 5, Type: ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS, Category: ACCOUNT_CATEGORY_CASH, Currency: "USD"},
+		{AccountId: 6, Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, Category: ACCOUNT_CATEGORY_CASH, Currency: "USD", Balance: 100, Hidden: true},
+	}
+	occurrences := []*ScheduledTransactionOccurrence{
+		{
+			Template:            &TransactionTemplate{TemplateId: 1, Type: TRANSACTION_TYPE_EXPENSE, AccountId: 1, Amount: 12000},
+			TransactionUnixTime: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC).Unix(),
+		},
+		{
+			Template:            &TransactionTemplate{TemplateId: 2, Type: TRANSACTION_TYPE_TRANSFER, AccountId: 1, Amount: 1000, RelatedAccountId: 2, RelatedAccountAmount: 800},
+			TransactionUnixTime: time.Date(2024, time.January, 2, 1, 0, 0, 0, time.UTC).Unix(),
+		},
+		{
+			Template:            &TransactionTemplate{TemplateId: 3, Type: TRANSACTION_TYPE_INCOME, AccountId: 1, Amount: 20000},
+			TransactionUnixTime: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC).Unix(),
+		},
+	}
+	exchangeRates := &LatestExchangeRateResponse{
+		BaseCurrency: "USD",
+		ExchangeRates: LatestExchangeRateSlice{
+			&LatestExchangeRate{Currency: "EUR", Rate: "0.8"},
+		},
+	}
+
+	forecast := GetCashFlowForecast(accounts, occurrences, startTime, endTime, time.UTC, "USD", exchangeRates)
+
+	assert.Equal(t, 3, len(forecast.Occurrences))
+	assert.Equal(t, []string{"XXX"}, forecast.ExcludedCurrencies)
+	assert.Equal(t, 4, len(forecast.Accounts))
+
+	assert.Equal(t, int64(1), forecast.Accounts[0].AccountId)
+	assert.Equal(t, "2024-01-02", forecast.Accounts[0].FirstNegativeDate)
+	assert.Equal(t, 3, len(forecast.Accounts[0].Balances))
+	assert.Equal(t, int64(10000), forecast.Accounts[0].Balances[0].Balance)
+	assert.False(t, forecast.Accounts[0].Balances[0].Negative)
+	assert.Equal(t, int64(-3000), forecast.Accounts[0].Balances[1].Balance)
+	assert.True(t, forecast.Accounts[0].Balances[1].Negative)
+	assert.Equal(t, int64(17000), forecast.Accounts[0].Balances[2].Balance)
+	assert.False(t, forecast.Accounts[0].Balances[2].Negative)
+
+	assert.Equal(t, int64(2), forecast.Accounts[1].AccountId)
+	assert.Equal(t, int64(1800), forecast.Accounts[1].Balances[2].Balance)
+
+	assert.Equal(t, int64(3), forecast.Accounts[2].AccountId)
+	assert.Equal(t, "", forecast.Accounts[2].FirstNegativeDate)
+	assert.False(t, forecast.Accounts[2].Balances[0].Negative)
+
+	assert.Equal(t, 3, len(forecast.Totals))
+	assert.Equal(t, "2024-01-01", forecast.Totals[0].Date)
+	assert.Equal(t, int64(10000+1250-5000), forecast.Totals[0].Balance)
+	assert.Equal(t, int64(-3000+2250-5000), forecast.Totals[1].Balance)
+	assert.True(t, forecast.Totals[1].Negative)
+	assert.Equal(t, int64(17000+2250-5000), forecast.Totals[2].Balance)
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const defaultCreditCardStatementCount = 12
+
+// CreditCardStatement represents a closed statement cycle of credit card account
+type CreditCardStatement struct {
+	StartTime         int64
+	EndTime           int64
+	DueTime           int64
+	OpeningBalance    int64
+	ClosingBalance    int64
+	Charges           int64
+	Payments          int64
+	PaymentsBeforeDue int64
+}
+
+// CreditCardStatementListRequest represents all parameters of credit card statement listing request
+type CreditCardStatementListRequest struct {
+	AccountId int64 `form:"account_id,string" binding:"required,min=1"`
+	Count     int32 `form:"count" binding:"min=0,max=60"`
+}
+
+// CreditCardStatementInfoResponse represents a view-object of credit card statement
+type CreditCardStatementInfoResponse struct {
+	StartTime         int64 `json:"startTime"`
+	EndTime           int64 `json:"endTime"`
+	DueTime           int64 `json:"dueTime"`
+	OpeningBalance    int64 `json:"openingBalance"`
+	ClosingBalance    int64 `json:"closingBalance"`
+	Charges           int64 `json:"charges"`
+	Payments          int64 `json:"payments"`
+	StatementBalance  int64 `json:"statementBalance"`
+	MinimumPayment    int64 `json:"minimumPayment"`
+	PaymentsBeforeDue int64 `json:"paymentsBeforeDue"`
+	Overdue           bool  `json:"overdue"`
+}
+
+// GetCreditCardStatementCount returns the count of statements which need to be returned
+func (r *CreditCardStatementListRequest) GetCreditCardStatementCount() int {
+	if r.Count < 1 {
+		return defaultCreditCardStatementCount
+	}
+
+	return int(r.Count)
+}
+
+// GetClosedCreditCardStatements returns the latest closed statement cycles (the newest one first) before the current unix time,
+// the statement cycle closes at the end of the statement date, and the payment due date is the grace period days after it
+func GetClosedCreditCardStatements(statementDate int, gracePeriodDays int, count int, currentUnixTime int64, timezone *time.Location) []*CreditCardStatement {
+	if statementDate < 1 || count < 1 {
+		return nil
+	}
+
+	currentTime := time.Unix(currentUnixTime, 0).In(timezone)
+	year := currentTime.Year()
+	month := currentTime.Month()
+	endTime := getCreditCardStatementEndTime(year, month, statementDate, timezone)
+
+	if endTime > currentUnixTime {
+		month--
+		endTime = getCreditCardStatementEndTime(year, month, statementDate, timezone)
+	}
+
+	statements := make([]*CreditCardStatement, count)
+
+	for i := 0; i < count; i++ {
+		month--
+		startTime := getCreditCardStatementEndTime(year, month, statementDate, timezone) + 1
+
+		statements[i] = &CreditCardStatement{
+			StartTime: startTime,
+			EndTime:   endTime,
+			DueTime:   endTime + int64(gracePeriodDays)*24*60*60,
+		}
+
+		endTime = startTime - 1
+	}
+
+	return statements
+}
+
+// FillCreditCardStatements fills the balances, charges and payments of all statements by account transactions which must be in ascending order of transaction time,
+// the opening balance is the account balance before all these transactions
+func FillCreditCardStatements(statements []*CreditCardStatement, transactions []*TransactionWithAccountBalance, openingBalance int64) {
+	for i := 0; i < len(statements); i++ {
+		statements[i].OpeningBalance = openingBalance
+		statements[i].ClosingBalance = openingBalance
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		transactionUnixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+		balanceChange := transaction.AccountClosingBalance - transaction.AccountOpeningBalance
+
+		for j := 0; j < len(statements); j++ {
+			statement := statements[j]
+
+			if transactionUnixTime < statement.StartTime {
+				statement.OpeningBalance = transaction.AccountClosingBalance
+				statement.ClosingBalance = transaction.AccountClosingBalance
+			} else if transactionUnixTime <= statement.EndTime {
+				statement.ClosingBalance = transaction.AccountClosingBalance
+
+				if balanceChange < 0 {
+					statement.Charges += -balanceChange
+				} else {
+					statement.Payments += balanceChange
+				}
+			} else if transactionUnixTime <= statement.DueTime && balanceChange > 0 {
+				statement.PaymentsBeforeDue += balanceChange
+			}
+		}
+	}
+}
+
+// GetStatementBalance returns the amount owed at the end of the statement cycle
+func (s *CreditCardStatement) GetStatementBalance() int64 {
+	if s.ClosingBalance >= 0 {
+		return 0
+	}
+
+	return -s.ClosingBalance
+}
+
+// GetMinimumPayment returns the minimum amount which needs to be paid before the due date
+func (s *CreditCardStatement) GetMinimumPayment(minimumPaymentRate int) int64 {
+	statementBalance := s.GetStatementBalance()
+	minimumPayment := int64(math.Ceil(float64(statementBalance) * float64(minimumPaymentRate) / 100))
+
+	if minimumPayment > statementBalance {
+		return statementBalance
+	}
+
+	return minimumPayment
+}
+
+// IsOverdue returns whether the minimum payment of statement has not been paid before the due date
+func (s *CreditCardStatement) IsOverdue(minimumPaymentRate int, currentUnixTime int64) bool {
+	return currentUnixTime > s.DueTime && s.PaymentsBeforeDue < s.GetMinimumPayment(minimumPaymentRate)
+}
+
+// ToCreditCardStatementInfoResponse returns a view-object according to the statement
+func (s *CreditCardStatement) ToCreditCardStatementInfoResponse(minimumPaymentRate int, currentUnixTime int64) *CreditCardStatementInfoResponse {
+	return &CreditCardStatementInfoResponse{
+		StartTime:         s.StartTime,
+		EndTime:           s.EndTime,
+		DueTime:           s.DueTime,
+		OpeningBalance:    s.OpeningBalance,
+		ClosingBalance:    s.ClosingBalance,
+		Charges:           s.Charges,
+		Payments:          s.Payments,
+		StatementBalance:  s.GetStatementBalance(),
+		MinimumPayment:    s.GetMinimumPayment(minimumPaymentRate),
+		PaymentsBeforeDue: s.PaymentsBeforeDue,
+		Overdue:           s.IsOverdue(minimumPaymentRate, currentUnixTime),
+	}
+}
+
+func getCreditCardStatementEndTime(year int, month time.Month, statementDate int, timezone *time.Location) int64 {
+	return time.Date(year, month, statementDate+1, 0, 0, 0, 0, timezone).Unix() - 1
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestGetClosedCreditCardStatements(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 8*60*60)
+	currentUnixTime := time.Date(2025, 3, 10, 12, 0, 0, 0, timezone).Unix()
+
+	statements := GetClosedCreditCardStatements(15, 20, 3, currentUnixTime, timezone)
+	assert.Equal(t, 3, len(statements))
+
+	assert.Equal(t, time.Date(2025, 1, 16, 0, 0, 0, 0, timezone).Unix(), statements[0].StartTime)
+	assert.Equal(t, time.Date(2025, 2, 15, 23, 59, 59, 0, timezone).Unix(), statements[0].EndTime)
+	assert.Equal(t, time.Date(2025, 3, 7, 23, 59, 59, 0, timezone).Unix(), statements[0].DueTime)
+
+	assert.Equal(t, time.Date(2024, 12, 16, 0, 0, 0, 0, timezone).Unix(), statements[1].StartTime)
+	assert.Equal(t, time.Date(2025, 1, 15, 23, 59, 59, 0, timezone).Unix(), statements[1].EndTime)
+
+	assert.Equal(t, time.Date(2024, 11, 16, 0, 0, 0, 0, timezone).Unix(), statements[2].StartTime)
+	assert.Equal(t, time.Date(2024, 12, 15, 23, 59, 59, 0, timezone).Unix(), statements[2].EndTime)
+}
+
+func TestGetClosedCreditCardStatements_CurrentMonthStatementClosed(t *testing.T) {
+	timezone := time.UTC
+	currentUnixTime := time.Date(2025, 3, 16, 0, 0, 0, 0, timezone).Unix()
+
+	statements := GetClosedCreditCardStatements(15, 20, 1, currentUnixTime, timezone)
+	assert.Equal(t, 1, len(statements))
+	assert.Equal(t, time.Date(2025, 2, 16, 0, 0, 0, 0, timezone).Unix(), statements[0].StartTime)
+	assert.Equal(t, time.Date(2025, 3, 15, 23, 59, 59, 0, timezone).Unix(), statements[0].EndTime)
+}
+
+func TestGetClosedCreditCardStatements_StatementDateNotSet(t *testing.T) {
+	statements := GetClosedCreditCardStatements(0, 20, 3, time.Now().Unix(), time.UTC)
+	assert.Nil(t, statements)
+}
+
+func TestFillCreditCardStatements(t *testing.T) {
+	timezone := time.UTC
+	currentUnixTime := time.Date(2025, 3, 20, 0, 0, 0, 0, timezone).Unix()
+	statements := GetClosedCreditCardStatements(15, 10, 2, currentUnixTime, timezone)
+
+	transactions := []*TransactionWithAccountBalance{
+		buildTestCreditCardTransaction(time.Date(2025, 1, 20, 10, 0, 0, 0, timezone), -1000, -3000),
+		buildTestCreditCardTransaction(time.Date(2025, 2, 1, 10, 0, 0, 0, timezone), -3000, 0),
+		buildTestCreditCardTransaction(time.Date(2025, 2, 10, 10, 0, 0, 0, timezone), 0, -5000),
+		buildTestCreditCardTransaction(time.Date(2025, 2, 20, 10, 0, 0, 0, timezone), -5000, -6000),
+		buildTestCreditCardTransaction(time.Date(2025, 2, 24, 10, 0, 0, 0, timezone), -6000, -4000),
+		buildTestCreditCardTransaction(time.Date(2025, 3, 1, 10, 0, 0, 0, timezone), -4000, -9000),
+	}
+
+	FillCreditCardStatements(statements, transactions, -1000)
+
+	assert.Equal(t, int64(-5000), statements[0].OpeningBalance)
+	assert.Equal(t, int64(-9000), statements[0].ClosingBalance)
+	assert.Equal(t, int64(6000), statements[0].Charges)
+	assert.Equal(t, int64(2000), statements[0].Payments)
+	assert.Equal(t, int64(0), statements[0].PaymentsBeforeDue)
+	assert.Equal(t, int64(9000), statements[0].GetStatementBalance())
+
+	assert.Equal(t, int64(-1000), statements[1].OpeningBalance)
+	assert.Equal(t, int64(-5000), statements[1].ClosingBalance)
+	assert.Equal(t, int64(7000), statements[1].Charges)
+	assert.Equal(t, int64(3000), statements[1].Payments)
+	assert.Equal(t, int64(2000), statements[1].PaymentsBeforeDue)
+	assert.Equal(t, int64(5000), statements[1].GetStatementBalance())
+
+	assert.Equal(t, int64(5000), statements[1].GetMinimumPayment(100))
+	assert.Equal(t, int64(500), statements[1].GetMinimumPayment(10))
+	assert.True(t, statements[1].IsOverdue(100, currentUnixTime))
+	assert.False(t, statements[1].IsOverdue(10, currentUnixTime))
+	assert.False(t, statements[0].IsOverdue(100, currentUnixTime))
+}
+
+func TestCreditCardStatementGetStatementBalance_PositiveBalance(t *testing.T) {
+	statement := &CreditCardStatement{
+		ClosingBalance: 1000,
+	}
+
+	assert.Equal(t, int64(0), statement.GetStatementBalance())
+	assert.Equal(t, int64(0), statement.GetMinimumPayment(100))
+	assert.False(t, statement.IsOverdue(100, statement.DueTime+1))
+}
+
+func buildTestCreditCardTransaction(transactionTime time.Time, openingBalance int64, closingBalance int64) *TransactionWithAccountBalance {
+	return &TransactionWithAccountBalance{
+		Transaction: &Transaction{
+			TransactionTime: utils.GetMinTransactionTimeFromUnixTime(transactionTime.Unix()),
+		},
+		AccountOpeningBalance: openingBalance,
+		AccountClosingBalance: closingBalance,
+	}
+}
@@ -26,13 +26,16 @@ type DataStatisticsResponse struct {
 
 // ExportTransactionDataRequest represents export transaction request
 type ExportTransactionDataRequest struct {
-	Type         TransactionType `form:"type" binding:"min=0,max=4"`
-	CategoryIds  string          `form:"category_ids"`
-	AccountIds   string          `form:"account_ids"`
-	TagFilter    string          `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter   string          `form:"item_filter" binding:"validItemFilter"`
-	AmountFilter string          `form:"amount_filter" binding:"validAmountFilter"`
-	Keyword      string          `form:"keyword"`
-	MaxTime      int64           `form:"max_time" binding:"min=0"` // Unix timestamp in seconds
-	MinTime      int64           `form:"min_time" binding:"min=0"` // Unix timestamp in seconds
+	Type          TransactionType `form:"type" binding:"min=0,max=4"`
+	CategoryIds   string          `form:"category_ids"`
+	AccountIds    string          `form:"account_ids"`
+	PayeeIds      string          `form:"payee_ids"`
+	TagFilter     string          `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter    string          `form:"item_filter" binding:"validItemFilter"`
+	AmountFilter  string          `form:"amount_filter" binding:"validAmountFilter"`
+	Keyword       string          `form:"keyword"`
+	SavedSearchId int64           `form:"saved_search_id,string" binding:"min=0"`
+	SearchQuery   string          `form:"search_query"`
+	MaxTime       int64           `form:"max_time" binding:"min=0"` // Unix timestamp in seconds
+	MinTime       int64           `form:"min_time" binding:"min=0"` // Unix timestamp in seconds
 }
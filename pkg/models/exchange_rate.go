@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"strings"
 
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -37,11 +38,60 @@ type UserCustomExchangeRateUpdateResponse struct {
 
 // LatestExchangeRateResponse returns a view-object which contains latest exchange rate
 type LatestExchangeRateResponse struct {
-	DataSource    string                  `json:"dataSource"`
-	ReferenceUrl  string                  `json:"referenceUrl"`
-	UpdateTime    int64                   `json:"updateTime"`
-	BaseCurrency  string                  `json:"baseCurrency"`
-	ExchangeRates LatestExchangeRateSlice `json:"exchangeRates"`
+	DataSource             string                          `json:"dataSource"`
+	ReferenceUrl           string                          `json:"referenceUrl"`
+	UpdateTime             int64                           `json:"updateTime"`
+	BaseCurrency           string                          `json:"baseCurrency"`
+	ExchangeRates          LatestExchangeRateSlice         `json:"exchangeRates"`
+	MergedDataSources      []string                        `json:"mergedDataSources,omitempty"`
+	DivergentExchangeRates []*LatestExchangeRateDivergence `json:"divergentExchangeRates,omitempty"`
+	FetchTime              int64                           `json:"fetchTime,omitempty"`
+	IsStale                bool                            `json:"isStale,omitempty"`
+}
+
+// GetExchangedAmount returns the amount exchanged from the source currency to the target currency, and whether the exchange rates of both currencies exist
+func (r *LatestExchangeRateResponse) GetExchangedAmount(amount int64, fromCurrency string, toCurrency string) (int64, bool) {
+	if fromCurrency == toCurrency {
+		return amount, true
+	}
+
+	if r == nil {
+		return 0, false
+	}
+
+	fromRate, fromRateExists := r.GetExchangeRate(fromCurrency)
+	toRate, toRateExists := r.GetExchangeRate(toCurrency)
+
+	if !fromRateExists || !toRateExists {
+		return 0, false
+	}
+
+	exchangedAmount := utils.ExchangeAmountDecimalPlaces(float64(amount)*toRate/fromRate, fromCurrency, toCurrency)
+
+	return int64(math.Round(exchangedAmount)), true
+}
+
+// GetExchangeRate returns the exchange rate of the specified currency against the base currency, and whether the exchange rate exists
+func (r *LatestExchangeRateResponse) GetExchangeRate(currency string) (float64, bool) {
+	if currency == r.BaseCurrency {
+		return 1, true
+	}
+
+	for i := 0; i < len(r.ExchangeRates); i++ {
+		if r.ExchangeRates[i].Currency != currency {
+			continue
+		}
+
+		rate, err := utils.StringToFloat64(r.ExchangeRates[i].Rate)
+
+		if err != nil || rate <= 0 {
+			return 0, false
+		}
+
+		return rate, true
+	}
+
+	return 0, false
 }
 
 // LatestExchangeRate represents a data pair of currency and exchange rate
@@ -50,6 +100,14 @@ type LatestExchangeRate struct {
 	Rate     string `json:"rate"`
 }
 
+// LatestExchangeRateDivergence represents the exchange rate of a currency which differs between the data source and the reference data source
+type LatestExchangeRateDivergence struct {
+	Currency            string `json:"currency"`
+	Rate                string `json:"rate"`
+	ReferenceDataSource string `json:"referenceDataSource"`
+	ReferenceRate       string `json:"referenceRate"`
+}
+
 // ToLatestExchangeRate returns a data pair of currency and exchange rate according to database model
 func (r *UserCustomExchangeRate) ToLatestExchangeRate(baseCurrencyRate int64) *LatestExchangeRate {
 	rate := float64(0)
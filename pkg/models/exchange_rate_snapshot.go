@@ -0,0 +1,185 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const exchangeRateSnapshotDateFormat = "2006-01-02"
+
+// ExchangeRateSnapshot represents the exchange rate of a currency on a specified date from an exchange rates data source
+type ExchangeRateSnapshot struct {
+	DataSource      string `xorm:"PK VARCHAR(64) NOT NULL"`
+	SnapshotDate    int32  `xorm:"PK NOT NULL"`
+	Currency        string `xorm:"PK VARCHAR(3) NOT NULL"`
+	BaseCurrency    string `xorm:"VARCHAR(3) NOT NULL"`
+	Rate            string `xorm:"VARCHAR(32) NOT NULL"`
+	UpdateTime      int64  `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+}
+
+// HistoricalExchangeRateRequest represents all parameters of historical exchange rate request
+type HistoricalExchangeRateRequest struct {
+	Date string `form:"date" binding:"required,len=10"`
+}
+
+// GetNumericDate returns the numeric date (e.g. 20240131) of the request
+func (r *HistoricalExchangeRateRequest) GetNumericDate() (int32, error) {
+	return ParseExchangeRateSnapshotDate(r.Date)
+}
+
+// ParseExchangeRateSnapshotDate returns the numeric date (e.g. 20240131) according to the date text (e.g. 2024-01-31)
+func ParseExchangeRateSnapshotDate(date string) (int32, error) {
+	t, err := time.ParseInLocation(exchangeRateSnapshotDateFormat, date, time.UTC)
+
+	if err != nil {
+		return 0, errs.ErrExchangeRateDateInvalid
+	}
+
+	return GetExchangeRateSnapshotDate(t.Unix()), nil
+}
+
+// GetExchangeRateSnapshotDate returns the numeric date (e.g. 20240131) of the exchange rate snapshot which the unix time belongs to
+func GetExchangeRateSnapshotDate(unixTime int64) int32 {
+	// exchange rate snapshots are always stored by the date in UTC, so that the snapshot dates do not depend on the server or client timezone
+	return utils.FormatUnixTimeToNumericYearMonthDay(unixTime, time.UTC)
+}
+
+// CreateExchangeRateSnapshots returns the exchange rate snapshot database models according to the exchange rates data
+func CreateExchangeRateSnapshots(dataSource string, exchangeRates *LatestExchangeRateResponse) []*ExchangeRateSnapshot {
+	if exchangeRates == nil || len(exchangeRates.ExchangeRates) < 1 {
+		return nil
+	}
+
+	snapshotDate := GetExchangeRateSnapshotDate(exchangeRates.UpdateTime)
+	snapshots := make([]*ExchangeRateSnapshot, 0, len(exchangeRates.ExchangeRates))
+
+	for i := 0; i < len(exchangeRates.ExchangeRates); i++ {
+		exchangeRate := exchangeRates.ExchangeRates[i]
+
+		snapshots = append(snapshots, &ExchangeRateSnapshot{
+			DataSource:   dataSource,
+			SnapshotDate: snapshotDate,
+			Currency:     exchangeRate.Currency,
+			BaseCurrency: exchangeRates.BaseCurrency,
+			Rate:         exchangeRate.Rate,
+			UpdateTime:   exchangeRates.UpdateTime,
+		})
+	}
+
+	return snapshots
+}
+
+// HistoricalExchangeRates represents the exchange rates of each snapshot date, and the latest exchange rates which are used when there is no snapshot
+type HistoricalExchangeRates struct {
+	snapshotDates       []int32
+	snapshotRates       map[int32]*LatestExchangeRateResponse
+	latestExchangeRates *LatestExchangeRateResponse
+}
+
+// TransactionAmountExchanger returns the transaction amount exchanged to the target currency, and whether the exchange rates exist
+type TransactionAmountExchanger func(transaction *Transaction) (int64, bool)
+
+// NewHistoricalExchangeRates returns a new historical exchange rates instance according to the exchange rate snapshots and the latest exchange rates
+func NewHistoricalExchangeRates(dataSource string, snapshots []*ExchangeRateSnapshot, latestExchangeRates *LatestExchangeRateResponse) *HistoricalExchangeRates {
+	historicalExchangeRates := &HistoricalExchangeRates{
+		snapshotDates:       make([]int32, 0),
+		snapshotRates:       make(map[int32]*LatestExchangeRateResponse),
+		latestExchangeRates: latestExchangeRates,
+	}
+
+	for i := 0; i < len(snapshots); i++ {
+		snapshot := snapshots[i]
+		exchangeRates, exists := historicalExchangeRates.snapshotRates[snapshot.SnapshotDate]
+
+		if !exists {
+			exchangeRates = &LatestExchangeRateResponse{
+				DataSource:    dataSource,
+				UpdateTime:    snapshot.UpdateTime,
+				BaseCurrency:  snapshot.BaseCurrency,
+				ExchangeRates: make(LatestExchangeRateSlice, 0),
+			}
+
+			historicalExchangeRates.snapshotDates = append(historicalExchangeRates.snapshotDates, snapshot.SnapshotDate)
+			historicalExchangeRates.snapshotRates[snapshot.SnapshotDate] = exchangeRates
+		}
+
+		exchangeRates.ExchangeRates = append(exchangeRates.ExchangeRates, &LatestExchangeRate{
+			Currency: snapshot.Currency,
+			Rate:     snapshot.Rate,
+		})
+	}
+
+	sort.Slice(historicalExchangeRates.snapshotDates, func(i, j int) bool {
+		return historicalExchangeRates.snapshotDates[i] < historicalExchangeRates.snapshotDates[j]
+	})
+
+	for _, exchangeRates := range historicalExchangeRates.snapshotRates {
+		sort.Sort(exchangeRates.ExchangeRates)
+	}
+
+	return historicalExchangeRates
+}
+
+// GetExchangeRatesOnDate returns the exchange rates effective on the specified numeric date (e.g. 20240131)
+// It returns the latest snapshot on or before that date, the earliest snapshot if the date is before all snapshots, or the latest exchange rates if there is no snapshot
+func (r *HistoricalExchangeRates) GetExchangeRatesOnDate(date int32) *LatestExchangeRateResponse {
+	if r == nil {
+		return nil
+	}
+
+	if len(r.snapshotDates) < 1 {
+		return r.latestExchangeRates
+	}
+
+	index := sort.Search(len(r.snapshotDates), func(i int) bool {
+		return r.snapshotDates[i] > date
+	})
+
+	if index > 0 {
+		index--
+	}
+
+	return r.snapshotRates[r.snapshotDates[index]]
+}
+
+// GetExchangedAmountOnDate returns the amount exchanged from the source currency to the target currency by the exchange rates effective on the specified numeric date (e.g. 20240131), and whether the exchange rates of both currencies exist
+func (r *HistoricalExchangeRates) GetExchangedAmountOnDate(amount int64, fromCurrency string, toCurrency string, date int32) (int64, bool) {
+	if fromCurrency == toCurrency {
+		return amount, true
+	}
+
+	if r == nil {
+		return 0, false
+	}
+
+	exchangedAmount, exchanged := r.GetExchangeRatesOnDate(date).GetExchangedAmount(amount, fromCurrency, toCurrency)
+
+	if exchanged {
+		return exchangedAmount, true
+	}
+
+	// the currency may be missing in the snapshot (e.g. the data source stops publishing its exchange rate), so fall back to the latest exchange rates
+	return r.latestExchangeRates.GetExchangedAmount(amount, fromCurrency, toCurrency)
+}
+
+// GetExchangedAmount returns the amount exchanged from the source currency to the target currency by the exchange rates effective at the specified unix time, and whether the exchange rates of both currencies exist
+func (r *HistoricalExchangeRates) GetExchangedAmount(amount int64, fromCurrency string, toCurrency string, unixTime int64) (int64, bool) {
+	return r.GetExchangedAmountOnDate(amount, fromCurrency, toCurrency, GetExchangeRateSnapshotDate(unixTime))
+}
+
+// ToTransactionAmountExchanger returns a transaction amount exchanger which exchanges the transaction amount from the account currency to the target currency by the exchange rates effective at the transaction time
+func (r *HistoricalExchangeRates) ToTransactionAmountExchanger(accountCurrencies map[int64]string, toCurrency string) TransactionAmountExchanger {
+	return func(transaction *Transaction) (int64, bool) {
+		fromCurrency, exists := accountCurrencies[transaction.AccountId]
+
+		if !exists {
+			return 0, false
+		}
+
+		return r.GetExchangedAmount(transaction.Amount, fromCurrency, toCurrency, utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime))
+	}
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestParseExchangeRateSnapshotDate(t *testing.T) {
+	date, err := ParseExchangeRateSnapshotDate("2024-01-31")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(20240131), date)
+
+	_, err = ParseExchangeRateSnapshotDate("2024-02-30")
+	assert.NotNil(t, err)
+
+	_, err = ParseExchangeRateSnapshotDate("20240131")
+	assert.NotNil(t, err)
+}
+
+func TestCreateExchangeRateSnapshots(t *testing.T) {
+	exchangeRates := &LatestExchangeRateResponse{
+		DataSource:   "European Central Bank",
+		UpdateTime:   1617285600, // 2021-04-01 14:00:00 UTC
+		BaseCurrency: "EUR",
+		ExchangeRates: LatestExchangeRateSlice{
+			&LatestExchangeRate{Currency: "EUR", Rate: "1"},
+			&LatestExchangeRate{Currency: "USD", Rate: "1.1746"},
+		},
+	}
+
+	snapshots := CreateExchangeRateSnapshots("euro_central_bank", exchangeRates)
+	assert.Equal(t, 2, len(snapshots))
+	assert.Equal(t, &ExchangeRateSnapshot{
+		DataSource:   "euro_central_bank",
+		SnapshotDate: 20210401,
+		Currency:     "USD",
+		BaseCurrency: "EUR",
+		Rate:         "1.1746",
+		UpdateTime:   1617285600,
+	}, snapshots[1])
+
+	assert.Nil(t, CreateExchangeRateSnapshots("euro_central_bank", nil))
+	assert.Nil(t, CreateExchangeRateSnapshots("euro_central_bank", &LatestExchangeRateResponse{}))
+}
+
+func TestHistoricalExchangeRatesGetExchangeRatesOnDate(t *testing.T) {
+	latestExchangeRates := &LatestExchangeRateResponse{
+		BaseCurrency: "EUR",
+		ExchangeRates: LatestExchangeRateSlice{
+			&LatestExchangeRate{Currency: "USD", Rate: "1.1"},
+		},
+	}
+
+	historicalExchangeRates := NewHistoricalExchangeRates("euro_central_bank", []*ExchangeRateSnapshot{
+		{SnapshotDate: 20240105, Currency: "USD", BaseCurrency: "EUR", Rate: "1.3"},
+		{SnapshotDate: 20240101, Currency: "USD", BaseCurrency: "EUR", Rate: "1.2"},
+		{SnapshotDate: 20240101, Currency: "JPY", BaseCurrency: "EUR", Rate: "150"},
+	}, latestExchangeRates)
+
+	exchangeRates := historicalExchangeRates.GetExchangeRatesOnDate(20240101)
+	assert.Equal(t, "euro_central_bank", exchangeRates.DataSource)
+	assert.Equal(t, 2, len(exchangeRates.ExchangeRates))
+	assert.Equal(t, "JPY", exchangeRates.ExchangeRates[0].Currency)
+	assert.Equal(t, "1.2", exchangeRates.ExchangeRates[1].Rate)
+
+	exchangeRates = historicalExchangeRates.GetExchangeRatesOnDate(20240104)
+	assert.Equal(t, "1.2", exchangeRates.ExchangeRates[1].Rate)
+
+	exchangeRates = historicalExchangeRates.GetExchangeRatesOnDate(20240105)
+	assert.Equal(t, "1.3", exchangeRates.ExchangeRates[0].Rate)
+
+	exchangeRates = historicalExchangeRates.GetExchangeRatesOnDate(20250101)
+	assert.Equal(t, "1.3", exchangeRates.ExchangeRates[0].Rate)
+
+	exchangeRates = historicalExchangeRates.GetExchangeRatesOnDate(20231231)
+	assert.Equal(t, "1.2", exchangeRates.ExchangeRates[1].Rate)
+
+	emptyHistoricalExchangeRates := NewHistoricalExchangeRates("euro_central_bank", nil, latestExchangeRates)
+	assert.Equal(t, latestExchangeRates, emptyHistoricalExchangeRates.GetExchangeRatesOnDate(20240101))
+}
+
+func TestHistoricalExchangeRatesGetExchangedAmount(t *testing.T) {
+	latestExchangeRates := &LatestExchangeRateResponse{
+		BaseCurrency: "EUR",
+		ExchangeRates: LatestExchangeRateSlice{
+			&LatestExchangeRate{Currency: "USD", Rate: "1.1"},
+			&LatestExchangeRate{Currency: "JPY", Rate: "160"},
+		},
+	}
+
+	historicalExchangeRates := NewHistoricalExchangeRates("euro_central_bank", []*ExchangeRateSnapshot{
+		{SnapshotDate: 20240101, Currency: "USD", BaseCurrency: "EUR", Rate: "1.25"},
+		{SnapshotDate: 20240201, Currency: "USD", BaseCurrency: "EUR", Rate: "1.5"},
+	}, latestExchangeRates)
+
+	amount, ok := historicalExchangeRates.GetExchangedAmount(1000, "EUR", "USD", 1704110400) // 2024-01-01 12:00:00 UTC
+	assert.True(t, ok)
+	assert.Equal(t, int64(1250), amount)
+
+	amount, ok = historicalExchangeRates.GetExchangedAmount(1000, "EUR", "USD", 1706788800) // 2024-02-01 12:00:00 UTC
+	assert.True(t, ok)
+	assert.Equal(t, int64(1500), amount)
+
+	amount, ok = historicalExchangeRates.GetExchangedAmountOnDate(1000, "EUR", "JPY", 20240101)
+	assert.True(t, ok)
+	assert.Equal(t, int64(160000), amount)
+
+	amount, ok = historicalExchangeRates.GetExchangedAmountOnDate(1000, "CNY", "CNY", 20240101)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	_, ok = historicalExchangeRates.GetExchangedAmountOnDate(1000, "EUR", "CNY", 20240101)
+	assert.False(t, ok)
+
+	var nilHistoricalExchangeRates *HistoricalExchangeRates
+	_, ok = nilHistoricalExchangeRates.GetExchangedAmountOnDate(1000, "EUR", "USD", 20240101)
+	assert.False(t, ok)
+}
+
+func TestHistoricalExchangeRatesToTransactionAmountExchanger(t *testing.T) {
+	historicalExchangeRates := NewHistoricalExchangeRates("euro_central_bank", []*ExchangeRateSnapshot{
+		{SnapshotDate: 20240101, Currency: "USD", BaseCurrency: "EUR", Rate: "1.25"},
+		{SnapshotDate: 20240201, Currency: "USD", BaseCurrency: "EUR", Rate: "1.5"},
+	}, nil)
+
+	exchanger := historicalExchangeRates.ToTransactionAmountExchanger(map[int64]string{
+		1: "USD",
+		2: "EUR",
+	}, "EUR")
+
+	amount, ok := exchanger(&Transaction{
+		AccountId:       1,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1704110400), // 2024-01-01 12:00:00 UTC
+		Amount:          1250,
+	})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	amount, ok = exchanger(&Transaction{
+		AccountId:       1,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1706788800), // 2024-02-01 12:00:00 UTC
+		Amount:          1500,
+	})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	amount, ok = exchanger(&Transaction{
+		AccountId:       2,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1706788800),
+		Amount:          1500,
+	})
+	assert.True(t, ok)
+	assert.Equal(t, int64(1500), amount)
+
+	_, ok = exchanger(&Transaction{
+		AccountId:       3,
+		TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1706788800),
+		Amount:          1500,
+	})
+	assert.False(t, ok)
+}
@@ -25,3 +25,41 @@ func TestLatestExchangeRateSliceLess(t *testing.T) {
 	assert.Equal(t, "EUR", latestExchangeRateSlice[1].Currency)
 	assert.Equal(t, "USD", latestExchangeRateSlice[2].Currency)
 }
+
+func TestLatestExchangeRateResponseGetExchangedAmount(t *testing.T) {
+	exchangeRates := &LatestExchangeRateResponse{
+		BaseCurrency: "EUR",
+		ExchangeRates: LatestExchangeRateSlice{
+			&LatestExchangeRate{Currency: "USD", Rate: "1.25"},
+			&LatestExchangeRate{Currency: "JPY", Rate: "150"},
+			&LatestExchangeRate{Currency: "KWD", Rate: "0.33"},
+			&LatestExchangeRate{Currency: "XXX", Rate: "0"},
+		},
+	}
+
+	amount, ok := exchangeRates.GetExchangedAmount(1000, "USD", "USD")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	amount, ok = exchangeRates.GetExchangedAmount(1000, "EUR", "USD")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1250), amount)
+
+	amount, ok = exchangeRates.GetExchangedAmount(1250, "USD", "JPY")
+	assert.True(t, ok)
+	assert.Equal(t, int64(150000), amount)
+
+	amount, ok = exchangeRates.GetExchangedAmount(1000, "EUR", "KWD")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3300), amount)
+
+	amount, ok = exchangeRates.GetExchangedAmount(3300, "KWD", "EUR")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), amount)
+
+	_, ok = exchangeRates.GetExchangedAmount(1000, "USD", "CNY")
+	assert.False(t, ok)
+
+	_, ok = exchangeRates.GetExchangedAmount(1000, "XXX", "USD")
+	assert.False(t, ok)
+}
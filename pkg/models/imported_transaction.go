@@ -12,6 +12,7 @@ type ImportTransaction struct {
 	OriginalDestinationAccountName     string
 	OriginalDestinationAccountCurrency string
 	OriginalTagNames                   []string
+	OriginalPayeeName                  string
 }
 
 // ImportTransactionRequest represents all parameters of the imported transaction data
@@ -49,6 +50,10 @@ type ImportTransactionResponse struct {
 	OriginalDestinationAccountCurrency string                          `json:"originalDestinationAccountCurrency,omitempty"`
 	SourceAmount                       int64                           `json:"sourceAmount"`
 	DestinationAmount                  int64                           `json:"destinationAmount,omitempty"`
+	OriginalCurrency                   string                          `json:"originalCurrency,omitempty"`
+	OriginalAmount                     int64                           `json:"originalAmount,omitempty"`
+	PayeeId                            int64                           `json:"payeeId,string,omitempty"`
+	OriginalPayeeName                  string                          `json:"originalPayeeName,omitempty"`
 	TagIds                             []string                        `json:"tagIds"`
 	OriginalTagNames                   []string                        `json:"originalTagNames"`
 	Comment                            string                          `json:"comment"`
@@ -92,6 +97,10 @@ func (t ImportTransaction) ToImportTransactionResponse() *ImportTransactionRespo
 		OriginalDestinationAccountCurrency: t.OriginalDestinationAccountCurrency,
 		SourceAmount:                       t.Amount,
 		DestinationAmount:                  t.RelatedAccountAmount,
+		OriginalCurrency:                   t.OriginalCurrency,
+		OriginalAmount:                     t.OriginalAmount,
+		PayeeId:                            t.PayeeId,
+		OriginalPayeeName:                  t.OriginalPayeeName,
 		TagIds:                             t.TagIds,
 		OriginalTagNames:                   t.OriginalTagNames,
 		Comment:                            t.Comment,
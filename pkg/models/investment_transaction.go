@@ -0,0 +1,423 @@
+package models
+
+import (
+	"math"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+// minimumHoldingQuantity is the tolerance of floating point error when comparing the quantities of holding
+const minimumHoldingQuantity = 1e-9
+
+// InvestmentTransactionType represents investment transaction type
+type InvestmentTransactionType byte
+
+// Investment transaction types
+const (
+	INVESTMENT_TRANSACTION_TYPE_BUY      InvestmentTransactionType = 1
+	INVESTMENT_TRANSACTION_TYPE_SELL     InvestmentTransactionType = 2
+	INVESTMENT_TRANSACTION_TYPE_DIVIDEND InvestmentTransactionType = 3
+	INVESTMENT_TRANSACTION_TYPE_SPLIT    InvestmentTransactionType = 4
+)
+
+// InvestmentTransaction represents investment transaction data stored in database,
+// quantity is the number of shares for buy and sell transactions and the split ratio (new shares per old share) for split transactions
+type InvestmentTransaction struct {
+	InvestmentTransactionId int64                     `xorm:"PK"`
+	Uid                     int64                     `xorm:"INDEX(IDX_investment_transaction_uid_deleted_account_id_time) INDEX(IDX_investment_transaction_uid_deleted_security_id_time) NOT NULL"`
+	Deleted                 bool                      `xorm:"INDEX(IDX_investment_transaction_uid_deleted_account_id_time) INDEX(IDX_investment_transaction_uid_deleted_security_id_time) NOT NULL"`
+	AccountId               int64                     `xorm:"INDEX(IDX_investment_transaction_uid_deleted_account_id_time) NOT NULL"`
+	SecurityId              int64                     `xorm:"INDEX(IDX_investment_transaction_uid_deleted_security_id_time) NOT NULL"`
+	TransactionTime         int64                     `xorm:"INDEX(IDX_investment_transaction_uid_deleted_account_id_time) INDEX(IDX_investment_transaction_uid_deleted_security_id_time) NOT NULL"`
+	Type                    InvestmentTransactionType `xorm:"NOT NULL"`
+	Quantity                float64                   `xorm:"NOT NULL"`
+	Price                   int64                     `xorm:"NOT NULL"`
+	Amount                  int64                     `xorm:"NOT NULL"`
+	Fee                     int64                     `xorm:"NOT NULL"`
+	Comment                 string                    `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime         int64
+	UpdatedUnixTime         int64
+	DeletedUnixTime         int64
+}
+
+// InvestmentTransactionListRequest represents all parameters of investment transaction listing request
+type InvestmentTransactionListRequest struct {
+	AccountId  int64 `form:"account_id,string" binding:"min=0"`
+	SecurityId int64 `form:"security_id,string" binding:"min=0"`
+}
+
+// InvestmentTransactionCreateRequest represents all parameters of investment transaction creation request
+type InvestmentTransactionCreateRequest struct {
+	Type       InvestmentTransactionType `json:"type" binding:"required,min=1,max=4"`
+	AccountId  int64                     `json:"accountId,string" binding:"required,min=1"`
+	SecurityId int64                     `json:"securityId,string" binding:"required,min=1"`
+	Time       int64                     `json:"time" binding:"required,min=1"`
+	Quantity   float64                   `json:"quantity" binding:"min=0"`
+	Price      int64                     `json:"price" binding:"min=0,max=99999999999"`
+	Amount     int64                     `json:"amount" binding:"min=0,max=99999999999"`
+	Fee        int64                     `json:"fee" binding:"min=0,max=99999999999"`
+	Comment    string                    `json:"comment" binding:"max=255"`
+}
+
+// InvestmentTransactionModifyRequest represents all parameters of investment transaction modification request
+type InvestmentTransactionModifyRequest struct {
+	Id       int64   `json:"id,string" binding:"required,min=1"`
+	Time     int64   `json:"time" binding:"required,min=1"`
+	Quantity float64 `json:"quantity" binding:"min=0"`
+	Price    int64   `json:"price" binding:"min=0,max=99999999999"`
+	Amount   int64   `json:"amount" binding:"min=0,max=99999999999"`
+	Fee      int64   `json:"fee" binding:"min=0,max=99999999999"`
+	Comment  string  `json:"comment" binding:"max=255"`
+}
+
+// InvestmentTransactionDeleteRequest represents all parameters of investment transaction deleting request
+type InvestmentTransactionDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// InvestmentHoldingListRequest represents all parameters of investment holding listing request
+type InvestmentHoldingListRequest struct {
+	AccountId int64 `form:"account_id,string" binding:"min=0"`
+}
+
+// InvestmentTransactionInfoResponse represents a view-object of investment transaction
+type InvestmentTransactionInfoResponse struct {
+	Id         int64                     `json:"id,string"`
+	Type       InvestmentTransactionType `json:"type"`
+	AccountId  int64                     `json:"accountId,string"`
+	SecurityId int64                     `json:"securityId,string"`
+	Time       int64                     `json:"time"`
+	Quantity   float64                   `json:"quantity"`
+	Price      int64                     `json:"price"`
+	Amount     int64                     `json:"amount"`
+	Fee        int64                     `json:"fee"`
+	Comment    string                    `json:"comment"`
+}
+
+// InvestmentHoldingInfoResponse represents a view-object of the holding of a security in an investment account
+type InvestmentHoldingInfoResponse struct {
+	AccountId       int64                        `json:"accountId,string"`
+	SecurityId      int64                        `json:"securityId,string"`
+	Currency        string                       `json:"currency"`
+	Quantity        float64                      `json:"quantity"`
+	CostBasis       int64                        `json:"costBasis"`
+	LatestPrice     int64                        `json:"latestPrice"`
+	LatestPriceTime int64                        `json:"latestPriceTime"`
+	MarketValue     int64                        `json:"marketValue"`
+	UnrealizedGain  int64                        `json:"unrealizedGain"`
+	RealizedGain    int64                        `json:"realizedGain"`
+	DividendIncome  int64                        `json:"dividendIncome"`
+	Lots            []*InvestmentLotInfoResponse `json:"lots"`
+}
+
+// InvestmentLotInfoResponse represents a view-object of a lot of security holding
+type InvestmentLotInfoResponse struct {
+	AcquiredTime int64   `json:"acquiredTime"`
+	Quantity     float64 `json:"quantity"`
+	CostBasis    int64   `json:"costBasis"`
+}
+
+// InvestmentLot represents a lot of security acquired by a buy transaction
+type InvestmentLot struct {
+	AcquiredTime int64
+	Quantity     float64
+	CostBasis    int64
+}
+
+// InvestmentHolding represents the holding of a security in an investment account which is calculated by replaying investment transactions
+type InvestmentHolding struct {
+	AccountId       int64
+	SecurityId      int64
+	CostBasisMethod SecurityCostBasisMethod
+	Lots            []*InvestmentLot
+	RealizedGain    int64
+	DividendIncome  int64
+}
+
+// InvestmentHoldings represents the holdings of all account and security pairs
+type InvestmentHoldings struct {
+	holdings   []*InvestmentHolding
+	holdingMap map[int64]map[int64]*InvestmentHolding
+}
+
+// IsValid returns whether the investment transaction type is valid
+func (t InvestmentTransactionType) IsValid() bool {
+	return t >= INVESTMENT_TRANSACTION_TYPE_BUY && t <= INVESTMENT_TRANSACTION_TYPE_SPLIT
+}
+
+// FillAmount calculates the amount by quantity and price if the amount is not set for buy and sell transactions
+func (t *InvestmentTransaction) FillAmount() {
+	if (t.Type == INVESTMENT_TRANSACTION_TYPE_BUY || t.Type == INVESTMENT_TRANSACTION_TYPE_SELL) && t.Amount == 0 && t.Price > 0 {
+		t.Amount = int64(math.Round(t.Quantity * float64(t.Price)))
+	}
+}
+
+// Validate returns error if the quantity or amount is not valid for the investment transaction type
+func (t *InvestmentTransaction) Validate() error {
+	if !t.Type.IsValid() {
+		return errs.ErrInvestmentTransactionTypeInvalid
+	}
+
+	if t.Type == INVESTMENT_TRANSACTION_TYPE_BUY || t.Type == INVESTMENT_TRANSACTION_TYPE_SELL || t.Type == INVESTMENT_TRANSACTION_TYPE_SPLIT {
+		if t.Quantity < minimumHoldingQuantity || math.IsInf(t.Quantity, 0) || math.IsNaN(t.Quantity) {
+			return errs.ErrInvestmentTransactionQuantityInvalid
+		}
+	}
+
+	if t.Type == INVESTMENT_TRANSACTION_TYPE_DIVIDEND && t.Amount <= 0 {
+		return errs.ErrInvestmentTransactionAmountInvalid
+	}
+
+	if t.Amount < 0 || t.Fee < 0 {
+		return errs.ErrInvestmentTransactionAmountInvalid
+	}
+
+	return nil
+}
+
+// ToInvestmentTransactionInfoResponse returns a view-object according to database model
+func (t *InvestmentTransaction) ToInvestmentTransactionInfoResponse() *InvestmentTransactionInfoResponse {
+	return &InvestmentTransactionInfoResponse{
+		Id:         t.InvestmentTransactionId,
+		Type:       t.Type,
+		AccountId:  t.AccountId,
+		SecurityId: t.SecurityId,
+		Time:       t.TransactionTime,
+		Quantity:   t.Quantity,
+		Price:      t.Price,
+		Amount:     t.Amount,
+		Fee:        t.Fee,
+		Comment:    t.Comment,
+	}
+}
+
+// NewInvestmentHolding returns a new empty holding of the security in the account
+func NewInvestmentHolding(accountId int64, securityId int64, costBasisMethod SecurityCostBasisMethod) *InvestmentHolding {
+	return &InvestmentHolding{
+		AccountId:       accountId,
+		SecurityId:      securityId,
+		CostBasisMethod: costBasisMethod,
+		Lots:            make([]*InvestmentLot, 0),
+	}
+}
+
+// GetQuantity returns the total quantity of all lots
+func (h *InvestmentHolding) GetQuantity() float64 {
+	quantity := float64(0)
+
+	for i := 0; i < len(h.Lots); i++ {
+		quantity += h.Lots[i].Quantity
+	}
+
+	return quantity
+}
+
+// GetCostBasis returns the total cost basis of all lots
+func (h *InvestmentHolding) GetCostBasis() int64 {
+	costBasis := int64(0)
+
+	for i := 0; i < len(h.Lots); i++ {
+		costBasis += h.Lots[i].CostBasis
+	}
+
+	return costBasis
+}
+
+// GetMarketValue returns the market value of the holding by the specified price
+func (h *InvestmentHolding) GetMarketValue(price int64) int64 {
+	return int64(math.Round(h.GetQuantity() * float64(price)))
+}
+
+// Apply updates the lots, realized gain and dividend income of the holding by the investment transaction,
+// the investment transactions must be applied in ascending order of transaction time
+func (h *InvestmentHolding) Apply(transaction *InvestmentTransaction) error {
+	switch transaction.Type {
+	case INVESTMENT_TRANSACTION_TYPE_BUY:
+		h.applyBuy(transaction)
+		return nil
+	case INVESTMENT_TRANSACTION_TYPE_SELL:
+		return h.applySell(transaction)
+	case INVESTMENT_TRANSACTION_TYPE_DIVIDEND:
+		h.DividendIncome += transaction.Amount - transaction.Fee
+		return nil
+	case INVESTMENT_TRANSACTION_TYPE_SPLIT:
+		if transaction.Quantity < minimumHoldingQuantity {
+			return errs.ErrInvestmentTransactionQuantityInvalid
+		}
+
+		for i := 0; i < len(h.Lots); i++ {
+			h.Lots[i].Quantity *= transaction.Quantity
+		}
+
+		return nil
+	}
+
+	return errs.ErrInvestmentTransactionTypeInvalid
+}
+
+// ToInvestmentHoldingInfoResponse returns a view-object according to the holding and the latest price of the security
+func (h *InvestmentHolding) ToInvestmentHoldingInfoResponse(currency string, latestPrice *SecurityPrice) *InvestmentHoldingInfoResponse {
+	resp := &InvestmentHoldingInfoResponse{
+		AccountId:      h.AccountId,
+		SecurityId:     h.SecurityId,
+		Currency:       currency,
+		Quantity:       h.GetQuantity(),
+		CostBasis:      h.GetCostBasis(),
+		RealizedGain:   h.RealizedGain,
+		DividendIncome: h.DividendIncome,
+		Lots:           make([]*InvestmentLotInfoResponse, len(h.Lots)),
+	}
+
+	if latestPrice != nil {
+		resp.LatestPrice = latestPrice.Price
+		resp.LatestPriceTime = latestPrice.PriceTime
+		resp.MarketValue = h.GetMarketValue(latestPrice.Price)
+		resp.UnrealizedGain = resp.MarketValue - resp.CostBasis
+	}
+
+	for i := 0; i < len(h.Lots); i++ {
+		resp.Lots[i] = &InvestmentLotInfoResponse{
+			AcquiredTime: h.Lots[i].AcquiredTime,
+			Quantity:     h.Lots[i].Quantity,
+			CostBasis:    h.Lots[i].CostBasis,
+		}
+	}
+
+	return resp
+}
+
+func (h *InvestmentHolding) applyBuy(transaction *InvestmentTransaction) {
+	costBasis := transaction.Amount + transaction.Fee
+
+	if h.CostBasisMethod == SECURITY_COST_BASIS_METHOD_AVERAGE && len(h.Lots) > 0 {
+		h.Lots[0].Quantity += transaction.Quantity
+		h.Lots[0].CostBasis += costBasis
+		return
+	}
+
+	h.Lots = append(h.Lots, &InvestmentLot{
+		AcquiredTime: transaction.TransactionTime,
+		Quantity:     transaction.Quantity,
+		CostBasis:    costBasis,
+	})
+}
+
+func (h *InvestmentHolding) applySell(transaction *InvestmentTransaction) error {
+	if transaction.Quantity-h.GetQuantity() > minimumHoldingQuantity {
+		return errs.ErrInvestmentSellQuantityExceedsHolding
+	}
+
+	remainingQuantity := transaction.Quantity
+	consumedCostBasis := int64(0)
+
+	for len(h.Lots) > 0 && remainingQuantity > minimumHoldingQuantity {
+		lot := h.Lots[0]
+
+		if lot.Quantity-remainingQuantity <= minimumHoldingQuantity {
+			remainingQuantity -= lot.Quantity
+			consumedCostBasis += lot.CostBasis
+			h.Lots = h.Lots[1:]
+			continue
+		}
+
+		lotConsumedCostBasis := int64(math.Round(float64(lot.CostBasis) * remainingQuantity / lot.Quantity))
+		lot.Quantity -= remainingQuantity
+		lot.CostBasis -= lotConsumedCostBasis
+		consumedCostBasis += lotConsumedCostBasis
+		remainingQuantity = 0
+	}
+
+	h.RealizedGain += transaction.Amount - transaction.Fee - consumedCostBasis
+
+	return nil
+}
+
+// NewInvestmentHoldings returns a new empty holdings container
+func NewInvestmentHoldings() *InvestmentHoldings {
+	return &InvestmentHoldings{
+		holdings:   make([]*InvestmentHolding, 0),
+		holdingMap: make(map[int64]map[int64]*InvestmentHolding),
+	}
+}
+
+// Apply updates the holding of the account and security of the investment transaction, creates a new holding if not exists
+func (h *InvestmentHoldings) Apply(transaction *InvestmentTransaction, costBasisMethod SecurityCostBasisMethod) error {
+	accountHoldings, exists := h.holdingMap[transaction.AccountId]
+
+	if !exists {
+		accountHoldings = make(map[int64]*InvestmentHolding)
+		h.holdingMap[transaction.AccountId] = accountHoldings
+	}
+
+	holding, exists := accountHoldings[transaction.SecurityId]
+
+	if !exists {
+		holding = NewInvestmentHolding(transaction.AccountId, transaction.SecurityId, costBasisMethod)
+		accountHoldings[transaction.SecurityId] = holding
+		h.holdings = append(h.holdings, holding)
+	}
+
+	return holding.Apply(transaction)
+}
+
+// GetAllHoldings returns all holdings in the order of their first investment transaction
+func (h *InvestmentHoldings) GetAllHoldings() []*InvestmentHolding {
+	return h.holdings
+}
+
+// GetAccountHoldings returns all holdings of the specified account
+func (h *InvestmentHoldings) GetAccountHoldings(accountId int64) []*InvestmentHolding {
+	accountHoldings := make([]*InvestmentHolding, 0)
+
+	for i := 0; i < len(h.holdings); i++ {
+		if h.holdings[i].AccountId == accountId {
+			accountHoldings = append(accountHoldings, h.holdings[i])
+		}
+	}
+
+	return accountHoldings
+}
+
+// GetAccountValuation returns the total cost basis, market value and realized gain (including dividend income) of all holdings of the specified account,
+// the cost basis is used as the market value of the holding which has no price
+func (h *InvestmentHoldings) GetAccountValuation(accountId int64, priceMap map[int64]*SecurityPrice) (costBasis int64, marketValue int64, realizedGain int64) {
+	accountHoldings := h.GetAccountHoldings(accountId)
+
+	for i := 0; i < len(accountHoldings); i++ {
+		holding := accountHoldings[i]
+		holdingCostBasis := holding.GetCostBasis()
+
+		costBasis += holdingCostBasis
+		realizedGain += holding.RealizedGain + holding.DividendIncome
+
+		if price, exists := priceMap[holding.SecurityId]; exists && price != nil {
+			marketValue += holding.GetMarketValue(price.Price)
+		} else {
+			marketValue += holdingCostBasis
+		}
+	}
+
+	return costBasis, marketValue, realizedGain
+}
+
+// InvestmentTransactionSlice represents the slice data structure of InvestmentTransaction
+type InvestmentTransactionSlice []*InvestmentTransaction
+
+// Len returns the count of items
+func (s InvestmentTransactionSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s InvestmentTransactionSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s InvestmentTransactionSlice) Less(i, j int) bool {
+	if s[i].TransactionTime != s[j].TransactionTime {
+		return s[i].TransactionTime < s[j].TransactionTime
+	}
+
+	return s[i].InvestmentTransactionId < s[j].InvestmentTransactionId
+}
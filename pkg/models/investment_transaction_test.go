@@ -0,0 +1,132 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestInvestmentTransactionFillAmount(t *testing.T) {
+	transaction := &InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 1.5, Price: 10001}
+	transaction.FillAmount()
+	assert.Equal(t, int64(15002), transaction.Amount)
+
+	transaction = &InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 2, Price: 10000, Amount: 19900}
+	transaction.FillAmount()
+	assert.Equal(t, int64(19900), transaction.Amount)
+
+	transaction = &InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SPLIT, Quantity: 2, Price: 10000}
+	transaction.FillAmount()
+	assert.Equal(t, int64(0), transaction.Amount)
+}
+
+func TestInvestmentTransactionValidate(t *testing.T) {
+	assert.Equal(t, errs.ErrInvestmentTransactionTypeInvalid, (&InvestmentTransaction{Type: 0}).Validate())
+	assert.Equal(t, errs.ErrInvestmentTransactionQuantityInvalid, (&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 0}).Validate())
+	assert.Equal(t, errs.ErrInvestmentTransactionQuantityInvalid, (&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SPLIT, Quantity: 0}).Validate())
+	assert.Equal(t, errs.ErrInvestmentTransactionAmountInvalid, (&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_DIVIDEND, Amount: 0}).Validate())
+	assert.Equal(t, errs.ErrInvestmentTransactionAmountInvalid, (&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SELL, Quantity: 1, Amount: 100, Fee: -1}).Validate())
+	assert.Nil(t, (&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 1, Amount: 100}).Validate())
+	assert.Nil(t, (&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_DIVIDEND, Amount: 100}).Validate())
+}
+
+func TestInvestmentHoldingApply_FifoSell(t *testing.T) {
+	holding := NewInvestmentHolding(1, 2, SECURITY_COST_BASIS_METHOD_FIFO)
+
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, TransactionTime: 100, Quantity: 10, Amount: 10000, Fee: 100}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, TransactionTime: 200, Quantity: 10, Amount: 20000}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SELL, TransactionTime: 300, Quantity: 15, Amount: 30000, Fee: 200}))
+
+	assert.Equal(t, 1, len(holding.Lots))
+	assert.Equal(t, int64(200), holding.Lots[0].AcquiredTime)
+	assert.Equal(t, float64(5), holding.GetQuantity())
+	assert.Equal(t, int64(10000), holding.GetCostBasis())
+	assert.Equal(t, int64(30000-200-10100-10000), holding.RealizedGain)
+}
+
+func TestInvestmentHoldingApply_AverageCostSell(t *testing.T) {
+	holding := NewInvestmentHolding(1, 2, SECURITY_COST_BASIS_METHOD_AVERAGE)
+
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, TransactionTime: 100, Quantity: 10, Amount: 10000}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, TransactionTime: 200, Quantity: 10, Amount: 20000}))
+	assert.Equal(t, 1, len(holding.Lots))
+
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SELL, TransactionTime: 300, Quantity: 15, Amount: 30000}))
+
+	assert.Equal(t, float64(5), holding.GetQuantity())
+	assert.Equal(t, int64(7500), holding.GetCostBasis())
+	assert.Equal(t, int64(30000-22500), holding.RealizedGain)
+}
+
+func TestInvestmentHoldingApply_SellAll(t *testing.T) {
+	holding := NewInvestmentHolding(1, 2, SECURITY_COST_BASIS_METHOD_FIFO)
+
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 0.1, Amount: 1000}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 0.2, Amount: 2000}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SELL, Quantity: 0.3, Amount: 3600}))
+
+	assert.Equal(t, 0, len(holding.Lots))
+	assert.Equal(t, int64(0), holding.GetCostBasis())
+	assert.Equal(t, int64(600), holding.RealizedGain)
+}
+
+func TestInvestmentHoldingApply_SellExceedsHolding(t *testing.T) {
+	holding := NewInvestmentHolding(1, 2, SECURITY_COST_BASIS_METHOD_FIFO)
+
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 10, Amount: 10000}))
+	assert.Equal(t, errs.ErrInvestmentSellQuantityExceedsHolding, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SELL, Quantity: 11, Amount: 11000}))
+}
+
+func TestInvestmentHoldingApply_SplitAndDividend(t *testing.T) {
+	holding := NewInvestmentHolding(1, 2, SECURITY_COST_BASIS_METHOD_FIFO)
+
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 10, Amount: 10000}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_SPLIT, Quantity: 4}))
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_DIVIDEND, Amount: 500, Fee: 50}))
+
+	assert.Equal(t, float64(40), holding.GetQuantity())
+	assert.Equal(t, int64(10000), holding.GetCostBasis())
+	assert.Equal(t, int64(450), holding.DividendIncome)
+	assert.Equal(t, int64(12000), holding.GetMarketValue(300))
+}
+
+func TestInvestmentHoldingToInvestmentHoldingInfoResponse(t *testing.T) {
+	holding := NewInvestmentHolding(1, 2, SECURITY_COST_BASIS_METHOD_FIFO)
+	assert.Nil(t, holding.Apply(&InvestmentTransaction{Type: INVESTMENT_TRANSACTION_TYPE_BUY, TransactionTime: 100, Quantity: 10, Amount: 10000}))
+
+	resp := holding.ToInvestmentHoldingInfoResponse("USD", nil)
+	assert.Equal(t, int64(10000), resp.CostBasis)
+	assert.Equal(t, int64(0), resp.MarketValue)
+	assert.Equal(t, int64(0), resp.UnrealizedGain)
+	assert.Equal(t, 1, len(resp.Lots))
+
+	resp = holding.ToInvestmentHoldingInfoResponse("USD", &SecurityPrice{PriceTime: 200, Price: 1200})
+	assert.Equal(t, int64(1200), resp.LatestPrice)
+	assert.Equal(t, int64(200), resp.LatestPriceTime)
+	assert.Equal(t, int64(12000), resp.MarketValue)
+	assert.Equal(t, int64(2000), resp.UnrealizedGain)
+}
+
+func TestInvestmentHoldingsGetAccountValuation(t *testing.T) {
+	holdings := NewInvestmentHoldings()
+
+	assert.Nil(t, holdings.Apply(&InvestmentTransaction{AccountId: 1, SecurityId: 10, Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 10, Amount: 10000}, SECURITY_COST_BASIS_METHOD_FIFO))
+	assert.Nil(t, holdings.Apply(&InvestmentTransaction{AccountId: 1, SecurityId: 11, Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 5, Amount: 5000}, SECURITY_COST_BASIS_METHOD_AVERAGE))
+	assert.Nil(t, holdings.Apply(&InvestmentTransaction{AccountId: 2, SecurityId: 10, Type: INVESTMENT_TRANSACTION_TYPE_BUY, Quantity: 1, Amount: 1000}, SECURITY_COST_BASIS_METHOD_FIFO))
+	assert.Nil(t, holdings.Apply(&InvestmentTransaction{AccountId: 1, SecurityId: 10, Type: INVESTMENT_TRANSACTION_TYPE_SELL, Quantity: 5, Amount: 6000}, SECURITY_COST_BASIS_METHOD_FIFO))
+	assert.Nil(t, holdings.Apply(&InvestmentTransaction{AccountId: 1, SecurityId: 11, Type: INVESTMENT_TRANSACTION_TYPE_DIVIDEND, Amount: 100}, SECURITY_COST_BASIS_METHOD_AVERAGE))
+
+	assert.Equal(t, 3, len(holdings.GetAllHoldings()))
+	assert.Equal(t, 2, len(holdings.GetAccountHoldings(1)))
+	assert.Equal(t, 1, len(holdings.GetAccountHoldings(2)))
+
+	costBasis, marketValue, realizedGain := holdings.GetAccountValuation(1, map[int64]*SecurityPrice{
+		10: {SecurityId: 10, Price: 1500},
+	})
+
+	assert.Equal(t, int64(10000), costBasis)
+	assert.Equal(t, int64(5*1500+5000), marketValue)
+	assert.Equal(t, int64(1000+100), realizedGain)
+}
@@ -2,16 +2,17 @@ package models
 
 // RecognizedReceiptImageResponse represents a view-object of recognized receipt image response
 type RecognizedReceiptImageResponse struct {
-	Type                 TransactionType `json:"type"`
-	Time                 int64           `json:"time,omitempty"`
-	CategoryId           int64           `json:"categoryId,string,omitempty"`
-	SourceAccountId      int64           `json:"sourceAccountId,string,omitempty"`
-	DestinationAccountId int64           `json:"destinationAccountId,string,omitempty"`
-	SourceAmount         int64           `json:"sourceAmount,omitempty"`
-	DestinationAmount    int64           `json:"destinationAmount,omitempty"`
-	TagIds               []string        `json:"tagIds,omitempty"`
-	ItemIds              []string        `json:"itemIds,omitempty"`
-	Comment              string          `json:"comment,omitempty"`
+	Type                 TransactionType                      `json:"type"`
+	Time                 int64                                `json:"time,omitempty"`
+	CategoryId           int64                                `json:"categoryId,string,omitempty"`
+	SourceAccountId      int64                                `json:"sourceAccountId,string,omitempty"`
+	DestinationAccountId int64                                `json:"destinationAccountId,string,omitempty"`
+	SourceAmount         int64                                `json:"sourceAmount,omitempty"`
+	DestinationAmount    int64                                `json:"destinationAmount,omitempty"`
+	TagIds               []string                             `json:"tagIds,omitempty"`
+	ItemIds              []string                             `json:"itemIds,omitempty"`
+	ItemDetails          []*TransactionItemDetailInfoResponse `json:"itemDetails,omitempty"`
+	Comment              string                               `json:"comment,omitempty"`
 }
 
 // RecognizedReceiptImageListResponse represents a list of recognized transactions (e.g. from OCR bill list)
@@ -19,16 +20,26 @@ type RecognizedReceiptImageListResponse struct {
 	Transactions []RecognizedReceiptImageResponse `json:"transactions"`
 }
 
+// RecognizedReceiptImageItemDetail represents the recognized quantity, unit, unit price and line amount of a transaction item
+type RecognizedReceiptImageItemDetail struct {
+	ItemName  string `json:"item_name" jsonschema_description:"Transaction item name"`
+	Quantity  string `json:"quantity,omitempty" jsonschema_description:"Quantity of the item"`
+	Unit      string `json:"unit,omitempty" jsonschema_description:"Unit of the quantity (e.g. kg, pcs)"`
+	UnitPrice string `json:"unit_price,omitempty" jsonschema_description:"Price per unit"`
+	Amount    string `json:"amount,omitempty" jsonschema_description:"Line amount of the item"`
+}
+
 // RecognizedReceiptImageResult represents the result of recognized receipt image
 type RecognizedReceiptImageResult struct {
-	Type                   string   `json:"type,omitempty" jsonschema:"enum=income,enum=expense,enum=transfer" jsonschema_description:"Transaction type (income, expense, transfer)"`
-	Time                   string   `json:"time" jsonschema:"format=date-time" jsonschema_description:"Transaction time in long date time format (YYYY-MM-DD HH:mm:ss, e.g. 2023-01-01 12:00:00)"`
-	Amount                 string   `json:"amount,omitempty" jsonschema_description:"Transaction amount"`
-	AccountName            string   `json:"account,omitempty" jsonschema_description:"Account name for the transaction"`
-	CategoryName           string   `json:"category,omitempty" jsonschema_description:"Category name for the transaction"`
-	TagNames               []string `json:"tags,omitempty" jsonschema_description:"List of tags associated with the transaction (maximum 10 tags allowed)"`
-	ItemNames              []string `json:"itemNames,omitempty" jsonschema_description:"Transaction project/item names"`
-	Description            string   `json:"description,omitempty" jsonschema_description:"Transaction description (comment)"`
-	DestinationAmount      string   `json:"destination_amount,omitempty" jsonschema_description:"Destination amount for transfer transactions"`
-	DestinationAccountName string   `json:"destination_account,omitempty" jsonschema_description:"Destination account name for transfer transactions"`
+	Type                   string                              `json:"type,omitempty" jsonschema:"enum=income,enum=expense,enum=transfer" jsonschema_description:"Transaction type (income, expense, transfer)"`
+	Time                   string                              `json:"time" jsonschema:"format=date-time" jsonschema_description:"Transaction time in long date time format (YYYY-MM-DD HH:mm:ss, e.g. 2023-01-01 12:00:00)"`
+	Amount                 string                              `json:"amount,omitempty" jsonschema_description:"Transaction amount"`
+	AccountName            string                              `json:"account,omitempty" jsonschema_description:"Account name for the transaction"`
+	CategoryName           string                              `json:"category,omitempty" jsonschema_description:"Category name for the transaction"`
+	TagNames               []string                            `json:"tags,omitempty" jsonschema_description:"List of tags associated with the transaction (maximum 10 tags allowed)"`
+	ItemNames              []string                            `json:"itemNames,omitempty" jsonschema_description:"Transaction project/item names"`
+	ItemDetails            []*RecognizedReceiptImageItemDetail `json:"item_details,omitempty" jsonschema_description:"Quantity, unit, unit price and line amount of transaction items"`
+	Description            string                              `json:"description,omitempty" jsonschema_description:"Transaction description (comment)"`
+	DestinationAmount      string                              `json:"destination_amount,omitempty" jsonschema_description:"Destination amount for transfer transactions"`
+	DestinationAccountName string                              `json:"destination_account,omitempty" jsonschema_description:"Destination account name for transfer transactions"`
 }
@@ -0,0 +1,206 @@
+package models
+
+// LedgerMemberRole represents the role of a member in a shared ledger
+type LedgerMemberRole byte
+
+// Ledger member roles
+const (
+	LEDGER_MEMBER_ROLE_NONE   LedgerMemberRole = 0
+	LEDGER_MEMBER_ROLE_VIEWER LedgerMemberRole = 1
+	LEDGER_MEMBER_ROLE_EDITOR LedgerMemberRole = 2
+	LEDGER_MEMBER_ROLE_ADMIN  LedgerMemberRole = 3
+	LEDGER_MEMBER_ROLE_OWNER  LedgerMemberRole = 4
+)
+
+// LedgerMemberStatus represents the status of a member in a shared ledger
+type LedgerMemberStatus byte
+
+// Ledger member statuses
+const (
+	LEDGER_MEMBER_STATUS_PENDING  LedgerMemberStatus = 1
+	LEDGER_MEMBER_STATUS_ACCEPTED LedgerMemberStatus = 2
+)
+
+// Ledger represents a shared ledger stored in database, a ledger shares all the data of its owner with the ledger members
+type Ledger struct {
+	LedgerId        int64  `xorm:"PK"`
+	OwnerUid        int64  `xorm:"INDEX(IDX_ledger_owner_uid_deleted) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_ledger_owner_uid_deleted) NOT NULL"`
+	Name            string `xorm:"VARCHAR(64) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// LedgerMember represents a member of shared ledger stored in database
+type LedgerMember struct {
+	MemberId        int64              `xorm:"PK"`
+	LedgerId        int64              `xorm:"INDEX(IDX_ledger_member_ledger_id_deleted) NOT NULL"`
+	Uid             int64              `xorm:"INDEX(IDX_ledger_member_uid_deleted) NOT NULL"`
+	Deleted         bool               `xorm:"INDEX(IDX_ledger_member_ledger_id_deleted) INDEX(IDX_ledger_member_uid_deleted) NOT NULL"`
+	Role            LedgerMemberRole   `xorm:"NOT NULL"`
+	Status          LedgerMemberStatus `xorm:"NOT NULL"`
+	InviterUid      int64              `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// LedgerCreateRequest represents all parameters of shared ledger creation request
+type LedgerCreateRequest struct {
+	Name string `json:"name" binding:"required,notBlank,max=64"`
+}
+
+// LedgerModifyRequest represents all parameters of shared ledger modification request
+type LedgerModifyRequest struct {
+	Id   int64  `json:"id,string" binding:"required,min=1"`
+	Name string `json:"name" binding:"required,notBlank,max=64"`
+}
+
+// LedgerDeleteRequest represents all parameters of shared ledger deleting request
+type LedgerDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// LedgerMemberInviteRequest represents all parameters of shared ledger member invitation request
+type LedgerMemberInviteRequest struct {
+	LedgerId int64            `json:"ledgerId,string" binding:"required,min=1"`
+	Username string           `json:"username" binding:"required,notBlank,max=32"`
+	Role     LedgerMemberRole `json:"role" binding:"required,min=1,max=3"`
+}
+
+// LedgerMemberModifyRoleRequest represents all parameters of shared ledger member role modification request
+type LedgerMemberModifyRoleRequest struct {
+	Id   int64            `json:"id,string" binding:"required,min=1"`
+	Role LedgerMemberRole `json:"role" binding:"required,min=1,max=3"`
+}
+
+// LedgerMemberRemoveRequest represents all parameters of shared ledger member removing (or leaving) request
+type LedgerMemberRemoveRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// LedgerInvitationAcceptRequest represents all parameters of shared ledger invitation accepting request
+type LedgerInvitationAcceptRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// LedgerInfoResponse represents a view-object of shared ledger
+type LedgerInfoResponse struct {
+	Id            int64                       `json:"id,string"`
+	Name          string                      `json:"name"`
+	OwnerUsername string                      `json:"ownerUsername"`
+	OwnerNickname string                      `json:"ownerNickname"`
+	Role          LedgerMemberRole            `json:"role"`
+	MemberId      int64                       `json:"memberId,string,omitempty"`
+	Status        LedgerMemberStatus          `json:"status,omitempty"`
+	Members       []*LedgerMemberInfoResponse `json:"members,omitempty"`
+}
+
+// LedgerMemberInfoResponse represents a view-object of shared ledger member
+type LedgerMemberInfoResponse struct {
+	Id       int64              `json:"id,string"`
+	Username string             `json:"username"`
+	Nickname string             `json:"nickname"`
+	Role     LedgerMemberRole   `json:"role"`
+	Status   LedgerMemberStatus `json:"status"`
+}
+
+// IsValid returns whether the role can be assigned to a ledger member
+func (r LedgerMemberRole) IsValid() bool {
+	return r == LEDGER_MEMBER_ROLE_VIEWER || r == LEDGER_MEMBER_ROLE_EDITOR || r == LEDGER_MEMBER_ROLE_ADMIN
+}
+
+// HasPermissionOf returns whether the role has all the permissions of the required role
+func (r LedgerMemberRole) HasPermissionOf(requiredRole LedgerMemberRole) bool {
+	return r != LEDGER_MEMBER_ROLE_NONE && r >= requiredRole
+}
+
+// CanRead returns whether the role can read the data of the ledger
+func (r LedgerMemberRole) CanRead() bool {
+	return r >= LEDGER_MEMBER_ROLE_VIEWER
+}
+
+// CanWrite returns whether the role can create, modify or delete the data of the ledger
+func (r LedgerMemberRole) CanWrite() bool {
+	return r >= LEDGER_MEMBER_ROLE_EDITOR
+}
+
+// CanManage returns whether the role can manage the members of the ledger
+func (r LedgerMemberRole) CanManage() bool {
+	return r >= LEDGER_MEMBER_ROLE_ADMIN
+}
+
+// CanManageRole returns whether the role can invite, modify or remove a member with the specified role
+func (r LedgerMemberRole) CanManageRole(target LedgerMemberRole) bool {
+	return r.CanManage() && target < r
+}
+
+// GetEffectiveRole returns the role of the member, returns none if the member has not accepted the invitation
+func (m *LedgerMember) GetEffectiveRole() LedgerMemberRole {
+	if m.Status != LEDGER_MEMBER_STATUS_ACCEPTED {
+		return LEDGER_MEMBER_ROLE_NONE
+	}
+
+	return m.Role
+}
+
+// ToLedgerMemberInfoResponse returns a view-object according to database model
+func (m *LedgerMember) ToLedgerMemberInfoResponse(user *User) *LedgerMemberInfoResponse {
+	resp := &LedgerMemberInfoResponse{
+		Id:     m.MemberId,
+		Role:   m.Role,
+		Status: m.Status,
+	}
+
+	if user != nil {
+		resp.Username = user.Username
+		resp.Nickname = user.Nickname
+	}
+
+	return resp
+}
+
+// ToLedgerInfoResponse returns a view-object according to database model
+func (l *Ledger) ToLedgerInfoResponse(owner *User, member *LedgerMember) *LedgerInfoResponse {
+	resp := &LedgerInfoResponse{
+		Id:   l.LedgerId,
+		Name: l.Name,
+		Role: LEDGER_MEMBER_ROLE_OWNER,
+	}
+
+	if owner != nil {
+		resp.OwnerUsername = owner.Username
+		resp.OwnerNickname = owner.Nickname
+	}
+
+	if member != nil {
+		resp.Role = member.Role
+		resp.MemberId = member.MemberId
+		resp.Status = member.Status
+	}
+
+	return resp
+}
+
+// LedgerInfoResponseSlice represents the slice data structure of LedgerInfoResponse
+type LedgerInfoResponseSlice []*LedgerInfoResponse
+
+// Len returns the count of items
+func (s LedgerInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s LedgerInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s LedgerInfoResponseSlice) Less(i, j int) bool {
+	if s[i].Role != s[j].Role {
+		return s[i].Role > s[j].Role
+	}
+
+	return s[i].Id < s[j].Id
+}
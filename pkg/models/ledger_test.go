@@ -0,0 +1,127 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerMemberRoleIsValid(t *testing.T) {
+	assert.False(t, LEDGER_MEMBER_ROLE_NONE.IsValid())
+	assert.True(t, LEDGER_MEMBER_ROLE_VIEWER.IsValid())
+	assert.True(t, LEDGER_MEMBER_ROLE_EDITOR.IsValid())
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.IsValid())
+	assert.False(t, LEDGER_MEMBER_ROLE_OWNER.IsValid())
+}
+
+func TestLedgerMemberRolePermissions(t *testing.T) {
+	assert.False(t, LEDGER_MEMBER_ROLE_NONE.CanRead())
+	assert.False(t, LEDGER_MEMBER_ROLE_NONE.CanWrite())
+	assert.False(t, LEDGER_MEMBER_ROLE_NONE.CanManage())
+
+	assert.True(t, LEDGER_MEMBER_ROLE_VIEWER.CanRead())
+	assert.False(t, LEDGER_MEMBER_ROLE_VIEWER.CanWrite())
+	assert.False(t, LEDGER_MEMBER_ROLE_VIEWER.CanManage())
+
+	assert.True(t, LEDGER_MEMBER_ROLE_EDITOR.CanRead())
+	assert.True(t, LEDGER_MEMBER_ROLE_EDITOR.CanWrite())
+	assert.False(t, LEDGER_MEMBER_ROLE_EDITOR.CanManage())
+
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.CanRead())
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.CanWrite())
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.CanManage())
+
+	assert.True(t, LEDGER_MEMBER_ROLE_OWNER.CanRead())
+	assert.True(t, LEDGER_MEMBER_ROLE_OWNER.CanWrite())
+	assert.True(t, LEDGER_MEMBER_ROLE_OWNER.CanManage())
+}
+
+func TestLedgerMemberRoleHasPermissionOf(t *testing.T) {
+	assert.False(t, LEDGER_MEMBER_ROLE_NONE.HasPermissionOf(LEDGER_MEMBER_ROLE_NONE))
+	assert.False(t, LEDGER_MEMBER_ROLE_NONE.HasPermissionOf(LEDGER_MEMBER_ROLE_VIEWER))
+
+	assert.True(t, LEDGER_MEMBER_ROLE_VIEWER.HasPermissionOf(LEDGER_MEMBER_ROLE_VIEWER))
+	assert.False(t, LEDGER_MEMBER_ROLE_VIEWER.HasPermissionOf(LEDGER_MEMBER_ROLE_EDITOR))
+
+	assert.True(t, LEDGER_MEMBER_ROLE_EDITOR.HasPermissionOf(LEDGER_MEMBER_ROLE_EDITOR))
+	assert.False(t, LEDGER_MEMBER_ROLE_EDITOR.HasPermissionOf(LEDGER_MEMBER_ROLE_ADMIN))
+
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.HasPermissionOf(LEDGER_MEMBER_ROLE_ADMIN))
+	assert.True(t, LEDGER_MEMBER_ROLE_OWNER.HasPermissionOf(LEDGER_MEMBER_ROLE_ADMIN))
+}
+
+func TestLedgerMemberRoleCanManageRole(t *testing.T) {
+	assert.False(t, LEDGER_MEMBER_ROLE_EDITOR.CanManageRole(LEDGER_MEMBER_ROLE_VIEWER))
+
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.CanManageRole(LEDGER_MEMBER_ROLE_VIEWER))
+	assert.True(t, LEDGER_MEMBER_ROLE_ADMIN.CanManageRole(LEDGER_MEMBER_ROLE_EDITOR))
+	assert.False(t, LEDGER_MEMBER_ROLE_ADMIN.CanManageRole(LEDGER_MEMBER_ROLE_ADMIN))
+
+	assert.True(t, LEDGER_MEMBER_ROLE_OWNER.CanManageRole(LEDGER_MEMBER_ROLE_ADMIN))
+}
+
+func TestLedgerMemberGetEffectiveRole(t *testing.T) {
+	member := &LedgerMember{
+		Role:   LEDGER_MEMBER_ROLE_EDITOR,
+		Status: LEDGER_MEMBER_STATUS_PENDING,
+	}
+	assert.Equal(t, LEDGER_MEMBER_ROLE_NONE, member.GetEffectiveRole())
+
+	member.Status = LEDGER_MEMBER_STATUS_ACCEPTED
+	assert.Equal(t, LEDGER_MEMBER_ROLE_EDITOR, member.GetEffectiveRole())
+}
+
+func TestLedgerToLedgerInfoResponse(t *testing.T) {
+	ledger := &Ledger{
+		LedgerId: 1,
+		OwnerUid: 10,
+		Name:     "Household",
+	}
+	owner := &User{
+		Uid:      10,
+		Username: "owner",
+		Nickname: "Owner",
+	}
+
+	ledgerResp := ledger.ToLedgerInfoResponse(owner, nil)
+	assert.Equal(t, int64(1), ledgerResp.Id)
+	assert.Equal(t, "owner", ledgerResp.OwnerUsername)
+	assert.Equal(t, LEDGER_MEMBER_ROLE_OWNER, ledgerResp.Role)
+	assert.Equal(t, int64(0), ledgerResp.MemberId)
+
+	member := &LedgerMember{
+		MemberId: 2,
+		LedgerId: 1,
+		Uid:      11,
+		Role:     LEDGER_MEMBER_ROLE_VIEWER,
+		Status:   LEDGER_MEMBER_STATUS_PENDING,
+	}
+
+	ledgerResp = ledger.ToLedgerInfoResponse(owner, member)
+	assert.Equal(t, LEDGER_MEMBER_ROLE_VIEWER, ledgerResp.Role)
+	assert.Equal(t, int64(2), ledgerResp.MemberId)
+	assert.Equal(t, LEDGER_MEMBER_STATUS_PENDING, ledgerResp.Status)
+}
+
+func TestLedgerInfoResponseSliceLess(t *testing.T) {
+	var ledgerRespSlice LedgerInfoResponseSlice
+	ledgerRespSlice = append(ledgerRespSlice, &LedgerInfoResponse{
+		Id:   3,
+		Role: LEDGER_MEMBER_ROLE_VIEWER,
+	})
+	ledgerRespSlice = append(ledgerRespSlice, &LedgerInfoResponse{
+		Id:   2,
+		Role: LEDGER_MEMBER_ROLE_VIEWER,
+	})
+	ledgerRespSlice = append(ledgerRespSlice, &LedgerInfoResponse{
+		Id:   1,
+		Role: LEDGER_MEMBER_ROLE_OWNER,
+	})
+
+	sort.Sort(ledgerRespSlice)
+
+	assert.Equal(t, int64(1), ledgerRespSlice[0].Id)
+	assert.Equal(t, int64(2), ledgerRespSlice[1].Id)
+	assert.Equal(t, int64(3), ledgerRespSlice[2].Id)
+}
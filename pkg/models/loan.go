@@ -0,0 +1,269 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Loan represents loan data stored in database, the payments of loan are made monthly with fixed amount (annuity)
+type Loan struct {
+	LoanId              int64   `xorm:"PK"`
+	Uid                 int64   `xorm:"INDEX(IDX_loan_uid_deleted_account_id) NOT NULL"`
+	Deleted             bool    `xorm:"INDEX(IDX_loan_uid_deleted_account_id) INDEX(IDX_loan_deleted_scheduled_enabled_scheduled_at) NOT NULL"`
+	AccountId           int64   `xorm:"INDEX(IDX_loan_uid_deleted_account_id) NOT NULL"`
+	PaymentAccountId    int64   `xorm:"NOT NULL"`
+	PrincipalCategoryId int64   `xorm:"NOT NULL"`
+	InterestCategoryId  int64   `xorm:"NOT NULL"`
+	Name                string  `xorm:"VARCHAR(64) NOT NULL"`
+	Principal           int64   `xorm:"NOT NULL"`
+	AnnualInterestRate  float64 `xorm:"NOT NULL"`
+	TermMonths          int32   `xorm:"NOT NULL"`
+	PaymentDay          int32   `xorm:"NOT NULL"`
+	StartTime           int64   `xorm:"NOT NULL"`
+	TimezoneUtcOffset   int16   `xorm:"NOT NULL"`
+	ScheduledEnabled    bool    `xorm:"INDEX(IDX_loan_deleted_scheduled_enabled_scheduled_at) NOT NULL"`
+	ScheduledAt         int16   `xorm:"INDEX(IDX_loan_deleted_scheduled_enabled_scheduled_at) NOT NULL"`
+	PaidPeriods         int32   `xorm:"NOT NULL"`
+	Comment             string  `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime     int64
+	UpdatedUnixTime     int64
+	DeletedUnixTime     int64
+}
+
+// LoanGetRequest represents all parameters of loan getting request
+type LoanGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// LoanCreateRequest represents all parameters of loan creation request
+type LoanCreateRequest struct {
+	Name                string  `json:"name" binding:"required,notBlank,max=64"`
+	AccountId           int64   `json:"accountId,string" binding:"required,min=1"`
+	PaymentAccountId    int64   `json:"paymentAccountId,string" binding:"required,min=1"`
+	PrincipalCategoryId int64   `json:"principalCategoryId,string" binding:"required,min=1"`
+	InterestCategoryId  int64   `json:"interestCategoryId,string" binding:"required,min=1"`
+	Principal           int64   `json:"principal" binding:"required,min=1,max=99999999999"`
+	AnnualInterestRate  float64 `json:"annualInterestRate" binding:"min=0,max=100"`
+	TermMonths          int32   `json:"termMonths" binding:"required,min=1,max=600"`
+	PaymentDay          int32   `json:"paymentDay" binding:"required,min=1,max=28"`
+	StartTime           int64   `json:"startTime" binding:"required,min=1"`
+	UtcOffset           int16   `json:"utcOffset" binding:"min=-720,max=840"`
+	PaidPeriods         *int32  `json:"paidPeriods" binding:"omitempty,min=0"`
+	ScheduledEnabled    bool    `json:"scheduledEnabled"`
+	Comment             string  `json:"comment" binding:"max=255"`
+}
+
+// LoanModifyRequest represents all parameters of loan modification request
+type LoanModifyRequest struct {
+	Id                  int64  `json:"id,string" binding:"required,min=1"`
+	Name                string `json:"name" binding:"required,notBlank,max=64"`
+	PaymentAccountId    int64  `json:"paymentAccountId,string" binding:"required,min=1"`
+	PrincipalCategoryId int64  `json:"principalCategoryId,string" binding:"required,min=1"`
+	InterestCategoryId  int64  `json:"interestCategoryId,string" binding:"required,min=1"`
+	PaidPeriods         int32  `json:"paidPeriods" binding:"min=0"`
+	ScheduledEnabled    bool   `json:"scheduledEnabled"`
+	Comment             string `json:"comment" binding:"max=255"`
+}
+
+// LoanDeleteRequest represents all parameters of loan deleting request
+type LoanDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// LoanAmortizationItem represents a payment period in the amortization schedule of loan
+type LoanAmortizationItem struct {
+	Period             int32
+	PaymentTime        int64
+	Payment            int64
+	Principal          int64
+	Interest           int64
+	RemainingPrincipal int64
+}
+
+// LoanInfoResponse represents a view-object of loan
+type LoanInfoResponse struct {
+	Id                  int64   `json:"id,string"`
+	Name                string  `json:"name"`
+	AccountId           int64   `json:"accountId,string"`
+	PaymentAccountId    int64   `json:"paymentAccountId,string"`
+	PrincipalCategoryId int64   `json:"principalCategoryId,string"`
+	InterestCategoryId  int64   `json:"interestCategoryId,string"`
+	Principal           int64   `json:"principal"`
+	AnnualInterestRate  float64 `json:"annualInterestRate"`
+	TermMonths          int32   `json:"termMonths"`
+	PaymentDay          int32   `json:"paymentDay"`
+	StartTime           int64   `json:"startTime"`
+	UtcOffset           int16   `json:"utcOffset"`
+	ScheduledEnabled    bool    `json:"scheduledEnabled"`
+	PaidPeriods         int32   `json:"paidPeriods"`
+	MonthlyPayment      int64   `json:"monthlyPayment"`
+	TotalInterest       int64   `json:"totalInterest"`
+	RemainingPrincipal  int64   `json:"remainingPrincipal"`
+	NextPaymentTime     int64   `json:"nextPaymentTime,omitempty"`
+	PayoffTime          int64   `json:"payoffTime"`
+	Comment             string  `json:"comment"`
+}
+
+// LoanAmortizationItemResponse represents a view-object of a payment period in the amortization schedule of loan
+type LoanAmortizationItemResponse struct {
+	Period             int32 `json:"period"`
+	PaymentTime        int64 `json:"paymentTime"`
+	Payment            int64 `json:"payment"`
+	Principal          int64 `json:"principal"`
+	Interest           int64 `json:"interest"`
+	RemainingPrincipal int64 `json:"remainingPrincipal"`
+	Paid               bool  `json:"paid"`
+}
+
+// GetMonthlyInterestRate returns the monthly interest rate of loan
+func (l *Loan) GetMonthlyInterestRate() float64 {
+	return l.AnnualInterestRate / 100 / 12
+}
+
+// GetMonthlyPayment returns the fixed monthly payment amount of loan
+func (l *Loan) GetMonthlyPayment() int64 {
+	if l.TermMonths < 1 {
+		return 0
+	}
+
+	monthlyRate := l.GetMonthlyInterestRate()
+
+	if monthlyRate <= 0 {
+		return int64(math.Ceil(float64(l.Principal) / float64(l.TermMonths)))
+	}
+
+	return int64(math.Round(float64(l.Principal) * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(l.TermMonths)))))
+}
+
+// GetPaymentTime returns the unix time of the payment date of the specified period (starts from 1),
+// the first payment is made in the month after the loan start time
+func (l *Loan) GetPaymentTime(period int32) int64 {
+	timezone := time.FixedZone("Loan Timezone", int(l.TimezoneUtcOffset)*60)
+	startTime := time.Unix(l.StartTime, 0).In(timezone)
+	paymentTime := time.Date(startTime.Year(), startTime.Month()+time.Month(period), int(l.PaymentDay), 0, 0, 0, 0, timezone)
+
+	return paymentTime.Unix()
+}
+
+// GetAmortizationSchedule returns all payment periods of loan, the last payment pays off all the remaining principal
+func (l *Loan) GetAmortizationSchedule() []*LoanAmortizationItem {
+	schedule := make([]*LoanAmortizationItem, 0, l.TermMonths)
+	monthlyRate := l.GetMonthlyInterestRate()
+	monthlyPayment := l.GetMonthlyPayment()
+	remainingPrincipal := l.Principal
+
+	for period := int32(1); period <= l.TermMonths && remainingPrincipal > 0; period++ {
+		interest := int64(math.Round(float64(remainingPrincipal) * monthlyRate))
+		principal := monthlyPayment - interest
+
+		if period == l.TermMonths || principal > remainingPrincipal {
+			principal = remainingPrincipal
+		}
+
+		remainingPrincipal -= principal
+
+		schedule = append(schedule, &LoanAmortizationItem{
+			Period:             period,
+			PaymentTime:        l.GetPaymentTime(period),
+			Payment:            principal + interest,
+			Principal:          principal,
+			Interest:           interest,
+			RemainingPrincipal: remainingPrincipal,
+		})
+	}
+
+	return schedule
+}
+
+// GetDuePeriods returns the count of periods whose payment time is not later than the specified unix time
+func (l *Loan) GetDuePeriods(unixTime int64) int32 {
+	duePeriods := int32(0)
+
+	for period := int32(1); period <= l.TermMonths && l.GetPaymentTime(period) <= unixTime; period++ {
+		duePeriods = period
+	}
+
+	return duePeriods
+}
+
+// ToLoanInfoResponse returns a view-object according to database model
+func (l *Loan) ToLoanInfoResponse() *LoanInfoResponse {
+	schedule := l.GetAmortizationSchedule()
+	totalInterest := int64(0)
+	remainingPrincipal := l.Principal
+	nextPaymentTime := int64(0)
+	payoffTime := int64(0)
+
+	for i := 0; i < len(schedule); i++ {
+		item := schedule[i]
+		totalInterest += item.Interest
+
+		if item.Period <= l.PaidPeriods {
+			remainingPrincipal = item.RemainingPrincipal
+		} else if nextPaymentTime == 0 {
+			nextPaymentTime = item.PaymentTime
+		}
+	}
+
+	if len(schedule) > 0 {
+		payoffTime = schedule[len(schedule)-1].PaymentTime
+	}
+
+	return &LoanInfoResponse{
+		Id:                  l.LoanId,
+		Name:                l.Name,
+		AccountId:           l.AccountId,
+		PaymentAccountId:    l.PaymentAccountId,
+		PrincipalCategoryId: l.PrincipalCategoryId,
+		InterestCategoryId:  l.InterestCategoryId,
+		Principal:           l.Principal,
+		AnnualInterestRate:  l.AnnualInterestRate,
+		TermMonths:          l.TermMonths,
+		PaymentDay:          l.PaymentDay,
+		StartTime:           l.StartTime,
+		UtcOffset:           l.TimezoneUtcOffset,
+		ScheduledEnabled:    l.ScheduledEnabled,
+		PaidPeriods:         l.PaidPeriods,
+		MonthlyPayment:      l.GetMonthlyPayment(),
+		TotalInterest:       totalInterest,
+		RemainingPrincipal:  remainingPrincipal,
+		NextPaymentTime:     nextPaymentTime,
+		PayoffTime:          payoffTime,
+		Comment:             l.Comment,
+	}
+}
+
+// ToLoanAmortizationItemResponse returns a view-object according to the amortization schedule item
+func (i *LoanAmortizationItem) ToLoanAmortizationItemResponse(paidPeriods int32) *LoanAmortizationItemResponse {
+	return &LoanAmortizationItemResponse{
+		Period:             i.Period,
+		PaymentTime:        i.PaymentTime,
+		Payment:            i.Payment,
+		Principal:          i.Principal,
+		Interest:           i.Interest,
+		RemainingPrincipal: i.RemainingPrincipal,
+		Paid:               i.Period <= paidPeriods,
+	}
+}
+
+// LoanInfoResponseSlice represents the slice data structure of LoanInfoResponse
+type LoanInfoResponseSlice []*LoanInfoResponse
+
+// Len returns the count of items
+func (s LoanInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s LoanInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s LoanInfoResponseSlice) Less(i, j int) bool {
+	if s[i].StartTime != s[j].StartTime {
+		return s[i].StartTime < s[j].StartTime
+	}
+
+	return s[i].Id < s[j].Id
+}
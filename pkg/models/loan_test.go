@@ -0,0 +1,124 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoanGetMonthlyPayment(t *testing.T) {
+	loan := &Loan{
+		Principal:          1200000,
+		AnnualInterestRate: 12,
+		TermMonths:         12,
+	}
+
+	assert.Equal(t, int64(106619), loan.GetMonthlyPayment())
+}
+
+func TestLoanGetMonthlyPayment_ZeroInterestRate(t *testing.T) {
+	loan := &Loan{
+		Principal:          100000,
+		AnnualInterestRate: 0,
+		TermMonths:         3,
+	}
+
+	assert.Equal(t, int64(33334), loan.GetMonthlyPayment())
+}
+
+func TestLoanGetPaymentTime(t *testing.T) {
+	timezone := time.FixedZone("Test Timezone", 8*60*60)
+	loan := &Loan{
+		PaymentDay:        15,
+		StartTime:         time.Date(2024, 11, 20, 10, 0, 0, 0, timezone).Unix(),
+		TimezoneUtcOffset: 480,
+	}
+
+	assert.Equal(t, time.Date(2024, 12, 15, 0, 0, 0, 0, timezone).Unix(), loan.GetPaymentTime(1))
+	assert.Equal(t, time.Date(2025, 1, 15, 0, 0, 0, 0, timezone).Unix(), loan.GetPaymentTime(2))
+	assert.Equal(t, time.Date(2025, 11, 15, 0, 0, 0, 0, timezone).Unix(), loan.GetPaymentTime(12))
+}
+
+func TestLoanGetAmortizationSchedule(t *testing.T) {
+	loan := &Loan{
+		Principal:          1200000,
+		AnnualInterestRate: 12,
+		TermMonths:         12,
+		PaymentDay:         1,
+		StartTime:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	schedule := loan.GetAmortizationSchedule()
+	assert.Equal(t, 12, len(schedule))
+
+	assert.Equal(t, int32(1), schedule[0].Period)
+	assert.Equal(t, int64(12000), schedule[0].Interest)
+	assert.Equal(t, int64(94619), schedule[0].Principal)
+	assert.Equal(t, int64(106619), schedule[0].Payment)
+	assert.Equal(t, int64(1105381), schedule[0].RemainingPrincipal)
+
+	totalPrincipal := int64(0)
+
+	for i := 0; i < len(schedule); i++ {
+		totalPrincipal += schedule[i].Principal
+		assert.Equal(t, schedule[i].Principal+schedule[i].Interest, schedule[i].Payment)
+	}
+
+	assert.Equal(t, loan.Principal, totalPrincipal)
+	assert.Equal(t, int64(0), schedule[11].RemainingPrincipal)
+}
+
+func TestLoanGetAmortizationSchedule_ZeroInterestRate(t *testing.T) {
+	loan := &Loan{
+		Principal:          100000,
+		AnnualInterestRate: 0,
+		TermMonths:         3,
+		PaymentDay:         1,
+		StartTime:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	schedule := loan.GetAmortizationSchedule()
+	assert.Equal(t, 3, len(schedule))
+	assert.Equal(t, int64(33334), schedule[0].Principal)
+	assert.Equal(t, int64(33334), schedule[1].Principal)
+	assert.Equal(t, int64(33332), schedule[2].Principal)
+	assert.Equal(t, int64(0), schedule[0].Interest)
+	assert.Equal(t, int64(0), schedule[2].RemainingPrincipal)
+}
+
+func TestLoanGetDuePeriods(t *testing.T) {
+	loan := &Loan{
+		Principal:  100000,
+		TermMonths: 3,
+		PaymentDay: 10,
+		StartTime:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+
+	assert.Equal(t, int32(0), loan.GetDuePeriods(time.Date(2025, 2, 9, 23, 59, 59, 0, time.UTC).Unix()))
+	assert.Equal(t, int32(1), loan.GetDuePeriods(time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, int32(2), loan.GetDuePeriods(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC).Unix()))
+	assert.Equal(t, int32(3), loan.GetDuePeriods(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()))
+}
+
+func TestLoanToLoanInfoResponse(t *testing.T) {
+	loan := &Loan{
+		Principal:          1200000,
+		AnnualInterestRate: 12,
+		TermMonths:         12,
+		PaymentDay:         1,
+		StartTime:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		PaidPeriods:        1,
+	}
+
+	response := loan.ToLoanInfoResponse()
+	assert.Equal(t, int64(106619), response.MonthlyPayment)
+	assert.Equal(t, int64(1105381), response.RemainingPrincipal)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix(), response.NextPaymentTime)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), response.PayoffTime)
+
+	loan.PaidPeriods = 12
+	response = loan.ToLoanInfoResponse()
+	assert.Equal(t, int64(0), response.RemainingPrincipal)
+	assert.Equal(t, int64(0), response.NextPaymentTime)
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MaximumAliasesCountOfPayee represents the maximum count of aliases for one payee
+const MaximumAliasesCountOfPayee = 20
+
+// MaximumDefaultTagsCountOfPayee represents the maximum count of default tags for one payee
+const MaximumDefaultTagsCountOfPayee = MaximumTagsCountOfTransaction
+
+// Payee represents payee data stored in database
+type Payee struct {
+	PayeeId           int64        `xorm:"PK"`
+	Uid               int64        `xorm:"INDEX(IDX_payee_uid_deleted_order) NOT NULL"`
+	Deleted           bool         `xorm:"INDEX(IDX_payee_uid_deleted_order) NOT NULL"`
+	Name              string       `xorm:"VARCHAR(64) NOT NULL"`
+	DisplayOrder      int32        `xorm:"INDEX(IDX_payee_uid_deleted_order) NOT NULL"`
+	DefaultCategoryId int64        `xorm:"NOT NULL DEFAULT 0"`
+	DefaultTagIds     string       `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	Extend            *PayeeExtend `xorm:"BLOB"`
+	Hidden            bool         `xorm:"NOT NULL"`
+	Comment           string       `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime   int64
+	UpdatedUnixTime   int64
+	DeletedUnixTime   int64
+}
+
+// PayeeExtend represents payee extend data stored in database
+type PayeeExtend struct {
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// PayeeGetRequest represents all parameters of payee getting request
+type PayeeGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// PayeeCreateRequest represents all parameters of payee creation request
+type PayeeCreateRequest struct {
+	Name              string   `json:"name" binding:"required,notBlank,max=64"`
+	Aliases           []string `json:"aliases" binding:"omitempty,dive,notBlank,max=64"`
+	DefaultCategoryId int64    `json:"defaultCategoryId,string" binding:"min=0"`
+	DefaultTagIds     []string `json:"defaultTagIds"`
+	Comment           string   `json:"comment" binding:"max=255"`
+}
+
+// PayeeCreateBatchRequest represents all parameters of payee batch creation request
+type PayeeCreateBatchRequest struct {
+	Payees     []*PayeeCreateRequest `json:"payees" binding:"required"`
+	SkipExists bool                  `json:"skipExists"`
+}
+
+// PayeeModifyRequest represents all parameters of payee modification request
+type PayeeModifyRequest struct {
+	Id                int64    `json:"id,string" binding:"required,min=1"`
+	Name              string   `json:"name" binding:"required,notBlank,max=64"`
+	Aliases           []string `json:"aliases" binding:"omitempty,dive,notBlank,max=64"`
+	DefaultCategoryId int64    `json:"defaultCategoryId,string" binding:"min=0"`
+	DefaultTagIds     []string `json:"defaultTagIds"`
+	Comment           string   `json:"comment" binding:"max=255"`
+}
+
+// PayeeHideRequest represents all parameters of payee hiding request
+type PayeeHideRequest struct {
+	Id     int64 `json:"id,string" binding:"required,min=1"`
+	Hidden bool  `json:"hidden"`
+}
+
+// PayeeMoveRequest represents all parameters of payee moving request
+type PayeeMoveRequest struct {
+	NewDisplayOrders []*PayeeNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// PayeeNewDisplayOrderRequest represents a data pair of id and display order
+type PayeeNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// PayeeDeleteRequest represents all parameters of payee deleting request
+type PayeeDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// PayeeInfoResponse represents a view-object of payee
+type PayeeInfoResponse struct {
+	Id                int64    `json:"id,string"`
+	Name              string   `json:"name"`
+	Aliases           []string `json:"aliases"`
+	DefaultCategoryId int64    `json:"defaultCategoryId,string"`
+	DefaultTagIds     []string `json:"defaultTagIds"`
+	Comment           string   `json:"comment"`
+	DisplayOrder      int32    `json:"displayOrder"`
+	Hidden            bool     `json:"hidden"`
+}
+
+// PayeeTotalAmount represents total amount and transaction count of a payee in one account
+type PayeeTotalAmount struct {
+	PayeeId          int64
+	AccountId        int64
+	IncomeAmount     int64
+	ExpenseAmount    int64
+	TransactionCount int64
+}
+
+// FromDB fills the fields from the data stored in database
+func (p *PayeeExtend) FromDB(data []byte) error {
+	return json.Unmarshal(data, p)
+}
+
+// ToDB returns the actual stored data in database
+func (p *PayeeExtend) ToDB() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// GetAliases returns all aliases of the payee
+func (p *Payee) GetAliases() []string {
+	if p.Extend == nil || p.Extend.Aliases == nil {
+		return []string{}
+	}
+
+	return p.Extend.Aliases
+}
+
+// GetDefaultTagIds returns all default tag ids of the payee
+func (p *Payee) GetDefaultTagIds() []int64 {
+	tagIds := make([]string, 0)
+
+	if p.DefaultTagIds != "" {
+		tagIds = strings.Split(p.DefaultTagIds, ",")
+	}
+
+	result, _ := utils.StringArrayToInt64Array(tagIds)
+
+	return result
+}
+
+// IsNameMatched returns whether the given name equals to the payee name or one of its aliases (case insensitive)
+func (p *Payee) IsNameMatched(name string) bool {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return false
+	}
+
+	if strings.EqualFold(strings.TrimSpace(p.Name), name) {
+		return true
+	}
+
+	aliases := p.GetAliases()
+
+	for i := 0; i < len(aliases); i++ {
+		if strings.EqualFold(strings.TrimSpace(aliases[i]), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FillFromOtherPayee fills all the fields in this current payee from other payee
+func (p *Payee) FillFromOtherPayee(payee *Payee) {
+	p.PayeeId = payee.PayeeId
+	p.Uid = payee.Uid
+	p.Deleted = payee.Deleted
+	p.Name = payee.Name
+	p.DisplayOrder = payee.DisplayOrder
+	p.DefaultCategoryId = payee.DefaultCategoryId
+	p.DefaultTagIds = payee.DefaultTagIds
+	p.Extend = payee.Extend
+	p.Hidden = payee.Hidden
+	p.Comment = payee.Comment
+	p.CreatedUnixTime = payee.CreatedUnixTime
+	p.UpdatedUnixTime = payee.UpdatedUnixTime
+	p.DeletedUnixTime = payee.DeletedUnixTime
+}
+
+// ToPayeeInfoResponse returns a view-object according to database model
+func (p *Payee) ToPayeeInfoResponse() *PayeeInfoResponse {
+	return &PayeeInfoResponse{
+		Id:                p.PayeeId,
+		Name:              p.Name,
+		Aliases:           p.GetAliases(),
+		DefaultCategoryId: p.DefaultCategoryId,
+		DefaultTagIds:     utils.Int64ArrayToStringArray(p.GetDefaultTagIds()),
+		Comment:           p.Comment,
+		DisplayOrder:      p.DisplayOrder,
+		Hidden:            p.Hidden,
+	}
+}
+
+// PayeeInfoResponseSlice represents the slice data structure of PayeeInfoResponse
+type PayeeInfoResponseSlice []*PayeeInfoResponse
+
+// Len returns the count of items
+func (s PayeeInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s PayeeInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s PayeeInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayeeIsNameMatched(t *testing.T) {
+	payee := &Payee{
+		Name: "Starbucks",
+		Extend: &PayeeExtend{
+			Aliases: []string{"STARBUCKS COFFEE", " 星巴克 "},
+		},
+	}
+
+	assert.True(t, payee.IsNameMatched("Starbucks"))
+	assert.True(t, payee.IsNameMatched(" starbucks "))
+	assert.True(t, payee.IsNameMatched("Starbucks Coffee"))
+	assert.True(t, payee.IsNameMatched("星巴克"))
+	assert.False(t, payee.IsNameMatched("Starbucks Store #123"))
+	assert.False(t, payee.IsNameMatched(""))
+	assert.False(t, payee.IsNameMatched("   "))
+}
+
+func TestPayeeIsNameMatched_WithoutExtend(t *testing.T) {
+	payee := &Payee{
+		Name: "Costco",
+	}
+
+	assert.True(t, payee.IsNameMatched("COSTCO"))
+	assert.False(t, payee.IsNameMatched("Costco Wholesale"))
+	assert.Equal(t, []string{}, payee.GetAliases())
+}
+
+func TestPayeeGetDefaultTagIds(t *testing.T) {
+	payee := &Payee{}
+	assert.Equal(t, 0, len(payee.GetDefaultTagIds()))
+
+	payee.DefaultTagIds = "1,2,3"
+	assert.Equal(t, []int64{1, 2, 3}, payee.GetDefaultTagIds())
+}
+
+func TestPayeeToPayeeInfoResponse(t *testing.T) {
+	payee := &Payee{
+		PayeeId:           1,
+		Name:              "Starbucks",
+		DefaultCategoryId: 2,
+		DefaultTagIds:     "3,4",
+		Extend: &PayeeExtend{
+			Aliases: []string{"STARBUCKS COFFEE"},
+		},
+		DisplayOrder: 5,
+	}
+
+	payeeResp := payee.ToPayeeInfoResponse()
+	assert.Equal(t, int64(1), payeeResp.Id)
+	assert.Equal(t, "Starbucks", payeeResp.Name)
+	assert.Equal(t, []string{"STARBUCKS COFFEE"}, payeeResp.Aliases)
+	assert.Equal(t, int64(2), payeeResp.DefaultCategoryId)
+	assert.Equal(t, []string{"3", "4"}, payeeResp.DefaultTagIds)
+	assert.Equal(t, int32(5), payeeResp.DisplayOrder)
+}
+
+func TestPayeeInfoResponseSliceSort(t *testing.T) {
+	payeeResps := PayeeInfoResponseSlice{
+		{Id: 1, DisplayOrder: 3},
+		{Id: 2, DisplayOrder: 1},
+		{Id: 3, DisplayOrder: 2},
+	}
+
+	sort.Sort(payeeResps)
+	assert.Equal(t, int64(2), payeeResps[0].Id)
+	assert.Equal(t, int64(3), payeeResps[1].Id)
+	assert.Equal(t, int64(1), payeeResps[2].Id)
+}
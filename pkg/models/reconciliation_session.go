@@ -0,0 +1,142 @@
+package models
+
+// ReconciliationSessionStatus represents the status of reconciliation session
+type ReconciliationSessionStatus byte
+
+// Reconciliation session statuses
+const (
+	RECONCILIATION_SESSION_STATUS_IN_PROGRESS ReconciliationSessionStatus = 1
+	RECONCILIATION_SESSION_STATUS_COMPLETED   ReconciliationSessionStatus = 2
+)
+
+// ReconciliationSession represents a session of reconciling the cleared transactions of an account against the bank statement stored in database
+type ReconciliationSession struct {
+	SessionId              int64                       `xorm:"PK"`
+	Uid                    int64                       `xorm:"INDEX(IDX_reconciliation_session_uid_deleted_account_id) NOT NULL"`
+	Deleted                bool                        `xorm:"INDEX(IDX_reconciliation_session_uid_deleted_account_id) NOT NULL"`
+	AccountId              int64                       `xorm:"INDEX(IDX_reconciliation_session_uid_deleted_account_id) NOT NULL"`
+	Status                 ReconciliationSessionStatus `xorm:"NOT NULL"`
+	StatementEndTime       int64                       `xorm:"NOT NULL"`
+	StatementEndingBalance int64                       `xorm:"NOT NULL"`
+	ReconciledCount        int32                       `xorm:"NOT NULL"`
+	CreatedUnixTime        int64
+	UpdatedUnixTime        int64
+	CompletedUnixTime      int64
+	DeletedUnixTime        int64
+}
+
+// ReconciliationSessionListRequest represents all parameters of reconciliation session listing request
+type ReconciliationSessionListRequest struct {
+	AccountId int64 `form:"account_id,string" binding:"min=0"`
+}
+
+// ReconciliationSessionGetRequest represents all parameters of reconciliation session getting request
+type ReconciliationSessionGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// ReconciliationSessionCreateRequest represents all parameters of reconciliation session creation request
+type ReconciliationSessionCreateRequest struct {
+	AccountId              int64 `json:"accountId,string" binding:"required,min=1"`
+	StatementEndTime       int64 `json:"statementEndTime" binding:"required,min=1"`
+	StatementEndingBalance int64 `json:"statementEndingBalance" binding:"min=-99999999999,max=99999999999"`
+}
+
+// ReconciliationSessionCompleteRequest represents all parameters of reconciliation session completion request
+type ReconciliationSessionCompleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// ReconciliationSessionDeleteRequest represents all parameters of reconciliation session deleting request
+type ReconciliationSessionDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// ReconciliationSessionInfoResponse represents a view-object of reconciliation session
+type ReconciliationSessionInfoResponse struct {
+	Id                     int64                       `json:"id,string"`
+	AccountId              int64                       `json:"accountId,string"`
+	Status                 ReconciliationSessionStatus `json:"status"`
+	StatementEndTime       int64                       `json:"statementEndTime"`
+	StatementEndingBalance int64                       `json:"statementEndingBalance"`
+	ClearedBalance         int64                       `json:"clearedBalance"`
+	Difference             int64                       `json:"difference"`
+	UnclearedCount         int32                       `json:"unclearedCount"`
+	ReconciledCount        int32                       `json:"reconciledCount"`
+	CreatedTime            int64                       `json:"createdTime"`
+	CompletedTime          int64                       `json:"completedTime,omitempty"`
+}
+
+// ReconciliationClearedBalance represents the cleared balance of an account until the statement end time
+type ReconciliationClearedBalance struct {
+	ClearedBalance int64
+	UnclearedCount int32
+}
+
+// NewReconciliationClearedBalance returns the cleared balance calculated by all transactions of the account
+func NewReconciliationClearedBalance(transactions []*Transaction) *ReconciliationClearedBalance {
+	clearedBalance := &ReconciliationClearedBalance{}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.IsCleared() {
+			clearedBalance.ClearedBalance += transaction.GetAccountBalanceChange()
+		} else {
+			clearedBalance.UnclearedCount++
+		}
+	}
+
+	return clearedBalance
+}
+
+// IsCompleted returns whether the reconciliation session has been completed
+func (s *ReconciliationSession) IsCompleted() bool {
+	return s.Status == RECONCILIATION_SESSION_STATUS_COMPLETED
+}
+
+// ToReconciliationSessionInfoResponse returns a view-object according to database model and the current cleared balance
+func (s *ReconciliationSession) ToReconciliationSessionInfoResponse(clearedBalance *ReconciliationClearedBalance) *ReconciliationSessionInfoResponse {
+	resp := &ReconciliationSessionInfoResponse{
+		Id:                     s.SessionId,
+		AccountId:              s.AccountId,
+		Status:                 s.Status,
+		StatementEndTime:       s.StatementEndTime,
+		StatementEndingBalance: s.StatementEndingBalance,
+		ReconciledCount:        s.ReconciledCount,
+		CreatedTime:            s.CreatedUnixTime,
+		CompletedTime:          s.CompletedUnixTime,
+	}
+
+	if clearedBalance != nil {
+		resp.ClearedBalance = clearedBalance.ClearedBalance
+		resp.Difference = s.StatementEndingBalance - clearedBalance.ClearedBalance
+		resp.UnclearedCount = clearedBalance.UnclearedCount
+	} else if s.IsCompleted() {
+		resp.ClearedBalance = s.StatementEndingBalance
+	}
+
+	return resp
+}
+
+// ReconciliationSessionInfoResponseSlice represents the slice data structure of ReconciliationSessionInfoResponse
+type ReconciliationSessionInfoResponseSlice []*ReconciliationSessionInfoResponse
+
+// Len returns the count of items
+func (s ReconciliationSessionInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s ReconciliationSessionInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s ReconciliationSessionInfoResponseSlice) Less(i, j int) bool {
+	if s[i].StatementEndTime != s[j].StatementEndTime {
+		return s[i].StatementEndTime > s[j].StatementEndTime
+	}
+
+	return s[i].Id > s[j].Id
+}
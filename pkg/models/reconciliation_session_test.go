@@ -0,0 +1,70 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReconciliationClearedBalance(t *testing.T) {
+	transactions := []*Transaction{
+		{Type: TRANSACTION_DB_TYPE_MODIFY_BALANCE, RelatedAccountAmount: 10000},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 1000, ClearedStatus: TRANSACTION_CLEARED_STATUS_RECONCILED},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 2000, ClearedStatus: TRANSACTION_CLEARED_STATUS_CLEARED},
+		{Type: TRANSACTION_DB_TYPE_INCOME, Amount: 500, ClearedStatus: TRANSACTION_CLEARED_STATUS_CLEARED},
+		{Type: TRANSACTION_DB_TYPE_TRANSFER_IN, Amount: 3000, ClearedStatus: TRANSACTION_CLEARED_STATUS_UNCLEARED},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 4000, ClearedStatus: TRANSACTION_CLEARED_STATUS_UNCLEARED},
+	}
+
+	clearedBalance := NewReconciliationClearedBalance(transactions)
+	assert.Equal(t, int64(7500), clearedBalance.ClearedBalance)
+	assert.Equal(t, int32(2), clearedBalance.UnclearedCount)
+}
+
+func TestReconciliationSessionToReconciliationSessionInfoResponse(t *testing.T) {
+	session := &ReconciliationSession{
+		SessionId:              1,
+		AccountId:              2,
+		Status:                 RECONCILIATION_SESSION_STATUS_IN_PROGRESS,
+		StatementEndTime:       1700000000,
+		StatementEndingBalance: 8000,
+	}
+
+	resp := session.ToReconciliationSessionInfoResponse(&ReconciliationClearedBalance{
+		ClearedBalance: 7500,
+		UnclearedCount: 2,
+	})
+
+	assert.Equal(t, int64(7500), resp.ClearedBalance)
+	assert.Equal(t, int64(500), resp.Difference)
+	assert.Equal(t, int32(2), resp.UnclearedCount)
+
+	session.Status = RECONCILIATION_SESSION_STATUS_COMPLETED
+	resp = session.ToReconciliationSessionInfoResponse(nil)
+
+	assert.Equal(t, int64(8000), resp.ClearedBalance)
+	assert.Equal(t, int64(0), resp.Difference)
+}
+
+func TestReconciliationSessionInfoResponseSliceLess(t *testing.T) {
+	var sessionRespSlice ReconciliationSessionInfoResponseSlice
+	sessionRespSlice = append(sessionRespSlice, &ReconciliationSessionInfoResponse{
+		Id:               1,
+		StatementEndTime: 1700000000,
+	})
+	sessionRespSlice = append(sessionRespSlice, &ReconciliationSessionInfoResponse{
+		Id:               2,
+		StatementEndTime: 1800000000,
+	})
+	sessionRespSlice = append(sessionRespSlice, &ReconciliationSessionInfoResponse{
+		Id:               3,
+		StatementEndTime: 1700000000,
+	})
+
+	sort.Sort(sessionRespSlice)
+
+	assert.Equal(t, int64(2), sessionRespSlice[0].Id)
+	assert.Equal(t, int64(3), sessionRespSlice[1].Id)
+	assert.Equal(t, int64(1), sessionRespSlice[2].Id)
+}
@@ -0,0 +1,93 @@
+package models
+
+// SavedSearch represents a saved transaction search stored in database
+type SavedSearch struct {
+	SavedSearchId   int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_saved_search_uid_deleted_order) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_saved_search_uid_deleted_order) NOT NULL"`
+	Name            string `xorm:"VARCHAR(64) NOT NULL"`
+	DisplayOrder    int32  `xorm:"INDEX(IDX_saved_search_uid_deleted_order) NOT NULL"`
+	Query           string `xorm:"VARCHAR(1024) NOT NULL"`
+	Hidden          bool   `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// SavedSearchCreateRequest represents all parameters of saved search creation request
+type SavedSearchCreateRequest struct {
+	Name  string `json:"name" binding:"required,notBlank,max=64"`
+	Query string `json:"query" binding:"required,notBlank,max=1024"`
+}
+
+// SavedSearchModifyRequest represents all parameters of saved search modification request
+type SavedSearchModifyRequest struct {
+	Id    int64  `json:"id,string" binding:"required,min=1"`
+	Name  string `json:"name" binding:"required,notBlank,max=64"`
+	Query string `json:"query" binding:"required,notBlank,max=1024"`
+}
+
+// SavedSearchGetRequest represents all parameters of saved search getting request
+type SavedSearchGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// SavedSearchHideRequest represents all parameters of saved search hiding request
+type SavedSearchHideRequest struct {
+	Id     int64 `json:"id,string" binding:"required,min=1"`
+	Hidden bool  `json:"hidden"`
+}
+
+// SavedSearchMoveRequest represents all parameters of saved search moving request
+type SavedSearchMoveRequest struct {
+	NewDisplayOrders []*SavedSearchNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// SavedSearchNewDisplayOrderRequest represents a data pair of id and display order
+type SavedSearchNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// SavedSearchDeleteRequest represents all parameters of saved search deleting request
+type SavedSearchDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// SavedSearchInfoResponse represents a view-object of saved search info
+type SavedSearchInfoResponse struct {
+	Id           int64  `json:"id,string"`
+	Name         string `json:"name"`
+	Query        string `json:"query"`
+	DisplayOrder int32  `json:"displayOrder"`
+	Hidden       bool   `json:"hidden"`
+}
+
+// ToSavedSearchInfoResponse returns a view-object according to database model
+func (s *SavedSearch) ToSavedSearchInfoResponse() *SavedSearchInfoResponse {
+	return &SavedSearchInfoResponse{
+		Id:           s.SavedSearchId,
+		Name:         s.Name,
+		Query:        s.Query,
+		DisplayOrder: s.DisplayOrder,
+		Hidden:       s.Hidden,
+	}
+}
+
+// SavedSearchInfoResponseSlice represents the slice data structure of SavedSearchInfoResponse
+type SavedSearchInfoResponseSlice []*SavedSearchInfoResponse
+
+// Len returns the count of items
+func (s SavedSearchInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s SavedSearchInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s SavedSearchInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
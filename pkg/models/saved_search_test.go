@@ -0,0 +1,47 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchToSavedSearchInfoResponse(t *testing.T) {
+	savedSearch := &SavedSearch{
+		SavedSearchId: 1,
+		Name:          "Trip Expenses",
+		Query:         "type:expense tag:trip",
+		DisplayOrder:  2,
+		Hidden:        true,
+	}
+
+	savedSearchResp := savedSearch.ToSavedSearchInfoResponse()
+	assert.Equal(t, int64(1), savedSearchResp.Id)
+	assert.Equal(t, "Trip Expenses", savedSearchResp.Name)
+	assert.Equal(t, "type:expense tag:trip", savedSearchResp.Query)
+	assert.Equal(t, int32(2), savedSearchResp.DisplayOrder)
+	assert.True(t, savedSearchResp.Hidden)
+}
+
+func TestSavedSearchInfoResponseSliceLess(t *testing.T) {
+	var savedSearchRespSlice SavedSearchInfoResponseSlice
+	savedSearchRespSlice = append(savedSearchRespSlice, &SavedSearchInfoResponse{
+		Id:           1,
+		DisplayOrder: 3,
+	})
+	savedSearchRespSlice = append(savedSearchRespSlice, &SavedSearchInfoResponse{
+		Id:           2,
+		DisplayOrder: 1,
+	})
+	savedSearchRespSlice = append(savedSearchRespSlice, &SavedSearchInfoResponse{
+		Id:           3,
+		DisplayOrder: 2,
+	})
+
+	sort.Sort(savedSearchRespSlice)
+
+	assert.Equal(t, int64(2), savedSearchRespSlice[0].Id)
+	assert.Equal(t, int64(3), savedSearchRespSlice[1].Id)
+	assert.Equal(t, int64(1), savedSearchRespSlice[2].Id)
+}
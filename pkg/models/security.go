@@ -0,0 +1,163 @@
+package models
+
+// SecurityCostBasisMethod represents the method of calculating cost basis of a security
+type SecurityCostBasisMethod byte
+
+// Security cost basis methods
+const (
+	SECURITY_COST_BASIS_METHOD_FIFO    SecurityCostBasisMethod = 1
+	SECURITY_COST_BASIS_METHOD_AVERAGE SecurityCostBasisMethod = 2
+)
+
+// SecurityPriceSourceManual represents the security price is entered by user
+const SecurityPriceSourceManual = "manual"
+
+// Security represents security (e.g. stock, fund or bond) data stored in database
+type Security struct {
+	SecurityId      int64                   `xorm:"PK"`
+	Uid             int64                   `xorm:"INDEX(IDX_security_uid_deleted_ticker) NOT NULL"`
+	Deleted         bool                    `xorm:"INDEX(IDX_security_uid_deleted_ticker) NOT NULL"`
+	Ticker          string                  `xorm:"INDEX(IDX_security_uid_deleted_ticker) VARCHAR(32) NOT NULL"`
+	Name            string                  `xorm:"VARCHAR(64) NOT NULL"`
+	Currency        string                  `xorm:"VARCHAR(3) NOT NULL"`
+	CostBasisMethod SecurityCostBasisMethod `xorm:"NOT NULL"`
+	Hidden          bool                    `xorm:"NOT NULL"`
+	Comment         string                  `xorm:"VARCHAR(255) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// SecurityPrice represents the price of a security at a certain time stored in database
+type SecurityPrice struct {
+	PriceId         int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_security_price_uid_deleted_security_id_time) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_security_price_uid_deleted_security_id_time) NOT NULL"`
+	SecurityId      int64  `xorm:"INDEX(IDX_security_price_uid_deleted_security_id_time) NOT NULL"`
+	PriceTime       int64  `xorm:"INDEX(IDX_security_price_uid_deleted_security_id_time) NOT NULL"`
+	Price           int64  `xorm:"NOT NULL"`
+	Source          string `xorm:"VARCHAR(32) NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// SecurityGetRequest represents all parameters of security getting request
+type SecurityGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// SecurityCreateRequest represents all parameters of security creation request
+type SecurityCreateRequest struct {
+	Ticker          string                  `json:"ticker" binding:"required,notBlank,max=32"`
+	Name            string                  `json:"name" binding:"required,notBlank,max=64"`
+	Currency        string                  `json:"currency" binding:"required,len=3,validCurrency"`
+	CostBasisMethod SecurityCostBasisMethod `json:"costBasisMethod" binding:"required,min=1,max=2"`
+	Comment         string                  `json:"comment" binding:"max=255"`
+}
+
+// SecurityModifyRequest represents all parameters of security modification request
+type SecurityModifyRequest struct {
+	Id              int64                   `json:"id,string" binding:"required,min=1"`
+	Ticker          string                  `json:"ticker" binding:"required,notBlank,max=32"`
+	Name            string                  `json:"name" binding:"required,notBlank,max=64"`
+	CostBasisMethod SecurityCostBasisMethod `json:"costBasisMethod" binding:"required,min=1,max=2"`
+	Comment         string                  `json:"comment" binding:"max=255"`
+	Hidden          bool                    `json:"hidden"`
+}
+
+// SecurityDeleteRequest represents all parameters of security deleting request
+type SecurityDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// SecurityPriceListRequest represents all parameters of security price listing request
+type SecurityPriceListRequest struct {
+	SecurityId int64 `form:"security_id,string" binding:"required,min=1"`
+	StartTime  int64 `form:"start_time" binding:"min=0"`
+	EndTime    int64 `form:"end_time" binding:"min=0"`
+}
+
+// SecurityPriceCreateRequest represents all parameters of security price creation request
+type SecurityPriceCreateRequest struct {
+	SecurityId int64 `json:"securityId,string" binding:"required,min=1"`
+	Time       int64 `json:"time" binding:"required,min=1"`
+	Price      int64 `json:"price" binding:"required,min=1,max=99999999999"`
+}
+
+// SecurityPriceDeleteRequest represents all parameters of security price deleting request
+type SecurityPriceDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// SecurityPriceUpdateRequest represents all parameters of fetching the latest security price from the data source
+type SecurityPriceUpdateRequest struct {
+	SecurityId int64 `json:"securityId,string" binding:"required,min=1"`
+}
+
+// SecurityInfoResponse represents a view-object of security
+type SecurityInfoResponse struct {
+	Id              int64                   `json:"id,string"`
+	Ticker          string                  `json:"ticker"`
+	Name            string                  `json:"name"`
+	Currency        string                  `json:"currency"`
+	CostBasisMethod SecurityCostBasisMethod `json:"costBasisMethod"`
+	Comment         string                  `json:"comment"`
+	Hidden          bool                    `json:"hidden"`
+}
+
+// SecurityPriceInfoResponse represents a view-object of security price
+type SecurityPriceInfoResponse struct {
+	Id         int64  `json:"id,string"`
+	SecurityId int64  `json:"securityId,string"`
+	Time       int64  `json:"time"`
+	Price      int64  `json:"price"`
+	Source     string `json:"source"`
+}
+
+// IsValid returns whether the cost basis method is valid
+func (m SecurityCostBasisMethod) IsValid() bool {
+	return m == SECURITY_COST_BASIS_METHOD_FIFO || m == SECURITY_COST_BASIS_METHOD_AVERAGE
+}
+
+// ToSecurityInfoResponse returns a view-object according to database model
+func (s *Security) ToSecurityInfoResponse() *SecurityInfoResponse {
+	return &SecurityInfoResponse{
+		Id:              s.SecurityId,
+		Ticker:          s.Ticker,
+		Name:            s.Name,
+		Currency:        s.Currency,
+		CostBasisMethod: s.CostBasisMethod,
+		Comment:         s.Comment,
+		Hidden:          s.Hidden,
+	}
+}
+
+// ToSecurityPriceInfoResponse returns a view-object according to database model
+func (p *SecurityPrice) ToSecurityPriceInfoResponse() *SecurityPriceInfoResponse {
+	return &SecurityPriceInfoResponse{
+		Id:         p.PriceId,
+		SecurityId: p.SecurityId,
+		Time:       p.PriceTime,
+		Price:      p.Price,
+		Source:     p.Source,
+	}
+}
+
+// SecurityInfoResponseSlice represents the slice data structure of SecurityInfoResponse
+type SecurityInfoResponseSlice []*SecurityInfoResponse
+
+// Len returns the count of items
+func (s SecurityInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s SecurityInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s SecurityInfoResponseSlice) Less(i, j int) bool {
+	return s[i].Ticker < s[j].Ticker
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityCostBasisMethodIsValid(t *testing.T) {
+	assert.False(t, SecurityCostBasisMethod(0).IsValid())
+	assert.True(t, SECURITY_COST_BASIS_METHOD_FIFO.IsValid())
+	assert.True(t, SECURITY_COST_BASIS_METHOD_AVERAGE.IsValid())
+	assert.False(t, SecurityCostBasisMethod(3).IsValid())
+}
+
+func TestSecurityInfoResponseSliceSortByTicker(t *testing.T) {
+	securityResps := SecurityInfoResponseSlice{
+		&SecurityInfoResponse{Id: 1, Ticker: "VTI"},
+		&SecurityInfoResponse{Id: 2, Ticker: "AAPL"},
+		&SecurityInfoResponse{Id: 3, Ticker: "MSFT"},
+	}
+
+	sort.Sort(securityResps)
+
+	assert.Equal(t, int64(2), securityResps[0].Id)
+	assert.Equal(t, int64(3), securityResps[1].Id)
+	assert.Equal(t, int64(1), securityResps[2].Id)
+}
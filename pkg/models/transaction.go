@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -106,6 +107,16 @@ func (t TransactionDbType) ToTransactionRelatedAccountType() (TransactionRelated
 	}
 }
 
+// TransactionClearedStatus represents whether the transaction has been cleared or reconciled against the bank statement
+type TransactionClearedStatus byte
+
+// Transaction cleared statuses
+const (
+	TRANSACTION_CLEARED_STATUS_UNCLEARED  TransactionClearedStatus = 0
+	TRANSACTION_CLEARED_STATUS_CLEARED    TransactionClearedStatus = 1
+	TRANSACTION_CLEARED_STATUS_RECONCILED TransactionClearedStatus = 2
+)
+
 // TransactionTagFilterValue represents transaction tag filter value for no tag
 const TransactionNoTagFilterValue = "none"
 
@@ -136,27 +147,33 @@ const (
 
 // Transaction represents transaction data stored in database
 type Transaction struct {
-	TransactionId        int64             `xorm:"PK"`
-	Uid                  int64             `xorm:"UNIQUE(UQE_transaction_uid_time) INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) NOT NULL"`
-	Deleted              bool              `xorm:"INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) NOT NULL"`
-	Type                 TransactionDbType `xorm:"INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) NOT NULL"`
-	CategoryId           int64             `xorm:"INDEX(IDX_transaction_uid_deleted_category_id_time) NOT NULL"`
-	AccountId            int64             `xorm:"INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) NOT NULL"`
-	TransactionTime      int64             `xorm:"UNIQUE(UQE_transaction_uid_time) INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) NOT NULL"`
-	TimezoneUtcOffset    int16             `xorm:"NOT NULL"`
-	Amount               int64             `xorm:"NOT NULL"`
-	RelatedId            int64             `xorm:"NOT NULL"`
-	RelatedAccountId     int64             `xorm:"NOT NULL"`
-	RelatedAccountAmount int64             `xorm:"NOT NULL"`
-	HideAmount           bool              `xorm:"NOT NULL"`
-	Comment              string            `xorm:"VARCHAR(255) NOT NULL"`
-	GeoLongitude         float64           `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
-	GeoLatitude          float64           `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
-	CreatedIp            string            `xorm:"VARCHAR(39)"`
-	ScheduledCreated     bool
-	CreatedUnixTime      int64
-	UpdatedUnixTime      int64
-	DeletedUnixTime      int64
+	TransactionId               int64                    `xorm:"PK"`
+	Uid                         int64                    `xorm:"UNIQUE(UQE_transaction_uid_time) INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_payee_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) NOT NULL"`
+	Deleted                     bool                     `xorm:"INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_payee_id_time) INDEX(IDX_transaction_uid_deleted_time_longitude_latitude) NOT NULL"`
+	Type                        TransactionDbType        `xorm:"INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) NOT NULL"`
+	CategoryId                  int64                    `xorm:"INDEX(IDX_transaction_uid_deleted_category_id_time) NOT NULL"`
+	AccountId                   int64                    `xorm:"INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) NOT NULL"`
+	PayeeId                     int64                    `xorm:"INDEX(IDX_transaction_uid_deleted_payee_id_time) NOT NULL DEFAULT 0"`
+	TransactionTime             int64                    `xorm:"UNIQUE(UQE_transaction_uid_time) INDEX(IDX_transaction_uid_deleted_time) INDEX(IDX_transaction_uid_deleted_type_time) INDEX(IDX_transaction_uid_deleted_type_account_id_time) INDEX(IDX_transaction_uid_deleted_category_id_time) INDEX(IDX_transaction_uid_deleted_account_id_time) INDEX(IDX_transaction_uid_deleted_payee_id_time) NOT NULL"`
+	TimezoneUtcOffset           int16                    `xorm:"NOT NULL"`
+	Amount                      int64                    `xorm:"NOT NULL"`
+	RelatedId                   int64                    `xorm:"NOT NULL"`
+	RelatedAccountId            int64                    `xorm:"NOT NULL"`
+	RelatedAccountAmount        int64                    `xorm:"NOT NULL"`
+	OriginalCurrency            string                   `xorm:"VARCHAR(3)"`
+	OriginalAmount              int64                    `xorm:"NOT NULL DEFAULT 0"`
+	OriginalAmountDecimalPlaces int32                    `xorm:"NOT NULL DEFAULT 2"`
+	HideAmount                  bool                     `xorm:"NOT NULL"`
+	ClearedStatus               TransactionClearedStatus `xorm:"NOT NULL DEFAULT 0"`
+	Comment                     string                   `xorm:"VARCHAR(255) NOT NULL"`
+	GeoLongitude                float64                  `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
+	GeoLatitude                 float64                  `xorm:"INDEX(IDX_transaction_uid_deleted_time_longitude_latitude)"`
+	CreatedIp                   string                   `xorm:"VARCHAR(39)"`
+	CreatedByUid                int64                    `xorm:"NOT NULL DEFAULT 0"`
+	ScheduledCreated            bool
+	CreatedUnixTime             int64
+	UpdatedUnixTime             int64
+	DeletedUnixTime             int64
 }
 
 // TransactionWithAccountBalance represents a transaction item with account balance
@@ -174,39 +191,52 @@ type TransactionGeoLocationRequest struct {
 
 // TransactionCreateRequest represents all parameters of transaction creation request
 type TransactionCreateRequest struct {
-	Type                 TransactionType                `json:"type" binding:"required"`
-	CategoryId           int64                          `json:"categoryId,string"`
-	Time                 int64                          `json:"time" binding:"required,min=1"`
-	UtcOffset            int16                          `json:"utcOffset" binding:"min=-720,max=840"`
-	SourceAccountId      int64                          `json:"sourceAccountId,string" binding:"required,min=1"`
-	DestinationAccountId int64                          `json:"destinationAccountId,string" binding:"min=0"`
-	SourceAmount         int64                          `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
-	DestinationAmount    int64                          `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
-	HideAmount           bool                           `json:"hideAmount"`
-	TagIds               []string                       `json:"tagIds"`
-	ItemIds              []string                       `json:"itemIds"`
-	PictureIds           []string                       `json:"pictureIds"`
-	Comment              string                         `json:"comment" binding:"max=255"`
-	GeoLocation          *TransactionGeoLocationRequest `json:"geoLocation" binding:"omitempty"`
-	ClientSessionId      string                         `json:"clientSessionId"`
+	Type                 TransactionType                 `json:"type" binding:"required"`
+	CategoryId           int64                           `json:"categoryId,string"`
+	Time                 int64                           `json:"time" binding:"required,min=1"`
+	UtcOffset            int16                           `json:"utcOffset" binding:"min=-720,max=840"`
+	SourceAccountId      int64                           `json:"sourceAccountId,string" binding:"required,min=1"`
+	DestinationAccountId int64                           `json:"destinationAccountId,string" binding:"min=0"`
+	SourceAmount         int64                           `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
+	DestinationAmount    int64                           `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
+	OriginalCurrency     string                          `json:"originalCurrency,omitempty" binding:"omitempty,len=3,validCurrency"`
+	OriginalAmount       int64                           `json:"originalAmount,omitempty" binding:"min=-99999999999,max=99999999999"`
+	PayeeId              int64                           `json:"payeeId,string" binding:"min=0"`
+	HideAmount           bool                            `json:"hideAmount"`
+	TagIds               []string                        `json:"tagIds"`
+	ItemIds              []string                        `json:"itemIds"`
+	ItemDetails          []*TransactionItemDetailRequest `json:"itemDetails" binding:"omitempty,dive"`
+	PictureIds           []string                        `json:"pictureIds"`
+	Splits               []*TransactionSplitRequest      `json:"splits" binding:"omitempty,dive"`
+	Comment              string                          `json:"comment" binding:"max=255"`
+	GeoLocation          *TransactionGeoLocationRequest  `json:"geoLocation" binding:"omitempty"`
+	OriginalCategoryName string                          `json:"originalCategoryName,omitempty" binding:"max=255"`
+	Counterparty         string                          `json:"counterparty,omitempty" binding:"max=255"`
+	ApplyRules           bool                            `json:"applyRules"`
+	ClientSessionId      string                          `json:"clientSessionId"`
 }
 
 // TransactionModifyRequest represents all parameters of transaction modification request
 type TransactionModifyRequest struct {
-	Id                   int64                          `json:"id,string" binding:"required,min=1"`
-	CategoryId           int64                          `json:"categoryId,string"`
-	Time                 int64                          `json:"time" binding:"required,min=1"`
-	UtcOffset            int16                          `json:"utcOffset" binding:"min=-720,max=840"`
-	SourceAccountId      int64                          `json:"sourceAccountId,string" binding:"required,min=1"`
-	DestinationAccountId int64                          `json:"destinationAccountId,string" binding:"min=0"`
-	SourceAmount         int64                          `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
-	DestinationAmount    int64                          `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
-	HideAmount           bool                           `json:"hideAmount"`
-	TagIds               []string                       `json:"tagIds"`
-	ItemIds              []string                       `json:"itemIds"`
-	PictureIds           []string                       `json:"pictureIds"`
-	Comment              string                         `json:"comment" binding:"max=255"`
-	GeoLocation          *TransactionGeoLocationRequest `json:"geoLocation" binding:"omitempty"`
+	Id                   int64                           `json:"id,string" binding:"required,min=1"`
+	CategoryId           int64                           `json:"categoryId,string"`
+	Time                 int64                           `json:"time" binding:"required,min=1"`
+	UtcOffset            int16                           `json:"utcOffset" binding:"min=-720,max=840"`
+	SourceAccountId      int64                           `json:"sourceAccountId,string" binding:"required,min=1"`
+	DestinationAccountId int64                           `json:"destinationAccountId,string" binding:"min=0"`
+	SourceAmount         int64                           `json:"sourceAmount" binding:"min=-99999999999,max=99999999999"`
+	DestinationAmount    int64                           `json:"destinationAmount" binding:"min=-99999999999,max=99999999999"`
+	OriginalCurrency     *string                         `json:"originalCurrency,omitempty" binding:"omitempty,max=3"`
+	OriginalAmount       *int64                          `json:"originalAmount,omitempty" binding:"omitempty,min=-99999999999,max=99999999999"`
+	PayeeId              int64                           `json:"payeeId,string" binding:"min=0"`
+	HideAmount           bool                            `json:"hideAmount"`
+	TagIds               []string                        `json:"tagIds"`
+	ItemIds              []string                        `json:"itemIds"`
+	ItemDetails          []*TransactionItemDetailRequest `json:"itemDetails" binding:"omitempty,dive"`
+	PictureIds           []string                        `json:"pictureIds"`
+	Splits               []*TransactionSplitRequest      `json:"splits" binding:"omitempty,dive"`
+	Comment              string                          `json:"comment" binding:"max=255"`
+	GeoLocation          *TransactionGeoLocationRequest  `json:"geoLocation" binding:"omitempty"`
 }
 
 // TransactionImportRequest represents all parameters of transaction import request
@@ -225,6 +255,39 @@ type TransactionTagFilter struct {
 	Type   TransactionTagFilterType
 }
 
+// IsMatched returns whether the specified tag id set matches the tag filter
+func (f *TransactionTagFilter) IsMatched(tagIds map[int64]bool) bool {
+	filterTagIds := make(map[int64]bool, len(f.TagIds))
+	matchedCount := 0
+
+	for i := 0; i < len(f.TagIds); i++ {
+		if filterTagIds[f.TagIds[i]] {
+			continue
+		}
+
+		filterTagIds[f.TagIds[i]] = true
+
+		if tagIds[f.TagIds[i]] {
+			matchedCount++
+		}
+	}
+
+	hasAll := matchedCount > 0 && matchedCount >= len(filterTagIds)
+
+	switch f.Type {
+	case TRANSACTION_TAG_FILTER_HAS_ANY:
+		return matchedCount > 0
+	case TRANSACTION_TAG_FILTER_HAS_ALL:
+		return hasAll
+	case TRANSACTION_TAG_FILTER_NOT_HAS_ANY:
+		return matchedCount < 1
+	case TRANSACTION_TAG_FILTER_NOT_HAS_ALL:
+		return !hasAll
+	}
+
+	return true
+}
+
 type TransactionItemFilter struct {
 	ItemIds []int64
 	Type    TransactionItemFilterType
@@ -232,36 +295,42 @@ type TransactionItemFilter struct {
 
 // TransactionCountRequest represents transaction count request
 type TransactionCountRequest struct {
-	Type         TransactionType `form:"type" binding:"min=0,max=4"`
-	CategoryIds  string          `form:"category_ids"`
-	AccountIds   string          `form:"account_ids"`
-	TagFilter    string          `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter   string          `form:"item_filter" binding:"validItemFilter"`
-	AmountFilter string          `form:"amount_filter" binding:"validAmountFilter"`
-	Keyword      string          `form:"keyword"`
-	MaxTime      int64           `form:"max_time" binding:"min=0"` // Transaction time sequence id
-	MinTime      int64           `form:"min_time" binding:"min=0"` // Transaction time sequence id
+	Type          TransactionType `form:"type" binding:"min=0,max=4"`
+	CategoryIds   string          `form:"category_ids"`
+	AccountIds    string          `form:"account_ids"`
+	PayeeIds      string          `form:"payee_ids"`
+	TagFilter     string          `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter    string          `form:"item_filter" binding:"validItemFilter"`
+	AmountFilter  string          `form:"amount_filter" binding:"validAmountFilter"`
+	Keyword       string          `form:"keyword"`
+	SavedSearchId int64           `form:"saved_search_id,string" binding:"min=0"`
+	SearchQuery   string          `form:"search_query"`
+	MaxTime       int64           `form:"max_time" binding:"min=0"` // Transaction time sequence id
+	MinTime       int64           `form:"min_time" binding:"min=0"` // Transaction time sequence id
 }
 
 // TransactionListByMaxTimeRequest represents all parameters of transaction listing by max time request
 type TransactionListByMaxTimeRequest struct {
-	Type         TransactionType `form:"type" binding:"min=0,max=4"`
-	CategoryIds  string          `form:"category_ids"`
-	AccountIds   string          `form:"account_ids"`
-	TagFilter    string          `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter   string          `form:"item_filter" binding:"validItemFilter"`
-	AmountFilter string          `form:"amount_filter" binding:"validAmountFilter"`
-	Keyword      string          `form:"keyword"`
-	MaxTime      int64           `form:"max_time" binding:"min=0"` // Transaction time sequence id
-	MinTime      int64           `form:"min_time" binding:"min=0"` // Transaction time sequence id
-	Page         int32           `form:"page" binding:"min=0"`
-	Count        int32           `form:"count" binding:"required,min=1,max=50"`
-	WithCount    bool            `form:"with_count"`
-	WithPictures bool            `form:"with_pictures"`
-	TrimAccount  bool            `form:"trim_account"`
-	TrimCategory bool            `form:"trim_category"`
-	TrimTag      bool            `form:"trim_tag"`
-	TrimItem     bool            `form:"trim_item"`
+	Type          TransactionType `form:"type" binding:"min=0,max=4"`
+	CategoryIds   string          `form:"category_ids"`
+	AccountIds    string          `form:"account_ids"`
+	PayeeIds      string          `form:"payee_ids"`
+	TagFilter     string          `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter    string          `form:"item_filter" binding:"validItemFilter"`
+	AmountFilter  string          `form:"amount_filter" binding:"validAmountFilter"`
+	Keyword       string          `form:"keyword"`
+	SavedSearchId int64           `form:"saved_search_id,string" binding:"min=0"`
+	SearchQuery   string          `form:"search_query"`
+	MaxTime       int64           `form:"max_time" binding:"min=0"` // Transaction time sequence id
+	MinTime       int64           `form:"min_time" binding:"min=0"` // Transaction time sequence id
+	Page          int32           `form:"page" binding:"min=0"`
+	Count         int32           `form:"count" binding:"required,min=1,max=50"`
+	WithCount     bool            `form:"with_count"`
+	WithPictures  bool            `form:"with_pictures"`
+	TrimAccount   bool            `form:"trim_account"`
+	TrimCategory  bool            `form:"trim_category"`
+	TrimTag       bool            `form:"trim_tag"`
+	TrimItem      bool            `form:"trim_item"`
 }
 
 // TransactionListInMonthByPageRequest represents all parameters of transaction listing by month request
@@ -271,6 +340,7 @@ type TransactionListInMonthByPageRequest struct {
 	Type         TransactionType `form:"type" binding:"min=0,max=4"`
 	CategoryIds  string          `form:"category_ids"`
 	AccountIds   string          `form:"account_ids"`
+	PayeeIds     string          `form:"payee_ids"`
 	TagFilter    string          `form:"tag_filter" binding:"validTagFilter"`
 	ItemFilter   string          `form:"item_filter" binding:"validItemFilter"`
 	AmountFilter string          `form:"amount_filter" binding:"validAmountFilter"`
@@ -284,20 +354,23 @@ type TransactionListInMonthByPageRequest struct {
 
 // TransactionAllListRequest represents all parameters of all transaction listing request
 type TransactionAllListRequest struct {
-	Type         TransactionType `form:"type" binding:"min=0,max=4"`
-	CategoryIds  string          `form:"category_ids"`
-	AccountIds   string          `form:"account_ids"`
-	TagFilter    string          `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter   string          `form:"item_filter" binding:"validItemFilter"`
-	AmountFilter string          `form:"amount_filter" binding:"validAmountFilter"`
-	Keyword      string          `form:"keyword"`
-	StartTime    int64           `form:"start_time" binding:"min=0"`
-	EndTime      int64           `form:"end_time" binding:"min=0"`
-	WithPictures bool            `form:"with_pictures"`
-	TrimAccount  bool            `form:"trim_account"`
-	TrimCategory bool            `form:"trim_category"`
-	TrimTag      bool            `form:"trim_tag"`
-	TrimItem     bool            `form:"trim_item"`
+	Type          TransactionType `form:"type" binding:"min=0,max=4"`
+	CategoryIds   string          `form:"category_ids"`
+	AccountIds    string          `form:"account_ids"`
+	PayeeIds      string          `form:"payee_ids"`
+	TagFilter     string          `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter    string          `form:"item_filter" binding:"validItemFilter"`
+	AmountFilter  string          `form:"amount_filter" binding:"validAmountFilter"`
+	Keyword       string          `form:"keyword"`
+	SavedSearchId int64           `form:"saved_search_id,string" binding:"min=0"`
+	SearchQuery   string          `form:"search_query"`
+	StartTime     int64           `form:"start_time" binding:"min=0"`
+	EndTime       int64           `form:"end_time" binding:"min=0"`
+	WithPictures  bool            `form:"with_pictures"`
+	TrimAccount   bool            `form:"trim_account"`
+	TrimCategory  bool            `form:"trim_category"`
+	TrimTag       bool            `form:"trim_tag"`
+	TrimItem      bool            `form:"trim_item"`
 }
 
 // TransactionReconciliationStatementRequest represents all parameters of transaction reconciliation statement request
@@ -307,29 +380,60 @@ type TransactionReconciliationStatementRequest struct {
 	EndTime   int64 `form:"end_time"`
 }
 
+// TransactionClearedStatusModifyRequest represents all parameters of transaction cleared status modification request
+type TransactionClearedStatusModifyRequest struct {
+	Id            int64                    `json:"id,string" binding:"required,min=1"`
+	ClearedStatus TransactionClearedStatus `json:"clearedStatus" binding:"min=0,max=1"`
+}
+
 // TransactionStatisticRequest represents all parameters of transaction statistic request
 type TransactionStatisticRequest struct {
-	StartTime              int64  `form:"start_time" binding:"min=0"`
-	EndTime                int64  `form:"end_time" binding:"min=0"`
-	TagFilter              string `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter             string `form:"item_filter" binding:"validItemFilter"`
-	Keyword                string `form:"keyword"`
-	UseTransactionTimezone bool   `form:"use_transaction_timezone"`
+	StartTime                  int64  `form:"start_time" binding:"min=0"`
+	EndTime                    int64  `form:"end_time" binding:"min=0"`
+	TagFilter                  string `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter                 string `form:"item_filter" binding:"validItemFilter"`
+	PayeeIds                   string `form:"payee_ids"`
+	Keyword                    string `form:"keyword"`
+	SavedSearchId              int64  `form:"saved_search_id,string" binding:"min=0"`
+	SearchQuery                string `form:"search_query"`
+	UseTransactionTimezone     bool   `form:"use_transaction_timezone"`
+	UseHistoricalExchangeRates bool   `form:"use_historical_exchange_rates"`
+	GroupByOriginalCurrency    bool   `form:"group_by_original_currency"`
 }
 
 // TransactionStatisticTrendsRequest represents all parameters of transaction statistic trends request
 type TransactionStatisticTrendsRequest struct {
 	YearMonthRangeRequest
-	TagFilter              string `form:"tag_filter" binding:"validTagFilter"`
-	ItemFilter             string `form:"item_filter" binding:"validItemFilter"`
-	Keyword                string `form:"keyword"`
+	TagFilter                  string `form:"tag_filter" binding:"validTagFilter"`
+	ItemFilter                 string `form:"item_filter" binding:"validItemFilter"`
+	PayeeIds                   string `form:"payee_ids"`
+	Keyword                    string `form:"keyword"`
+	SavedSearchId              int64  `form:"saved_search_id,string" binding:"min=0"`
+	SearchQuery                string `form:"search_query"`
+	UseTransactionTimezone     bool   `form:"use_transaction_timezone"`
+	UseHistoricalExchangeRates bool   `form:"use_historical_exchange_rates"`
+}
+
+// TransactionItemStatisticRequest represents all parameters of transaction item statistic request
+type TransactionItemStatisticRequest struct {
+	YearMonthRangeRequest
+	ItemIds                string `form:"item_ids"`
+	UseTransactionTimezone bool   `form:"use_transaction_timezone"`
+}
+
+// TransactionPayeeStatisticRequest represents all parameters of transaction payee statistic request
+type TransactionPayeeStatisticRequest struct {
+	StartTime              int64  `form:"start_time" binding:"min=0"`
+	EndTime                int64  `form:"end_time" binding:"min=0"`
+	PayeeIds               string `form:"payee_ids"`
 	UseTransactionTimezone bool   `form:"use_transaction_timezone"`
 }
 
 // TransactionStatisticAssetTrendsRequest represents all parameters of transaction statistic asset trends request
 type TransactionStatisticAssetTrendsRequest struct {
-	StartTime int64 `form:"start_time"`
-	EndTime   int64 `form:"end_time"`
+	StartTime                  int64 `form:"start_time"`
+	EndTime                    int64 `form:"end_time"`
+	UseHistoricalExchangeRates bool  `form:"use_historical_exchange_rates"`
 }
 
 // TransactionAmountsRequest represents all parameters of transaction amounts request
@@ -395,17 +499,30 @@ type TransactionInfoResponse struct {
 	DestinationAccount   *AccountInfoResponse                     `json:"destinationAccount,omitempty"`
 	SourceAmount         int64                                    `json:"sourceAmount"`
 	DestinationAmount    int64                                    `json:"destinationAmount,omitempty"`
+	OriginalCurrency     string                                   `json:"originalCurrency,omitempty"`
+	OriginalAmount       int64                                    `json:"originalAmount,omitempty"`
+	PayeeId              int64                                    `json:"payeeId,string,omitempty"`
 	HideAmount           bool                                     `json:"hideAmount"`
+	ClearedStatus        TransactionClearedStatus                 `json:"clearedStatus"`
 	TagIds               []string                                 `json:"tagIds"`
 	Tags                 []*TransactionTagInfoResponse            `json:"tags,omitempty"`
 	ItemIds              []string                                 `json:"itemIds"`
 	Items                []*TransactionItemInfoResponse           `json:"items,omitempty"`
+	ItemDetails          []*TransactionItemDetailInfoResponse     `json:"itemDetails,omitempty"`
 	Pictures             TransactionPictureInfoBasicResponseSlice `json:"pictures,omitempty"`
+	Splits               []*TransactionSplitInfoResponse          `json:"splits,omitempty"`
 	Comment              string                                   `json:"comment"`
 	GeoLocation          *TransactionGeoLocationResponse          `json:"geoLocation,omitempty"`
+	CreatedBy            *TransactionCreatorInfoResponse          `json:"createdBy,omitempty"`
 	Editable             bool                                     `json:"editable"`
 }
 
+// TransactionCreatorInfoResponse represents a view-object of the shared ledger member who created the transaction
+type TransactionCreatorInfoResponse struct {
+	Username string `json:"username"`
+	Nickname string `json:"nickname"`
+}
+
 // TransactionCountResponse represents transaction count response
 type TransactionCountResponse struct {
 	TotalCount int64 `json:"totalCount"`
@@ -454,6 +571,7 @@ type TransactionStatisticResponseItem struct {
 	RelatedAccountId   int64                         `json:"relatedAccountId,string,omitempty"`
 	RelatedAccountType TransactionRelatedAccountType `json:"relatedAccountType,omitempty"`
 	TotalAmount        int64                         `json:"amount"`
+	Currency           string                        `json:"currency,omitempty"`
 }
 
 // TransactionStatisticTrendsResponseItem represents the data within each statistic interval
@@ -463,6 +581,35 @@ type TransactionStatisticTrendsResponseItem struct {
 	Items []*TransactionStatisticResponseItem `json:"items"`
 }
 
+// TransactionItemStatisticResponseItem represents total quantity, total line amount and average unit price of a transaction item in one currency
+type TransactionItemStatisticResponseItem struct {
+	ItemId           int64                                           `json:"itemId,string"`
+	Currency         string                                          `json:"currency"`
+	Unit             string                                          `json:"unit"`
+	TotalQuantity    float64                                         `json:"totalQuantity"`
+	TotalAmount      int64                                           `json:"totalAmount"`
+	AverageUnitPrice int64                                           `json:"averageUnitPrice"`
+	Trends           TransactionItemStatisticTrendsResponseItemSlice `json:"trends"`
+}
+
+// TransactionItemStatisticTrendsResponseItem represents the transaction item data within each statistic interval
+type TransactionItemStatisticTrendsResponseItem struct {
+	Year             int32   `json:"year"`
+	Month            int32   `json:"month"`
+	TotalQuantity    float64 `json:"totalQuantity"`
+	TotalAmount      int64   `json:"totalAmount"`
+	AverageUnitPrice int64   `json:"averageUnitPrice"`
+}
+
+// TransactionPayeeStatisticResponseItem represents total income amount, total expense amount and transaction count of a payee in one currency
+type TransactionPayeeStatisticResponseItem struct {
+	PayeeId            int64  `json:"payeeId,string"`
+	Currency           string `json:"currency"`
+	TotalIncomeAmount  int64  `json:"totalIncomeAmount"`
+	TotalExpenseAmount int64  `json:"totalExpenseAmount"`
+	TransactionCount   int64  `json:"transactionCount"`
+}
+
 // TransactionStatisticAssetTrendsResponseItem represents the data within each statistic interval
 type TransactionStatisticAssetTrendsResponseItem struct {
 	Year  int32                                              `json:"year"`
@@ -473,9 +620,14 @@ type TransactionStatisticAssetTrendsResponseItem struct {
 
 // TransactionStatisticAssetTrendsResponseDataItem represents an asset trends data item
 type TransactionStatisticAssetTrendsResponseDataItem struct {
-	AccountId             int64 `json:"accountId,string"`
-	AccountOpeningBalance int64 `json:"accountOpeningBalance"`
-	AccountClosingBalance int64 `json:"accountClosingBalance"`
+	AccountId                int64  `json:"accountId,string"`
+	AccountOpeningBalance    int64  `json:"accountOpeningBalance"`
+	AccountClosingBalance    int64  `json:"accountClosingBalance"`
+	InvestmentCostBasis      int64  `json:"investmentCostBasis,omitempty"`
+	InvestmentMarketValue    int64  `json:"investmentMarketValue,omitempty"`
+	InvestmentUnrealizedGain int64  `json:"investmentUnrealizedGain,omitempty"`
+	InvestmentRealizedGain   int64  `json:"investmentRealizedGain,omitempty"`
+	Currency                 string `json:"currency,omitempty"`
 }
 
 // TransactionAmountsResponseItem represents an item of transaction amounts
@@ -655,7 +807,11 @@ func (t *Transaction) ToTransactionInfoResponse(tagIds []int64, itemIds []int64,
 		DestinationAccountId: destinationAccountId,
 		SourceAmount:         sourceAmount,
 		DestinationAmount:    destinationAmount,
+		OriginalCurrency:     t.OriginalCurrency,
+		OriginalAmount:       t.OriginalAmount,
+		PayeeId:              t.PayeeId,
 		HideAmount:           t.HideAmount,
+		ClearedStatus:        t.ClearedStatus,
 		TagIds:               utils.Int64ArrayToStringArray(tagIds),
 		ItemIds:              utils.Int64ArrayToStringArray(itemIds),
 		Comment:              t.Comment,
@@ -664,6 +820,71 @@ func (t *Transaction) ToTransactionInfoResponse(tagIds []int64, itemIds []int64,
 	}
 }
 
+// HasOriginalAmount returns whether the transaction has the amount in original currency (e.g. card purchase made abroad)
+func (t *Transaction) HasOriginalAmount() bool {
+	return t.OriginalCurrency != "" && (t.Type == TRANSACTION_DB_TYPE_EXPENSE || t.Type == TRANSACTION_DB_TYPE_INCOME)
+}
+
+// GetOriginalAmountOfPart returns the original amount in proportion to the specified part of the transaction amount (e.g. the amount of a split line)
+func (t *Transaction) GetOriginalAmountOfPart(amount int64) int64 {
+	if t.Amount == 0 || amount == t.Amount {
+		return t.OriginalAmount
+	}
+
+	return int64(math.Round(float64(t.OriginalAmount) * float64(amount) / float64(t.Amount)))
+}
+
+// GetAccountBalanceChange returns the change amount of the account balance caused by this transaction
+func (t *Transaction) GetAccountBalanceChange() int64 {
+	if t.Type == TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+		return t.RelatedAccountAmount
+	} else if t.Type == TRANSACTION_DB_TYPE_INCOME || t.Type == TRANSACTION_DB_TYPE_TRANSFER_IN {
+		return t.Amount
+	} else if t.Type == TRANSACTION_DB_TYPE_EXPENSE || t.Type == TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		return -t.Amount
+	}
+
+	return 0
+}
+
+// IsCleared returns whether the transaction has been cleared or reconciled, the balance modification transaction is always treated as cleared
+func (t *Transaction) IsCleared() bool {
+	return t.Type == TRANSACTION_DB_TYPE_MODIFY_BALANCE || t.ClearedStatus == TRANSACTION_CLEARED_STATUS_CLEARED || t.ClearedStatus == TRANSACTION_CLEARED_STATUS_RECONCILED
+}
+
+// IsReconciled returns whether the transaction has been reconciled and locked from modification
+func (t *Transaction) IsReconciled() bool {
+	return t.ClearedStatus == TRANSACTION_CLEARED_STATUS_RECONCILED
+}
+
+// IsLocked returns whether the transaction time is at or before the transaction lock time of user or any of the specified accounts
+func (t *Transaction) IsLocked(userTransactionLockTime int64, accounts ...*Account) bool {
+	transactionLockTime := GetTransactionLockTime(userTransactionLockTime, accounts...)
+	return transactionLockTime > 0 && utils.GetUnixTimeFromTransactionTime(t.TransactionTime) <= transactionLockTime
+}
+
+// GetTransactionLockTime returns the latest transaction lock time of user and all the specified accounts, returns 0 if none of them is locked
+func GetTransactionLockTime(userTransactionLockTime int64, accounts ...*Account) int64 {
+	transactionLockTime := userTransactionLockTime
+
+	for i := 0; i < len(accounts); i++ {
+		if accounts[i] != nil && accounts[i].GetTransactionLockTime() > transactionLockTime {
+			transactionLockTime = accounts[i].GetTransactionLockTime()
+		}
+	}
+
+	if transactionLockTime < 0 {
+		return 0
+	}
+
+	return transactionLockTime
+}
+
+// IsCreatedByOtherMember returns whether the transaction is created by other member of the shared ledger rather than the owner
+func (t *Transaction) IsCreatedByOtherMember() bool {
+	return t.CreatedByUid > 0 && t.CreatedByUid != t.Uid
+}
+
 // GetTransactionAmountsRequestItems returns request items by query parameters
 func (t *TransactionAmountsRequest) GetTransactionAmountsRequestItems() ([]*TransactionAmountsRequestItem, error) {
 	items := strings.Split(t.Query, "|")
@@ -768,6 +989,80 @@ func (s TransactionStatisticTrendsResponseItemSlice) Less(i, j int) bool {
 	return s[i].Month < s[j].Month
 }
 
+// TransactionItemStatisticResponseItemSlice represents the slice data structure of TransactionItemStatisticResponseItem
+type TransactionItemStatisticResponseItemSlice []*TransactionItemStatisticResponseItem
+
+// Len returns the count of items
+func (s TransactionItemStatisticResponseItemSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionItemStatisticResponseItemSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionItemStatisticResponseItemSlice) Less(i, j int) bool {
+	if s[i].ItemId != s[j].ItemId {
+		return s[i].ItemId < s[j].ItemId
+	}
+
+	if s[i].Currency != s[j].Currency {
+		return strings.Compare(s[i].Currency, s[j].Currency) < 0
+	}
+
+	return strings.Compare(s[i].Unit, s[j].Unit) < 0
+}
+
+// TransactionItemStatisticTrendsResponseItemSlice represents the slice data structure of TransactionItemStatisticTrendsResponseItem
+type TransactionItemStatisticTrendsResponseItemSlice []*TransactionItemStatisticTrendsResponseItem
+
+// Len returns the count of items
+func (s TransactionItemStatisticTrendsResponseItemSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionItemStatisticTrendsResponseItemSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionItemStatisticTrendsResponseItemSlice) Less(i, j int) bool {
+	if s[i].Year != s[j].Year {
+		return s[i].Year < s[j].Year
+	}
+
+	return s[i].Month < s[j].Month
+}
+
+// TransactionPayeeStatisticResponseItemSlice represents the slice data structure of TransactionPayeeStatisticResponseItem
+type TransactionPayeeStatisticResponseItemSlice []*TransactionPayeeStatisticResponseItem
+
+// Len returns the count of items
+func (s TransactionPayeeStatisticResponseItemSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionPayeeStatisticResponseItemSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionPayeeStatisticResponseItemSlice) Less(i, j int) bool {
+	if s[i].TotalExpenseAmount != s[j].TotalExpenseAmount {
+		return s[i].TotalExpenseAmount > s[j].TotalExpenseAmount
+	}
+
+	if s[i].PayeeId != s[j].PayeeId {
+		return s[i].PayeeId < s[j].PayeeId
+	}
+
+	return strings.Compare(s[i].Currency, s[j].Currency) < 0
+}
+
 // TransactionStatisticAssetTrendsResponseItemSlice represents the slice data structure of TransactionStatisticAssetTrendsResponseItem
 type TransactionStatisticAssetTrendsResponseItemSlice []*TransactionStatisticAssetTrendsResponseItem
 
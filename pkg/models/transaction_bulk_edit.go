@@ -0,0 +1,149 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// MaximumTransactionsCountOfBulkEdit represents the maximum count of transactions which can be edited in one bulk edit request
+const MaximumTransactionsCountOfBulkEdit = 1000
+
+// TransactionBulkEditRequest represents all parameters of transaction bulk edit request
+type TransactionBulkEditRequest struct {
+	TransactionIds []string        `json:"transactionIds"`
+	Type           TransactionType `json:"type" binding:"min=0,max=4"`
+	CategoryIds    string          `json:"categoryIds"`
+	AccountIds     string          `json:"accountIds"`
+	PayeeIds       string          `json:"payeeIds"`
+	TagFilter      string          `json:"tagFilter" binding:"validTagFilter"`
+	ItemFilter     string          `json:"itemFilter" binding:"validItemFilter"`
+	AmountFilter   string          `json:"amountFilter" binding:"validAmountFilter"`
+	Keyword        string          `json:"keyword"`
+	MaxTime        int64           `json:"maxTime" binding:"min=0"` // Transaction time sequence id
+	MinTime        int64           `json:"minTime" binding:"min=0"` // Transaction time sequence id
+	SetCategoryId  int64           `json:"setCategoryId,string" binding:"min=0"`
+	SetAccountId   int64           `json:"setAccountId,string" binding:"min=0"`
+	AddTagIds      []string        `json:"addTagIds"`
+	RemoveTagIds   []string        `json:"removeTagIds"`
+	AddItemIds     []string        `json:"addItemIds"`
+	RemoveItemIds  []string        `json:"removeItemIds"`
+	SetComment     *string         `json:"setComment" binding:"omitempty,max=255"`
+	Delete         bool            `json:"delete"`
+	DryRun         bool            `json:"dryRun"`
+}
+
+// TransactionBulkEditFilter represents the filter of transactions to be edited in bulk
+type TransactionBulkEditFilter struct {
+	TransactionIds     []int64
+	MaxTransactionTime int64
+	MinTransactionTime int64
+	Type               TransactionType
+	CategoryIds        []int64
+	AccountIds         []int64
+	PayeeIds           []int64
+	TagFilters         []*TransactionTagFilter
+	NoTags             bool
+	ItemFilters        []*TransactionItemFilter
+	NoItems            bool
+	AmountFilter       string
+	Keyword            string
+}
+
+// IsEmpty returns whether the filter would match all transactions of user
+func (f *TransactionBulkEditFilter) IsEmpty() bool {
+	return len(f.TransactionIds) < 1 && f.MaxTransactionTime <= 0 && f.MinTransactionTime <= 0 && f.Type == 0 &&
+		len(f.CategoryIds) < 1 && len(f.AccountIds) < 1 && len(f.PayeeIds) < 1 &&
+		len(f.TagFilters) < 1 && !f.NoTags && len(f.ItemFilters) < 1 && !f.NoItems &&
+		f.AmountFilter == "" && f.Keyword == ""
+}
+
+// TransactionBulkEditChanges represents the changes to be applied to every matched transaction in bulk
+type TransactionBulkEditChanges struct {
+	CategoryId    int64
+	AccountId     int64
+	AddTagIds     []int64
+	RemoveTagIds  []int64
+	AddItemIds    []int64
+	RemoveItemIds []int64
+	Comment       *string
+	Delete        bool
+}
+
+// IsEmpty returns whether there is no change to be applied
+func (c *TransactionBulkEditChanges) IsEmpty() bool {
+	return c.CategoryId == 0 && c.AccountId == 0 && len(c.AddTagIds) < 1 && len(c.RemoveTagIds) < 1 &&
+		len(c.AddItemIds) < 1 && len(c.RemoveItemIds) < 1 && c.Comment == nil && !c.Delete
+}
+
+// HasModification returns whether there is any change other than deletion to be applied
+func (c *TransactionBulkEditChanges) HasModification() bool {
+	return c.CategoryId != 0 || c.AccountId != 0 || len(c.AddTagIds) > 0 || len(c.RemoveTagIds) > 0 ||
+		len(c.AddItemIds) > 0 || len(c.RemoveItemIds) > 0 || c.Comment != nil
+}
+
+// CanApplyToSplitTransaction returns whether the changes can be applied to the transaction which is split into multiple categories
+// The category of split transaction is saved in every split, so the category change and the category filter which only checks the whole transaction cannot be applied to it
+func (c *TransactionBulkEditChanges) CanApplyToSplitTransaction(filter *TransactionBulkEditFilter) bool {
+	return c.CategoryId == 0 && len(filter.CategoryIds) < 1
+}
+
+// TransactionBulkEditResult represents the result of transaction bulk edit
+type TransactionBulkEditResult struct {
+	MatchedTransactionIds  []int64
+	ModifiedTransactionIds []int64
+	DeletedTransactionIds  []int64
+	SkippedTransactionIds  []int64
+	AccountBalanceChanges  map[int64]int64
+}
+
+// TransactionBulkEditAccountBalanceChangeResponse represents a view-object of account balance change caused by transaction bulk edit
+type TransactionBulkEditAccountBalanceChangeResponse struct {
+	AccountId int64 `json:"accountId,string"`
+	Amount    int64 `json:"amount"`
+}
+
+// TransactionBulkEditResponse represents a view-object of transaction bulk edit result
+type TransactionBulkEditResponse struct {
+	DryRun                 bool                                               `json:"dryRun"`
+	MatchedCount           int                                                `json:"matchedCount"`
+	ModifiedCount          int                                                `json:"modifiedCount"`
+	DeletedCount           int                                                `json:"deletedCount"`
+	SkippedCount           int                                                `json:"skippedCount"`
+	ModifiedTransactionIds []string                                           `json:"modifiedTransactionIds"`
+	DeletedTransactionIds  []string                                           `json:"deletedTransactionIds"`
+	SkippedTransactionIds  []string                                           `json:"skippedTransactionIds"`
+	AccountBalanceChanges  []*TransactionBulkEditAccountBalanceChangeResponse `json:"accountBalanceChanges"`
+}
+
+// ToTransactionBulkEditResponse returns a view-object according to bulk edit result
+func (r *TransactionBulkEditResult) ToTransactionBulkEditResponse(dryRun bool) *TransactionBulkEditResponse {
+	accountBalanceChanges := make([]*TransactionBulkEditAccountBalanceChangeResponse, 0, len(r.AccountBalanceChanges))
+
+	for accountId, amount := range r.AccountBalanceChanges {
+		if amount == 0 {
+			continue
+		}
+
+		accountBalanceChanges = append(accountBalanceChanges, &TransactionBulkEditAccountBalanceChangeResponse{
+			AccountId: accountId,
+			Amount:    amount,
+		})
+	}
+
+	sort.Slice(accountBalanceChanges, func(i, j int) bool {
+		return accountBalanceChanges[i].AccountId < accountBalanceChanges[j].AccountId
+	})
+
+	return &TransactionBulkEditResponse{
+		DryRun:                 dryRun,
+		MatchedCount:           len(r.MatchedTransactionIds),
+		ModifiedCount:          len(r.ModifiedTransactionIds),
+		DeletedCount:           len(r.DeletedTransactionIds),
+		SkippedCount:           len(r.SkippedTransactionIds),
+		ModifiedTransactionIds: utils.Int64ArrayToStringArray(r.ModifiedTransactionIds),
+		DeletedTransactionIds:  utils.Int64ArrayToStringArray(r.DeletedTransactionIds),
+		SkippedTransactionIds:  utils.Int64ArrayToStringArray(r.SkippedTransactionIds),
+		AccountBalanceChanges:  accountBalanceChanges,
+	}
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionBulkEditFilterIsEmpty(t *testing.T) {
+	filter := &TransactionBulkEditFilter{}
+	assert.True(t, filter.IsEmpty())
+
+	filter = &TransactionBulkEditFilter{TransactionIds: []int64{1}}
+	assert.False(t, filter.IsEmpty())
+
+	filter = &TransactionBulkEditFilter{NoTags: true}
+	assert.False(t, filter.IsEmpty())
+
+	filter = &TransactionBulkEditFilter{Keyword: "test"}
+	assert.False(t, filter.IsEmpty())
+}
+
+func TestTransactionBulkEditChangesIsEmptyAndHasModification(t *testing.T) {
+	changes := &TransactionBulkEditChanges{}
+	assert.True(t, changes.IsEmpty())
+	assert.False(t, changes.HasModification())
+
+	changes = &TransactionBulkEditChanges{Delete: true}
+	assert.False(t, changes.IsEmpty())
+	assert.False(t, changes.HasModification())
+
+	comment := ""
+	changes = &TransactionBulkEditChanges{Comment: &comment}
+	assert.False(t, changes.IsEmpty())
+	assert.True(t, changes.HasModification())
+
+	changes = &TransactionBulkEditChanges{RemoveItemIds: []int64{1}}
+	assert.False(t, changes.IsEmpty())
+	assert.True(t, changes.HasModification())
+}
+
+func TestTransactionBulkEditChangesCanApplyToSplitTransaction(t *testing.T) {
+	comment := ""
+	changes := &TransactionBulkEditChanges{Comment: &comment, AddTagIds: []int64{1}}
+	assert.True(t, changes.CanApplyToSplitTransaction(&TransactionBulkEditFilter{TransactionIds: []int64{1}}))
+
+	changes = &TransactionBulkEditChanges{Delete: true}
+	assert.True(t, changes.CanApplyToSplitTransaction(&TransactionBulkEditFilter{Keyword: "test"}))
+
+	changes = &TransactionBulkEditChanges{CategoryId: 1}
+	assert.False(t, changes.CanApplyToSplitTransaction(&TransactionBulkEditFilter{TransactionIds: []int64{1}}))
+
+	changes = &TransactionBulkEditChanges{Comment: &comment}
+	assert.False(t, changes.CanApplyToSplitTransaction(&TransactionBulkEditFilter{CategoryIds: []int64{1}}))
+
+	changes = &TransactionBulkEditChanges{Delete: true}
+	assert.False(t, changes.CanApplyToSplitTransaction(&TransactionBulkEditFilter{CategoryIds: []int64{1}}))
+}
+
+func TestTransactionBulkEditResultToTransactionBulkEditResponse(t *testing.T) {
+	result := &TransactionBulkEditResult{
+		MatchedTransactionIds:  []int64{1, 2, 3, 4},
+		ModifiedTransactionIds: []int64{1, 2},
+		DeletedTransactionIds:  []int64{},
+		SkippedTransactionIds:  []int64{3},
+		AccountBalanceChanges: map[int64]int64{
+			20: -100,
+			10: 100,
+			30: 0,
+		},
+	}
+
+	response := result.ToTransactionBulkEditResponse(true)
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 4, response.MatchedCount)
+	assert.Equal(t, 2, response.ModifiedCount)
+	assert.Equal(t, 0, response.DeletedCount)
+	assert.Equal(t, 1, response.SkippedCount)
+	assert.Equal(t, []string{"1", "2"}, response.ModifiedTransactionIds)
+	assert.Equal(t, []string{"3"}, response.SkippedTransactionIds)
+	assert.Equal(t, 2, len(response.AccountBalanceChanges))
+	assert.Equal(t, int64(10), response.AccountBalanceChanges[0].AccountId)
+	assert.Equal(t, int64(100), response.AccountBalanceChanges[0].Amount)
+	assert.Equal(t, int64(20), response.AccountBalanceChanges[1].AccountId)
+	assert.Equal(t, int64(-100), response.AccountBalanceChanges[1].Amount)
+}
@@ -1,14 +1,102 @@
 package models
 
+import "math"
+
 // TransactionItemIndex represents transaction and transaction item relation stored in database
 type TransactionItemIndex struct {
-	ItemIndexId      int64 `xorm:"PK"`
-	Uid              int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
-	Deleted          bool  `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id) NOT NULL"`
-	TransactionTime  int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) NOT NULL"`
-	ItemId           int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id)"`
-	TransactionId    int64 `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
-	CreatedUnixTime  int64
-	UpdatedUnixTime  int64
-	DeletedUnixTime  int64
+	ItemIndexId     int64   `xorm:"PK"`
+	Uid             int64   `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
+	Deleted         bool    `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id) NOT NULL"`
+	TransactionTime int64   `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id) NOT NULL"`
+	ItemId          int64   `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_time_item_id)"`
+	TransactionId   int64   `xorm:"INDEX(IDX_transaction_item_index_uid_deleted_item_id_transaction_id) INDEX(IDX_transaction_item_index_uid_deleted_transaction_id)"`
+	Quantity        float64 `xorm:"NOT NULL DEFAULT 0"`
+	Unit            string  `xorm:"VARCHAR(16) NOT NULL DEFAULT ''"`
+	UnitPrice       int64   `xorm:"NOT NULL DEFAULT 0"`
+	Amount          int64   `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// TransactionItemDetailRequest represents quantity, unit, unit price and line amount of a transaction item in transaction creation or modification request
+type TransactionItemDetailRequest struct {
+	ItemId    int64   `json:"itemId,string" binding:"required,min=1"`
+	Quantity  float64 `json:"quantity" binding:"min=0,max=99999999"`
+	Unit      string  `json:"unit" binding:"max=16"`
+	UnitPrice int64   `json:"unitPrice" binding:"min=0,max=99999999999"`
+	Amount    int64   `json:"amount" binding:"min=0,max=99999999999"`
+}
+
+// TransactionItemDetailInfoResponse represents a view-object of quantity, unit, unit price and line amount of a transaction item
+type TransactionItemDetailInfoResponse struct {
+	ItemId    int64   `json:"itemId,string"`
+	Quantity  float64 `json:"quantity"`
+	Unit      string  `json:"unit"`
+	UnitPrice int64   `json:"unitPrice"`
+	Amount    int64   `json:"amount"`
+}
+
+// TransactionItemTotalAmount represents total quantity and total line amount of a transaction item in one account
+type TransactionItemTotalAmount struct {
+	ItemId    int64
+	AccountId int64
+	Unit      string
+	Quantity  float64
+	Amount    int64
+}
+
+// FillDetail fills the quantity, unit, unit price and line amount from the specified transaction item detail, and calculates the missing one of line amount and unit price
+func (t *TransactionItemIndex) FillDetail(detail *TransactionItemDetailRequest) {
+	if detail == nil {
+		t.Quantity = 0
+		t.Unit = ""
+		t.UnitPrice = 0
+		t.Amount = 0
+		return
+	}
+
+	t.Quantity = detail.Quantity
+	t.Unit = detail.Unit
+	t.UnitPrice = detail.UnitPrice
+	t.Amount = detail.Amount
+
+	if t.Amount == 0 && t.Quantity > 0 && t.UnitPrice > 0 {
+		t.Amount = int64(math.Round(t.Quantity * float64(t.UnitPrice)))
+	} else if t.UnitPrice == 0 && t.Quantity > 0 && t.Amount > 0 {
+		t.UnitPrice = GetTransactionItemUnitPrice(t.Amount, t.Quantity)
+	}
+}
+
+// HasDetail returns whether this transaction item index has any of quantity, unit, unit price and line amount
+func (t *TransactionItemIndex) HasDetail() bool {
+	return t.Quantity != 0 || t.Unit != "" || t.UnitPrice != 0 || t.Amount != 0
+}
+
+// IsSameDetail returns whether this transaction item index has the same quantity, unit, unit price and line amount with the specified one
+func (t *TransactionItemIndex) IsSameDetail(other *TransactionItemIndex) bool {
+	return t.Quantity == other.Quantity &&
+		t.Unit == other.Unit &&
+		t.UnitPrice == other.UnitPrice &&
+		t.Amount == other.Amount
+}
+
+// ToTransactionItemDetailInfoResponse returns a view-object according to database model
+func (t *TransactionItemIndex) ToTransactionItemDetailInfoResponse() *TransactionItemDetailInfoResponse {
+	return &TransactionItemDetailInfoResponse{
+		ItemId:    t.ItemId,
+		Quantity:  t.Quantity,
+		Unit:      t.Unit,
+		UnitPrice: t.UnitPrice,
+		Amount:    t.Amount,
+	}
+}
+
+// GetTransactionItemUnitPrice returns the unit price (rounded to the minimum currency unit) by the specified line amount and quantity
+func GetTransactionItemUnitPrice(amount int64, quantity float64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+
+	return int64(math.Round(float64(amount) / quantity))
 }
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionItemIndexFillDetail_CalculateAmount(t *testing.T) {
+	itemIndex := &TransactionItemIndex{}
+	itemIndex.FillDetail(&TransactionItemDetailRequest{
+		Quantity:  1.5,
+		Unit:      "kg",
+		UnitPrice: 1999,
+	})
+
+	assert.Equal(t, 1.5, itemIndex.Quantity)
+	assert.Equal(t, "kg", itemIndex.Unit)
+	assert.Equal(t, int64(1999), itemIndex.UnitPrice)
+	assert.Equal(t, int64(2999), itemIndex.Amount)
+}
+
+func TestTransactionItemIndexFillDetail_CalculateUnitPrice(t *testing.T) {
+	itemIndex := &TransactionItemIndex{}
+	itemIndex.FillDetail(&TransactionItemDetailRequest{
+		Quantity: 3,
+		Amount:   1000,
+	})
+
+	assert.Equal(t, int64(333), itemIndex.UnitPrice)
+	assert.Equal(t, int64(1000), itemIndex.Amount)
+}
+
+func TestTransactionItemIndexFillDetail_KeepSpecifiedValues(t *testing.T) {
+	itemIndex := &TransactionItemIndex{}
+	itemIndex.FillDetail(&TransactionItemDetailRequest{
+		Quantity:  2,
+		UnitPrice: 500,
+		Amount:    900,
+	})
+
+	assert.Equal(t, int64(500), itemIndex.UnitPrice)
+	assert.Equal(t, int64(900), itemIndex.Amount)
+}
+
+func TestTransactionItemIndexFillDetail_Nil(t *testing.T) {
+	itemIndex := &TransactionItemIndex{Quantity: 2, Unit: "pcs", UnitPrice: 500, Amount: 1000}
+	itemIndex.FillDetail(nil)
+
+	assert.False(t, itemIndex.HasDetail())
+}
+
+func TestTransactionItemIndexIsSameDetail(t *testing.T) {
+	itemIndex := &TransactionItemIndex{ItemIndexId: 1, ItemId: 2, Quantity: 2, Unit: "pcs", UnitPrice: 500, Amount: 1000}
+
+	assert.True(t, itemIndex.IsSameDetail(&TransactionItemIndex{ItemIndexId: 3, ItemId: 2, Quantity: 2, Unit: "pcs", UnitPrice: 500, Amount: 1000}))
+	assert.False(t, itemIndex.IsSameDetail(&TransactionItemIndex{Quantity: 3, Unit: "pcs", UnitPrice: 500, Amount: 1000}))
+	assert.False(t, itemIndex.IsSameDetail(&TransactionItemIndex{Quantity: 2, Unit: "kg", UnitPrice: 500, Amount: 1000}))
+	assert.False(t, itemIndex.IsSameDetail(&TransactionItemIndex{Quantity: 2, Unit: "pcs", UnitPrice: 400, Amount: 1000}))
+	assert.False(t, itemIndex.IsSameDetail(&TransactionItemIndex{Quantity: 2, Unit: "pcs", UnitPrice: 500, Amount: 800}))
+}
+
+func TestGetTransactionItemUnitPrice(t *testing.T) {
+	assert.Equal(t, int64(0), GetTransactionItemUnitPrice(1000, 0))
+	assert.Equal(t, int64(500), GetTransactionItemUnitPrice(1000, 2))
+	assert.Equal(t, int64(667), GetTransactionItemUnitPrice(1000, 1.5))
+}
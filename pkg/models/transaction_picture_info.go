@@ -1,7 +1,23 @@
 package models
 
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
 const TransactionPictureNewPictureTransactionId = int64(0)
 
+// TransactionAttachmentType represents the type of transaction attachment
+type TransactionAttachmentType byte
+
+// Transaction attachment types
+const (
+	TRANSACTION_ATTACHMENT_TYPE_IMAGE    TransactionAttachmentType = 1
+	TRANSACTION_ATTACHMENT_TYPE_PDF      TransactionAttachmentType = 2
+	TRANSACTION_ATTACHMENT_TYPE_DOCUMENT TransactionAttachmentType = 3
+)
+
 // TransactionPictureInfo represents transaction picture file info stored in database
 type TransactionPictureInfo struct {
 	Uid              int64  `xorm:"INDEX(IDX_transaction_picture_uid_deleted_transaction_id_picture_id) INDEX(IDX_transaction_picture_uid_deleted_picture_id) NOT NULL"`
@@ -9,6 +25,9 @@ type TransactionPictureInfo struct {
 	TransactionId    int64  `xorm:"INDEX(IDX_transaction_picture_uid_deleted_transaction_id_picture_id) NOT NULL"`
 	PictureId        int64  `xorm:"PK INDEX(IDX_transaction_picture_uid_deleted_transaction_id_picture_id) INDEX(IDX_transaction_picture_uid_deleted_picture_id)"`
 	PictureExtension string `xorm:"VARCHAR(10) NOT NULL"`
+	FileName         string `xorm:"VARCHAR(255)"`
+	ContentType      string `xorm:"VARCHAR(128)"`
+	FileSize         int64
 	CreatedIp        string `xorm:"VARCHAR(39)"`
 	CreatedUnixTime  int64
 	UpdatedUnixTime  int64
@@ -24,16 +43,61 @@ type TransactionPictureUnusedDeleteRequest struct {
 type TransactionPictureInfoBasicResponse struct {
 	PictureId   int64  `json:"pictureId,string"`
 	OriginalUrl string `json:"originalUrl"`
+	DownloadUrl string `json:"downloadUrl"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType"`
+	FileSize    int64  `json:"fileSize,omitempty"`
+	IsImage     bool   `json:"isImage"`
+}
+
+// GetContentType returns the content type of the transaction attachment
+func (p *TransactionPictureInfo) GetContentType() string {
+	if p.ContentType != "" {
+		return p.ContentType
+	}
+
+	// pictures uploaded before attachments supported do not have content type
+	return utils.GetAttachmentContentType(p.PictureExtension)
+}
+
+// GetAttachmentType returns the attachment type of the transaction attachment
+func (p *TransactionPictureInfo) GetAttachmentType() TransactionAttachmentType {
+	return GetTransactionAttachmentType(p.GetContentType())
+}
+
+// GetDownloadFileName returns the file name used for downloading the transaction attachment
+func (p *TransactionPictureInfo) GetDownloadFileName() string {
+	if p.FileName != "" {
+		return p.FileName
+	}
+
+	return utils.Int64ToString(p.PictureId) + "." + p.PictureExtension
 }
 
 // ToTransactionPictureInfoBasicResponse returns a view-object according to database model
-func (p *TransactionPictureInfo) ToTransactionPictureInfoBasicResponse(originalUrl string) *TransactionPictureInfoBasicResponse {
+func (p *TransactionPictureInfo) ToTransactionPictureInfoBasicResponse(originalUrl string, downloadUrl string) *TransactionPictureInfoBasicResponse {
 	return &TransactionPictureInfoBasicResponse{
 		PictureId:   p.PictureId,
 		OriginalUrl: originalUrl,
+		DownloadUrl: downloadUrl,
+		FileName:    p.FileName,
+		ContentType: p.GetContentType(),
+		FileSize:    p.FileSize,
+		IsImage:     p.GetAttachmentType() == TRANSACTION_ATTACHMENT_TYPE_IMAGE,
 	}
 }
 
+// GetTransactionAttachmentType returns the attachment type according to the content type
+func GetTransactionAttachmentType(contentType string) TransactionAttachmentType {
+	if strings.HasPrefix(contentType, "image/") {
+		return TRANSACTION_ATTACHMENT_TYPE_IMAGE
+	} else if contentType == "application/pdf" {
+		return TRANSACTION_ATTACHMENT_TYPE_PDF
+	}
+
+	return TRANSACTION_ATTACHMENT_TYPE_DOCUMENT
+}
+
 // TransactionPictureInfoBasicResponseSlice represents the slice data structure of TransactionPictureInfoBasicResponse
 type TransactionPictureInfoBasicResponseSlice []*TransactionPictureInfoBasicResponse
 
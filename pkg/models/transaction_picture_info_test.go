@@ -1,27 +1,41 @@
 package models
 
 import (
-	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
-func TestTransactionPictureInfoBasicResponseSliceLess(t *testing.T) {
-	var pictureInfoSlice TransactionPictureInfoBasicResponseSlice
-	pictureInfoSlice = append(pictureInfoSlice, &TransactionPictureInfoBasicResponse{
-		PictureId: 2,
-	})
-	pictureInfoSlice = append(pictureInfoSlice, &TransactionPictureInfoBasicResponse{
-		PictureId: 3,
-	})
-	pictureInfoSlice = append(pictureInfoSlice, &TransactionPictureInfoBasicResponse{
-		PictureId: 1,
-	})
+func TestGetTransactionAttachmentType(t *testing.T) {
+	assert.Equal(t, TRANSACTION_ATTACHMENT_TYPE_IMAGE, GetTransactionAttachmentType("image/png"))
+	assert.Equal(t, TRANSACTION_ATTACHMENT_TYPE_PDF, GetTransactionAttachmentType("application/pdf"))
+	assert.Equal(t, TRANSACTION_ATTACHMENT_TYPE_DOCUMENT, GetTransactionAttachmentType("text/csv"))
+}
+
+func TestTransactionPictureInfoToTransactionPictureInfoBasicResponse(t *testing.T) {
+	pictureInfo := &TransactionPictureInfo{
+		PictureId:        1234,
+		PictureExtension: "jpg",
+	}
+
+	response := pictureInfo.ToTransactionPictureInfoBasicResponse("/pictures/1234.jpg", "/attachments/1234.jpg")
+	assert.Equal(t, "image/jpeg", response.ContentType)
+	assert.Equal(t, "", response.FileName)
+	assert.True(t, response.IsImage)
+	assert.Equal(t, "1234.jpg", pictureInfo.GetDownloadFileName())
 
-	sort.Sort(pictureInfoSlice)
+	attachmentInfo := &TransactionPictureInfo{
+		PictureId:        1235,
+		PictureExtension: "pdf",
+		FileName:         "Invoice 2024-01.pdf",
+		ContentType:      "application/pdf",
+		FileSize:         2048,
+	}
 
-	assert.Equal(t, int64(1), pictureInfoSlice[0].PictureId)
-	assert.Equal(t, int64(2), pictureInfoSlice[1].PictureId)
-	assert.Equal(t, int64(3), pictureInfoSlice[2].PictureId)
+	response = attachmentInfo.ToTransactionPictureInfoBasicResponse("/attachments/1235.pdf", "/attachments/1235.pdf")
+	assert.Equal(t, "application/pdf", response.ContentType)
+	assert.Equal(t, "Invoice 2024-01.pdf", response.FileName)
+	assert.Equal(t, int64(2048), response.FileSize)
+	assert.False(t, response.IsImage)
+	assert.Equal(t, "Invoice 2024-01.pdf", attachmentInfo.GetDownloadFileName())
 }
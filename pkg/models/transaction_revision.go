@@ -0,0 +1,496 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// TransactionRevisionType represents the operation type of transaction revision
+type TransactionRevisionType byte
+
+// Transaction revision types
+const (
+	TRANSACTION_REVISION_TYPE_MODIFY  TransactionRevisionType = 1
+	TRANSACTION_REVISION_TYPE_REVERT  TransactionRevisionType = 2
+	TRANSACTION_REVISION_TYPE_CREATE  TransactionRevisionType = 3
+	TRANSACTION_REVISION_TYPE_DELETE  TransactionRevisionType = 4
+	TRANSACTION_REVISION_TYPE_RESTORE TransactionRevisionType = 5
+)
+
+// TransactionRevisionSource represents the source of the operation which creates transaction revision
+type TransactionRevisionSource byte
+
+// Transaction revision sources
+const (
+	TRANSACTION_REVISION_SOURCE_SYSTEM TransactionRevisionSource = 0
+	TRANSACTION_REVISION_SOURCE_WEB    TransactionRevisionSource = 1
+	TRANSACTION_REVISION_SOURCE_API    TransactionRevisionSource = 2
+	TRANSACTION_REVISION_SOURCE_MCP    TransactionRevisionSource = 3
+	TRANSACTION_REVISION_SOURCE_CLI    TransactionRevisionSource = 4
+)
+
+// Changed field names of transaction revision
+const (
+	TRANSACTION_REVISION_FIELD_CATEGORY            = "category"
+	TRANSACTION_REVISION_FIELD_ACCOUNT             = "account"
+	TRANSACTION_REVISION_FIELD_PAYEE               = "payee"
+	TRANSACTION_REVISION_FIELD_TIME                = "time"
+	TRANSACTION_REVISION_FIELD_TIMEZONE            = "timezone"
+	TRANSACTION_REVISION_FIELD_AMOUNT              = "amount"
+	TRANSACTION_REVISION_FIELD_DESTINATION_ACCOUNT = "destinationAccount"
+	TRANSACTION_REVISION_FIELD_DESTINATION_AMOUNT  = "destinationAmount"
+	TRANSACTION_REVISION_FIELD_ORIGINAL_AMOUNT     = "originalAmount"
+	TRANSACTION_REVISION_FIELD_HIDE_AMOUNT         = "hideAmount"
+	TRANSACTION_REVISION_FIELD_COMMENT             = "comment"
+	TRANSACTION_REVISION_FIELD_GEO_LOCATION        = "geoLocation"
+	TRANSACTION_REVISION_FIELD_TAGS                = "tags"
+	TRANSACTION_REVISION_FIELD_ITEMS               = "items"
+	TRANSACTION_REVISION_FIELD_PICTURES            = "pictures"
+	TRANSACTION_REVISION_FIELD_SPLITS              = "splits"
+)
+
+// TransactionRevision represents the before and after snapshots of a transaction modification stored in database,
+// the before snapshot of creation and restoration, and the after snapshot of deletion are empty
+type TransactionRevision struct {
+	RevisionId         int64                        `xorm:"PK"`
+	Uid                int64                        `xorm:"INDEX(IDX_transaction_revision_uid_deleted_transaction_id) NOT NULL"`
+	Deleted            bool                         `xorm:"INDEX(IDX_transaction_revision_uid_deleted_transaction_id) NOT NULL"`
+	TransactionId      int64                        `xorm:"INDEX(IDX_transaction_revision_uid_deleted_transaction_id) NOT NULL"`
+	Type               TransactionRevisionType      `xorm:"NOT NULL"`
+	RevertedRevisionId int64                        `xorm:"NOT NULL DEFAULT 0"`
+	OperatorUid        int64                        `xorm:"NOT NULL DEFAULT 0"`
+	Source             TransactionRevisionSource    `xorm:"NOT NULL DEFAULT 0"`
+	ClientIp           string                       `xorm:"VARCHAR(39)"`
+	Before             *TransactionRevisionSnapshot `xorm:"BLOB"`
+	After              *TransactionRevisionSnapshot `xorm:"BLOB"`
+	CreatedUnixTime    int64
+	DeletedUnixTime    int64
+}
+
+// TransactionRevisionActor represents the actor who creates, modifies or deletes the transaction
+type TransactionRevisionActor struct {
+	OperatorUid        int64
+	Source             TransactionRevisionSource
+	ClientIp           string
+	RevertedRevisionId int64
+}
+
+// TransactionRevisionSnapshot represents the snapshot of transaction content at one time
+type TransactionRevisionSnapshot struct {
+	CategoryId                  int64                               `json:"categoryId"`
+	AccountId                   int64                               `json:"accountId"`
+	PayeeId                     int64                               `json:"payeeId"`
+	TransactionTime             int64                               `json:"transactionTime"`
+	TimezoneUtcOffset           int16                               `json:"timezoneUtcOffset"`
+	Amount                      int64                               `json:"amount"`
+	RelatedAccountId            int64                               `json:"relatedAccountId"`
+	RelatedAccountAmount        int64                               `json:"relatedAccountAmount"`
+	OriginalCurrency            string                              `json:"originalCurrency,omitempty"`
+	OriginalAmount              int64                               `json:"originalAmount,omitempty"`
+	OriginalAmountDecimalPlaces int32                               `json:"originalAmountDecimalPlaces,omitempty"`
+	HideAmount                  bool                                `json:"hideAmount"`
+	Comment                     string                              `json:"comment"`
+	GeoLongitude                float64                             `json:"geoLongitude"`
+	GeoLatitude                 float64                             `json:"geoLatitude"`
+	TagIds                      []int64                             `json:"tagIds"`
+	Items                       []*TransactionRevisionItemSnapshot  `json:"items"`
+	PictureIds                  []int64                             `json:"pictureIds"`
+	Splits                      []*TransactionRevisionSplitSnapshot `json:"splits"`
+}
+
+// TransactionRevisionItemSnapshot represents the snapshot of a transaction item index
+type TransactionRevisionItemSnapshot struct {
+	ItemId    int64   `json:"itemId"`
+	Quantity  float64 `json:"quantity"`
+	Unit      string  `json:"unit"`
+	UnitPrice int64   `json:"unitPrice"`
+	Amount    int64   `json:"amount"`
+}
+
+// TransactionRevisionSplitSnapshot represents the snapshot of a transaction split line
+type TransactionRevisionSplitSnapshot struct {
+	CategoryId int64  `json:"categoryId"`
+	Amount     int64  `json:"amount"`
+	TagIds     string `json:"tagIds"`
+	Comment    string `json:"comment"`
+}
+
+// TransactionRevisionListRequest represents all parameters of transaction revision listing request
+type TransactionRevisionListRequest struct {
+	TransactionId int64 `form:"transaction_id,string" binding:"required,min=1"`
+}
+
+// TransactionRevisionRevertRequest represents all parameters of transaction revision reverting request
+type TransactionRevisionRevertRequest struct {
+	TransactionId int64 `json:"transactionId,string" binding:"required,min=1"`
+	RevisionId    int64 `json:"revisionId,string" binding:"required,min=1"`
+}
+
+// TransactionRevisionInfoResponse represents a view-object of transaction revision
+type TransactionRevisionInfoResponse struct {
+	Id                 int64                                `json:"id,string"`
+	TransactionId      int64                                `json:"transactionId,string"`
+	Type               TransactionRevisionType              `json:"type"`
+	RevertedRevisionId int64                                `json:"revertedRevisionId,string,omitempty"`
+	Operator           *TransactionCreatorInfoResponse      `json:"operator,omitempty"`
+	Source             TransactionRevisionSource            `json:"source"`
+	ClientIp           string                               `json:"clientIp"`
+	Before             *TransactionRevisionSnapshotResponse `json:"before"`
+	After              *TransactionRevisionSnapshotResponse `json:"after"`
+	ChangedFields      []string                             `json:"changedFields"`
+	CreatedAt          int64                                `json:"createdAt"`
+}
+
+// TransactionRevisionSnapshotResponse represents a view-object of transaction revision snapshot
+type TransactionRevisionSnapshotResponse struct {
+	CategoryId           int64                                   `json:"categoryId,string"`
+	SourceAccountId      int64                                   `json:"sourceAccountId,string"`
+	DestinationAccountId int64                                   `json:"destinationAccountId,string,omitempty"`
+	PayeeId              int64                                   `json:"payeeId,string,omitempty"`
+	Time                 int64                                   `json:"time"`
+	UtcOffset            int16                                   `json:"utcOffset"`
+	SourceAmount         int64                                   `json:"sourceAmount"`
+	DestinationAmount    int64                                   `json:"destinationAmount,omitempty"`
+	OriginalCurrency     string                                  `json:"originalCurrency,omitempty"`
+	OriginalAmount       int64                                   `json:"originalAmount,omitempty"`
+	HideAmount           bool                                    `json:"hideAmount"`
+	Comment              string                                  `json:"comment"`
+	GeoLocation          *TransactionGeoLocationResponse         `json:"geoLocation,omitempty"`
+	TagIds               []string                                `json:"tagIds"`
+	ItemIds              []string                                `json:"itemIds"`
+	ItemDetails          []*TransactionItemDetailInfoResponse    `json:"itemDetails,omitempty"`
+	PictureIds           []string                                `json:"pictureIds"`
+	Splits               []*TransactionRevisionSplitInfoResponse `json:"splits,omitempty"`
+}
+
+// TransactionRevisionSplitInfoResponse represents a view-object of transaction split line in transaction revision snapshot
+type TransactionRevisionSplitInfoResponse struct {
+	CategoryId int64    `json:"categoryId,string"`
+	Amount     int64    `json:"amount"`
+	TagIds     []string `json:"tagIds"`
+	Comment    string   `json:"comment"`
+}
+
+// FromDB fills the fields from the data stored in database
+func (s *TransactionRevisionSnapshot) FromDB(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// ToDB returns the actual stored data in database
+func (s *TransactionRevisionSnapshot) ToDB() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// NewTransactionRevisionSnapshot returns a new transaction revision snapshot according to the transaction and its related data
+func NewTransactionRevisionSnapshot(transaction *Transaction, tagIds []int64, itemIndexes []*TransactionItemIndex, pictureIds []int64, splits []*TransactionSplit) *TransactionRevisionSnapshot {
+	snapshot := &TransactionRevisionSnapshot{
+		CategoryId:           transaction.CategoryId,
+		AccountId:            transaction.AccountId,
+		PayeeId:              transaction.PayeeId,
+		TransactionTime:      transaction.TransactionTime,
+		TimezoneUtcOffset:    transaction.TimezoneUtcOffset,
+		Amount:               transaction.Amount,
+		RelatedAccountId:     transaction.RelatedAccountId,
+		RelatedAccountAmount: transaction.RelatedAccountAmount,
+		OriginalCurrency:     transaction.OriginalCurrency,
+		OriginalAmount:       transaction.OriginalAmount,
+		HideAmount:           transaction.HideAmount,
+		Comment:              transaction.Comment,
+		GeoLongitude:         transaction.GeoLongitude,
+		GeoLatitude:          transaction.GeoLatitude,
+		TagIds:               utils.ToUniqueInt64Slice(tagIds),
+		Items:                make([]*TransactionRevisionItemSnapshot, len(itemIndexes)),
+		PictureIds:           utils.ToUniqueInt64Slice(pictureIds),
+		Splits:               make([]*TransactionRevisionSplitSnapshot, len(splits)),
+	}
+
+	if transaction.OriginalCurrency != "" {
+		snapshot.OriginalAmountDecimalPlaces = transaction.OriginalAmountDecimalPlaces
+	}
+
+	slices.Sort(snapshot.TagIds)
+	slices.Sort(snapshot.PictureIds)
+
+	for i := 0; i < len(itemIndexes); i++ {
+		itemIndex := itemIndexes[i]
+		snapshot.Items[i] = &TransactionRevisionItemSnapshot{
+			ItemId:    itemIndex.ItemId,
+			Quantity:  itemIndex.Quantity,
+			Unit:      itemIndex.Unit,
+			UnitPrice: itemIndex.UnitPrice,
+			Amount:    itemIndex.Amount,
+		}
+	}
+
+	slices.SortFunc(snapshot.Items, func(item1, item2 *TransactionRevisionItemSnapshot) int {
+		if item1.ItemId < item2.ItemId {
+			return -1
+		} else if item1.ItemId > item2.ItemId {
+			return 1
+		}
+
+		return 0
+	})
+
+	for i := 0; i < len(splits); i++ {
+		split := splits[i]
+		snapshot.Splits[i] = &TransactionRevisionSplitSnapshot{
+			CategoryId: split.CategoryId,
+			Amount:     split.Amount,
+			TagIds:     split.TagIds,
+			Comment:    split.Comment,
+		}
+	}
+
+	return snapshot
+}
+
+// GetItemIds returns the item ids of the snapshot
+func (s *TransactionRevisionSnapshot) GetItemIds() []int64 {
+	itemIds := make([]int64, len(s.Items))
+
+	for i := 0; i < len(s.Items); i++ {
+		itemIds[i] = s.Items[i].ItemId
+	}
+
+	return itemIds
+}
+
+// GetItemDetails returns the item detail map of the snapshot
+func (s *TransactionRevisionSnapshot) GetItemDetails() map[int64]*TransactionItemIndex {
+	itemDetails := make(map[int64]*TransactionItemIndex, len(s.Items))
+
+	for i := 0; i < len(s.Items); i++ {
+		item := s.Items[i]
+		itemDetails[item.ItemId] = &TransactionItemIndex{
+			ItemId:    item.ItemId,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			UnitPrice: item.UnitPrice,
+			Amount:    item.Amount,
+		}
+	}
+
+	return itemDetails
+}
+
+// ToTransactionSplits returns new transaction split models according to the snapshot
+func (s *TransactionRevisionSnapshot) ToTransactionSplits() []*TransactionSplit {
+	splits := make([]*TransactionSplit, len(s.Splits))
+
+	for i := 0; i < len(s.Splits); i++ {
+		split := s.Splits[i]
+		splits[i] = &TransactionSplit{
+			CategoryId: split.CategoryId,
+			Amount:     split.Amount,
+			TagIds:     split.TagIds,
+			Comment:    split.Comment,
+		}
+	}
+
+	return splits
+}
+
+// GetChangedFields returns the names of fields which are different between this snapshot and the other snapshot
+func (s *TransactionRevisionSnapshot) GetChangedFields(other *TransactionRevisionSnapshot) []string {
+	changedFields := make([]string, 0)
+
+	if s.CategoryId != other.CategoryId {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_CATEGORY)
+	}
+
+	if s.AccountId != other.AccountId {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_ACCOUNT)
+	}
+
+	if s.PayeeId != other.PayeeId {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_PAYEE)
+	}
+
+	if utils.GetUnixTimeFromTransactionTime(s.TransactionTime) != utils.GetUnixTimeFromTransactionTime(other.TransactionTime) {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_TIME)
+	}
+
+	if s.TimezoneUtcOffset != other.TimezoneUtcOffset {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_TIMEZONE)
+	}
+
+	if s.Amount != other.Amount {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_AMOUNT)
+	}
+
+	if s.RelatedAccountId != other.RelatedAccountId {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_DESTINATION_ACCOUNT)
+	}
+
+	if s.RelatedAccountAmount != other.RelatedAccountAmount {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_DESTINATION_AMOUNT)
+	}
+
+	if s.OriginalCurrency != other.OriginalCurrency || s.OriginalAmount != other.OriginalAmount {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_ORIGINAL_AMOUNT)
+	}
+
+	if s.HideAmount != other.HideAmount {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_HIDE_AMOUNT)
+	}
+
+	if s.Comment != other.Comment {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_COMMENT)
+	}
+
+	if s.GeoLongitude != other.GeoLongitude || s.GeoLatitude != other.GeoLatitude {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_GEO_LOCATION)
+	}
+
+	if !slices.Equal(s.TagIds, other.TagIds) {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_TAGS)
+	}
+
+	if !slices.EqualFunc(s.Items, other.Items, func(item1, item2 *TransactionRevisionItemSnapshot) bool {
+		return *item1 == *item2
+	}) {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_ITEMS)
+	}
+
+	if !slices.Equal(s.PictureIds, other.PictureIds) {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_PICTURES)
+	}
+
+	if !slices.EqualFunc(s.Splits, other.Splits, func(split1, split2 *TransactionRevisionSplitSnapshot) bool {
+		return *split1 == *split2
+	}) {
+		changedFields = append(changedFields, TRANSACTION_REVISION_FIELD_SPLITS)
+	}
+
+	return changedFields
+}
+
+// ToTransactionRevisionSnapshotResponse returns a view-object according to the snapshot
+func (s *TransactionRevisionSnapshot) ToTransactionRevisionSnapshotResponse(transactionType TransactionDbType) *TransactionRevisionSnapshotResponse {
+	response := &TransactionRevisionSnapshotResponse{
+		CategoryId:       s.CategoryId,
+		SourceAccountId:  s.AccountId,
+		PayeeId:          s.PayeeId,
+		Time:             utils.GetUnixTimeFromTransactionTime(s.TransactionTime),
+		UtcOffset:        s.TimezoneUtcOffset,
+		SourceAmount:     s.Amount,
+		OriginalCurrency: s.OriginalCurrency,
+		OriginalAmount:   s.OriginalAmount,
+		HideAmount:       s.HideAmount,
+		Comment:          s.Comment,
+		TagIds:           utils.Int64ArrayToStringArray(s.TagIds),
+		ItemIds:          utils.Int64ArrayToStringArray(s.GetItemIds()),
+		PictureIds:       utils.Int64ArrayToStringArray(s.PictureIds),
+	}
+
+	if transactionType == TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		response.DestinationAccountId = s.RelatedAccountId
+		response.DestinationAmount = s.RelatedAccountAmount
+	}
+
+	if s.GeoLongitude != 0 || s.GeoLatitude != 0 {
+		response.GeoLocation = &TransactionGeoLocationResponse{
+			Longitude: s.GeoLongitude,
+			Latitude:  s.GeoLatitude,
+		}
+	}
+
+	for i := 0; i < len(s.Items); i++ {
+		item := s.Items[i]
+
+		if item.Quantity == 0 && item.Unit == "" && item.UnitPrice == 0 && item.Amount == 0 {
+			continue
+		}
+
+		response.ItemDetails = append(response.ItemDetails, &TransactionItemDetailInfoResponse{
+			ItemId:    item.ItemId,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			UnitPrice: item.UnitPrice,
+			Amount:    item.Amount,
+		})
+	}
+
+	for i := 0; i < len(s.Splits); i++ {
+		split := s.Splits[i]
+		response.Splits = append(response.Splits, &TransactionRevisionSplitInfoResponse{
+			CategoryId: split.CategoryId,
+			Amount:     split.Amount,
+			TagIds:     utils.Int64ArrayToStringArray(split.GetTagIds()),
+			Comment:    split.Comment,
+		})
+	}
+
+	return response
+}
+
+// GetTagIds returns the tag ids of the split snapshot
+func (s *TransactionRevisionSplitSnapshot) GetTagIds() []int64 {
+	split := &TransactionSplit{TagIds: s.TagIds}
+	return split.GetTagIds()
+}
+
+// ToTransactionRevisionInfoResponse returns a view-object according to database model
+func (r *TransactionRevision) ToTransactionRevisionInfoResponse(transactionType TransactionDbType) *TransactionRevisionInfoResponse {
+	response := &TransactionRevisionInfoResponse{
+		Id:                 r.RevisionId,
+		TransactionId:      r.TransactionId,
+		Type:               r.Type,
+		RevertedRevisionId: r.RevertedRevisionId,
+		Source:             r.Source,
+		ClientIp:           r.ClientIp,
+		ChangedFields:      make([]string, 0),
+		CreatedAt:          r.CreatedUnixTime,
+	}
+
+	if r.Before != nil {
+		response.Before = r.Before.ToTransactionRevisionSnapshotResponse(transactionType)
+	}
+
+	if r.After != nil {
+		response.After = r.After.ToTransactionRevisionSnapshotResponse(transactionType)
+	}
+
+	if r.Before != nil && r.After != nil {
+		response.ChangedFields = r.Before.GetChangedFields(r.After)
+	}
+
+	return response
+}
+
+// GetTransactionRevisionSourceByTokenType returns the transaction revision source according to the token type
+func GetTransactionRevisionSourceByTokenType(tokenType core.TokenType) TransactionRevisionSource {
+	if tokenType == core.USER_TOKEN_TYPE_API {
+		return TRANSACTION_REVISION_SOURCE_API
+	} else if tokenType == core.USER_TOKEN_TYPE_MCP {
+		return TRANSACTION_REVISION_SOURCE_MCP
+	}
+
+	return TRANSACTION_REVISION_SOURCE_WEB
+}
+
+// TransactionRevisionInfoResponseSlice represents the slice data structure of TransactionRevisionInfoResponse
+type TransactionRevisionInfoResponseSlice []*TransactionRevisionInfoResponse
+
+// Len returns the count of items
+func (s TransactionRevisionInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionRevisionInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionRevisionInfoResponseSlice) Less(i, j int) bool {
+	if s[i].CreatedAt != s[j].CreatedAt {
+		return s[i].CreatedAt > s[j].CreatedAt
+	}
+
+	return s[i].Id > s[j].Id
+}
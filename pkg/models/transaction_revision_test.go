@@ -0,0 +1,153 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+)
+
+func TestNewTransactionRevisionSnapshot(t *testing.T) {
+	transaction := &Transaction{
+		CategoryId: 1,
+		AccountId:  2,
+		Amount:     100,
+		Comment:    "test",
+	}
+	itemIndexes := []*TransactionItemIndex{
+		{ItemId: 5, Quantity: 2, UnitPrice: 30, Amount: 60},
+		{ItemId: 3},
+	}
+	splits := []*TransactionSplit{
+		{CategoryId: 10, Amount: 60, TagIds: "2,1"},
+		{CategoryId: 11, Amount: 40},
+	}
+
+	snapshot := NewTransactionRevisionSnapshot(transaction, []int64{3, 1, 3}, itemIndexes, []int64{9, 8}, splits)
+	assert.Equal(t, int64(1), snapshot.CategoryId)
+	assert.Equal(t, int64(2), snapshot.AccountId)
+	assert.Equal(t, int64(100), snapshot.Amount)
+	assert.Equal(t, "test", snapshot.Comment)
+	assert.Equal(t, []int64{1, 3}, snapshot.TagIds)
+	assert.Equal(t, []int64{3, 5}, snapshot.GetItemIds())
+	assert.Equal(t, []int64{8, 9}, snapshot.PictureIds)
+	assert.Equal(t, 2, len(snapshot.Splits))
+	assert.Equal(t, []int64{2, 1}, snapshot.Splits[0].GetTagIds())
+	assert.Equal(t, int32(0), snapshot.OriginalAmountDecimalPlaces)
+}
+
+func TestNewTransactionRevisionSnapshot_OriginalAmount(t *testing.T) {
+	transaction := &Transaction{
+		AccountId:                   2,
+		Amount:                      100,
+		OriginalCurrency:            "KWD",
+		OriginalAmount:              1234,
+		OriginalAmountDecimalPlaces: 3,
+	}
+
+	snapshot := NewTransactionRevisionSnapshot(transaction, nil, nil, nil, nil)
+	assert.Equal(t, "KWD", snapshot.OriginalCurrency)
+	assert.Equal(t, int64(1234), snapshot.OriginalAmount)
+	assert.Equal(t, int32(3), snapshot.OriginalAmountDecimalPlaces)
+}
+
+func TestTransactionRevisionSnapshotGetChangedFields(t *testing.T) {
+	transaction := &Transaction{
+		CategoryId:      1,
+		AccountId:       2,
+		TransactionTime: 1000000,
+		Amount:          100,
+	}
+	itemIndexes := []*TransactionItemIndex{{ItemId: 5, Quantity: 1}}
+
+	snapshot1 := NewTransactionRevisionSnapshot(transaction, []int64{1}, itemIndexes, []int64{}, nil)
+	snapshot2 := NewTransactionRevisionSnapshot(transaction, []int64{1}, itemIndexes, []int64{}, nil)
+	assert.Equal(t, []string{}, snapshot1.GetChangedFields(snapshot2))
+
+	transaction.TransactionTime = 1000001
+	snapshot2 = NewTransactionRevisionSnapshot(transaction, []int64{1}, itemIndexes, []int64{}, nil)
+	assert.Equal(t, []string{}, snapshot1.GetChangedFields(snapshot2))
+
+	transaction.Amount = 200
+	transaction.Comment = "test"
+	snapshot2 = NewTransactionRevisionSnapshot(transaction, []int64{1, 2}, []*TransactionItemIndex{{ItemId: 5, Quantity: 2}}, []int64{3}, []*TransactionSplit{{CategoryId: 1, Amount: 200}})
+	assert.Equal(t, []string{
+		TRANSACTION_REVISION_FIELD_AMOUNT,
+		TRANSACTION_REVISION_FIELD_COMMENT,
+		TRANSACTION_REVISION_FIELD_TAGS,
+		TRANSACTION_REVISION_FIELD_ITEMS,
+		TRANSACTION_REVISION_FIELD_PICTURES,
+		TRANSACTION_REVISION_FIELD_SPLITS,
+	}, snapshot1.GetChangedFields(snapshot2))
+}
+
+func TestTransactionRevisionSnapshotGetItemDetailsAndSplits(t *testing.T) {
+	snapshot := &TransactionRevisionSnapshot{
+		Items: []*TransactionRevisionItemSnapshot{
+			{ItemId: 1, Quantity: 2, Unit: "kg", UnitPrice: 50, Amount: 100},
+		},
+		Splits: []*TransactionRevisionSplitSnapshot{
+			{CategoryId: 3, Amount: 100, TagIds: "4", Comment: "part"},
+		},
+	}
+
+	itemDetails := snapshot.GetItemDetails()
+	assert.Equal(t, 1, len(itemDetails))
+	assert.True(t, itemDetails[1].IsSameDetail(&TransactionItemIndex{Quantity: 2, Unit: "kg", UnitPrice: 50, Amount: 100}))
+
+	splits := snapshot.ToTransactionSplits()
+	assert.Equal(t, 1, len(splits))
+	assert.True(t, splits[0].IsSameContent(&TransactionSplit{CategoryId: 3, Amount: 100, TagIds: "4", Comment: "part"}))
+}
+
+func TestTransactionRevisionSnapshotToTransactionRevisionSnapshotResponse(t *testing.T) {
+	snapshot := &TransactionRevisionSnapshot{
+		AccountId:            1,
+		RelatedAccountId:     2,
+		RelatedAccountAmount: 300,
+	}
+
+	response := snapshot.ToTransactionRevisionSnapshotResponse(TRANSACTION_DB_TYPE_TRANSFER_OUT)
+	assert.Equal(t, int64(2), response.DestinationAccountId)
+	assert.Equal(t, int64(300), response.DestinationAmount)
+
+	response = snapshot.ToTransactionRevisionSnapshotResponse(TRANSACTION_DB_TYPE_EXPENSE)
+	assert.Equal(t, int64(0), response.DestinationAccountId)
+	assert.Equal(t, int64(0), response.DestinationAmount)
+}
+
+func TestTransactionRevisionToTransactionRevisionInfoResponse_CreateAndDelete(t *testing.T) {
+	snapshot := &TransactionRevisionSnapshot{
+		AccountId: 1,
+		Amount:    100,
+	}
+
+	createRevision := &TransactionRevision{
+		Type:  TRANSACTION_REVISION_TYPE_CREATE,
+		After: snapshot,
+	}
+
+	response := createRevision.ToTransactionRevisionInfoResponse(TRANSACTION_DB_TYPE_EXPENSE)
+	assert.Equal(t, TRANSACTION_REVISION_TYPE_CREATE, response.Type)
+	assert.Nil(t, response.Before)
+	assert.Equal(t, int64(100), response.After.SourceAmount)
+	assert.Equal(t, 0, len(response.ChangedFields))
+
+	deleteRevision := &TransactionRevision{
+		Type:   TRANSACTION_REVISION_TYPE_DELETE,
+		Before: snapshot,
+	}
+
+	response = deleteRevision.ToTransactionRevisionInfoResponse(TRANSACTION_DB_TYPE_EXPENSE)
+	assert.Equal(t, TRANSACTION_REVISION_TYPE_DELETE, response.Type)
+	assert.Equal(t, int64(100), response.Before.SourceAmount)
+	assert.Nil(t, response.After)
+	assert.Equal(t, 0, len(response.ChangedFields))
+}
+
+func TestGetTransactionRevisionSourceByTokenType(t *testing.T) {
+	assert.Equal(t, TRANSACTION_REVISION_SOURCE_WEB, GetTransactionRevisionSourceByTokenType(core.USER_TOKEN_TYPE_NORMAL))
+	assert.Equal(t, TRANSACTION_REVISION_SOURCE_API, GetTransactionRevisionSourceByTokenType(core.USER_TOKEN_TYPE_API))
+	assert.Equal(t, TRANSACTION_REVISION_SOURCE_MCP, GetTransactionRevisionSourceByTokenType(core.USER_TOKEN_TYPE_MCP))
+}
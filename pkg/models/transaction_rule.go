@@ -0,0 +1,469 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const transactionRuleCommentPlaceholder = "{comment}"
+
+// TransactionRule represents transaction rule data stored in database
+type TransactionRule struct {
+	RuleId          int64                      `xorm:"PK"`
+	Uid             int64                      `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Deleted         bool                       `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Name            string                     `xorm:"VARCHAR(64) NOT NULL"`
+	DisplayOrder    int32                      `xorm:"INDEX(IDX_transaction_rule_uid_deleted_order) NOT NULL"`
+	Conditions      *TransactionRuleConditions `xorm:"BLOB"`
+	Actions         *TransactionRuleActions    `xorm:"BLOB"`
+	StopProcessing  bool                       `xorm:"NOT NULL"`
+	Disabled        bool                       `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// TransactionRuleConditions represents the conditions of transaction rule, a transaction matches the rule only if it matches all the specified conditions
+type TransactionRuleConditions struct {
+	Type                 TransactionType `json:"type,omitempty" binding:"min=0,max=4"`
+	Keyword              string          `json:"keyword,omitempty" binding:"max=255"`
+	CommentPattern       string          `json:"commentPattern,omitempty" binding:"max=255"`
+	MinAmount            *int64          `json:"minAmount,omitempty" binding:"omitempty,min=-99999999999,max=99999999999"`
+	MaxAmount            *int64          `json:"maxAmount,omitempty" binding:"omitempty,min=-99999999999,max=99999999999"`
+	AccountId            int64           `json:"accountId,string,omitempty" binding:"min=0"`
+	OriginalCategoryName string          `json:"originalCategoryName,omitempty" binding:"max=255"`
+	Counterparty         string          `json:"counterparty,omitempty" binding:"max=255"`
+
+	commentRegexp *regexp.Regexp
+}
+
+// TransactionRuleActions represents the actions of transaction rule which are applied to the matched transactions
+type TransactionRuleActions struct {
+	CategoryId           int64    `json:"categoryId,string,omitempty" binding:"min=0"`
+	TagIds               []string `json:"tagIds,omitempty"`
+	ItemIds              []string `json:"itemIds,omitempty"`
+	Comment              *string  `json:"comment,omitempty" binding:"omitempty,max=255"`
+	DestinationAccountId int64    `json:"destinationAccountId,string,omitempty" binding:"min=0"`
+	HideAmount           *bool    `json:"hideAmount,omitempty"`
+}
+
+// TransactionRuleTarget represents the transaction data which transaction rules match and modify
+type TransactionRuleTarget struct {
+	Type                 TransactionType
+	CategoryId           int64
+	AccountId            int64
+	DestinationAccountId int64
+	Amount               int64
+	HideAmount           bool
+	Comment              string
+	TagIds               []int64
+	ItemIds              []int64
+	OriginalCategoryName string
+	Counterparty         string
+}
+
+// TransactionRuleCreateRequest represents all parameters of transaction rule creation request
+type TransactionRuleCreateRequest struct {
+	Name            string                     `json:"name" binding:"required,notBlank,max=64"`
+	Conditions      *TransactionRuleConditions `json:"conditions" binding:"required"`
+	Actions         *TransactionRuleActions    `json:"actions" binding:"required"`
+	StopProcessing  bool                       `json:"stopProcessing"`
+	ClientSessionId string                     `json:"clientSessionId"`
+}
+
+// TransactionRuleModifyRequest represents all parameters of transaction rule modification request
+type TransactionRuleModifyRequest struct {
+	Id             int64                      `json:"id,string" binding:"required,min=1"`
+	Name           string                     `json:"name" binding:"required,notBlank,max=64"`
+	Conditions     *TransactionRuleConditions `json:"conditions" binding:"required"`
+	Actions        *TransactionRuleActions    `json:"actions" binding:"required"`
+	StopProcessing bool                       `json:"stopProcessing"`
+	Disabled       bool                       `json:"disabled"`
+}
+
+// TransactionRuleGetRequest represents all parameters of transaction rule getting request
+type TransactionRuleGetRequest struct {
+	Id int64 `form:"id,string" binding:"required,min=1"`
+}
+
+// TransactionRuleMoveRequest represents all parameters of transaction rule moving request
+type TransactionRuleMoveRequest struct {
+	NewDisplayOrders []*TransactionRuleNewDisplayOrderRequest `json:"newDisplayOrders" binding:"required,min=1"`
+}
+
+// TransactionRuleNewDisplayOrderRequest represents a data pair of id and display order
+type TransactionRuleNewDisplayOrderRequest struct {
+	Id           int64 `json:"id,string" binding:"required,min=1"`
+	DisplayOrder int32 `json:"displayOrder"`
+}
+
+// TransactionRuleDeleteRequest represents all parameters of transaction rule deleting request
+type TransactionRuleDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// TransactionRuleRunRequest represents all parameters of running transaction rules on existed transactions request
+type TransactionRuleRunRequest struct {
+	RuleIds   []string `json:"ruleIds"`
+	StartTime int64    `json:"startTime" binding:"min=0"`
+	EndTime   int64    `json:"endTime" binding:"min=0"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+// TransactionRuleInfoResponse represents a view-object of transaction rule
+type TransactionRuleInfoResponse struct {
+	Id             int64                      `json:"id,string"`
+	Name           string                     `json:"name"`
+	DisplayOrder   int32                      `json:"displayOrder"`
+	Conditions     *TransactionRuleConditions `json:"conditions"`
+	Actions        *TransactionRuleActions    `json:"actions"`
+	StopProcessing bool                       `json:"stopProcessing"`
+	Disabled       bool                       `json:"disabled"`
+}
+
+// TransactionRuleTargetResponse represents a view-object of transaction data which transaction rules match and modify
+type TransactionRuleTargetResponse struct {
+	Type                 TransactionType `json:"type"`
+	CategoryId           int64           `json:"categoryId,string"`
+	SourceAccountId      int64           `json:"sourceAccountId,string"`
+	DestinationAccountId int64           `json:"destinationAccountId,string,omitempty"`
+	SourceAmount         int64           `json:"sourceAmount"`
+	HideAmount           bool            `json:"hideAmount"`
+	Comment              string          `json:"comment"`
+	TagIds               []string        `json:"tagIds"`
+	ItemIds              []string        `json:"itemIds"`
+}
+
+// TransactionRuleChangeResponse represents a view-object of the change of one transaction made by transaction rules
+type TransactionRuleChangeResponse struct {
+	TransactionId int64                          `json:"transactionId,string"`
+	Time          int64                          `json:"time"`
+	RuleIds       []string                       `json:"ruleIds"`
+	Before        *TransactionRuleTargetResponse `json:"before"`
+	After         *TransactionRuleTargetResponse `json:"after"`
+	Applied       bool                           `json:"applied"`
+}
+
+// TransactionRuleRunResponse represents a view-object of the result of running transaction rules on existed transactions
+type TransactionRuleRunResponse struct {
+	DryRun        bool                             `json:"dryRun"`
+	MatchedCount  int                              `json:"matchedCount"`
+	ModifiedCount int                              `json:"modifiedCount"`
+	FailedCount   int                              `json:"failedCount"`
+	Changes       []*TransactionRuleChangeResponse `json:"changes"`
+}
+
+// FromDB fills the fields from the data stored in database
+func (c *TransactionRuleConditions) FromDB(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// ToDB returns the actual stored data in database
+func (c *TransactionRuleConditions) ToDB() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Validate returns error if the transaction rule conditions are empty or invalid
+func (c *TransactionRuleConditions) Validate() error {
+	if c.Type != 0 && (c.Type < TRANSACTION_TYPE_MODIFY_BALANCE || c.Type > TRANSACTION_TYPE_TRANSFER) {
+		return errs.ErrTransactionRuleTransactionTypeInvalid
+	}
+
+	if c.MinAmount != nil && c.MaxAmount != nil && *c.MinAmount > *c.MaxAmount {
+		return errs.ErrTransactionRuleAmountRangeInvalid
+	}
+
+	if c.CommentPattern != "" {
+		if _, err := regexp.Compile(c.CommentPattern); err != nil {
+			return errs.ErrTransactionRuleCommentPatternInvalid
+		}
+	}
+
+	if c.Type == 0 && c.Keyword == "" && c.CommentPattern == "" && c.MinAmount == nil && c.MaxAmount == nil &&
+		c.AccountId == 0 && c.OriginalCategoryName == "" && c.Counterparty == "" {
+		return errs.ErrTransactionRuleConditionsEmpty
+	}
+
+	return nil
+}
+
+// IsMatch returns whether the transaction matches all the specified conditions
+func (c *TransactionRuleConditions) IsMatch(target *TransactionRuleTarget) bool {
+	if c.Type != 0 && c.Type != target.Type {
+		return false
+	}
+
+	if c.AccountId != 0 && c.AccountId != target.AccountId {
+		return false
+	}
+
+	if c.MinAmount != nil && target.Amount < *c.MinAmount {
+		return false
+	}
+
+	if c.MaxAmount != nil && target.Amount > *c.MaxAmount {
+		return false
+	}
+
+	if c.Keyword != "" && !strings.Contains(strings.ToLower(target.Comment), strings.ToLower(c.Keyword)) {
+		return false
+	}
+
+	if c.OriginalCategoryName != "" && !strings.EqualFold(strings.TrimSpace(target.OriginalCategoryName), strings.TrimSpace(c.OriginalCategoryName)) {
+		return false
+	}
+
+	if c.Counterparty != "" && !strings.Contains(strings.ToLower(target.Counterparty), strings.ToLower(c.Counterparty)) {
+		return false
+	}
+
+	if c.CommentPattern != "" {
+		if c.commentRegexp == nil {
+			commentRegexp, err := regexp.Compile(c.CommentPattern)
+
+			if err != nil {
+				return false
+			}
+
+			c.commentRegexp = commentRegexp
+		}
+
+		if !c.commentRegexp.MatchString(target.Comment) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FromDB fills the fields from the data stored in database
+func (a *TransactionRuleActions) FromDB(data []byte) error {
+	return json.Unmarshal(data, a)
+}
+
+// ToDB returns the actual stored data in database
+func (a *TransactionRuleActions) ToDB() ([]byte, error) {
+	return json.Marshal(a)
+}
+
+// GetTagIds returns the tag ids which would be added to the matched transactions
+func (a *TransactionRuleActions) GetTagIds() []int64 {
+	result, _ := utils.StringArrayToInt64Array(a.TagIds)
+	return result
+}
+
+// GetItemIds returns the item ids which would be added to the matched transactions
+func (a *TransactionRuleActions) GetItemIds() []int64 {
+	result, _ := utils.StringArrayToInt64Array(a.ItemIds)
+	return result
+}
+
+// Validate returns error if the transaction rule actions are empty, or cannot be applied to the transactions matching the specified conditions
+func (a *TransactionRuleActions) Validate(conditions *TransactionRuleConditions) error {
+	tagIds, err := utils.StringArrayToInt64Array(a.TagIds)
+
+	if err != nil {
+		return errs.ErrTransactionTagIdInvalid
+	}
+
+	if len(tagIds) > MaximumTagsCountOfTransaction {
+		return errs.ErrTransactionRuleHasTooManyTags
+	}
+
+	itemIds, err := utils.StringArrayToInt64Array(a.ItemIds)
+
+	if err != nil {
+		return errs.ErrTransactionItemIdInvalid
+	}
+
+	if len(itemIds) > MaximumItemsCountOfTransaction {
+		return errs.ErrTransactionRuleHasTooManyItems
+	}
+
+	// the category type must be the same as the transaction type, so the rule which sets category must specify the transaction type
+	if a.CategoryId != 0 && (conditions.Type == 0 || conditions.Type == TRANSACTION_TYPE_MODIFY_BALANCE) {
+		return errs.ErrTransactionRuleTransactionTypeRequired
+	}
+
+	if a.DestinationAccountId != 0 && conditions.Type != TRANSACTION_TYPE_TRANSFER {
+		return errs.ErrTransactionRuleTransactionTypeRequired
+	}
+
+	if a.CategoryId == 0 && len(tagIds) == 0 && len(itemIds) == 0 && a.Comment == nil && a.DestinationAccountId == 0 && a.HideAmount == nil {
+		return errs.ErrTransactionRuleActionsEmpty
+	}
+
+	return nil
+}
+
+// ApplyTo modifies the transaction according to the actions, the placeholder "{comment}" in new comment would be replaced with the original comment
+func (a *TransactionRuleActions) ApplyTo(target *TransactionRuleTarget) {
+	if a.CategoryId != 0 && target.Type != TRANSACTION_TYPE_MODIFY_BALANCE {
+		target.CategoryId = a.CategoryId
+	}
+
+	if a.DestinationAccountId != 0 && target.Type == TRANSACTION_TYPE_TRANSFER && a.DestinationAccountId != target.AccountId {
+		target.DestinationAccountId = a.DestinationAccountId
+	}
+
+	if a.Comment != nil {
+		target.Comment = strings.ReplaceAll(*a.Comment, transactionRuleCommentPlaceholder, target.Comment)
+	}
+
+	if a.HideAmount != nil {
+		target.HideAmount = *a.HideAmount
+	}
+
+	target.TagIds = appendUniqueIds(target.TagIds, a.GetTagIds(), MaximumTagsCountOfTransaction)
+	target.ItemIds = appendUniqueIds(target.ItemIds, a.GetItemIds(), MaximumItemsCountOfTransaction)
+}
+
+// IsMatch returns whether the transaction rule is enabled and the transaction matches the conditions of the rule
+func (r *TransactionRule) IsMatch(target *TransactionRuleTarget) bool {
+	if r.Deleted || r.Disabled || r.Conditions == nil || r.Actions == nil {
+		return false
+	}
+
+	return r.Conditions.IsMatch(target)
+}
+
+// ToTransactionRuleInfoResponse returns a view-object according to database model
+func (r *TransactionRule) ToTransactionRuleInfoResponse() *TransactionRuleInfoResponse {
+	return &TransactionRuleInfoResponse{
+		Id:             r.RuleId,
+		Name:           r.Name,
+		DisplayOrder:   r.DisplayOrder,
+		Conditions:     r.Conditions,
+		Actions:        r.Actions,
+		StopProcessing: r.StopProcessing,
+		Disabled:       r.Disabled,
+	}
+}
+
+// ApplyTransactionRules applies all the matched transaction rules to the transaction in ascending order of display order,
+// stops after applying the first matched rule which requires stopping processing, and returns the ids of applied rules
+func ApplyTransactionRules(rules []*TransactionRule, target *TransactionRuleTarget) []int64 {
+	sortedRules := make([]*TransactionRule, len(rules))
+	copy(sortedRules, rules)
+
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return sortedRules[i].DisplayOrder < sortedRules[j].DisplayOrder
+	})
+
+	appliedRuleIds := make([]int64, 0)
+
+	for i := 0; i < len(sortedRules); i++ {
+		rule := sortedRules[i]
+
+		if !rule.IsMatch(target) {
+			continue
+		}
+
+		rule.Actions.ApplyTo(target)
+		appliedRuleIds = append(appliedRuleIds, rule.RuleId)
+
+		if rule.StopProcessing {
+			break
+		}
+	}
+
+	return appliedRuleIds
+}
+
+// NewTransactionRuleTargetFromCreateRequest returns the transaction rule target according to the transaction creation request
+func NewTransactionRuleTargetFromCreateRequest(req *TransactionCreateRequest) *TransactionRuleTarget {
+	tagIds, _ := utils.StringArrayToInt64Array(req.TagIds)
+	itemIds, _ := utils.StringArrayToInt64Array(req.ItemIds)
+
+	return &TransactionRuleTarget{
+		Type:                 req.Type,
+		CategoryId:           req.CategoryId,
+		AccountId:            req.SourceAccountId,
+		DestinationAccountId: req.DestinationAccountId,
+		Amount:               req.SourceAmount,
+		HideAmount:           req.HideAmount,
+		Comment:              req.Comment,
+		TagIds:               tagIds,
+		ItemIds:              itemIds,
+		OriginalCategoryName: req.OriginalCategoryName,
+		Counterparty:         req.Counterparty,
+	}
+}
+
+// FillCreateRequest fills the transaction creation request with the fields modified by transaction rules
+func (t *TransactionRuleTarget) FillCreateRequest(req *TransactionCreateRequest) {
+	req.CategoryId = t.CategoryId
+	req.DestinationAccountId = t.DestinationAccountId
+	req.HideAmount = t.HideAmount
+	req.Comment = t.Comment
+	req.TagIds = utils.Int64ArrayToStringArray(t.TagIds)
+	req.ItemIds = utils.Int64ArrayToStringArray(t.ItemIds)
+}
+
+// ToTransactionRuleTargetResponse returns a view-object according to transaction rule target
+func (t *TransactionRuleTarget) ToTransactionRuleTargetResponse() *TransactionRuleTargetResponse {
+	return &TransactionRuleTargetResponse{
+		Type:                 t.Type,
+		CategoryId:           t.CategoryId,
+		SourceAccountId:      t.AccountId,
+		DestinationAccountId: t.DestinationAccountId,
+		SourceAmount:         t.Amount,
+		HideAmount:           t.HideAmount,
+		Comment:              t.Comment,
+		TagIds:               utils.Int64ArrayToStringArray(t.TagIds),
+		ItemIds:              utils.Int64ArrayToStringArray(t.ItemIds),
+	}
+}
+
+// Clone returns a copy of transaction rule target
+func (t *TransactionRuleTarget) Clone() *TransactionRuleTarget {
+	cloned := *t
+	cloned.TagIds = append([]int64{}, t.TagIds...)
+	cloned.ItemIds = append([]int64{}, t.ItemIds...)
+
+	return &cloned
+}
+
+// IsSameContent returns whether the modifiable fields of two transaction rule targets are the same
+func (t *TransactionRuleTarget) IsSameContent(other *TransactionRuleTarget) bool {
+	return t.CategoryId == other.CategoryId &&
+		t.DestinationAccountId == other.DestinationAccountId &&
+		t.HideAmount == other.HideAmount &&
+		t.Comment == other.Comment &&
+		slices.Equal(t.TagIds, other.TagIds) &&
+		slices.Equal(t.ItemIds, other.ItemIds)
+}
+
+func appendUniqueIds(ids []int64, newIds []int64, maxCount int) []int64 {
+	for i := 0; i < len(newIds) && len(ids) < maxCount; i++ {
+		if !slices.Contains(ids, newIds[i]) {
+			ids = append(ids, newIds[i])
+		}
+	}
+
+	return ids
+}
+
+// TransactionRuleInfoResponseSlice represents the slice data structure of TransactionRuleInfoResponse
+type TransactionRuleInfoResponseSlice []*TransactionRuleInfoResponse
+
+// Len returns the count of items
+func (s TransactionRuleInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionRuleInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionRuleInfoResponseSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestTransactionRuleConditionsValidate(t *testing.T) {
+	minAmount := int64(1000)
+	maxAmount := int64(100)
+
+	assert.Equal(t, errs.ErrTransactionRuleConditionsEmpty, (&TransactionRuleConditions{}).Validate())
+	assert.Equal(t, errs.ErrTransactionRuleTransactionTypeInvalid, (&TransactionRuleConditions{Type: 5}).Validate())
+	assert.Equal(t, errs.ErrTransactionRuleAmountRangeInvalid, (&TransactionRuleConditions{MinAmount: &minAmount, MaxAmount: &maxAmount}).Validate())
+	assert.Equal(t, errs.ErrTransactionRuleCommentPatternInvalid, (&TransactionRuleConditions{CommentPattern: "(abc"}).Validate())
+
+	assert.Nil(t, (&TransactionRuleConditions{Type: TRANSACTION_TYPE_EXPENSE}).Validate())
+	assert.Nil(t, (&TransactionRuleConditions{Keyword: "coffee"}).Validate())
+	assert.Nil(t, (&TransactionRuleConditions{MaxAmount: &minAmount}).Validate())
+	assert.Nil(t, (&TransactionRuleConditions{CommentPattern: "^STARBUCKS\\s+\\d+$"}).Validate())
+}
+
+func TestTransactionRuleConditionsIsMatch(t *testing.T) {
+	minAmount := int64(100)
+	maxAmount := int64(1000)
+	conditions := &TransactionRuleConditions{
+		Type:                 TRANSACTION_TYPE_EXPENSE,
+		Keyword:              "coffee",
+		MinAmount:            &minAmount,
+		MaxAmount:            &maxAmount,
+		AccountId:            1,
+		OriginalCategoryName: "Food & Drink",
+		Counterparty:         "starbucks",
+	}
+	target := &TransactionRuleTarget{
+		Type:                 TRANSACTION_TYPE_EXPENSE,
+		AccountId:            1,
+		Amount:               500,
+		Comment:              "Morning Coffee",
+		OriginalCategoryName: " food & drink ",
+		Counterparty:         "Starbucks Store #123",
+	}
+	assert.True(t, conditions.IsMatch(target))
+
+	target.Type = TRANSACTION_TYPE_INCOME
+	assert.False(t, conditions.IsMatch(target))
+	target.Type = TRANSACTION_TYPE_EXPENSE
+
+	target.AccountId = 2
+	assert.False(t, conditions.IsMatch(target))
+	target.AccountId = 1
+
+	target.Amount = 99
+	assert.False(t, conditions.IsMatch(target))
+	target.Amount = 1001
+	assert.False(t, conditions.IsMatch(target))
+	target.Amount = 1000
+	assert.True(t, conditions.IsMatch(target))
+
+	target.Comment = "Tea"
+	assert.False(t, conditions.IsMatch(target))
+	target.Comment = "Morning Coffee"
+
+	target.OriginalCategoryName = "Food"
+	assert.False(t, conditions.IsMatch(target))
+	target.OriginalCategoryName = "Food & Drink"
+
+	target.Counterparty = ""
+	assert.False(t, conditions.IsMatch(target))
+}
+
+func TestTransactionRuleConditionsIsMatch_CommentPattern(t *testing.T) {
+	conditions := &TransactionRuleConditions{
+		CommentPattern: "^UBER\\s+\\*TRIP",
+	}
+
+	assert.True(t, conditions.IsMatch(&TransactionRuleTarget{Comment: "UBER *TRIP HELP.UBER.COM"}))
+	assert.False(t, conditions.IsMatch(&TransactionRuleTarget{Comment: "uber *trip"}))
+	assert.False(t, conditions.IsMatch(&TransactionRuleTarget{Comment: "PAYMENT UBER *TRIP"}))
+}
+
+func TestTransactionRuleActionsValidate(t *testing.T) {
+	comment := "new comment"
+	expenseConditions := &TransactionRuleConditions{Type: TRANSACTION_TYPE_EXPENSE}
+	keywordConditions := &TransactionRuleConditions{Keyword: "coffee"}
+
+	assert.Equal(t, errs.ErrTransactionRuleActionsEmpty, (&TransactionRuleActions{}).Validate(keywordConditions))
+	assert.Equal(t, errs.ErrTransactionTagIdInvalid, (&TransactionRuleActions{TagIds: []string{"a"}}).Validate(keywordConditions))
+	assert.Equal(t, errs.ErrTransactionRuleHasTooManyTags, (&TransactionRuleActions{TagIds: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}}).Validate(keywordConditions))
+	assert.Equal(t, errs.ErrTransactionRuleTransactionTypeRequired, (&TransactionRuleActions{CategoryId: 1}).Validate(keywordConditions))
+	assert.Equal(t, errs.ErrTransactionRuleTransactionTypeRequired, (&TransactionRuleActions{DestinationAccountId: 1}).Validate(expenseConditions))
+
+	assert.Nil(t, (&TransactionRuleActions{CategoryId: 1}).Validate(expenseConditions))
+	assert.Nil(t, (&TransactionRuleActions{Comment: &comment}).Validate(keywordConditions))
+	assert.Nil(t, (&TransactionRuleActions{DestinationAccountId: 1}).Validate(&TransactionRuleConditions{Type: TRANSACTION_TYPE_TRANSFER}))
+}
+
+func TestTransactionRuleActionsApplyTo(t *testing.T) {
+	comment := "Coffee ({comment})"
+	hideAmount := true
+	actions := &TransactionRuleActions{
+		CategoryId:           10,
+		TagIds:               []string{"2", "3"},
+		ItemIds:              []string{"5"},
+		Comment:              &comment,
+		DestinationAccountId: 20,
+		HideAmount:           &hideAmount,
+	}
+	target := &TransactionRuleTarget{
+		Type:       TRANSACTION_TYPE_EXPENSE,
+		CategoryId: 1,
+		AccountId:  1,
+		Comment:    "STARBUCKS 123",
+		TagIds:     []int64{1, 2},
+	}
+
+	actions.ApplyTo(target)
+	assert.Equal(t, int64(10), target.CategoryId)
+	assert.Equal(t, int64(0), target.DestinationAccountId)
+	assert.Equal(t, "Coffee (STARBUCKS 123)", target.Comment)
+	assert.True(t, target.HideAmount)
+	assert.Equal(t, []int64{1, 2, 3}, target.TagIds)
+	assert.Equal(t, []int64{5}, target.ItemIds)
+}
+
+func TestTransactionRuleActionsApplyTo_TransferAndBalanceModification(t *testing.T) {
+	actions := &TransactionRuleActions{
+		CategoryId:           10,
+		DestinationAccountId: 20,
+	}
+
+	transferTarget := &TransactionRuleTarget{
+		Type:                 TRANSACTION_TYPE_TRANSFER,
+		CategoryId:           1,
+		AccountId:            1,
+		DestinationAccountId: 2,
+	}
+	actions.ApplyTo(transferTarget)
+	assert.Equal(t, int64(10), transferTarget.CategoryId)
+	assert.Equal(t, int64(20), transferTarget.DestinationAccountId)
+
+	sameAccountTarget := &TransactionRuleTarget{
+		Type:                 TRANSACTION_TYPE_TRANSFER,
+		AccountId:            20,
+		DestinationAccountId: 2,
+	}
+	actions.ApplyTo(sameAccountTarget)
+	assert.Equal(t, int64(2), sameAccountTarget.DestinationAccountId)
+
+	balanceModificationTarget := &TransactionRuleTarget{
+		Type: TRANSACTION_TYPE_MODIFY_BALANCE,
+	}
+	actions.ApplyTo(balanceModificationTarget)
+	assert.Equal(t, int64(0), balanceModificationTarget.CategoryId)
+}
+
+func TestTransactionRuleActionsApplyTo_TooManyTags(t *testing.T) {
+	actions := &TransactionRuleActions{
+		TagIds: []string{"9", "10", "11", "12"},
+	}
+	target := &TransactionRuleTarget{
+		TagIds: []int64{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+
+	actions.ApplyTo(target)
+	assert.Equal(t, MaximumTagsCountOfTransaction, len(target.TagIds))
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, target.TagIds)
+}
+
+func TestApplyTransactionRules(t *testing.T) {
+	comment1 := "rule1"
+	comment2 := "{comment} rule2"
+	comment3 := "{comment} rule3"
+	rules := []*TransactionRule{
+		{RuleId: 3, DisplayOrder: 3, Conditions: &TransactionRuleConditions{Keyword: "rule"}, Actions: &TransactionRuleActions{Comment: &comment3}},
+		{RuleId: 1, DisplayOrder: 1, Conditions: &TransactionRuleConditions{Keyword: "test"}, Actions: &TransactionRuleActions{Comment: &comment1}},
+		{RuleId: 2, DisplayOrder: 2, Conditions: &TransactionRuleConditions{Keyword: "rule1"}, Actions: &TransactionRuleActions{Comment: &comment2}},
+		{RuleId: 4, DisplayOrder: 4, Conditions: &TransactionRuleConditions{Keyword: "rule"}, Actions: &TransactionRuleActions{Comment: &comment3}, Disabled: true},
+	}
+	target := &TransactionRuleTarget{
+		Comment: "test",
+	}
+
+	appliedRuleIds := ApplyTransactionRules(rules, target)
+	assert.Equal(t, []int64{1, 2, 3}, appliedRuleIds)
+	assert.Equal(t, "rule1 rule2 rule3", target.Comment)
+
+	rules[2].StopProcessing = true
+	target = &TransactionRuleTarget{
+		Comment: "test",
+	}
+
+	appliedRuleIds = ApplyTransactionRules(rules, target)
+	assert.Equal(t, []int64{1, 2}, appliedRuleIds)
+	assert.Equal(t, "rule1 rule2", target.Comment)
+
+	target = &TransactionRuleTarget{
+		Comment: "other",
+	}
+
+	appliedRuleIds = ApplyTransactionRules(rules, target)
+	assert.Equal(t, 0, len(appliedRuleIds))
+	assert.Equal(t, "other", target.Comment)
+}
+
+func TestTransactionRuleTargetIsSameContent(t *testing.T) {
+	target := &TransactionRuleTarget{
+		CategoryId: 1,
+		Comment:    "test",
+		TagIds:     []int64{},
+	}
+	cloned := target.Clone()
+	assert.True(t, target.IsSameContent(cloned))
+
+	cloned.TagIds = append(cloned.TagIds, 1)
+	assert.False(t, target.IsSameContent(cloned))
+
+	cloned = target.Clone()
+	cloned.Comment = "test2"
+	assert.False(t, target.IsSameContent(cloned))
+}
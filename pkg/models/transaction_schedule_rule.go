@@ -0,0 +1,453 @@
+package models
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const maximumScheduleRuleInterval = 999
+const maximumScheduleRuleLength = 100
+
+// TransactionScheduleRuleFrequency represents the frequency of transaction schedule rule
+type TransactionScheduleRuleFrequency byte
+
+// Transaction schedule rule frequencies
+const (
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_DAILY   TransactionScheduleRuleFrequency = 1
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_WEEKLY  TransactionScheduleRuleFrequency = 2
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY TransactionScheduleRuleFrequency = 3
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_YEARLY  TransactionScheduleRuleFrequency = 4
+)
+
+var transactionScheduleRuleFrequencyNames = map[TransactionScheduleRuleFrequency]string{
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_DAILY:   "DAILY",
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_WEEKLY:  "WEEKLY",
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY: "MONTHLY",
+	TRANSACTION_SCHEDULE_RULE_FREQUENCY_YEARLY:  "YEARLY",
+}
+
+var transactionScheduleRuleWeekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// TransactionScheduleRuleWeekday represents a weekday in transaction schedule rule, with an optional ordinal (e.g. -1FR means the last friday)
+type TransactionScheduleRuleWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// TransactionScheduleRule represents a recurrence rule of scheduled transaction, which is a subset of RFC 5545 RRULE
+// supporting FREQ, INTERVAL, BYDAY, BYMONTHDAY, BYMONTH and BYSETPOS
+type TransactionScheduleRule struct {
+	Frequency      TransactionScheduleRuleFrequency
+	Interval       int
+	ByDays         []TransactionScheduleRuleWeekday
+	ByMonthDays    []int
+	ByMonths       []int
+	BySetPositions []int
+}
+
+// ParseTransactionScheduleRule returns the transaction schedule rule parsed from the RRULE string (e.g. FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1)
+func ParseTransactionScheduleRule(value string) (*TransactionScheduleRule, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	value = strings.TrimPrefix(value, "RRULE:")
+
+	if value == "" {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	rule := &TransactionScheduleRule{
+		Interval: 1,
+	}
+
+	existedParts := make(map[string]bool)
+	parts := strings.Split(value, ";")
+
+	for i := 0; i < len(parts); i++ {
+		if parts[i] == "" {
+			continue
+		}
+
+		keyValue := strings.SplitN(parts[i], "=", 2)
+
+		if len(keyValue) != 2 || keyValue[1] == "" || existedParts[keyValue[0]] {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		existedParts[keyValue[0]] = true
+		var err error
+
+		switch keyValue[0] {
+		case "FREQ":
+			rule.Frequency, err = parseTransactionScheduleRuleFrequency(keyValue[1])
+		case "INTERVAL":
+			rule.Interval, err = utils.StringToInt(keyValue[1])
+
+			if err == nil && (rule.Interval < 1 || rule.Interval > maximumScheduleRuleInterval) {
+				err = errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		case "BYDAY":
+			rule.ByDays, err = parseTransactionScheduleRuleWeekdays(keyValue[1])
+		case "BYMONTHDAY":
+			rule.ByMonthDays, err = parseTransactionScheduleRuleIntegers(keyValue[1], 1, 31, true)
+		case "BYMONTH":
+			rule.ByMonths, err = parseTransactionScheduleRuleIntegers(keyValue[1], 1, 12, false)
+		case "BYSETPOS":
+			rule.BySetPositions, err = parseTransactionScheduleRuleIntegers(keyValue[1], 1, 366, true)
+		default:
+			err = errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		if err != nil {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+	}
+
+	if rule.Frequency == 0 {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	for i := 0; i < len(rule.ByDays); i++ {
+		if rule.ByDays[i].Ordinal == 0 {
+			continue
+		}
+
+		if rule.Frequency != TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY && rule.Frequency != TRANSACTION_SCHEDULE_RULE_FREQUENCY_YEARLY {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		if (rule.Frequency == TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY || len(rule.ByMonths) > 0) &&
+			(rule.ByDays[i].Ordinal < -5 || rule.ByDays[i].Ordinal > 5) {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+	}
+
+	if len(rule.ByMonthDays) > 0 && rule.Frequency == TRANSACTION_SCHEDULE_RULE_FREQUENCY_WEEKLY {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	if len(rule.BySetPositions) > 0 && len(rule.ByDays) < 1 && len(rule.ByMonthDays) < 1 && len(rule.ByMonths) < 1 {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	if len(rule.String()) > maximumScheduleRuleLength {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	return rule, nil
+}
+
+// String returns the normalized RRULE string of transaction schedule rule
+func (r *TransactionScheduleRule) String() string {
+	var builder strings.Builder
+
+	builder.WriteString("FREQ=")
+	builder.WriteString(transactionScheduleRuleFrequencyNames[r.Frequency])
+
+	if r.Interval > 1 {
+		builder.WriteString(";INTERVAL=")
+		builder.WriteString(utils.IntToString(r.Interval))
+	}
+
+	if len(r.ByMonths) > 0 {
+		builder.WriteString(";BYMONTH=")
+		writeTransactionScheduleRuleIntegers(&builder, r.ByMonths)
+	}
+
+	if len(r.ByMonthDays) > 0 {
+		builder.WriteString(";BYMONTHDAY=")
+		writeTransactionScheduleRuleIntegers(&builder, r.ByMonthDays)
+	}
+
+	if len(r.ByDays) > 0 {
+		builder.WriteString(";BYDAY=")
+
+		for i := 0; i < len(r.ByDays); i++ {
+			if i > 0 {
+				builder.WriteRune(',')
+			}
+
+			if r.ByDays[i].Ordinal != 0 {
+				builder.WriteString(utils.IntToString(r.ByDays[i].Ordinal))
+			}
+
+			builder.WriteString(transactionScheduleRuleWeekdayNames[r.ByDays[i].Weekday])
+		}
+	}
+
+	if len(r.BySetPositions) > 0 {
+		builder.WriteString(";BYSETPOS=")
+		writeTransactionScheduleRuleIntegers(&builder, r.BySetPositions)
+	}
+
+	return builder.String()
+}
+
+// IsOccurrence returns whether the specified date is an occurrence of transaction schedule rule,
+// the interval of rule is counted from the anchor date, and both dates should be in the same timezone
+func (r *TransactionScheduleRule) IsOccurrence(date time.Time, anchor time.Time) bool {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, time.UTC)
+
+	if date.Before(anchor) {
+		return false
+	}
+
+	var periodStart time.Time
+	var periodEnd time.Time
+
+	switch r.Frequency {
+	case TRANSACTION_SCHEDULE_RULE_FREQUENCY_DAILY:
+		if getDaysBetween(anchor, date)%r.Interval != 0 {
+			return false
+		}
+
+		periodStart = date
+		periodEnd = date
+	case TRANSACTION_SCHEDULE_RULE_FREQUENCY_WEEKLY:
+		periodStart = getFirstDayOfWeek(date)
+		periodEnd = periodStart.AddDate(0, 0, 6)
+
+		if (getDaysBetween(getFirstDayOfWeek(anchor), periodStart)/7)%r.Interval != 0 {
+			return false
+		}
+	case TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY:
+		periodStart = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		periodEnd = periodStart.AddDate(0, 1, -1)
+
+		if ((date.Year()*12+int(date.Month()))-(anchor.Year()*12+int(anchor.Month())))%r.Interval != 0 {
+			return false
+		}
+	case TRANSACTION_SCHEDULE_RULE_FREQUENCY_YEARLY:
+		periodStart = time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		periodEnd = time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+
+		if (date.Year()-anchor.Year())%r.Interval != 0 {
+			return false
+		}
+	default:
+		return false
+	}
+
+	candidates := r.getCandidatesInPeriod(periodStart, periodEnd, anchor)
+
+	for i := 0; i < len(candidates); i++ {
+		if candidates[i].Equal(date) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *TransactionScheduleRule) getCandidatesInPeriod(periodStart time.Time, periodEnd time.Time, anchor time.Time) []time.Time {
+	byMonths := r.ByMonths
+	byMonthDays := r.ByMonthDays
+	byDays := r.ByDays
+
+	// use the anchor date to complete the rule when it is not specified, just as DTSTART in RFC 5545
+	if r.Frequency == TRANSACTION_SCHEDULE_RULE_FREQUENCY_WEEKLY && len(byDays) < 1 {
+		byDays = []TransactionScheduleRuleWeekday{{Weekday: anchor.Weekday()}}
+	} else if r.Frequency == TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY && len(byMonthDays) < 1 && len(byDays) < 1 {
+		byMonthDays = []int{anchor.Day()}
+	} else if r.Frequency == TRANSACTION_SCHEDULE_RULE_FREQUENCY_YEARLY && len(byMonthDays) < 1 && len(byDays) < 1 {
+		byMonthDays = []int{anchor.Day()}
+
+		if len(byMonths) < 1 {
+			byMonths = []int{int(anchor.Month())}
+		}
+	}
+
+	candidates := make([]time.Time, 0, 31)
+
+	for current := periodStart; !current.After(periodEnd); current = current.AddDate(0, 0, 1) {
+		if len(byMonths) > 0 && !slices.Contains(byMonths, int(current.Month())) {
+			continue
+		}
+
+		if len(byMonthDays) > 0 && !isTransactionScheduleRuleMonthDayMatched(byMonthDays, current) {
+			continue
+		}
+
+		if len(byDays) > 0 && !r.isTransactionScheduleRuleWeekdayMatched(byDays, current) {
+			continue
+		}
+
+		candidates = append(candidates, current)
+	}
+
+	if len(r.BySetPositions) < 1 {
+		return candidates
+	}
+
+	result := make([]time.Time, 0, len(r.BySetPositions))
+
+	for i := 0; i < len(r.BySetPositions); i++ {
+		position := r.BySetPositions[i]
+
+		if position > 0 && position <= len(candidates) {
+			result = append(result, candidates[position-1])
+		} else if position < 0 && -position <= len(candidates) {
+			result = append(result, candidates[len(candidates)+position])
+		}
+	}
+
+	return result
+}
+
+func (r *TransactionScheduleRule) isTransactionScheduleRuleWeekdayMatched(byDays []TransactionScheduleRuleWeekday, date time.Time) bool {
+	for i := 0; i < len(byDays); i++ {
+		if byDays[i].Weekday != date.Weekday() {
+			continue
+		}
+
+		if byDays[i].Ordinal == 0 {
+			return true
+		}
+
+		var scopeStart time.Time
+		var scopeEnd time.Time
+
+		// the ordinal is relative to the month in monthly rule or yearly rule with BYMONTH, otherwise it is relative to the year
+		if r.Frequency == TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY || len(r.ByMonths) > 0 {
+			scopeStart = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+			scopeEnd = scopeStart.AddDate(0, 1, -1)
+		} else {
+			scopeStart = time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+			scopeEnd = time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+		}
+
+		if byDays[i].Ordinal > 0 && getDaysBetween(scopeStart, date)/7+1 == byDays[i].Ordinal {
+			return true
+		} else if byDays[i].Ordinal < 0 && -(getDaysBetween(date, scopeEnd)/7+1) == byDays[i].Ordinal {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTransactionScheduleRuleMonthDayMatched(byMonthDays []int, date time.Time) bool {
+	daysInMonth := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	for i := 0; i < len(byMonthDays); i++ {
+		if byMonthDays[i] > 0 && byMonthDays[i] == date.Day() {
+			return true
+		} else if byMonthDays[i] < 0 && daysInMonth+byMonthDays[i]+1 == date.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseTransactionScheduleRuleFrequency(value string) (TransactionScheduleRuleFrequency, error) {
+	for frequency, name := range transactionScheduleRuleFrequencyNames {
+		if name == value {
+			return frequency, nil
+		}
+	}
+
+	return 0, errs.ErrScheduledTransactionFrequencyInvalid
+}
+
+func parseTransactionScheduleRuleWeekdays(value string) ([]TransactionScheduleRuleWeekday, error) {
+	items := strings.Split(value, ",")
+	weekdays := make([]TransactionScheduleRuleWeekday, 0, len(items))
+
+	for i := 0; i < len(items); i++ {
+		item := strings.TrimSpace(items[i])
+
+		if len(item) < 2 {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		weekdayName := item[len(item)-2:]
+		weekday := -1
+
+		for j := 0; j < len(transactionScheduleRuleWeekdayNames); j++ {
+			if transactionScheduleRuleWeekdayNames[j] == weekdayName {
+				weekday = j
+				break
+			}
+		}
+
+		if weekday < 0 {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		ordinal := 0
+
+		if len(item) > 2 {
+			var err error
+			ordinal, err = utils.StringToInt(item[:len(item)-2])
+
+			if err != nil || ordinal == 0 || ordinal < -53 || ordinal > 53 {
+				return nil, errs.ErrScheduledTransactionFrequencyInvalid
+			}
+		}
+
+		weekdays = append(weekdays, TransactionScheduleRuleWeekday{
+			Ordinal: ordinal,
+			Weekday: time.Weekday(weekday),
+		})
+	}
+
+	return weekdays, nil
+}
+
+func parseTransactionScheduleRuleIntegers(value string, minValue int, maxValue int, allowNegative bool) ([]int, error) {
+	items := strings.Split(value, ",")
+	values := make([]int, 0, len(items))
+	valueExistMap := make(map[int]bool)
+
+	for i := 0; i < len(items); i++ {
+		item, err := utils.StringToInt(strings.TrimSpace(items[i]))
+
+		if err != nil {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		absValue := item
+
+		if allowNegative && item < 0 {
+			absValue = -item
+		}
+
+		if absValue < minValue || absValue > maxValue {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		if !valueExistMap[item] {
+			values = append(values, item)
+			valueExistMap[item] = true
+		}
+	}
+
+	sort.Ints(values)
+
+	return values, nil
+}
+
+func writeTransactionScheduleRuleIntegers(builder *strings.Builder, values []int) {
+	for i := 0; i < len(values); i++ {
+		if i > 0 {
+			builder.WriteRune(',')
+		}
+
+		builder.WriteString(utils.IntToString(values[i]))
+	}
+}
+
+func getDaysBetween(startDate time.Time, endDate time.Time) int {
+	return int(endDate.Sub(startDate).Hours() / 24)
+}
+
+func getFirstDayOfWeek(date time.Time) time.Time {
+	// weeks start on monday, just as the default WKST in RFC 5545
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func getTestScheduleRuleDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseTransactionScheduleRule_ValidRules(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=DAILY")
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_SCHEDULE_RULE_FREQUENCY_DAILY, rule.Frequency)
+	assert.Equal(t, 1, rule.Interval)
+
+	rule, err = ParseTransactionScheduleRule("rrule:freq=monthly;interval=3;bymonthday=-1")
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY, rule.Frequency)
+	assert.Equal(t, 3, rule.Interval)
+	assert.Equal(t, []int{-1}, rule.ByMonthDays)
+
+	rule, err = ParseTransactionScheduleRule("FREQ=MONTHLY;BYDAY=FR,MO,TU,WE,TH;BYSETPOS=-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(rule.ByDays))
+	assert.Equal(t, []int{-1}, rule.BySetPositions)
+
+	rule, err = ParseTransactionScheduleRule("FREQ=YEARLY;BYMONTH=11;BYDAY=4TH")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{11}, rule.ByMonths)
+	assert.Equal(t, []TransactionScheduleRuleWeekday{{Ordinal: 4, Weekday: time.Thursday}}, rule.ByDays)
+}
+
+func TestParseTransactionScheduleRule_InvalidRules(t *testing.T) {
+	invalidRules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=1000",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=MONTHLY;BYMONTHDAY",
+	}
+
+	for i := 0; i < len(invalidRules); i++ {
+		_, err := ParseTransactionScheduleRule(invalidRules[i])
+		assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err, invalidRules[i])
+	}
+}
+
+func TestTransactionScheduleRuleString(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("RRULE:FREQ=MONTHLY;BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR;INTERVAL=1")
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", rule.String())
+
+	rule, err = ParseTransactionScheduleRule("FREQ=YEARLY;INTERVAL=2;BYMONTHDAY=15,1;BYMONTH=6,1")
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=YEARLY;INTERVAL=2;BYMONTH=1,6;BYMONTHDAY=1,15", rule.String())
+}
+
+func TestTransactionScheduleRuleIsOccurrence_EveryNDays(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=DAILY;INTERVAL=3")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.February, 27)
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.February, 27), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.February, 28), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.February, 29), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.March, 1), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.February, 24), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_EveryNWeeks(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.January, 3) // Wednesday
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 1), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 5), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 8), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 12), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 15), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 16), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_WeeklyWithoutByDay(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=WEEKLY")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.January, 3) // Wednesday
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 10), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 11), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_Quarterly(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=MONTHLY;INTERVAL=3")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.January, 15)
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 15), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.February, 15), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.April, 15), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.April, 16), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2025, time.January, 15), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_LastDayOfMonth(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=MONTHLY;BYMONTHDAY=-1")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.January, 1)
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.January, 31), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.February, 29), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.April, 30), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.April, 29), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2025, time.February, 28), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_LastWeekdayOfMonth(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.January, 1)
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.March, 29), anchor)) // Friday
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.March, 31), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.June, 28), anchor)) // Friday
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.July, 31), anchor)) // Wednesday
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.July, 30), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_LastFridayOfMonth(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=MONTHLY;BYDAY=-1FR")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.January, 1)
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.March, 29), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.March, 22), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.May, 31), anchor))
+}
+
+func TestTransactionScheduleRuleIsOccurrence_Yearly(t *testing.T) {
+	rule, err := ParseTransactionScheduleRule("FREQ=YEARLY")
+	assert.Nil(t, err)
+
+	anchor := getTestScheduleRuleDate(2024, time.March, 10)
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2025, time.March, 10), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2025, time.April, 10), anchor))
+
+	rule, err = ParseTransactionScheduleRule("FREQ=YEARLY;BYMONTH=11;BYDAY=4TH")
+	assert.Nil(t, err)
+
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.November, 28), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2024, time.November, 21), anchor))
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2025, time.November, 27), anchor))
+
+	rule, err = ParseTransactionScheduleRule("FREQ=YEARLY;INTERVAL=2;BYMONTH=2;BYMONTHDAY=-1")
+	assert.Nil(t, err)
+
+	assert.True(t, rule.IsOccurrence(getTestScheduleRuleDate(2026, time.February, 28), anchor))
+	assert.False(t, rule.IsOccurrence(getTestScheduleRuleDate(2025, time.February, 28), anchor))
+}
@@ -0,0 +1,689 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const transactionSearchQueryDateFormat = "2006-01-02"
+
+// Transaction search query keys
+const (
+	TRANSACTION_SEARCH_QUERY_KEY_TYPE     = "type"
+	TRANSACTION_SEARCH_QUERY_KEY_CATEGORY = "category"
+	TRANSACTION_SEARCH_QUERY_KEY_ACCOUNT  = "account"
+	TRANSACTION_SEARCH_QUERY_KEY_PAYEE    = "payee"
+	TRANSACTION_SEARCH_QUERY_KEY_TAG      = "tag"
+	TRANSACTION_SEARCH_QUERY_KEY_AMOUNT   = "amount"
+	TRANSACTION_SEARCH_QUERY_KEY_AFTER    = "after"
+	TRANSACTION_SEARCH_QUERY_KEY_BEFORE   = "before"
+	TRANSACTION_SEARCH_QUERY_KEY_COMMENT  = "comment"
+)
+
+var transactionSearchQueryTypeNames = map[string]TransactionType{
+	"balance":  TRANSACTION_TYPE_MODIFY_BALANCE,
+	"income":   TRANSACTION_TYPE_INCOME,
+	"expense":  TRANSACTION_TYPE_EXPENSE,
+	"transfer": TRANSACTION_TYPE_TRANSFER,
+}
+
+// TransactionSearchQuery represents a parsed transaction search query, e.g. category:Food amount:>50 tag:trip -tag:work account:"Visa" after:2025-01-01 comment:~"uber"
+type TransactionSearchQuery struct {
+	Type                 TransactionType
+	CategoryNames        []string
+	ExcludeCategoryNames []string
+	AccountNames         []string
+	ExcludeAccountNames  []string
+	PayeeNames           []string
+	ExcludePayeeNames    []string
+	TagNames             []string
+	ExcludeTagNames      []string
+	AmountFilter         string
+	AfterDate            string // Inclusive, in YYYY-MM-DD format
+	BeforeDate           string // Exclusive, in YYYY-MM-DD format
+	Keywords             []string
+}
+
+// TransactionSearchConditions represents the transaction query conditions resolved from search query and other filter parameters
+type TransactionSearchConditions struct {
+	Type               TransactionType
+	MaxTransactionTime int64
+	MinTransactionTime int64
+	CategoryIds        []int64
+	AccountIds         []int64
+	PayeeIds           []int64
+	TagFilters         []*TransactionTagFilter
+	NoTags             bool
+	AmountFilter       string
+	Keyword            string
+}
+
+// ParseTransactionSearchQuery parses transaction search query from string
+func ParseTransactionSearchQuery(query string) (*TransactionSearchQuery, error) {
+	terms, err := splitTransactionSearchQueryTerms(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery := &TransactionSearchQuery{}
+
+	for i := 0; i < len(terms); i++ {
+		term := terms[i]
+		key, value, negated := parseTransactionSearchQueryTerm(term)
+
+		if key == "" {
+			searchQuery.Keywords = append(searchQuery.Keywords, term.text)
+			continue
+		}
+
+		if value == "" {
+			return nil, errs.ErrTransactionSearchQueryInvalid
+		}
+
+		switch key {
+		case TRANSACTION_SEARCH_QUERY_KEY_TYPE:
+			transactionType, exists := transactionSearchQueryTypeNames[strings.ToLower(value)]
+
+			if !exists || negated || (searchQuery.Type != 0 && searchQuery.Type != transactionType) {
+				return nil, errs.ErrTransactionSearchQueryInvalid
+			}
+
+			searchQuery.Type = transactionType
+		case TRANSACTION_SEARCH_QUERY_KEY_CATEGORY:
+			if negated {
+				searchQuery.ExcludeCategoryNames = append(searchQuery.ExcludeCategoryNames, value)
+			} else {
+				searchQuery.CategoryNames = append(searchQuery.CategoryNames, value)
+			}
+		case TRANSACTION_SEARCH_QUERY_KEY_ACCOUNT:
+			if negated {
+				searchQuery.ExcludeAccountNames = append(searchQuery.ExcludeAccountNames, value)
+			} else {
+				searchQuery.AccountNames = append(searchQuery.AccountNames, value)
+			}
+		case TRANSACTION_SEARCH_QUERY_KEY_PAYEE:
+			if negated {
+				searchQuery.ExcludePayeeNames = append(searchQuery.ExcludePayeeNames, value)
+			} else {
+				searchQuery.PayeeNames = append(searchQuery.PayeeNames, value)
+			}
+		case TRANSACTION_SEARCH_QUERY_KEY_TAG:
+			if negated {
+				searchQuery.ExcludeTagNames = append(searchQuery.ExcludeTagNames, value)
+			} else {
+				searchQuery.TagNames = append(searchQuery.TagNames, value)
+			}
+		case TRANSACTION_SEARCH_QUERY_KEY_AMOUNT:
+			if searchQuery.AmountFilter != "" {
+				return nil, errs.ErrTransactionSearchQueryInvalid
+			}
+
+			amountFilter, err := parseTransactionSearchQueryAmountFilter(value, negated)
+
+			if err != nil {
+				return nil, err
+			}
+
+			searchQuery.AmountFilter = amountFilter
+		case TRANSACTION_SEARCH_QUERY_KEY_AFTER, TRANSACTION_SEARCH_QUERY_KEY_BEFORE:
+			if _, err := time.Parse(transactionSearchQueryDateFormat, value); err != nil || negated {
+				return nil, errs.ErrTransactionSearchQueryInvalid
+			}
+
+			if key == TRANSACTION_SEARCH_QUERY_KEY_AFTER {
+				searchQuery.AfterDate = value
+			} else {
+				searchQuery.BeforeDate = value
+			}
+		case TRANSACTION_SEARCH_QUERY_KEY_COMMENT:
+			value = strings.TrimPrefix(value, "~")
+
+			if value == "" || negated {
+				return nil, errs.ErrTransactionSearchQueryInvalid
+			}
+
+			searchQuery.Keywords = append(searchQuery.Keywords, value)
+		}
+	}
+
+	return searchQuery, nil
+}
+
+// ToTransactionSearchConditions resolves the names in search query to the ids of the given accounts, categories, tags and payees
+func (q *TransactionSearchQuery) ToTransactionSearchConditions(accounts []*Account, categories []*TransactionCategory, tags []*TransactionTag, payees []*Payee, clientTimezone *time.Location) (*TransactionSearchConditions, error) {
+	conditions := &TransactionSearchConditions{
+		Type:         q.Type,
+		AmountFilter: q.AmountFilter,
+		Keyword:      strings.Join(q.Keywords, " "),
+	}
+
+	if q.AfterDate != "" {
+		afterTime, err := time.ParseInLocation(transactionSearchQueryDateFormat, q.AfterDate, clientTimezone)
+
+		if err != nil {
+			return nil, errs.ErrTransactionSearchQueryInvalid
+		}
+
+		conditions.MinTransactionTime = utils.GetMinTransactionTimeFromUnixTime(afterTime.Unix())
+	}
+
+	if q.BeforeDate != "" {
+		beforeTime, err := time.ParseInLocation(transactionSearchQueryDateFormat, q.BeforeDate, clientTimezone)
+
+		if err != nil {
+			return nil, errs.ErrTransactionSearchQueryInvalid
+		}
+
+		conditions.MaxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(beforeTime.Unix() - 1)
+	}
+
+	var err error
+
+	if conditions.AccountIds, err = q.getAccountIds(accounts); err != nil {
+		return nil, err
+	}
+
+	if conditions.CategoryIds, err = q.getCategoryIds(categories); err != nil {
+		return nil, err
+	}
+
+	if conditions.PayeeIds, err = q.getPayeeIds(payees); err != nil {
+		return nil, err
+	}
+
+	if conditions.TagFilters, err = q.getTagFilters(tags); err != nil {
+		return nil, err
+	}
+
+	return conditions, nil
+}
+
+// Merge applies the conditions resolved from search query to current conditions, the ids, type, amount filter and keyword in search query replace the current ones, the time ranges are intersected and the tag filters are combined
+func (c *TransactionSearchConditions) Merge(searchConditions *TransactionSearchConditions) {
+	if searchConditions.Type != 0 {
+		c.Type = searchConditions.Type
+	}
+
+	if searchConditions.MaxTransactionTime > 0 && (c.MaxTransactionTime <= 0 || searchConditions.MaxTransactionTime < c.MaxTransactionTime) {
+		c.MaxTransactionTime = searchConditions.MaxTransactionTime
+	}
+
+	if searchConditions.MinTransactionTime > c.MinTransactionTime {
+		c.MinTransactionTime = searchConditions.MinTransactionTime
+	}
+
+	if len(searchConditions.CategoryIds) > 0 {
+		c.CategoryIds = searchConditions.CategoryIds
+	}
+
+	if len(searchConditions.AccountIds) > 0 {
+		c.AccountIds = searchConditions.AccountIds
+	}
+
+	if len(searchConditions.PayeeIds) > 0 {
+		c.PayeeIds = searchConditions.PayeeIds
+	}
+
+	if len(searchConditions.TagFilters) > 0 {
+		c.TagFilters = append(c.TagFilters, searchConditions.TagFilters...)
+	}
+
+	c.NoTags = c.NoTags || searchConditions.NoTags
+
+	if searchConditions.AmountFilter != "" {
+		c.AmountFilter = searchConditions.AmountFilter
+	}
+
+	if searchConditions.Keyword != "" {
+		c.Keyword = searchConditions.Keyword
+	}
+}
+
+// FilterTransactionsByTypeCategoryAndAccount returns the transactions matching the type, category ids and account ids in conditions, which is used for the aggregated transactions which cannot be filtered in database
+func (c *TransactionSearchConditions) FilterTransactionsByTypeCategoryAndAccount(transactions []*Transaction) []*Transaction {
+	if c.Type == 0 && len(c.CategoryIds) < 1 && len(c.AccountIds) < 1 {
+		return transactions
+	}
+
+	categoryIds := make(map[int64]bool, len(c.CategoryIds))
+	accountIds := make(map[int64]bool, len(c.AccountIds))
+
+	for i := 0; i < len(c.CategoryIds); i++ {
+		categoryIds[c.CategoryIds[i]] = true
+	}
+
+	for i := 0; i < len(c.AccountIds); i++ {
+		accountIds[c.AccountIds[i]] = true
+	}
+
+	filteredTransactions := make([]*Transaction, 0, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if c.Type != 0 {
+			transactionType, err := transaction.Type.ToTransactionType()
+
+			if err != nil || transactionType != c.Type {
+				continue
+			}
+		}
+
+		if len(categoryIds) > 0 && !categoryIds[transaction.CategoryId] {
+			continue
+		}
+
+		if len(accountIds) > 0 && !accountIds[transaction.AccountId] {
+			continue
+		}
+
+		filteredTransactions = append(filteredTransactions, transaction)
+	}
+
+	return filteredTransactions
+}
+
+func (q *TransactionSearchQuery) getAccountIds(accounts []*Account) ([]int64, error) {
+	if len(q.AccountNames) < 1 && len(q.ExcludeAccountNames) < 1 {
+		return nil, nil
+	}
+
+	getAccountIdsByName := func(accountName string) []int64 {
+		accountIds := make([]int64, 0)
+		parentAccountIds := make(map[int64]bool)
+
+		for i := 0; i < len(accounts); i++ {
+			account := accounts[i]
+
+			if !strings.EqualFold(account.Name, accountName) {
+				continue
+			}
+
+			if account.Type == ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+				parentAccountIds[account.AccountId] = true
+			} else {
+				accountIds = append(accountIds, account.AccountId)
+			}
+		}
+
+		for i := 0; i < len(accounts); i++ {
+			if parentAccountIds[accounts[i].ParentAccountId] {
+				accountIds = append(accountIds, accounts[i].AccountId)
+			}
+		}
+
+		return accountIds
+	}
+
+	var accountIds []int64
+
+	if len(q.AccountNames) > 0 {
+		for i := 0; i < len(q.AccountNames); i++ {
+			ids := getAccountIdsByName(q.AccountNames[i])
+
+			if len(ids) < 1 {
+				return nil, errs.ErrAccountNotFound
+			}
+
+			accountIds = append(accountIds, ids...)
+		}
+	} else {
+		for i := 0; i < len(accounts); i++ {
+			if accounts[i].Type != ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+				accountIds = append(accountIds, accounts[i].AccountId)
+			}
+		}
+	}
+
+	for i := 0; i < len(q.ExcludeAccountNames); i++ {
+		ids := getAccountIdsByName(q.ExcludeAccountNames[i])
+
+		if len(ids) < 1 {
+			return nil, errs.ErrAccountNotFound
+		}
+
+		accountIds = utils.Int64SliceMinus(accountIds, ids)
+	}
+
+	accountIds = utils.ToUniqueInt64Slice(accountIds)
+
+	if len(accountIds) < 1 {
+		return nil, errs.ErrAccountNotFound
+	}
+
+	return accountIds, nil
+}
+
+func (q *TransactionSearchQuery) getCategoryIds(categories []*TransactionCategory) ([]int64, error) {
+	if len(q.CategoryNames) < 1 && len(q.ExcludeCategoryNames) < 1 {
+		return nil, nil
+	}
+
+	getCategoryIdsByName := func(categoryName string) []int64 {
+		categoryIds := make([]int64, 0)
+		parentCategoryIds := make(map[int64]bool)
+
+		for i := 0; i < len(categories); i++ {
+			category := categories[i]
+
+			if !strings.EqualFold(category.Name, categoryName) {
+				continue
+			}
+
+			if category.ParentCategoryId == LevelOneTransactionCategoryParentId {
+				parentCategoryIds[category.CategoryId] = true
+			} else {
+				categoryIds = append(categoryIds, category.CategoryId)
+			}
+		}
+
+		for i := 0; i < len(categories); i++ {
+			if parentCategoryIds[categories[i].ParentCategoryId] {
+				categoryIds = append(categoryIds, categories[i].CategoryId)
+			}
+		}
+
+		return categoryIds
+	}
+
+	var categoryIds []int64
+
+	if len(q.CategoryNames) > 0 {
+		for i := 0; i < len(q.CategoryNames); i++ {
+			ids := getCategoryIdsByName(q.CategoryNames[i])
+
+			if len(ids) < 1 {
+				return nil, errs.ErrTransactionCategoryNotFound
+			}
+
+			categoryIds = append(categoryIds, ids...)
+		}
+	} else {
+		// balance modification transactions have no category, so they are kept when only excluding categories
+		categoryIds = append(categoryIds, 0)
+
+		for i := 0; i < len(categories); i++ {
+			if categories[i].ParentCategoryId != LevelOneTransactionCategoryParentId {
+				categoryIds = append(categoryIds, categories[i].CategoryId)
+			}
+		}
+	}
+
+	for i := 0; i < len(q.ExcludeCategoryNames); i++ {
+		ids := getCategoryIdsByName(q.ExcludeCategoryNames[i])
+
+		if len(ids) < 1 {
+			return nil, errs.ErrTransactionCategoryNotFound
+		}
+
+		categoryIds = utils.Int64SliceMinus(categoryIds, ids)
+	}
+
+	categoryIds = utils.ToUniqueInt64Slice(categoryIds)
+
+	if len(categoryIds) < 1 {
+		return nil, errs.ErrTransactionCategoryNotFound
+	}
+
+	return categoryIds, nil
+}
+
+func (q *TransactionSearchQuery) getPayeeIds(payees []*Payee) ([]int64, error) {
+	if len(q.PayeeNames) < 1 && len(q.ExcludePayeeNames) < 1 {
+		return nil, nil
+	}
+
+	getPayeeIdsByName := func(payeeName string) []int64 {
+		payeeIds := make([]int64, 0)
+
+		for i := 0; i < len(payees); i++ {
+			if payees[i].IsNameMatched(payeeName) {
+				payeeIds = append(payeeIds, payees[i].PayeeId)
+			}
+		}
+
+		return payeeIds
+	}
+
+	var payeeIds []int64
+
+	if len(q.PayeeNames) > 0 {
+		for i := 0; i < len(q.PayeeNames); i++ {
+			ids := getPayeeIdsByName(q.PayeeNames[i])
+
+			if len(ids) < 1 {
+				return nil, errs.ErrPayeeNotFound
+			}
+
+			payeeIds = append(payeeIds, ids...)
+		}
+	} else {
+		// transactions without payee are kept when only excluding payees
+		payeeIds = append(payeeIds, 0)
+
+		for i := 0; i < len(payees); i++ {
+			payeeIds = append(payeeIds, payees[i].PayeeId)
+		}
+	}
+
+	for i := 0; i < len(q.ExcludePayeeNames); i++ {
+		ids := getPayeeIdsByName(q.ExcludePayeeNames[i])
+
+		if len(ids) < 1 {
+			return nil, errs.ErrPayeeNotFound
+		}
+
+		payeeIds = utils.Int64SliceMinus(payeeIds, ids)
+	}
+
+	payeeIds = utils.ToUniqueInt64Slice(payeeIds)
+
+	if len(payeeIds) < 1 {
+		return nil, errs.ErrPayeeNotFound
+	}
+
+	return payeeIds, nil
+}
+
+func (q *TransactionSearchQuery) getTagFilters(tags []*TransactionTag) ([]*TransactionTagFilter, error) {
+	getTagIdsByName := func(tagName string) []int64 {
+		tagIds := make([]int64, 0)
+
+		for i := 0; i < len(tags); i++ {
+			if strings.EqualFold(tags[i].Name, tagName) {
+				tagIds = append(tagIds, tags[i].TagId)
+			}
+		}
+
+		return tagIds
+	}
+
+	tagFilters := make([]*TransactionTagFilter, 0, len(q.TagNames)+1)
+
+	for i := 0; i < len(q.TagNames); i++ {
+		tagIds := getTagIdsByName(q.TagNames[i])
+
+		if len(tagIds) < 1 {
+			return nil, errs.ErrTransactionTagNotFound
+		}
+
+		tagFilters = append(tagFilters, &TransactionTagFilter{
+			TagIds: tagIds,
+			Type:   TRANSACTION_TAG_FILTER_HAS_ANY,
+		})
+	}
+
+	excludeTagIds := make([]int64, 0, len(q.ExcludeTagNames))
+
+	for i := 0; i < len(q.ExcludeTagNames); i++ {
+		tagIds := getTagIdsByName(q.ExcludeTagNames[i])
+
+		if len(tagIds) < 1 {
+			return nil, errs.ErrTransactionTagNotFound
+		}
+
+		excludeTagIds = append(excludeTagIds, tagIds...)
+	}
+
+	if len(excludeTagIds) > 0 {
+		tagFilters = append(tagFilters, &TransactionTagFilter{
+			TagIds: utils.ToUniqueInt64Slice(excludeTagIds),
+			Type:   TRANSACTION_TAG_FILTER_NOT_HAS_ANY,
+		})
+	}
+
+	return tagFilters, nil
+}
+
+type transactionSearchQueryTerm struct {
+	text    string
+	literal bool // whether the whole term is quoted, e.g. "tag:trip", which is always treated as keyword
+}
+
+func splitTransactionSearchQueryTerms(query string) ([]*transactionSearchQueryTerm, error) {
+	terms := make([]*transactionSearchQueryTerm, 0)
+	var current strings.Builder
+	inTerm := false
+	inQuotes := false
+	literal := false
+
+	for _, ch := range query {
+		if ch == '"' {
+			if !inTerm {
+				literal = true
+			}
+
+			inQuotes = !inQuotes
+			inTerm = true
+			continue
+		}
+
+		if !inQuotes && (ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n') {
+			if inTerm {
+				terms = append(terms, &transactionSearchQueryTerm{text: current.String(), literal: literal})
+				current.Reset()
+				inTerm = false
+				literal = false
+			}
+
+			continue
+		}
+
+		current.WriteRune(ch)
+		inTerm = true
+	}
+
+	if inQuotes {
+		return nil, errs.ErrTransactionSearchQueryInvalid
+	}
+
+	if inTerm {
+		terms = append(terms, &transactionSearchQueryTerm{text: current.String(), literal: literal})
+	}
+
+	return terms, nil
+}
+
+func parseTransactionSearchQueryTerm(term *transactionSearchQueryTerm) (key string, value string, negated bool) {
+	if term.literal {
+		return "", "", false
+	}
+
+	text := term.text
+
+	if strings.HasPrefix(text, "-") {
+		text = text[1:]
+		negated = true
+	}
+
+	separatorIndex := strings.Index(text, ":")
+
+	if separatorIndex < 1 {
+		return "", "", false
+	}
+
+	key = strings.ToLower(text[:separatorIndex])
+
+	switch key {
+	case TRANSACTION_SEARCH_QUERY_KEY_TYPE, TRANSACTION_SEARCH_QUERY_KEY_CATEGORY, TRANSACTION_SEARCH_QUERY_KEY_ACCOUNT,
+		TRANSACTION_SEARCH_QUERY_KEY_PAYEE, TRANSACTION_SEARCH_QUERY_KEY_TAG, TRANSACTION_SEARCH_QUERY_KEY_AMOUNT,
+		TRANSACTION_SEARCH_QUERY_KEY_AFTER, TRANSACTION_SEARCH_QUERY_KEY_BEFORE, TRANSACTION_SEARCH_QUERY_KEY_COMMENT:
+		return key, text[separatorIndex+1:], negated
+	default:
+		return "", "", false
+	}
+}
+
+func parseTransactionSearchQueryAmountFilter(value string, negated bool) (string, error) {
+	if rangeItems := strings.Split(value, ".."); len(rangeItems) == 2 {
+		minAmount, err := utils.ParseAmount(rangeItems[0])
+
+		if err != nil || rangeItems[0] == "" {
+			return "", errs.ErrTransactionSearchQueryInvalid
+		}
+
+		maxAmount, err := utils.ParseAmount(rangeItems[1])
+
+		if err != nil || rangeItems[1] == "" || minAmount > maxAmount {
+			return "", errs.ErrTransactionSearchQueryInvalid
+		}
+
+		if negated {
+			return "nb:" + utils.Int64ToString(minAmount) + ":" + utils.Int64ToString(maxAmount), nil
+		}
+
+		return "bt:" + utils.Int64ToString(minAmount) + ":" + utils.Int64ToString(maxAmount), nil
+	}
+
+	operator := "="
+
+	for _, prefix := range []string{">=", "<=", "!=", ">", "<", "="} {
+		if strings.HasPrefix(value, prefix) {
+			operator = prefix
+			value = value[len(prefix):]
+			break
+		}
+	}
+
+	amount, err := utils.ParseAmount(value)
+
+	if err != nil || value == "" {
+		return "", errs.ErrTransactionSearchQueryInvalid
+	}
+
+	if negated {
+		switch operator {
+		case ">=":
+			operator = "<"
+		case "<=":
+			operator = ">"
+		case "!=":
+			operator = "="
+		case ">":
+			operator = "<="
+		case "<":
+			operator = ">="
+		case "=":
+			operator = "!="
+		}
+	}
+
+	switch operator {
+	case ">=":
+		return "gt:" + utils.Int64ToString(amount-1), nil
+	case "<=":
+		return "lt:" + utils.Int64ToString(amount+1), nil
+	case "!=":
+		return "ne:" + utils.Int64ToString(amount), nil
+	case ">":
+		return "gt:" + utils.Int64ToString(amount), nil
+	case "<":
+		return "lt:" + utils.Int64ToString(amount), nil
+	default:
+		return "eq:" + utils.Int64ToString(amount), nil
+	}
+}
@@ -0,0 +1,240 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+func TestParseTransactionSearchQuery(t *testing.T) {
+	searchQuery, err := ParseTransactionSearchQuery(`category:Food amount:>50 tag:trip -tag:work account:"Visa Card" after:2025-01-01 comment:~"uber eats" taxi`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Food"}, searchQuery.CategoryNames)
+	assert.Equal(t, "gt:5000", searchQuery.AmountFilter)
+	assert.Equal(t, []string{"trip"}, searchQuery.TagNames)
+	assert.Equal(t, []string{"work"}, searchQuery.ExcludeTagNames)
+	assert.Equal(t, []string{"Visa Card"}, searchQuery.AccountNames)
+	assert.Equal(t, "2025-01-01", searchQuery.AfterDate)
+	assert.Equal(t, []string{"uber eats", "taxi"}, searchQuery.Keywords)
+
+	searchQuery, err = ParseTransactionSearchQuery(`TYPE:Expense -payee:Shop "tag:trip" -5 12:30`)
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_TYPE_EXPENSE, searchQuery.Type)
+	assert.Equal(t, []string{"Shop"}, searchQuery.ExcludePayeeNames)
+	assert.Equal(t, []string{"tag:trip", "-5", "12:30"}, searchQuery.Keywords)
+
+	searchQuery, err = ParseTransactionSearchQuery("")
+	assert.Nil(t, err)
+	assert.Equal(t, &TransactionSearchQuery{}, searchQuery)
+}
+
+func TestParseTransactionSearchQuery_AmountFilter(t *testing.T) {
+	expectedAmountFilters := map[string]string{
+		"amount:50":          "eq:5000",
+		"amount:=50":         "eq:5000",
+		"amount:!=50":        "ne:5000",
+		"amount:>50":         "gt:5000",
+		"amount:>=50":        "gt:4999",
+		"amount:<50.5":       "lt:5050",
+		"amount:<=50":        "lt:5001",
+		"amount:10..20":      "bt:1000:2000",
+		"-amount:10..20":     "nb:1000:2000",
+		"-amount:50":         "ne:5000",
+		"-amount:>50":        "lt:5001",
+		"-amount:<=50":       "gt:5000",
+		"amount:-10..-5":     "bt:-1000:-500",
+		"amount:>-0.01":      "gt:-1",
+		"-amount:!=50":       "eq:5000",
+		"-amount:>=50":       "lt:5000",
+		"-amount:<50":        "gt:4999",
+		"amount:\"10..20\"":  "bt:1000:2000",
+		"AMOUNT:0.5":         "eq:50",
+		"amount:+1":          "eq:100",
+		"amount:<=0":         "lt:1",
+		"amount:>=0":         "gt:-1",
+		"amount:100..100":    "bt:10000:10000",
+		"amount:99999999.99": "eq:9999999999",
+	}
+
+	for query, expectedAmountFilter := range expectedAmountFilters {
+		searchQuery, err := ParseTransactionSearchQuery(query)
+		assert.Nil(t, err, query)
+		assert.Equal(t, expectedAmountFilter, searchQuery.AmountFilter, query)
+	}
+}
+
+func TestParseTransactionSearchQuery_InvalidQuery(t *testing.T) {
+	invalidQueries := []string{
+		`account:"Visa`,
+		"category:",
+		"type:unknown",
+		"-type:expense",
+		"type:expense type:income",
+		"amount:abc",
+		"amount:>",
+		"amount:20..10",
+		"amount:10..",
+		"amount:1.234",
+		"amount:10 amount:20",
+		"after:2025-13-01",
+		"before:yesterday",
+		"-after:2025-01-01",
+		"comment:~",
+		"-comment:uber",
+	}
+
+	for _, query := range invalidQueries {
+		_, err := ParseTransactionSearchQuery(query)
+		assert.Equal(t, errs.ErrTransactionSearchQueryInvalid, err, query)
+	}
+}
+
+func TestTransactionSearchQueryToTransactionSearchConditions(t *testing.T) {
+	accounts := []*Account{
+		{AccountId: 1, Name: "Cash", Type: ACCOUNT_TYPE_SINGLE_ACCOUNT},
+		{AccountId: 2, Name: "Visa", Type: ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS},
+		{AccountId: 3, Name: "Visa USD", Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, ParentAccountId: 2},
+		{AccountId: 4, Name: "Visa EUR", Type: ACCOUNT_TYPE_SINGLE_ACCOUNT, ParentAccountId: 2},
+	}
+	categories := []*TransactionCategory{
+		{CategoryId: 10, Name: "Food", ParentCategoryId: LevelOneTransactionCategoryParentId},
+		{CategoryId: 11, Name: "Restaurant", ParentCategoryId: 10},
+		{CategoryId: 12, Name: "Snack", ParentCategoryId: 10},
+		{CategoryId: 20, Name: "Transport", ParentCategoryId: LevelOneTransactionCategoryParentId},
+		{CategoryId: 21, Name: "Taxi", ParentCategoryId: 20},
+	}
+	tags := []*TransactionTag{
+		{TagId: 100, Name: "Trip"},
+		{TagId: 101, Name: "Work"},
+	}
+	payees := []*Payee{
+		{PayeeId: 200, Name: "Uber", Extend: &PayeeExtend{Aliases: []string{"Uber Eats"}}},
+		{PayeeId: 201, Name: "Lyft"},
+	}
+	timezone := time.FixedZone("Test Timezone", 8*60*60)
+
+	searchQuery, err := ParseTransactionSearchQuery(`category:food tag:trip -tag:work account:Visa payee:"uber eats" after:2025-01-01 before:2025-02-01 amount:>50 comment:~"uber"`)
+	assert.Nil(t, err)
+
+	conditions, err := searchQuery.ToTransactionSearchConditions(accounts, categories, tags, payees, timezone)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{3, 4}, conditions.AccountIds)
+	assert.Equal(t, []int64{11, 12}, conditions.CategoryIds)
+	assert.Equal(t, []int64{200}, conditions.PayeeIds)
+	assert.Equal(t, 2, len(conditions.TagFilters))
+	assert.Equal(t, []int64{100}, conditions.TagFilters[0].TagIds)
+	assert.Equal(t, TRANSACTION_TAG_FILTER_HAS_ANY, conditions.TagFilters[0].Type)
+	assert.Equal(t, []int64{101}, conditions.TagFilters[1].TagIds)
+	assert.Equal(t, TRANSACTION_TAG_FILTER_NOT_HAS_ANY, conditions.TagFilters[1].Type)
+	assert.Equal(t, "gt:5000", conditions.AmountFilter)
+	assert.Equal(t, "uber", conditions.Keyword)
+	assert.Equal(t, utils.GetMinTransactionTimeFromUnixTime(1735660800), conditions.MinTransactionTime)
+	assert.Equal(t, utils.GetMaxTransactionTimeFromUnixTime(1738339199), conditions.MaxTransactionTime)
+
+	searchQuery, err = ParseTransactionSearchQuery(`-category:Food -account:"Visa EUR" -payee:Lyft`)
+	assert.Nil(t, err)
+
+	conditions, err = searchQuery.ToTransactionSearchConditions(accounts, categories, tags, payees, timezone)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 3}, conditions.AccountIds)
+	assert.Equal(t, []int64{0, 21}, conditions.CategoryIds)
+	assert.Equal(t, []int64{0, 200}, conditions.PayeeIds)
+	assert.Equal(t, 0, len(conditions.TagFilters))
+}
+
+func TestTransactionSearchQueryToTransactionSearchConditions_NameNotFound(t *testing.T) {
+	accounts := []*Account{{AccountId: 1, Name: "Cash", Type: ACCOUNT_TYPE_SINGLE_ACCOUNT}}
+	categories := []*TransactionCategory{{CategoryId: 11, Name: "Restaurant", ParentCategoryId: 10}}
+	tags := []*TransactionTag{{TagId: 100, Name: "Trip"}}
+	payees := []*Payee{{PayeeId: 200, Name: "Uber"}}
+
+	expectedErrors := map[string]error{
+		"account:Visa":     errs.ErrAccountNotFound,
+		"-account:Cash":    errs.ErrAccountNotFound,
+		"category:Food":    errs.ErrTransactionCategoryNotFound,
+		"-category:Food":   errs.ErrTransactionCategoryNotFound,
+		"tag:Work":         errs.ErrTransactionTagNotFound,
+		"-tag:Work":        errs.ErrTransactionTagNotFound,
+		"payee:Lyft":       errs.ErrPayeeNotFound,
+		"-payee:Lyft":      errs.ErrPayeeNotFound,
+		"category:Snack ":  errs.ErrTransactionCategoryNotFound,
+		"tag:trip tag:foo": errs.ErrTransactionTagNotFound,
+	}
+
+	for query, expectedError := range expectedErrors {
+		searchQuery, err := ParseTransactionSearchQuery(query)
+		assert.Nil(t, err, query)
+
+		_, err = searchQuery.ToTransactionSearchConditions(accounts, categories, tags, payees, time.UTC)
+		assert.Equal(t, expectedError, err, query)
+	}
+}
+
+func TestTransactionSearchConditionsMerge(t *testing.T) {
+	conditions := &TransactionSearchConditions{
+		Type:               TRANSACTION_TYPE_INCOME,
+		MaxTransactionTime: 2000,
+		MinTransactionTime: 1000,
+		CategoryIds:        []int64{1},
+		AccountIds:         []int64{2},
+		TagFilters:         []*TransactionTagFilter{{TagIds: []int64{3}, Type: TRANSACTION_TAG_FILTER_HAS_ALL}},
+		AmountFilter:       "gt:1",
+		Keyword:            "test",
+	}
+
+	conditions.Merge(&TransactionSearchConditions{
+		MaxTransactionTime: 3000,
+		MinTransactionTime: 1500,
+		AccountIds:         []int64{4, 5},
+		TagFilters:         []*TransactionTagFilter{{TagIds: []int64{6}, Type: TRANSACTION_TAG_FILTER_NOT_HAS_ANY}},
+	})
+
+	assert.Equal(t, TRANSACTION_TYPE_INCOME, conditions.Type)
+	assert.Equal(t, int64(2000), conditions.MaxTransactionTime)
+	assert.Equal(t, int64(1500), conditions.MinTransactionTime)
+	assert.Equal(t, []int64{1}, conditions.CategoryIds)
+	assert.Equal(t, []int64{4, 5}, conditions.AccountIds)
+	assert.Equal(t, 2, len(conditions.TagFilters))
+	assert.Equal(t, "gt:1", conditions.AmountFilter)
+	assert.Equal(t, "test", conditions.Keyword)
+
+	conditions = &TransactionSearchConditions{}
+	conditions.Merge(&TransactionSearchConditions{
+		Type:               TRANSACTION_TYPE_EXPENSE,
+		MaxTransactionTime: 3000,
+		AmountFilter:       "lt:1",
+		Keyword:            "uber",
+	})
+
+	assert.Equal(t, TRANSACTION_TYPE_EXPENSE, conditions.Type)
+	assert.Equal(t, int64(3000), conditions.MaxTransactionTime)
+	assert.Equal(t, int64(0), conditions.MinTransactionTime)
+	assert.Equal(t, "lt:1", conditions.AmountFilter)
+	assert.Equal(t, "uber", conditions.Keyword)
+}
+
+func TestTransactionSearchConditionsFilterTransactionsByTypeCategoryAndAccount(t *testing.T) {
+	transactions := []*Transaction{
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 1, AccountId: 10},
+		{Type: TRANSACTION_DB_TYPE_EXPENSE, CategoryId: 2, AccountId: 10},
+		{Type: TRANSACTION_DB_TYPE_INCOME, CategoryId: 3, AccountId: 11},
+		{Type: TRANSACTION_DB_TYPE_TRANSFER_OUT, CategoryId: 4, AccountId: 11},
+		{Type: TRANSACTION_DB_TYPE_TRANSFER_IN, CategoryId: 4, AccountId: 10},
+	}
+
+	conditions := &TransactionSearchConditions{}
+	assert.Equal(t, 5, len(conditions.FilterTransactionsByTypeCategoryAndAccount(transactions)))
+
+	conditions = &TransactionSearchConditions{Type: TRANSACTION_TYPE_TRANSFER}
+	assert.Equal(t, []*Transaction{transactions[3], transactions[4]}, conditions.FilterTransactionsByTypeCategoryAndAccount(transactions))
+
+	conditions = &TransactionSearchConditions{CategoryIds: []int64{1, 3}}
+	assert.Equal(t, []*Transaction{transactions[0], transactions[2]}, conditions.FilterTransactionsByTypeCategoryAndAccount(transactions))
+
+	conditions = &TransactionSearchConditions{Type: TRANSACTION_TYPE_EXPENSE, AccountIds: []int64{10}, CategoryIds: []int64{2, 4}}
+	assert.Equal(t, []*Transaction{transactions[1]}, conditions.FilterTransactionsByTypeCategoryAndAccount(transactions))
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const MaximumSplitsCountOfTransaction = 20
+
+// TransactionSplit represents a split line of transaction stored in database
+type TransactionSplit struct {
+	SplitId         int64  `xorm:"PK"`
+	Uid             int64  `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_id) INDEX(IDX_transaction_split_uid_deleted_transaction_time) NOT NULL"`
+	Deleted         bool   `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_id) INDEX(IDX_transaction_split_uid_deleted_transaction_time) NOT NULL"`
+	TransactionId   int64  `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_id) NOT NULL"`
+	TransactionTime int64  `xorm:"INDEX(IDX_transaction_split_uid_deleted_transaction_time) NOT NULL"`
+	CategoryId      int64  `xorm:"NOT NULL"`
+	Amount          int64  `xorm:"NOT NULL"`
+	TagIds          string `xorm:"VARCHAR(255) NOT NULL"`
+	Comment         string `xorm:"VARCHAR(255) NOT NULL"`
+	DisplayOrder    int32  `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// TransactionSplitRequest represents all parameters of a transaction split line in transaction creation or modification request
+type TransactionSplitRequest struct {
+	CategoryId int64    `json:"categoryId,string" binding:"required,min=1"`
+	Amount     int64    `json:"amount" binding:"min=1,max=99999999999"`
+	TagIds     []string `json:"tagIds"`
+	Comment    string   `json:"comment" binding:"max=255"`
+}
+
+// TransactionSplitInfoResponse represents a view-object of transaction split line
+type TransactionSplitInfoResponse struct {
+	CategoryId int64    `json:"categoryId,string"`
+	Amount     int64    `json:"amount"`
+	TagIds     []string `json:"tagIds"`
+	Comment    string   `json:"comment"`
+}
+
+// GetTagIds returns tag ids list of this transaction split line
+func (t *TransactionSplit) GetTagIds() []int64 {
+	tagIds := make([]string, 0)
+
+	if t.TagIds != "" {
+		tagIds = strings.Split(t.TagIds, ",")
+	}
+
+	result, _ := utils.StringArrayToInt64Array(tagIds)
+
+	return result
+}
+
+// IsSameContent returns whether this transaction split line has the same category, amount, tags and comment with the specified one
+func (t *TransactionSplit) IsSameContent(other *TransactionSplit) bool {
+	return t.CategoryId == other.CategoryId &&
+		t.Amount == other.Amount &&
+		t.TagIds == other.TagIds &&
+		t.Comment == other.Comment
+}
+
+// ToTransactionSplitInfoResponse returns a view-object according to database model
+func (t *TransactionSplit) ToTransactionSplitInfoResponse() *TransactionSplitInfoResponse {
+	tagIds := make([]string, 0)
+
+	if t.TagIds != "" {
+		tagIds = strings.Split(t.TagIds, ",")
+	}
+
+	return &TransactionSplitInfoResponse{
+		CategoryId: t.CategoryId,
+		Amount:     t.Amount,
+		TagIds:     tagIds,
+		Comment:    t.Comment,
+	}
+}
+
+// TransactionSplitSlice represents the slice data structure of TransactionSplit
+type TransactionSplitSlice []*TransactionSplit
+
+// Len returns the count of items
+func (s TransactionSplitSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TransactionSplitSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TransactionSplitSlice) Less(i, j int) bool {
+	return s[i].DisplayOrder < s[j].DisplayOrder
+}
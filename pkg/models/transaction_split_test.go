@@ -0,0 +1,62 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionSplitGetTagIds(t *testing.T) {
+	split := &TransactionSplit{TagIds: ""}
+	assert.Equal(t, 0, len(split.GetTagIds()))
+
+	split = &TransactionSplit{TagIds: "1,23,456"}
+	assert.Equal(t, []int64{1, 23, 456}, split.GetTagIds())
+}
+
+func TestTransactionSplitIsSameContent(t *testing.T) {
+	split := &TransactionSplit{SplitId: 1, CategoryId: 2, Amount: 100, TagIds: "1,2", Comment: "foo", DisplayOrder: 1}
+
+	assert.True(t, split.IsSameContent(&TransactionSplit{SplitId: 3, CategoryId: 2, Amount: 100, TagIds: "1,2", Comment: "foo", DisplayOrder: 2}))
+	assert.False(t, split.IsSameContent(&TransactionSplit{CategoryId: 3, Amount: 100, TagIds: "1,2", Comment: "foo"}))
+	assert.False(t, split.IsSameContent(&TransactionSplit{CategoryId: 2, Amount: 101, TagIds: "1,2", Comment: "foo"}))
+	assert.False(t, split.IsSameContent(&TransactionSplit{CategoryId: 2, Amount: 100, TagIds: "1", Comment: "foo"}))
+	assert.False(t, split.IsSameContent(&TransactionSplit{CategoryId: 2, Amount: 100, TagIds: "1,2", Comment: "bar"}))
+}
+
+func TestTransactionSplitToTransactionSplitInfoResponse(t *testing.T) {
+	split := &TransactionSplit{CategoryId: 2, Amount: 100, TagIds: "3,4", Comment: "foo"}
+	splitResp := split.ToTransactionSplitInfoResponse()
+
+	assert.Equal(t, int64(2), splitResp.CategoryId)
+	assert.Equal(t, int64(100), splitResp.Amount)
+	assert.Equal(t, []string{"3", "4"}, splitResp.TagIds)
+	assert.Equal(t, "foo", splitResp.Comment)
+
+	split = &TransactionSplit{CategoryId: 2, Amount: 100}
+	splitResp = split.ToTransactionSplitInfoResponse()
+	assert.Equal(t, 0, len(splitResp.TagIds))
+}
+
+func TestTransactionSplitSliceLess(t *testing.T) {
+	var splitSlice TransactionSplitSlice
+	splitSlice = append(splitSlice, &TransactionSplit{
+		SplitId:      1,
+		DisplayOrder: 3,
+	})
+	splitSlice = append(splitSlice, &TransactionSplit{
+		SplitId:      2,
+		DisplayOrder: 1,
+	})
+	splitSlice = append(splitSlice, &TransactionSplit{
+		SplitId:      3,
+		DisplayOrder: 2,
+	})
+
+	sort.Sort(splitSlice)
+
+	assert.Equal(t, int64(2), splitSlice[0].SplitId)
+	assert.Equal(t, int64(3), splitSlice[1].SplitId)
+	assert.Equal(t, int64(1), splitSlice[2].SplitId)
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
@@ -24,6 +25,8 @@ const (
 	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED TransactionScheduleFrequencyType = 0
 	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY   TransactionScheduleFrequencyType = 1
 	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY  TransactionScheduleFrequencyType = 2
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY    TransactionScheduleFrequencyType = 3
+	TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RULE     TransactionScheduleFrequencyType = 4
 )
 
 // TransactionTemplate represents transaction template stored in database
@@ -42,6 +45,7 @@ type TransactionTemplate struct {
 	ScheduledEndTime           *int64                           `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledAt                int16                            `xorm:"INDEX(IDX_transaction_template_deleted_type_freqtype_scheduled_time)"`
 	ScheduledTimezoneUtcOffset int16
+	ScheduledNeedConfirm       bool
 	TagIds                     string `xorm:"VARCHAR(255) NOT NULL"`
 	Amount                     int64  `xorm:"NOT NULL"`
 	RelatedAccountId           int64  `xorm:"NOT NULL"`
@@ -79,10 +83,11 @@ type TransactionTemplateCreateRequest struct {
 	TagIds                     []string                          `json:"tagIds"`
 	Comment                    string                            `json:"comment" binding:"max=255"`
 	ScheduledFrequencyType     *TransactionScheduleFrequencyType `json:"scheduledFrequencyType" binding:"omitempty"`
-	ScheduledFrequency         *string                           `json:"scheduledFrequency" binding:"omitempty"`
+	ScheduledFrequency         *string                           `json:"scheduledFrequency" binding:"omitempty,validScheduledFrequency"`
 	ScheduledStartDate         *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate           *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledTimezoneUtcOffset *int16                            `json:"utcOffset" binding:"omitempty,min=-720,max=840"`
+	ScheduledNeedConfirm       bool                              `json:"scheduledNeedConfirm"`
 	ClientSessionId            string                            `json:"clientSessionId"`
 }
 
@@ -106,10 +111,11 @@ type TransactionTemplateModifyRequest struct {
 	TagIds                     []string                          `json:"tagIds"`
 	Comment                    string                            `json:"comment" binding:"max=255"`
 	ScheduledFrequencyType     *TransactionScheduleFrequencyType `json:"scheduledFrequencyType" binding:"omitempty"`
-	ScheduledFrequency         *string                           `json:"scheduledFrequency" binding:"omitempty"`
+	ScheduledFrequency         *string                           `json:"scheduledFrequency" binding:"omitempty,validScheduledFrequency"`
 	ScheduledStartDate         *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate           *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledTimezoneUtcOffset *int16                            `json:"utcOffset" binding:"omitempty,min=-720,max=840"`
+	ScheduledNeedConfirm       bool                              `json:"scheduledNeedConfirm"`
 }
 
 // TransactionTemplateHideRequest represents all parameters of transaction template hiding request
@@ -143,6 +149,7 @@ type TransactionTemplateInfoResponse struct {
 	ScheduledStartDate     *string                           `json:"scheduledStartDate" binding:"omitempty"`
 	ScheduledEndDate       *string                           `json:"scheduledEndDate" binding:"omitempty"`
 	ScheduledAt            *int16                            `json:"scheduledAt,omitempty"`
+	ScheduledNeedConfirm   *bool                             `json:"scheduledNeedConfirm,omitempty"`
 	DisplayOrder           int32                             `json:"displayOrder"`
 	Hidden                 bool                              `json:"hidden"`
 }
@@ -160,6 +167,70 @@ func (t *TransactionTemplate) GetTagIds() []int64 {
 	return result
 }
 
+// GetScheduleRule returns the transaction schedule rule equivalent to the scheduled frequency of transaction template
+func (t *TransactionTemplate) GetScheduleRule() (*TransactionScheduleRule, error) {
+	return GetTransactionScheduleRule(t.ScheduledFrequencyType, t.ScheduledFrequency)
+}
+
+// IsScheduledOn returns whether the transaction template should create transaction on the date of specified time,
+// the interval of schedule rule is counted from the scheduled start date, or the created date if the start date is not set
+func (t *TransactionTemplate) IsScheduledOn(transactionTime time.Time) (bool, error) {
+	rule, err := t.GetScheduleRule()
+
+	if err != nil {
+		return false, err
+	}
+
+	return t.isScheduledOn(rule, transactionTime), nil
+}
+
+func (t *TransactionTemplate) isScheduledOn(rule *TransactionScheduleRule, transactionTime time.Time) bool {
+	templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
+	anchorUnixTime := t.CreatedUnixTime
+
+	if t.ScheduledStartTime != nil {
+		anchorUnixTime = *t.ScheduledStartTime
+	}
+
+	return rule.IsOccurrence(transactionTime.In(templateTimeZone), time.Unix(anchorUnixTime, 0).In(templateTimeZone))
+}
+
+// ToScheduledTransaction returns a new transaction model which is created by the scheduled transaction template at the specified unix time
+func (t *TransactionTemplate) ToScheduledTransaction(transactionUnixTime int64) (*Transaction, error) {
+	var transactionDbType TransactionDbType
+
+	if t.Type == TRANSACTION_TYPE_EXPENSE {
+		transactionDbType = TRANSACTION_DB_TYPE_EXPENSE
+	} else if t.Type == TRANSACTION_TYPE_INCOME {
+		transactionDbType = TRANSACTION_DB_TYPE_INCOME
+	} else if t.Type == TRANSACTION_TYPE_TRANSFER {
+		transactionDbType = TRANSACTION_DB_TYPE_TRANSFER_OUT
+	} else {
+		return nil, errs.ErrTransactionTypeInvalid
+	}
+
+	transaction := &Transaction{
+		Uid:               t.Uid,
+		Type:              transactionDbType,
+		CategoryId:        t.CategoryId,
+		TransactionTime:   utils.GetMinTransactionTimeFromUnixTime(transactionUnixTime),
+		TimezoneUtcOffset: t.ScheduledTimezoneUtcOffset,
+		AccountId:         t.AccountId,
+		Amount:            t.Amount,
+		HideAmount:        t.HideAmount,
+		Comment:           t.Comment,
+		CreatedIp:         "127.0.0.1",
+		ScheduledCreated:  true,
+	}
+
+	if t.Type == TRANSACTION_TYPE_TRANSFER {
+		transaction.RelatedAccountId = t.RelatedAccountId
+		transaction.RelatedAccountAmount = t.RelatedAccountAmount
+	}
+
+	return transaction, nil
+}
+
 // ToTransactionTemplateInfoResponse returns a view-object according to database model
 func (t *TransactionTemplate) ToTransactionTemplateInfoResponse(serverUtcOffset int16) *TransactionTemplateInfoResponse {
 	utcOffset := serverUtcOffset
@@ -180,6 +251,7 @@ func (t *TransactionTemplate) ToTransactionTemplateInfoResponse(serverUtcOffset
 		response.ScheduledFrequencyType = &t.ScheduledFrequencyType
 		response.ScheduledFrequency = &t.ScheduledFrequency
 		response.ScheduledAt = &t.ScheduledAt
+		response.ScheduledNeedConfirm = &t.ScheduledNeedConfirm
 
 		templateTimeZone := time.FixedZone("Template Timezone", int(t.ScheduledTimezoneUtcOffset)*60)
 
@@ -240,3 +312,62 @@ func (s TransactionTemplateInfoResponseSlice) Swap(i, j int) {
 func (s TransactionTemplateInfoResponseSlice) Less(i, j int) bool {
 	return s[i].DisplayOrder < s[j].DisplayOrder
 }
+
+// GetTransactionScheduleRule returns the transaction schedule rule equivalent to the specified scheduled frequency type and frequency,
+// the frequency of weekly type is comma-separated weekdays (0 is sunday), the frequency of monthly type is comma-separated days of month
+// (negative value is counted from the end of month, e.g. -1 is the last day), the frequency of daily type is the interval days,
+// and the frequency of rule type is a RRULE string
+func GetTransactionScheduleRule(frequencyType TransactionScheduleFrequencyType, frequency string) (*TransactionScheduleRule, error) {
+	if frequencyType == TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RULE {
+		return ParseTransactionScheduleRule(frequency)
+	}
+
+	if frequency == "" {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	values, err := utils.StringArrayToInt64Array(strings.Split(frequency, ","))
+
+	if err != nil {
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	rule := &TransactionScheduleRule{
+		Interval: 1,
+	}
+
+	switch frequencyType {
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY:
+		rule.Frequency = TRANSACTION_SCHEDULE_RULE_FREQUENCY_WEEKLY
+
+		for i := 0; i < len(values); i++ {
+			if values[i] < int64(time.Sunday) || values[i] > int64(time.Saturday) {
+				return nil, errs.ErrScheduledTransactionFrequencyInvalid
+			}
+
+			rule.ByDays = append(rule.ByDays, TransactionScheduleRuleWeekday{Weekday: time.Weekday(values[i])})
+		}
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY:
+		rule.Frequency = TRANSACTION_SCHEDULE_RULE_FREQUENCY_MONTHLY
+
+		for i := 0; i < len(values); i++ {
+			if values[i] == 0 || values[i] < -31 || values[i] > 31 {
+				return nil, errs.ErrScheduledTransactionFrequencyInvalid
+			}
+
+			rule.ByMonthDays = append(rule.ByMonthDays, int(values[i]))
+		}
+	case TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY:
+		rule.Frequency = TRANSACTION_SCHEDULE_RULE_FREQUENCY_DAILY
+
+		if len(values) != 1 || values[0] < 1 || values[0] > maximumScheduleRuleInterval {
+			return nil, errs.ErrScheduledTransactionFrequencyInvalid
+		}
+
+		rule.Interval = int(values[0])
+	default:
+		return nil, errs.ErrScheduledTransactionFrequencyInvalid
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"sort"
+)
+
+// TransactionTemplateOccurrenceStatus represents the status of scheduled transaction template occurrence
+type TransactionTemplateOccurrenceStatus byte
+
+// Transaction template occurrence statuses
+const (
+	TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING   TransactionTemplateOccurrenceStatus = 1
+	TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_CONFIRMED TransactionTemplateOccurrenceStatus = 2
+	TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_SKIPPED   TransactionTemplateOccurrenceStatus = 3
+	TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED TransactionTemplateOccurrenceStatus = 4
+)
+
+// TransactionTemplateOccurrence represents a single occurrence of scheduled transaction template stored in database,
+// which is waiting for confirmation, or has been confirmed, skipped or postponed
+type TransactionTemplateOccurrence struct {
+	OccurrenceId    int64                               `xorm:"PK"`
+	Uid             int64                               `xorm:"UNIQUE(UQE_transaction_template_occurrence_uid_template_id_scheduled_time) INDEX(IDX_transaction_template_occurrence_uid_deleted_template_id) NOT NULL"`
+	Deleted         bool                                `xorm:"INDEX(IDX_transaction_template_occurrence_uid_deleted_template_id) INDEX(IDX_transaction_template_occurrence_deleted_status_postponed_time) NOT NULL"`
+	TemplateId      int64                               `xorm:"UNIQUE(UQE_transaction_template_occurrence_uid_template_id_scheduled_time) INDEX(IDX_transaction_template_occurrence_uid_deleted_template_id) NOT NULL"`
+	Status          TransactionTemplateOccurrenceStatus `xorm:"INDEX(IDX_transaction_template_occurrence_deleted_status_postponed_time) NOT NULL"`
+	ScheduledTime   int64                               `xorm:"UNIQUE(UQE_transaction_template_occurrence_uid_template_id_scheduled_time) NOT NULL"`
+	PostponedTime   int64                               `xorm:"INDEX(IDX_transaction_template_occurrence_deleted_status_postponed_time) NOT NULL"`
+	TransactionId   int64                               `xorm:"NOT NULL"`
+	CreatedUnixTime int64
+	UpdatedUnixTime int64
+	DeletedUnixTime int64
+}
+
+// TransactionTemplateOccurrenceListRequest represents all parameters of transaction template occurrence listing request
+type TransactionTemplateOccurrenceListRequest struct {
+	Status TransactionTemplateOccurrenceStatus `form:"status" binding:"min=0,max=4"`
+}
+
+// TransactionTemplateOccurrenceConfirmRequest represents all parameters of pending transaction template occurrence confirmation request
+type TransactionTemplateOccurrenceConfirmRequest struct {
+	Id                int64  `json:"id,string" binding:"required,min=1"`
+	SourceAmount      *int64 `json:"sourceAmount" binding:"omitempty,min=-99999999999,max=99999999999"`
+	DestinationAmount *int64 `json:"destinationAmount" binding:"omitempty,min=-99999999999,max=99999999999"`
+}
+
+// TransactionTemplateOccurrenceSkipRequest represents all parameters of pending transaction template occurrence skipping request
+type TransactionTemplateOccurrenceSkipRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// TransactionTemplateUpcomingOccurrenceSkipRequest represents all parameters of upcoming transaction template occurrence skipping request
+type TransactionTemplateUpcomingOccurrenceSkipRequest struct {
+	TemplateId int64 `json:"templateId,string" binding:"required,min=1"`
+	Time       int64 `json:"time" binding:"required,min=1"`
+}
+
+// TransactionTemplateUpcomingOccurrencePostponeRequest represents all parameters of upcoming transaction template occurrence postponing request
+type TransactionTemplateUpcomingOccurrencePostponeRequest struct {
+	TemplateId    int64 `json:"templateId,string" binding:"required,min=1"`
+	Time          int64 `json:"time" binding:"required,min=1"`
+	PostponedTime int64 `json:"postponedTime" binding:"required,min=1"`
+}
+
+// TransactionTemplateOccurrenceDeleteRequest represents all parameters of transaction template occurrence deleting request
+type TransactionTemplateOccurrenceDeleteRequest struct {
+	Id int64 `json:"id,string" binding:"required,min=1"`
+}
+
+// TransactionTemplateOccurrenceInfoResponse represents a view-object of transaction template occurrence
+type TransactionTemplateOccurrenceInfoResponse struct {
+	Id            int64                                       `json:"id,string"`
+	Status        TransactionTemplateOccurrenceStatus         `json:"status"`
+	ScheduledTime int64                                       `json:"scheduledTime"`
+	PostponedTime int64                                       `json:"postponedTime,omitempty"`
+	TransactionId int64                                       `json:"transactionId,string,omitempty"`
+	Occurrence    *ScheduledTransactionOccurrenceInfoResponse `json:"occurrence"`
+}
+
+// GetTransactionUnixTime returns the unix time of the transaction which is created by this occurrence
+func (o *TransactionTemplateOccurrence) GetTransactionUnixTime() int64 {
+	if o.PostponedTime > 0 {
+		return o.PostponedTime
+	}
+
+	return o.ScheduledTime
+}
+
+// ToTransactionTemplateOccurrenceInfoResponse returns a view-object according to database model
+func (o *TransactionTemplateOccurrence) ToTransactionTemplateOccurrenceInfoResponse(template *TransactionTemplate) *TransactionTemplateOccurrenceInfoResponse {
+	occurrence := &ScheduledTransactionOccurrence{
+		Template:            template,
+		TransactionUnixTime: o.GetTransactionUnixTime(),
+	}
+
+	return &TransactionTemplateOccurrenceInfoResponse{
+		Id:            o.OccurrenceId,
+		Status:        o.Status,
+		ScheduledTime: o.ScheduledTime,
+		PostponedTime: o.PostponedTime,
+		TransactionId: o.TransactionId,
+		Occurrence:    occurrence.ToScheduledTransactionOccurrenceInfoResponse(),
+	}
+}
+
+// ApplyTransactionTemplateOccurrences returns the scheduled transaction occurrences between the start time and the end time (both inclusive)
+// after removing the skipped and postponed ones, adding the postponed ones at their new time, and adding the pending ones at the start time
+func ApplyTransactionTemplateOccurrences(occurrences []*ScheduledTransactionOccurrence, templateOccurrences []*TransactionTemplateOccurrence, templates []*TransactionTemplate, startUnixTime int64, endUnixTime int64) []*ScheduledTransactionOccurrence {
+	templateMap := make(map[int64]*TransactionTemplate, len(templates))
+
+	for i := 0; i < len(templates); i++ {
+		templateMap[templates[i].TemplateId] = templates[i]
+	}
+
+	overriddenOccurrences := make(map[int64]map[int64]bool)
+	result := make([]*ScheduledTransactionOccurrence, 0, len(occurrences))
+
+	for i := 0; i < len(templateOccurrences); i++ {
+		templateOccurrence := templateOccurrences[i]
+		template, exists := templateMap[templateOccurrence.TemplateId]
+
+		if !exists || templateOccurrence.Deleted {
+			continue
+		}
+
+		if _, exists := overriddenOccurrences[templateOccurrence.TemplateId]; !exists {
+			overriddenOccurrences[templateOccurrence.TemplateId] = make(map[int64]bool)
+		}
+
+		overriddenOccurrences[templateOccurrence.TemplateId][templateOccurrence.ScheduledTime] = true
+
+		if templateOccurrence.Status == TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING {
+			result = append(result, &ScheduledTransactionOccurrence{
+				Template:            template,
+				TransactionUnixTime: startUnixTime,
+			})
+		} else if templateOccurrence.Status == TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED &&
+			templateOccurrence.PostponedTime >= startUnixTime && templateOccurrence.PostponedTime <= endUnixTime {
+			result = append(result, &ScheduledTransactionOccurrence{
+				Template:            template,
+				TransactionUnixTime: templateOccurrence.PostponedTime,
+			})
+		}
+	}
+
+	for i := 0; i < len(occurrences); i++ {
+		occurrence := occurrences[i]
+
+		if overriddenOccurrences[occurrence.Template.TemplateId][occurrence.TransactionUnixTime] {
+			continue
+		}
+
+		result = append(result, occurrence)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TransactionUnixTime < result[j].TransactionUnixTime
+	})
+
+	return result
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionTemplateOccurrenceGetTransactionUnixTime(t *testing.T) {
+	occurrence := &TransactionTemplateOccurrence{
+		ScheduledTime: 1000,
+	}
+	assert.Equal(t, int64(1000), occurrence.GetTransactionUnixTime())
+
+	occurrence.PostponedTime = 2000
+	assert.Equal(t, int64(2000), occurrence.GetTransactionUnixTime())
+}
+
+func TestApplyTransactionTemplateOccurrences(t *testing.T) {
+	template1 := &TransactionTemplate{TemplateId: 1}
+	template2 := &TransactionTemplate{TemplateId: 2}
+	templates := []*TransactionTemplate{template1, template2}
+	occurrences := []*ScheduledTransactionOccurrence{
+		{Template: template1, TransactionUnixTime: 200},
+		{Template: template2, TransactionUnixTime: 300},
+		{Template: template1, TransactionUnixTime: 400},
+		{Template: template2, TransactionUnixTime: 500},
+	}
+	templateOccurrences := []*TransactionTemplateOccurrence{
+		{TemplateId: 1, Status: TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING, ScheduledTime: 50},
+		{TemplateId: 1, Status: TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_SKIPPED, ScheduledTime: 200},
+		{TemplateId: 2, Status: TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED, ScheduledTime: 300, PostponedTime: 450},
+		{TemplateId: 2, Status: TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED, ScheduledTime: 500, PostponedTime: 900},
+		{TemplateId: 3, Status: TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_SKIPPED, ScheduledTime: 400},
+	}
+
+	actualOccurrences := ApplyTransactionTemplateOccurrences(occurrences, templateOccurrences, templates, 100, 600)
+	assert.Equal(t, 3, len(actualOccurrences))
+
+	assert.Equal(t, int64(1), actualOccurrences[0].Template.TemplateId)
+	assert.Equal(t, int64(100), actualOccurrences[0].TransactionUnixTime)
+
+	assert.Equal(t, int64(1), actualOccurrences[1].Template.TemplateId)
+	assert.Equal(t, int64(400), actualOccurrences[1].TransactionUnixTime)
+
+	assert.Equal(t, int64(2), actualOccurrences[2].Template.TemplateId)
+	assert.Equal(t, int64(450), actualOccurrences[2].TransactionUnixTime)
+}
@@ -3,8 +3,11 @@ package models
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
 )
 
 func TestTransactionTemplateGetTagIds(t *testing.T) {
@@ -43,3 +46,96 @@ func TestTransactionTemplateInfoResponseSliceLess(t *testing.T) {
 	assert.Equal(t, int64(3), transactionTemplateRespSlice[1].Id)
 	assert.Equal(t, int64(1), transactionTemplateRespSlice[2].Id)
 }
+
+func TestGetTransactionScheduleRule_LegacyFrequencies(t *testing.T) {
+	rule, err := GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "0,6")
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=SU,SA", rule.String())
+
+	rule, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "1,15,-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=1,15,-1", rule.String())
+
+	rule, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "2")
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=DAILY;INTERVAL=2", rule.String())
+
+	rule, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_RULE, "FREQ=MONTHLY;INTERVAL=3")
+	assert.Nil(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;INTERVAL=3", rule.String())
+}
+
+func TestGetTransactionScheduleRule_InvalidFrequencies(t *testing.T) {
+	_, err := GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, "7")
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+
+	_, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "0")
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+
+	_, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, "32")
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+
+	_, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "1,2")
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+
+	_, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY, "")
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+
+	_, err = GetTransactionScheduleRule(TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, "1")
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+}
+
+func TestTransactionTemplateIsScheduledOn(t *testing.T) {
+	startTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.FixedZone("", 8*60*60)).Unix()
+	template := &TransactionTemplate{
+		ScheduledFrequencyType:     TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DAILY,
+		ScheduledFrequency:         "2",
+		ScheduledStartTime:         &startTime,
+		ScheduledTimezoneUtcOffset: 480,
+	}
+
+	scheduled, err := template.IsScheduledOn(time.Date(2024, time.January, 2, 16, 0, 0, 0, time.UTC)) // 2024-01-03 00:00 in UTC+8
+	assert.Nil(t, err)
+	assert.True(t, scheduled)
+
+	scheduled, err = template.IsScheduledOn(time.Date(2024, time.January, 3, 16, 0, 0, 0, time.UTC)) // 2024-01-04 00:00 in UTC+8
+	assert.Nil(t, err)
+	assert.False(t, scheduled)
+
+	template.ScheduledFrequency = ""
+	_, err = template.IsScheduledOn(time.Date(2024, time.January, 3, 16, 0, 0, 0, time.UTC))
+	assert.Equal(t, errs.ErrScheduledTransactionFrequencyInvalid, err)
+}
+
+func TestTransactionTemplateToScheduledTransaction(t *testing.T) {
+	template := &TransactionTemplate{
+		Uid:                        1,
+		Type:                       TRANSACTION_TYPE_TRANSFER,
+		CategoryId:                 2,
+		AccountId:                  3,
+		Amount:                     100,
+		RelatedAccountId:           4,
+		RelatedAccountAmount:       200,
+		ScheduledTimezoneUtcOffset: 480,
+	}
+
+	transaction, err := template.ToScheduledTransaction(1704067200)
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_DB_TYPE_TRANSFER_OUT, transaction.Type)
+	assert.Equal(t, int64(1704067200000), transaction.TransactionTime)
+	assert.Equal(t, int16(480), transaction.TimezoneUtcOffset)
+	assert.Equal(t, int64(100), transaction.Amount)
+	assert.Equal(t, int64(4), transaction.RelatedAccountId)
+	assert.Equal(t, int64(200), transaction.RelatedAccountAmount)
+	assert.True(t, transaction.ScheduledCreated)
+
+	template.Type = TRANSACTION_TYPE_EXPENSE
+	transaction, err = template.ToScheduledTransaction(1704067200)
+	assert.Nil(t, err)
+	assert.Equal(t, TRANSACTION_DB_TYPE_EXPENSE, transaction.Type)
+	assert.Equal(t, int64(0), transaction.RelatedAccountId)
+
+	template.Type = TRANSACTION_TYPE_MODIFY_BALANCE
+	_, err = template.ToScheduledTransaction(1704067200)
+	assert.Equal(t, errs.ErrTransactionTypeInvalid, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 func TestParseTransactionTagFilter_EmptyTagFilter(t *testing.T) {
@@ -259,6 +260,99 @@ func TestTransactionStatisticTrendsResponseItemSliceLess(t *testing.T) {
 	assert.Equal(t, int32(9), transactionTrendsSlice[4].Month)
 }
 
+func TestTransactionItemStatisticResponseItemSliceLess(t *testing.T) {
+	var itemStatisticSlice TransactionItemStatisticResponseItemSlice
+	itemStatisticSlice = append(itemStatisticSlice, &TransactionItemStatisticResponseItem{
+		ItemId:   2,
+		Currency: "CNY",
+	})
+	itemStatisticSlice = append(itemStatisticSlice, &TransactionItemStatisticResponseItem{
+		ItemId:   1,
+		Currency: "USD",
+	})
+	itemStatisticSlice = append(itemStatisticSlice, &TransactionItemStatisticResponseItem{
+		ItemId:   1,
+		Currency: "CNY",
+		Unit:     "kg",
+	})
+	itemStatisticSlice = append(itemStatisticSlice, &TransactionItemStatisticResponseItem{
+		ItemId:   1,
+		Currency: "CNY",
+		Unit:     "g",
+	})
+
+	sort.Sort(itemStatisticSlice)
+
+	assert.Equal(t, int64(1), itemStatisticSlice[0].ItemId)
+	assert.Equal(t, "CNY", itemStatisticSlice[0].Currency)
+	assert.Equal(t, "g", itemStatisticSlice[0].Unit)
+	assert.Equal(t, int64(1), itemStatisticSlice[1].ItemId)
+	assert.Equal(t, "CNY", itemStatisticSlice[1].Currency)
+	assert.Equal(t, "kg", itemStatisticSlice[1].Unit)
+	assert.Equal(t, int64(1), itemStatisticSlice[2].ItemId)
+	assert.Equal(t, "USD", itemStatisticSlice[2].Currency)
+	assert.Equal(t, int64(2), itemStatisticSlice[3].ItemId)
+	assert.Equal(t, "CNY", itemStatisticSlice[3].Currency)
+}
+
+func TestTransactionItemStatisticTrendsResponseItemSliceLess(t *testing.T) {
+	var itemTrendsSlice TransactionItemStatisticTrendsResponseItemSlice
+	itemTrendsSlice = append(itemTrendsSlice, &TransactionItemStatisticTrendsResponseItem{
+		Year:  2024,
+		Month: 9,
+	})
+	itemTrendsSlice = append(itemTrendsSlice, &TransactionItemStatisticTrendsResponseItem{
+		Year:  2023,
+		Month: 11,
+	})
+	itemTrendsSlice = append(itemTrendsSlice, &TransactionItemStatisticTrendsResponseItem{
+		Year:  2024,
+		Month: 1,
+	})
+
+	sort.Sort(itemTrendsSlice)
+
+	assert.Equal(t, int32(2023), itemTrendsSlice[0].Year)
+	assert.Equal(t, int32(11), itemTrendsSlice[0].Month)
+	assert.Equal(t, int32(2024), itemTrendsSlice[1].Year)
+	assert.Equal(t, int32(1), itemTrendsSlice[1].Month)
+	assert.Equal(t, int32(2024), itemTrendsSlice[2].Year)
+	assert.Equal(t, int32(9), itemTrendsSlice[2].Month)
+}
+
+func TestTransactionPayeeStatisticResponseItemSliceLess(t *testing.T) {
+	var payeeStatisticSlice TransactionPayeeStatisticResponseItemSlice
+	payeeStatisticSlice = append(payeeStatisticSlice, &TransactionPayeeStatisticResponseItem{
+		PayeeId:            2,
+		Currency:           "CNY",
+		TotalExpenseAmount: 100,
+	})
+	payeeStatisticSlice = append(payeeStatisticSlice, &TransactionPayeeStatisticResponseItem{
+		PayeeId:            1,
+		Currency:           "USD",
+		TotalExpenseAmount: 100,
+	})
+	payeeStatisticSlice = append(payeeStatisticSlice, &TransactionPayeeStatisticResponseItem{
+		PayeeId:            3,
+		Currency:           "CNY",
+		TotalExpenseAmount: 200,
+	})
+	payeeStatisticSlice = append(payeeStatisticSlice, &TransactionPayeeStatisticResponseItem{
+		PayeeId:            1,
+		Currency:           "CNY",
+		TotalExpenseAmount: 100,
+	})
+
+	sort.Sort(payeeStatisticSlice)
+
+	assert.Equal(t, int64(3), payeeStatisticSlice[0].PayeeId)
+	assert.Equal(t, int64(1), payeeStatisticSlice[1].PayeeId)
+	assert.Equal(t, "CNY", payeeStatisticSlice[1].Currency)
+	assert.Equal(t, int64(1), payeeStatisticSlice[2].PayeeId)
+	assert.Equal(t, "USD", payeeStatisticSlice[2].Currency)
+	assert.Equal(t, int64(2), payeeStatisticSlice[3].PayeeId)
+}
+
 func TestTransactionStatisticAssetTrendsResponseItemSliceLess(t *testing.T) {
 	var transactionTrendsSlice TransactionStatisticAssetTrendsResponseItemSlice
 	transactionTrendsSlice = append(transactionTrendsSlice, &TransactionStatisticAssetTrendsResponseItem{
@@ -332,3 +426,85 @@ func TestTransactionAmountsResponseItemAmountInfoSliceLess(t *testing.T) {
 	assert.Equal(t, "EUR", amountInfoSlice[1].Currency)
 	assert.Equal(t, "USD", amountInfoSlice[2].Currency)
 }
+
+func TestTransactionIsCreatedByOtherMember(t *testing.T) {
+	transaction := &Transaction{Uid: 1}
+	assert.False(t, transaction.IsCreatedByOtherMember())
+
+	transaction.CreatedByUid = 1
+	assert.False(t, transaction.IsCreatedByOtherMember())
+
+	transaction.CreatedByUid = 2
+	assert.True(t, transaction.IsCreatedByOtherMember())
+}
+
+func TestTransactionGetAccountBalanceChange(t *testing.T) {
+	assert.Equal(t, int64(500), (&Transaction{Type: TRANSACTION_DB_TYPE_MODIFY_BALANCE, Amount: 1000, RelatedAccountAmount: 500}).GetAccountBalanceChange())
+	assert.Equal(t, int64(1000), (&Transaction{Type: TRANSACTION_DB_TYPE_INCOME, Amount: 1000}).GetAccountBalanceChange())
+	assert.Equal(t, int64(-1000), (&Transaction{Type: TRANSACTION_DB_TYPE_EXPENSE, Amount: 1000}).GetAccountBalanceChange())
+	assert.Equal(t, int64(-1000), (&Transaction{Type: TRANSACTION_DB_TYPE_TRANSFER_OUT, Amount: 1000, RelatedAccountAmount: 900}).GetAccountBalanceChange())
+	assert.Equal(t, int64(900), (&Transaction{Type: TRANSACTION_DB_TYPE_TRANSFER_IN, Amount: 900, RelatedAccountAmount: 1000}).GetAccountBalanceChange())
+}
+
+func TestTransactionIsClearedAndIsReconciled(t *testing.T) {
+	transaction := &Transaction{Type: TRANSACTION_DB_TYPE_EXPENSE}
+	assert.False(t, transaction.IsCleared())
+	assert.False(t, transaction.IsReconciled())
+
+	transaction.ClearedStatus = TRANSACTION_CLEARED_STATUS_CLEARED
+	assert.True(t, transaction.IsCleared())
+	assert.False(t, transaction.IsReconciled())
+
+	transaction.ClearedStatus = TRANSACTION_CLEARED_STATUS_RECONCILED
+	assert.True(t, transaction.IsCleared())
+	assert.True(t, transaction.IsReconciled())
+
+	transaction = &Transaction{Type: TRANSACTION_DB_TYPE_MODIFY_BALANCE}
+	assert.True(t, transaction.IsCleared())
+	assert.False(t, transaction.IsReconciled())
+}
+
+func TestTransactionIsLocked(t *testing.T) {
+	transaction := &Transaction{TransactionTime: utils.GetMinTransactionTimeFromUnixTime(1000)}
+	assert.False(t, transaction.IsLocked(0))
+	assert.False(t, transaction.IsLocked(999))
+	assert.True(t, transaction.IsLocked(1000))
+	assert.True(t, transaction.IsLocked(2000))
+
+	accountLockTime := int64(1500)
+	lockedAccount := &Account{Extend: &AccountExtend{TransactionLockTime: &accountLockTime}}
+	unlockedAccount := &Account{}
+	assert.True(t, transaction.IsLocked(0, unlockedAccount, lockedAccount))
+	assert.True(t, transaction.IsLocked(0, lockedAccount, nil))
+	assert.False(t, transaction.IsLocked(0, unlockedAccount, nil))
+}
+
+func TestGetTransactionLockTime(t *testing.T) {
+	accountLockTime := int64(1500)
+	lockedAccount := &Account{Extend: &AccountExtend{TransactionLockTime: &accountLockTime}}
+
+	assert.Equal(t, int64(0), GetTransactionLockTime(0))
+	assert.Equal(t, int64(0), GetTransactionLockTime(-1, nil))
+	assert.Equal(t, int64(1000), GetTransactionLockTime(1000, &Account{}))
+	assert.Equal(t, int64(1500), GetTransactionLockTime(1000, lockedAccount))
+	assert.Equal(t, int64(2000), GetTransactionLockTime(2000, lockedAccount))
+}
+
+func TestTransactionTagFilterIsMatched(t *testing.T) {
+	tagIds := map[int64]bool{1: true, 2: true}
+
+	assert.True(t, (&TransactionTagFilter{TagIds: []int64{2, 3}, Type: TRANSACTION_TAG_FILTER_HAS_ANY}).IsMatched(tagIds))
+	assert.False(t, (&TransactionTagFilter{TagIds: []int64{3, 4}, Type: TRANSACTION_TAG_FILTER_HAS_ANY}).IsMatched(tagIds))
+
+	assert.True(t, (&TransactionTagFilter{TagIds: []int64{1, 2, 2}, Type: TRANSACTION_TAG_FILTER_HAS_ALL}).IsMatched(tagIds))
+	assert.False(t, (&TransactionTagFilter{TagIds: []int64{1, 3}, Type: TRANSACTION_TAG_FILTER_HAS_ALL}).IsMatched(tagIds))
+
+	assert.True(t, (&TransactionTagFilter{TagIds: []int64{3, 4}, Type: TRANSACTION_TAG_FILTER_NOT_HAS_ANY}).IsMatched(tagIds))
+	assert.False(t, (&TransactionTagFilter{TagIds: []int64{2, 3}, Type: TRANSACTION_TAG_FILTER_NOT_HAS_ANY}).IsMatched(tagIds))
+
+	assert.True(t, (&TransactionTagFilter{TagIds: []int64{1, 3}, Type: TRANSACTION_TAG_FILTER_NOT_HAS_ALL}).IsMatched(tagIds))
+	assert.False(t, (&TransactionTagFilter{TagIds: []int64{1, 2}, Type: TRANSACTION_TAG_FILTER_NOT_HAS_ALL}).IsMatched(tagIds))
+
+	assert.False(t, (&TransactionTagFilter{TagIds: []int64{1}, Type: TRANSACTION_TAG_FILTER_HAS_ANY}).IsMatched(map[int64]bool{}))
+	assert.True(t, (&TransactionTagFilter{TagIds: []int64{1}, Type: TRANSACTION_TAG_FILTER_NOT_HAS_ALL}).IsMatched(nil))
+}
@@ -0,0 +1,148 @@
+package models
+
+import "github.com/mayswind/ezbookkeeping/pkg/utils"
+
+// TrashItemType represents the type of deleted data in recycle bin
+type TrashItemType byte
+
+// Trash item types
+const (
+	TRASH_ITEM_TYPE_TRANSACTION TrashItemType = 1
+	TRASH_ITEM_TYPE_ACCOUNT     TrashItemType = 2
+	TRASH_ITEM_TYPE_CATEGORY    TrashItemType = 3
+	TRASH_ITEM_TYPE_TAG         TrashItemType = 4
+	TRASH_ITEM_TYPE_ITEM        TrashItemType = 5
+	TRASH_ITEM_TYPE_TEMPLATE    TrashItemType = 6
+)
+
+// TrashItemListRequest represents all parameters of recycle bin listing request
+type TrashItemListRequest struct {
+	Type TrashItemType `form:"type" binding:"required,min=1,max=6"`
+}
+
+// TrashItemRestoreRequest represents all parameters of recycle bin item restoring request
+type TrashItemRestoreRequest struct {
+	Type TrashItemType `json:"type" binding:"required,min=1,max=6"`
+	Id   int64         `json:"id,string" binding:"required,min=1"`
+}
+
+// TrashItemPurgeRequest represents all parameters of recycle bin item purging request
+type TrashItemPurgeRequest struct {
+	Type TrashItemType `json:"type" binding:"required,min=1,max=6"`
+	Id   int64         `json:"id,string" binding:"required,min=1"`
+}
+
+// TrashItemInfoResponse represents a view-object of deleted data in recycle bin
+type TrashItemInfoResponse struct {
+	Id              int64           `json:"id,string"`
+	Type            TrashItemType   `json:"type"`
+	Name            string          `json:"name"`
+	ParentId        int64           `json:"parentId,string,omitempty"`
+	TransactionType TransactionType `json:"transactionType,omitempty"`
+	CategoryId      int64           `json:"categoryId,string,omitempty"`
+	AccountId       int64           `json:"accountId,string,omitempty"`
+	Amount          int64           `json:"amount,omitempty"`
+	Time            int64           `json:"time,omitempty"`
+	DeletedAt       int64           `json:"deletedAt"`
+}
+
+// ToTrashItemInfoResponse returns a recycle bin view-object according to database model
+func (t *Transaction) ToTrashItemInfoResponse() *TrashItemInfoResponse {
+	transactionType, _ := t.Type.ToTransactionType()
+
+	return &TrashItemInfoResponse{
+		Id:              t.TransactionId,
+		Type:            TRASH_ITEM_TYPE_TRANSACTION,
+		Name:            t.Comment,
+		TransactionType: transactionType,
+		CategoryId:      t.CategoryId,
+		AccountId:       t.AccountId,
+		Amount:          t.Amount,
+		Time:            utils.GetUnixTimeFromTransactionTime(t.TransactionTime),
+		DeletedAt:       t.DeletedUnixTime,
+	}
+}
+
+// ToTrashItemInfoResponse returns a recycle bin view-object according to database model
+func (a *Account) ToTrashItemInfoResponse() *TrashItemInfoResponse {
+	response := &TrashItemInfoResponse{
+		Id:        a.AccountId,
+		Type:      TRASH_ITEM_TYPE_ACCOUNT,
+		Name:      a.Name,
+		DeletedAt: a.DeletedUnixTime,
+	}
+
+	if a.ParentAccountId != LevelOneAccountParentId {
+		response.ParentId = a.ParentAccountId
+	}
+
+	return response
+}
+
+// ToTrashItemInfoResponse returns a recycle bin view-object according to database model
+func (c *TransactionCategory) ToTrashItemInfoResponse() *TrashItemInfoResponse {
+	response := &TrashItemInfoResponse{
+		Id:        c.CategoryId,
+		Type:      TRASH_ITEM_TYPE_CATEGORY,
+		Name:      c.Name,
+		DeletedAt: c.DeletedUnixTime,
+	}
+
+	if c.ParentCategoryId != LevelOneTransactionCategoryParentId {
+		response.ParentId = c.ParentCategoryId
+	}
+
+	return response
+}
+
+// ToTrashItemInfoResponse returns a recycle bin view-object according to database model
+func (t *TransactionTag) ToTrashItemInfoResponse() *TrashItemInfoResponse {
+	return &TrashItemInfoResponse{
+		Id:        t.TagId,
+		Type:      TRASH_ITEM_TYPE_TAG,
+		Name:      t.Name,
+		DeletedAt: t.DeletedUnixTime,
+	}
+}
+
+// ToTrashItemInfoResponse returns a recycle bin view-object according to database model
+func (t *TransactionItem) ToTrashItemInfoResponse() *TrashItemInfoResponse {
+	return &TrashItemInfoResponse{
+		Id:        t.ItemId,
+		Type:      TRASH_ITEM_TYPE_ITEM,
+		Name:      t.Name,
+		DeletedAt: t.DeletedUnixTime,
+	}
+}
+
+// ToTrashItemInfoResponse returns a recycle bin view-object according to database model
+func (t *TransactionTemplate) ToTrashItemInfoResponse() *TrashItemInfoResponse {
+	return &TrashItemInfoResponse{
+		Id:        t.TemplateId,
+		Type:      TRASH_ITEM_TYPE_TEMPLATE,
+		Name:      t.Name,
+		DeletedAt: t.DeletedUnixTime,
+	}
+}
+
+// TrashItemInfoResponseSlice represents the slice data structure of TrashItemInfoResponse
+type TrashItemInfoResponseSlice []*TrashItemInfoResponse
+
+// Len returns the count of items
+func (s TrashItemInfoResponseSlice) Len() int {
+	return len(s)
+}
+
+// Swap swaps two items
+func (s TrashItemInfoResponseSlice) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// Less reports whether the first item is less than the second one
+func (s TrashItemInfoResponseSlice) Less(i, j int) bool {
+	if s[i].DeletedAt != s[j].DeletedAt {
+		return s[i].DeletedAt > s[j].DeletedAt
+	}
+
+	return s[i].Id > s[j].Id
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionToTrashItemInfoResponse(t *testing.T) {
+	transaction := &Transaction{
+		TransactionId:   1,
+		Type:            TRANSACTION_DB_TYPE_TRANSFER_OUT,
+		CategoryId:      2,
+		AccountId:       3,
+		Amount:          100,
+		Comment:         "test",
+		DeletedUnixTime: 1700000000,
+	}
+
+	response := transaction.ToTrashItemInfoResponse()
+	assert.Equal(t, int64(1), response.Id)
+	assert.Equal(t, TRASH_ITEM_TYPE_TRANSACTION, response.Type)
+	assert.Equal(t, "test", response.Name)
+	assert.Equal(t, TRANSACTION_TYPE_TRANSFER, response.TransactionType)
+	assert.Equal(t, int64(2), response.CategoryId)
+	assert.Equal(t, int64(3), response.AccountId)
+	assert.Equal(t, int64(100), response.Amount)
+	assert.Equal(t, int64(1700000000), response.DeletedAt)
+}
+
+func TestAccountToTrashItemInfoResponse(t *testing.T) {
+	account := &Account{
+		AccountId:       1,
+		ParentAccountId: LevelOneAccountParentId,
+		Name:            "test",
+	}
+
+	response := account.ToTrashItemInfoResponse()
+	assert.Equal(t, TRASH_ITEM_TYPE_ACCOUNT, response.Type)
+	assert.Equal(t, int64(0), response.ParentId)
+
+	account.ParentAccountId = 2
+	response = account.ToTrashItemInfoResponse()
+	assert.Equal(t, int64(2), response.ParentId)
+}
+
+func TestTransactionCategoryToTrashItemInfoResponse(t *testing.T) {
+	category := &TransactionCategory{
+		CategoryId:       1,
+		ParentCategoryId: LevelOneTransactionCategoryParentId,
+		Name:             "test",
+	}
+
+	response := category.ToTrashItemInfoResponse()
+	assert.Equal(t, TRASH_ITEM_TYPE_CATEGORY, response.Type)
+	assert.Equal(t, int64(0), response.ParentId)
+
+	category.ParentCategoryId = 2
+	response = category.ToTrashItemInfoResponse()
+	assert.Equal(t, int64(2), response.ParentId)
+}
+
+func TestTrashItemInfoResponseSliceLess(t *testing.T) {
+	var trashItems TrashItemInfoResponseSlice
+	trashItems = append(trashItems, &TrashItemInfoResponse{Id: 1, DeletedAt: 100})
+	trashItems = append(trashItems, &TrashItemInfoResponse{Id: 2, DeletedAt: 200})
+	trashItems = append(trashItems, &TrashItemInfoResponse{Id: 3, DeletedAt: 100})
+	trashItems = append(trashItems, &TrashItemInfoResponse{Id: 4, DeletedAt: 300})
+
+	sort.Sort(trashItems)
+
+	assert.Equal(t, int64(4), trashItems[0].Id)
+	assert.Equal(t, int64(2), trashItems[1].Id)
+	assert.Equal(t, int64(3), trashItems[2].Id)
+	assert.Equal(t, int64(1), trashItems[3].Id)
+}
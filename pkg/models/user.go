@@ -90,6 +90,7 @@ type User struct {
 	Salt                  string `xorm:"VARCHAR(10) NOT NULL"`
 	CustomAvatarType      string `xorm:"VARCHAR(10)"`
 	DefaultAccountId      int64
+	TransactionLockTime   int64
 	TransactionEditScope  TransactionEditScope       `xorm:"TINYINT NOT NULL"`
 	Language              string                     `xorm:"VARCHAR(10)"`
 	DefaultCurrency       string                     `xorm:"VARCHAR(3) NOT NULL"`
@@ -129,6 +130,7 @@ type UserBasicInfo struct {
 	AvatarProvider        string                     `json:"avatarProvider,omitempty"`
 	DefaultAccountId      int64                      `json:"defaultAccountId,string"`
 	TransactionEditScope  TransactionEditScope       `json:"transactionEditScope"`
+	TransactionLockTime   int64                      `json:"transactionLockTime"`
 	Language              string                     `json:"language"`
 	DefaultCurrency       string                     `json:"defaultCurrency"`
 	FirstDayOfWeek        core.WeekDay               `json:"firstDayOfWeek"`
@@ -195,6 +197,7 @@ type UserProfileUpdateRequest struct {
 	OldPassword           string                      `json:"oldPassword" binding:"omitempty,min=6,max=128"`
 	DefaultAccountId      int64                       `json:"defaultAccountId,string" binding:"omitempty,min=1"`
 	TransactionEditScope  *TransactionEditScope       `json:"transactionEditScope" binding:"omitempty,min=0,max=6"`
+	TransactionLockTime   *int64                      `json:"transactionLockTime" binding:"omitempty,min=0"`
 	Language              string                      `json:"language" binding:"omitempty,min=2,max=16"`
 	DefaultCurrency       string                      `json:"defaultCurrency" binding:"omitempty,len=3,validCurrency"`
 	FirstDayOfWeek        *core.WeekDay               `json:"firstDayOfWeek" binding:"omitempty,min=0,max=6"`
@@ -286,6 +289,7 @@ func (u *User) ToUserBasicInfo(avatarProvider core.UserAvatarProviderType, avata
 		AvatarProvider:        string(avatarProvider),
 		DefaultAccountId:      u.DefaultAccountId,
 		TransactionEditScope:  u.TransactionEditScope,
+		TransactionLockTime:   u.TransactionLockTime,
 		Language:              u.Language,
 		DefaultCurrency:       u.DefaultCurrency,
 		FirstDayOfWeek:        u.FirstDayOfWeek,
@@ -14,13 +14,16 @@ import (
 // PaddleBillOCRRawItem represents one raw recognized transaction item returned by PaddleOCR HTTP service.
 // It matches the JSON structure provided by the external Paddle service.
 type PaddleBillOCRRawItem struct {
-	Amount   string `json:"amount"`
-	Classify string `json:"classify"`
-	Account  string `json:"account"`
-	Date     string `json:"date"`
-	Project  string `json:"project"`
-	Label    string `json:"label"`
-	Text     string `json:"text"`
+	Amount    string `json:"amount"`
+	Classify  string `json:"classify"`
+	Account   string `json:"account"`
+	Date      string `json:"date"`
+	Project   string `json:"project"`
+	Quantity  string `json:"quantity"`
+	Unit      string `json:"unit"`
+	UnitPrice string `json:"unit_price"`
+	Label     string `json:"label"`
+	Text      string `json:"text"`
 }
 
 // PaddleBillOCRResponse represents the response body of the PaddleOCR HTTP service.
@@ -35,6 +38,9 @@ type PaddleBillOCRRawItem struct {
 //	      "account": "微信",
 //	      "date": "2026-02-11 22:31:24",
 //	      "project": "项目1",
+//	      "quantity": "2",
+//	      "unit": "kg",
+//	      "unit_price": "50.00",
 //	      "label": "标签1",
 //	      "text": "2月7日 21:49 京东超市 -100.00"
 //	    }
@@ -0,0 +1,77 @@
+package securityprices
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/httpclient"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// HttpSecurityPricesDataSource defines the structure of http security prices data source
+type HttpSecurityPricesDataSource interface {
+	// BuildRequest returns the http request of the security
+	BuildRequest(ticker string) (*http.Request, error)
+
+	// Parse returns the security price according to the data source raw response, the security id and uid of the price are not set
+	Parse(c core.Context, content []byte) (*models.SecurityPrice, error)
+}
+
+// CommonHttpSecurityPricesDataProvider defines the structure of common http security prices data provider
+type CommonHttpSecurityPricesDataProvider struct {
+	SecurityPricesDataProvider
+	dataSource HttpSecurityPricesDataSource
+	httpClient *http.Client
+}
+
+// GetLatestSecurityPrice returns the latest price of the security from the http data source
+func (e *CommonHttpSecurityPricesDataProvider) GetLatestSecurityPrice(c core.Context, uid int64, security *models.Security) (*models.SecurityPrice, error) {
+	req, err := e.dataSource.BuildRequest(security.Ticker)
+
+	if err != nil {
+		log.Errorf(c, "[common_http_security_prices_data_provider.GetLatestSecurityPrice] failed to build request of security \"id:%d\" for user \"uid:%d\", because %s", security.SecurityId, uid, err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	req = req.WithContext(httpclient.CustomHttpResponseLog(c, func(data []byte) {
+		log.Debugf(c, "[common_http_security_prices_data_provider.GetLatestSecurityPrice] response is %s", data)
+	}))
+
+	resp, err := e.httpClient.Do(req)
+
+	if err != nil {
+		log.Errorf(c, "[common_http_security_prices_data_provider.GetLatestSecurityPrice] failed to request latest price of security \"id:%d\" for user \"uid:%d\", because %s", security.SecurityId, uid, err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != 200 {
+		log.Errorf(c, "[common_http_security_prices_data_provider.GetLatestSecurityPrice] failed to get latest price response of security \"id:%d\" for user \"uid:%d\", because response code is %d", security.SecurityId, uid, resp.StatusCode)
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	price, err := e.dataSource.Parse(c, body)
+
+	if err != nil {
+		log.Errorf(c, "[common_http_security_prices_data_provider.GetLatestSecurityPrice] failed to parse response of security \"id:%d\" for user \"uid:%d\", because %s", security.SecurityId, uid, err.Error())
+		return nil, errs.Or(err, errs.ErrFailedToRequestRemoteApi)
+	}
+
+	price.Uid = uid
+	price.SecurityId = security.SecurityId
+
+	return price, nil
+}
+
+func newCommonHttpSecurityPricesDataProvider(config *settings.Config, dataSource HttpSecurityPricesDataSource) *CommonHttpSecurityPricesDataProvider {
+	return &CommonHttpSecurityPricesDataProvider{
+		dataSource: dataSource,
+		httpClient: httpclient.NewHttpClient(config.SecurityPricesRequestTimeout, config.SecurityPricesProxy, config.SecurityPricesSkipTLSVerify, settings.GetUserAgent(), config.EnableDebugLog),
+	}
+}
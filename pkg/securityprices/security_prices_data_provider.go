@@ -0,0 +1,12 @@
+package securityprices
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// SecurityPricesDataProvider defines the structure of security prices data provider
+type SecurityPricesDataProvider interface {
+	// GetLatestSecurityPrice returns the latest price of the security
+	GetLatestSecurityPrice(c core.Context, uid int64, security *models.Security) (*models.SecurityPrice, error)
+}
@@ -0,0 +1,45 @@
+package securityprices
+
+import (
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/settings"
+)
+
+// SecurityPricesDataProviderContainer contains the current security prices data provider
+type SecurityPricesDataProviderContainer struct {
+	current SecurityPricesDataProvider
+}
+
+// Initialize a security prices data provider container singleton instance
+var (
+	Container = &SecurityPricesDataProviderContainer{}
+)
+
+// InitializeSecurityPricesDataSource initializes the current security prices data source according to the config
+func InitializeSecurityPricesDataSource(config *settings.Config) error {
+	if config.SecurityPricesDataSource == "" {
+		Container.current = nil
+		return nil
+	} else if config.SecurityPricesDataSource == settings.StooqSecurityPricesDataSource {
+		Container.current = newCommonHttpSecurityPricesDataProvider(config, &StooqDataSource{})
+		return nil
+	}
+
+	return errs.ErrInvalidSecurityPricesDataSource
+}
+
+// IsEnabled returns whether a security prices data source is enabled
+func (e *SecurityPricesDataProviderContainer) IsEnabled() bool {
+	return e.current != nil
+}
+
+// GetLatestSecurityPrice returns the latest price of the security from the current security prices data source
+func (e *SecurityPricesDataProviderContainer) GetLatestSecurityPrice(c core.Context, uid int64, security *models.Security) (*models.SecurityPrice, error) {
+	if e.current == nil {
+		return nil, errs.ErrSecurityPricesDataSourceNotEnabled
+	}
+
+	return e.current.GetLatestSecurityPrice(c, uid, security)
+}
@@ -0,0 +1,105 @@
+package securityprices
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+const stooqSecurityPriceUrl = "https://stooq.com/q/l/?f=sd2t2ohlcv&h&e=csv&s="
+const stooqDataSource = "stooq"
+
+const stooqDataNotAvailableValue = "N/D"
+const stooqDataDateFormat = "2006-01-02"
+
+// StooqDataSource defines the structure of security prices data source of stooq
+type StooqDataSource struct {
+	HttpSecurityPricesDataSource
+}
+
+// BuildRequest returns the stooq security price http request
+func (e *StooqDataSource) BuildRequest(ticker string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", stooqSecurityPriceUrl+url.QueryEscape(strings.ToLower(ticker)), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// Parse returns the security price according to the stooq data source raw response,
+// the price time is the beginning of the trading date in UTC and the price is the close price of that date
+func (e *StooqDataSource) Parse(c core.Context, content []byte) (*models.SecurityPrice, error) {
+	csvReader := csv.NewReader(bytes.NewReader(content))
+	allLines, err := csvReader.ReadAll()
+
+	if err != nil {
+		log.Errorf(c, "[stooq_datasource.Parse] failed to parse csv data, content is %s, because %s", string(content), err.Error())
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	if len(allLines) < 2 {
+		log.Errorf(c, "[stooq_datasource.Parse] csv data has no price line, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	header := allLines[0]
+	dateColumnIndex := -1
+	closeColumnIndex := -1
+
+	for i := 0; i < len(header); i++ {
+		if header[i] == "Date" {
+			dateColumnIndex = i
+		} else if header[i] == "Close" {
+			closeColumnIndex = i
+		}
+	}
+
+	if dateColumnIndex < 0 || closeColumnIndex < 0 {
+		log.Errorf(c, "[stooq_datasource.Parse] missing date or close column, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	line := allLines[1]
+
+	if len(line) <= dateColumnIndex || len(line) <= closeColumnIndex {
+		log.Errorf(c, "[stooq_datasource.Parse] price line is incomplete, content is %s", string(content))
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	if line[dateColumnIndex] == stooqDataNotAvailableValue || line[closeColumnIndex] == stooqDataNotAvailableValue {
+		log.Warnf(c, "[stooq_datasource.Parse] price of the security is not available, content is %s", string(content))
+		return nil, errs.ErrSecurityPriceNotFound
+	}
+
+	priceDate, err := time.Parse(stooqDataDateFormat, line[dateColumnIndex])
+
+	if err != nil {
+		log.Errorf(c, "[stooq_datasource.Parse] failed to parse date, date is %s", line[dateColumnIndex])
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	closePrice, err := utils.StringToFloat64(line[closeColumnIndex])
+
+	if err != nil || closePrice <= 0 {
+		log.Errorf(c, "[stooq_datasource.Parse] close price is invalid, price is %s", line[closeColumnIndex])
+		return nil, errs.ErrFailedToRequestRemoteApi
+	}
+
+	return &models.SecurityPrice{
+		PriceTime: priceDate.Unix(),
+		Price:     int64(math.Round(closePrice * 100)),
+		Source:    stooqDataSource,
+	}, nil
+}
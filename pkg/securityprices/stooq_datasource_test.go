@@ -0,0 +1,57 @@
+package securityprices
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+const stooqMinimumRequiredContent = "" +
+	"Symbol,Date,Time,Open,High,Low,Close,Volume\r\n" +
+	"AAPL.US,2024-11-08,22:00:09,227.17,228.66,226.405,226.965,38328824\r\n"
+
+func TestStooqDataSource_StandardDataExtractPriceTime(t *testing.T) {
+	dataSource := &StooqDataSource{}
+	context := core.NewNullContext()
+
+	actualPrice, err := dataSource.Parse(context, []byte(stooqMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(1731024000), actualPrice.PriceTime)
+}
+
+func TestStooqDataSource_StandardDataExtractClosePrice(t *testing.T) {
+	dataSource := &StooqDataSource{}
+	context := core.NewNullContext()
+
+	actualPrice, err := dataSource.Parse(context, []byte(stooqMinimumRequiredContent))
+	assert.Equal(t, nil, err)
+	assert.Equal(t, int64(22697), actualPrice.Price)
+	assert.Equal(t, "stooq", actualPrice.Source)
+}
+
+func TestStooqDataSource_PriceNotAvailable(t *testing.T) {
+	dataSource := &StooqDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("Symbol,Date,Time,Open,High,Low,Close,Volume\r\nUNKNOWN,N/D,N/D,N/D,N/D,N/D,N/D,N/D\r\n"))
+	assert.Equal(t, errs.ErrSecurityPriceNotFound, err)
+}
+
+func TestStooqDataSource_EmptyContent(t *testing.T) {
+	dataSource := &StooqDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte(""))
+	assert.Equal(t, errs.ErrFailedToRequestRemoteApi, err)
+}
+
+func TestStooqDataSource_MissingCloseColumn(t *testing.T) {
+	dataSource := &StooqDataSource{}
+	context := core.NewNullContext()
+
+	_, err := dataSource.Parse(context, []byte("Symbol,Date,Time\r\nAAPL.US,2024-11-08,22:00:09\r\n"))
+	assert.Equal(t, errs.ErrFailedToRequestRemoteApi, err)
+}
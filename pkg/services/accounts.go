@@ -16,6 +16,8 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/uuid"
 )
 
+const pageCountForMigrateAccountAmounts = 500
+
 // AccountService represents account service
 type AccountService struct {
 	ServiceUsingDB
@@ -255,6 +257,7 @@ func (s *AccountService) CreateAccounts(c core.Context, mainAccount *models.Acco
 
 	for i := 0; i < len(allAccounts); i++ {
 		allAccounts[i].Deleted = false
+		allAccounts[i].AmountDecimalPlaces = utils.GetCurrencyAmountDecimalPlaces(allAccounts[i].Currency)
 		allAccounts[i].CreatedUnixTime = now
 		allAccounts[i].UpdatedUnixTime = now
 
@@ -297,6 +300,20 @@ func (s *AccountService) CreateAccounts(c core.Context, mainAccount *models.Acco
 		}
 	}
 
+	if len(allInitTransactions) > 0 {
+		userTransactionLockTime, err := s.getUserTransactionLockTime(c, mainAccount.Uid)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(allInitTransactions); i++ {
+			if allInitTransactions[i].IsLocked(userTransactionLockTime) {
+				return errs.ErrTransactionTimeInLockedPeriod
+			}
+		}
+	}
+
 	userDataDb := s.UserDataDB(mainAccount.Uid)
 
 	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
@@ -399,6 +416,7 @@ func (s *AccountService) ModifyAccounts(c core.Context, mainAccount *models.Acco
 			childAccount.Uid = mainAccount.Uid
 			childAccount.Type = models.ACCOUNT_TYPE_SINGLE_ACCOUNT
 			childAccount.Deleted = false
+			childAccount.AmountDecimalPlaces = utils.GetCurrencyAmountDecimalPlaces(childAccount.Currency)
 			childAccount.CreatedUnixTime = now
 			childAccount.UpdatedUnixTime = now
 
@@ -439,6 +457,23 @@ func (s *AccountService) ModifyAccounts(c core.Context, mainAccount *models.Acco
 		}
 	}
 
+	userTransactionLockTime := int64(0)
+
+	if len(addInitTransactions) > 0 || len(removeSubAccountIds) > 0 {
+		var err error
+		userTransactionLockTime, err = s.getUserTransactionLockTime(c, mainAccount.Uid)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(addInitTransactions); i++ {
+		if addInitTransactions[i].IsLocked(userTransactionLockTime) {
+			return errs.ErrTransactionTimeInLockedPeriod
+		}
+	}
+
 	userDataDb := s.UserDataDB(mainAccount.Uid)
 
 	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
@@ -529,13 +564,21 @@ func (s *AccountService) ModifyAccounts(c core.Context, mainAccount *models.Acco
 			}
 
 			var relatedTransactionsByAccount []*models.Transaction
-			err = sess.Cols("transaction_id", "uid", "deleted", "account_id", "type").Where("uid=? AND deleted=?", mainAccount.Uid, false).In("account_id", removeSubAccountIds).Limit(len(removeSubAccountIds) + 1).Find(&relatedTransactionsByAccount)
+			err = sess.Cols("transaction_id", "uid", "deleted", "account_id", "type", "transaction_time").Where("uid=? AND deleted=?", mainAccount.Uid, false).In("account_id", removeSubAccountIds).Limit(len(removeSubAccountIds) + 1).Find(&relatedTransactionsByAccount)
 
 			if err != nil {
 				return err
 			} else if len(relatedTransactionsByAccount) > len(removeSubAccountIds) {
 				return errs.ErrSubAccountInUseCannotBeDeleted
 			} else if len(relatedTransactionsByAccount) > 0 {
+				var removeSubAccounts []*models.Account
+				err = sess.Cols("account_id", "extend").Where("uid=? AND deleted=?", mainAccount.Uid, false).In("account_id", removeSubAccountIds).Find(&removeSubAccounts)
+
+				if err != nil {
+					return err
+				}
+
+				removeSubAccountMap := s.GetAccountMapByList(removeSubAccounts)
 				accountTransactionExists := make(map[int64]bool)
 
 				for i := 0; i < len(relatedTransactionsByAccount); i++ {
@@ -545,6 +588,8 @@ func (s *AccountService) ModifyAccounts(c core.Context, mainAccount *models.Acco
 						return errs.ErrAccountInUseCannotBeDeleted
 					} else if _, exists := accountTransactionExists[transaction.AccountId]; exists {
 						return errs.ErrAccountInUseCannotBeDeleted
+					} else if transaction.IsLocked(userTransactionLockTime, removeSubAccountMap[transaction.AccountId]) {
+						return errs.ErrTransactionTimeInLockedPeriod
 					}
 
 					accountTransactionExists[transaction.AccountId] = true
@@ -644,6 +689,50 @@ func (s *AccountService) ModifyAccountDisplayOrders(c core.Context, uid int64, a
 	})
 }
 
+// SetAccountTransactionLockTime sets the transaction lock time of an existed account, the lock time can be moved backward or cleared (set to 0)
+func (s *AccountService) SetAccountTransactionLockTime(c core.Context, uid int64, accountId int64, transactionLockTime int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if accountId <= 0 {
+		return errs.ErrAccountIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		account := &models.Account{}
+		has, err := sess.ID(accountId).Where("uid=? AND deleted=?", uid, false).Get(account)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrAccountNotFound
+		}
+
+		if account.Extend == nil {
+			account.Extend = &models.AccountExtend{}
+		}
+
+		if transactionLockTime > 0 {
+			account.Extend.TransactionLockTime = &transactionLockTime
+		} else {
+			account.Extend.TransactionLockTime = nil
+		}
+
+		account.UpdatedUnixTime = time.Now().Unix()
+
+		updatedRows, err := sess.ID(account.AccountId).Cols("extend", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(account)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrAccountNotFound
+		}
+
+		return nil
+	})
+}
+
 // DeleteAccount deletes an existed account from database
 func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int64) error {
 	if uid <= 0 {
@@ -658,6 +747,12 @@ func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int6
 		DeletedUnixTime: now,
 	}
 
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
 	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
 		var accountAndSubAccounts []*models.Account
 		err := sess.Where("uid=? AND deleted=? AND ((account_id=? AND parent_account_id=?) OR parent_account_id=?)", uid, false, accountId, models.LevelOneAccountParentId, accountId).Find(&accountAndSubAccounts)
@@ -681,13 +776,14 @@ func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int6
 		}
 
 		var relatedTransactionsByAccount []*models.Transaction
-		err = sess.Cols("transaction_id", "uid", "deleted", "account_id", "type").Where("uid=? AND deleted=?", uid, false).In("account_id", accountAndSubAccountIds).Limit(len(accountAndSubAccounts) + 1).Find(&relatedTransactionsByAccount)
+		err = sess.Cols("transaction_id", "uid", "deleted", "account_id", "type", "transaction_time").Where("uid=? AND deleted=?", uid, false).In("account_id", accountAndSubAccountIds).Limit(len(accountAndSubAccounts) + 1).Find(&relatedTransactionsByAccount)
 
 		if err != nil {
 			return err
 		} else if len(relatedTransactionsByAccount) > len(accountAndSubAccountIds) {
 			return errs.ErrAccountInUseCannotBeDeleted
 		} else if len(relatedTransactionsByAccount) > 0 {
+			accountMap := s.GetAccountMapByList(accountAndSubAccounts)
 			accountTransactionExists := make(map[int64]bool)
 
 			for i := 0; i < len(relatedTransactionsByAccount); i++ {
@@ -697,6 +793,8 @@ func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int6
 					return errs.ErrAccountInUseCannotBeDeleted
 				} else if _, exists := accountTransactionExists[transaction.AccountId]; exists {
 					return errs.ErrAccountInUseCannotBeDeleted
+				} else if transaction.IsLocked(userTransactionLockTime, accountMap[transaction.AccountId]) {
+					return errs.ErrTransactionTimeInLockedPeriod
 				}
 
 				accountTransactionExists[transaction.AccountId] = true
@@ -728,6 +826,27 @@ func (s *AccountService) DeleteAccount(c core.Context, uid int64, accountId int6
 			return errs.ErrAccountInUseCannotBeDeleted
 		}
 
+		loanQueryCondition := fmt.Sprintf("uid=? AND deleted=? AND (account_id IN (%s) OR payment_account_id IN (%s))", accountAndSubAccountIdsConditions.String(), accountAndSubAccountIdsConditions.String())
+		loanQueryConditionParams := make([]any, 0, len(accountAndSubAccountIds)*2+2)
+		loanQueryConditionParams = append(loanQueryConditionParams, uid)
+		loanQueryConditionParams = append(loanQueryConditionParams, false)
+
+		for i := 0; i < len(accountAndSubAccountIds); i++ {
+			loanQueryConditionParams = append(loanQueryConditionParams, accountAndSubAccountIds[i])
+		}
+
+		for i := 0; i < len(accountAndSubAccountIds); i++ {
+			loanQueryConditionParams = append(loanQueryConditionParams, accountAndSubAccountIds[i])
+		}
+
+		exists, err = sess.Cols("uid", "deleted", "account_id", "payment_account_id").Where(loanQueryCondition, loanQueryConditionParams...).Limit(1).Exist(&models.Loan{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrAccountInUseCannotBeDeleted
+		}
+
 		deletedRows, err := sess.Cols("balance", "deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("account_id", accountAndSubAccountIds).Update(updateModel)
 
 		if err != nil {
@@ -776,9 +895,15 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 		DeletedUnixTime: now,
 	}
 
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
 	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
 		account := &models.Account{}
-		has, err := sess.Cols("account_id", "uid", "deleted", "parent_account_id").Where("uid=? AND deleted=? AND account_id=? AND parent_account_id<>?", uid, false, accountId, models.LevelOneAccountParentId).Limit(1).Get(account)
+		has, err := sess.Cols("account_id", "uid", "deleted", "parent_account_id", "extend").Where("uid=? AND deleted=? AND account_id=? AND parent_account_id<>?", uid, false, accountId, models.LevelOneAccountParentId).Limit(1).Get(account)
 
 		if err != nil {
 			return err
@@ -793,7 +918,7 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 		}
 
 		var relatedTransactionsByAccount []*models.Transaction
-		err = sess.Cols("transaction_id", "uid", "deleted", "account_id", "type").Where("uid=? AND deleted=? AND account_id=?", uid, false, accountId).Limit(2).Find(&relatedTransactionsByAccount)
+		err = sess.Cols("transaction_id", "uid", "deleted", "account_id", "type", "transaction_time").Where("uid=? AND deleted=? AND account_id=?", uid, false, accountId).Limit(2).Find(&relatedTransactionsByAccount)
 
 		if err != nil {
 			return err
@@ -805,6 +930,8 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 
 				if transaction.Type != models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
 					return errs.ErrSubAccountInUseCannotBeDeleted
+				} else if transaction.IsLocked(userTransactionLockTime, account) {
+					return errs.ErrTransactionTimeInLockedPeriod
 				}
 			}
 		}
@@ -817,6 +944,14 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 			return errs.ErrSubAccountInUseCannotBeDeleted
 		}
 
+		exists, err = sess.Cols("uid", "deleted", "account_id", "payment_account_id").Where("uid=? AND deleted=? AND (account_id=? OR payment_account_id=?)", uid, false, accountId, accountId).Limit(1).Exist(&models.Loan{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrSubAccountInUseCannotBeDeleted
+		}
+
 		deletedRows, err := sess.Cols("balance", "deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND account_id=?", uid, false, accountId).Update(updateModel)
 
 		if err != nil {
@@ -851,6 +986,228 @@ func (s *AccountService) DeleteSubAccount(c core.Context, uid int64, accountId i
 	})
 }
 
+// GetAllDeletedAccountsByUid returns all deleted account models of user which can be restored, the sub-accounts deleted together with their parent account are not included
+func (s *AccountService) GetAllDeletedAccountsByUid(c core.Context, uid int64) ([]*models.Account, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var accounts []*models.Account
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, true).OrderBy("deleted_unix_time desc, display_order asc").Find(&accounts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accountMap := s.GetAccountMapByList(accounts)
+	deletedAccounts := make([]*models.Account, 0, len(accounts))
+
+	for i := 0; i < len(accounts); i++ {
+		account := accounts[i]
+
+		if parentAccount, exists := accountMap[account.ParentAccountId]; exists && parentAccount.DeletedUnixTime == account.DeletedUnixTime {
+			continue
+		}
+
+		deletedAccounts = append(deletedAccounts, account)
+	}
+
+	return deletedAccounts, nil
+}
+
+// RestoreAccount restores a deleted account with the sub-accounts and balance modification transactions deleted together, and re-applies the account balances
+func (s *AccountService) RestoreAccount(c core.Context, uid int64, accountId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if accountId <= 0 {
+		return errs.ErrAccountIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	transactionRestoreModel := &models.Transaction{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		account := &models.Account{}
+		has, err := sess.ID(accountId).Where("uid=? AND deleted=?", uid, true).Get(account)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		accountAndSubAccounts := []*models.Account{account}
+
+		if account.ParentAccountId != models.LevelOneAccountParentId {
+			exists, err := sess.ID(account.ParentAccountId).Where("uid=? AND deleted=?", uid, false).Exist(&models.Account{})
+
+			if err != nil {
+				return err
+			} else if !exists {
+				return errs.ErrTrashItemParentNotRestored
+			}
+		} else if account.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+			var subAccounts []*models.Account
+			err = sess.Where("uid=? AND deleted=? AND parent_account_id=? AND deleted_unix_time=?", uid, true, account.AccountId, account.DeletedUnixTime).Find(&subAccounts)
+
+			if err != nil {
+				return err
+			}
+
+			accountAndSubAccounts = append(accountAndSubAccounts, subAccounts...)
+		}
+
+		accountAndSubAccountIds := make([]int64, len(accountAndSubAccounts))
+
+		for i := 0; i < len(accountAndSubAccounts); i++ {
+			accountAndSubAccountIds[i] = accountAndSubAccounts[i].AccountId
+		}
+
+		var balanceModificationTransactions []*models.Transaction
+		err = sess.Where("uid=? AND deleted=? AND type=? AND deleted_unix_time=?", uid, true, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, account.DeletedUnixTime).In("account_id", accountAndSubAccountIds).Find(&balanceModificationTransactions)
+
+		if err != nil {
+			return err
+		}
+
+		accountBalances := make(map[int64]int64, len(accountAndSubAccounts))
+		transactionIds := make([]int64, len(balanceModificationTransactions))
+
+		for i := 0; i < len(balanceModificationTransactions); i++ {
+			transaction := balanceModificationTransactions[i]
+			accountBalances[transaction.AccountId] += transaction.RelatedAccountAmount
+			transactionIds[i] = transaction.TransactionId
+		}
+
+		for i := 0; i < len(accountAndSubAccounts); i++ {
+			restoreModel := &models.Account{
+				Balance:         accountBalances[accountAndSubAccounts[i].AccountId],
+				Deleted:         false,
+				UpdatedUnixTime: now,
+				DeletedUnixTime: 0,
+			}
+
+			restoredRows, err := sess.ID(accountAndSubAccounts[i].AccountId).Cols("balance", "deleted", "updated_unix_time", "deleted_unix_time").Where("uid=? AND deleted=?", uid, true).Update(restoreModel)
+
+			if err != nil {
+				return err
+			} else if restoredRows < 1 {
+				return errs.ErrTrashItemNotFound
+			}
+		}
+
+		if len(transactionIds) > 0 {
+			restoredTransactionRows, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, true).In("transaction_id", transactionIds).Update(transactionRestoreModel)
+
+			if err != nil {
+				return err
+			} else if restoredTransactionRows < int64(len(transactionIds)) {
+				log.Errorf(c, "[accounts.RestoreAccount] it should restore %d transactions, but have restored %d actually", len(transactionIds), restoredTransactionRows)
+				return errs.ErrDatabaseOperationFailed
+			}
+		}
+
+		return nil
+	})
+}
+
+// PurgeAccount permanently deletes a deleted account and the sub-accounts deleted together from database
+func (s *AccountService) PurgeAccount(c core.Context, uid int64, accountId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if accountId <= 0 {
+		return errs.ErrAccountIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		account := &models.Account{}
+		has, err := sess.ID(accountId).Where("uid=? AND deleted=?", uid, true).Get(account)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		deletedRows, err := sess.Where("uid=? AND deleted=? AND deleted_unix_time=? AND (account_id=? OR parent_account_id=?)", uid, true, account.DeletedUnixTime, account.AccountId, account.AccountId).Delete(&models.Account{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeAllDeletedAccounts permanently deletes all accounts which have been deleted before the specified unix time
+func (s *AccountService) PurgeAllDeletedAccounts(c core.Context, deletedBeforeUnixTime int64) error {
+	count, err := s.purgeDeletedRows(c, deletedBeforeUnixTime, &models.Account{})
+
+	if count > 0 {
+		log.Infof(c, "[accounts.PurgeAllDeletedAccounts] %d deleted accounts have been purged", count)
+	} else if err == nil {
+		log.Infof(c, "[accounts.PurgeAllDeletedAccounts] no deleted accounts have been purged")
+	}
+
+	return err
+}
+
+// MigrateAllAccountsAmountDecimalPlaces rescales the stored amounts of all accounts which are not stored in the decimal places of their currencies (e.g. the accounts created before per-currency precision is supported), returns the count of migrated accounts
+func (s *AccountService) MigrateAllAccountsAmountDecimalPlaces(c core.Context) (int, error) {
+	currencies := getCurrenciesWithNonDefaultAmountDecimalPlaces()
+
+	if len(currencies) < 1 {
+		return 0, nil
+	}
+
+	var errors []error
+	totalCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var accounts []*models.Account
+		err := s.UserDataDBByIndex(i).NewSession(c).Cols("account_id", "uid", "currency", "amount_decimal_places").In("currency", currencies).Find(&accounts)
+
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+
+		for j := 0; j < len(accounts); j++ {
+			account := accounts[j]
+			amountDecimalPlaces := utils.GetCurrencyAmountDecimalPlaces(account.Currency)
+
+			if account.AmountDecimalPlaces == amountDecimalPlaces {
+				continue
+			} else if account.AmountDecimalPlaces < utils.DefaultCurrencyDecimalPlaces || account.AmountDecimalPlaces > amountDecimalPlaces {
+				log.Warnf(c, "[accounts.MigrateAllAccountsAmountDecimalPlaces] cannot migrate account \"id:%d\" of user \"uid:%d\" from %d decimal places to %d decimal places", account.AccountId, account.Uid, account.AmountDecimalPlaces, amountDecimalPlaces)
+				continue
+			}
+
+			err = s.migrateAccountAmountDecimalPlaces(c, s.UserDataDBByIndex(i), account, amountDecimalPlaces)
+
+			if err != nil {
+				log.Errorf(c, "[accounts.MigrateAllAccountsAmountDecimalPlaces] failed to migrate account \"id:%d\" of user \"uid:%d\", because %s", account.AccountId, account.Uid, err.Error())
+				errors = append(errors, err)
+				continue
+			}
+
+			totalCount++
+		}
+	}
+
+	return totalCount, errs.NewMultiErrorOrNil(errors...)
+}
+
 // GetAccountMapByList returns an account map by a list
 func (s *AccountService) GetAccountMapByList(accounts []*models.Account) map[int64]*models.Account {
 	accountMap := make(map[int64]*models.Account)
@@ -986,3 +1343,138 @@ func (s *AccountService) GetAccountOrSubAccountIdsByAccountName(accounts []*mode
 
 	return accountIds
 }
+
+func (s *AccountService) migrateAccountAmountDecimalPlaces(c core.Context, database *datastore.Database, account *models.Account, amountDecimalPlaces int32) error {
+	multiplier := getAmountDecimalPlacesMultiplier(account.AmountDecimalPlaces, amountDecimalPlaces)
+
+	return database.DoTransaction(c, func(sess *xorm.Session) error {
+		updateModel := &models.Account{
+			AmountDecimalPlaces: amountDecimalPlaces,
+			UpdatedUnixTime:     time.Now().Unix(),
+		}
+
+		// the account may have been migrated by other process
+		updatedRows, err := sess.ID(account.AccountId).SetExpr("balance", fmt.Sprintf("balance*%d", multiplier)).Cols("amount_decimal_places", "updated_unix_time").Where("uid=? AND amount_decimal_places=?", account.Uid, account.AmountDecimalPlaces).Update(updateModel)
+
+		if err != nil || updatedRows < 1 {
+			return err
+		}
+
+		_, err = sess.SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Where("uid=? AND account_id=?", account.Uid, account.AccountId).Update(&models.Transaction{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.SetExpr("related_account_amount", fmt.Sprintf("related_account_amount*%d", multiplier)).Where("uid=? AND related_account_id=?", account.Uid, account.AccountId).Update(&models.Transaction{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Where("uid=? AND account_id=?", account.Uid, account.AccountId).Update(&models.TransactionTemplate{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.SetExpr("related_account_amount", fmt.Sprintf("related_account_amount*%d", multiplier)).Where("uid=? AND related_account_id=?", account.Uid, account.AccountId).Update(&models.TransactionTemplate{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.SetExpr("statement_ending_balance", fmt.Sprintf("statement_ending_balance*%d", multiplier)).Where("uid=? AND account_id=?", account.Uid, account.AccountId).Update(&models.ReconciliationSession{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.SetExpr("principal", fmt.Sprintf("principal*%d", multiplier)).Where("uid=? AND account_id=?", account.Uid, account.AccountId).Update(&models.Loan{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.SetExpr("price", fmt.Sprintf("price*%d", multiplier)).SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Where("uid=? AND account_id=?", account.Uid, account.AccountId).Update(&models.InvestmentTransaction{})
+
+		if err != nil {
+			return err
+		}
+
+		var transactions []*models.Transaction
+		err = sess.Cols("transaction_id").Where("uid=? AND account_id=?", account.Uid, account.AccountId).Find(&transactions)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(transactions); i += pageCountForMigrateAccountAmounts {
+			transactionIds := make([]int64, 0, pageCountForMigrateAccountAmounts)
+
+			for j := i; j < len(transactions) && j < i+pageCountForMigrateAccountAmounts; j++ {
+				transactionIds = append(transactionIds, transactions[j].TransactionId)
+			}
+
+			_, err = sess.SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Where("uid=?", account.Uid).In("transaction_id", transactionIds).Update(&models.TransactionSplit{})
+
+			if err != nil {
+				return err
+			}
+
+			_, err = sess.SetExpr("unit_price", fmt.Sprintf("unit_price*%d", multiplier)).SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Where("uid=?", account.Uid).In("transaction_id", transactionIds).Update(&models.TransactionItemIndex{})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return updateAllTransactionRevisionSnapshots(sess, account.Uid, func(snapshot *models.TransactionRevisionSnapshot) bool {
+			updated := false
+
+			if snapshot.AccountId == account.AccountId {
+				snapshot.Amount *= multiplier
+
+				for i := 0; i < len(snapshot.Items); i++ {
+					snapshot.Items[i].UnitPrice *= multiplier
+					snapshot.Items[i].Amount *= multiplier
+				}
+
+				for i := 0; i < len(snapshot.Splits); i++ {
+					snapshot.Splits[i].Amount *= multiplier
+				}
+
+				updated = true
+			}
+
+			if snapshot.RelatedAccountId == account.AccountId {
+				snapshot.RelatedAccountAmount *= multiplier
+				updated = true
+			}
+
+			return updated
+		})
+	})
+}
+
+func getCurrenciesWithNonDefaultAmountDecimalPlaces() []string {
+	currencies := make([]string, 0, len(utils.CurrencyDecimalPlaces))
+
+	for currency := range utils.CurrencyDecimalPlaces {
+		if utils.GetCurrencyAmountDecimalPlaces(currency) != utils.DefaultCurrencyDecimalPlaces {
+			currencies = append(currencies, currency)
+		}
+	}
+
+	return currencies
+}
+
+func getAmountDecimalPlacesMultiplier(fromDecimalPlaces int32, toDecimalPlaces int32) int64 {
+	multiplier := int64(1)
+
+	for i := fromDecimalPlaces; i < toDecimalPlaces; i++ {
+		multiplier *= 10
+	}
+
+	return multiplier
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 func TestGetAccountMapByList_EmptyList(t *testing.T) {
@@ -216,3 +217,22 @@ func TestGetAccountOrSubAccountIdsByAccountName_MatchMultiple(t *testing.T) {
 	assert.NotContains(t, actualAccountMap, int64(3001))
 	assert.NotContains(t, actualAccountMap, int64(4001))
 }
+
+func TestGetCurrenciesWithNonDefaultAmountDecimalPlaces(t *testing.T) {
+	currencies := getCurrenciesWithNonDefaultAmountDecimalPlaces()
+
+	assert.Contains(t, currencies, "KWD")
+	assert.Contains(t, currencies, "BHD")
+	assert.NotContains(t, currencies, "USD")
+	assert.NotContains(t, currencies, "JPY")
+
+	for i := 0; i < len(currencies); i++ {
+		assert.NotEqual(t, utils.DefaultCurrencyDecimalPlaces, utils.GetCurrencyAmountDecimalPlaces(currencies[i]))
+	}
+}
+
+func TestGetAmountDecimalPlacesMultiplier(t *testing.T) {
+	assert.Equal(t, int64(1), getAmountDecimalPlacesMultiplier(2, 2))
+	assert.Equal(t, int64(10), getAmountDecimalPlacesMultiplier(2, 3))
+	assert.Equal(t, int64(100), getAmountDecimalPlacesMultiplier(2, 4))
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"xorm.io/xorm"
+
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
 	"github.com/mayswind/ezbookkeeping/pkg/mail"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/settings"
 	"github.com/mayswind/ezbookkeeping/pkg/storage"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
@@ -53,6 +57,52 @@ func (s *ServiceUsingDB) UserDataDBCount() int {
 	return s.container.UserDataStore.Count()
 }
 
+// getUserTransactionLockTime returns the unix time before which (inclusive) the transactions of specified user cannot be created, modified or deleted
+func (s *ServiceUsingDB) getUserTransactionLockTime(c core.Context, uid int64) (int64, error) {
+	user := &models.User{}
+	has, err := s.UserDB().NewSession(c).ID(uid).Cols("uid", "transaction_lock_time").Where("deleted=?", false).Get(user)
+
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, errs.ErrUserNotFound
+	}
+
+	return user.TransactionLockTime, nil
+}
+
+// purgeDeletedRows permanently deletes the rows of specified tables which have been deleted before the specified unix time from all user data datastores
+func (s *ServiceUsingDB) purgeDeletedRows(c core.Context, deletedBeforeUnixTime int64, beans ...any) (int64, error) {
+	var errors []error
+	totalCount := int64(0)
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		count := int64(0)
+
+		err := s.UserDataDBByIndex(i).DoTransaction(c, func(sess *xorm.Session) error {
+			for j := 0; j < len(beans); j++ {
+				deletedRows, err := sess.Where("deleted=? AND deleted_unix_time<?", true, deletedBeforeUnixTime).Delete(beans[j])
+
+				if err != nil {
+					return err
+				}
+
+				count += deletedRows
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			errors = append(errors, err)
+		} else {
+			totalCount += count
+		}
+	}
+
+	return totalCount, errs.NewMultiErrorOrNil(errors...)
+}
+
 // ServiceUsingConfig represents a service that need to use config
 type ServiceUsingConfig struct {
 	container *settings.ConfigContainer
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// BudgetService represents budget service
+type BudgetService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a budget service singleton instance
+var (
+	Budgets = &BudgetService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetTotalBudgetsCountByUid returns total budgets count of user
+func (s *BudgetService) GetTotalBudgetsCountByUid(c core.Context, uid int64) (int64, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	count, err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).Count(&models.Budget{})
+
+	return count, err
+}
+
+// GetAllBudgetsByUid returns all budget models of user
+func (s *BudgetService) GetAllBudgetsByUid(c core.Context, uid int64) ([]*models.Budget, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var budgets []*models.Budget
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).Find(&budgets)
+
+	return budgets, err
+}
+
+// GetBudgetByBudgetId returns a budget model according to budget id
+func (s *BudgetService) GetBudgetByBudgetId(c core.Context, uid int64, budgetId int64) (*models.Budget, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if budgetId <= 0 {
+		return nil, errs.ErrBudgetIdInvalid
+	}
+
+	budget := &models.Budget{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(budgetId).Where("uid=? AND deleted=?", uid, false).Get(budget)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrBudgetNotFound
+	}
+
+	return budget, nil
+}
+
+// GetMaxDisplayOrder returns the max display order
+func (s *BudgetService) GetMaxDisplayOrder(c core.Context, uid int64) (int32, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	budget := &models.Budget{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("uid", "deleted", "display_order").Where("uid=? AND deleted=?", uid, false).OrderBy("display_order desc").Limit(1).Get(budget)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if has {
+		return budget.DisplayOrder, nil
+	} else {
+		return 0, nil
+	}
+}
+
+// CreateBudget saves a new budget model to database
+func (s *BudgetService) CreateBudget(c core.Context, budget *models.Budget) error {
+	if budget.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	budget.BudgetId = s.GenerateUuid(uuid.UUID_TYPE_BUDGET)
+
+	if budget.BudgetId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	amountChange := &models.BudgetAmountChange{
+		ChangeId: s.GenerateUuid(uuid.UUID_TYPE_DEFAULT),
+	}
+
+	if amountChange.ChangeId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	budget.AmountDecimalPlaces = utils.GetCurrencyAmountDecimalPlaces(budget.Currency)
+	budget.Deleted = false
+	budget.CreatedUnixTime = time.Now().Unix()
+	budget.UpdatedUnixTime = time.Now().Unix()
+
+	amountChange.Uid = budget.Uid
+	amountChange.BudgetId = budget.BudgetId
+	amountChange.Currency = budget.Currency
+	amountChange.Amount = budget.Amount
+	amountChange.AmountDecimalPlaces = budget.AmountDecimalPlaces
+	amountChange.EffectiveUnixTime = budget.CreatedUnixTime
+	amountChange.CreatedUnixTime = budget.CreatedUnixTime
+
+	return s.UserDataDB(budget.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(budget)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(amountChange)
+		return err
+	})
+}
+
+// ModifyBudget saves an existed budget model to database
+func (s *BudgetService) ModifyBudget(c core.Context, budget *models.Budget) error {
+	if budget.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	changeIds := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, 2)
+
+	if len(changeIds) < 2 {
+		return errs.ErrSystemIsBusy
+	}
+
+	budget.AmountDecimalPlaces = utils.GetCurrencyAmountDecimalPlaces(budget.Currency)
+	budget.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(budget.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		oldBudget := &models.Budget{}
+		has, err := sess.ID(budget.BudgetId).Where("uid=? AND deleted=?", budget.Uid, false).Get(oldBudget)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrBudgetNotFound
+		}
+
+		updatedRows, err := sess.ID(budget.BudgetId).Cols("name", "type", "period_type", "category_id", "account_id", "currency", "amount", "amount_decimal_places", "rollover", "hidden", "comment", "updated_unix_time").Where("uid=? AND deleted=?", budget.Uid, false).Update(budget)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrBudgetNotFound
+		}
+
+		if budget.Amount == oldBudget.Amount && budget.Currency == oldBudget.Currency {
+			return nil
+		}
+
+		exists, err := sess.Where("uid=? AND budget_id=?", budget.Uid, budget.BudgetId).Exist(&models.BudgetAmountChange{})
+
+		if err != nil {
+			return err
+		}
+
+		// keep the original amount for the past periods of the budget created before amount changes are saved
+		if !exists {
+			_, err = sess.Insert(&models.BudgetAmountChange{
+				ChangeId:            changeIds[0],
+				Uid:                 oldBudget.Uid,
+				BudgetId:            oldBudget.BudgetId,
+				Currency:            oldBudget.Currency,
+				Amount:              oldBudget.Amount,
+				AmountDecimalPlaces: oldBudget.AmountDecimalPlaces,
+				EffectiveUnixTime:   oldBudget.CreatedUnixTime,
+				CreatedUnixTime:     budget.UpdatedUnixTime,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = sess.Insert(&models.BudgetAmountChange{
+			ChangeId:            changeIds[1],
+			Uid:                 budget.Uid,
+			BudgetId:            budget.BudgetId,
+			Currency:            budget.Currency,
+			Amount:              budget.Amount,
+			AmountDecimalPlaces: budget.AmountDecimalPlaces,
+			EffectiveUnixTime:   budget.UpdatedUnixTime,
+			CreatedUnixTime:     budget.UpdatedUnixTime,
+		})
+
+		return err
+	})
+}
+
+// GetBudgetAmountChangesByBudgetIds returns the amount changes of the specified budgets in ascending order of effective time
+func (s *BudgetService) GetBudgetAmountChangesByBudgetIds(c core.Context, uid int64, budgetIds []int64) (map[int64][]*models.BudgetAmountChange, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	amountChangesMap := make(map[int64][]*models.BudgetAmountChange, len(budgetIds))
+
+	if len(budgetIds) < 1 {
+		return amountChangesMap, nil
+	}
+
+	var amountChanges []*models.BudgetAmountChange
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=?", uid).In("budget_id", budgetIds).OrderBy("effective_unix_time asc, change_id asc").Find(&amountChanges)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(amountChanges); i++ {
+		amountChange := amountChanges[i]
+		amountChangesMap[amountChange.BudgetId] = append(amountChangesMap[amountChange.BudgetId], amountChange)
+	}
+
+	return amountChangesMap, nil
+}
+
+// HideBudget updates hidden field of given budget ids
+func (s *BudgetService) HideBudget(c core.Context, uid int64, ids []int64, hidden bool) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Budget{
+		Hidden:          hidden,
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.Cols("hidden", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).In("budget_id", ids).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrBudgetNotFound
+		}
+
+		return err
+	})
+}
+
+// ModifyBudgetDisplayOrders updates display order of given budgets
+func (s *BudgetService) ModifyBudgetDisplayOrders(c core.Context, uid int64, budgets []*models.Budget) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	for i := 0; i < len(budgets); i++ {
+		budgets[i].UpdatedUnixTime = time.Now().Unix()
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(budgets); i++ {
+			budget := budgets[i]
+			updatedRows, err := sess.ID(budget.BudgetId).Cols("display_order", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(budget)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrBudgetNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteBudget deletes an existed budget from database
+func (s *BudgetService) DeleteBudget(c core.Context, uid int64, budgetId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Budget{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(budgetId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrBudgetNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllBudgets deletes all existed budgets from database
+func (s *BudgetService) DeleteAllBudgets(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Budget{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// MigrateAllBudgetsAmountDecimalPlaces rescales the amounts of all budgets which are not stored in the decimal places of their currencies, returns the count of migrated budgets
+func (s *BudgetService) MigrateAllBudgetsAmountDecimalPlaces(c core.Context) (int64, error) {
+	currencies := getCurrenciesWithNonDefaultAmountDecimalPlaces()
+
+	if len(currencies) < 1 {
+		return 0, nil
+	}
+
+	totalCount := int64(0)
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		for j := 0; j < len(currencies); j++ {
+			currency := currencies[j]
+			amountDecimalPlaces := utils.GetCurrencyAmountDecimalPlaces(currency)
+			multiplier := getAmountDecimalPlacesMultiplier(utils.DefaultCurrencyDecimalPlaces, amountDecimalPlaces)
+
+			updateModel := &models.Budget{
+				AmountDecimalPlaces: amountDecimalPlaces,
+			}
+
+			updatedRows, err := s.UserDataDBByIndex(i).NewSession(c).SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Cols("amount_decimal_places").Where("currency=? AND amount_decimal_places=?", currency, utils.DefaultCurrencyDecimalPlaces).Update(updateModel)
+
+			if err != nil {
+				return totalCount, err
+			}
+
+			totalCount += updatedRows
+
+			amountChangeUpdateModel := &models.BudgetAmountChange{
+				AmountDecimalPlaces: amountDecimalPlaces,
+			}
+
+			_, err = s.UserDataDBByIndex(i).NewSession(c).SetExpr("amount", fmt.Sprintf("amount*%d", multiplier)).Cols("amount_decimal_places").Where("currency=? AND amount_decimal_places=?", currency, utils.DefaultCurrencyDecimalPlaces).Update(amountChangeUpdateModel)
+
+			if err != nil {
+				return totalCount, err
+			}
+		}
+	}
+
+	return totalCount, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// ExchangeRateSnapshotService represents exchange rate snapshot service
+type ExchangeRateSnapshotService struct {
+	ServiceUsingDB
+}
+
+// Initialize a exchange rate snapshot service singleton instance
+var (
+	ExchangeRateSnapshots = &ExchangeRateSnapshotService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetExchangeRatesOnDate returns the exchange rates of the latest snapshot on or before the specified numeric date (e.g. 20240131)
+func (s *ExchangeRateSnapshotService) GetExchangeRatesOnDate(c core.Context, dataSource string, date int32) (*models.LatestExchangeRateResponse, error) {
+	snapshotDate, err := s.getLatestSnapshotDate(c, dataSource, date)
+
+	if err != nil {
+		return nil, err
+	} else if snapshotDate <= 0 {
+		return nil, errs.ErrExchangeRateSnapshotNotFound
+	}
+
+	var snapshots []*models.ExchangeRateSnapshot
+	err = s.UserDB().NewSession(c).Where("data_source=? AND snapshot_date=?", dataSource, snapshotDate).Find(&snapshots)
+
+	if err != nil {
+		return nil, err
+	} else if len(snapshots) < 1 {
+		return nil, errs.ErrExchangeRateSnapshotNotFound
+	}
+
+	return models.NewHistoricalExchangeRates(dataSource, snapshots, nil).GetExchangeRatesOnDate(snapshotDate), nil
+}
+
+// GetExchangeRateSnapshotsByDateRange returns all the exchange rate snapshots which are effective between the start numeric date and the end numeric date
+func (s *ExchangeRateSnapshotService) GetExchangeRateSnapshotsByDateRange(c core.Context, dataSource string, startDate int32, endDate int32) ([]*models.ExchangeRateSnapshot, error) {
+	if startDate > 0 && endDate > 0 && startDate > endDate {
+		return nil, errs.ErrExchangeRateSnapshotDateRangeInvalid
+	}
+
+	condition := "data_source=?"
+	conditionParams := make([]any, 0, 3)
+	conditionParams = append(conditionParams, dataSource)
+
+	if startDate > 0 {
+		// the snapshot effective on the start date may be saved before that date (e.g. the start date is a holiday)
+		latestSnapshotDate, err := s.getLatestSnapshotDate(c, dataSource, startDate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if latestSnapshotDate > 0 {
+			startDate = latestSnapshotDate
+		}
+
+		condition = condition + " AND snapshot_date>=?"
+		conditionParams = append(conditionParams, startDate)
+	}
+
+	if endDate > 0 {
+		condition = condition + " AND snapshot_date<=?"
+		conditionParams = append(conditionParams, endDate)
+	}
+
+	var snapshots []*models.ExchangeRateSnapshot
+	err := s.UserDB().NewSession(c).Where(condition, conditionParams...).OrderBy("snapshot_date asc").Find(&snapshots)
+
+	return snapshots, err
+}
+
+// SaveExchangeRateSnapshots saves the exchange rate snapshots of one date to database, and the existed snapshots of the same data source and date would be replaced
+func (s *ExchangeRateSnapshotService) SaveExchangeRateSnapshots(c core.Context, dataSource string, snapshotDate int32, snapshots []*models.ExchangeRateSnapshot) error {
+	if dataSource == "" {
+		return errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	if snapshotDate <= 0 {
+		return errs.ErrExchangeRateDateInvalid
+	}
+
+	if len(snapshots) < 1 {
+		return nil
+	}
+
+	now := time.Now().Unix()
+
+	for i := 0; i < len(snapshots); i++ {
+		snapshot := snapshots[i]
+
+		if snapshot.DataSource != dataSource || snapshot.SnapshotDate != snapshotDate {
+			return errs.ErrExchangeRateDateInvalid
+		}
+
+		snapshot.CreatedUnixTime = now
+	}
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Where("data_source=? AND snapshot_date=?", dataSource, snapshotDate).Delete(&models.ExchangeRateSnapshot{})
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(snapshots)
+
+		return err
+	})
+}
+
+func (s *ExchangeRateSnapshotService) getLatestSnapshotDate(c core.Context, dataSource string, date int32) (int32, error) {
+	snapshot := &models.ExchangeRateSnapshot{}
+	has, err := s.UserDB().NewSession(c).Cols("data_source", "snapshot_date").Where("data_source=? AND snapshot_date<=?", dataSource, date).OrderBy("snapshot_date desc").Limit(1).Get(snapshot)
+
+	if err != nil {
+		return 0, err
+	} else if !has {
+		return 0, nil
+	}
+
+	return snapshot.SnapshotDate, nil
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// InvestmentTransactionService represents investment transaction service
+type InvestmentTransactionService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize an investment transaction service singleton instance
+var (
+	InvestmentTransactions = &InvestmentTransactionService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetInvestmentTransactions returns all investment transaction models of user in ascending order of transaction time, account id and security id are optional
+func (s *InvestmentTransactionService) GetInvestmentTransactions(c core.Context, uid int64, accountId int64, securityId int64, maxTime int64) ([]*models.InvestmentTransaction, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	condition := "uid=? AND deleted=?"
+	conditionParams := []any{uid, false}
+
+	if accountId > 0 {
+		condition = condition + " AND account_id=?"
+		conditionParams = append(conditionParams, accountId)
+	}
+
+	if securityId > 0 {
+		condition = condition + " AND security_id=?"
+		conditionParams = append(conditionParams, securityId)
+	}
+
+	if maxTime > 0 {
+		condition = condition + " AND transaction_time<=?"
+		conditionParams = append(conditionParams, maxTime)
+	}
+
+	var transactions []*models.InvestmentTransaction
+	err := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...).OrderBy("transaction_time asc, investment_transaction_id asc").Find(&transactions)
+
+	return transactions, err
+}
+
+// GetInvestmentTransactionById returns an investment transaction model according to investment transaction id
+func (s *InvestmentTransactionService) GetInvestmentTransactionById(c core.Context, uid int64, transactionId int64) (*models.InvestmentTransaction, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if transactionId <= 0 {
+		return nil, errs.ErrInvestmentTransactionIdInvalid
+	}
+
+	transaction := &models.InvestmentTransaction{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(transactionId).Where("uid=? AND deleted=?", uid, false).Get(transaction)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrInvestmentTransactionNotFound
+	}
+
+	return transaction, nil
+}
+
+// CreateInvestmentTransaction saves a new investment transaction model to database, returns error if the holding becomes invalid after the transaction
+func (s *InvestmentTransactionService) CreateInvestmentTransaction(c core.Context, transaction *models.InvestmentTransaction, costBasisMethod models.SecurityCostBasisMethod) error {
+	if transaction.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	transaction.FillAmount()
+
+	if err := transaction.Validate(); err != nil {
+		return err
+	}
+
+	transaction.InvestmentTransactionId = s.GenerateUuid(uuid.UUID_TYPE_INVESTMENT)
+
+	if transaction.InvestmentTransactionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	transaction.Deleted = false
+	transaction.CreatedUnixTime = time.Now().Unix()
+	transaction.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		existedTransactions, err := s.getInvestmentTransactionsOfHolding(sess, transaction.Uid, transaction.AccountId, transaction.SecurityId)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = s.calculateInvestmentHolding(transaction.AccountId, transaction.SecurityId, costBasisMethod, append(existedTransactions, transaction))
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(transaction)
+		return err
+	})
+}
+
+// ModifyInvestmentTransaction saves an existed investment transaction model to database, returns error if the holding becomes invalid after the modification
+func (s *InvestmentTransactionService) ModifyInvestmentTransaction(c core.Context, transaction *models.InvestmentTransaction, costBasisMethod models.SecurityCostBasisMethod) error {
+	if transaction.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	transaction.FillAmount()
+
+	if err := transaction.Validate(); err != nil {
+		return err
+	}
+
+	transaction.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		existedTransactions, err := s.getInvestmentTransactionsOfHolding(sess, transaction.Uid, transaction.AccountId, transaction.SecurityId)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(existedTransactions); i++ {
+			if existedTransactions[i].InvestmentTransactionId == transaction.InvestmentTransactionId {
+				existedTransactions[i] = transaction
+			}
+		}
+
+		_, err = s.calculateInvestmentHolding(transaction.AccountId, transaction.SecurityId, costBasisMethod, existedTransactions)
+
+		if err != nil {
+			return err
+		}
+
+		updatedRows, err := sess.ID(transaction.InvestmentTransactionId).Cols("transaction_time", "quantity", "price", "amount", "fee", "comment", "updated_unix_time").Where("uid=? AND deleted=?", transaction.Uid, false).Update(transaction)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrInvestmentTransactionNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteInvestmentTransaction deletes an existed investment transaction from database, returns error if the holding becomes invalid after the deletion
+func (s *InvestmentTransactionService) DeleteInvestmentTransaction(c core.Context, transaction *models.InvestmentTransaction, costBasisMethod models.SecurityCostBasisMethod) error {
+	if transaction.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.InvestmentTransaction{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		existedTransactions, err := s.getInvestmentTransactionsOfHolding(sess, transaction.Uid, transaction.AccountId, transaction.SecurityId)
+
+		if err != nil {
+			return err
+		}
+
+		remainingTransactions := make([]*models.InvestmentTransaction, 0, len(existedTransactions))
+
+		for i := 0; i < len(existedTransactions); i++ {
+			if existedTransactions[i].InvestmentTransactionId != transaction.InvestmentTransactionId {
+				remainingTransactions = append(remainingTransactions, existedTransactions[i])
+			}
+		}
+
+		_, err = s.calculateInvestmentHolding(transaction.AccountId, transaction.SecurityId, costBasisMethod, remainingTransactions)
+
+		if err != nil {
+			return err
+		}
+
+		deletedRows, err := sess.ID(transaction.InvestmentTransactionId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", transaction.Uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrInvestmentTransactionNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllInvestmentTransactions deletes all existed investment transactions from database
+func (s *InvestmentTransactionService) DeleteAllInvestmentTransactions(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.InvestmentTransaction{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		return err
+	})
+}
+
+// CalculateInvestmentHoldings returns the holdings of all account and security pairs by replaying the investment transactions which must be in ascending order of transaction time
+func (s *InvestmentTransactionService) CalculateInvestmentHoldings(transactions []*models.InvestmentTransaction, securityMap map[int64]*models.Security) (*models.InvestmentHoldings, error) {
+	holdings := models.NewInvestmentHoldings()
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		security, exists := securityMap[transaction.SecurityId]
+
+		if !exists {
+			return nil, errs.ErrSecurityNotFound
+		}
+
+		err := holdings.Apply(transaction, security.CostBasisMethod)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return holdings, nil
+}
+
+func (s *InvestmentTransactionService) getInvestmentTransactionsOfHolding(sess *xorm.Session, uid int64, accountId int64, securityId int64) ([]*models.InvestmentTransaction, error) {
+	var transactions []*models.InvestmentTransaction
+	err := sess.Where("uid=? AND deleted=? AND account_id=? AND security_id=?", uid, false, accountId, securityId).Find(&transactions)
+
+	return transactions, err
+}
+
+func (s *InvestmentTransactionService) calculateInvestmentHolding(accountId int64, securityId int64, costBasisMethod models.SecurityCostBasisMethod, transactions []*models.InvestmentTransaction) (*models.InvestmentHolding, error) {
+	sort.Sort(models.InvestmentTransactionSlice(transactions))
+	holding := models.NewInvestmentHolding(accountId, securityId, costBasisMethod)
+
+	for i := 0; i < len(transactions); i++ {
+		err := holding.Apply(transactions[i])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return holding, nil
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// LedgerService represents shared ledger service
+type LedgerService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a shared ledger service singleton instance
+var (
+	Ledgers = &LedgerService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetLedgerByLedgerId returns a shared ledger model according to ledger id
+func (s *LedgerService) GetLedgerByLedgerId(c core.Context, ledgerId int64) (*models.Ledger, error) {
+	if ledgerId <= 0 {
+		return nil, errs.ErrLedgerIdInvalid
+	}
+
+	ledger := &models.Ledger{}
+	has, err := s.UserDB().NewSession(c).ID(ledgerId).Where("deleted=?", false).Get(ledger)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrLedgerNotFound
+	}
+
+	return ledger, nil
+}
+
+// GetLedgerByOwnerUid returns the shared ledger model owned by the user
+func (s *LedgerService) GetLedgerByOwnerUid(c core.Context, ownerUid int64) (*models.Ledger, error) {
+	if ownerUid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	ledger := &models.Ledger{}
+	has, err := s.UserDB().NewSession(c).Where("owner_uid=? AND deleted=?", ownerUid, false).Get(ledger)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrLedgerNotFound
+	}
+
+	return ledger, nil
+}
+
+// GetLedgersByLedgerIds returns shared ledger models according to ledger ids
+func (s *LedgerService) GetLedgersByLedgerIds(c core.Context, ledgerIds []int64) (map[int64]*models.Ledger, error) {
+	if len(ledgerIds) <= 0 {
+		return make(map[int64]*models.Ledger), nil
+	}
+
+	var ledgers []*models.Ledger
+	err := s.UserDB().NewSession(c).Where("deleted=?", false).In("ledger_id", ledgerIds).Find(&ledgers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerMap := make(map[int64]*models.Ledger, len(ledgers))
+
+	for i := 0; i < len(ledgers); i++ {
+		ledgerMap[ledgers[i].LedgerId] = ledgers[i]
+	}
+
+	return ledgerMap, nil
+}
+
+// GetAllMembersByLedgerId returns all member models (including pending invitations) of the shared ledger
+func (s *LedgerService) GetAllMembersByLedgerId(c core.Context, ledgerId int64) ([]*models.LedgerMember, error) {
+	if ledgerId <= 0 {
+		return nil, errs.ErrLedgerIdInvalid
+	}
+
+	var members []*models.LedgerMember
+	err := s.UserDB().NewSession(c).Where("ledger_id=? AND deleted=?", ledgerId, false).OrderBy("member_id asc").Find(&members)
+
+	return members, err
+}
+
+// GetAllMembershipsByUid returns all member models (including pending invitations) of the user in other users' shared ledgers
+func (s *LedgerService) GetAllMembershipsByUid(c core.Context, uid int64) ([]*models.LedgerMember, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var members []*models.LedgerMember
+	err := s.UserDB().NewSession(c).Where("uid=? AND deleted=?", uid, false).Find(&members)
+
+	return members, err
+}
+
+// GetLedgerMemberByMemberId returns a shared ledger member model according to member id
+func (s *LedgerService) GetLedgerMemberByMemberId(c core.Context, memberId int64) (*models.LedgerMember, error) {
+	if memberId <= 0 {
+		return nil, errs.ErrLedgerMemberIdInvalid
+	}
+
+	member := &models.LedgerMember{}
+	has, err := s.UserDB().NewSession(c).ID(memberId).Where("deleted=?", false).Get(member)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrLedgerMemberNotFound
+	}
+
+	return member, nil
+}
+
+// GetLedgerAndRoleOfUser returns the shared ledger model and the effective role of the user in the ledger
+func (s *LedgerService) GetLedgerAndRoleOfUser(c core.Context, ledgerId int64, uid int64) (*models.Ledger, models.LedgerMemberRole, error) {
+	if uid <= 0 {
+		return nil, models.LEDGER_MEMBER_ROLE_NONE, errs.ErrUserIdInvalid
+	}
+
+	ledger, err := s.GetLedgerByLedgerId(c, ledgerId)
+
+	if err != nil {
+		return nil, models.LEDGER_MEMBER_ROLE_NONE, err
+	}
+
+	if ledger.OwnerUid == uid {
+		return ledger, models.LEDGER_MEMBER_ROLE_OWNER, nil
+	}
+
+	member := &models.LedgerMember{}
+	has, err := s.UserDB().NewSession(c).Where("ledger_id=? AND uid=? AND deleted=?", ledgerId, uid, false).Get(member)
+
+	if err != nil {
+		return nil, models.LEDGER_MEMBER_ROLE_NONE, err
+	} else if !has {
+		return ledger, models.LEDGER_MEMBER_ROLE_NONE, nil
+	}
+
+	return ledger, member.GetEffectiveRole(), nil
+}
+
+// CreateLedger saves a new shared ledger model to database, each user can only own one shared ledger because a shared ledger shares all the data of its owner
+func (s *LedgerService) CreateLedger(c core.Context, ledger *models.Ledger) error {
+	if ledger.OwnerUid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	exists, err := s.UserDB().NewSession(c).Where("owner_uid=? AND deleted=?", ledger.OwnerUid, false).Exist(&models.Ledger{})
+
+	if err != nil {
+		return err
+	} else if exists {
+		return errs.ErrLedgerAlreadyExists
+	}
+
+	ledger.LedgerId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if ledger.LedgerId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	ledger.Deleted = false
+	ledger.CreatedUnixTime = time.Now().Unix()
+	ledger.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(ledger)
+		return err
+	})
+}
+
+// ModifyLedger saves an existed shared ledger model to database
+func (s *LedgerService) ModifyLedger(c core.Context, ledger *models.Ledger) error {
+	if ledger.LedgerId <= 0 {
+		return errs.ErrLedgerIdInvalid
+	}
+
+	ledger.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(ledger.LedgerId).Cols("name", "updated_unix_time").Where("deleted=?", false).Update(ledger)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrLedgerNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteLedger deletes an existed shared ledger and all its members from database
+func (s *LedgerService) DeleteLedger(c core.Context, ownerUid int64, ledgerId int64) error {
+	if ownerUid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Ledger{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	updateMemberModel := &models.LedgerMember{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(ledgerId).Cols("deleted", "deleted_unix_time").Where("owner_uid=? AND deleted=?", ownerUid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrLedgerNotFound
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("ledger_id=? AND deleted=?", ledgerId, false).Update(updateMemberModel)
+
+		return err
+	})
+}
+
+// CreateLedgerMember saves a new shared ledger member (pending invitation) model to database
+func (s *LedgerService) CreateLedgerMember(c core.Context, ledger *models.Ledger, member *models.LedgerMember) error {
+	if member.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if member.Uid == ledger.OwnerUid {
+		return errs.ErrCannotInviteLedgerOwner
+	}
+
+	if !member.Role.IsValid() {
+		return errs.ErrLedgerMemberRoleInvalid
+	}
+
+	member.MemberId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if member.MemberId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	member.LedgerId = ledger.LedgerId
+	member.Deleted = false
+	member.Status = models.LEDGER_MEMBER_STATUS_PENDING
+	member.CreatedUnixTime = time.Now().Unix()
+	member.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Where("ledger_id=? AND uid=? AND deleted=?", member.LedgerId, member.Uid, false).Exist(&models.LedgerMember{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrLedgerMemberAlreadyExists
+		}
+
+		_, err = sess.Insert(member)
+		return err
+	})
+}
+
+// AcceptLedgerInvitation updates the status of the pending invitation of the user to accepted
+func (s *LedgerService) AcceptLedgerInvitation(c core.Context, uid int64, memberId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if memberId <= 0 {
+		return errs.ErrLedgerMemberIdInvalid
+	}
+
+	updateModel := &models.LedgerMember{
+		Status:          models.LEDGER_MEMBER_STATUS_ACCEPTED,
+		UpdatedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		member := &models.LedgerMember{}
+		has, err := sess.ID(memberId).Where("uid=? AND deleted=?", uid, false).Get(member)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrLedgerMemberNotFound
+		} else if member.Status == models.LEDGER_MEMBER_STATUS_ACCEPTED {
+			return errs.ErrLedgerInvitationAlreadyAccepted
+		}
+
+		_, err = sess.ID(memberId).Cols("status", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		return err
+	})
+}
+
+// ModifyLedgerMemberRole updates the role of an existed shared ledger member
+func (s *LedgerService) ModifyLedgerMemberRole(c core.Context, member *models.LedgerMember) error {
+	if member.MemberId <= 0 {
+		return errs.ErrLedgerMemberIdInvalid
+	}
+
+	if !member.Role.IsValid() {
+		return errs.ErrLedgerMemberRoleInvalid
+	}
+
+	member.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(member.MemberId).Cols("role", "updated_unix_time").Where("deleted=?", false).Update(member)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrLedgerMemberNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteLedgerMember deletes an existed shared ledger member from database
+func (s *LedgerService) DeleteLedgerMember(c core.Context, memberId int64) error {
+	if memberId <= 0 {
+		return errs.ErrLedgerMemberIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.LedgerMember{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDB().DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(memberId).Cols("deleted", "deleted_unix_time").Where("deleted=?", false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrLedgerMemberNotFound
+		}
+
+		return err
+	})
+}
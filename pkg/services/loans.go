@@ -0,0 +1,165 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// LoanService represents loan service
+type LoanService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a loan service singleton instance
+var (
+	Loans = &LoanService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllLoansByUid returns all loan models of user
+func (s *LoanService) GetAllLoansByUid(c core.Context, uid int64) ([]*models.Loan, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var loans []*models.Loan
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).Find(&loans)
+
+	return loans, err
+}
+
+// GetLoanByLoanId returns a loan model according to loan id
+func (s *LoanService) GetLoanByLoanId(c core.Context, uid int64, loanId int64) (*models.Loan, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if loanId <= 0 {
+		return nil, errs.ErrLoanIdInvalid
+	}
+
+	loan := &models.Loan{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(loanId).Where("uid=? AND deleted=?", uid, false).Get(loan)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrLoanNotFound
+	}
+
+	return loan, nil
+}
+
+// CreateLoan saves a new loan model to database
+func (s *LoanService) CreateLoan(c core.Context, loan *models.Loan) error {
+	if loan.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if loan.PaidPeriods > loan.TermMonths {
+		return errs.ErrLoanPaidPeriodsExceedTermMonths
+	}
+
+	loan.LoanId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if loan.LoanId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	loan.Deleted = false
+	loan.CreatedUnixTime = time.Now().Unix()
+	loan.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(loan.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Where("uid=? AND deleted=? AND account_id=?", loan.Uid, false, loan.AccountId).Exist(&models.Loan{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrLoanAccountAlreadyHasLoan
+		}
+
+		_, err = sess.Insert(loan)
+		return err
+	})
+}
+
+// ModifyLoan saves an existed loan model to database
+func (s *LoanService) ModifyLoan(c core.Context, loan *models.Loan) error {
+	if loan.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if loan.PaidPeriods > loan.TermMonths {
+		return errs.ErrLoanPaidPeriodsExceedTermMonths
+	}
+
+	loan.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(loan.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(loan.LoanId).Cols("name", "payment_account_id", "principal_category_id", "interest_category_id", "paid_periods", "scheduled_enabled", "comment", "updated_unix_time").Where("uid=? AND deleted=?", loan.Uid, false).Update(loan)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrLoanNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteLoan deletes an existed loan from database
+func (s *LoanService) DeleteLoan(c core.Context, uid int64, loanId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.Loan{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(loanId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrLoanNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllLoans deletes all existed loans from database
+func (s *LoanService) DeleteAllLoans(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.Loan{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		return err
+	})
+}
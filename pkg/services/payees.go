@@ -0,0 +1,604 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// PayeeService represents payee service
+type PayeeService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a payee service singleton instance
+var (
+	Payees = &PayeeService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllPayeesByUid returns all payee models of user
+func (s *PayeeService) GetAllPayeesByUid(c core.Context, uid int64) ([]*models.Payee, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var payees []*models.Payee
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("display_order asc").Find(&payees)
+
+	return payees, err
+}
+
+// GetPayeeByPayeeId returns a payee model according to payee id
+func (s *PayeeService) GetPayeeByPayeeId(c core.Context, uid int64, payeeId int64) (*models.Payee, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if payeeId <= 0 {
+		return nil, errs.ErrPayeeIdInvalid
+	}
+
+	payee := &models.Payee{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(payeeId).Where("uid=? AND deleted=?", uid, false).Get(payee)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrPayeeNotFound
+	}
+
+	return payee, nil
+}
+
+// GetPayeesByPayeeIds returns payee models according to payee ids
+func (s *PayeeService) GetPayeesByPayeeIds(c core.Context, uid int64, payeeIds []int64) (map[int64]*models.Payee, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if payeeIds == nil {
+		return nil, errs.ErrPayeeIdInvalid
+	}
+
+	var payees []*models.Payee
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("payee_id", payeeIds).Find(&payees)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payeeMap := s.GetPayeeMapByList(payees)
+	return payeeMap, err
+}
+
+// GetMaxDisplayOrder returns the max display order
+func (s *PayeeService) GetMaxDisplayOrder(c core.Context, uid int64) (int32, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	payee := &models.Payee{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("uid", "deleted", "display_order").Where("uid=? AND deleted=?", uid, false).OrderBy("display_order desc").Limit(1).Get(payee)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if has {
+		return payee.DisplayOrder, nil
+	} else {
+		return 0, nil
+	}
+}
+
+// GetPayeesTotalAmounts returns total income amounts, total expense amounts and transaction counts of payees by specific date range
+func (s *PayeeService) GetPayeesTotalAmounts(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, payeeIds []int64, clientTimezone *time.Location, useTransactionTimezone bool) ([]*models.PayeeTotalAmount, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var startLocalDateTime, endLocalDateTime, startTransactionTime, endTransactionTime int64
+
+	if startUnixTime > 0 {
+		startLocalDateTime = utils.FormatUnixTimeToNumericLocalDateTime(startUnixTime, clientTimezone)
+		startUnixTime = utils.GetMinUnixTimeWithSameLocalDateTime(startUnixTime, utils.GetTimezoneOffsetMinutes(startUnixTime, clientTimezone))
+		startTransactionTime = utils.GetMinTransactionTimeFromUnixTime(startUnixTime)
+	}
+
+	if endUnixTime > 0 {
+		endLocalDateTime = utils.FormatUnixTimeToNumericLocalDateTime(endUnixTime, clientTimezone)
+		endUnixTime = utils.GetMaxUnixTimeWithSameLocalDateTime(endUnixTime, utils.GetTimezoneOffsetMinutes(endUnixTime, clientTimezone))
+		endTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(endUnixTime)
+	}
+
+	condition := "uid=? AND deleted=? AND payee_id<>? AND (type=? OR type=?)"
+	conditionParams := []any{uid, false, 0, models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_EXPENSE}
+
+	minTransactionTime := startTransactionTime
+	maxTransactionTime := endTransactionTime
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= 0 {
+		var transactions []*models.Transaction
+
+		finalCondition := condition
+		finalConditionParams := make([]any, 0, 7)
+		finalConditionParams = append(finalConditionParams, conditionParams...)
+
+		if minTransactionTime > 0 {
+			finalCondition = finalCondition + " AND transaction_time>=?"
+			finalConditionParams = append(finalConditionParams, minTransactionTime)
+		}
+
+		if maxTransactionTime > 0 {
+			finalCondition = finalCondition + " AND transaction_time<=?"
+			finalConditionParams = append(finalConditionParams, maxTransactionTime)
+		}
+
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, account_id, payee_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
+
+		if len(payeeIds) > 0 {
+			sess = sess.In("payee_id", payeeIds)
+		}
+
+		err := sess.Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			maxTransactionTime = -1
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	payeeTotalAmountsMap := make(map[string]*models.PayeeTotalAmount)
+
+	for i := 0; i < len(allTransactions); i++ {
+		transaction := allTransactions[i]
+		timeZone := clientTimezone
+
+		if useTransactionTimezone {
+			timeZone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		}
+
+		localDateTime := utils.FormatUnixTimeToNumericLocalDateTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), timeZone)
+
+		if (startLocalDateTime > 0 && localDateTime < startLocalDateTime) || (endLocalDateTime > 0 && localDateTime > endLocalDateTime) {
+			continue
+		}
+
+		groupKey := fmt.Sprintf("%d_%d", transaction.PayeeId, transaction.AccountId)
+		totalAmount, exists := payeeTotalAmountsMap[groupKey]
+
+		if !exists {
+			totalAmount = &models.PayeeTotalAmount{
+				PayeeId:   transaction.PayeeId,
+				AccountId: transaction.AccountId,
+			}
+
+			payeeTotalAmountsMap[groupKey] = totalAmount
+		}
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			totalAmount.IncomeAmount += transaction.Amount
+		} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			totalAmount.ExpenseAmount += transaction.Amount
+		}
+
+		totalAmount.TransactionCount++
+	}
+
+	payeeTotalAmounts := make([]*models.PayeeTotalAmount, 0, len(payeeTotalAmountsMap))
+
+	for _, totalAmount := range payeeTotalAmountsMap {
+		payeeTotalAmounts = append(payeeTotalAmounts, totalAmount)
+	}
+
+	return payeeTotalAmounts, nil
+}
+
+// CreatePayee saves a new payee model to database
+func (s *PayeeService) CreatePayee(c core.Context, payee *models.Payee) error {
+	if payee.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	exists, err := s.ExistsPayeeName(c, payee.Uid, payee.Name)
+
+	if err != nil {
+		return err
+	} else if exists {
+		return errs.ErrPayeeNameAlreadyExists
+	}
+
+	payee.PayeeId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if payee.PayeeId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	payee.Deleted = false
+	payee.CreatedUnixTime = time.Now().Unix()
+	payee.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(payee.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		err := s.isPayeeValid(sess, payee)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Insert(payee)
+		return err
+	})
+}
+
+// CreatePayees saves a few payee models to database
+func (s *PayeeService) CreatePayees(c core.Context, uid int64, payees []*models.Payee, skipExists bool) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	allPayeeNames := make([]string, len(payees))
+
+	for i := 0; i < len(payees); i++ {
+		allPayeeNames[i] = payees[i].Name
+	}
+
+	var existPayees []*models.Payee
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("name", allPayeeNames).Find(&existPayees)
+
+	if err != nil {
+		return err
+	} else if !skipExists && len(existPayees) > 0 {
+		return errs.ErrPayeeNameAlreadyExists
+	}
+
+	existsNamePayeeMap := make(map[string]*models.Payee, len(existPayees))
+
+	for i := 0; i < len(existPayees); i++ {
+		payee := existPayees[i]
+		existsNamePayeeMap[payee.Name] = payee
+	}
+
+	newPayees := make([]*models.Payee, 0, len(payees))
+	newPayeeNames := make(map[string]*models.Payee, len(payees))
+	var duplicatedPayees []*models.Payee
+
+	for i := 0; i < len(payees); i++ {
+		payee := payees[i]
+		existsPayee, exists := existsNamePayeeMap[payee.Name]
+
+		if exists {
+			payee.FillFromOtherPayee(existsPayee)
+			continue
+		}
+
+		if _, exists := newPayeeNames[payee.Name]; exists {
+			if !skipExists {
+				return errs.ErrPayeeNameAlreadyExists
+			}
+
+			duplicatedPayees = append(duplicatedPayees, payee)
+			continue
+		}
+
+		newPayees = append(newPayees, payee)
+		newPayeeNames[payee.Name] = payee
+	}
+
+	payeeUuids := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(newPayees)))
+
+	if len(payeeUuids) < len(newPayees) {
+		return errs.ErrSystemIsBusy
+	}
+
+	for i := 0; i < len(newPayees); i++ {
+		payee := newPayees[i]
+		payee.PayeeId = payeeUuids[i]
+		payee.Deleted = false
+		payee.CreatedUnixTime = time.Now().Unix()
+		payee.UpdatedUnixTime = time.Now().Unix()
+	}
+
+	for i := 0; i < len(duplicatedPayees); i++ {
+		payee := duplicatedPayees[i]
+		payee.FillFromOtherPayee(newPayeeNames[payee.Name])
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(newPayees); i++ {
+			payee := newPayees[i]
+			err := s.isPayeeValid(sess, payee)
+
+			if err != nil {
+				return err
+			}
+
+			_, err = sess.Insert(payee)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FillNewPayeesIdsAndTimes generates the ids of new payees which would be saved to database together with other data later
+func (s *PayeeService) FillNewPayeesIdsAndTimes(payees []*models.Payee) error {
+	payeeUuids := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(payees)))
+
+	if len(payeeUuids) < len(payees) {
+		return errs.ErrSystemIsBusy
+	}
+
+	now := time.Now().Unix()
+
+	for i := 0; i < len(payees); i++ {
+		payee := payees[i]
+		payee.PayeeId = payeeUuids[i]
+		payee.Deleted = false
+		payee.CreatedUnixTime = now
+		payee.UpdatedUnixTime = now
+	}
+
+	return nil
+}
+
+// ModifyPayee saves an existed payee model to database
+func (s *PayeeService) ModifyPayee(c core.Context, payee *models.Payee, payeeNameChanged bool) error {
+	if payee.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if payeeNameChanged {
+		exists, err := s.ExistsPayeeName(c, payee.Uid, payee.Name)
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrPayeeNameAlreadyExists
+		}
+	}
+
+	payee.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(payee.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		err := s.isPayeeValid(sess, payee)
+
+		if err != nil {
+			return err
+		}
+
+		updatedRows, err := sess.ID(payee.PayeeId).Cols("name", "default_category_id", "default_tag_ids", "extend", "comment", "updated_unix_time").Where("uid=? AND deleted=?", payee.Uid, false).Update(payee)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrPayeeNotFound
+		}
+
+		return err
+	})
+}
+
+// HidePayee updates hidden field of given payees
+func (s *PayeeService) HidePayee(c core.Context, uid int64, ids []int64, hidden bool) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Payee{
+		Hidden:          hidden,
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.Cols("hidden", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).In("payee_id", ids).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrPayeeNotFound
+		}
+
+		return err
+	})
+}
+
+// ModifyPayeeDisplayOrders updates display order of given payees
+func (s *PayeeService) ModifyPayeeDisplayOrders(c core.Context, uid int64, payees []*models.Payee) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	for i := 0; i < len(payees); i++ {
+		payees[i].UpdatedUnixTime = time.Now().Unix()
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(payees); i++ {
+			payee := payees[i]
+			updatedRows, err := sess.ID(payee.PayeeId).Cols("display_order", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(payee)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrPayeeNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeletePayee deletes an existed payee from database
+func (s *PayeeService) DeletePayee(c core.Context, uid int64, payeeId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Payee{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Cols("uid", "deleted", "payee_id").Where("uid=? AND deleted=? AND payee_id=?", uid, false, payeeId).Limit(1).Exist(&models.Transaction{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrPayeeInUseCannotBeDeleted
+		}
+
+		deletedRows, err := sess.ID(payeeId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrPayeeNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllPayees soft-deletes all payees for user
+func (s *PayeeService) DeleteAllPayees(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Payee{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	_, err := s.UserDataDB(uid).NewSession(c).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+	return err
+}
+
+// ExistsPayeeName returns whether the given payee name exists
+func (s *PayeeService) ExistsPayeeName(c core.Context, uid int64, name string) (bool, error) {
+	if name == "" {
+		return false, errs.ErrPayeeNameIsEmpty
+	}
+
+	return s.UserDataDB(uid).NewSession(c).Cols("name").Where("uid=? AND deleted=? AND name=?", uid, false, name).Exist(&models.Payee{})
+}
+
+// GetPayeeMapByList returns a payee map by a list
+func (s *PayeeService) GetPayeeMapByList(payees []*models.Payee) map[int64]*models.Payee {
+	payeeMap := make(map[int64]*models.Payee)
+
+	for i := 0; i < len(payees); i++ {
+		payee := payees[i]
+		payeeMap[payee.PayeeId] = payee
+	}
+	return payeeMap
+}
+
+// GetPayeeByName returns the first visible payee whose name or one of aliases matches the given name
+func (s *PayeeService) GetPayeeByName(payees []*models.Payee, name string) *models.Payee {
+	for i := 0; i < len(payees); i++ {
+		payee := payees[i]
+
+		if !payee.Hidden && payee.IsNameMatched(name) {
+			return payee
+		}
+	}
+
+	return nil
+}
+
+// GetPayeeIds converts a comma-separated string of payee ids into a slice of int64
+func (s *PayeeService) GetPayeeIds(payeeIds string) ([]int64, error) {
+	if payeeIds == "" || payeeIds == "0" {
+		return nil, nil
+	}
+
+	requestPayeeIds, err := utils.StringArrayToInt64Array(strings.Split(payeeIds, ","))
+
+	if err != nil {
+		return nil, errs.Or(err, errs.ErrPayeeIdInvalid)
+	}
+
+	return requestPayeeIds, nil
+}
+
+func (s *PayeeService) isPayeeValid(sess *xorm.Session, payee *models.Payee) error {
+	// check default category is valid
+	if payee.DefaultCategoryId != 0 {
+		category := &models.TransactionCategory{}
+		has, err := sess.ID(payee.DefaultCategoryId).Where("uid=? AND deleted=?", payee.Uid, false).Get(category)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTransactionCategoryNotFound
+		}
+
+		if category.Hidden {
+			return errs.ErrCannotUseHiddenTransactionCategory
+		}
+
+		if category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			return errs.ErrCannotUsePrimaryCategoryForTransaction
+		}
+	}
+
+	// check default tags are valid
+	tagIds := utils.ToUniqueInt64Slice(payee.GetDefaultTagIds())
+
+	if len(tagIds) > 0 {
+		var tags []*models.TransactionTag
+		err := sess.Where("uid=? AND deleted=?", payee.Uid, false).In("tag_id", tagIds).Find(&tags)
+
+		if err != nil {
+			return err
+		} else if len(tags) < len(tagIds) {
+			return errs.ErrTransactionTagNotFound
+		}
+
+		for i := 0; i < len(tags); i++ {
+			if tags[i].Hidden {
+				return errs.ErrCannotUseHiddenTransactionTag
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// ReconciliationSessionService represents reconciliation session service
+type ReconciliationSessionService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a reconciliation session service singleton instance
+var (
+	ReconciliationSessions = &ReconciliationSessionService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetReconciliationSessions returns all reconciliation session models of user, account id is optional
+func (s *ReconciliationSessionService) GetReconciliationSessions(c core.Context, uid int64, accountId int64) ([]*models.ReconciliationSession, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	condition := "uid=? AND deleted=?"
+	conditionParams := []any{uid, false}
+
+	if accountId > 0 {
+		condition = condition + " AND account_id=?"
+		conditionParams = append(conditionParams, accountId)
+	}
+
+	var sessions []*models.ReconciliationSession
+	err := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...).Find(&sessions)
+
+	return sessions, err
+}
+
+// GetReconciliationSessionById returns a reconciliation session model according to session id
+func (s *ReconciliationSessionService) GetReconciliationSessionById(c core.Context, uid int64, sessionId int64) (*models.ReconciliationSession, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if sessionId <= 0 {
+		return nil, errs.ErrReconciliationSessionIdInvalid
+	}
+
+	session := &models.ReconciliationSession{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(sessionId).Where("uid=? AND deleted=?", uid, false).Get(session)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrReconciliationSessionNotFound
+	}
+
+	return session, nil
+}
+
+// GetClearedBalance returns the cleared balance of the account until the statement end time of the reconciliation session
+func (s *ReconciliationSessionService) GetClearedBalance(c core.Context, session *models.ReconciliationSession) (*models.ReconciliationClearedBalance, error) {
+	if session.Uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	transactions, err := s.getTransactionsBeforeStatementEndTime(s.UserDataDB(session.Uid).NewSession(c), session)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewReconciliationClearedBalance(transactions), nil
+}
+
+// CreateReconciliationSession saves a new reconciliation session model to database
+func (s *ReconciliationSessionService) CreateReconciliationSession(c core.Context, session *models.ReconciliationSession) error {
+	if session.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	session.SessionId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if session.SessionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	session.Deleted = false
+	session.Status = models.RECONCILIATION_SESSION_STATUS_IN_PROGRESS
+	session.CreatedUnixTime = time.Now().Unix()
+	session.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(session.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Where("uid=? AND deleted=? AND account_id=? AND status=?", session.Uid, false, session.AccountId, models.RECONCILIATION_SESSION_STATUS_IN_PROGRESS).Exist(&models.ReconciliationSession{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrReconciliationSessionAlreadyInProgress
+		}
+
+		_, err = sess.Insert(session)
+		return err
+	})
+}
+
+// CompleteReconciliationSession marks all cleared transactions of the account until the statement end time as reconciled (only the transfer side in this account), returns error if the cleared balance does not match the statement ending balance
+func (s *ReconciliationSessionService) CompleteReconciliationSession(c core.Context, session *models.ReconciliationSession) error {
+	if session.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if session.IsCompleted() {
+		return errs.ErrReconciliationSessionAlreadyCompleted
+	}
+
+	now := time.Now().Unix()
+
+	transactionUpdateModel := &models.Transaction{
+		ClearedStatus:   models.TRANSACTION_CLEARED_STATUS_RECONCILED,
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(session.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		transactions, err := s.getTransactionsBeforeStatementEndTime(sess, session)
+
+		if err != nil {
+			return err
+		}
+
+		clearedBalance := models.NewReconciliationClearedBalance(transactions)
+
+		if clearedBalance.ClearedBalance != session.StatementEndingBalance {
+			return errs.ErrReconciliationBalanceNotMatch
+		}
+
+		toReconcileTransactionIds := make([]int64, 0, len(transactions))
+		reconciledCount := int32(0)
+
+		for i := 0; i < len(transactions); i++ {
+			transaction := transactions[i]
+
+			if transaction.ClearedStatus != models.TRANSACTION_CLEARED_STATUS_CLEARED {
+				continue
+			}
+
+			toReconcileTransactionIds = append(toReconcileTransactionIds, transaction.TransactionId)
+			reconciledCount++
+		}
+
+		if len(toReconcileTransactionIds) > 0 {
+			_, err = sess.Cols("cleared_status", "updated_unix_time").Where("uid=? AND deleted=?", session.Uid, false).In("transaction_id", toReconcileTransactionIds).Update(transactionUpdateModel)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		session.Status = models.RECONCILIATION_SESSION_STATUS_COMPLETED
+		session.ReconciledCount = reconciledCount
+		session.UpdatedUnixTime = now
+		session.CompletedUnixTime = now
+
+		updatedRows, err := sess.ID(session.SessionId).Cols("status", "reconciled_count", "updated_unix_time", "completed_unix_time").Where("uid=? AND deleted=? AND status=?", session.Uid, false, models.RECONCILIATION_SESSION_STATUS_IN_PROGRESS).Update(session)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrReconciliationSessionNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteReconciliationSession deletes an existed reconciliation session from database, the reconciled transactions are not affected (they can be changed back to cleared one by one)
+func (s *ReconciliationSessionService) DeleteReconciliationSession(c core.Context, uid int64, sessionId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.ReconciliationSession{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(sessionId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrReconciliationSessionNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllReconciliationSessions deletes all existed reconciliation sessions from database
+func (s *ReconciliationSessionService) DeleteAllReconciliationSessions(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.ReconciliationSession{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		return err
+	})
+}
+
+func (s *ReconciliationSessionService) getTransactionsBeforeStatementEndTime(sess *xorm.Session, session *models.ReconciliationSession) ([]*models.Transaction, error) {
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(session.StatementEndTime)
+
+	var transactions []*models.Transaction
+	err := sess.Cols("transaction_id", "type", "amount", "related_id", "related_account_amount", "cleared_status").Where("uid=? AND deleted=? AND account_id=? AND transaction_time<=?", session.Uid, false, session.AccountId, maxTransactionTime).Find(&transactions)
+
+	return transactions, err
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// SavedSearchService represents saved search service
+type SavedSearchService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a saved search service singleton instance
+var (
+	SavedSearches = &SavedSearchService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllSavedSearchesByUid returns all saved search models of user
+func (s *SavedSearchService) GetAllSavedSearchesByUid(c core.Context, uid int64) ([]*models.SavedSearch, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var savedSearches []*models.SavedSearch
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("display_order asc").Find(&savedSearches)
+
+	return savedSearches, err
+}
+
+// GetSavedSearchBySavedSearchId returns a saved search model according to saved search id
+func (s *SavedSearchService) GetSavedSearchBySavedSearchId(c core.Context, uid int64, savedSearchId int64) (*models.SavedSearch, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if savedSearchId <= 0 {
+		return nil, errs.ErrSavedSearchIdInvalid
+	}
+
+	savedSearch := &models.SavedSearch{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(savedSearchId).Where("uid=? AND deleted=?", uid, false).Get(savedSearch)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrSavedSearchNotFound
+	}
+
+	return savedSearch, nil
+}
+
+// GetSavedSearchByName returns a saved search model according to saved search name
+func (s *SavedSearchService) GetSavedSearchByName(c core.Context, uid int64, name string) (*models.SavedSearch, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if name == "" {
+		return nil, errs.ErrSavedSearchNameIsEmpty
+	}
+
+	savedSearch := &models.SavedSearch{}
+	has, err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND name=?", uid, false, name).Get(savedSearch)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrSavedSearchNotFound
+	}
+
+	return savedSearch, nil
+}
+
+// GetMaxDisplayOrder returns the max display order
+func (s *SavedSearchService) GetMaxDisplayOrder(c core.Context, uid int64) (int32, error) {
+	if uid <= 0 {
+		return 0, errs.ErrUserIdInvalid
+	}
+
+	savedSearch := &models.SavedSearch{}
+	has, err := s.UserDataDB(uid).NewSession(c).Cols("uid", "deleted", "display_order").Where("uid=? AND deleted=?", uid, false).OrderBy("display_order desc").Limit(1).Get(savedSearch)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if has {
+		return savedSearch.DisplayOrder, nil
+	} else {
+		return 0, nil
+	}
+}
+
+// CreateSavedSearch saves a new saved search model to database
+func (s *SavedSearchService) CreateSavedSearch(c core.Context, savedSearch *models.SavedSearch) error {
+	if savedSearch.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	exists, err := s.ExistsSavedSearchName(c, savedSearch.Uid, savedSearch.Name)
+
+	if err != nil {
+		return err
+	} else if exists {
+		return errs.ErrSavedSearchNameAlreadyExists
+	}
+
+	savedSearch.SavedSearchId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if savedSearch.SavedSearchId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	savedSearch.Deleted = false
+	savedSearch.CreatedUnixTime = time.Now().Unix()
+	savedSearch.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(savedSearch.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(savedSearch)
+		return err
+	})
+}
+
+// ModifySavedSearch saves an existed saved search model to database
+func (s *SavedSearchService) ModifySavedSearch(c core.Context, savedSearch *models.SavedSearch, savedSearchNameChanged bool) error {
+	if savedSearch.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if savedSearchNameChanged {
+		exists, err := s.ExistsSavedSearchName(c, savedSearch.Uid, savedSearch.Name)
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrSavedSearchNameAlreadyExists
+		}
+	}
+
+	savedSearch.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(savedSearch.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(savedSearch.SavedSearchId).Cols("name", "query", "updated_unix_time").Where("uid=? AND deleted=?", savedSearch.Uid, false).Update(savedSearch)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrSavedSearchNotFound
+		}
+
+		return err
+	})
+}
+
+// HideSavedSearch updates hidden field of given saved search ids
+func (s *SavedSearchService) HideSavedSearch(c core.Context, uid int64, ids []int64, hidden bool) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.SavedSearch{
+		Hidden:          hidden,
+		UpdatedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.Cols("hidden", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).In("saved_search_id", ids).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrSavedSearchNotFound
+		}
+
+		return err
+	})
+}
+
+// ModifySavedSearchDisplayOrders updates display order of given saved searches
+func (s *SavedSearchService) ModifySavedSearchDisplayOrders(c core.Context, uid int64, savedSearches []*models.SavedSearch) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	for i := 0; i < len(savedSearches); i++ {
+		savedSearches[i].UpdatedUnixTime = time.Now().Unix()
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(savedSearches); i++ {
+			savedSearch := savedSearches[i]
+			updatedRows, err := sess.ID(savedSearch.SavedSearchId).Cols("display_order", "updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(savedSearch)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrSavedSearchNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteSavedSearch deletes an existed saved search from database
+func (s *SavedSearchService) DeleteSavedSearch(c core.Context, uid int64, savedSearchId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.SavedSearch{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(savedSearchId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrSavedSearchNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllSavedSearches deletes all existed saved searches from database
+func (s *SavedSearchService) DeleteAllSavedSearches(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.SavedSearch{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// ExistsSavedSearchName returns whether the given saved search name exists
+func (s *SavedSearchService) ExistsSavedSearchName(c core.Context, uid int64, name string) (bool, error) {
+	if name == "" {
+		return false, errs.ErrSavedSearchNameIsEmpty
+	}
+
+	return s.UserDataDB(uid).NewSession(c).Cols("name").Where("uid=? AND deleted=? AND name=?", uid, false, name).Exist(&models.SavedSearch{})
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// SecurityService represents security service
+type SecurityService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a security service singleton instance
+var (
+	Securities = &SecurityService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllSecuritiesByUid returns all security models of user
+func (s *SecurityService) GetAllSecuritiesByUid(c core.Context, uid int64) ([]*models.Security, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var securities []*models.Security
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).Find(&securities)
+
+	return securities, err
+}
+
+// GetSecurityBySecurityId returns a security model according to security id
+func (s *SecurityService) GetSecurityBySecurityId(c core.Context, uid int64, securityId int64) (*models.Security, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if securityId <= 0 {
+		return nil, errs.ErrSecurityIdInvalid
+	}
+
+	security := &models.Security{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(securityId).Where("uid=? AND deleted=?", uid, false).Get(security)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrSecurityNotFound
+	}
+
+	return security, nil
+}
+
+// GetSecuritiesBySecurityIds returns security models according to security ids
+func (s *SecurityService) GetSecuritiesBySecurityIds(c core.Context, uid int64, securityIds []int64) (map[int64]*models.Security, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if len(securityIds) <= 0 {
+		return make(map[int64]*models.Security), nil
+	}
+
+	var securities []*models.Security
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("security_id", securityIds).Find(&securities)
+
+	if err != nil {
+		return nil, err
+	}
+
+	securityMap := make(map[int64]*models.Security, len(securities))
+
+	for i := 0; i < len(securities); i++ {
+		securityMap[securities[i].SecurityId] = securities[i]
+	}
+
+	return securityMap, nil
+}
+
+// CreateSecurity saves a new security model to database
+func (s *SecurityService) CreateSecurity(c core.Context, security *models.Security) error {
+	if security.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if !security.CostBasisMethod.IsValid() {
+		return errs.ErrSecurityCostBasisMethodInvalid
+	}
+
+	security.SecurityId = s.GenerateUuid(uuid.UUID_TYPE_INVESTMENT)
+
+	if security.SecurityId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	security.Deleted = false
+	security.CreatedUnixTime = time.Now().Unix()
+	security.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(security.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Where("uid=? AND deleted=? AND ticker=?", security.Uid, false, security.Ticker).Exist(&models.Security{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrSecurityTickerAlreadyExists
+		}
+
+		_, err = sess.Insert(security)
+		return err
+	})
+}
+
+// ModifySecurity saves an existed security model to database
+func (s *SecurityService) ModifySecurity(c core.Context, security *models.Security) error {
+	if security.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if !security.CostBasisMethod.IsValid() {
+		return errs.ErrSecurityCostBasisMethodInvalid
+	}
+
+	security.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(security.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Where("uid=? AND deleted=? AND ticker=? AND security_id<>?", security.Uid, false, security.Ticker, security.SecurityId).Exist(&models.Security{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrSecurityTickerAlreadyExists
+		}
+
+		updatedRows, err := sess.ID(security.SecurityId).Cols("ticker", "name", "cost_basis_method", "hidden", "comment", "updated_unix_time").Where("uid=? AND deleted=?", security.Uid, false).Update(security)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrSecurityNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteSecurity deletes an existed security and all its prices from database
+func (s *SecurityService) DeleteSecurity(c core.Context, uid int64, securityId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Security{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	updatePriceModel := &models.SecurityPrice{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		exists, err := sess.Cols("uid", "deleted", "security_id").Where("uid=? AND deleted=? AND security_id=?", uid, false, securityId).Limit(1).Exist(&models.InvestmentTransaction{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrSecurityInUseCannotBeDeleted
+		}
+
+		deletedRows, err := sess.ID(securityId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrSecurityNotFound
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND security_id=?", uid, false, securityId).Update(updatePriceModel)
+
+		return err
+	})
+}
+
+// DeleteAllSecurities deletes all existed securities and prices from database
+func (s *SecurityService) DeleteAllSecurities(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Security{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	updatePriceModel := &models.SecurityPrice{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updatePriceModel)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		return err
+	})
+}
+
+// GetSecurityPrices returns all price models of the security within the time range
+func (s *SecurityService) GetSecurityPrices(c core.Context, uid int64, securityId int64, minTime int64, maxTime int64) ([]*models.SecurityPrice, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if securityId <= 0 {
+		return nil, errs.ErrSecurityIdInvalid
+	}
+
+	condition := "uid=? AND deleted=? AND security_id=?"
+	conditionParams := []any{uid, false, securityId}
+
+	if minTime > 0 {
+		condition = condition + " AND price_time>=?"
+		conditionParams = append(conditionParams, minTime)
+	}
+
+	if maxTime > 0 {
+		condition = condition + " AND price_time<=?"
+		conditionParams = append(conditionParams, maxTime)
+	}
+
+	var prices []*models.SecurityPrice
+	err := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...).OrderBy("price_time asc").Find(&prices)
+
+	return prices, err
+}
+
+// GetAllSecurityPricesByUid returns all price models of all securities of user before the max time in ascending order of price time
+func (s *SecurityService) GetAllSecurityPricesByUid(c core.Context, uid int64, maxTime int64) ([]*models.SecurityPrice, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var prices []*models.SecurityPrice
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND price_time<=?", uid, false, maxTime).OrderBy("price_time asc").Find(&prices)
+
+	return prices, err
+}
+
+// GetLatestSecurityPrices returns the latest price model of each security before the max time
+func (s *SecurityService) GetLatestSecurityPrices(c core.Context, uid int64, securityIds []int64, maxTime int64) (map[int64]*models.SecurityPrice, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	priceMap := make(map[int64]*models.SecurityPrice, len(securityIds))
+
+	for i := 0; i < len(securityIds); i++ {
+		price := &models.SecurityPrice{}
+		has, err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND security_id=? AND price_time<=?", uid, false, securityIds[i], maxTime).OrderBy("price_time desc").Limit(1).Get(price)
+
+		if err != nil {
+			return nil, err
+		} else if has {
+			priceMap[securityIds[i]] = price
+		}
+	}
+
+	return priceMap, nil
+}
+
+// SaveSecurityPrice saves a security price model to database, the existed price of the security at the same time will be replaced
+func (s *SecurityService) SaveSecurityPrice(c core.Context, price *models.SecurityPrice) error {
+	if price.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if price.Price <= 0 {
+		return errs.ErrSecurityPriceInvalid
+	}
+
+	now := time.Now().Unix()
+
+	return s.UserDataDB(price.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		existedPrice := &models.SecurityPrice{}
+		has, err := sess.Where("uid=? AND deleted=? AND security_id=? AND price_time=?", price.Uid, false, price.SecurityId, price.PriceTime).Get(existedPrice)
+
+		if err != nil {
+			return err
+		}
+
+		price.UpdatedUnixTime = now
+
+		if has {
+			price.PriceId = existedPrice.PriceId
+			price.CreatedUnixTime = existedPrice.CreatedUnixTime
+			_, err = sess.ID(price.PriceId).Cols("price", "source", "updated_unix_time").Where("uid=? AND deleted=?", price.Uid, false).Update(price)
+			return err
+		}
+
+		price.PriceId = s.GenerateUuid(uuid.UUID_TYPE_INVESTMENT)
+
+		if price.PriceId < 1 {
+			return errs.ErrSystemIsBusy
+		}
+
+		price.Deleted = false
+		price.CreatedUnixTime = now
+
+		_, err = sess.Insert(price)
+		return err
+	})
+}
+
+// DeleteSecurityPrice deletes an existed security price from database
+func (s *SecurityService) DeleteSecurityPrice(c core.Context, uid int64, priceId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.SecurityPrice{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(priceId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrSecurityPriceNotFound
+		}
+
+		return err
+	})
+}
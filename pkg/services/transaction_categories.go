@@ -9,6 +9,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 	"github.com/mayswind/ezbookkeeping/pkg/uuid"
@@ -449,6 +450,130 @@ func (s *TransactionCategoryService) DeleteAllCategories(c core.Context, uid int
 	})
 }
 
+// GetAllDeletedCategoriesByUid returns all deleted transaction category models of user which can be restored, the sub-categories deleted together with their parent category are not included
+func (s *TransactionCategoryService) GetAllDeletedCategoriesByUid(c core.Context, uid int64) ([]*models.TransactionCategory, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var categories []*models.TransactionCategory
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, true).OrderBy("deleted_unix_time desc, type asc, display_order asc").Find(&categories)
+
+	if err != nil {
+		return nil, err
+	}
+
+	categoryMap := s.GetCategoryMapByList(categories)
+	deletedCategories := make([]*models.TransactionCategory, 0, len(categories))
+
+	for i := 0; i < len(categories); i++ {
+		category := categories[i]
+
+		if parentCategory, exists := categoryMap[category.ParentCategoryId]; exists && parentCategory.DeletedUnixTime == category.DeletedUnixTime {
+			continue
+		}
+
+		deletedCategories = append(deletedCategories, category)
+	}
+
+	return deletedCategories, nil
+}
+
+// RestoreCategory restores a deleted transaction category with the sub-categories deleted together
+func (s *TransactionCategoryService) RestoreCategory(c core.Context, uid int64, categoryId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if categoryId <= 0 {
+		return errs.ErrTransactionCategoryIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	restoreModel := &models.TransactionCategory{
+		Deleted:         false,
+		UpdatedUnixTime: now,
+		DeletedUnixTime: 0,
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		category := &models.TransactionCategory{}
+		has, err := sess.ID(categoryId).Where("uid=? AND deleted=?", uid, true).Get(category)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		if category.ParentCategoryId != models.LevelOneTransactionCategoryParentId {
+			exists, err := sess.ID(category.ParentCategoryId).Where("uid=? AND deleted=?", uid, false).Exist(&models.TransactionCategory{})
+
+			if err != nil {
+				return err
+			} else if !exists {
+				return errs.ErrTrashItemParentNotRestored
+			}
+		}
+
+		restoredRows, err := sess.Cols("deleted", "updated_unix_time", "deleted_unix_time").Where("uid=? AND deleted=? AND deleted_unix_time=? AND (category_id=? OR parent_category_id=?)", uid, true, category.DeletedUnixTime, category.CategoryId, category.CategoryId).Update(restoreModel)
+
+		if err != nil {
+			return err
+		} else if restoredRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeCategory permanently deletes a deleted transaction category and the sub-categories deleted together from database
+func (s *TransactionCategoryService) PurgeCategory(c core.Context, uid int64, categoryId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if categoryId <= 0 {
+		return errs.ErrTransactionCategoryIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		category := &models.TransactionCategory{}
+		has, err := sess.ID(categoryId).Where("uid=? AND deleted=?", uid, true).Get(category)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		deletedRows, err := sess.Where("uid=? AND deleted=? AND deleted_unix_time=? AND (category_id=? OR parent_category_id=?)", uid, true, category.DeletedUnixTime, category.CategoryId, category.CategoryId).Delete(&models.TransactionCategory{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeAllDeletedCategories permanently deletes all transaction categories which have been deleted before the specified unix time
+func (s *TransactionCategoryService) PurgeAllDeletedCategories(c core.Context, deletedBeforeUnixTime int64) error {
+	count, err := s.purgeDeletedRows(c, deletedBeforeUnixTime, &models.TransactionCategory{})
+
+	if count > 0 {
+		log.Infof(c, "[transaction_categories.PurgeAllDeletedCategories] %d deleted transaction categories have been purged", count)
+	} else if err == nil {
+		log.Infof(c, "[transaction_categories.PurgeAllDeletedCategories] no deleted transaction categories have been purged")
+	}
+
+	return err
+}
+
 // GetCategoryMapByList returns a transaction category map by a list
 func (s *TransactionCategoryService) GetCategoryMapByList(categories []*models.TransactionCategory) map[int64]*models.TransactionCategory {
 	categoryMap := make(map[int64]*models.TransactionCategory)
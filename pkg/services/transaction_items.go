@@ -1,6 +1,7 @@
 package services
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 	"github.com/mayswind/ezbookkeeping/pkg/uuid"
@@ -132,6 +134,172 @@ func (s *TransactionItemService) GetAllItemIdsOfTransactions(c core.Context, uid
 	return allTransactionItemIds, err
 }
 
+// GetAllItemIndexesOfTransactions returns transaction item indexes for given transactions
+func (s *TransactionItemService) GetAllItemIndexesOfTransactions(c core.Context, uid int64, transactionIds []int64) (map[int64][]*models.TransactionItemIndex, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var itemIndexes []*models.TransactionItemIndex
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).OrderBy("transaction_id asc, item_index_id asc").Find(&itemIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	allTransactionItemIndexes := make(map[int64][]*models.TransactionItemIndex)
+
+	for i := 0; i < len(itemIndexes); i++ {
+		itemIndex := itemIndexes[i]
+		allTransactionItemIndexes[itemIndex.TransactionId] = append(allTransactionItemIndexes[itemIndex.TransactionId], itemIndex)
+	}
+
+	return allTransactionItemIndexes, nil
+}
+
+// GetItemsMonthlyTotalAmounts returns monthly total quantities and line amounts of transaction items in expense transactions grouped by item, account and unit
+func (s *TransactionItemService) GetItemsMonthlyTotalAmounts(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, itemIds []int64, clientTimezone *time.Location, useTransactionTimezone bool) (map[int32][]*models.TransactionItemTotalAmount, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var startTransactionTime, endTransactionTime int64
+	var err error
+
+	if startYear > 0 && startMonth > 0 {
+		startTransactionTime, _, err = utils.GetTransactionTimeRangeByYearMonth(startYear, startMonth)
+
+		if err != nil {
+			return nil, errs.ErrSystemError
+		}
+	}
+
+	if endYear > 0 && endMonth > 0 {
+		_, endTransactionTime, err = utils.GetTransactionTimeRangeByYearMonth(endYear, endMonth)
+
+		if err != nil {
+			return nil, errs.ErrSystemError
+		}
+	}
+
+	condition := "uid=? AND deleted=? AND (quantity<>? OR amount<>?)"
+	conditionParams := []any{uid, false, 0, 0}
+
+	if startTransactionTime > 0 {
+		condition = condition + " AND transaction_time>=?"
+		conditionParams = append(conditionParams, startTransactionTime)
+	}
+
+	if endTransactionTime > 0 {
+		condition = condition + " AND transaction_time<=?"
+		conditionParams = append(conditionParams, endTransactionTime)
+	}
+
+	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
+
+	if len(itemIds) > 0 {
+		sess = sess.In("item_id", itemIds)
+	}
+
+	var itemIndexes []*models.TransactionItemIndex
+	err = sess.OrderBy("transaction_time asc").Find(&itemIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transactionIds := make([]int64, 0, len(itemIndexes))
+
+	for i := 0; i < len(itemIndexes); i++ {
+		transactionIds = append(transactionIds, itemIndexes[i].TransactionId)
+	}
+
+	transactionIds = utils.ToUniqueInt64Slice(transactionIds)
+	transactionMap := make(map[int64]*models.Transaction, len(transactionIds))
+
+	for i := 0; i < len(transactionIds); i += pageCountForLoadTransactionAmounts {
+		end := i + pageCountForLoadTransactionAmounts
+
+		if end > len(transactionIds) {
+			end = len(transactionIds)
+		}
+
+		var transactions []*models.Transaction
+		err = s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, account_id, transaction_time, timezone_utc_offset").Where("uid=? AND deleted=? AND type=?", uid, false, models.TRANSACTION_DB_TYPE_EXPENSE).In("transaction_id", transactionIds[i:end]).Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for j := 0; j < len(transactions); j++ {
+			transactionMap[transactions[j].TransactionId] = transactions[j]
+		}
+	}
+
+	startYearMonth := startYear*100 + startMonth
+	endYearMonth := endYear*100 + endMonth
+	itemsMonthlyTotalAmountsMap := make(map[int32]map[string]*models.TransactionItemTotalAmount)
+
+	for i := 0; i < len(itemIndexes); i++ {
+		itemIndex := itemIndexes[i]
+		transaction, exists := transactionMap[itemIndex.TransactionId]
+
+		if !exists {
+			continue
+		}
+
+		timeZone := clientTimezone
+
+		if useTransactionTimezone {
+			timeZone = time.FixedZone("Transaction Timezone", int(transaction.TimezoneUtcOffset)*60)
+		}
+
+		yearMonth := utils.FormatUnixTimeToNumericYearMonth(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime), timeZone)
+
+		if (startYearMonth > 0 && yearMonth < startYearMonth) || (endYearMonth > 0 && yearMonth > endYearMonth) {
+			continue
+		}
+
+		monthlyTotalAmounts, exists := itemsMonthlyTotalAmountsMap[yearMonth]
+
+		if !exists {
+			monthlyTotalAmounts = make(map[string]*models.TransactionItemTotalAmount)
+			itemsMonthlyTotalAmountsMap[yearMonth] = monthlyTotalAmounts
+		}
+
+		// the quantities in different units cannot be summed up, so they are grouped by unit
+		groupKey := fmt.Sprintf("%d_%d_%s", itemIndex.ItemId, transaction.AccountId, itemIndex.Unit)
+		totalAmount, exists := monthlyTotalAmounts[groupKey]
+
+		if !exists {
+			totalAmount = &models.TransactionItemTotalAmount{
+				ItemId:    itemIndex.ItemId,
+				AccountId: transaction.AccountId,
+				Unit:      itemIndex.Unit,
+			}
+
+			monthlyTotalAmounts[groupKey] = totalAmount
+		}
+
+		totalAmount.Quantity += itemIndex.Quantity
+		totalAmount.Amount += itemIndex.Amount
+	}
+
+	itemsMonthlyTotalAmounts := make(map[int32][]*models.TransactionItemTotalAmount, len(itemsMonthlyTotalAmountsMap))
+
+	for yearMonth, monthlyTotalAmounts := range itemsMonthlyTotalAmountsMap {
+		totalAmounts := make([]*models.TransactionItemTotalAmount, 0, len(monthlyTotalAmounts))
+
+		for _, totalAmount := range monthlyTotalAmounts {
+			totalAmounts = append(totalAmounts, totalAmount)
+		}
+
+		itemsMonthlyTotalAmounts[yearMonth] = totalAmounts
+	}
+
+	return itemsMonthlyTotalAmounts, nil
+}
+
 // GetGroupedTransactionItemIds returns a map of transaction item ids grouped by transaction id
 func (s *TransactionItemService) GetGroupedTransactionItemIds(itemIndexes []*models.TransactionItemIndex) map[int64][]int64 {
 	allTransactionItemIds := make(map[int64][]int64)
@@ -373,6 +541,110 @@ func (s *TransactionItemService) DeleteItem(c core.Context, uid int64, itemId in
 	})
 }
 
+// GetAllDeletedItemsByUid returns all deleted transaction item models of user
+func (s *TransactionItemService) GetAllDeletedItemsByUid(c core.Context, uid int64) ([]*models.TransactionItem, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var items []*models.TransactionItem
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, true).OrderBy("deleted_unix_time desc, display_order asc").Find(&items)
+
+	return items, err
+}
+
+// RestoreItem restores a deleted transaction item, the item will be ungrouped if its item group has been deleted
+func (s *TransactionItemService) RestoreItem(c core.Context, uid int64, itemId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if itemId <= 0 {
+		return errs.ErrTransactionItemIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		item := &models.TransactionItem{}
+		has, err := sess.ID(itemId).Where("uid=? AND deleted=?", uid, true).Get(item)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		exists, err := sess.Cols("name").Where("uid=? AND deleted=? AND name=?", uid, false, item.Name).Exist(&models.TransactionItem{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrTransactionItemNameAlreadyExists
+		}
+
+		if item.ItemGroupId > 0 {
+			exists, err = sess.ID(item.ItemGroupId).Where("uid=? AND deleted=?", uid, false).Exist(&models.TransactionItemGroup{})
+
+			if err != nil {
+				return err
+			} else if !exists {
+				item.ItemGroupId = 0
+			}
+		}
+
+		item.Deleted = false
+		item.UpdatedUnixTime = now
+		item.DeletedUnixTime = 0
+
+		restoredRows, err := sess.ID(item.ItemId).Cols("item_group_id", "deleted", "updated_unix_time", "deleted_unix_time").Where("uid=? AND deleted=?", uid, true).Update(item)
+
+		if err != nil {
+			return err
+		} else if restoredRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeItem permanently deletes a deleted transaction item from database
+func (s *TransactionItemService) PurgeItem(c core.Context, uid int64, itemId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if itemId <= 0 {
+		return errs.ErrTransactionItemIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(itemId).Where("uid=? AND deleted=?", uid, true).Delete(&models.TransactionItem{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeAllDeletedItems permanently deletes all transaction items which have been deleted before the specified unix time
+func (s *TransactionItemService) PurgeAllDeletedItems(c core.Context, deletedBeforeUnixTime int64) error {
+	count, err := s.purgeDeletedRows(c, deletedBeforeUnixTime, &models.TransactionItem{})
+
+	if count > 0 {
+		log.Infof(c, "[transaction_items.PurgeAllDeletedItems] %d deleted transaction items have been purged", count)
+	} else if err == nil {
+		log.Infof(c, "[transaction_items.PurgeAllDeletedItems] no deleted transaction items have been purged")
+	}
+
+	return err
+}
+
 // ExistsItemName returns whether the given item name exists
 func (s *TransactionItemService) ExistsItemName(c core.Context, uid int64, name string) (bool, error) {
 	if name == "" {
@@ -11,6 +11,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/storage"
 	"github.com/mayswind/ezbookkeeping/pkg/uuid"
@@ -134,39 +135,50 @@ func (s *TransactionPictureService) GetPictureInfosByTransactionIds(c core.Conte
 
 // GetPictureByPictureId returns the transaction picture data according to transaction picture id
 func (s *TransactionPictureService) GetPictureByPictureId(c core.Context, uid int64, pictureId int64, fileExtension string) ([]byte, error) {
+	_, pictureData, err := s.GetAttachmentByPictureId(c, uid, pictureId, fileExtension)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pictureData, nil
+}
+
+// GetAttachmentByPictureId returns the transaction attachment info model and data according to transaction picture id
+func (s *TransactionPictureService) GetAttachmentByPictureId(c core.Context, uid int64, pictureId int64, fileExtension string) (*models.TransactionPictureInfo, []byte, error) {
 	if uid <= 0 {
-		return nil, errs.ErrUserIdInvalid
+		return nil, nil, errs.ErrUserIdInvalid
 	}
 
 	if pictureId <= 0 {
-		return nil, errs.ErrTransactionPictureIdInvalid
+		return nil, nil, errs.ErrTransactionPictureIdInvalid
 	}
 
 	pictureInfo := &models.TransactionPictureInfo{}
 	has, err := s.UserDataDB(uid).NewSession(c).ID(pictureId).Where("uid=? AND deleted=?", uid, false).Get(pictureInfo)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	} else if !has {
-		return nil, errs.ErrTransactionPictureNotFound
+		return nil, nil, errs.ErrTransactionPictureNotFound
 	}
 
 	if pictureInfo.PictureExtension == "" {
-		return nil, errs.ErrTransactionPictureNotFound
+		return nil, nil, errs.ErrTransactionPictureNotFound
 	}
 
 	if pictureInfo.PictureExtension != fileExtension {
-		return nil, errs.ErrTransactionPictureExtensionInvalid
+		return nil, nil, errs.ErrTransactionPictureExtensionInvalid
 	}
 
 	pictureFile, err := s.ReadTransactionPicture(c, pictureInfo.Uid, pictureInfo.PictureId, pictureInfo.PictureExtension)
 
 	if os.IsNotExist(err) {
-		return nil, errs.ErrTransactionPictureNoExists
+		return nil, nil, errs.ErrTransactionPictureNoExists
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defer pictureFile.Close()
@@ -174,10 +186,10 @@ func (s *TransactionPictureService) GetPictureByPictureId(c core.Context, uid in
 	pictureData, err := io.ReadAll(pictureFile)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return pictureData, nil
+	return pictureInfo, pictureData, nil
 }
 
 // UploadPicture uploads the transaction picture for specified user
@@ -241,6 +253,65 @@ func (s *TransactionPictureService) RemoveUnusedTransactionPicture(c core.Contex
 	})
 }
 
+// DeletePictureFiles deletes the files of purged transaction pictures from object storage
+func (s *TransactionPictureService) DeletePictureFiles(c core.Context, pictureInfos []*models.TransactionPictureInfo) error {
+	var errors []error
+
+	for i := 0; i < len(pictureInfos); i++ {
+		pictureInfo := pictureInfos[i]
+		err := s.DeleteTransactionPicture(c, pictureInfo.Uid, pictureInfo.PictureId, pictureInfo.PictureExtension)
+
+		if err != nil {
+			log.Warnf(c, "[transaction_pictures.DeletePictureFiles] failed to delete picture file \"id:%d\" of user \"uid:%d\", because %s", pictureInfo.PictureId, pictureInfo.Uid, err.Error())
+			errors = append(errors, err)
+		}
+	}
+
+	return errs.NewMultiErrorOrNil(errors...)
+}
+
+// PurgeAllDeletedPictures permanently deletes all transaction pictures and their files which have been deleted before the specified unix time
+func (s *TransactionPictureService) PurgeAllDeletedPictures(c core.Context, deletedBeforeUnixTime int64) error {
+	var errors []error
+	totalCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var pictureInfos []*models.TransactionPictureInfo
+
+		err := s.UserDataDBByIndex(i).DoTransaction(c, func(sess *xorm.Session) error {
+			err := sess.Cols("uid", "picture_id", "picture_extension").Where("deleted=? AND deleted_unix_time<?", true, deletedBeforeUnixTime).Find(&pictureInfos)
+
+			if err != nil || len(pictureInfos) < 1 {
+				return err
+			}
+
+			_, err = sess.Where("deleted=? AND deleted_unix_time<?", true, deletedBeforeUnixTime).Delete(&models.TransactionPictureInfo{})
+			return err
+		})
+
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+
+		err = s.DeletePictureFiles(c, pictureInfos)
+
+		if err != nil {
+			errors = append(errors, err)
+		}
+
+		totalCount += len(pictureInfos)
+	}
+
+	if totalCount > 0 {
+		log.Infof(c, "[transaction_pictures.PurgeAllDeletedPictures] %d deleted transaction pictures have been purged", totalCount)
+	} else if len(errors) == 0 {
+		log.Infof(c, "[transaction_pictures.PurgeAllDeletedPictures] no deleted transaction pictures have been purged")
+	}
+
+	return errs.NewMultiErrorOrNil(errors...)
+}
+
 // GetPictureInfoMapByList returns a transaction picture info list map by a list
 func (s *TransactionPictureService) GetPictureInfoMapByList(pictureInfos []*models.TransactionPictureInfo) map[int64]*models.TransactionPictureInfo {
 	pictureInfoMap := make(map[int64]*models.TransactionPictureInfo)
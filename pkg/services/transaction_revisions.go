@@ -0,0 +1,116 @@
+package services
+
+import (
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+)
+
+// TransactionRevisionService represents transaction revision service
+type TransactionRevisionService struct {
+	ServiceUsingDB
+}
+
+// Initialize a transaction revision service singleton instance
+var (
+	TransactionRevisions = &TransactionRevisionService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+	}
+)
+
+// GetRevisionsByTransactionId returns all transaction revision models of specified transaction in descending order of revision time
+func (s *TransactionRevisionService) GetRevisionsByTransactionId(c core.Context, uid int64, transactionId int64) ([]*models.TransactionRevision, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if transactionId <= 0 {
+		return nil, errs.ErrTransactionIdInvalid
+	}
+
+	var revisions []*models.TransactionRevision
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND transaction_id=?", uid, false, transactionId).OrderBy("created_unix_time desc, revision_id desc").Find(&revisions)
+
+	return revisions, err
+}
+
+// GetRevisionByRevisionId returns a transaction revision model according to transaction revision id
+func (s *TransactionRevisionService) GetRevisionByRevisionId(c core.Context, uid int64, revisionId int64) (*models.TransactionRevision, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if revisionId <= 0 {
+		return nil, errs.ErrTransactionRevisionIdInvalid
+	}
+
+	revision := &models.TransactionRevision{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(revisionId).Where("uid=? AND deleted=?", uid, false).Get(revision)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrTransactionRevisionNotFound
+	}
+
+	return revision, nil
+}
+
+// GetRevisionOperatorUids returns the unique operator uids of transaction revisions
+func (s *TransactionRevisionService) GetRevisionOperatorUids(revisions []*models.TransactionRevision) []int64 {
+	operatorUids := make([]int64, 0, len(revisions))
+	operatorUidExists := make(map[int64]bool, len(revisions))
+
+	for i := 0; i < len(revisions); i++ {
+		operatorUid := revisions[i].OperatorUid
+
+		if operatorUid <= 0 || operatorUidExists[operatorUid] {
+			continue
+		}
+
+		operatorUids = append(operatorUids, operatorUid)
+		operatorUidExists[operatorUid] = true
+	}
+
+	return operatorUids
+}
+
+func updateAllTransactionRevisionSnapshots(sess *xorm.Session, uid int64, updateSnapshot func(snapshot *models.TransactionRevisionSnapshot) bool) error {
+	lastRevisionId := int64(0)
+
+	for {
+		var revisions []*models.TransactionRevision
+		err := sess.Cols("revision_id", "uid", "before", "after").Where("uid=? AND revision_id>?", uid, lastRevisionId).OrderBy("revision_id asc").Limit(pageCountForMigrateAccountAmounts).Find(&revisions)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(revisions); i++ {
+			revision := revisions[i]
+			beforeUpdated := revision.Before != nil && updateSnapshot(revision.Before)
+			afterUpdated := revision.After != nil && updateSnapshot(revision.After)
+
+			if !beforeUpdated && !afterUpdated {
+				continue
+			}
+
+			_, err = sess.ID(revision.RevisionId).Cols("before", "after").Where("uid=?", uid).Update(revision)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(revisions) < pageCountForMigrateAccountAmounts {
+			return nil
+		}
+
+		lastRevisionId = revisions[len(revisions)-1].RevisionId
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/utils"
 	"github.com/mayswind/ezbookkeeping/pkg/uuid"
@@ -464,6 +465,110 @@ func (s *TransactionTagService) DeleteAllTags(c core.Context, uid int64) error {
 	})
 }
 
+// GetAllDeletedTagsByUid returns all deleted transaction tag models of user
+func (s *TransactionTagService) GetAllDeletedTagsByUid(c core.Context, uid int64) ([]*models.TransactionTag, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var tags []*models.TransactionTag
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, true).OrderBy("deleted_unix_time desc, display_order asc").Find(&tags)
+
+	return tags, err
+}
+
+// RestoreTag restores a deleted transaction tag, the tag will be ungrouped if its tag group has been deleted
+func (s *TransactionTagService) RestoreTag(c core.Context, uid int64, tagId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if tagId <= 0 {
+		return errs.ErrTransactionTagIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		tag := &models.TransactionTag{}
+		has, err := sess.ID(tagId).Where("uid=? AND deleted=?", uid, true).Get(tag)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		exists, err := sess.Cols("name").Where("uid=? AND deleted=? AND name=?", uid, false, tag.Name).Exist(&models.TransactionTag{})
+
+		if err != nil {
+			return err
+		} else if exists {
+			return errs.ErrTransactionTagNameAlreadyExists
+		}
+
+		if tag.TagGroupId > 0 {
+			exists, err = sess.ID(tag.TagGroupId).Where("uid=? AND deleted=?", uid, false).Exist(&models.TransactionTagGroup{})
+
+			if err != nil {
+				return err
+			} else if !exists {
+				tag.TagGroupId = 0
+			}
+		}
+
+		tag.Deleted = false
+		tag.UpdatedUnixTime = now
+		tag.DeletedUnixTime = 0
+
+		restoredRows, err := sess.ID(tag.TagId).Cols("tag_group_id", "deleted", "updated_unix_time", "deleted_unix_time").Where("uid=? AND deleted=?", uid, true).Update(tag)
+
+		if err != nil {
+			return err
+		} else if restoredRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeTag permanently deletes a deleted transaction tag from database
+func (s *TransactionTagService) PurgeTag(c core.Context, uid int64, tagId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if tagId <= 0 {
+		return errs.ErrTransactionTagIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(tagId).Where("uid=? AND deleted=?", uid, true).Delete(&models.TransactionTag{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// PurgeAllDeletedTags permanently deletes all transaction tags which have been deleted before the specified unix time
+func (s *TransactionTagService) PurgeAllDeletedTags(c core.Context, deletedBeforeUnixTime int64) error {
+	count, err := s.purgeDeletedRows(c, deletedBeforeUnixTime, &models.TransactionTag{})
+
+	if count > 0 {
+		log.Infof(c, "[transaction_tags.PurgeAllDeletedTags] %d deleted transaction tags have been purged", count)
+	} else if err == nil {
+		log.Infof(c, "[transaction_tags.PurgeAllDeletedTags] no deleted transaction tags have been purged")
+	}
+
+	return err
+}
+
 // ExistsTagName returns whether the given tag name exists
 func (s *TransactionTagService) ExistsTagName(c core.Context, uid int64, name string) (bool, error) {
 	if name == "" {
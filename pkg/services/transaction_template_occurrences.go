@@ -0,0 +1,188 @@
+package services
+
+import (
+	"time"
+
+	"xorm.io/xorm"
+
+	"github.com/mayswind/ezbookkeeping/pkg/core"
+	"github.com/mayswind/ezbookkeeping/pkg/datastore"
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/uuid"
+)
+
+// TransactionTemplateOccurrenceService represents transaction template occurrence service
+type TransactionTemplateOccurrenceService struct {
+	ServiceUsingDB
+	ServiceUsingUuid
+}
+
+// Initialize a transaction template occurrence service singleton instance
+var (
+	TransactionTemplateOccurrences = &TransactionTemplateOccurrenceService{
+		ServiceUsingDB: ServiceUsingDB{
+			container: datastore.Container,
+		},
+		ServiceUsingUuid: ServiceUsingUuid{
+			container: uuid.Container,
+		},
+	}
+)
+
+// GetAllOccurrencesByUid returns all transaction template occurrence models of user, status is optional
+func (s *TransactionTemplateOccurrenceService) GetAllOccurrencesByUid(c core.Context, uid int64, status models.TransactionTemplateOccurrenceStatus) ([]*models.TransactionTemplateOccurrence, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	condition := "uid=? AND deleted=?"
+	conditionParams := []any{uid, false}
+
+	if status > 0 {
+		condition = condition + " AND status=?"
+		conditionParams = append(conditionParams, status)
+	}
+
+	var occurrences []*models.TransactionTemplateOccurrence
+	err := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...).OrderBy("scheduled_time asc").Find(&occurrences)
+
+	return occurrences, err
+}
+
+// GetAllUnprocessedOccurrencesByUid returns all pending, skipped and postponed transaction template occurrence models of user which are not processed by the scheduled task
+func (s *TransactionTemplateOccurrenceService) GetAllUnprocessedOccurrencesByUid(c core.Context, uid int64) ([]*models.TransactionTemplateOccurrence, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var occurrences []*models.TransactionTemplateOccurrence
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND status<>?", uid, false, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_CONFIRMED).Find(&occurrences)
+
+	return occurrences, err
+}
+
+// GetOccurrenceByOccurrenceId returns a transaction template occurrence model according to occurrence id
+func (s *TransactionTemplateOccurrenceService) GetOccurrenceByOccurrenceId(c core.Context, uid int64, occurrenceId int64) (*models.TransactionTemplateOccurrence, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if occurrenceId <= 0 {
+		return nil, errs.ErrTransactionTemplateOccurrenceIdInvalid
+	}
+
+	occurrence := &models.TransactionTemplateOccurrence{}
+	has, err := s.UserDataDB(uid).NewSession(c).ID(occurrenceId).Where("uid=? AND deleted=?", uid, false).Get(occurrence)
+
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errs.ErrTransactionTemplateOccurrenceNotFound
+	}
+
+	return occurrence, nil
+}
+
+// CreateOccurrence saves a new transaction template occurrence model to database, the unique index of occurrence would reject it if the same occurrence of the template has been processed
+func (s *TransactionTemplateOccurrenceService) CreateOccurrence(c core.Context, occurrence *models.TransactionTemplateOccurrence) error {
+	if occurrence.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	occurrence.OccurrenceId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if occurrence.OccurrenceId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	occurrence.Deleted = false
+	occurrence.CreatedUnixTime = time.Now().Unix()
+	occurrence.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(occurrence.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Insert(occurrence)
+		return err
+	})
+}
+
+// SkipPendingOccurrence marks the pending transaction template occurrence as skipped
+func (s *TransactionTemplateOccurrenceService) SkipPendingOccurrence(c core.Context, uid int64, occurrenceId int64) error {
+	return s.updatePendingOccurrenceStatus(c, uid, occurrenceId, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_SKIPPED, 0)
+}
+
+// DeleteOccurrence permanently deletes an existed skipped or postponed transaction template occurrence from database, so that the occurrence would be created as scheduled
+func (s *TransactionTemplateOccurrenceService) DeleteOccurrence(c core.Context, uid int64, occurrenceId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		occurrence := &models.TransactionTemplateOccurrence{}
+		has, err := sess.ID(occurrenceId).Where("uid=? AND deleted=?", uid, false).Get(occurrence)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTransactionTemplateOccurrenceNotFound
+		}
+
+		if (occurrence.Status != models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_SKIPPED && occurrence.Status != models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED) ||
+			occurrence.ScheduledTime <= now {
+			return errs.ErrTransactionTemplateOccurrenceCannotBeDeleted
+		}
+
+		deletedRows, err := sess.ID(occurrenceId).Where("uid=? AND deleted=? AND status=?", uid, false, occurrence.Status).Delete(&models.TransactionTemplateOccurrence{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTransactionTemplateOccurrenceNotFound
+		}
+
+		return err
+	})
+}
+
+// DeleteAllOccurrences deletes all existed transaction template occurrences from database
+func (s *TransactionTemplateOccurrenceService) DeleteAllOccurrences(c core.Context, uid int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.TransactionTemplateOccurrence{
+		Deleted:         true,
+		DeletedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		return err
+	})
+}
+
+func (s *TransactionTemplateOccurrenceService) updatePendingOccurrenceStatus(c core.Context, uid int64, occurrenceId int64, status models.TransactionTemplateOccurrenceStatus, transactionId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.TransactionTemplateOccurrence{
+		Status:          status,
+		TransactionId:   transactionId,
+		UpdatedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(occurrenceId).Cols("status", "transaction_id", "updated_unix_time").Where("uid=? AND deleted=? AND status=?", uid, false, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrTransactionTemplateOccurrenceNotPending
+		}
+
+		return err
+	})
+}
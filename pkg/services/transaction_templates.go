@@ -8,6 +8,7 @@ import (
 	"github.com/mayswind/ezbookkeeping/pkg/core"
 	"github.com/mayswind/ezbookkeeping/pkg/datastore"
 	"github.com/mayswind/ezbookkeeping/pkg/errs"
+	"github.com/mayswind/ezbookkeeping/pkg/log"
 	"github.com/mayswind/ezbookkeeping/pkg/models"
 	"github.com/mayswind/ezbookkeeping/pkg/uuid"
 )
@@ -149,7 +150,7 @@ func (s *TransactionTemplateService) ModifyTemplate(c core.Context, template *mo
 			return err
 		}
 
-		updatedRows, err := sess.ID(template.TemplateId).Cols("name", "type", "category_id", "account_id", "scheduled_frequency_type", "scheduled_frequency", "scheduled_start_time", "scheduled_end_time", "scheduled_at", "scheduled_timezone_utc_offset", "tag_ids", "amount", "related_account_id", "related_account_amount", "hide_amount", "comment", "updated_unix_time").Where("uid=? AND deleted=?", template.Uid, false).Update(template)
+		updatedRows, err := sess.ID(template.TemplateId).Cols("name", "type", "category_id", "account_id", "scheduled_frequency_type", "scheduled_frequency", "scheduled_start_time", "scheduled_end_time", "scheduled_at", "scheduled_timezone_utc_offset", "scheduled_need_confirm", "tag_ids", "amount", "related_account_id", "related_account_amount", "hide_amount", "comment", "updated_unix_time").Where("uid=? AND deleted=?", template.Uid, false).Update(template)
 
 		if err != nil {
 			return err
@@ -235,6 +236,13 @@ func (s *TransactionTemplateService) DeleteTemplate(c core.Context, uid int64, t
 			return errs.ErrTransactionTemplateNotFound
 		}
 
+		occurrenceUpdateModel := &models.TransactionTemplateOccurrence{
+			Deleted:         true,
+			DeletedUnixTime: now,
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND template_id=? AND status<>?", uid, false, templateId, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_CONFIRMED).Update(occurrenceUpdateModel)
+
 		return err
 	})
 }
@@ -263,6 +271,109 @@ func (s *TransactionTemplateService) DeleteAllTemplates(c core.Context, uid int6
 	})
 }
 
+// GetAllDeletedTemplatesByUid returns all deleted transaction template models of user
+func (s *TransactionTemplateService) GetAllDeletedTemplatesByUid(c core.Context, uid int64) ([]*models.TransactionTemplate, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var templates []*models.TransactionTemplate
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, true).OrderBy("deleted_unix_time desc, display_order asc").Find(&templates)
+
+	return templates, err
+}
+
+// RestoreTemplate restores a deleted transaction template with the occurrences deleted together
+func (s *TransactionTemplateService) RestoreTemplate(c core.Context, uid int64, templateId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if templateId <= 0 {
+		return errs.ErrTransactionTemplateIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		template := &models.TransactionTemplate{}
+		has, err := sess.ID(templateId).Where("uid=? AND deleted=?", uid, true).Get(template)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		err = s.isTemplateValid(sess, template)
+
+		if err != nil {
+			return err
+		}
+
+		restoreModel := &models.TransactionTemplate{
+			Deleted:         false,
+			UpdatedUnixTime: now,
+			DeletedUnixTime: 0,
+		}
+
+		restoredRows, err := sess.ID(templateId).Cols("deleted", "updated_unix_time", "deleted_unix_time").Where("uid=? AND deleted=?", uid, true).Update(restoreModel)
+
+		if err != nil {
+			return err
+		} else if restoredRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		occurrenceRestoreModel := &models.TransactionTemplateOccurrence{
+			Deleted:         false,
+			DeletedUnixTime: 0,
+		}
+
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND template_id=? AND deleted_unix_time=?", uid, true, templateId, template.DeletedUnixTime).Update(occurrenceRestoreModel)
+
+		return err
+	})
+}
+
+// PurgeTemplate permanently deletes a deleted transaction template and its deleted occurrences from database
+func (s *TransactionTemplateService) PurgeTemplate(c core.Context, uid int64, templateId int64) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if templateId <= 0 {
+		return errs.ErrTransactionTemplateIdInvalid
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		deletedRows, err := sess.ID(templateId).Where("uid=? AND deleted=?", uid, true).Delete(&models.TransactionTemplate{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		_, err = sess.Where("uid=? AND deleted=? AND template_id=?", uid, true, templateId).Delete(&models.TransactionTemplateOccurrence{})
+
+		return err
+	})
+}
+
+// PurgeAllDeletedTemplates permanently deletes all transaction templates and occurrences which have been deleted before the specified unix time
+func (s *TransactionTemplateService) PurgeAllDeletedTemplates(c core.Context, deletedBeforeUnixTime int64) error {
+	count, err := s.purgeDeletedRows(c, deletedBeforeUnixTime, &models.TransactionTemplate{}, &models.TransactionTemplateOccurrence{})
+
+	if count > 0 {
+		log.Infof(c, "[transaction_templates.PurgeAllDeletedTemplates] %d deleted transaction template rows have been purged", count)
+	} else if err == nil {
+		log.Infof(c, "[transaction_templates.PurgeAllDeletedTemplates] no deleted transaction templates have been purged")
+	}
+
+	return err
+}
+
 func (s *TransactionTemplateService) isTemplateValid(sess *xorm.Session, template *models.TransactionTemplate) error {
 	// check accounts are valid
 	sourceAccount := &models.Account{}
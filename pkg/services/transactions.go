@@ -3,6 +3,8 @@ package services
 import (
 	"fmt"
 	"math"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -76,11 +78,11 @@ func (s *TransactionService) GetAllTransactions(c core.Context, uid int64, pageC
 
 // GetAllTransactionsByMaxTime returns all transactions before given time
 func (s *TransactionService) GetAllTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, count int32, noDuplicated bool) ([]*models.Transaction, error) {
-	return s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, nil, nil, false, "", "", 1, count, false, noDuplicated)
+	return s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, nil, nil, nil, false, "", "", 1, count, false, noDuplicated)
 }
 
 // GetAllSpecifiedTransactions returns all transactions that match given conditions
-func (s *TransactionService) GetAllSpecifiedTransactions(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string, pageCount int32, noDuplicated bool) ([]*models.Transaction, error) {
+func (s *TransactionService) GetAllSpecifiedTransactions(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, payeeIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string, pageCount int32, noDuplicated bool) ([]*models.Transaction, error) {
 	if maxTransactionTime <= 0 {
 		maxTransactionTime = utils.GetMaxTransactionTimeFromUnixTime(time.Now().Unix())
 	}
@@ -88,7 +90,7 @@ func (s *TransactionService) GetAllSpecifiedTransactions(c core.Context, uid int
 	var allTransactions []*models.Transaction
 
 	for maxTransactionTime > 0 {
-		transactions, err := s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, tagFilters, noTags, amountFilter, keyword, 1, pageCount, false, noDuplicated)
+		transactions, err := s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, minTransactionTime, transactionType, categoryIds, accountIds, payeeIds, tagFilters, noTags, amountFilter, keyword, 1, pageCount, false, noDuplicated)
 
 		if err != nil {
 			return nil, err
@@ -116,7 +118,7 @@ func (s *TransactionService) GetAllTransactionsInOneAccountWithAccountBalanceByM
 	var allTransactions []*models.Transaction
 
 	for maxTransactionTime > 0 {
-		transactions, err := s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, []int64{accountId}, nil, false, "", "", 1, pageCount, false, true)
+		transactions, err := s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, []int64{accountId}, nil, nil, false, "", "", 1, pageCount, false, true)
 
 		if err != nil {
 			return nil, 0, 0, 0, 0, err
@@ -206,7 +208,7 @@ func (s *TransactionService) GetAllAccountsDailyOpeningAndClosingBalance(c core.
 	var allTransactions []*models.Transaction
 
 	for maxTransactionTime > 0 {
-		transactions, err := s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, nil, nil, false, "", "", 1, pageCountForLoadTransactionAmounts, false, false)
+		transactions, err := s.GetTransactionsByMaxTime(c, uid, maxTransactionTime, 0, 0, nil, nil, nil, nil, false, "", "", 1, pageCountForLoadTransactionAmounts, false, false)
 
 		if err != nil {
 			return nil, err
@@ -323,7 +325,7 @@ func (s *TransactionService) GetAllAccountsDailyOpeningAndClosingBalance(c core.
 }
 
 // GetTransactionsByMaxTime returns transactions before given time
-func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string, page int32, count int32, needOneMoreItem bool, noDuplicated bool) ([]*models.Transaction, error) {
+func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, payeeIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string, page int32, count int32, needOneMoreItem bool, noDuplicated bool) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -357,7 +359,7 @@ func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64,
 		actualCount++
 	}
 
-	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionDbType, categoryIds, accountIds, tagFilters, amountFilter, keyword, noDuplicated)
+	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionDbType, categoryIds, accountIds, payeeIds, tagFilters, amountFilter, keyword, noDuplicated)
 	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
 	sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 
@@ -367,7 +369,7 @@ func (s *TransactionService) GetTransactionsByMaxTime(c core.Context, uid int64,
 }
 
 // GetTransactionsInMonthByPage returns all transactions in given year and month
-func (s *TransactionService) GetTransactionsInMonthByPage(c core.Context, uid int64, year int32, month int32, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string) ([]*models.Transaction, error) {
+func (s *TransactionService) GetTransactionsInMonthByPage(c core.Context, uid int64, year int32, month int32, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, payeeIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -391,7 +393,7 @@ func (s *TransactionService) GetTransactionsInMonthByPage(c core.Context, uid in
 
 	var transactions []*models.Transaction
 
-	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionDbType, categoryIds, accountIds, tagFilters, amountFilter, keyword, true)
+	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionDbType, categoryIds, accountIds, payeeIds, tagFilters, amountFilter, keyword, true)
 	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
 	sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 
@@ -436,11 +438,55 @@ func (s *TransactionService) GetTransactionByTransactionId(c core.Context, uid i
 
 // GetAllTransactionCount returns total count of transactions
 func (s *TransactionService) GetAllTransactionCount(c core.Context, uid int64) (int64, error) {
-	return s.GetTransactionCount(c, uid, 0, 0, 0, nil, nil, nil, false, "", "")
+	return s.GetTransactionCount(c, uid, 0, 0, 0, nil, nil, nil, nil, false, "", "")
+}
+
+// GetTransactionSearchConditions returns the transaction query conditions resolved from the specified saved search and search query, the conditions of search query are applied after the ones of saved search
+func (s *TransactionService) GetTransactionSearchConditions(c core.Context, uid int64, savedSearchId int64, searchQuery string, clientTimezone *time.Location) (*models.TransactionSearchConditions, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if savedSearchId <= 0 && searchQuery == "" {
+		return nil, nil
+	}
+
+	searchConditions := &models.TransactionSearchConditions{}
+
+	if savedSearchId > 0 {
+		savedSearch := &models.SavedSearch{}
+		has, err := s.UserDataDB(uid).NewSession(c).ID(savedSearchId).Where("uid=? AND deleted=?", uid, false).Get(savedSearch)
+
+		if err != nil {
+			return nil, err
+		} else if !has {
+			return nil, errs.ErrSavedSearchNotFound
+		}
+
+		savedSearchConditions, err := s.resolveTransactionSearchQuery(c, uid, savedSearch.Query, clientTimezone)
+
+		if err != nil {
+			return nil, err
+		}
+
+		searchConditions.Merge(savedSearchConditions)
+	}
+
+	if searchQuery != "" {
+		querySearchConditions, err := s.resolveTransactionSearchQuery(c, uid, searchQuery, clientTimezone)
+
+		if err != nil {
+			return nil, err
+		}
+
+		searchConditions.Merge(querySearchConditions)
+	}
+
+	return searchConditions, nil
 }
 
 // GetTransactionCount returns count of transactions
-func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string) (int64, error) {
+func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxTransactionTime int64, minTransactionTime int64, transactionType models.TransactionType, categoryIds []int64, accountIds []int64, payeeIds []int64, tagFilters []*models.TransactionTagFilter, noTags bool, amountFilter string, keyword string) (int64, error) {
 	if uid <= 0 {
 		return 0, errs.ErrUserIdInvalid
 	}
@@ -456,7 +502,7 @@ func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxT
 		}
 	}
 
-	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionDbType, categoryIds, accountIds, tagFilters, amountFilter, keyword, true)
+	condition, conditionParams := s.buildTransactionQueryCondition(uid, maxTransactionTime, minTransactionTime, transactionDbType, categoryIds, accountIds, payeeIds, tagFilters, amountFilter, keyword, true)
 	sess := s.UserDataDB(uid).NewSession(c).Where(condition, conditionParams...)
 	sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 
@@ -464,7 +510,57 @@ func (s *TransactionService) GetTransactionCount(c core.Context, uid int64, maxT
 }
 
 // CreateTransaction saves a new transaction to database
-func (s *TransactionService) CreateTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, itemIds []int64, pictureIds []int64) error {
+func (s *TransactionService) CreateTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, itemIds []int64, itemDetails map[int64]*models.TransactionItemIndex, pictureIds []int64, splits []*models.TransactionSplit, actor *models.TransactionRevisionActor) error {
+	return s.createTransaction(c, transaction, tagIds, itemIds, itemDetails, pictureIds, splits, actor, nil)
+}
+
+// CreateTransactionOfOccurrence saves a new transaction of the scheduled transaction template occurrence to database and marks the occurrence as confirmed in the same database transaction,
+// a new confirmed occurrence would be created if the occurrence has not been saved, otherwise the current status of the occurrence must be the specified status
+func (s *TransactionService) CreateTransactionOfOccurrence(c core.Context, transaction *models.Transaction, tagIds []int64, occurrence *models.TransactionTemplateOccurrence, currentStatus models.TransactionTemplateOccurrenceStatus, actor *models.TransactionRevisionActor) error {
+	if occurrence.Uid != transaction.Uid {
+		return errs.ErrUserIdInvalid
+	}
+
+	isNewOccurrence := occurrence.OccurrenceId < 1
+
+	if isNewOccurrence {
+		occurrence.OccurrenceId = s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+		if occurrence.OccurrenceId < 1 {
+			return errs.ErrSystemIsBusy
+		}
+	}
+
+	return s.createTransaction(c, transaction, tagIds, nil, nil, nil, nil, actor, func(sess *xorm.Session) error {
+		now := time.Now().Unix()
+
+		occurrence.Status = models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_CONFIRMED
+		occurrence.TransactionId = transaction.TransactionId
+		occurrence.UpdatedUnixTime = now
+
+		if isNewOccurrence {
+			occurrence.Deleted = false
+			occurrence.CreatedUnixTime = now
+
+			_, err := sess.Insert(occurrence)
+			return err
+		}
+
+		updatedRows, err := sess.ID(occurrence.OccurrenceId).Cols("status", "transaction_id", "updated_unix_time").Where("uid=? AND deleted=? AND status=?", occurrence.Uid, false, currentStatus).Update(occurrence)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 && currentStatus == models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING {
+			return errs.ErrTransactionTemplateOccurrenceNotPending
+		} else if updatedRows < 1 {
+			return errs.ErrTransactionTemplateOccurrenceNotFound
+		}
+
+		return nil
+	})
+}
+
+func (s *TransactionService) createTransaction(c core.Context, transaction *models.Transaction, tagIds []int64, itemIds []int64, itemDetails map[int64]*models.TransactionItemIndex, pictureIds []int64, splits []*models.TransactionSplit, actor *models.TransactionRevisionActor, afterCreated func(sess *xorm.Session) error) error {
 	if transaction.Uid <= 0 {
 		return errs.ErrUserIdInvalid
 	}
@@ -477,17 +573,10 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 	}
 
 	now := time.Now().Unix()
+	err = s.fillNewTransactionIdsAndTimes(transaction, now)
 
-	needTransactionUuidCount := 1
-
-	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
-		needTransactionUuidCount = 2
-	}
-
-	transactionUuids := s.GenerateUuids(uuid.UUID_TYPE_TRANSACTION, uint16(needTransactionUuidCount))
-
-	if len(transactionUuids) < needTransactionUuidCount {
-		return errs.ErrSystemIsBusy
+	if err != nil {
+		return err
 	}
 
 	tagIds = utils.ToUniqueInt64Slice(tagIds)
@@ -506,16 +595,18 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 		return errs.ErrSystemIsBusy
 	}
 
-	transaction.TransactionId = transactionUuids[0]
+	needSplitUuidCount := uint16(len(splits))
+	splitUuids := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, needSplitUuidCount)
 
-	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
-		transaction.RelatedId = transactionUuids[1]
+	if len(splitUuids) < int(needSplitUuidCount) {
+		return errs.ErrSystemIsBusy
 	}
 
-	transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime))
+	revisionId := s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
 
-	transaction.CreatedUnixTime = now
-	transaction.UpdatedUnixTime = now
+	if revisionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
 
 	transactionTagIndexes := make([]*models.TransactionTagIndex, len(tagIds))
 
@@ -543,6 +634,21 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 			CreatedUnixTime: now,
 			UpdatedUnixTime: now,
 		}
+
+		if itemDetail, exists := itemDetails[itemIds[i]]; exists {
+			s.fillTransactionItemIndexDetail(transactionItemIndexes[i], itemDetail)
+		}
+	}
+
+	for i := 0; i < len(splits); i++ {
+		split := splits[i]
+		split.SplitId = splitUuids[i]
+		split.Uid = transaction.Uid
+		split.Deleted = false
+		split.TransactionId = transaction.TransactionId
+		split.DisplayOrder = int32(i + 1)
+		split.CreatedUnixTime = now
+		split.UpdatedUnixTime = now
 	}
 
 	pictureUpdateModel := &models.TransactionPictureInfo{
@@ -550,15 +656,34 @@ func (s *TransactionService) CreateTransaction(c core.Context, transaction *mode
 		UpdatedUnixTime: now,
 	}
 
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, transaction.Uid)
+
+	if err != nil {
+		return err
+	}
+
 	userDataDb := s.UserDataDB(transaction.Uid)
 
 	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
-		return s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, transactionItemIndexes, tagIds, itemIds, pictureIds, pictureUpdateModel)
+		err := s.doCreateTransaction(c, userDataDb, sess, userTransactionLockTime, transaction, transactionTagIndexes, transactionItemIndexes, tagIds, itemIds, pictureIds, pictureUpdateModel, splits)
+
+		if err != nil {
+			return err
+		}
+
+		afterSnapshot := models.NewTransactionRevisionSnapshot(transaction, tagIds, transactionItemIndexes, pictureIds, splits)
+		err = s.insertTransactionRevision(sess, revisionId, transaction.Uid, transaction.TransactionId, models.TRANSACTION_REVISION_TYPE_CREATE, nil, afterSnapshot, actor, now)
+
+		if err != nil || afterCreated == nil {
+			return err
+		}
+
+		return afterCreated(sess)
 	})
 }
 
-// BatchCreateTransactions saves new transactions to database
-func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64, transactions []*models.Transaction, allTagIds map[int][]int64, processHandler core.TaskProcessUpdateHandler) error {
+// BatchCreateTransactions saves new transactions and the new payees used by them to database
+func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64, transactions []*models.Transaction, allTagIds map[int][]int64, newPayees []*models.Payee, actor *models.TransactionRevisionActor, processHandler core.TaskProcessUpdateHandler) error {
 	now := time.Now().Unix()
 	currentProcess := float64(0)
 	processUpdateStep := int(math.Max(100.0, float64(len(transactions)/100.0)))
@@ -588,6 +713,10 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 
 		transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime))
 
+		if transaction.CreatedByUid <= 0 {
+			transaction.CreatedByUid = transaction.Uid
+		}
+
 		transaction.CreatedUnixTime = now
 		transaction.UpdatedUnixTime = now
 	}
@@ -601,7 +730,7 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 		needTagIndexUuidCount += uint16(len(uniqueTagIds))
 	}
 
-	if needTransactionUuidCount > uint16(65535) || needTagIndexUuidCount > uint16(65535) {
+	if needTransactionUuidCount > uint16(65535) || needTagIndexUuidCount > uint16(65535) || len(transactions) > 65535 {
 		return errs.ErrImportTooManyTransaction
 	}
 
@@ -624,6 +753,12 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 		}
 	}
 
+	revisionUuids := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(transactions)))
+
+	if len(revisionUuids) < len(transactions) {
+		return errs.ErrSystemIsBusy
+	}
+
 	tagIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_TAG_INDEX, needTagIndexUuidCount)
 	tagIndexUuidIndex := 0
 
@@ -658,14 +793,47 @@ func (s *TransactionService) BatchCreateTransactions(c core.Context, uid int64,
 		allTransactionTagIds[transaction.TransactionId] = uniqueTagIds
 	}
 
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
 	userDataDb := s.UserDataDB(uid)
 
 	return userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(newPayees); i++ {
+			payee := newPayees[i]
+
+			if payee.Uid != uid {
+				return errs.ErrUserIdInvalid
+			}
+
+			exists, err := sess.Cols("uid", "deleted", "name").Where("uid=? AND deleted=? AND name=?", uid, false, payee.Name).Exist(&models.Payee{})
+
+			if err != nil {
+				return err
+			} else if exists {
+				return errs.ErrPayeeNameAlreadyExists
+			}
+
+			_, err = sess.Insert(payee)
+
+			if err != nil {
+				return err
+			}
+		}
+
 		for i := 0; i < len(transactions); i++ {
 			transaction := transactions[i]
 			transactionTagIndexes := allTransactionTagIndexes[transaction.TransactionId]
 			transactionTagIds := allTransactionTagIds[transaction.TransactionId]
-			err := s.doCreateTransaction(c, userDataDb, sess, transaction, transactionTagIndexes, nil, transactionTagIds, nil, nil, nil)
+			err := s.doCreateTransaction(c, userDataDb, sess, userTransactionLockTime, transaction, transactionTagIndexes, nil, transactionTagIds, nil, nil, nil, nil)
+
+			if err == nil {
+				afterSnapshot := models.NewTransactionRevisionSnapshot(transaction, transactionTagIds, nil, nil, nil)
+				err = s.insertTransactionRevision(sess, revisionUuids[i], uid, transaction.TransactionId, models.TRANSACTION_REVISION_TYPE_CREATE, nil, afterSnapshot, actor, now)
+			}
 
 			currentProcess = float64(i) / float64(len(transactions)) * 100
 
@@ -705,7 +873,7 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 
 	for i := 0; i < s.UserDataDBCount(); i++ {
 		var templates []*models.TransactionTemplate
-		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND (scheduled_frequency_type=? OR scheduled_frequency_type=?) AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?) AND scheduled_at>=? AND scheduled_at<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY, startTime.Unix(), startTime.Unix(), minScheduledAt, maxScheduledAt).Find(&templates)
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND template_type=? AND scheduled_frequency_type<>? AND (scheduled_start_time IS NULL OR scheduled_start_time<=?) AND (scheduled_end_time IS NULL OR scheduled_end_time>=?) AND scheduled_at>=? AND scheduled_at<?", false, models.TRANSACTION_TEMPLATE_TYPE_SCHEDULE, models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_DISABLED, startTime.Unix(), startTime.Unix(), minScheduledAt, maxScheduledAt).Find(&templates)
 
 		if err != nil {
 			return err
@@ -733,15 +901,10 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		if (template.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY &&
-			template.ScheduledFrequencyType != models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY) ||
-			template.ScheduledFrequency == "" {
-			skipCount++
-			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid scheduled transaction frequency", template.TemplateId)
-			continue
-		}
-
-		frequencyValues, err := utils.StringArrayToInt64Array(strings.Split(template.ScheduledFrequency, ","))
+		templateTimeZone := time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60)
+		transactionUnixTime := todayFirstUnixTimeInUTC + int64(template.ScheduledAt)*60
+		transactionTime := time.Unix(transactionUnixTime, 0).In(templateTimeZone)
+		scheduled, err := template.IsScheduledOn(transactionTime)
 
 		if err != nil {
 			skipCount++
@@ -749,18 +912,9 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		frequencyValueSet := utils.ToSet(frequencyValues)
-		templateTimeZone := time.FixedZone("Template Timezone", int(template.ScheduledTimezoneUtcOffset)*60)
-		transactionUnixTime := todayFirstUnixTimeInUTC + int64(template.ScheduledAt)*60
-		transactionTime := time.Unix(transactionUnixTime, 0).In(templateTimeZone)
-
-		if template.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_WEEKLY && !frequencyValueSet[int64(transactionTime.Weekday())] {
-			skipCount++
-			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %s", template.TemplateId, startTimeInUTC.Weekday())
-			continue
-		} else if template.ScheduledFrequencyType == models.TRANSACTION_SCHEDULE_FREQUENCY_TYPE_MONTHLY && !frequencyValueSet[int64(transactionTime.Day())] {
+		if !scheduled {
 			skipCount++
-			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %d of month", template.TemplateId, startTimeInUTC.Day())
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, today is %s", template.TemplateId, transactionTime.Format("2006-01-02"))
 			continue
 		}
 
@@ -776,41 +930,54 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 			continue
 		}
 
-		var transactionDbType models.TransactionDbType
+		processed, err := s.UserDataDB(template.Uid).NewSession(c).Where("uid=? AND deleted=? AND template_id=? AND scheduled_time=?", template.Uid, false, template.TemplateId, transactionUnixTime).Exist(&models.TransactionTemplateOccurrence{})
 
-		if template.Type == models.TRANSACTION_TYPE_EXPENSE {
-			transactionDbType = models.TRANSACTION_DB_TYPE_EXPENSE
-		} else if template.Type == models.TRANSACTION_TYPE_INCOME {
-			transactionDbType = models.TRANSACTION_DB_TYPE_INCOME
-		} else if template.Type == models.TRANSACTION_TYPE_TRANSFER {
-			transactionDbType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
-		} else {
+		if err != nil {
+			failedCount++
+			log.Errorf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" failed to check whether the occurrence has been processed, because %s", template.TemplateId, err.Error())
+			continue
+		} else if processed {
 			skipCount++
-			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid transaction type", template.TemplateId)
+			log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" does not need to create transaction, the occurrence at %d has been processed", template.TemplateId, transactionUnixTime)
 			continue
 		}
 
-		transaction := &models.Transaction{
-			Uid:               template.Uid,
-			Type:              transactionDbType,
-			CategoryId:        template.CategoryId,
-			TransactionTime:   utils.GetMinTransactionTimeFromUnixTime(transactionTime.Unix()),
-			TimezoneUtcOffset: template.ScheduledTimezoneUtcOffset,
-			AccountId:         template.AccountId,
-			Amount:            template.Amount,
-			HideAmount:        template.HideAmount,
-			Comment:           template.Comment,
-			CreatedIp:         "127.0.0.1",
-			ScheduledCreated:  true,
+		if template.ScheduledNeedConfirm {
+			occurrence := &models.TransactionTemplateOccurrence{
+				Uid:           template.Uid,
+				TemplateId:    template.TemplateId,
+				Status:        models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING,
+				ScheduledTime: transactionUnixTime,
+			}
+
+			err = TransactionTemplateOccurrences.CreateOccurrence(c, occurrence)
+
+			if err == nil {
+				successCount++
+				log.Infof(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has created a new pending occurrence \"id:%d\"", template.TemplateId, occurrence.OccurrenceId)
+			} else {
+				failedCount++
+				log.Errorf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" failed to create new pending occurrence, because %s", template.TemplateId, err.Error())
+			}
+
+			continue
+		}
+
+		transaction, err := template.ToScheduledTransaction(transactionUnixTime)
+
+		if err != nil {
+			skipCount++
+			log.Warnf(c, "[transactions.CreateScheduledTransactions] transaction template \"id:%d\" has invalid transaction type", template.TemplateId)
+			continue
 		}
 
-		if template.Type == models.TRANSACTION_TYPE_TRANSFER {
-			transaction.RelatedAccountId = template.RelatedAccountId
-			transaction.RelatedAccountAmount = template.RelatedAccountAmount
+		occurrence := &models.TransactionTemplateOccurrence{
+			Uid:           template.Uid,
+			TemplateId:    template.TemplateId,
+			ScheduledTime: transactionUnixTime,
 		}
 
-		tagIds := template.GetTagIds()
-		err = s.CreateTransaction(c, transaction, tagIds, nil, nil)
+		err = s.CreateTransactionOfOccurrence(c, transaction, template.GetTagIds(), occurrence, 0, nil)
 
 		if err == nil {
 			successCount++
@@ -826,109 +993,318 @@ func (s *TransactionService) CreateScheduledTransactions(c core.Context, current
 	return nil
 }
 
-// ModifyTransaction saves an existed transaction to database
-func (s *TransactionService) ModifyTransaction(c core.Context, transaction *models.Transaction, currentTagIdsCount int, addTagIds []int64, removeTagIds []int64, addItemIds []int64, removeItemIds []int64, addPictureIds []int64, removePictureIds []int64) error {
-	if transaction.Uid <= 0 {
-		return errs.ErrUserIdInvalid
-	}
+// CreatePostponedScheduledTransactions saves all transactions of the postponed scheduled transaction template occurrences that should be created now,
+// or marks them as pending if the template needs to be confirmed
+func (s *TransactionService) CreatePostponedScheduledTransactions(c core.Context, currentUnixTime int64, interval time.Duration) error {
+	var allOccurrences []*models.TransactionTemplateOccurrence
+	intervalMinute := int(interval / time.Minute)
+	currentTime := time.Unix(currentUnixTime, 0)
+	currentMinute := (currentTime.Minute() / intervalMinute) * intervalMinute
 
-	needTagIndexUuidCount := uint16(len(addTagIds))
-	tagIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_TAG_INDEX, needTagIndexUuidCount)
+	startTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), currentTime.Hour(), currentMinute, 0, 0, time.Local)
+	minPostponedTime := startTime.Unix()
+	maxPostponedTime := minPostponedTime + int64(intervalMinute)*60
 
-	if len(tagIndexUuids) < int(needTagIndexUuidCount) {
-		return errs.ErrSystemIsBusy
-	}
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var occurrences []*models.TransactionTemplateOccurrence
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND status=? AND postponed_time>=? AND postponed_time<?", false, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED, minPostponedTime, maxPostponedTime).Find(&occurrences)
 
-	needItemIndexUuidCount := uint16(len(addItemIds))
-	itemIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_ITEM_INDEX, needItemIndexUuidCount)
+		if err != nil {
+			return err
+		}
 
-	if len(itemIndexUuids) < int(needItemIndexUuidCount) {
-		return errs.ErrSystemIsBusy
+		allOccurrences = append(allOccurrences, occurrences...)
 	}
 
-	updateCols := make([]string, 0, 16)
-
-	now := time.Now().Unix()
-
-	transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime))
-	transaction.UpdatedUnixTime = now
-	updateCols = append(updateCols, "updated_unix_time")
-
-	addTagIds = utils.ToUniqueInt64Slice(addTagIds)
-	removeTagIds = utils.ToUniqueInt64Slice(removeTagIds)
-	addItemIds = utils.ToUniqueInt64Slice(addItemIds)
-	removeItemIds = utils.ToUniqueInt64Slice(removeItemIds)
-
-	transactionTagIndexes := make([]*models.TransactionTagIndex, len(addTagIds))
-
-	for i := 0; i < len(addTagIds); i++ {
-		transactionTagIndexes[i] = &models.TransactionTagIndex{
-			TagIndexId:      tagIndexUuids[i],
-			Uid:             transaction.Uid,
-			Deleted:         false,
-			TagId:           addTagIds[i],
-			TransactionId:   transaction.TransactionId,
-			CreatedUnixTime: now,
-			UpdatedUnixTime: now,
-		}
+	if len(allOccurrences) < 1 {
+		return nil
 	}
 
-	transactionItemIndexes := make([]*models.TransactionItemIndex, len(addItemIds))
+	log.Infof(c, "[transactions.CreatePostponedScheduledTransactions] should process %d postponed scheduled transaction template occurrences now (postponed time from %d to %d)", len(allOccurrences), minPostponedTime, maxPostponedTime)
 
-	for i := 0; i < len(addItemIds); i++ {
-		transactionItemIndexes[i] = &models.TransactionItemIndex{
-			ItemIndexId:     itemIndexUuids[i],
-			Uid:             transaction.Uid,
-			Deleted:         false,
-			ItemId:          addItemIds[i],
-			TransactionId:   transaction.TransactionId,
-			CreatedUnixTime: now,
-			UpdatedUnixTime: now,
-		}
-	}
+	successCount := 0
+	skipCount := 0
+	failedCount := 0
 
-	err := s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
-		// Get and verify current transaction
-		oldTransaction := &models.Transaction{}
-		has, err := sess.ID(transaction.TransactionId).Where("uid=? AND deleted=?", transaction.Uid, false).Get(oldTransaction)
+	for i := 0; i < len(allOccurrences); i++ {
+		occurrence := allOccurrences[i]
+		template := &models.TransactionTemplate{}
+		has, err := s.UserDataDB(occurrence.Uid).NewSession(c).ID(occurrence.TemplateId).Where("uid=? AND deleted=?", occurrence.Uid, false).Get(template)
 
 		if err != nil {
-			log.Errorf(c, "[transactions.ModifyTransaction] failed to get current transaction, because %s", err.Error())
-			return err
+			failedCount++
+			log.Errorf(c, "[transactions.CreatePostponedScheduledTransactions] failed to get transaction template \"id:%d\" of occurrence \"id:%d\", because %s", occurrence.TemplateId, occurrence.OccurrenceId, err.Error())
+			continue
 		} else if !has {
-			return errs.ErrTransactionNotFound
+			skipCount++
+			log.Warnf(c, "[transactions.CreatePostponedScheduledTransactions] transaction template \"id:%d\" of occurrence \"id:%d\" does not exist", occurrence.TemplateId, occurrence.OccurrenceId)
+			continue
 		}
 
-		transaction.Type = oldTransaction.Type
+		if template.ScheduledNeedConfirm {
+			err = s.updatePostponedTransactionTemplateOccurrence(c, occurrence, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_PENDING, 0)
 
-		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			transaction.RelatedId = oldTransaction.RelatedId
+			if err == nil {
+				successCount++
+				log.Infof(c, "[transactions.CreatePostponedScheduledTransactions] transaction template occurrence \"id:%d\" is pending now", occurrence.OccurrenceId)
+			} else {
+				failedCount++
+				log.Errorf(c, "[transactions.CreatePostponedScheduledTransactions] failed to update transaction template occurrence \"id:%d\" to pending, because %s", occurrence.OccurrenceId, err.Error())
+			}
+
+			continue
 		}
 
-		// Check whether account id is valid
-		err = s.isAccountIdValid(transaction)
+		transaction, err := template.ToScheduledTransaction(occurrence.PostponedTime)
 
 		if err != nil {
-			return err
+			skipCount++
+			log.Warnf(c, "[transactions.CreatePostponedScheduledTransactions] transaction template \"id:%d\" has invalid transaction type", template.TemplateId)
+			continue
 		}
 
-		// Get and verify source and destination account (if necessary)
-		sourceAccount, destinationAccount, err := s.getAccountModels(sess, transaction)
+		err = s.CreateTransactionOfOccurrence(c, transaction, template.GetTagIds(), occurrence, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED, nil)
 
 		if err != nil {
-			log.Errorf(c, "[transactions.ModifyTransaction] failed to get account, because %s", err.Error())
-			return err
+			failedCount++
+			log.Errorf(c, "[transactions.CreatePostponedScheduledTransactions] transaction template occurrence \"id:%d\" failed to create new trasaction, because %s", occurrence.OccurrenceId, err.Error())
+			continue
 		}
 
-		if sourceAccount.Hidden || (destinationAccount != nil && destinationAccount.Hidden) {
-			return errs.ErrCannotModifyTransactionInHiddenAccount
-		}
+		successCount++
+		log.Infof(c, "[transactions.CreatePostponedScheduledTransactions] transaction template occurrence \"id:%d\" has created a new trasaction \"id:%d\"", occurrence.OccurrenceId, transaction.TransactionId)
+	}
 
-		if sourceAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS || (destinationAccount != nil && destinationAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS) {
-			return errs.ErrCannotModifyTransactionInParentAccount
-		}
+	log.Infof(c, "[transactions.CreatePostponedScheduledTransactions] %d occurrences has been processed successfully, %d occurrences does not need to be processed and %d occurrences failed to process", successCount, skipCount, failedCount)
 
-		if (transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN) &&
+	return nil
+}
+
+// CreateScheduledLoanPayments saves the principal and interest transactions of all loan payments that are due now
+func (s *TransactionService) CreateScheduledLoanPayments(c core.Context, currentUnixTime int64, interval time.Duration) error {
+	var allLoans []*models.Loan
+	intervalMinute := int(interval / time.Minute)
+	currentTime := time.Unix(currentUnixTime, 0)
+	currentMinute := (currentTime.Minute() / intervalMinute) * intervalMinute
+
+	startTime := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), currentTime.Hour(), currentMinute, 0, 0, time.Local)
+	startTimeInUTC := startTime.In(time.UTC)
+
+	minScheduledAt := startTimeInUTC.Hour()*60 + startTimeInUTC.Minute()
+	maxScheduledAt := minScheduledAt + intervalMinute
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var loans []*models.Loan
+		err := s.UserDataDBByIndex(i).NewSession(c).Where("deleted=? AND scheduled_enabled=? AND scheduled_at>=? AND scheduled_at<? AND paid_periods<term_months", false, true, minScheduledAt, maxScheduledAt).Find(&loans)
+
+		if err != nil {
+			return err
+		}
+
+		allLoans = append(allLoans, loans...)
+	}
+
+	if len(allLoans) < 1 {
+		return nil
+	}
+
+	log.Infof(c, "[transactions.CreateScheduledLoanPayments] should process %d loans now (scheduled at from %d to %d)", len(allLoans), minScheduledAt, maxScheduledAt)
+
+	successCount := 0
+	skipCount := 0
+	failedCount := 0
+
+	for i := 0; i < len(allLoans); i++ {
+		loan := allLoans[i]
+		duePeriods := loan.GetDuePeriods(currentUnixTime)
+
+		if duePeriods <= loan.PaidPeriods {
+			skipCount++
+			log.Infof(c, "[transactions.CreateScheduledLoanPayments] loan \"id:%d\" does not need to create payment, %d of %d periods have been paid", loan.LoanId, loan.PaidPeriods, loan.TermMonths)
+			continue
+		}
+
+		schedule := loan.GetAmortizationSchedule()
+
+		for period := loan.PaidPeriods + 1; period <= duePeriods && int(period) <= len(schedule); period++ {
+			item := schedule[period-1]
+			created, err := s.createLoanPaymentTransactions(c, loan, period, item)
+
+			if err != nil {
+				failedCount++
+				log.Errorf(c, "[transactions.CreateScheduledLoanPayments] loan \"id:%d\" failed to create payment transactions of period %d, because %s", loan.LoanId, period, err.Error())
+				break
+			} else if !created {
+				skipCount++
+				log.Warnf(c, "[transactions.CreateScheduledLoanPayments] period %d of loan \"id:%d\" has been paid by another process", period, loan.LoanId)
+				break
+			}
+
+			successCount++
+			log.Infof(c, "[transactions.CreateScheduledLoanPayments] loan \"id:%d\" has created payment transactions of period %d", loan.LoanId, period)
+		}
+	}
+
+	log.Infof(c, "[transactions.CreateScheduledLoanPayments] %d loan payments has been created successfully, %d loans does not need to create payments and %d loan payments failed to create", successCount, skipCount, failedCount)
+
+	return nil
+}
+
+// ModifyTransaction saves an existed transaction to database
+func (s *TransactionService) ModifyTransaction(c core.Context, transaction *models.Transaction, currentTagIdsCount int, addTagIds []int64, removeTagIds []int64, addItemIds []int64, removeItemIds []int64, itemDetails map[int64]*models.TransactionItemIndex, addPictureIds []int64, removePictureIds []int64, splits []*models.TransactionSplit, actor *models.TransactionRevisionActor) error {
+	if transaction.Uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	revisionId := s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if revisionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	needTagIndexUuidCount := uint16(len(addTagIds))
+	tagIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_TAG_INDEX, needTagIndexUuidCount)
+
+	if len(tagIndexUuids) < int(needTagIndexUuidCount) {
+		return errs.ErrSystemIsBusy
+	}
+
+	needItemIndexUuidCount := uint16(len(addItemIds))
+	itemIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_ITEM_INDEX, needItemIndexUuidCount)
+
+	if len(itemIndexUuids) < int(needItemIndexUuidCount) {
+		return errs.ErrSystemIsBusy
+	}
+
+	needSplitUuidCount := uint16(len(splits))
+	splitUuids := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, needSplitUuidCount)
+
+	if len(splitUuids) < int(needSplitUuidCount) {
+		return errs.ErrSystemIsBusy
+	}
+
+	updateCols := make([]string, 0, 16)
+
+	now := time.Now().Unix()
+
+	transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime))
+	transaction.UpdatedUnixTime = now
+	updateCols = append(updateCols, "updated_unix_time")
+
+	addTagIds = utils.ToUniqueInt64Slice(addTagIds)
+	removeTagIds = utils.ToUniqueInt64Slice(removeTagIds)
+	addItemIds = utils.ToUniqueInt64Slice(addItemIds)
+	removeItemIds = utils.ToUniqueInt64Slice(removeItemIds)
+
+	transactionTagIndexes := make([]*models.TransactionTagIndex, len(addTagIds))
+
+	for i := 0; i < len(addTagIds); i++ {
+		transactionTagIndexes[i] = &models.TransactionTagIndex{
+			TagIndexId:      tagIndexUuids[i],
+			Uid:             transaction.Uid,
+			Deleted:         false,
+			TagId:           addTagIds[i],
+			TransactionId:   transaction.TransactionId,
+			CreatedUnixTime: now,
+			UpdatedUnixTime: now,
+		}
+	}
+
+	transactionItemIndexes := make([]*models.TransactionItemIndex, len(addItemIds))
+
+	for i := 0; i < len(addItemIds); i++ {
+		transactionItemIndexes[i] = &models.TransactionItemIndex{
+			ItemIndexId:     itemIndexUuids[i],
+			Uid:             transaction.Uid,
+			Deleted:         false,
+			ItemId:          addItemIds[i],
+			TransactionId:   transaction.TransactionId,
+			CreatedUnixTime: now,
+			UpdatedUnixTime: now,
+		}
+
+		if itemDetail, exists := itemDetails[addItemIds[i]]; exists {
+			s.fillTransactionItemIndexDetail(transactionItemIndexes[i], itemDetail)
+		}
+	}
+
+	for i := 0; i < len(splits); i++ {
+		split := splits[i]
+		split.SplitId = splitUuids[i]
+		split.Uid = transaction.Uid
+		split.Deleted = false
+		split.TransactionId = transaction.TransactionId
+		split.DisplayOrder = int32(i + 1)
+		split.CreatedUnixTime = now
+		split.UpdatedUnixTime = now
+	}
+
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, transaction.Uid)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.UserDataDB(transaction.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		// Get and verify current transaction
+		oldTransaction := &models.Transaction{}
+		has, err := sess.ID(transaction.TransactionId).Where("uid=? AND deleted=?", transaction.Uid, false).Get(oldTransaction)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get current transaction, because %s", err.Error())
+			return err
+		} else if !has {
+			return errs.ErrTransactionNotFound
+		}
+
+		relatedTransactionReconciled, err := s.isRelatedTransactionReconciled(sess, oldTransaction)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get the cleared status of related transaction, because %s", err.Error())
+			return err
+		}
+
+		if oldTransaction.IsReconciled() || relatedTransactionReconciled {
+			return errs.ErrCannotModifyReconciledTransaction
+		}
+
+		beforeSnapshot, err := s.getTransactionRevisionSnapshot(sess, oldTransaction)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get current transaction snapshot, because %s", err.Error())
+			return err
+		}
+
+		transaction.Type = oldTransaction.Type
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			transaction.RelatedId = oldTransaction.RelatedId
+		}
+
+		// Check whether account id is valid
+		err = s.isAccountIdValid(transaction)
+
+		if err != nil {
+			return err
+		}
+
+		// Get and verify source and destination account (if necessary)
+		sourceAccount, destinationAccount, err := s.getAccountModels(sess, transaction)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get account, because %s", err.Error())
+			return err
+		}
+
+		if sourceAccount.Hidden || (destinationAccount != nil && destinationAccount.Hidden) {
+			return errs.ErrCannotModifyTransactionInHiddenAccount
+		}
+
+		if sourceAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS || (destinationAccount != nil && destinationAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS) {
+			return errs.ErrCannotModifyTransactionInParentAccount
+		}
+
+		if (transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN) &&
 			sourceAccount.Currency == destinationAccount.Currency && transaction.Amount != transaction.RelatedAccountAmount {
 			return errs.ErrTransactionSourceAndDestinationAmountNotEqual
 		}
@@ -949,6 +1325,10 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			return errs.ErrCannotAddTransactionToHiddenAccount
 		}
 
+		if oldTransaction.IsLocked(userTransactionLockTime, oldSourceAccount, oldDestinationAccount) || transaction.IsLocked(userTransactionLockTime, sourceAccount, destinationAccount) {
+			return errs.ErrTransactionTimeInLockedPeriod
+		}
+
 		// Append modified columns and verify
 		if transaction.CategoryId != oldTransaction.CategoryId {
 			// Get and verify category
@@ -1016,6 +1396,20 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			}
 		}
 
+		if transaction.OriginalCurrency != oldTransaction.OriginalCurrency {
+			updateCols = append(updateCols, "original_currency")
+		}
+
+		if transaction.OriginalAmount != oldTransaction.OriginalAmount {
+			updateCols = append(updateCols, "original_amount")
+		}
+
+		transaction.OriginalAmountDecimalPlaces = utils.GetCurrencyAmountDecimalPlaces(transaction.OriginalCurrency)
+
+		if transaction.OriginalAmountDecimalPlaces != oldTransaction.OriginalAmountDecimalPlaces {
+			updateCols = append(updateCols, "original_amount_decimal_places")
+		}
+
 		if transaction.HideAmount != oldTransaction.HideAmount {
 			updateCols = append(updateCols, "hide_amount")
 		}
@@ -1032,6 +1426,17 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			updateCols = append(updateCols, "geo_latitude")
 		}
 
+		if transaction.PayeeId != oldTransaction.PayeeId {
+			// Get and verify payee
+			err = s.isPayeeValid(sess, transaction)
+
+			if err != nil {
+				return err
+			}
+
+			updateCols = append(updateCols, "payee_id")
+		}
+
 		// Get and verify tags
 		err = s.isTagsValid(sess, transaction, transactionTagIndexes, addTagIds)
 
@@ -1053,6 +1458,29 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			return err
 		}
 
+		// Get and verify splits
+		var oldSplits []*models.TransactionSplit
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).OrderBy("display_order asc").Find(&oldSplits)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get current transaction splits, because %s", err.Error())
+			return err
+		}
+
+		splitsModified := len(oldSplits) != len(splits)
+
+		for i := 0; i < len(oldSplits) && !splitsModified; i++ {
+			if !oldSplits[i].IsSameContent(splits[i]) {
+				splitsModified = true
+			}
+		}
+
+		err = s.isSplitsValid(sess, transaction, splits)
+
+		if err != nil {
+			return err
+		}
+
 		// Not allow to add transaction before balance modification transaction
 		if transaction.Type != models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
 			otherTransactionExists := false
@@ -1171,6 +1599,77 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			}
 		}
 
+		// Update quantity, unit, unit price and line amount of remaining transaction item index
+		var remainingItemIndexes []*models.TransactionItemIndex
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Find(&remainingItemIndexes)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to get remaining transaction item index, because %s", err.Error())
+			return err
+		}
+
+		for i := 0; i < len(remainingItemIndexes); i++ {
+			itemIndex := remainingItemIndexes[i]
+			newItemIndex := &models.TransactionItemIndex{
+				UpdatedUnixTime: now,
+			}
+
+			if itemDetail, exists := itemDetails[itemIndex.ItemId]; exists {
+				s.fillTransactionItemIndexDetail(newItemIndex, itemDetail)
+			}
+
+			if itemIndex.IsSameDetail(newItemIndex) {
+				continue
+			}
+
+			_, err := sess.ID(itemIndex.ItemIndexId).Cols("quantity", "unit", "unit_price", "amount", "updated_unix_time").Where("uid=? AND deleted=?", transaction.Uid, false).Update(newItemIndex)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.ModifyTransaction] failed to update transaction item index detail, because %s", err.Error())
+				return err
+			}
+		}
+
+		// Update transaction splits
+		if splitsModified {
+			if len(oldSplits) > 0 {
+				splitUpdateModel := &models.TransactionSplit{
+					Deleted:         true,
+					DeletedUnixTime: now,
+				}
+
+				_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Update(splitUpdateModel)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.ModifyTransaction] failed to remove old transaction splits, because %s", err.Error())
+					return err
+				}
+			}
+
+			for i := 0; i < len(splits); i++ {
+				split := splits[i]
+				split.TransactionTime = transaction.TransactionTime
+
+				_, err := sess.Insert(split)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.ModifyTransaction] failed to add transaction split, because %s", err.Error())
+					return err
+				}
+			}
+		} else if len(oldSplits) > 0 && modifyTransactionTime {
+			splitUpdateModel := &models.TransactionSplit{
+				TransactionTime: transaction.TransactionTime,
+			}
+
+			_, err := sess.Cols("transaction_time").Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Update(splitUpdateModel)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.ModifyTransaction] failed to update transaction splits, because %s", err.Error())
+				return err
+			}
+		}
+
 		// Update transaction picture
 		if len(removePictureIds) > 0 {
 			pictureUpdateModel := &models.TransactionPictureInfo{
@@ -1364,6 +1863,14 @@ func (s *TransactionService) ModifyTransaction(c core.Context, transaction *mode
 			return errs.ErrTransactionTypeInvalid
 		}
 
+		// Save transaction revision
+		err = s.createTransactionRevision(sess, revisionId, transaction.Uid, transaction.TransactionId, beforeSnapshot, actor, now)
+
+		if err != nil {
+			log.Errorf(c, "[transactions.ModifyTransaction] failed to save transaction revision, because %s", err.Error())
+			return err
+		}
+
 		return nil
 	})
 
@@ -1387,10 +1894,16 @@ func (s *TransactionService) MoveAllTransactionsBetweenAccounts(c core.Context,
 		return errs.ErrCannotMoveTransactionToSameAccount
 	}
 
-	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
-		// get and verify from and to account
-		fromAccount := &models.Account{}
-		has, err := sess.ID(fromAccountId).Where("uid=? AND deleted=?", uid, false).Get(fromAccount)
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		// get and verify from and to account
+		fromAccount := &models.Account{}
+		has, err := sess.ID(fromAccountId).Where("uid=? AND deleted=?", uid, false).Get(fromAccount)
 
 		if err != nil {
 			return err
@@ -1419,6 +1932,20 @@ func (s *TransactionService) MoveAllTransactionsBetweenAccounts(c core.Context,
 			return errs.ErrCannotMoveTransactionBetweenAccountsWithDifferentCurrencies
 		}
 
+		// verify whether any transaction to be moved is in the locked period
+		transactionLockTime := models.GetTransactionLockTime(userTransactionLockTime, fromAccount, toAccount)
+
+		if transactionLockTime > 0 {
+			maxLockedTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(transactionLockTime)
+			lockedTransactionExists, err := sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND (account_id=? OR related_account_id=?) AND transaction_time<=?", uid, false, fromAccountId, fromAccountId, maxLockedTransactionTime).Limit(1).Exist(&models.Transaction{})
+
+			if err != nil {
+				return err
+			} else if lockedTransactionExists {
+				return errs.ErrTransactionTimeInLockedPeriod
+			}
+		}
+
 		// combine balance modification transaction
 		var balanceModificationTransactions []*models.Transaction
 		err = sess.Where("uid=? AND deleted=? AND type=? AND (account_id=? OR account_id=?)", uid, false, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, fromAccountId, toAccountId).Find(&balanceModificationTransactions)
@@ -1427,6 +1954,12 @@ func (s *TransactionService) MoveAllTransactionsBetweenAccounts(c core.Context,
 			return err
 		}
 
+		for i := 0; i < len(balanceModificationTransactions); i++ {
+			if balanceModificationTransactions[i].IsLocked(transactionLockTime) {
+				return errs.ErrTransactionTimeInLockedPeriod
+			}
+		}
+
 		if len(balanceModificationTransactions) > 2 {
 			log.Errorf(c, "[transactions.MoveAllTransactionsBetweenAccounts] user \"uid:%d\" has more than 2 balance modification transactions in account \"id:%d\" and account \"id:%d\", cannot combine balance modification transaction", uid, fromAccountId, toAccountId)
 			return errs.ErrOperationFailed
@@ -1587,275 +2120,1445 @@ func (s *TransactionService) MoveAllTransactionsBetweenAccounts(c core.Context,
 	})
 }
 
-// DeleteTransaction deletes an existed transaction from database
-func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transactionId int64) error {
-	if uid <= 0 {
-		return errs.ErrUserIdInvalid
+// BulkEditTransactions applies the same changes to all transactions matched by the filter in one database transaction, nothing is saved when dry run is set
+func (s *TransactionService) BulkEditTransactions(c core.Context, user *models.User, clientTimezone *time.Location, filter *models.TransactionBulkEditFilter, changes *models.TransactionBulkEditChanges, dryRun bool, actor *models.TransactionRevisionActor) (*models.TransactionBulkEditResult, error) {
+	if user == nil || user.Uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
 	}
 
-	now := time.Now().Unix()
-
-	updateModel := &models.Transaction{
-		Deleted:         true,
-		DeletedUnixTime: now,
+	if changes.IsEmpty() {
+		return nil, errs.ErrTransactionBulkEditNoChanges
 	}
 
-	tagIndexUpdateModel := &models.TransactionTagIndex{
-		Deleted:         true,
-		DeletedUnixTime: now,
+	if changes.Delete && changes.HasModification() {
+		return nil, errs.ErrCannotDeleteAndModifyTransactionsInBulkEdit
 	}
 
-	pictureUpdateModel := &models.TransactionPictureInfo{
-		Deleted:         true,
-		DeletedUnixTime: now,
+	if filter.IsEmpty() {
+		return nil, errs.ErrTransactionBulkEditFilterIsEmpty
 	}
 
-	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
-		// Get and verify current transaction
-		oldTransaction := &models.Transaction{}
-		has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, false).Get(oldTransaction)
+	uid := user.Uid
+	var transactionDbType models.TransactionDbType = 0
+
+	if filter.Type > 0 {
+		var err error
+		transactionDbType, err = filter.Type.ToTransactionDbType()
 
 		if err != nil {
-			return err
-		} else if !has {
-			return errs.ErrTransactionNotFound
+			return nil, err
 		}
+	}
 
-		// Get and verify source and destination account
-		sourceAccount, destinationAccount, err := s.getAccountModels(sess, oldTransaction)
+	addTagIds := utils.ToUniqueInt64Slice(changes.AddTagIds)
+	removeTagIds := utils.Int64SliceMinus(utils.ToUniqueInt64Slice(changes.RemoveTagIds), addTagIds)
+	addItemIds := utils.ToUniqueInt64Slice(changes.AddItemIds)
+	removeItemIds := utils.Int64SliceMinus(utils.ToUniqueInt64Slice(changes.RemoveItemIds), addItemIds)
 
-		if err != nil {
-			return err
-		}
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
 
-		if sourceAccount.Hidden || (destinationAccount != nil && destinationAccount.Hidden) {
-			return errs.ErrCannotDeleteTransactionInHiddenAccount
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		if sourceAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS || (destinationAccount != nil && destinationAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS) {
-			return errs.ErrCannotDeleteTransactionInParentAccount
-		}
+	now := time.Now().Unix()
 
-		// Update transaction row to deleted
-		deletedRows, err := sess.ID(oldTransaction.TransactionId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+	result := &models.TransactionBulkEditResult{
+		MatchedTransactionIds:  make([]int64, 0),
+		ModifiedTransactionIds: make([]int64, 0),
+		DeletedTransactionIds:  make([]int64, 0),
+		SkippedTransactionIds:  make([]int64, 0),
+		AccountBalanceChanges:  make(map[int64]int64),
+	}
+
+	err = s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		// Get matched transactions
+		transactions, err := s.getBulkEditTransactions(sess, uid, transactionDbType, filter)
 
 		if err != nil {
 			return err
-		} else if deletedRows < 1 {
-			return errs.ErrTransactionNotFound
 		}
 
-		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
-			deletedRows, err = sess.ID(oldTransaction.RelatedId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		// Get and verify new category, account, tags and items
+		var newCategory *models.TransactionCategory
+
+		if changes.CategoryId > 0 {
+			newCategory = &models.TransactionCategory{}
+			has, err := sess.ID(changes.CategoryId).Where("uid=? AND deleted=?", uid, false).Get(newCategory)
 
 			if err != nil {
 				return err
-			} else if deletedRows < 1 {
-				return errs.ErrTransactionNotFound
+			} else if !has {
+				return errs.ErrTransactionCategoryNotFound
 			}
-		}
 
-		// Update transaction tag index
-		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(tagIndexUpdateModel)
+			var categoryTransactionDbType models.TransactionDbType
 
-		if err != nil {
-			return err
-		}
+			if newCategory.Type == models.CATEGORY_TYPE_INCOME {
+				categoryTransactionDbType = models.TRANSACTION_DB_TYPE_INCOME
+			} else if newCategory.Type == models.CATEGORY_TYPE_EXPENSE {
+				categoryTransactionDbType = models.TRANSACTION_DB_TYPE_EXPENSE
+			} else {
+				categoryTransactionDbType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
+			}
 
-		// Update transaction picture
-		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(pictureUpdateModel)
+			err = s.isCategoryValid(sess, &models.Transaction{Uid: uid, Type: categoryTransactionDbType, CategoryId: changes.CategoryId})
 
-		if err != nil {
-			return err
+			if err != nil {
+				return err
+			}
 		}
 
-		// Update account table
-		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
-			if oldTransaction.RelatedAccountAmount != 0 {
-				sourceAccount.UpdatedUnixTime = time.Now().Unix()
-				updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance-(%d)", oldTransaction.RelatedAccountAmount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
+		var newAccount *models.Account
+		var newAccountBalanceModificationTime int64 = 0
 
-				if err != nil {
-					return err
-				} else if updatedRows < 1 {
-					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
-					return errs.ErrDatabaseOperationFailed
-				}
+		if changes.AccountId > 0 {
+			newAccount = &models.Account{}
+			has, err := sess.ID(changes.AccountId).Where("uid=? AND deleted=?", uid, false).Get(newAccount)
+
+			if err != nil {
+				return err
+			} else if !has {
+				return errs.ErrAccountNotFound
 			}
-		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
-			if oldTransaction.Amount != 0 {
-				sourceAccount.UpdatedUnixTime = time.Now().Unix()
-				updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance-(%d)", oldTransaction.Amount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
 
-				if err != nil {
-					return err
-				} else if updatedRows < 1 {
-					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
-					return errs.ErrDatabaseOperationFailed
-				}
+			if newAccount.Hidden {
+				return errs.ErrCannotMoveTransactionFromOrToHiddenAccount
 			}
-		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
-			if oldTransaction.Amount != 0 {
-				sourceAccount.UpdatedUnixTime = time.Now().Unix()
-				updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", oldTransaction.Amount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
 
-				if err != nil {
-					return err
-				} else if updatedRows < 1 {
-					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
-					return errs.ErrDatabaseOperationFailed
-				}
+			if newAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS {
+				return errs.ErrCannotMoveTransactionFromOrToParentAccount
 			}
-		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-			if oldTransaction.Amount != 0 {
-				sourceAccount.UpdatedUnixTime = time.Now().Unix()
-				updatedSourceRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", oldTransaction.Amount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
 
-				if err != nil {
-					return err
-				} else if updatedSourceRows < 1 {
-					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
-					return errs.ErrDatabaseOperationFailed
-				}
+			balanceModificationTransaction := &models.Transaction{}
+			has, err = sess.Where("uid=? AND deleted=? AND type=? AND account_id=?", uid, false, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, newAccount.AccountId).OrderBy("transaction_time desc").Limit(1).Get(balanceModificationTransaction)
+
+			if err != nil {
+				return err
+			} else if has {
+				newAccountBalanceModificationTime = balanceModificationTransaction.TransactionTime
 			}
+		}
 
-			if oldTransaction.RelatedAccountAmount != 0 {
-				destinationAccount.UpdatedUnixTime = time.Now().Unix()
-				updatedDestinationRows, err := sess.ID(destinationAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance-(%d)", oldTransaction.RelatedAccountAmount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", destinationAccount.Uid, false).Update(destinationAccount)
+		if len(addTagIds) > 0 {
+			tagIndexes := make([]*models.TransactionTagIndex, len(addTagIds))
 
-				if err != nil {
-					return err
-				} else if updatedDestinationRows < 1 {
-					log.Errorf(c, "[transactions.DeleteTransaction] failed to update related account balance")
-					return errs.ErrDatabaseOperationFailed
+			for i := 0; i < len(addTagIds); i++ {
+				tagIndexes[i] = &models.TransactionTagIndex{
+					TagId: addTagIds[i],
 				}
 			}
-		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
-			return errs.ErrTransactionTypeInvalid
+
+			err = s.isTagsValid(sess, &models.Transaction{Uid: uid}, tagIndexes, addTagIds)
+
+			if err != nil {
+				return err
+			}
 		}
 
-		return err
-	})
-}
+		if len(addItemIds) > 0 {
+			itemIndexes := make([]*models.TransactionItemIndex, len(addItemIds))
 
-// DeleteAllTransactions deletes all existed transactions from database
-func (s *TransactionService) DeleteAllTransactions(c core.Context, uid int64, deleteAccount bool) error {
-	if uid <= 0 {
-		return errs.ErrUserIdInvalid
-	}
+			for i := 0; i < len(addItemIds); i++ {
+				itemIndexes[i] = &models.TransactionItemIndex{
+					ItemId: addItemIds[i],
+				}
+			}
 
-	now := time.Now().Unix()
+			err = s.isItemsValid(sess, &models.Transaction{Uid: uid}, itemIndexes, addItemIds)
 
-	updateModel := &models.Transaction{
-		Deleted:         true,
-		DeletedUnixTime: now,
-	}
+			if err != nil {
+				return err
+			}
+		}
 
-	tagIndexUpdateModel := &models.TransactionTagIndex{
-		Deleted:         true,
-		DeletedUnixTime: now,
-	}
+		// Get accounts, tag indexes and item indexes of matched transactions
+		transactionIds := make([]int64, len(transactions))
+		accountIds := make([]int64, 0, len(transactions)*2)
 
-	itemIndexUpdateModel := &models.TransactionItemIndex{
-		Deleted:         true,
-		DeletedUnixTime: now,
-	}
+		for i := 0; i < len(transactions); i++ {
+			transactionIds[i] = transactions[i].TransactionId
+			accountIds = append(accountIds, transactions[i].AccountId)
 
-	pictureUpdateModel := &models.TransactionPictureInfo{
-		Deleted:         true,
-		DeletedUnixTime: now,
-	}
+			if transactions[i].Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+				accountIds = append(accountIds, transactions[i].RelatedAccountId)
+			}
+		}
 
-	accountUpdateModel := &models.Account{
-		Balance:         0,
-		Deleted:         deleteAccount,
-		DeletedUnixTime: now,
-	}
+		result.MatchedTransactionIds = transactionIds
 
-	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
-		// Update all transactions to deleted
-		_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+		if len(transactions) < 1 {
+			return nil
+		}
+
+		var accounts []*models.Account
+		err = sess.Where("uid=? AND deleted=?", uid, false).In("account_id", utils.ToUniqueInt64Slice(accountIds)).Find(&accounts)
 
 		if err != nil {
 			return err
 		}
 
-		// Update all transaction tag index to deleted
-		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(tagIndexUpdateModel)
+		accountMap := make(map[int64]*models.Account, len(accounts))
 
-		if err != nil {
-			return err
+		for i := 0; i < len(accounts); i++ {
+			accountMap[accounts[i].AccountId] = accounts[i]
 		}
 
-		// Update all transaction item index to deleted
-		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(itemIndexUpdateModel)
+		var tagIndexes []*models.TransactionTagIndex
+		err = sess.Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Find(&tagIndexes)
 
 		if err != nil {
 			return err
 		}
 
-		// Update all transaction pictures to deleted
-		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(pictureUpdateModel)
+		transactionTagIds := make(map[int64][]int64, len(transactions))
 
-		if err != nil {
-			return err
+		for i := 0; i < len(tagIndexes); i++ {
+			transactionTagIds[tagIndexes[i].TransactionId] = append(transactionTagIds[tagIndexes[i].TransactionId], tagIndexes[i].TagId)
 		}
 
-		// Update all accounts to deleted or set amount to zero
-		_, err = sess.Cols("balance", "deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(accountUpdateModel)
+		var itemIndexes []*models.TransactionItemIndex
+		err = sess.Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Find(&itemIndexes)
 
 		if err != nil {
 			return err
 		}
 
-		return nil
-	})
-}
+		transactionItemIds := make(map[int64][]int64, len(transactions))
 
-// DeleteAllTransactionsOfAccount deletes all existed transactions of specific account from database
-func (s *TransactionService) DeleteAllTransactionsOfAccount(c core.Context, uid int64, accountId int64, pageCount int32) error {
-	if uid <= 0 {
-		return errs.ErrUserIdInvalid
-	}
+		for i := 0; i < len(itemIndexes); i++ {
+			transactionItemIds[itemIndexes[i].TransactionId] = append(transactionItemIds[itemIndexes[i].TransactionId], itemIndexes[i].ItemId)
+		}
 
-	if accountId <= 0 {
-		return errs.ErrAccountIdInvalid
-	}
+		relatedTransactionIds := make([]int64, 0, len(transactions))
 
-	transactions, err := s.GetAllSpecifiedTransactions(c, uid, 0, 0, 0, nil, []int64{accountId}, nil, false, "", "", pageCount, true)
+		for i := 0; i < len(transactions); i++ {
+			if transactions[i].Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+				relatedTransactionIds = append(relatedTransactionIds, transactions[i].RelatedId)
+			}
+		}
 
-	if err != nil {
-		return err
-	}
+		reconciledRelatedTransactionIds := make(map[int64]bool, len(relatedTransactionIds))
 
-	if len(transactions) < 1 {
-		return nil
-	}
+		if len(relatedTransactionIds) > 0 {
+			var reconciledRelatedTransactions []*models.Transaction
+			err = sess.Cols("transaction_id").Where("uid=? AND deleted=? AND cleared_status=?", uid, false, models.TRANSACTION_CLEARED_STATUS_RECONCILED).In("transaction_id", relatedTransactionIds).Find(&reconciledRelatedTransactions)
 
-	for i := 0; i < len(transactions); i++ {
-		transaction := transactions[i]
+			if err != nil {
+				return err
+			}
 
-		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
-			err = s.DeleteTransaction(c, uid, transaction.RelatedId)
-		} else {
-			err = s.DeleteTransaction(c, uid, transaction.TransactionId)
+			for i := 0; i < len(reconciledRelatedTransactions); i++ {
+				reconciledRelatedTransactionIds[reconciledRelatedTransactions[i].TransactionId] = true
+			}
 		}
 
-		if err != nil {
-			return err
+		splitTransactionIds := make(map[int64]bool)
+
+		if !changes.CanApplyToSplitTransaction(filter) {
+			var splits []*models.TransactionSplit
+			err = sess.Cols("transaction_id").Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Find(&splits)
+
+			if err != nil {
+				return err
+			}
+
+			for i := 0; i < len(splits); i++ {
+				splitTransactionIds[splits[i].TransactionId] = true
+			}
 		}
-	}
 
-	return nil
-}
+		// Decide the changes of every transaction
+		deletedTransactions := make([]*models.Transaction, 0, len(transactions))
+		modifiedTransactions := make([]*models.Transaction, 0, len(transactions))
+		modifiedTransactionUpdateCols := make(map[int64][]string, len(transactions))
+		modifiedTransactionOldAccountIds := make(map[int64]int64, len(transactions))
+		modifiedTransactionAddTagIds := make(map[int64][]int64, len(transactions))
+		modifiedTransactionRemoveTagIds := make(map[int64][]int64, len(transactions))
+		modifiedTransactionAddItemIds := make(map[int64][]int64, len(transactions))
+		modifiedTransactionRemoveItemIds := make(map[int64][]int64, len(transactions))
+		totalAddTagIndexCount := 0
+		totalAddItemIndexCount := 0
 
-// GetRelatedTransferTransaction returns the related transaction for transfer transaction
-func (s *TransactionService) GetRelatedTransferTransaction(originalTransaction *models.Transaction) *models.Transaction {
-	var relatedType models.TransactionDbType
-	var relatedTransactionTime int64
+		for i := 0; i < len(transactions); i++ {
+			transaction := transactions[i]
+			sourceAccount := accountMap[transaction.AccountId]
+			var destinationAccount *models.Account
 
-	if originalTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
-		relatedType = models.TRANSACTION_DB_TYPE_TRANSFER_IN
+			if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+				destinationAccount = accountMap[transaction.RelatedAccountId]
+			}
+
+			if !user.CanEditTransactionByTransactionTime(transaction.TransactionTime, clientTimezone) ||
+				transaction.IsReconciled() ||
+				(transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && reconciledRelatedTransactionIds[transaction.RelatedId]) ||
+				splitTransactionIds[transaction.TransactionId] ||
+				sourceAccount == nil || sourceAccount.Hidden ||
+				(transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && (destinationAccount == nil || destinationAccount.Hidden)) ||
+				transaction.IsLocked(userTransactionLockTime, sourceAccount, destinationAccount) {
+				result.SkippedTransactionIds = append(result.SkippedTransactionIds, transaction.TransactionId)
+				continue
+			}
+
+			if changes.Delete {
+				if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+					result.AccountBalanceChanges[transaction.AccountId] -= transaction.RelatedAccountAmount
+				} else if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+					result.AccountBalanceChanges[transaction.AccountId] -= transaction.Amount
+				} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+					result.AccountBalanceChanges[transaction.AccountId] += transaction.Amount
+				} else if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+					result.AccountBalanceChanges[transaction.AccountId] += transaction.Amount
+					result.AccountBalanceChanges[transaction.RelatedAccountId] -= transaction.RelatedAccountAmount
+				}
+
+				deletedTransactions = append(deletedTransactions, transaction)
+				result.DeletedTransactionIds = append(result.DeletedTransactionIds, transaction.TransactionId)
+				continue
+			}
+
+			skipped := false
+			updateCols := make([]string, 0, 4)
+
+			if newCategory != nil && transaction.CategoryId != newCategory.CategoryId {
+				if (transaction.Type == models.TRANSACTION_DB_TYPE_INCOME && newCategory.Type == models.CATEGORY_TYPE_INCOME) ||
+					(transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE && newCategory.Type == models.CATEGORY_TYPE_EXPENSE) ||
+					(transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && newCategory.Type == models.CATEGORY_TYPE_TRANSFER) {
+					updateCols = append(updateCols, "category_id")
+				} else {
+					skipped = true
+				}
+			}
+
+			if newAccount != nil && transaction.AccountId != newAccount.AccountId {
+				if transaction.Type != models.TRANSACTION_DB_TYPE_MODIFY_BALANCE &&
+					newAccount.Currency == sourceAccount.Currency &&
+					!(transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT && transaction.RelatedAccountId == newAccount.AccountId) &&
+					!transaction.IsLocked(userTransactionLockTime, newAccount) &&
+					transaction.TransactionTime > newAccountBalanceModificationTime {
+					updateCols = append(updateCols, "account_id")
+				} else {
+					skipped = true
+				}
+			}
+
+			currentTagIds := transactionTagIds[transaction.TransactionId]
+			transactionAddTagIds := utils.Int64SliceMinus(addTagIds, currentTagIds)
+			transactionRemoveTagIds := utils.Int64SliceMinus(removeTagIds, utils.Int64SliceMinus(removeTagIds, currentTagIds))
+
+			if len(currentTagIds)+len(transactionAddTagIds)-len(transactionRemoveTagIds) > models.MaximumTagsCountOfTransaction {
+				skipped = true
+			}
+
+			currentItemIds := transactionItemIds[transaction.TransactionId]
+			transactionAddItemIds := utils.Int64SliceMinus(addItemIds, currentItemIds)
+			transactionRemoveItemIds := utils.Int64SliceMinus(removeItemIds, utils.Int64SliceMinus(removeItemIds, currentItemIds))
+
+			if len(currentItemIds)+len(transactionAddItemIds)-len(transactionRemoveItemIds) > models.MaximumItemsCountOfTransaction {
+				skipped = true
+			}
+
+			if changes.Comment != nil && transaction.Comment != *changes.Comment {
+				updateCols = append(updateCols, "comment")
+			}
+
+			if skipped {
+				result.SkippedTransactionIds = append(result.SkippedTransactionIds, transaction.TransactionId)
+				continue
+			}
+
+			if len(updateCols) < 1 && len(transactionAddTagIds) < 1 && len(transactionRemoveTagIds) < 1 && len(transactionAddItemIds) < 1 && len(transactionRemoveItemIds) < 1 {
+				continue
+			}
+
+			if slices.Contains(updateCols, "account_id") {
+				if transaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+					result.AccountBalanceChanges[transaction.AccountId] -= transaction.Amount
+					result.AccountBalanceChanges[newAccount.AccountId] += transaction.Amount
+				} else if transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+					result.AccountBalanceChanges[transaction.AccountId] += transaction.Amount
+					result.AccountBalanceChanges[newAccount.AccountId] -= transaction.Amount
+				}
+
+				modifiedTransactionOldAccountIds[transaction.TransactionId] = transaction.AccountId
+			}
+
+			modifiedTransactions = append(modifiedTransactions, transaction)
+			modifiedTransactionUpdateCols[transaction.TransactionId] = updateCols
+			modifiedTransactionAddTagIds[transaction.TransactionId] = transactionAddTagIds
+			modifiedTransactionRemoveTagIds[transaction.TransactionId] = transactionRemoveTagIds
+			modifiedTransactionAddItemIds[transaction.TransactionId] = transactionAddItemIds
+			modifiedTransactionRemoveItemIds[transaction.TransactionId] = transactionRemoveItemIds
+			totalAddTagIndexCount += len(transactionAddTagIds)
+			totalAddItemIndexCount += len(transactionAddItemIds)
+			result.ModifiedTransactionIds = append(result.ModifiedTransactionIds, transaction.TransactionId)
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		// Delete transactions
+		if len(deletedTransactions) > 0 {
+			revisionIds := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(deletedTransactions)))
+
+			if len(revisionIds) < len(deletedTransactions) {
+				return errs.ErrSystemIsBusy
+			}
+
+			err = s.doBulkDeleteTransactions(sess, uid, deletedTransactions, revisionIds, actor, now)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.BulkEditTransactions] failed to delete transactions, because %s", err.Error())
+				return err
+			}
+		}
+
+		// Modify transactions
+		if len(modifiedTransactions) > 0 {
+			revisionIds := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(modifiedTransactions)))
+
+			if len(revisionIds) < len(modifiedTransactions) {
+				return errs.ErrSystemIsBusy
+			}
+
+			tagIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_TAG_INDEX, uint16(totalAddTagIndexCount))
+
+			if len(tagIndexUuids) < totalAddTagIndexCount {
+				return errs.ErrSystemIsBusy
+			}
+
+			itemIndexUuids := s.GenerateUuids(uuid.UUID_TYPE_ITEM_INDEX, uint16(totalAddItemIndexCount))
+
+			if len(itemIndexUuids) < totalAddItemIndexCount {
+				return errs.ErrSystemIsBusy
+			}
+
+			for i := 0; i < len(modifiedTransactions); i++ {
+				transaction := modifiedTransactions[i]
+				beforeSnapshot, err := s.getTransactionRevisionSnapshot(sess, transaction)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.BulkEditTransactions] failed to get transaction \"id:%d\" snapshot, because %s", transaction.TransactionId, err.Error())
+					return err
+				}
+
+				updateCols := modifiedTransactionUpdateCols[transaction.TransactionId]
+
+				if len(updateCols) > 0 {
+					if newCategory != nil {
+						transaction.CategoryId = newCategory.CategoryId
+					}
+
+					if newAccount != nil {
+						transaction.AccountId = newAccount.AccountId
+					}
+
+					if changes.Comment != nil {
+						transaction.Comment = *changes.Comment
+					}
+
+					transaction.UpdatedUnixTime = now
+					updateCols = append(updateCols, "updated_unix_time")
+
+					updatedRows, err := sess.ID(transaction.TransactionId).Cols(updateCols...).Where("uid=? AND deleted=?", uid, false).Update(transaction)
+
+					if err != nil {
+						log.Errorf(c, "[transactions.BulkEditTransactions] failed to update transaction \"id:%d\", because %s", transaction.TransactionId, err.Error())
+						return err
+					} else if updatedRows < 1 {
+						return errs.ErrTransactionNotFound
+					}
+
+					if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+						relatedTransaction := s.GetRelatedTransferTransaction(transaction)
+						updatedRows, err = sess.ID(relatedTransaction.TransactionId).Cols(s.getRelatedUpdateColumns(updateCols)...).Where("uid=? AND deleted=?", uid, false).Update(relatedTransaction)
+
+						if err != nil {
+							log.Errorf(c, "[transactions.BulkEditTransactions] failed to update related transaction \"id:%d\", because %s", relatedTransaction.TransactionId, err.Error())
+							return err
+						} else if updatedRows < 1 {
+							log.Errorf(c, "[transactions.BulkEditTransactions] failed to update related transaction \"id:%d\"", relatedTransaction.TransactionId)
+							return errs.ErrDatabaseOperationFailed
+						}
+					}
+				}
+
+				// Update transaction tag index
+				transactionRemoveTagIds := modifiedTransactionRemoveTagIds[transaction.TransactionId]
+
+				if len(transactionRemoveTagIds) > 0 {
+					tagIndexUpdateModel := &models.TransactionTagIndex{
+						Deleted:         true,
+						DeletedUnixTime: now,
+					}
+
+					_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, transaction.TransactionId).In("tag_id", transactionRemoveTagIds).Update(tagIndexUpdateModel)
+
+					if err != nil {
+						log.Errorf(c, "[transactions.BulkEditTransactions] failed to remove transaction tag index, because %s", err.Error())
+						return err
+					}
+				}
+
+				transactionAddTagIds := modifiedTransactionAddTagIds[transaction.TransactionId]
+
+				for j := 0; j < len(transactionAddTagIds); j++ {
+					transactionTagIndex := &models.TransactionTagIndex{
+						TagIndexId:      tagIndexUuids[0],
+						Uid:             uid,
+						Deleted:         false,
+						TagId:           transactionAddTagIds[j],
+						TransactionId:   transaction.TransactionId,
+						TransactionTime: transaction.TransactionTime,
+						CreatedUnixTime: now,
+						UpdatedUnixTime: now,
+					}
+
+					tagIndexUuids = tagIndexUuids[1:]
+					_, err := sess.Insert(transactionTagIndex)
+
+					if err != nil {
+						log.Errorf(c, "[transactions.BulkEditTransactions] failed to add transaction tag index, because %s", err.Error())
+						return err
+					}
+				}
+
+				// Update transaction item index
+				transactionRemoveItemIds := modifiedTransactionRemoveItemIds[transaction.TransactionId]
+
+				if len(transactionRemoveItemIds) > 0 {
+					itemIndexUpdateModel := &models.TransactionItemIndex{
+						Deleted:         true,
+						DeletedUnixTime: now,
+					}
+
+					_, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, transaction.TransactionId).In("item_id", transactionRemoveItemIds).Update(itemIndexUpdateModel)
+
+					if err != nil {
+						log.Errorf(c, "[transactions.BulkEditTransactions] failed to remove transaction item index, because %s", err.Error())
+						return err
+					}
+				}
+
+				transactionAddItemIds := modifiedTransactionAddItemIds[transaction.TransactionId]
+
+				for j := 0; j < len(transactionAddItemIds); j++ {
+					transactionItemIndex := &models.TransactionItemIndex{
+						ItemIndexId:     itemIndexUuids[0],
+						Uid:             uid,
+						Deleted:         false,
+						ItemId:          transactionAddItemIds[j],
+						TransactionId:   transaction.TransactionId,
+						TransactionTime: transaction.TransactionTime,
+						CreatedUnixTime: now,
+						UpdatedUnixTime: now,
+					}
+
+					itemIndexUuids = itemIndexUuids[1:]
+					_, err := sess.Insert(transactionItemIndex)
+
+					if err != nil {
+						log.Errorf(c, "[transactions.BulkEditTransactions] failed to add transaction item index, because %s", err.Error())
+						return err
+					}
+				}
+
+				// Save transaction revision
+				err = s.createTransactionRevision(sess, revisionIds[i], uid, transaction.TransactionId, beforeSnapshot, actor, now)
+
+				if err != nil {
+					log.Errorf(c, "[transactions.BulkEditTransactions] failed to save transaction \"id:%d\" revision, because %s", transaction.TransactionId, err.Error())
+					return err
+				}
+			}
+		}
+
+		// Update account table
+		changedAccountIds := make([]int64, 0, len(result.AccountBalanceChanges))
+
+		for accountId, amount := range result.AccountBalanceChanges {
+			if amount != 0 {
+				changedAccountIds = append(changedAccountIds, accountId)
+			}
+		}
+
+		slices.Sort(changedAccountIds)
+
+		for i := 0; i < len(changedAccountIds); i++ {
+			accountId := changedAccountIds[i]
+			account := &models.Account{
+				UpdatedUnixTime: now,
+			}
+
+			updatedRows, err := sess.ID(accountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", result.AccountBalanceChanges[accountId])).Cols("updated_unix_time").Where("uid=? AND deleted=?", uid, false).Update(account)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.BulkEditTransactions] failed to update account \"id:%d\" balance, because %s", accountId, err.Error())
+				return err
+			} else if updatedRows < 1 {
+				log.Errorf(c, "[transactions.BulkEditTransactions] failed to update account \"id:%d\" balance", accountId)
+				return errs.ErrDatabaseOperationFailed
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ModifyTransactionClearedStatus updates the cleared status of an existed transaction (and the related transaction of transfer if it is not reconciled), the reconciled transaction can only be changed back to cleared
+func (s *TransactionService) ModifyTransactionClearedStatus(c core.Context, uid int64, transactionId int64, clearedStatus models.TransactionClearedStatus) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	updateModel := &models.Transaction{
+		ClearedStatus:   clearedStatus,
+		UpdatedUnixTime: time.Now().Unix(),
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		transaction := &models.Transaction{}
+		has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, false).Get(transaction)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTransactionNotFound
+		}
+
+		if transaction.IsReconciled() {
+			if clearedStatus != models.TRANSACTION_CLEARED_STATUS_CLEARED {
+				return errs.ErrCannotModifyReconciledTransaction
+			}
+
+			// only the transfer side in the reconciled account is changed back to cleared
+			updatedRows, err := sess.ID(transaction.TransactionId).Cols("cleared_status", "updated_unix_time").Where("uid=? AND deleted=? AND cleared_status=?", uid, false, models.TRANSACTION_CLEARED_STATUS_RECONCILED).Update(updateModel)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				return errs.ErrTransactionNotFound
+			}
+
+			return nil
+		}
+
+		transactionIds := []int64{transaction.TransactionId}
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			transactionIds = append(transactionIds, transaction.RelatedId)
+		}
+
+		// the reconciled transfer side in the other account keeps unchanged
+		updatedRows, err := sess.Cols("cleared_status", "updated_unix_time").Where("uid=? AND deleted=? AND cleared_status<>?", uid, false, models.TRANSACTION_CLEARED_STATUS_RECONCILED).In("transaction_id", transactionIds).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrTransactionNotFound
+		}
+
+		return nil
+	})
+}
+
+// DeleteTransaction deletes an existed transaction from database
+func (s *TransactionService) DeleteTransaction(c core.Context, uid int64, transactionId int64, actor *models.TransactionRevisionActor) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Transaction{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	tagIndexUpdateModel := &models.TransactionTagIndex{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	itemIndexUpdateModel := &models.TransactionItemIndex{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	pictureUpdateModel := &models.TransactionPictureInfo{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	splitUpdateModel := &models.TransactionSplit{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	revisionUpdateModel := &models.TransactionRevision{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	revisionId := s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if revisionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		// Get and verify current transaction
+		oldTransaction := &models.Transaction{}
+		has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, false).Get(oldTransaction)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTransactionNotFound
+		}
+
+		relatedTransactionReconciled, err := s.isRelatedTransactionReconciled(sess, oldTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		if oldTransaction.IsReconciled() || relatedTransactionReconciled {
+			return errs.ErrCannotDeleteReconciledTransaction
+		}
+
+		// Get and verify source and destination account
+		sourceAccount, destinationAccount, err := s.getAccountModels(sess, oldTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		if sourceAccount.Hidden || (destinationAccount != nil && destinationAccount.Hidden) {
+			return errs.ErrCannotDeleteTransactionInHiddenAccount
+		}
+
+		if sourceAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS || (destinationAccount != nil && destinationAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS) {
+			return errs.ErrCannotDeleteTransactionInParentAccount
+		}
+
+		if oldTransaction.IsLocked(userTransactionLockTime, sourceAccount, destinationAccount) {
+			return errs.ErrTransactionTimeInLockedPeriod
+		}
+
+		// Get the snapshot before deletion, the revisions of transfer transaction are saved with the transfer out transaction
+		revisionTransaction := oldTransaction
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			revisionTransaction = &models.Transaction{}
+			has, err = sess.ID(oldTransaction.RelatedId).Where("uid=? AND deleted=?", uid, false).Get(revisionTransaction)
+
+			if err != nil {
+				return err
+			} else if !has {
+				return errs.ErrTransactionNotFound
+			}
+		}
+
+		beforeSnapshot, err := s.getTransactionRevisionSnapshot(sess, revisionTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction row to deleted
+		deletedRows, err := sess.ID(oldTransaction.TransactionId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTransactionNotFound
+		}
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			deletedRows, err = sess.ID(oldTransaction.RelatedId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+			if err != nil {
+				return err
+			} else if deletedRows < 1 {
+				return errs.ErrTransactionNotFound
+			}
+		}
+
+		// Update transaction tag index
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(tagIndexUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction item index
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(itemIndexUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction picture
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(pictureUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction splits
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, oldTransaction.TransactionId).Update(splitUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update transaction revisions
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=?", uid, false, revisionTransaction.TransactionId).Update(revisionUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		err = s.insertTransactionRevision(sess, revisionId, uid, revisionTransaction.TransactionId, models.TRANSACTION_REVISION_TYPE_DELETE, beforeSnapshot, nil, actor, now)
+
+		if err != nil {
+			return err
+		}
+
+		// Update account table
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			if oldTransaction.RelatedAccountAmount != 0 {
+				sourceAccount.UpdatedUnixTime = time.Now().Unix()
+				updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance-(%d)", oldTransaction.RelatedAccountAmount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
+
+				if err != nil {
+					return err
+				} else if updatedRows < 1 {
+					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
+					return errs.ErrDatabaseOperationFailed
+				}
+			}
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			if oldTransaction.Amount != 0 {
+				sourceAccount.UpdatedUnixTime = time.Now().Unix()
+				updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance-(%d)", oldTransaction.Amount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
+
+				if err != nil {
+					return err
+				} else if updatedRows < 1 {
+					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
+					return errs.ErrDatabaseOperationFailed
+				}
+			}
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			if oldTransaction.Amount != 0 {
+				sourceAccount.UpdatedUnixTime = time.Now().Unix()
+				updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", oldTransaction.Amount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
+
+				if err != nil {
+					return err
+				} else if updatedRows < 1 {
+					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
+					return errs.ErrDatabaseOperationFailed
+				}
+			}
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			if oldTransaction.Amount != 0 {
+				sourceAccount.UpdatedUnixTime = time.Now().Unix()
+				updatedSourceRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", oldTransaction.Amount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
+
+				if err != nil {
+					return err
+				} else if updatedSourceRows < 1 {
+					log.Errorf(c, "[transactions.DeleteTransaction] failed to update account balance")
+					return errs.ErrDatabaseOperationFailed
+				}
+			}
+
+			if oldTransaction.RelatedAccountAmount != 0 {
+				destinationAccount.UpdatedUnixTime = time.Now().Unix()
+				updatedDestinationRows, err := sess.ID(destinationAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance-(%d)", oldTransaction.RelatedAccountAmount)).Cols("updated_unix_time").Where("uid=? AND deleted=?", destinationAccount.Uid, false).Update(destinationAccount)
+
+				if err != nil {
+					return err
+				} else if updatedDestinationRows < 1 {
+					log.Errorf(c, "[transactions.DeleteTransaction] failed to update related account balance")
+					return errs.ErrDatabaseOperationFailed
+				}
+			}
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			return errs.ErrTransactionTypeInvalid
+		}
+
+		return err
+	})
+}
+
+// GetAllDeletedTransactionsByUid returns all deleted transaction models of user which can be restored
+func (s *TransactionService) GetAllDeletedTransactionsByUid(c core.Context, uid int64) ([]*models.Transaction, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var transactions []*models.Transaction
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND type<>?", uid, true, models.TRANSACTION_DB_TYPE_TRANSFER_IN).OrderBy("deleted_unix_time desc, transaction_time desc").Find(&transactions)
+
+	return transactions, err
+}
+
+// RestoreTransaction restores a deleted transaction with the tag indexes, item indexes, pictures, splits and revisions deleted together, and re-applies the account balances
+func (s *TransactionService) RestoreTransaction(c core.Context, uid int64, transactionId int64, actor *models.TransactionRevisionActor) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if transactionId <= 0 {
+		return errs.ErrTransactionIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	restoreModel := &models.Transaction{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	tagIndexRestoreModel := &models.TransactionTagIndex{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	itemIndexRestoreModel := &models.TransactionItemIndex{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	pictureRestoreModel := &models.TransactionPictureInfo{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	splitRestoreModel := &models.TransactionSplit{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	revisionRestoreModel := &models.TransactionRevision{
+		Deleted:         false,
+		DeletedUnixTime: 0,
+	}
+
+	revisionId := s.GenerateUuid(uuid.UUID_TYPE_DEFAULT)
+
+	if revisionId < 1 {
+		return errs.ErrSystemIsBusy
+	}
+
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		// Get and verify deleted transaction
+		oldTransaction := &models.Transaction{}
+		has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, true).Get(oldTransaction)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			return errs.ErrTransactionTypeInvalid
+		}
+
+		deletedUnixTime := oldTransaction.DeletedUnixTime
+
+		// Get and verify source and destination account
+		sourceAccount, destinationAccount, err := s.getAccountModels(sess, oldTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		if sourceAccount.Hidden || (destinationAccount != nil && destinationAccount.Hidden) {
+			return errs.ErrCannotAddTransactionToHiddenAccount
+		}
+
+		if sourceAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS || (destinationAccount != nil && destinationAccount.Type == models.ACCOUNT_TYPE_MULTI_SUB_ACCOUNTS) {
+			return errs.ErrCannotAddTransactionToParentAccount
+		}
+
+		if oldTransaction.IsLocked(userTransactionLockTime, sourceAccount, destinationAccount) {
+			return errs.ErrTransactionTimeInLockedPeriod
+		}
+
+		// Get and verify category and payee
+		err = s.isCategoryValid(sess, oldTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		err = s.isPayeeValid(sess, oldTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		// Get and verify tags and items deleted together
+		var transactionTagIndexes []*models.TransactionTagIndex
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=? AND deleted_unix_time=?", uid, true, oldTransaction.TransactionId, deletedUnixTime).Find(&transactionTagIndexes)
+
+		if err != nil {
+			return err
+		}
+
+		tagIds := make([]int64, len(transactionTagIndexes))
+
+		for i := 0; i < len(transactionTagIndexes); i++ {
+			tagIds[i] = transactionTagIndexes[i].TagId
+		}
+
+		err = s.isTagsValid(sess, oldTransaction, transactionTagIndexes, tagIds)
+
+		if err != nil {
+			return err
+		}
+
+		var transactionItemIndexes []*models.TransactionItemIndex
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=? AND deleted_unix_time=?", uid, true, oldTransaction.TransactionId, deletedUnixTime).Find(&transactionItemIndexes)
+
+		if err != nil {
+			return err
+		}
+
+		itemIds := make([]int64, len(transactionItemIndexes))
+
+		for i := 0; i < len(transactionItemIndexes); i++ {
+			itemIds[i] = transactionItemIndexes[i].ItemId
+		}
+
+		err = s.isItemsValid(sess, oldTransaction, transactionItemIndexes, itemIds)
+
+		if err != nil {
+			return err
+		}
+
+		// Get and verify splits deleted together
+		var transactionSplits []*models.TransactionSplit
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=? AND deleted_unix_time=?", uid, true, oldTransaction.TransactionId, deletedUnixTime).OrderBy("display_order asc").Find(&transactionSplits)
+
+		if err != nil {
+			return err
+		}
+
+		err = s.isSplitsValid(sess, oldTransaction, transactionSplits)
+
+		if err != nil {
+			return err
+		}
+
+		// Verify balance modification transaction
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			otherTransactionExists, err := sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND account_id=? AND (type=? OR transaction_time<=?)", uid, false, sourceAccount.AccountId, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, oldTransaction.TransactionTime).Limit(1).Exist(&models.Transaction{})
+
+			if err != nil {
+				return err
+			} else if otherTransactionExists {
+				return errs.ErrBalanceModificationTransactionCannotAddWhenNotEmpty
+			}
+		} else {
+			otherTransactionExists := false
+
+			if destinationAccount != nil && sourceAccount.AccountId != destinationAccount.AccountId {
+				otherTransactionExists, err = sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND type=? AND (account_id=? OR account_id=?) AND transaction_time>=?", uid, false, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, sourceAccount.AccountId, destinationAccount.AccountId, oldTransaction.TransactionTime).Limit(1).Exist(&models.Transaction{})
+			} else {
+				otherTransactionExists, err = sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND type=? AND account_id=? AND transaction_time>=?", uid, false, models.TRANSACTION_DB_TYPE_MODIFY_BALANCE, sourceAccount.AccountId, oldTransaction.TransactionTime).Limit(1).Exist(&models.Transaction{})
+			}
+
+			if err != nil {
+				return err
+			} else if otherTransactionExists {
+				return errs.ErrCannotAddTransactionBeforeBalanceModificationTransaction
+			}
+		}
+
+		// Update transaction row to not deleted
+		restoredRows, err := sess.ID(oldTransaction.TransactionId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND deleted_unix_time=?", uid, true, deletedUnixTime).Update(restoreModel)
+
+		if err != nil {
+			return err
+		} else if restoredRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			restoredRows, err = sess.ID(oldTransaction.RelatedId).Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND deleted_unix_time=?", uid, true, deletedUnixTime).Update(restoreModel)
+
+			if err != nil {
+				return err
+			} else if restoredRows < 1 {
+				return errs.ErrTrashItemNotFound
+			}
+		}
+
+		// Update transaction tag index, item index, picture, splits and revisions deleted together
+		restoreModels := []any{tagIndexRestoreModel, itemIndexRestoreModel, pictureRestoreModel, splitRestoreModel, revisionRestoreModel}
+
+		for i := 0; i < len(restoreModels); i++ {
+			_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=? AND transaction_id=? AND deleted_unix_time=?", uid, true, oldTransaction.TransactionId, deletedUnixTime).Update(restoreModels[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		afterSnapshot, err := s.getTransactionRevisionSnapshot(sess, oldTransaction)
+
+		if err != nil {
+			return err
+		}
+
+		err = s.insertTransactionRevision(sess, revisionId, uid, oldTransaction.TransactionId, models.TRANSACTION_REVISION_TYPE_RESTORE, nil, afterSnapshot, actor, now)
+
+		if err != nil {
+			return err
+		}
+
+		// Update account table
+		sourceAccountBalanceChange := int64(0)
+		destinationAccountBalanceChange := int64(0)
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
+			sourceAccountBalanceChange = oldTransaction.RelatedAccountAmount
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_INCOME {
+			sourceAccountBalanceChange = oldTransaction.Amount
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE {
+			sourceAccountBalanceChange = -oldTransaction.Amount
+		} else if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			sourceAccountBalanceChange = -oldTransaction.Amount
+			destinationAccountBalanceChange = oldTransaction.RelatedAccountAmount
+		}
+
+		if sourceAccountBalanceChange != 0 {
+			sourceAccount.UpdatedUnixTime = now
+			updatedRows, err := sess.ID(sourceAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", sourceAccountBalanceChange)).Cols("updated_unix_time").Where("uid=? AND deleted=?", sourceAccount.Uid, false).Update(sourceAccount)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				log.Errorf(c, "[transactions.RestoreTransaction] failed to update account balance")
+				return errs.ErrDatabaseOperationFailed
+			}
+		}
+
+		if destinationAccountBalanceChange != 0 {
+			destinationAccount.UpdatedUnixTime = now
+			updatedRows, err := sess.ID(destinationAccount.AccountId).SetExpr("balance", fmt.Sprintf("balance+(%d)", destinationAccountBalanceChange)).Cols("updated_unix_time").Where("uid=? AND deleted=?", destinationAccount.Uid, false).Update(destinationAccount)
+
+			if err != nil {
+				return err
+			} else if updatedRows < 1 {
+				log.Errorf(c, "[transactions.RestoreTransaction] failed to update related account balance")
+				return errs.ErrDatabaseOperationFailed
+			}
+		}
+
+		return nil
+	})
+}
+
+// PurgeTransaction permanently deletes a deleted transaction and its related data from database, returns the picture infos whose files should be deleted
+func (s *TransactionService) PurgeTransaction(c core.Context, uid int64, transactionId int64) ([]*models.TransactionPictureInfo, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if transactionId <= 0 {
+		return nil, errs.ErrTransactionIdInvalid
+	}
+
+	var pictureInfos []*models.TransactionPictureInfo
+
+	err := s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		oldTransaction := &models.Transaction{}
+		has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, true).Get(oldTransaction)
+
+		if err != nil {
+			return err
+		} else if !has {
+			return errs.ErrTrashItemNotFound
+		}
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			return errs.ErrTransactionTypeInvalid
+		}
+
+		transactionIds := []int64{oldTransaction.TransactionId}
+
+		if oldTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			transactionIds = append(transactionIds, oldTransaction.RelatedId)
+		}
+
+		err = sess.Where("uid=? AND deleted=? AND transaction_id=?", uid, true, oldTransaction.TransactionId).Find(&pictureInfos)
+
+		if err != nil {
+			return err
+		}
+
+		deletedRows, err := sess.Where("uid=? AND deleted=?", uid, true).In("transaction_id", transactionIds).Delete(&models.Transaction{})
+
+		if err != nil {
+			return err
+		} else if deletedRows < 1 {
+			return errs.ErrTrashItemNotFound
+		}
+
+		beans := []any{&models.TransactionTagIndex{}, &models.TransactionItemIndex{}, &models.TransactionPictureInfo{}, &models.TransactionSplit{}, &models.TransactionRevision{}}
+
+		for i := 0; i < len(beans); i++ {
+			_, err = sess.Where("uid=? AND deleted=? AND transaction_id=?", uid, true, oldTransaction.TransactionId).Delete(beans[i])
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pictureInfos, nil
+}
+
+// MigrateAllTransactionsOriginalAmountDecimalPlaces rescales the original amounts of all transactions which are not stored in the decimal places of their original currencies, returns the count of migrated users
+func (s *TransactionService) MigrateAllTransactionsOriginalAmountDecimalPlaces(c core.Context) (int, error) {
+	currencies := getCurrenciesWithNonDefaultAmountDecimalPlaces()
+
+	if len(currencies) < 1 {
+		return 0, nil
+	}
+
+	var errors []error
+	totalCount := 0
+
+	for i := 0; i < s.UserDataDBCount(); i++ {
+		var transactions []*models.Transaction
+		err := s.UserDataDBByIndex(i).NewSession(c).Distinct("uid").In("original_currency", currencies).Where("original_amount_decimal_places=?", utils.DefaultCurrencyDecimalPlaces).Find(&transactions)
+
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+
+		for j := 0; j < len(transactions); j++ {
+			uid := transactions[j].Uid
+			err = s.migrateTransactionsOriginalAmountDecimalPlaces(c, s.UserDataDBByIndex(i), uid, currencies)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.MigrateAllTransactionsOriginalAmountDecimalPlaces] failed to migrate transactions of user \"uid:%d\", because %s", uid, err.Error())
+				errors = append(errors, err)
+				continue
+			}
+
+			totalCount++
+		}
+	}
+
+	return totalCount, errs.NewMultiErrorOrNil(errors...)
+}
+
+// PurgeAllDeletedTransactions permanently deletes all transactions and related data which have been deleted before the specified unix time
+func (s *TransactionService) PurgeAllDeletedTransactions(c core.Context, deletedBeforeUnixTime int64) error {
+	count, err := s.purgeDeletedRows(c, deletedBeforeUnixTime, &models.Transaction{}, &models.TransactionTagIndex{}, &models.TransactionItemIndex{}, &models.TransactionSplit{}, &models.TransactionRevision{})
+
+	if count > 0 {
+		log.Infof(c, "[transactions.PurgeAllDeletedTransactions] %d deleted transaction rows have been purged", count)
+	} else if err == nil {
+		log.Infof(c, "[transactions.PurgeAllDeletedTransactions] no deleted transaction rows have been purged")
+	}
+
+	return err
+}
+
+// DeleteAllTransactions deletes all existed transactions from database
+func (s *TransactionService) DeleteAllTransactions(c core.Context, uid int64, deleteAccount bool) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.Transaction{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	tagIndexUpdateModel := &models.TransactionTagIndex{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	itemIndexUpdateModel := &models.TransactionItemIndex{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	pictureUpdateModel := &models.TransactionPictureInfo{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	splitUpdateModel := &models.TransactionSplit{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	revisionUpdateModel := &models.TransactionRevision{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	accountUpdateModel := &models.Account{
+		Balance:         0,
+		Deleted:         deleteAccount,
+		DeletedUnixTime: now,
+	}
+
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, uid)
+
+	if err != nil {
+		return err
+	}
+
+	return s.UserDataDB(uid).DoTransaction(c, func(sess *xorm.Session) error {
+		// Verify whether any transaction is in the locked period
+		lockedTransactionExists, err := s.isAnyTransactionLocked(sess, uid, userTransactionLockTime)
+
+		if err != nil {
+			return err
+		} else if lockedTransactionExists {
+			return errs.ErrTransactionTimeInLockedPeriod
+		}
+
+		// Update all transactions to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(updateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update all transaction tag index to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(tagIndexUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update all transaction item index to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(itemIndexUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update all transaction pictures to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(pictureUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update all transaction splits to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(splitUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update all transaction revisions to deleted
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(revisionUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		// Update all accounts to deleted or set amount to zero
+		_, err = sess.Cols("balance", "deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).Update(accountUpdateModel)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// DeleteAllTransactionsOfAccount deletes all existed transactions of specific account from database
+func (s *TransactionService) DeleteAllTransactionsOfAccount(c core.Context, uid int64, accountId int64, pageCount int32, actor *models.TransactionRevisionActor) error {
+	if uid <= 0 {
+		return errs.ErrUserIdInvalid
+	}
+
+	if accountId <= 0 {
+		return errs.ErrAccountIdInvalid
+	}
+
+	transactions, err := s.GetAllSpecifiedTransactions(c, uid, 0, 0, 0, nil, []int64{accountId}, nil, nil, false, "", "", pageCount, true)
+
+	if err != nil {
+		return err
+	}
+
+	if len(transactions) < 1 {
+		return nil
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			err = s.DeleteTransaction(c, uid, transaction.RelatedId, actor)
+		} else {
+			err = s.DeleteTransaction(c, uid, transaction.TransactionId, actor)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetRelatedTransferTransaction returns the related transaction for transfer transaction
+func (s *TransactionService) GetRelatedTransferTransaction(originalTransaction *models.Transaction) *models.Transaction {
+	var relatedType models.TransactionDbType
+	var relatedTransactionTime int64
+
+	if originalTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+		relatedType = models.TRANSACTION_DB_TYPE_TRANSFER_IN
 		relatedTransactionTime = originalTransaction.TransactionTime + 1
 	} else if originalTransaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
 		relatedType = models.TRANSACTION_DB_TYPE_TRANSFER_OUT
@@ -1877,10 +3580,13 @@ func (s *TransactionService) GetRelatedTransferTransaction(originalTransaction *
 		RelatedId:            originalTransaction.TransactionId,
 		RelatedAccountId:     originalTransaction.AccountId,
 		RelatedAccountAmount: originalTransaction.Amount,
+		PayeeId:              originalTransaction.PayeeId,
+		ClearedStatus:        originalTransaction.ClearedStatus,
 		Comment:              originalTransaction.Comment,
 		GeoLongitude:         originalTransaction.GeoLongitude,
 		GeoLatitude:          originalTransaction.GeoLatitude,
 		CreatedIp:            originalTransaction.CreatedIp,
+		CreatedByUid:         originalTransaction.CreatedByUid,
 		CreatedUnixTime:      originalTransaction.CreatedUnixTime,
 		UpdatedUnixTime:      originalTransaction.UpdatedUnixTime,
 		DeletedUnixTime:      originalTransaction.DeletedUnixTime,
@@ -2014,7 +3720,9 @@ func (s *TransactionService) GetAccountsTotalIncomeAndExpense(c core.Context, ui
 }
 
 // GetAccountsAndCategoriesTotalInflowAndOutflow returns the every accounts and categories total inflows and outflows amount by specific date range
-func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, tagFilters []*models.TransactionTagFilter, noTags bool, keyword string, clientTimezone *time.Location, useTransactionTimezone bool) ([]*models.Transaction, error) {
+// If the amount exchanger is specified, the amount of each transaction is exchanged before summing up, and it returns error if any transaction cannot be exchanged
+// If grouping by original currency, the transactions which have original amount are summed up by their original currencies and original amounts instead
+func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c core.Context, uid int64, startUnixTime int64, endUnixTime int64, tagFilters []*models.TransactionTagFilter, noTags bool, payeeIds []int64, keyword string, clientTimezone *time.Location, useTransactionTimezone bool, groupByOriginalCurrency bool, amountExchanger models.TransactionAmountExchanger) ([]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -2042,6 +3750,14 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 	conditionParams = append(conditionParams, models.TRANSACTION_DB_TYPE_TRANSFER_OUT)
 	conditionParams = append(conditionParams, models.TRANSACTION_DB_TYPE_TRANSFER_IN)
 
+	if len(payeeIds) > 0 {
+		condition = condition + " AND payee_id IN (" + strings.Repeat(",?", len(payeeIds))[1:] + ")"
+
+		for i := 0; i < len(payeeIds); i++ {
+			conditionParams = append(conditionParams, payeeIds[i])
+		}
+	}
+
 	minTransactionTime := startTransactionTime
 	maxTransactionTime := endTransactionTime
 	var allTransactions []*models.Transaction
@@ -2068,8 +3784,8 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 			finalConditionParams = append(finalConditionParams, "%%"+keyword+"%%")
 		}
 
-		sess := s.UserDataDB(uid).NewSession(c).Select("type, category_id, account_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
-		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, category_id, account_id, related_id, related_account_id, transaction_time, timezone_utc_offset, amount, original_currency, original_amount").Where(finalCondition, finalConditionParams...)
+		sess = s.appendFilterTagIdsConditionForNonSplitTransactionsToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 
 		err := sess.Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
 
@@ -2087,6 +3803,12 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
+	allTransactions, err := s.expandTransactionSplits(c, uid, allTransactions, tagFilters, noTags)
+
+	if err != nil {
+		return nil, err
+	}
+
 	transactionTotalAmountsMap := make(map[string]*models.Transaction)
 
 	for i := 0; i < len(allTransactions); i++ {
@@ -2103,10 +3825,28 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 			continue
 		}
 
+		amount := transaction.Amount
+		originalCurrency := ""
+
+		if groupByOriginalCurrency && transaction.HasOriginalAmount() {
+			amount = transaction.OriginalAmount
+			originalCurrency = transaction.OriginalCurrency
+		} else if amountExchanger != nil {
+			exchangedAmount, exchanged := amountExchanger(transaction)
+
+			if !exchanged {
+				return nil, errs.ErrTransactionAmountCannotBeExchanged
+			}
+
+			amount = exchangedAmount
+		}
+
 		groupKey := fmt.Sprintf("%d_%d", transaction.CategoryId, transaction.AccountId)
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
 			groupKey = fmt.Sprintf("%d_%d_%d_%d", transaction.CategoryId, transaction.AccountId, transaction.RelatedAccountId, transaction.Type)
+		} else if originalCurrency != "" {
+			groupKey = fmt.Sprintf("%d_%d_%s", transaction.CategoryId, transaction.AccountId, originalCurrency)
 		}
 
 		totalAmounts, exists := transactionTotalAmountsMap[groupKey]
@@ -2117,13 +3857,14 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 				CategoryId:       transaction.CategoryId,
 				AccountId:        transaction.AccountId,
 				RelatedAccountId: transaction.RelatedAccountId,
+				OriginalCurrency: originalCurrency,
 				Amount:           0,
 			}
 
 			transactionTotalAmountsMap[groupKey] = totalAmounts
 		}
 
-		totalAmounts.Amount += transaction.Amount
+		totalAmounts.Amount += amount
 	}
 
 	transactionTotalAmounts := make([]*models.Transaction, 0, len(transactionTotalAmountsMap))
@@ -2135,8 +3876,97 @@ func (s *TransactionService) GetAccountsAndCategoriesTotalInflowAndOutflow(c cor
 	return transactionTotalAmounts, nil
 }
 
+// GetAccountsAndCategoriesTotalIncomeAndExpenseInPeriods returns the total income and expense amounts grouped by category and account of every period split by the specified ascending period boundaries
+// The result at index i contains the total amounts of transactions whose time is between periodBoundaryUnixTimes[i] (inclusive) and periodBoundaryUnixTimes[i+1] (exclusive)
+func (s *TransactionService) GetAccountsAndCategoriesTotalIncomeAndExpenseInPeriods(c core.Context, uid int64, periodBoundaryUnixTimes []int64) ([][]*models.Transaction, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	if len(periodBoundaryUnixTimes) < 2 {
+		return make([][]*models.Transaction, 0), nil
+	}
+
+	minTransactionTime := utils.GetMinTransactionTimeFromUnixTime(periodBoundaryUnixTimes[0])
+	maxTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(periodBoundaryUnixTimes[len(periodBoundaryUnixTimes)-1] - 1)
+	var allTransactions []*models.Transaction
+
+	for maxTransactionTime >= minTransactionTime {
+		var transactions []*models.Transaction
+
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, category_id, account_id, related_id, related_account_id, transaction_time, amount, original_currency, original_amount")
+		err := sess.Where("uid=? AND deleted=? AND (type=? OR type=?) AND transaction_time>=? AND transaction_time<=?", uid, false, models.TRANSACTION_DB_TYPE_INCOME, models.TRANSACTION_DB_TYPE_EXPENSE, minTransactionTime, maxTransactionTime).Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactions = append(allTransactions, transactions...)
+
+		if len(transactions) < pageCountForLoadTransactionAmounts {
+			break
+		}
+
+		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
+	}
+
+	allTransactions, err := s.expandTransactionSplits(c, uid, allTransactions, nil, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	periodTotalAmountsMaps := make([]map[string]*models.Transaction, len(periodBoundaryUnixTimes)-1)
+
+	for i := 0; i < len(periodTotalAmountsMaps); i++ {
+		periodTotalAmountsMaps[i] = make(map[string]*models.Transaction)
+	}
+
+	for i := 0; i < len(allTransactions); i++ {
+		transaction := allTransactions[i]
+		unixTime := utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime)
+
+		periodIndex := sort.Search(len(periodBoundaryUnixTimes), func(j int) bool {
+			return periodBoundaryUnixTimes[j] > unixTime
+		}) - 1
+
+		if periodIndex < 0 || periodIndex >= len(periodTotalAmountsMaps) {
+			continue
+		}
+
+		groupKey := fmt.Sprintf("%d_%d", transaction.CategoryId, transaction.AccountId)
+		totalAmounts, exists := periodTotalAmountsMaps[periodIndex][groupKey]
+
+		if !exists {
+			totalAmounts = &models.Transaction{
+				Type:       transaction.Type,
+				CategoryId: transaction.CategoryId,
+				AccountId:  transaction.AccountId,
+				Amount:     0,
+			}
+
+			periodTotalAmountsMaps[periodIndex][groupKey] = totalAmounts
+		}
+
+		totalAmounts.Amount += transaction.Amount
+	}
+
+	periodTotalAmounts := make([][]*models.Transaction, len(periodTotalAmountsMaps))
+
+	for i := 0; i < len(periodTotalAmountsMaps); i++ {
+		periodTotalAmounts[i] = make([]*models.Transaction, 0, len(periodTotalAmountsMaps[i]))
+
+		for _, totalAmounts := range periodTotalAmountsMaps[i] {
+			periodTotalAmounts[i] = append(periodTotalAmounts[i], totalAmounts)
+		}
+	}
+
+	return periodTotalAmounts, nil
+}
+
 // GetAccountsAndCategoriesMonthlyInflowAndOutflow returns the every accounts monthly inflows and outflows amount by specific date range
-func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, tagFilters []*models.TransactionTagFilter, noTags bool, keyword string, clientTimezone *time.Location, useTransactionTimezone bool) (map[int32][]*models.Transaction, error) {
+// If the amount exchanger is specified, the amount of each transaction is exchanged before summing up, and it returns error if any transaction cannot be exchanged
+func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c core.Context, uid int64, startYear int32, startMonth int32, endYear int32, endMonth int32, tagFilters []*models.TransactionTagFilter, noTags bool, payeeIds []int64, keyword string, clientTimezone *time.Location, useTransactionTimezone bool, amountExchanger models.TransactionAmountExchanger) (map[int32][]*models.Transaction, error) {
 	if uid <= 0 {
 		return nil, errs.ErrUserIdInvalid
 	}
@@ -2169,6 +3999,14 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 	conditionParams = append(conditionParams, models.TRANSACTION_DB_TYPE_TRANSFER_OUT)
 	conditionParams = append(conditionParams, models.TRANSACTION_DB_TYPE_TRANSFER_IN)
 
+	if len(payeeIds) > 0 {
+		condition = condition + " AND payee_id IN (" + strings.Repeat(",?", len(payeeIds))[1:] + ")"
+
+		for i := 0; i < len(payeeIds); i++ {
+			conditionParams = append(conditionParams, payeeIds[i])
+		}
+	}
+
 	minTransactionTime := startTransactionTime
 	maxTransactionTime := endTransactionTime
 	var allTransactions []*models.Transaction
@@ -2195,8 +4033,8 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 			finalConditionParams = append(finalConditionParams, "%%"+keyword+"%%")
 		}
 
-		sess := s.UserDataDB(uid).NewSession(c).Select("type, category_id, account_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
-		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
+		sess := s.UserDataDB(uid).NewSession(c).Select("transaction_id, type, category_id, account_id, related_id, related_account_id, transaction_time, timezone_utc_offset, amount").Where(finalCondition, finalConditionParams...)
+		sess = s.appendFilterTagIdsConditionForNonSplitTransactionsToQuery(sess, uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
 
 		err := sess.Limit(pageCountForLoadTransactionAmounts, 0).OrderBy("transaction_time desc").Find(&transactions)
 
@@ -2214,6 +4052,12 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 		maxTransactionTime = transactions[len(transactions)-1].TransactionTime - 1
 	}
 
+	allTransactions, err = s.expandTransactionSplits(c, uid, allTransactions, tagFilters, noTags)
+
+	if err != nil {
+		return nil, err
+	}
+
 	startYearMonth := startYear*100 + startMonth
 	endYearMonth := endYear*100 + endMonth
 	transactionsMonthlyAmountsMap := make(map[string]*models.Transaction)
@@ -2233,6 +4077,18 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 			continue
 		}
 
+		amount := transaction.Amount
+
+		if amountExchanger != nil {
+			exchangedAmount, exchanged := amountExchanger(transaction)
+
+			if !exchanged {
+				return nil, errs.ErrTransactionAmountCannotBeExchanged
+			}
+
+			amount = exchangedAmount
+		}
+
 		groupKey := fmt.Sprintf("%d_%d_%d", yearMonth, transaction.CategoryId, transaction.AccountId)
 
 		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
@@ -2253,7 +4109,7 @@ func (s *TransactionService) GetAccountsAndCategoriesMonthlyInflowAndOutflow(c c
 			transactionsMonthlyAmountsMap[groupKey] = transactionAmounts
 		}
 
-		transactionAmounts.Amount += transaction.Amount
+		transactionAmounts.Amount += amount
 	}
 
 	for groupKey, transaction := range transactionsMonthlyAmountsMap {
@@ -2295,7 +4151,188 @@ func (s *TransactionService) GetTransactionIds(transactions []*models.Transactio
 	return transactionIds
 }
 
-func (s *TransactionService) doCreateTransaction(c core.Context, database *datastore.Database, sess *xorm.Session, transaction *models.Transaction, transactionTagIndexes []*models.TransactionTagIndex, transactionItemIndexes []*models.TransactionItemIndex, tagIds []int64, itemIds []int64, pictureIds []int64, pictureUpdateModel *models.TransactionPictureInfo) error {
+// GetSplitsOfTransactions returns transaction splits for given transactions
+func (s *TransactionService) GetSplitsOfTransactions(c core.Context, uid int64, transactionIds []int64) (map[int64][]*models.TransactionSplit, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var splits []*models.TransactionSplit
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).OrderBy("transaction_id asc, display_order asc").Find(&splits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getGroupedTransactionSplits(splits), nil
+}
+
+// GetAllSplitsMapOfAllTransactions returns all transaction splits of user grouped by transaction id
+func (s *TransactionService) GetAllSplitsMapOfAllTransactions(c core.Context, uid int64) (map[int64][]*models.TransactionSplit, error) {
+	if uid <= 0 {
+		return nil, errs.ErrUserIdInvalid
+	}
+
+	var splits []*models.TransactionSplit
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=?", uid, false).OrderBy("transaction_id asc, display_order asc").Find(&splits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getGroupedTransactionSplits(splits), nil
+}
+
+func (s *TransactionService) getGroupedTransactionSplits(splits []*models.TransactionSplit) map[int64][]*models.TransactionSplit {
+	allTransactionSplits := make(map[int64][]*models.TransactionSplit)
+
+	for i := 0; i < len(splits); i++ {
+		split := splits[i]
+		allTransactionSplits[split.TransactionId] = append(allTransactionSplits[split.TransactionId], split)
+	}
+
+	return allTransactionSplits
+}
+
+// expandTransactionSplits replaces every split transaction with one transaction per split line, so that each split amount is attributed to its own category
+// If tag filters are specified, they are applied after expanding, and each split line matches by its own tags together with the tags of its transaction
+func (s *TransactionService) expandTransactionSplits(c core.Context, uid int64, transactions []*models.Transaction, tagFilters []*models.TransactionTagFilter, noTags bool) ([]*models.Transaction, error) {
+	if len(transactions) < 1 {
+		return transactions, nil
+	}
+
+	minTransactionTime := transactions[0].TransactionTime
+	maxTransactionTime := transactions[0].TransactionTime
+
+	for i := 1; i < len(transactions); i++ {
+		if transactions[i].TransactionTime < minTransactionTime {
+			minTransactionTime = transactions[i].TransactionTime
+		}
+
+		if transactions[i].TransactionTime > maxTransactionTime {
+			maxTransactionTime = transactions[i].TransactionTime
+		}
+	}
+
+	var splits []*models.TransactionSplit
+	err := s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND transaction_time>=? AND transaction_time<=?", uid, false, minTransactionTime, maxTransactionTime).OrderBy("transaction_id asc, display_order asc").Find(&splits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filterByTags := noTags || len(tagFilters) > 0
+
+	if len(splits) < 1 && !filterByTags {
+		return transactions, nil
+	}
+
+	var allTransactionTagIds map[int64]map[int64]bool
+
+	if filterByTags {
+		var tagIndexes []*models.TransactionTagIndex
+		// the tags of transfer in transaction are saved with the related transfer out transaction, which transaction time is 1 less than it
+		err = s.UserDataDB(uid).NewSession(c).Where("uid=? AND deleted=? AND transaction_time>=? AND transaction_time<=?", uid, false, minTransactionTime-1, maxTransactionTime).Find(&tagIndexes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allTransactionTagIds = make(map[int64]map[int64]bool)
+
+		for i := 0; i < len(tagIndexes); i++ {
+			tagIndex := tagIndexes[i]
+			transactionTagIds, exists := allTransactionTagIds[tagIndex.TransactionId]
+
+			if !exists {
+				transactionTagIds = make(map[int64]bool)
+				allTransactionTagIds[tagIndex.TransactionId] = transactionTagIds
+			}
+
+			transactionTagIds[tagIndex.TagId] = true
+		}
+	}
+
+	allTransactionSplits := s.getGroupedTransactionSplits(splits)
+	expandedTransactions := make([]*models.Transaction, 0, len(transactions)+len(splits))
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+		transactionSplits, exists := allTransactionSplits[transaction.TransactionId]
+		var transactionTagIds map[int64]bool
+
+		if filterByTags {
+			transactionTagIds = make(map[int64]bool)
+
+			for tagId := range allTransactionTagIds[transaction.TransactionId] {
+				transactionTagIds[tagId] = true
+			}
+
+			if transaction.RelatedId > 0 {
+				for tagId := range allTransactionTagIds[transaction.RelatedId] {
+					transactionTagIds[tagId] = true
+				}
+			}
+		}
+
+		if !exists || (transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE) {
+			if !filterByTags || s.isTagIdsMatched(transactionTagIds, tagFilters, noTags) {
+				expandedTransactions = append(expandedTransactions, transaction)
+			}
+
+			continue
+		}
+
+		for j := 0; j < len(transactionSplits); j++ {
+			if filterByTags {
+				splitTagIds := make(map[int64]bool, len(transactionTagIds))
+
+				for tagId := range transactionTagIds {
+					splitTagIds[tagId] = true
+				}
+
+				for _, tagId := range transactionSplits[j].GetTagIds() {
+					splitTagIds[tagId] = true
+				}
+
+				if !s.isTagIdsMatched(splitTagIds, tagFilters, noTags) {
+					continue
+				}
+			}
+
+			expandedTransactions = append(expandedTransactions, &models.Transaction{
+				TransactionId:     transaction.TransactionId,
+				Type:              transaction.Type,
+				CategoryId:        transactionSplits[j].CategoryId,
+				AccountId:         transaction.AccountId,
+				RelatedAccountId:  transaction.RelatedAccountId,
+				TransactionTime:   transaction.TransactionTime,
+				TimezoneUtcOffset: transaction.TimezoneUtcOffset,
+				Amount:            transactionSplits[j].Amount,
+				OriginalCurrency:  transaction.OriginalCurrency,
+				OriginalAmount:    transaction.GetOriginalAmountOfPart(transactionSplits[j].Amount),
+			})
+		}
+	}
+
+	return expandedTransactions, nil
+}
+
+func (s *TransactionService) isTagIdsMatched(tagIds map[int64]bool, tagFilters []*models.TransactionTagFilter, noTags bool) bool {
+	if noTags {
+		return len(tagIds) < 1
+	}
+
+	for i := 0; i < len(tagFilters); i++ {
+		if !tagFilters[i].IsMatched(tagIds) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *TransactionService) doCreateTransaction(c core.Context, database *datastore.Database, sess *xorm.Session, userTransactionLockTime int64, transaction *models.Transaction, transactionTagIndexes []*models.TransactionTagIndex, transactionItemIndexes []*models.TransactionItemIndex, tagIds []int64, itemIds []int64, pictureIds []int64, pictureUpdateModel *models.TransactionPictureInfo, splits []*models.TransactionSplit) error {
 	// Get and verify source and destination account
 	sourceAccount, destinationAccount, err := s.getAccountModels(sess, transaction)
 
@@ -2311,6 +4348,10 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 		return errs.ErrCannotAddTransactionToParentAccount
 	}
 
+	if transaction.IsLocked(userTransactionLockTime, sourceAccount, destinationAccount) {
+		return errs.ErrTransactionTimeInLockedPeriod
+	}
+
 	if (transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN) &&
 		sourceAccount.Currency == destinationAccount.Currency && transaction.Amount != transaction.RelatedAccountAmount {
 		return errs.ErrTransactionSourceAndDestinationAmountNotEqual
@@ -2321,6 +4362,8 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 		return errs.ErrTransferTransactionAmountCannotBeLessThanZero
 	}
 
+	transaction.OriginalAmountDecimalPlaces = utils.GetCurrencyAmountDecimalPlaces(transaction.OriginalCurrency)
+
 	// Get and verify category
 	err = s.isCategoryValid(sess, transaction)
 
@@ -2328,6 +4371,13 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 		return err
 	}
 
+	// Get and verify payee
+	err = s.isPayeeValid(sess, transaction)
+
+	if err != nil {
+		return err
+	}
+
 	// Get and verify tags
 	err = s.isTagsValid(sess, transaction, transactionTagIndexes, tagIds)
 
@@ -2349,6 +4399,13 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 		return err
 	}
 
+	// Verify splits
+	err = s.isSplitsValid(sess, transaction, splits)
+
+	if err != nil {
+		return err
+	}
+
 	// Verify balance modification transaction and calculate real amount
 	if transaction.Type == models.TRANSACTION_DB_TYPE_MODIFY_BALANCE {
 		otherTransactionExists, err := sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND account_id=?", transaction.Uid, false, sourceAccount.AccountId).Limit(1).Exist(&models.Transaction{})
@@ -2479,10 +4536,25 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 			transactionItemIndex := transactionItemIndexes[i]
 			transactionItemIndex.TransactionTime = transaction.TransactionTime
 
-			_, err := sess.Insert(transactionItemIndex)
+			_, err := sess.Insert(transactionItemIndex)
+
+			if err != nil {
+				log.Errorf(c, "[transactions.doCreateTransaction] failed to add transaction item index, because %s", err.Error())
+				return err
+			}
+		}
+	}
+
+	// Insert transaction splits
+	if len(splits) > 0 {
+		for i := 0; i < len(splits); i++ {
+			split := splits[i]
+			split.TransactionTime = transaction.TransactionTime
+
+			_, err := sess.Insert(split)
 
 			if err != nil {
-				log.Errorf(c, "[transactions.doCreateTransaction] failed to add transaction item index, because %s", err.Error())
+				log.Errorf(c, "[transactions.doCreateTransaction] failed to add transaction split, because %s", err.Error())
 				return err
 			}
 		}
@@ -2571,7 +4643,51 @@ func (s *TransactionService) doCreateTransaction(c core.Context, database *datas
 	return err
 }
 
-func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransactionTime int64, minTransactionTime int64, transactionDbType models.TransactionDbType, categoryIds []int64, accountIds []int64, tagFilters []*models.TransactionTagFilter, amountFilter string, keyword string, noDuplicated bool) (string, []any) {
+func (s *TransactionService) resolveTransactionSearchQuery(c core.Context, uid int64, searchQuery string, clientTimezone *time.Location) (*models.TransactionSearchConditions, error) {
+	query, err := models.ParseTransactionSearchQuery(searchQuery)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sess := s.UserDataDB(uid).NewSession(c)
+
+	var accounts []*models.Account
+
+	if len(query.AccountNames) > 0 || len(query.ExcludeAccountNames) > 0 {
+		if err = sess.Where("uid=? AND deleted=?", uid, false).Find(&accounts); err != nil {
+			return nil, err
+		}
+	}
+
+	var categories []*models.TransactionCategory
+
+	if len(query.CategoryNames) > 0 || len(query.ExcludeCategoryNames) > 0 {
+		if err = sess.Where("uid=? AND deleted=?", uid, false).Find(&categories); err != nil {
+			return nil, err
+		}
+	}
+
+	var tags []*models.TransactionTag
+
+	if len(query.TagNames) > 0 || len(query.ExcludeTagNames) > 0 {
+		if err = sess.Where("uid=? AND deleted=?", uid, false).Find(&tags); err != nil {
+			return nil, err
+		}
+	}
+
+	var payees []*models.Payee
+
+	if len(query.PayeeNames) > 0 || len(query.ExcludePayeeNames) > 0 {
+		if err = sess.Where("uid=? AND deleted=?", uid, false).Find(&payees); err != nil {
+			return nil, err
+		}
+	}
+
+	return query.ToTransactionSearchConditions(accounts, categories, tags, payees, clientTimezone)
+}
+
+func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransactionTime int64, minTransactionTime int64, transactionDbType models.TransactionDbType, categoryIds []int64, accountIds []int64, payeeIds []int64, tagFilters []*models.TransactionTagFilter, amountFilter string, keyword string, noDuplicated bool) (string, []any) {
 	condition := "uid=? AND deleted=?"
 	conditionParams := make([]any, 0, 16)
 	conditionParams = append(conditionParams, uid)
@@ -2667,6 +4783,25 @@ func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransa
 		conditionParams = append(conditionParams, accountIdConditionParams...)
 	}
 
+	if len(payeeIds) > 0 {
+		var conditions strings.Builder
+
+		for i := 0; i < len(payeeIds); i++ {
+			if i > 0 {
+				conditions.WriteString(",")
+			}
+
+			conditions.WriteString("?")
+			conditionParams = append(conditionParams, payeeIds[i])
+		}
+
+		if conditions.Len() > 1 {
+			condition = condition + " AND payee_id IN (" + conditions.String() + ")"
+		} else {
+			condition = condition + " AND payee_id = " + conditions.String()
+		}
+	}
+
 	if amountFilter != "" {
 		amountFilterItems := strings.Split(amountFilter, ":")
 
@@ -2728,6 +4863,39 @@ func (s *TransactionService) buildTransactionQueryCondition(uid int64, maxTransa
 }
 
 func (s *TransactionService) appendFilterTagIdsConditionToQuery(sess *xorm.Session, uid int64, maxTransactionTime int64, minTransactionTime int64, tagFilters []*models.TransactionTagFilter, noTags bool) *xorm.Session {
+	condition := s.getFilterTagIdsCondition(uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
+
+	if condition == nil {
+		return sess
+	}
+
+	return sess.And(condition)
+}
+
+// appendFilterTagIdsConditionForNonSplitTransactionsToQuery filters the transactions by tags in database except the split transactions, whose split lines have their own tags and should be filtered after expanding
+func (s *TransactionService) appendFilterTagIdsConditionForNonSplitTransactionsToQuery(sess *xorm.Session, uid int64, maxTransactionTime int64, minTransactionTime int64, tagFilters []*models.TransactionTagFilter, noTags bool) *xorm.Session {
+	condition := s.getFilterTagIdsCondition(uid, maxTransactionTime, minTransactionTime, tagFilters, noTags)
+
+	if condition == nil {
+		return sess
+	}
+
+	subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
+
+	if maxTransactionTime > 0 {
+		subQueryCondition = subQueryCondition.And(builder.Lte{"transaction_time": maxTransactionTime})
+	}
+
+	if minTransactionTime > 0 {
+		subQueryCondition = subQueryCondition.And(builder.Gte{"transaction_time": minTransactionTime})
+	}
+
+	splitSubQuery := builder.Select("transaction_id").From("transaction_split").Where(subQueryCondition)
+
+	return sess.And(builder.Or(condition, builder.In("transaction_id", splitSubQuery)))
+}
+
+func (s *TransactionService) getFilterTagIdsCondition(uid int64, maxTransactionTime int64, minTransactionTime int64, tagFilters []*models.TransactionTagFilter, noTags bool) builder.Cond {
 	if noTags {
 		subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
 
@@ -2739,42 +4907,341 @@ func (s *TransactionService) appendFilterTagIdsConditionToQuery(sess *xorm.Sessi
 			subQueryCondition = subQueryCondition.And(builder.Gte{"transaction_time": minTransactionTime})
 		}
 
-		subQuery := builder.Select("transaction_id").From("transaction_tag_index").Where(subQueryCondition)
-		sess.NotIn("transaction_id", subQuery).NotIn("related_id", subQuery)
-		return sess
+		subQuery := builder.Select("transaction_id").From("transaction_tag_index").Where(subQueryCondition)
+		return builder.And(builder.NotIn("transaction_id", subQuery), builder.NotIn("related_id", subQuery))
+	}
+
+	if len(tagFilters) < 1 {
+		return nil
+	}
+
+	condition := builder.NewCond()
+
+	for i := 0; i < len(tagFilters); i++ {
+		tagFilter := tagFilters[i]
+		subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
+
+		if maxTransactionTime > 0 {
+			subQueryCondition = subQueryCondition.And(builder.Lte{"transaction_time": maxTransactionTime})
+		}
+
+		if minTransactionTime > 0 {
+			subQueryCondition = subQueryCondition.And(builder.Gte{"transaction_time": minTransactionTime})
+		}
+
+		subQueryCondition = subQueryCondition.And(builder.In("tag_id", tagFilter.TagIds))
+		subQuery := builder.Select("transaction_id").From("transaction_tag_index").Where(subQueryCondition)
+
+		if tagFilter.Type == models.TRANSACTION_TAG_FILTER_HAS_ALL || tagFilter.Type == models.TRANSACTION_TAG_FILTER_NOT_HAS_ALL {
+			subQuery = subQuery.GroupBy("transaction_id").Having(fmt.Sprintf("COUNT(DISTINCT tag_id) >= %d", len(tagFilter.TagIds)))
+		}
+
+		if tagFilter.Type == models.TRANSACTION_TAG_FILTER_HAS_ANY || tagFilter.Type == models.TRANSACTION_TAG_FILTER_HAS_ALL {
+			condition = condition.And(builder.Or(builder.In("transaction_id", subQuery), builder.In("related_id", subQuery)))
+		} else if tagFilter.Type == models.TRANSACTION_TAG_FILTER_NOT_HAS_ANY || tagFilter.Type == models.TRANSACTION_TAG_FILTER_NOT_HAS_ALL {
+			condition = condition.And(builder.NotIn("transaction_id", subQuery), builder.NotIn("related_id", subQuery))
+		}
+	}
+
+	if !condition.IsValid() {
+		return nil
+	}
+
+	return condition
+}
+
+func (s *TransactionService) appendFilterItemIdsConditionToQuery(sess *xorm.Session, uid int64, maxTransactionTime int64, minTransactionTime int64, itemFilters []*models.TransactionItemFilter, noItems bool) *xorm.Session {
+	if noItems {
+		subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
+
+		if maxTransactionTime > 0 {
+			subQueryCondition = subQueryCondition.And(builder.Lte{"transaction_time": maxTransactionTime})
+		}
+
+		if minTransactionTime > 0 {
+			subQueryCondition = subQueryCondition.And(builder.Gte{"transaction_time": minTransactionTime})
+		}
+
+		subQuery := builder.Select("transaction_id").From("transaction_item_index").Where(subQueryCondition)
+		sess.NotIn("transaction_id", subQuery).NotIn("related_id", subQuery)
+		return sess
+	}
+
+	if len(itemFilters) < 1 {
+		return sess
+	}
+
+	for i := 0; i < len(itemFilters); i++ {
+		itemFilter := itemFilters[i]
+		subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
+
+		if maxTransactionTime > 0 {
+			subQueryCondition = subQueryCondition.And(builder.Lte{"transaction_time": maxTransactionTime})
+		}
+
+		if minTransactionTime > 0 {
+			subQueryCondition = subQueryCondition.And(builder.Gte{"transaction_time": minTransactionTime})
+		}
+
+		subQueryCondition = subQueryCondition.And(builder.In("item_id", itemFilter.ItemIds))
+		subQuery := builder.Select("transaction_id").From("transaction_item_index").Where(subQueryCondition)
+
+		if itemFilter.Type == models.TRANSACTION_ITEM_FILTER_HAS_ALL || itemFilter.Type == models.TRANSACTION_ITEM_FILTER_NOT_HAS_ALL {
+			subQuery = subQuery.GroupBy("transaction_id").Having(fmt.Sprintf("COUNT(DISTINCT item_id) >= %d", len(itemFilter.ItemIds)))
+		}
+
+		if itemFilter.Type == models.TRANSACTION_ITEM_FILTER_HAS_ANY || itemFilter.Type == models.TRANSACTION_ITEM_FILTER_HAS_ALL {
+			sess.And(builder.Or(builder.In("transaction_id", subQuery), builder.In("related_id", subQuery)))
+		} else if itemFilter.Type == models.TRANSACTION_ITEM_FILTER_NOT_HAS_ANY || itemFilter.Type == models.TRANSACTION_ITEM_FILTER_NOT_HAS_ALL {
+			sess.NotIn("transaction_id", subQuery).NotIn("related_id", subQuery)
+		}
+	}
+
+	return sess
+}
+
+func (s *TransactionService) getBulkEditTransactions(sess *xorm.Session, uid int64, transactionDbType models.TransactionDbType, filter *models.TransactionBulkEditFilter) ([]*models.Transaction, error) {
+	var transactions []*models.Transaction
+
+	if len(filter.TransactionIds) > 0 {
+		transactionIds := utils.ToUniqueInt64Slice(filter.TransactionIds)
+
+		if len(transactionIds) > models.MaximumTransactionsCountOfBulkEdit {
+			return nil, errs.ErrTooManyTransactionsToBulkEdit
+		}
+
+		err := sess.Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		} else if len(transactions) < len(transactionIds) {
+			return nil, errs.ErrTransactionNotFound
+		}
+	} else {
+		condition, conditionParams := s.buildTransactionQueryCondition(uid, filter.MaxTransactionTime, filter.MinTransactionTime, transactionDbType, filter.CategoryIds, filter.AccountIds, filter.PayeeIds, filter.TagFilters, filter.AmountFilter, filter.Keyword, true)
+		sess = sess.Where(condition, conditionParams...)
+		sess = s.appendFilterTagIdsConditionToQuery(sess, uid, filter.MaxTransactionTime, filter.MinTransactionTime, filter.TagFilters, filter.NoTags)
+		sess = s.appendFilterItemIdsConditionToQuery(sess, uid, filter.MaxTransactionTime, filter.MinTransactionTime, filter.ItemFilters, filter.NoItems)
+
+		err := sess.Limit(models.MaximumTransactionsCountOfBulkEdit + 1).OrderBy("transaction_time desc").Find(&transactions)
+
+		if err != nil {
+			return nil, err
+		} else if len(transactions) > models.MaximumTransactionsCountOfBulkEdit {
+			return nil, errs.ErrTooManyTransactionsToBulkEdit
+		}
+	}
+
+	// use the transfer out transaction instead of transfer in transaction
+	matchedTransactions := make([]*models.Transaction, 0, len(transactions))
+	matchedTransactionIds := make(map[int64]bool, len(transactions))
+	transferOutTransactionIds := make([]int64, 0)
+
+	for i := 0; i < len(transactions); i++ {
+		transaction := transactions[i]
+
+		if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+			transferOutTransactionIds = append(transferOutTransactionIds, transaction.RelatedId)
+			continue
+		}
+
+		if !matchedTransactionIds[transaction.TransactionId] {
+			matchedTransactions = append(matchedTransactions, transaction)
+			matchedTransactionIds[transaction.TransactionId] = true
+		}
+	}
+
+	transferOutTransactionIds = utils.Int64SliceMinus(utils.ToUniqueInt64Slice(transferOutTransactionIds), s.GetTransactionIds(matchedTransactions))
+
+	if len(transferOutTransactionIds) > 0 {
+		var transferOutTransactions []*models.Transaction
+		err := sess.Where("uid=? AND deleted=? AND type=?", uid, false, models.TRANSACTION_DB_TYPE_TRANSFER_OUT).In("transaction_id", transferOutTransactionIds).Find(&transferOutTransactions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		matchedTransactions = append(matchedTransactions, transferOutTransactions...)
+	}
+
+	sort.Slice(matchedTransactions, func(i, j int) bool {
+		return matchedTransactions[i].TransactionTime > matchedTransactions[j].TransactionTime
+	})
+
+	return matchedTransactions, nil
+}
+
+func (s *TransactionService) doBulkDeleteTransactions(sess *xorm.Session, uid int64, transactions []*models.Transaction, revisionIds []int64, actor *models.TransactionRevisionActor, now int64) error {
+	transactionIds := make([]int64, len(transactions))
+	allTransactionIds := make([]int64, 0, len(transactions)*2)
+	beforeSnapshots := make([]*models.TransactionRevisionSnapshot, len(transactions))
+
+	for i := 0; i < len(transactions); i++ {
+		transactionIds[i] = transactions[i].TransactionId
+		allTransactionIds = append(allTransactionIds, transactions[i].TransactionId)
+
+		if transactions[i].Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT {
+			allTransactionIds = append(allTransactionIds, transactions[i].RelatedId)
+		}
+
+		beforeSnapshot, err := s.getTransactionRevisionSnapshot(sess, transactions[i])
+
+		if err != nil {
+			return err
+		}
+
+		beforeSnapshots[i] = beforeSnapshot
+	}
+
+	updateModel := &models.Transaction{
+		Deleted:         true,
+		DeletedUnixTime: now,
+	}
+
+	deletedRows, err := sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", allTransactionIds).Update(updateModel)
+
+	if err != nil {
+		return err
+	} else if deletedRows < int64(len(allTransactionIds)) {
+		return errs.ErrTransactionNotFound
+	}
+
+	childUpdateModels := []any{
+		&models.TransactionTagIndex{Deleted: true, DeletedUnixTime: now},
+		&models.TransactionItemIndex{Deleted: true, DeletedUnixTime: now},
+		&models.TransactionPictureInfo{Deleted: true, DeletedUnixTime: now},
+		&models.TransactionSplit{Deleted: true, DeletedUnixTime: now},
+		&models.TransactionRevision{Deleted: true, DeletedUnixTime: now},
+	}
+
+	for i := 0; i < len(childUpdateModels); i++ {
+		_, err = sess.Cols("deleted", "deleted_unix_time").Where("uid=? AND deleted=?", uid, false).In("transaction_id", transactionIds).Update(childUpdateModels[i])
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < len(transactions); i++ {
+		err = s.insertTransactionRevision(sess, revisionIds[i], uid, transactions[i].TransactionId, models.TRANSACTION_REVISION_TYPE_DELETE, beforeSnapshots[i], nil, actor, now)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TransactionService) isRelatedTransactionReconciled(sess *xorm.Session, transaction *models.Transaction) (bool, error) {
+	if transaction.Type != models.TRANSACTION_DB_TYPE_TRANSFER_OUT && transaction.Type != models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+		return false, nil
+	}
+
+	return sess.Where("uid=? AND deleted=? AND transaction_id=? AND cleared_status=?", transaction.Uid, false, transaction.RelatedId, models.TRANSACTION_CLEARED_STATUS_RECONCILED).Exist(&models.Transaction{})
+}
+
+func (s *TransactionService) getTransactionRevisionSnapshot(sess *xorm.Session, transaction *models.Transaction) (*models.TransactionRevisionSnapshot, error) {
+	var tagIndexes []*models.TransactionTagIndex
+	err := sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Find(&tagIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tagIds := make([]int64, len(tagIndexes))
+
+	for i := 0; i < len(tagIndexes); i++ {
+		tagIds[i] = tagIndexes[i].TagId
+	}
+
+	var itemIndexes []*models.TransactionItemIndex
+	err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Find(&itemIndexes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var pictureInfos []*models.TransactionPictureInfo
+	err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).Find(&pictureInfos)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pictureIds := make([]int64, len(pictureInfos))
+
+	for i := 0; i < len(pictureInfos); i++ {
+		pictureIds[i] = pictureInfos[i].PictureId
+	}
+
+	var splits []*models.TransactionSplit
+	err = sess.Where("uid=? AND deleted=? AND transaction_id=?", transaction.Uid, false, transaction.TransactionId).OrderBy("display_order asc").Find(&splits)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewTransactionRevisionSnapshot(transaction, tagIds, itemIndexes, pictureIds, splits), nil
+}
+
+func (s *TransactionService) createTransactionRevision(sess *xorm.Session, revisionId int64, uid int64, transactionId int64, beforeSnapshot *models.TransactionRevisionSnapshot, actor *models.TransactionRevisionActor, now int64) error {
+	newTransaction := &models.Transaction{}
+	has, err := sess.ID(transactionId).Where("uid=? AND deleted=?", uid, false).Get(newTransaction)
+
+	if err != nil {
+		return err
+	} else if !has {
+		return errs.ErrTransactionNotFound
 	}
 
-	if len(tagFilters) < 1 {
-		return sess
+	afterSnapshot, err := s.getTransactionRevisionSnapshot(sess, newTransaction)
+
+	if err != nil {
+		return err
 	}
 
-	for i := 0; i < len(tagFilters); i++ {
-		tagFilter := tagFilters[i]
-		subQueryCondition := builder.And(builder.Eq{"uid": uid}, builder.Eq{"deleted": false})
+	revisionType := models.TRANSACTION_REVISION_TYPE_MODIFY
 
-		if maxTransactionTime > 0 {
-			subQueryCondition = subQueryCondition.And(builder.Lte{"transaction_time": maxTransactionTime})
-		}
+	if actor != nil && actor.RevertedRevisionId > 0 {
+		revisionType = models.TRANSACTION_REVISION_TYPE_REVERT
+	}
 
-		if minTransactionTime > 0 {
-			subQueryCondition = subQueryCondition.And(builder.Gte{"transaction_time": minTransactionTime})
-		}
+	return s.insertTransactionRevision(sess, revisionId, uid, transactionId, revisionType, beforeSnapshot, afterSnapshot, actor, now)
+}
 
-		subQueryCondition = subQueryCondition.And(builder.In("tag_id", tagFilter.TagIds))
-		subQuery := builder.Select("transaction_id").From("transaction_tag_index").Where(subQueryCondition)
+func (s *TransactionService) insertTransactionRevision(sess *xorm.Session, revisionId int64, uid int64, transactionId int64, revisionType models.TransactionRevisionType, beforeSnapshot *models.TransactionRevisionSnapshot, afterSnapshot *models.TransactionRevisionSnapshot, actor *models.TransactionRevisionActor, now int64) error {
+	revision := &models.TransactionRevision{
+		RevisionId:      revisionId,
+		Uid:             uid,
+		Deleted:         false,
+		TransactionId:   transactionId,
+		Type:            revisionType,
+		Source:          models.TRANSACTION_REVISION_SOURCE_SYSTEM,
+		Before:          beforeSnapshot,
+		After:           afterSnapshot,
+		CreatedUnixTime: now,
+	}
 
-		if tagFilter.Type == models.TRANSACTION_TAG_FILTER_HAS_ALL || tagFilter.Type == models.TRANSACTION_TAG_FILTER_NOT_HAS_ALL {
-			subQuery = subQuery.GroupBy("transaction_id").Having(fmt.Sprintf("COUNT(DISTINCT tag_id) >= %d", len(tagFilter.TagIds)))
-		}
+	// the revision of deletion is deleted together with the transaction, so that it would be restored with the transaction
+	if revisionType == models.TRANSACTION_REVISION_TYPE_DELETE {
+		revision.Deleted = true
+		revision.DeletedUnixTime = now
+	}
 
-		if tagFilter.Type == models.TRANSACTION_TAG_FILTER_HAS_ANY || tagFilter.Type == models.TRANSACTION_TAG_FILTER_HAS_ALL {
-			sess.And(builder.Or(builder.In("transaction_id", subQuery), builder.In("related_id", subQuery)))
-		} else if tagFilter.Type == models.TRANSACTION_TAG_FILTER_NOT_HAS_ANY || tagFilter.Type == models.TRANSACTION_TAG_FILTER_NOT_HAS_ALL {
-			sess.NotIn("transaction_id", subQuery).NotIn("related_id", subQuery)
+	if actor != nil {
+		revision.OperatorUid = actor.OperatorUid
+		revision.Source = actor.Source
+		revision.ClientIp = actor.ClientIp
+
+		if revisionType == models.TRANSACTION_REVISION_TYPE_REVERT {
+			revision.RevertedRevisionId = actor.RevertedRevisionId
 		}
 	}
 
-	return sess
+	_, err := sess.Insert(revision)
+
+	return err
 }
 
 func (s *TransactionService) isAccountIdValid(transaction *models.Transaction) error {
@@ -2802,6 +5269,41 @@ func (s *TransactionService) isAccountIdValid(transaction *models.Transaction) e
 	return nil
 }
 
+func (s *TransactionService) isAnyTransactionLocked(sess *xorm.Session, uid int64, userTransactionLockTime int64) (bool, error) {
+	if userTransactionLockTime > 0 {
+		maxLockedTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(userTransactionLockTime)
+		exists, err := sess.Cols("uid", "deleted", "transaction_time").Where("uid=? AND deleted=? AND transaction_time<=?", uid, false, maxLockedTransactionTime).Limit(1).Exist(&models.Transaction{})
+
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+
+	var accounts []*models.Account
+	err := sess.Cols("account_id", "extend").Where("uid=? AND deleted=?", uid, false).Find(&accounts)
+
+	if err != nil {
+		return false, err
+	}
+
+	for i := 0; i < len(accounts); i++ {
+		accountTransactionLockTime := accounts[i].GetTransactionLockTime()
+
+		if accountTransactionLockTime <= userTransactionLockTime {
+			continue
+		}
+
+		maxLockedTransactionTime := utils.GetMaxTransactionTimeFromUnixTime(accountTransactionLockTime)
+		exists, err := sess.Cols("uid", "deleted", "account_id").Where("uid=? AND deleted=? AND account_id=? AND transaction_time<=?", uid, false, accounts[i].AccountId, maxLockedTransactionTime).Limit(1).Exist(&models.Transaction{})
+
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+
+	return false, nil
+}
+
 func (s *TransactionService) getAccountModels(sess *xorm.Session, transaction *models.Transaction) (sourceAccount *models.Account, destinationAccount *models.Account, err error) {
 	sourceAccount = &models.Account{}
 	destinationAccount = &models.Account{}
@@ -2988,6 +5490,27 @@ func (s *TransactionService) isCategoryValid(sess *xorm.Session, transaction *mo
 	return nil
 }
 
+func (s *TransactionService) isPayeeValid(sess *xorm.Session, transaction *models.Transaction) error {
+	if transaction.PayeeId == 0 {
+		return nil
+	}
+
+	payee := &models.Payee{}
+	has, err := sess.ID(transaction.PayeeId).Where("uid=? AND deleted=?", transaction.Uid, false).Get(payee)
+
+	if err != nil {
+		return err
+	} else if !has {
+		return errs.ErrPayeeNotFound
+	}
+
+	if payee.Hidden {
+		return errs.ErrCannotUseHiddenPayee
+	}
+
+	return nil
+}
+
 func (s *TransactionService) isTagsValid(sess *xorm.Session, transaction *models.Transaction, transactionTagIndexes []*models.TransactionTagIndex, tagIds []int64) error {
 	if len(transactionTagIndexes) > 0 {
 		var tags []*models.TransactionTag
@@ -3046,6 +5569,266 @@ func (s *TransactionService) isItemsValid(sess *xorm.Session, transaction *model
 	return nil
 }
 
+func (s *TransactionService) fillNewTransactionIdsAndTimes(transaction *models.Transaction, now int64) error {
+	needTransactionUuidCount := 1
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+		needTransactionUuidCount = 2
+	}
+
+	transactionUuids := s.GenerateUuids(uuid.UUID_TYPE_TRANSACTION, uint16(needTransactionUuidCount))
+
+	if len(transactionUuids) < needTransactionUuidCount {
+		return errs.ErrSystemIsBusy
+	}
+
+	transaction.TransactionId = transactionUuids[0]
+
+	if transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_OUT || transaction.Type == models.TRANSACTION_DB_TYPE_TRANSFER_IN {
+		transaction.RelatedId = transactionUuids[1]
+	}
+
+	transaction.TransactionTime = utils.GetMinTransactionTimeFromUnixTime(utils.GetUnixTimeFromTransactionTime(transaction.TransactionTime))
+
+	if transaction.CreatedByUid <= 0 {
+		transaction.CreatedByUid = transaction.Uid
+	}
+
+	transaction.CreatedUnixTime = now
+	transaction.UpdatedUnixTime = now
+
+	return nil
+}
+
+func (s *TransactionService) fillTransactionItemIndexDetail(itemIndex *models.TransactionItemIndex, itemDetail *models.TransactionItemIndex) {
+	itemIndex.Quantity = itemDetail.Quantity
+	itemIndex.Unit = itemDetail.Unit
+	itemIndex.UnitPrice = itemDetail.UnitPrice
+	itemIndex.Amount = itemDetail.Amount
+}
+
+func (s *TransactionService) createLoanPaymentTransactions(c core.Context, loan *models.Loan, period int32, item *models.LoanAmortizationItem) (bool, error) {
+	transactionTime := utils.GetMinTransactionTimeFromUnixTime(item.PaymentTime)
+	transactions := make([]*models.Transaction, 0, 2)
+
+	if item.Principal > 0 {
+		transactions = append(transactions, &models.Transaction{
+			Uid:                  loan.Uid,
+			Type:                 models.TRANSACTION_DB_TYPE_TRANSFER_OUT,
+			CategoryId:           loan.PrincipalCategoryId,
+			TransactionTime:      transactionTime,
+			TimezoneUtcOffset:    loan.TimezoneUtcOffset,
+			AccountId:            loan.PaymentAccountId,
+			Amount:               item.Principal,
+			RelatedAccountId:     loan.AccountId,
+			RelatedAccountAmount: item.Principal,
+			Comment:              loan.Name,
+			CreatedIp:            "127.0.0.1",
+			ScheduledCreated:     true,
+		})
+	}
+
+	if item.Interest > 0 {
+		transactions = append(transactions, &models.Transaction{
+			Uid:               loan.Uid,
+			Type:              models.TRANSACTION_DB_TYPE_EXPENSE,
+			CategoryId:        loan.InterestCategoryId,
+			TransactionTime:   transactionTime,
+			TimezoneUtcOffset: loan.TimezoneUtcOffset,
+			AccountId:         loan.PaymentAccountId,
+			Amount:            item.Interest,
+			Comment:           loan.Name,
+			CreatedIp:         "127.0.0.1",
+			ScheduledCreated:  true,
+		})
+	}
+
+	now := time.Now().Unix()
+
+	for i := 0; i < len(transactions); i++ {
+		err := s.isAccountIdValid(transactions[i])
+
+		if err != nil {
+			return false, err
+		}
+
+		err = s.fillNewTransactionIdsAndTimes(transactions[i], now)
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	revisionIds := s.GenerateUuids(uuid.UUID_TYPE_DEFAULT, uint16(len(transactions)))
+
+	if len(revisionIds) < len(transactions) {
+		return false, errs.ErrSystemIsBusy
+	}
+
+	userTransactionLockTime, err := s.getUserTransactionLockTime(c, loan.Uid)
+
+	if err != nil {
+		return false, err
+	}
+
+	updateModel := &models.Loan{
+		PaidPeriods:     period,
+		UpdatedUnixTime: now,
+	}
+
+	created := false
+	userDataDb := s.UserDataDB(loan.Uid)
+
+	err = userDataDb.DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(loan.LoanId).Cols("paid_periods", "updated_unix_time").Where("uid=? AND deleted=? AND paid_periods=?", loan.Uid, false, period-1).Update(updateModel)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return nil
+		}
+
+		for i := 0; i < len(transactions); i++ {
+			pictureUpdateModel := &models.TransactionPictureInfo{
+				TransactionId:   transactions[i].TransactionId,
+				UpdatedUnixTime: now,
+			}
+
+			err = s.doCreateTransaction(c, userDataDb, sess, userTransactionLockTime, transactions[i], nil, nil, nil, nil, nil, pictureUpdateModel, nil)
+
+			if err != nil {
+				return err
+			}
+
+			afterSnapshot := models.NewTransactionRevisionSnapshot(transactions[i], nil, nil, nil, nil)
+			err = s.insertTransactionRevision(sess, revisionIds[i], loan.Uid, transactions[i].TransactionId, models.TRANSACTION_REVISION_TYPE_CREATE, nil, afterSnapshot, nil, now)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		created = true
+		return nil
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (s *TransactionService) isSplitsValid(sess *xorm.Session, transaction *models.Transaction, splits []*models.TransactionSplit) error {
+	if len(splits) < 1 {
+		return nil
+	}
+
+	if transaction.Type != models.TRANSACTION_DB_TYPE_INCOME && transaction.Type != models.TRANSACTION_DB_TYPE_EXPENSE {
+		return errs.ErrTransactionCannotBeSplit
+	}
+
+	if len(splits) < 2 {
+		return errs.ErrTransactionSplitsTooFew
+	}
+
+	if len(splits) > models.MaximumSplitsCountOfTransaction {
+		return errs.ErrTransactionHasTooManySplits
+	}
+
+	totalAmount := int64(0)
+	categoryIds := make([]int64, 0, len(splits))
+	tagIds := make([]int64, 0)
+
+	for i := 0; i < len(splits); i++ {
+		totalAmount += splits[i].Amount
+		categoryIds = append(categoryIds, splits[i].CategoryId)
+		tagIds = append(tagIds, splits[i].GetTagIds()...)
+	}
+
+	if totalAmount != transaction.Amount {
+		return errs.ErrTransactionSplitAmountsNotEqualToTotalAmount
+	}
+
+	var categories []*models.TransactionCategory
+	err := sess.Where("uid=? AND deleted=?", transaction.Uid, false).In("category_id", utils.ToUniqueInt64Slice(categoryIds)).Find(&categories)
+
+	if err != nil {
+		return err
+	}
+
+	categoryMap := make(map[int64]*models.TransactionCategory, len(categories))
+	parentCategoryIds := make([]int64, 0, len(categories))
+
+	for i := 0; i < len(categories); i++ {
+		category := categories[i]
+
+		if category.Hidden {
+			return errs.ErrCannotUseHiddenTransactionCategory
+		}
+
+		if category.ParentCategoryId == models.LevelOneTransactionCategoryParentId {
+			return errs.ErrCannotUsePrimaryCategoryForTransaction
+		}
+
+		if (transaction.Type == models.TRANSACTION_DB_TYPE_INCOME && category.Type != models.CATEGORY_TYPE_INCOME) ||
+			(transaction.Type == models.TRANSACTION_DB_TYPE_EXPENSE && category.Type != models.CATEGORY_TYPE_EXPENSE) {
+			return errs.ErrTransactionSplitCategoryInvalid
+		}
+
+		categoryMap[category.CategoryId] = category
+		parentCategoryIds = append(parentCategoryIds, category.ParentCategoryId)
+	}
+
+	for i := 0; i < len(splits); i++ {
+		if _, exists := categoryMap[splits[i].CategoryId]; !exists {
+			return errs.ErrTransactionCategoryNotFound
+		}
+	}
+
+	parentCategoryIds = utils.ToUniqueInt64Slice(parentCategoryIds)
+
+	var parentCategories []*models.TransactionCategory
+	err = sess.Where("uid=? AND deleted=?", transaction.Uid, false).In("category_id", parentCategoryIds).Find(&parentCategories)
+
+	if err != nil {
+		return err
+	}
+
+	if len(parentCategories) < len(parentCategoryIds) {
+		return errs.ErrTransactionCategoryNotFound
+	}
+
+	for i := 0; i < len(parentCategories); i++ {
+		if parentCategories[i].Hidden {
+			return errs.ErrCannotUseHiddenTransactionCategory
+		}
+	}
+
+	if len(tagIds) > 0 {
+		tagIds = utils.ToUniqueInt64Slice(tagIds)
+
+		var tags []*models.TransactionTag
+		err = sess.Where("uid=? AND deleted=?", transaction.Uid, false).In("tag_id", tagIds).Find(&tags)
+
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(tags); i++ {
+			if tags[i].Hidden {
+				return errs.ErrCannotUseHiddenTransactionTag
+			}
+		}
+
+		if len(tags) < len(tagIds) {
+			return errs.ErrTransactionTagNotFound
+		}
+	}
+
+	return nil
+}
+
 func (s *TransactionService) isPicturesValid(sess *xorm.Session, transaction *models.Transaction, pictureIds []int64) error {
 	if len(pictureIds) > 0 {
 		var pictureInfos []*models.TransactionPictureInfo
@@ -3074,3 +5857,62 @@ func (s *TransactionService) isPicturesValid(sess *xorm.Session, transaction *mo
 
 	return nil
 }
+
+func (s *TransactionService) updatePostponedTransactionTemplateOccurrence(c core.Context, occurrence *models.TransactionTemplateOccurrence, status models.TransactionTemplateOccurrenceStatus, transactionId int64) error {
+	occurrence.Status = status
+	occurrence.TransactionId = transactionId
+	occurrence.UpdatedUnixTime = time.Now().Unix()
+
+	return s.UserDataDB(occurrence.Uid).DoTransaction(c, func(sess *xorm.Session) error {
+		updatedRows, err := sess.ID(occurrence.OccurrenceId).Cols("status", "transaction_id", "updated_unix_time").Where("uid=? AND deleted=? AND status=?", occurrence.Uid, false, models.TRANSACTION_TEMPLATE_OCCURRENCE_STATUS_POSTPONED).Update(occurrence)
+
+		if err != nil {
+			return err
+		} else if updatedRows < 1 {
+			return errs.ErrTransactionTemplateOccurrenceNotFound
+		}
+
+		return err
+	})
+}
+
+func (s *TransactionService) migrateTransactionsOriginalAmountDecimalPlaces(c core.Context, database *datastore.Database, uid int64, currencies []string) error {
+	return database.DoTransaction(c, func(sess *xorm.Session) error {
+		for i := 0; i < len(currencies); i++ {
+			currency := currencies[i]
+			amountDecimalPlaces := utils.GetCurrencyAmountDecimalPlaces(currency)
+			multiplier := getAmountDecimalPlacesMultiplier(utils.DefaultCurrencyDecimalPlaces, amountDecimalPlaces)
+
+			updateModel := &models.Transaction{
+				OriginalAmountDecimalPlaces: amountDecimalPlaces,
+			}
+
+			_, err := sess.SetExpr("original_amount", fmt.Sprintf("original_amount*%d", multiplier)).Cols("original_amount_decimal_places").Where("uid=? AND original_currency=? AND original_amount_decimal_places=?", uid, currency, utils.DefaultCurrencyDecimalPlaces).Update(updateModel)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return updateAllTransactionRevisionSnapshots(sess, uid, func(snapshot *models.TransactionRevisionSnapshot) bool {
+			if snapshot.OriginalCurrency == "" {
+				return false
+			}
+
+			amountDecimalPlaces := utils.GetCurrencyAmountDecimalPlaces(snapshot.OriginalCurrency)
+			snapshotAmountDecimalPlaces := snapshot.OriginalAmountDecimalPlaces
+
+			if snapshotAmountDecimalPlaces < 1 { // snapshots created before the decimal places of original amount are saved
+				snapshotAmountDecimalPlaces = utils.DefaultCurrencyDecimalPlaces
+			}
+
+			if snapshotAmountDecimalPlaces >= amountDecimalPlaces {
+				return false
+			}
+
+			snapshot.OriginalAmount *= getAmountDecimalPlacesMultiplier(snapshotAmountDecimalPlaces, amountDecimalPlaces)
+			snapshot.OriginalAmountDecimalPlaces = amountDecimalPlaces
+			return true
+		})
+	})
+}
@@ -103,6 +103,28 @@ func (s *UserService) GetUserById(c core.Context, uid int64) (*models.User, erro
 	return user, nil
 }
 
+// GetUsersByUids returns the user models according to user uids
+func (s *UserService) GetUsersByUids(c core.Context, uids []int64) (map[int64]*models.User, error) {
+	if len(uids) <= 0 {
+		return make(map[int64]*models.User), nil
+	}
+
+	var users []*models.User
+	err := s.UserDB().NewSession(c).Where("deleted=?", false).In("uid", uids).Find(&users)
+
+	if err != nil {
+		return nil, err
+	}
+
+	userMap := make(map[int64]*models.User, len(users))
+
+	for i := 0; i < len(users); i++ {
+		userMap[users[i].Uid] = users[i]
+	}
+
+	return userMap, nil
+}
+
 // GetUserByUsername returns the user model according to user name
 func (s *UserService) GetUserByUsername(c core.Context, username string) (*models.User, error) {
 	if username == "" {
@@ -281,6 +303,10 @@ func (s *UserService) UpdateUser(c core.Context, user *models.User, modifyUserLa
 		updateCols = append(updateCols, "transaction_edit_scope")
 	}
 
+	if user.TransactionLockTime > 0 {
+		updateCols = append(updateCols, "transaction_lock_time")
+	}
+
 	if modifyUserLanguage || user.Language != "" {
 		updateCols = append(updateCols, "language")
 	}
@@ -503,6 +529,30 @@ func (s *UserService) DisableUser(c core.Context, username string) error {
 	return nil
 }
 
+// SetUserTransactionLockTime sets the transaction lock time of user, the lock time can be moved backward or cleared (set to 0)
+func (s *UserService) SetUserTransactionLockTime(c core.Context, username string, transactionLockTime int64) error {
+	if username == "" {
+		return errs.ErrUsernameIsEmpty
+	}
+
+	now := time.Now().Unix()
+
+	updateModel := &models.User{
+		TransactionLockTime: transactionLockTime,
+		UpdatedUnixTime:     now,
+	}
+
+	updatedRows, err := s.UserDB().NewSession(c).Cols("transaction_lock_time", "updated_unix_time").Where("username=? AND deleted=?", username, false).Update(updateModel)
+
+	if err != nil {
+		return err
+	} else if updatedRows < 1 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
+
 // UpdateUserFeatureRestriction sets user user feature restrictions
 func (s *UserService) UpdateUserFeatureRestriction(c core.Context, username string, featureRestriction core.UserFeatureRestrictions) error {
 	if username == "" {
@@ -130,23 +130,33 @@ const (
 const (
 	ReserveBankOfAustraliaDataSource  string = "reserve_bank_of_australia"
 	BankOfCanadaDataSource            string = "bank_of_canada"
+	PeoplesBankOfChinaDataSource      string = "peoples_bank_of_china"
 	CzechNationalBankDataSource       string = "czech_national_bank"
 	DanmarksNationalbankDataSource    string = "danmarks_national_bank"
 	EuroCentralBankDataSource         string = "euro_central_bank"
 	NationalBankOfGeorgiaDataSource   string = "national_bank_of_georgia"
 	CentralBankOfHungaryDataSource    string = "central_bank_of_hungary"
 	BankOfIsraelDataSource            string = "bank_of_israel"
+	BankOfKoreaDataSource             string = "bank_of_korea"
 	CentralBankOfMyanmarDataSource    string = "central_bank_of_myanmar"
 	NorgesBankDataSource              string = "norges_bank"
 	NationalBankOfPolandDataSource    string = "national_bank_of_poland"
 	NationalBankOfRomaniaDataSource   string = "national_bank_of_romania"
 	BankOfRussiaDataSource            string = "bank_of_russia"
 	SwissNationalBankDataSource       string = "swiss_national_bank"
+	BankOfThailandDataSource          string = "bank_of_thailand"
+	CentralBankOfTurkiyeDataSource    string = "central_bank_of_turkiye"
 	NationalBankOfUkraineDataSource   string = "national_bank_of_ukraine"
+	BankOfEnglandDataSource           string = "bank_of_england"
 	CentralBankOfUzbekistanDataSource string = "central_bank_of_uzbekistan"
 	UserCustomExchangeRatesDataSource string = "user_custom"
 )
 
+// Security prices data source types
+const (
+	StooqSecurityPricesDataSource string = "stooq"
+)
+
 const (
 	defaultHttpAddr string = "0.0.0.0"
 	defaultHttpPort uint16 = 8080
@@ -171,6 +181,8 @@ const (
 	defaultInMemoryDuplicateCheckerCleanupInterval uint32 = 60  // 1 minutes
 	defaultDuplicateSubmissionsInterval            uint32 = 300 // 5 minutes
 
+	defaultDeletedDataRetentionDays uint32 = 30 // 30 days
+
 	defaultSecretKey                     string = "ezbookkeeping"
 	defaultTokenExpiredTime              uint32 = 2592000 // 30 days
 	defaultTokenMinRefreshInterval       uint32 = 86400   // 1 day
@@ -183,12 +195,16 @@ const (
 	defaultOAuth2StateExpiredTime uint32 = 300   // 5 minutes
 	defaultOAuth2RequestTimeout   uint32 = 10000 // 10 seconds
 
-	defaultTransactionPictureFileMaxSize uint32 = 10485760 // 10MB
-	defaultUserAvatarFileMaxSize         uint32 = 1048576  // 1MB
+	defaultTransactionPictureFileMaxSize            uint32 = 10485760 // 10MB
+	defaultTransactionPdfAttachmentFileMaxSize      uint32 = 20971520 // 20MB
+	defaultTransactionDocumentAttachmentFileMaxSize uint32 = 10485760 // 10MB
+	defaultUserAvatarFileMaxSize                    uint32 = 1048576  // 1MB
 
 	defaultImportFileMaxSize uint32 = 10485760 // 10MB
 
 	defaultExchangeRatesDataRequestTimeout uint32 = 10000 // 10 seconds
+
+	defaultSecurityPricesDataRequestTimeout uint32 = 10000 // 10 seconds
 )
 
 // DatabaseConfig represents the database setting config
@@ -361,6 +377,9 @@ type Config struct {
 	// Cron
 	EnableRemoveExpiredTokens        bool
 	EnableCreateScheduledTransaction bool
+	EnablePurgeDeletedData           bool
+	DeletedDataRetentionDays         uint32
+	EnableSaveExchangeRatesSnapshot  bool
 
 	// Secret
 	SecretKeyNoSet                        bool
@@ -402,15 +421,17 @@ type Config struct {
 	OAuth2GiteaBaseUrl                string
 
 	// User
-	EnableUserRegister            bool
-	EnableUserVerifyEmail         bool
-	EnableUserForceVerifyEmail    bool
-	EnableTransactionPictures     bool
-	MaxTransactionPictureFileSize uint32
-	EnableScheduledTransaction    bool
-	AvatarProvider                core.UserAvatarProviderType
-	MaxAvatarFileSize             uint32
-	DefaultFeatureRestrictions    core.UserFeatureRestrictions
+	EnableUserRegister                       bool
+	EnableUserVerifyEmail                    bool
+	EnableUserForceVerifyEmail               bool
+	EnableTransactionPictures                bool
+	MaxTransactionPictureFileSize            uint32
+	MaxTransactionPdfAttachmentFileSize      uint32
+	MaxTransactionDocumentAttachmentFileSize uint32
+	EnableScheduledTransaction               bool
+	AvatarProvider                           core.UserAvatarProviderType
+	MaxAvatarFileSize                        uint32
+	DefaultFeatureRestrictions               core.UserFeatureRestrictions
 
 	// Data
 	EnableDataExport  bool
@@ -449,6 +470,18 @@ type Config struct {
 	ExchangeRatesRequestTimeoutExceedDefaultValue bool
 	ExchangeRatesProxy                            string
 	ExchangeRatesSkipTLSVerify                    bool
+	ExchangeRatesFallbackDataSources              []string
+	ExchangeRatesMergeFallbackCurrencies          bool
+	ExchangeRatesCacheExpiration                  uint32
+	ExchangeRatesDivergenceWarningThreshold       float64
+	ExchangeRatesBankOfKoreaApiKey                string
+	ExchangeRatesBankOfThailandApiKey             string
+
+	// Investment
+	SecurityPricesDataSource     string
+	SecurityPricesRequestTimeout uint32
+	SecurityPricesProxy          string
+	SecurityPricesSkipTLSVerify  bool
 }
 
 // LoadConfiguration loads setting config from given config file path
@@ -590,6 +623,12 @@ func LoadConfiguration(configFilePath string) (*Config, error) {
 		return nil, err
 	}
 
+	err = loadInvestmentConfiguration(config, cfgFile, "investment")
+
+	if err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -981,6 +1020,14 @@ func loadDuplicateCheckerConfiguration(config *Config, configFile *ini.File, sec
 func loadCronConfiguration(config *Config, configFile *ini.File, sectionName string) error {
 	config.EnableRemoveExpiredTokens = getConfigItemBoolValue(configFile, sectionName, "enable_remove_expired_tokens", false)
 	config.EnableCreateScheduledTransaction = getConfigItemBoolValue(configFile, sectionName, "enable_create_scheduled_transaction", false)
+	config.EnablePurgeDeletedData = getConfigItemBoolValue(configFile, sectionName, "enable_purge_deleted_data", false)
+	config.DeletedDataRetentionDays = getConfigItemUint32Value(configFile, sectionName, "deleted_data_retention_days", defaultDeletedDataRetentionDays)
+
+	if config.DeletedDataRetentionDays < 1 {
+		config.DeletedDataRetentionDays = defaultDeletedDataRetentionDays
+	}
+
+	config.EnableSaveExchangeRatesSnapshot = getConfigItemBoolValue(configFile, sectionName, "enable_save_exchange_rates_snapshot", false)
 
 	return nil
 }
@@ -1100,6 +1147,8 @@ func loadUserConfiguration(config *Config, configFile *ini.File, sectionName str
 	config.EnableUserForceVerifyEmail = getConfigItemBoolValue(configFile, sectionName, "enable_force_email_verify", false)
 	config.EnableTransactionPictures = getConfigItemBoolValue(configFile, sectionName, "enable_transaction_picture", false)
 	config.MaxTransactionPictureFileSize = getConfigItemUint32Value(configFile, sectionName, "max_transaction_picture_size", defaultTransactionPictureFileMaxSize)
+	config.MaxTransactionPdfAttachmentFileSize = getConfigItemUint32Value(configFile, sectionName, "max_transaction_pdf_attachment_size", defaultTransactionPdfAttachmentFileMaxSize)
+	config.MaxTransactionDocumentAttachmentFileSize = getConfigItemUint32Value(configFile, sectionName, "max_transaction_document_attachment_size", defaultTransactionDocumentAttachmentFileMaxSize)
 	config.EnableScheduledTransaction = getConfigItemBoolValue(configFile, sectionName, "enable_scheduled_transaction", false)
 
 	if getConfigItemStringValue(configFile, sectionName, "avatar_provider") == string(core.USER_AVATAR_PROVIDER_INTERNAL) {
@@ -1207,36 +1256,129 @@ func loadMapConfiguration(config *Config, configFile *ini.File, sectionName stri
 func loadExchangeRatesConfiguration(config *Config, configFile *ini.File, sectionName string) error {
 	dataSource := getConfigItemStringValue(configFile, sectionName, "data_source")
 
-	if dataSource == ReserveBankOfAustraliaDataSource ||
+	if isValidExchangeRatesDataSource(dataSource) ||
+		dataSource == UserCustomExchangeRatesDataSource {
+		config.ExchangeRatesDataSource = dataSource
+	} else {
+		return errs.ErrInvalidExchangeRatesDataSource
+	}
+
+	config.ExchangeRatesProxy = getConfigItemStringValue(configFile, sectionName, "proxy", "system")
+	config.ExchangeRatesRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "request_timeout", defaultExchangeRatesDataRequestTimeout)
+
+	if config.ExchangeRatesRequestTimeout > defaultExchangeRatesDataRequestTimeout {
+		config.ExchangeRatesRequestTimeoutExceedDefaultValue = true
+	}
+
+	config.ExchangeRatesSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+
+	fallbackDataSources := getConfigItemStringValue(configFile, sectionName, "fallback_data_sources")
+	config.ExchangeRatesFallbackDataSources = make([]string, 0)
+
+	if fallbackDataSources != "" {
+		if config.ExchangeRatesDataSource == UserCustomExchangeRatesDataSource {
+			return errs.ErrInvalidExchangeRatesFallbackDataSource
+		}
+
+		existedDataSources := map[string]bool{
+			config.ExchangeRatesDataSource: true,
+		}
+
+		for _, fallbackDataSource := range strings.Split(fallbackDataSources, ",") {
+			fallbackDataSource = strings.TrimSpace(fallbackDataSource)
+
+			if fallbackDataSource == "" {
+				continue
+			}
+
+			if !isValidExchangeRatesDataSource(fallbackDataSource) || existedDataSources[fallbackDataSource] {
+				return errs.ErrInvalidExchangeRatesFallbackDataSource
+			}
+
+			existedDataSources[fallbackDataSource] = true
+			config.ExchangeRatesFallbackDataSources = append(config.ExchangeRatesFallbackDataSources, fallbackDataSource)
+		}
+	}
+
+	config.ExchangeRatesMergeFallbackCurrencies = getConfigItemBoolValue(configFile, sectionName, "merge_fallback_currencies", true)
+	config.ExchangeRatesCacheExpiration = getConfigItemUint32Value(configFile, sectionName, "cache_expiration", 0)
+
+	divergenceWarningThreshold := getConfigItemStringValue(configFile, sectionName, "divergence_warning_threshold")
+
+	if divergenceWarningThreshold != "" {
+		threshold, err := strconv.ParseFloat(divergenceWarningThreshold, 64)
+
+		if err != nil || threshold < 0 {
+			return errs.ErrInvalidExchangeRatesDivergenceWarningThreshold
+		}
+
+		config.ExchangeRatesDivergenceWarningThreshold = threshold
+	}
+
+	config.ExchangeRatesBankOfKoreaApiKey = getConfigItemStringValue(configFile, sectionName, "bank_of_korea_api_key")
+	config.ExchangeRatesBankOfThailandApiKey = getConfigItemStringValue(configFile, sectionName, "bank_of_thailand_api_key")
+
+	if config.ExchangeRatesBankOfKoreaApiKey == "" && isExchangeRatesDataSourceEnabled(config, BankOfKoreaDataSource) {
+		return errs.ErrMissingExchangeRatesDataSourceApiKey
+	}
+
+	if config.ExchangeRatesBankOfThailandApiKey == "" && isExchangeRatesDataSourceEnabled(config, BankOfThailandDataSource) {
+		return errs.ErrMissingExchangeRatesDataSourceApiKey
+	}
+
+	return nil
+}
+
+func isExchangeRatesDataSourceEnabled(config *Config, dataSource string) bool {
+	if config.ExchangeRatesDataSource == dataSource {
+		return true
+	}
+
+	for _, fallbackDataSource := range config.ExchangeRatesFallbackDataSources {
+		if fallbackDataSource == dataSource {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isValidExchangeRatesDataSource(dataSource string) bool {
+	return dataSource == ReserveBankOfAustraliaDataSource ||
 		dataSource == BankOfCanadaDataSource ||
+		dataSource == PeoplesBankOfChinaDataSource ||
 		dataSource == CzechNationalBankDataSource ||
 		dataSource == DanmarksNationalbankDataSource ||
 		dataSource == EuroCentralBankDataSource ||
 		dataSource == NationalBankOfGeorgiaDataSource ||
 		dataSource == CentralBankOfHungaryDataSource ||
 		dataSource == BankOfIsraelDataSource ||
+		dataSource == BankOfKoreaDataSource ||
 		dataSource == CentralBankOfMyanmarDataSource ||
 		dataSource == NorgesBankDataSource ||
 		dataSource == NationalBankOfPolandDataSource ||
 		dataSource == NationalBankOfRomaniaDataSource ||
 		dataSource == BankOfRussiaDataSource ||
 		dataSource == SwissNationalBankDataSource ||
+		dataSource == BankOfThailandDataSource ||
+		dataSource == CentralBankOfTurkiyeDataSource ||
 		dataSource == NationalBankOfUkraineDataSource ||
-		dataSource == CentralBankOfUzbekistanDataSource ||
-		dataSource == UserCustomExchangeRatesDataSource {
-		config.ExchangeRatesDataSource = dataSource
-	} else {
-		return errs.ErrInvalidExchangeRatesDataSource
-	}
+		dataSource == BankOfEnglandDataSource ||
+		dataSource == CentralBankOfUzbekistanDataSource
+}
 
-	config.ExchangeRatesProxy = getConfigItemStringValue(configFile, sectionName, "proxy", "system")
-	config.ExchangeRatesRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "request_timeout", defaultExchangeRatesDataRequestTimeout)
+func loadInvestmentConfiguration(config *Config, configFile *ini.File, sectionName string) error {
+	dataSource := getConfigItemStringValue(configFile, sectionName, "security_prices_data_source")
 
-	if config.ExchangeRatesRequestTimeout > defaultExchangeRatesDataRequestTimeout {
-		config.ExchangeRatesRequestTimeoutExceedDefaultValue = true
+	if dataSource == "" || dataSource == StooqSecurityPricesDataSource {
+		config.SecurityPricesDataSource = dataSource
+	} else {
+		return errs.ErrInvalidSecurityPricesDataSource
 	}
 
-	config.ExchangeRatesSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "skip_tls_verify", false)
+	config.SecurityPricesProxy = getConfigItemStringValue(configFile, sectionName, "security_prices_proxy", "system")
+	config.SecurityPricesRequestTimeout = getConfigItemUint32Value(configFile, sectionName, "security_prices_request_timeout", defaultSecurityPricesDataRequestTimeout)
+	config.SecurityPricesSkipTLSVerify = getConfigItemBoolValue(configFile, sectionName, "security_prices_skip_tls_verify", false)
 
 	return nil
 }
@@ -3,6 +3,7 @@ package utils
 import (
 	"encoding/json"
 	"errors"
+	"mime"
 	"net/http"
 	"reflect"
 
@@ -55,6 +56,19 @@ func PrintDataSuccessResult(c *core.WebContext, contentType string, fileName str
 	c.Data(http.StatusOK, contentType, result)
 }
 
+// PrintAttachmentSuccessResult writes file data response as attachment with the original file name to current http context
+func PrintAttachmentSuccessResult(c *core.WebContext, contentType string, fileName string, result []byte) {
+	contentDisposition := mime.FormatMediaType("attachment", map[string]string{"filename": fileName})
+
+	if contentDisposition == "" {
+		contentDisposition = "attachment"
+	}
+
+	c.Header("Content-Disposition", contentDisposition)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, contentType, result)
+}
+
 // PrintJsonErrorResult writes error response in json format to current http context
 func PrintJsonErrorResult(c *core.WebContext, err *errs.Error) {
 	c.SetResponseError(err)
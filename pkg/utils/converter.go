@@ -102,6 +102,11 @@ func StringToFloat64(str string) (float64, error) {
 
 // FormatAmount returns a textual representation of amount
 func FormatAmount(value int64) string {
+	return FormatAmountWithDecimalPlaces(value, DefaultCurrencyDecimalPlaces)
+}
+
+// FormatAmountWithDecimalPlaces returns a textual representation of amount which is stored in the specified decimal places
+func FormatAmountWithDecimalPlaces(value int64, decimalPlaces int32) string {
 	displayAmount := Int64ToString(value)
 	negative := displayAmount[0] == '-'
 
@@ -109,28 +114,30 @@ func FormatAmount(value int64) string {
 		displayAmount = displayAmount[1:]
 	}
 
-	integer := SubString(displayAmount, 0, len(displayAmount)-2)
-	decimals := SubString(displayAmount, -2, 2)
-
-	if integer == "" {
-		integer = "0"
-	}
+	if decimalPlaces > 0 {
+		if len(displayAmount) <= int(decimalPlaces) {
+			displayAmount = strings.Repeat("0", int(decimalPlaces)-len(displayAmount)+1) + displayAmount
+		}
 
-	if len(decimals) == 0 {
-		decimals = "00"
-	} else if len(decimals) == 1 {
-		decimals = "0" + decimals
+		integer := displayAmount[0 : len(displayAmount)-int(decimalPlaces)]
+		decimals := displayAmount[len(displayAmount)-int(decimalPlaces):]
+		displayAmount = integer + "." + decimals
 	}
 
 	if negative {
-		return "-" + integer + "." + decimals
+		return "-" + displayAmount
 	}
 
-	return integer + "." + decimals
+	return displayAmount
 }
 
 // ParseAmount parses a textual representation of amount
 func ParseAmount(amount string) (int64, error) {
+	return ParseAmountWithDecimalPlaces(amount, DefaultCurrencyDecimalPlaces)
+}
+
+// ParseAmountWithDecimalPlaces parses a textual representation of amount to the value stored in the specified decimal places
+func ParseAmountWithDecimalPlaces(amount string, decimalPlaces int32) (int64, error) {
 	if len(amount) < 1 {
 		return 0, nil
 	}
@@ -172,7 +179,7 @@ func ParseAmount(amount string) (int64, error) {
 	}
 
 	if len(items) == 2 {
-		if len(items[1]) > 2 {
+		if len(items[1]) > int(decimalPlaces) {
 			return 0, errs.ErrNumberInvalid
 		}
 
@@ -186,10 +193,8 @@ func ParseAmount(amount string) (int64, error) {
 			return 0, errs.ErrNumberInvalid
 		}
 
-		if len(items[1]) == 1 {
-			decimals = decimals * 10
-		}
+		decimals = decimals * pow10(decimalPlaces-int32(len(items[1])))
 	}
 
-	return sign*integer*100 + sign*decimals, nil
+	return sign*integer*pow10(decimalPlaces) + sign*decimals, nil
 }
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"math"
+	"strings"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+// DefaultCurrencyDecimalPlaces represents the decimal places of the minor unit of most currencies, and the amounts of the currencies which have fewer decimal places are also stored in this precision
+const DefaultCurrencyDecimalPlaces = int32(2)
+
+// MaximumCurrencyAmountDecimalPlaces represents the maximum decimal places of the amounts of all currencies stored in database
+const MaximumCurrencyAmountDecimalPlaces = int32(3)
+
+// CurrencyDecimalPlaces represents the decimal places of the minor unit of the currencies in ISO 4217 which are not two decimal places
+// Reference: https://www.six-group.com/dam/download/financial-information/data-center/iso-currrency/lists/list-one.xml
+var CurrencyDecimalPlaces = map[string]int32{
+	"BHD": 3, //Bahraini Dinar
+	"BIF": 0, //Burundi Franc
+	"CLP": 0, //Chilean Peso
+	"DJF": 0, //Djibouti Franc
+	"GNF": 0, //Guinean Franc
+	"IQD": 3, //Iraqi Dinar
+	"ISK": 0, //Iceland Krona
+	"JOD": 3, //Jordanian Dinar
+	"JPY": 0, //Yen
+	"KMF": 0, //Comorian Franc
+	"KRW": 0, //Won
+	"KWD": 3, //Kuwaiti Dinar
+	"LYD": 3, //Libyan Dinar
+	"OMR": 3, //Rial Omani
+	"PYG": 0, //Guarani
+	"RWF": 0, //Rwanda Franc
+	"TND": 3, //Tunisian Dinar
+	"UGX": 0, //Uganda Shilling
+	"VND": 0, //Dong
+	"VUV": 0, //Vatu
+	"XAF": 0, //CFA Franc BEAC
+	"XOF": 0, //CFA Franc BCEAO
+	"XPF": 0, //CFP Franc
+}
+
+// GetCurrencyDecimalPlaces returns the decimal places of the minor unit of the specified currency
+func GetCurrencyDecimalPlaces(currency string) int32 {
+	if decimalPlaces, exists := CurrencyDecimalPlaces[currency]; exists {
+		return decimalPlaces
+	}
+
+	return DefaultCurrencyDecimalPlaces
+}
+
+// GetCurrencyAmountDecimalPlaces returns the decimal places of the amount of the specified currency stored in database
+func GetCurrencyAmountDecimalPlaces(currency string) int32 {
+	decimalPlaces := GetCurrencyDecimalPlaces(currency)
+
+	if decimalPlaces < DefaultCurrencyDecimalPlaces {
+		return DefaultCurrencyDecimalPlaces
+	}
+
+	return decimalPlaces
+}
+
+// ParseCurrencyAmount parses a textual representation of amount of the specified currency, the fractional part cannot be more precise than the minor unit of the currency
+func ParseCurrencyAmount(amount string, currency string) (int64, error) {
+	amountDecimalPlaces := GetCurrencyAmountDecimalPlaces(currency)
+	value, err := ParseAmountWithDecimalPlaces(TrimTrailingZerosInDecimal(amount), amountDecimalPlaces)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if value%pow10(amountDecimalPlaces-GetCurrencyDecimalPlaces(currency)) != 0 {
+		return 0, errs.ErrNumberInvalid
+	}
+
+	return value, nil
+}
+
+// FormatCurrencyAmount returns a textual representation of amount of the specified currency, which only contains the fractional digits of the minor unit of the currency unless the amount is more precise
+func FormatCurrencyAmount(value int64, currency string) string {
+	amountDecimalPlaces := GetCurrencyAmountDecimalPlaces(currency)
+	textualValue := FormatAmountWithDecimalPlaces(value, amountDecimalPlaces)
+	trimmableCount := int(amountDecimalPlaces - GetCurrencyDecimalPlaces(currency))
+
+	for i := 0; i < trimmableCount && strings.HasSuffix(textualValue, "0"); i++ {
+		textualValue = textualValue[0 : len(textualValue)-1]
+	}
+
+	return strings.TrimSuffix(textualValue, ".")
+}
+
+// ParseAmountOfUndeterminedCurrency parses a textual representation of amount whose currency is not determined yet (e.g. amounts in an imported file before matching accounts), the value is stored in the maximum decimal places of all currencies
+func ParseAmountOfUndeterminedCurrency(amount string) (int64, error) {
+	return ParseAmountWithDecimalPlaces(TrimTrailingZerosInDecimal(amount), MaximumCurrencyAmountDecimalPlaces)
+}
+
+// FormatAmountOfUndeterminedCurrency returns a textual representation of amount parsed by ParseAmountOfUndeterminedCurrency, the trailing zeros beyond the default decimal places are omitted
+func FormatAmountOfUndeterminedCurrency(value int64) string {
+	textualValue := FormatAmountWithDecimalPlaces(value, MaximumCurrencyAmountDecimalPlaces)
+	trimmableCount := int(MaximumCurrencyAmountDecimalPlaces - DefaultCurrencyDecimalPlaces)
+
+	for i := 0; i < trimmableCount && strings.HasSuffix(textualValue, "0"); i++ {
+		textualValue = textualValue[0 : len(textualValue)-1]
+	}
+
+	return textualValue
+}
+
+// ExchangeAmountDecimalPlaces returns the amount stored in the precision of the source currency converted to the precision of the target currency
+func ExchangeAmountDecimalPlaces(amount float64, fromCurrency string, toCurrency string) float64 {
+	fromDecimalPlaces := GetCurrencyAmountDecimalPlaces(fromCurrency)
+	toDecimalPlaces := GetCurrencyAmountDecimalPlaces(toCurrency)
+
+	if fromDecimalPlaces == toDecimalPlaces {
+		return amount
+	} else if toDecimalPlaces > fromDecimalPlaces {
+		return amount * float64(pow10(toDecimalPlaces-fromDecimalPlaces))
+	} else {
+		return amount / float64(pow10(fromDecimalPlaces-toDecimalPlaces))
+	}
+}
+
+// RoundCurrencyAmount returns the amount stored in the precision of the specified currency which is rounded to the minor unit of the currency
+func RoundCurrencyAmount(amount float64, currency string) int64 {
+	amountDecimalPlaces := GetCurrencyAmountDecimalPlaces(currency)
+	decimalPlaces := GetCurrencyDecimalPlaces(currency)
+
+	if decimalPlaces >= amountDecimalPlaces {
+		return int64(math.Round(amount))
+	}
+
+	factor := pow10(amountDecimalPlaces - decimalPlaces)
+	return int64(math.Round(amount/float64(factor))) * factor
+}
+
+func pow10(exponent int32) int64 {
+	result := int64(1)
+
+	for i := int32(0); i < exponent; i++ {
+		result *= 10
+	}
+
+	return result
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/errs"
+)
+
+func TestGetCurrencyDecimalPlaces(t *testing.T) {
+	assert.Equal(t, int32(2), GetCurrencyDecimalPlaces("CNY"))
+	assert.Equal(t, int32(2), GetCurrencyDecimalPlaces("USD"))
+	assert.Equal(t, int32(0), GetCurrencyDecimalPlaces("JPY"))
+	assert.Equal(t, int32(3), GetCurrencyDecimalPlaces("KWD"))
+	assert.Equal(t, int32(2), GetCurrencyDecimalPlaces(""))
+}
+
+func TestGetCurrencyAmountDecimalPlaces(t *testing.T) {
+	assert.Equal(t, int32(2), GetCurrencyAmountDecimalPlaces("CNY"))
+	assert.Equal(t, int32(2), GetCurrencyAmountDecimalPlaces("JPY"))
+	assert.Equal(t, int32(3), GetCurrencyAmountDecimalPlaces("KWD"))
+	assert.Equal(t, int32(2), GetCurrencyAmountDecimalPlaces(""))
+}
+
+func TestParseCurrencyAmount(t *testing.T) {
+	actualValue, err := ParseCurrencyAmount("123.45", "CNY")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12345), actualValue)
+
+	actualValue, err = ParseCurrencyAmount("-1234", "JPY")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-123400), actualValue)
+
+	actualValue, err = ParseCurrencyAmount("1234.00", "JPY")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123400), actualValue)
+
+	actualValue, err = ParseCurrencyAmount("1.234", "KWD")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234), actualValue)
+
+	actualValue, err = ParseCurrencyAmount("-0.5", "KWD")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-500), actualValue)
+}
+
+func TestParseCurrencyAmount_InvalidPrecision(t *testing.T) {
+	_, err := ParseCurrencyAmount("1.234", "CNY")
+	assert.EqualError(t, err, errs.ErrNumberInvalid.Message)
+
+	_, err = ParseCurrencyAmount("1234.5", "JPY")
+	assert.EqualError(t, err, errs.ErrNumberInvalid.Message)
+
+	_, err = ParseCurrencyAmount("1.2345", "KWD")
+	assert.EqualError(t, err, errs.ErrNumberInvalid.Message)
+}
+
+func TestFormatCurrencyAmount(t *testing.T) {
+	assert.Equal(t, "123.45", FormatCurrencyAmount(12345, "CNY"))
+	assert.Equal(t, "0.00", FormatCurrencyAmount(0, "CNY"))
+	assert.Equal(t, "1234", FormatCurrencyAmount(123400, "JPY"))
+	assert.Equal(t, "-1234", FormatCurrencyAmount(-123400, "JPY"))
+	assert.Equal(t, "0", FormatCurrencyAmount(0, "JPY"))
+	assert.Equal(t, "1234.5", FormatCurrencyAmount(123450, "JPY"))
+	assert.Equal(t, "1.234", FormatCurrencyAmount(1234, "KWD"))
+	assert.Equal(t, "-0.500", FormatCurrencyAmount(-500, "KWD"))
+}
+
+func TestFormatAmountWithDecimalPlaces(t *testing.T) {
+	assert.Equal(t, "1.234", FormatAmountWithDecimalPlaces(1234, 3))
+	assert.Equal(t, "-0.001", FormatAmountWithDecimalPlaces(-1, 3))
+	assert.Equal(t, "1234", FormatAmountWithDecimalPlaces(1234, 0))
+}
+
+func TestParseAmountWithDecimalPlaces(t *testing.T) {
+	actualValue, err := ParseAmountWithDecimalPlaces("1.234", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234), actualValue)
+
+	actualValue, err = ParseAmountWithDecimalPlaces("-0.1", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-100), actualValue)
+
+	_, err = ParseAmountWithDecimalPlaces("1.2345", 3)
+	assert.EqualError(t, err, errs.ErrNumberInvalid.Message)
+}
+
+func TestMaximumCurrencyAmountDecimalPlaces(t *testing.T) {
+	for currency := range CurrencyDecimalPlaces {
+		assert.LessOrEqual(t, GetCurrencyAmountDecimalPlaces(currency), MaximumCurrencyAmountDecimalPlaces, currency)
+	}
+}
+
+func TestParseAmountOfUndeterminedCurrency(t *testing.T) {
+	actualValue, err := ParseAmountOfUndeterminedCurrency("123.45")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123450), actualValue)
+
+	actualValue, err = ParseAmountOfUndeterminedCurrency("-1.234")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1234), actualValue)
+
+	actualValue, err = ParseAmountOfUndeterminedCurrency("1.23400")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1234), actualValue)
+
+	_, err = ParseAmountOfUndeterminedCurrency("1.2345")
+	assert.EqualError(t, err, errs.ErrNumberInvalid.Message)
+}
+
+func TestFormatAmountOfUndeterminedCurrency(t *testing.T) {
+	assert.Equal(t, "123.45", FormatAmountOfUndeterminedCurrency(123450))
+	assert.Equal(t, "100.00", FormatAmountOfUndeterminedCurrency(100000))
+	assert.Equal(t, "-1.234", FormatAmountOfUndeterminedCurrency(-1234))
+	assert.Equal(t, "0.00", FormatAmountOfUndeterminedCurrency(0))
+}
+
+func TestExchangeAmountDecimalPlaces(t *testing.T) {
+	assert.Equal(t, float64(12345), ExchangeAmountDecimalPlaces(12345, "USD", "JPY"))
+	assert.Equal(t, float64(123450), ExchangeAmountDecimalPlaces(12345, "USD", "KWD"))
+	assert.Equal(t, float64(1234.5), ExchangeAmountDecimalPlaces(12345, "KWD", "USD"))
+}
+
+func TestRoundCurrencyAmount(t *testing.T) {
+	assert.Equal(t, int64(12346), RoundCurrencyAmount(12345.6, "USD"))
+	assert.Equal(t, int64(12300), RoundCurrencyAmount(12345.6, "JPY"))
+	assert.Equal(t, int64(12400), RoundCurrencyAmount(12351, "JPY"))
+	assert.Equal(t, int64(12346), RoundCurrencyAmount(12345.6, "KWD"))
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"bytes"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,6 +28,81 @@ func GetImageContentType(fileExtension string) string {
 	return contentType
 }
 
+var documentFileExtensionContentTypeMap = map[string]string{
+	"pdf":  "application/pdf",
+	"txt":  "text/plain",
+	"csv":  "text/csv",
+	"doc":  "application/msword",
+	"xls":  "application/vnd.ms-excel",
+	"docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"odt":  "application/vnd.oasis.opendocument.text",
+	"ods":  "application/vnd.oasis.opendocument.spreadsheet",
+}
+
+var fileExtensionSniffedContentTypeMap = map[string]string{
+	"jpg":  "image/jpeg",
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+	"webp": "image/webp",
+	"pdf":  "application/pdf",
+	"txt":  "text/plain",
+	"csv":  "text/plain",
+	"doc":  "application/x-ole-storage",
+	"xls":  "application/x-ole-storage",
+	"docx": "application/zip",
+	"xlsx": "application/zip",
+	"odt":  "application/zip",
+	"ods":  "application/zip",
+}
+
+var oleCompoundFileSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// GetAttachmentContentType returns the content type of specified attachment file extension or returns empty when the file extension is not supported
+func GetAttachmentContentType(fileExtension string) string {
+	contentType := GetImageContentType(fileExtension)
+
+	if contentType != "" {
+		return contentType
+	}
+
+	contentType, exists := documentFileExtensionContentTypeMap[fileExtension]
+
+	if !exists {
+		return ""
+	}
+
+	return contentType
+}
+
+// IsFileContentMatchedExtension returns whether the sniffed content type of the file header matches the specified file extension
+func IsFileContentMatchedExtension(fileExtension string, fileHeader []byte) bool {
+	expectedContentType, exists := fileExtensionSniffedContentTypeMap[fileExtension]
+
+	if !exists || len(fileHeader) < 1 {
+		return false
+	}
+
+	return SniffFileContentType(fileHeader) == expectedContentType
+}
+
+// SniffFileContentType returns the content type (without parameters) detected from the file header
+func SniffFileContentType(fileHeader []byte) string {
+	// legacy microsoft office documents are not recognized by http.DetectContentType
+	if bytes.HasPrefix(fileHeader, oleCompoundFileSignature) {
+		return "application/x-ole-storage"
+	}
+
+	contentType := http.DetectContentType(fileHeader)
+
+	if index := strings.Index(contentType, ";"); index >= 0 {
+		contentType = contentType[:index]
+	}
+
+	return strings.TrimSpace(contentType)
+}
+
 // ListFileNamesWithPrefixAndSuffix returns file name list which has specified prefix and suffix
 func ListFileNamesWithPrefixAndSuffix(path string, prefix string, suffix string) []string {
 	dir, err := os.Open(path)
@@ -18,6 +18,36 @@ func TestGetImageContentType(t *testing.T) {
 	assert.Equal(t, expectedContentType, actualContentType)
 }
 
+func TestGetAttachmentContentType(t *testing.T) {
+	assert.Equal(t, "image/png", GetAttachmentContentType("png"))
+	assert.Equal(t, "application/pdf", GetAttachmentContentType("pdf"))
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", GetAttachmentContentType("xlsx"))
+	assert.Equal(t, "", GetAttachmentContentType("exe"))
+	assert.Equal(t, "", GetAttachmentContentType("html"))
+}
+
+func TestIsFileContentMatchedExtension(t *testing.T) {
+	pngHeader := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	pdfHeader := []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	zipHeader := []byte("PK\x03\x04\x14\x00\x06\x00\x08\x00")
+	oleHeader := []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1, 0x00, 0x00}
+	textHeader := []byte("date,amount,comment\n2024-01-01,1.23,test\n")
+	htmlHeader := []byte("<html><script>alert(1)</script></html>")
+
+	assert.True(t, IsFileContentMatchedExtension("png", pngHeader))
+	assert.True(t, IsFileContentMatchedExtension("pdf", pdfHeader))
+	assert.True(t, IsFileContentMatchedExtension("docx", zipHeader))
+	assert.True(t, IsFileContentMatchedExtension("xls", oleHeader))
+	assert.True(t, IsFileContentMatchedExtension("csv", textHeader))
+
+	assert.False(t, IsFileContentMatchedExtension("jpg", pngHeader))
+	assert.False(t, IsFileContentMatchedExtension("pdf", htmlHeader))
+	assert.False(t, IsFileContentMatchedExtension("txt", htmlHeader))
+	assert.False(t, IsFileContentMatchedExtension("docx", pdfHeader))
+	assert.False(t, IsFileContentMatchedExtension("html", htmlHeader))
+	assert.False(t, IsFileContentMatchedExtension("pdf", []byte{}))
+}
+
 func TestGetFileNameWithoutExtension(t *testing.T) {
 	fileName := "name.ext"
 	expectedName := "name"
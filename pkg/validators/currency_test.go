@@ -5,6 +5,8 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
 )
 
 func TestValidCurrency(t *testing.T) {
@@ -39,3 +41,10 @@ func TestInvalidCurrency(t *testing.T) {
 	err = validate.Var("-", "validCurrency")
 	assert.NotNil(t, err)
 }
+
+func TestCurrencyDecimalPlacesInAllCurrencyNames(t *testing.T) {
+	for currency := range utils.CurrencyDecimalPlaces {
+		_, exists := AllCurrencyNames[currency]
+		assert.True(t, exists, currency)
+	}
+}
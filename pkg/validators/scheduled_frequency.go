@@ -0,0 +1,32 @@
+package validators
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/mayswind/ezbookkeeping/pkg/models"
+	"github.com/mayswind/ezbookkeeping/pkg/utils"
+)
+
+// ValidScheduledFrequency returns whether the given scheduled frequency is comma-separated integers or a supported RRULE string
+func ValidScheduledFrequency(fl validator.FieldLevel) bool {
+	if value, ok := fl.Field().Interface().(string); ok {
+		if value == "" {
+			return true
+		}
+
+		upperValue := strings.ToUpper(strings.TrimSpace(value))
+
+		if strings.HasPrefix(upperValue, "FREQ=") || strings.HasPrefix(upperValue, "RRULE:") {
+			_, err := models.ParseTransactionScheduleRule(value)
+			return err == nil
+		}
+
+		_, err := utils.StringArrayToInt64Array(strings.Split(value, ","))
+
+		return err == nil
+	}
+
+	return false
+}
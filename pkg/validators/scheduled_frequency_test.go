@@ -0,0 +1,56 @@
+package validators
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidScheduledFrequency_EmptyValue(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validScheduledFrequency", ValidScheduledFrequency)
+	assert.Nil(t, err)
+
+	err = validate.Var("", "validScheduledFrequency")
+	assert.Nil(t, err)
+}
+
+func TestValidScheduledFrequency_IntegerValues(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validScheduledFrequency", ValidScheduledFrequency)
+	assert.Nil(t, err)
+
+	err = validate.Var("1", "validScheduledFrequency")
+	assert.Nil(t, err)
+
+	err = validate.Var("1,15,-1", "validScheduledFrequency")
+	assert.Nil(t, err)
+
+	err = validate.Var("1,a", "validScheduledFrequency")
+	assert.NotNil(t, err)
+
+	err = validate.Var("1,,2", "validScheduledFrequency")
+	assert.NotNil(t, err)
+}
+
+func TestValidScheduledFrequency_RuleValues(t *testing.T) {
+	validate := validator.New()
+	err := validate.RegisterValidation("validScheduledFrequency", ValidScheduledFrequency)
+	assert.Nil(t, err)
+
+	err = validate.Var("FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1", "validScheduledFrequency")
+	assert.Nil(t, err)
+
+	err = validate.Var("RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "validScheduledFrequency")
+	assert.Nil(t, err)
+
+	err = validate.Var("freq=yearly;bymonth=12;bymonthday=25", "validScheduledFrequency")
+	assert.Nil(t, err)
+
+	err = validate.Var("FREQ=HOURLY", "validScheduledFrequency")
+	assert.NotNil(t, err)
+
+	err = validate.Var("FREQ=WEEKLY;BYDAY=XX", "validScheduledFrequency")
+	assert.NotNil(t, err)
+}
@@ -22,6 +22,17 @@ export interface LatestExchangeRateResponse {
     updateTime: number;
     readonly baseCurrency: string;
     readonly exchangeRates: LatestExchangeRate[];
+    readonly mergedDataSources?: string[];
+    readonly divergentExchangeRates?: LatestExchangeRateDivergence[];
+    readonly fetchTime?: number;
+    readonly isStale?: boolean;
+}
+
+export interface LatestExchangeRateDivergence {
+    readonly currency: string;
+    readonly rate: string;
+    readonly referenceDataSource: string;
+    readonly referenceRate: string;
 }
 
 export interface LocalizedLatestExchangeRate {